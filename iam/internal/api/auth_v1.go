@@ -2,32 +2,285 @@ package api
 
 import (
 	"context"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	authv1 "github.com/linemk/rocket-shop/shared/pkg/proto/auth/v1"
 
+	"github.com/linemk/rocket-shop/iam/internal/model"
+	"github.com/linemk/rocket-shop/iam/internal/service/api_key"
+	"github.com/linemk/rocket-shop/iam/internal/service/audit"
 	"github.com/linemk/rocket-shop/iam/internal/service/auth"
 	"github.com/linemk/rocket-shop/iam/internal/service/converter"
+	"github.com/linemk/rocket-shop/iam/internal/service/mfa"
+	"github.com/linemk/rocket-shop/iam/internal/service/oidc"
+)
+
+const (
+	// OIDCBindingCookieName cookie, связывающая OIDC вход с браузером, в котором он начат
+	OIDCBindingCookieName = "oidc_binding"
+	// HeaderSetCookie заголовок ответа для установки cookie
+	HeaderSetCookie = "set-cookie"
+
+	oidcCookiePath = "/auth/oidc/"
 )
 
 type authV1Handler struct {
-	authService auth.Service
+	authService   auth.Service
+	mfaService    mfa.Service
+	apiKeyService api_key.Service
+	auditService  audit.Service
+	oidcService   oidc.Service
 	authv1.UnimplementedAuthServiceServer
 }
 
-func NewAuthV1Handler(authService auth.Service) authv1.AuthServiceServer {
+func NewAuthV1Handler(
+	authService auth.Service,
+	mfaService mfa.Service,
+	apiKeyService api_key.Service,
+	auditService audit.Service,
+	oidcService oidc.Service,
+) authv1.AuthServiceServer {
 	return &authV1Handler{
-		authService: authService,
+		authService:   authService,
+		mfaService:    mfaService,
+		apiKeyService: apiKeyService,
+		auditService:  auditService,
+		oidcService:   oidcService,
 	}
 }
 
 func (h *authV1Handler) Login(ctx context.Context, req *authv1.LoginRequest) (*authv1.LoginResponse, error) {
-	session, err := h.authService.Login(ctx, req.Login, req.Password)
+	result, err := h.authService.Login(ctx, req.Login, req.Password)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+
+	return loginResultToProto(result), nil
+}
+
+func (h *authV1Handler) StartOIDCLogin(ctx context.Context, req *authv1.StartOIDCLoginRequest) (*authv1.StartOIDCLoginResponse, error) {
+	start, err := h.oidcService.Start(ctx, req.Provider)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+
+	// Envoy передает gRPC заголовок ответа браузеру как Set-Cookie
+	err = grpc.SetHeader(ctx, metadata.Pairs(HeaderSetCookie, oidcBindingCookie(start.Binding, time.Until(start.ExpiresAt)).String()))
+	if err != nil {
+		return nil, h.handleError(errors.Wrap(err, "failed to set oidc binding cookie"))
+	}
+
+	return &authv1.StartOIDCLoginResponse{
+		AuthorizationUrl: start.AuthorizationURL,
+	}, nil
+}
+
+func (h *authV1Handler) CompleteOIDCLogin(ctx context.Context, req *authv1.CompleteOIDCLoginRequest) (*authv1.LoginResponse, error) {
+	binding := oidcBindingFromContext(ctx)
+
+	// Cookie одноразовая, как и state
+	_ = grpc.SetHeader(ctx, metadata.Pairs(HeaderSetCookie, oidcBindingCookie("", -1).String()))
+
+	result, err := h.oidcService.Complete(ctx, req.Provider, req.State, req.Code, binding)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+
+	return loginResultToProto(result), nil
+}
+
+// oidcBindingCookie формирует cookie, связывающую OIDC state с браузером; maxAge < 0 удаляет cookie
+func oidcBindingCookie(value string, maxAge time.Duration) *http.Cookie {
+	cookie := &http.Cookie{
+		Name:     OIDCBindingCookieName,
+		Value:    value,
+		Path:     oidcCookiePath,
+		HttpOnly: true,
+		Secure:   true,
+		// Lax: cookie отправляется при переходе браузера с провайдера на callback
+		SameSite: http.SameSiteLaxMode,
+		MaxAge:   int(maxAge.Seconds()),
+	}
+	if maxAge < 0 {
+		cookie.MaxAge = -1
+	}
+
+	return cookie
+}
+
+// oidcBindingFromContext извлекает значение cookie привязки из входящих gRPC metadata
+func oidcBindingFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	req := &http.Request{Header: make(http.Header)}
+	for _, cookieHeader := range md.Get(HeaderCookie) {
+		req.Header.Add(HeaderCookie, cookieHeader)
+	}
+
+	cookie, err := req.Cookie(OIDCBindingCookieName)
+	if err != nil {
+		return ""
+	}
+
+	return cookie.Value
+}
+
+// loginResultToProto конвертирует результат входа: сессию или MFA челлендж
+func loginResultToProto(result *model.LoginResult) *authv1.LoginResponse {
+	if result.MFAChallenge != nil {
+		return &authv1.LoginResponse{
+			MfaRequired:      true,
+			MfaChallengeUuid: result.MFAChallenge.ChallengeUUID,
+		}
+	}
+
+	resp := &authv1.LoginResponse{
+		SessionUuid: result.Session.SessionUUID,
+	}
+	if result.AccessToken != nil {
+		resp.AccessToken = result.AccessToken.Token
+		resp.AccessTokenExpiresAt = timestamppb.New(result.AccessToken.ExpiresAt)
+	}
+
+	return resp
+}
+
+func (h *authV1Handler) VerifyMFA(ctx context.Context, req *authv1.VerifyMFARequest) (*authv1.VerifyMFAResponse, error) {
+	result, err := h.authService.VerifyMFA(ctx, req.MfaChallengeUuid, req.Code)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+
+	resp := &authv1.VerifyMFAResponse{
+		SessionUuid: result.Session.SessionUUID,
+	}
+	if result.AccessToken != nil {
+		resp.AccessToken = result.AccessToken.Token
+		resp.AccessTokenExpiresAt = timestamppb.New(result.AccessToken.ExpiresAt)
+	}
+
+	return resp, nil
+}
+
+func (h *authV1Handler) RefreshAccessToken(ctx context.Context, req *authv1.RefreshAccessTokenRequest) (*authv1.RefreshAccessTokenResponse, error) {
+	accessToken, err := h.authService.RefreshAccessToken(ctx, req.SessionUuid)
 	if err != nil {
 		return nil, h.handleError(err)
 	}
 
-	return &authv1.LoginResponse{
-		SessionUuid: session.SessionUUID,
+	return &authv1.RefreshAccessTokenResponse{
+		AccessToken:          accessToken.Token,
+		AccessTokenExpiresAt: timestamppb.New(accessToken.ExpiresAt),
+	}, nil
+}
+
+func (h *authV1Handler) EnrollTOTP(ctx context.Context, req *authv1.EnrollTOTPRequest) (*authv1.EnrollTOTPResponse, error) {
+	enrollment, err := h.mfaService.Enroll(ctx, req.UserUuid)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+
+	return &authv1.EnrollTOTPResponse{
+		Secret:     enrollment.Secret,
+		OtpauthUri: enrollment.URI,
+	}, nil
+}
+
+func (h *authV1Handler) ConfirmTOTP(ctx context.Context, req *authv1.ConfirmTOTPRequest) (*authv1.ConfirmTOTPResponse, error) {
+	recoveryCodes, err := h.mfaService.Confirm(ctx, req.UserUuid, req.Code)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+
+	return &authv1.ConfirmTOTPResponse{
+		RecoveryCodes: recoveryCodes,
+	}, nil
+}
+
+func (h *authV1Handler) DisableTOTP(ctx context.Context, req *authv1.DisableTOTPRequest) (*authv1.DisableTOTPResponse, error) {
+	err := h.mfaService.Disable(ctx, req.UserUuid, req.Code)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+
+	return &authv1.DisableTOTPResponse{}, nil
+}
+
+func (h *authV1Handler) RegenerateRecoveryCodes(ctx context.Context, req *authv1.RegenerateRecoveryCodesRequest) (*authv1.RegenerateRecoveryCodesResponse, error) {
+	recoveryCodes, err := h.mfaService.RegenerateRecoveryCodes(ctx, req.UserUuid, req.Code)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+
+	return &authv1.RegenerateRecoveryCodesResponse{
+		RecoveryCodes: recoveryCodes,
+	}, nil
+}
+
+func (h *authV1Handler) CreateAPIKey(ctx context.Context, req *authv1.CreateAPIKeyRequest) (*authv1.CreateAPIKeyResponse, error) {
+	var expiresAt *time.Time
+	if req.ExpiresAt != nil {
+		t := req.ExpiresAt.AsTime()
+		expiresAt = &t
+	}
+
+	created, err := h.apiKeyService.Create(ctx, req.UserUuid, req.Name, req.Scopes, expiresAt)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+
+	return &authv1.CreateAPIKeyResponse{
+		ApiKey: converter.APIKeyToProto(created.APIKey),
+		Key:    created.Key,
+	}, nil
+}
+
+func (h *authV1Handler) ListAPIKeys(ctx context.Context, req *authv1.ListAPIKeysRequest) (*authv1.ListAPIKeysResponse, error) {
+	keys, err := h.apiKeyService.List(ctx, req.UserUuid)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+
+	return &authv1.ListAPIKeysResponse{
+		ApiKeys: converter.APIKeysToProto(keys),
+	}, nil
+}
+
+func (h *authV1Handler) RevokeAPIKey(ctx context.Context, req *authv1.RevokeAPIKeyRequest) (*authv1.RevokeAPIKeyResponse, error) {
+	err := h.apiKeyService.Revoke(ctx, req.UserUuid, req.ApiKeyUuid)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+
+	return &authv1.RevokeAPIKeyResponse{}, nil
+}
+
+func (h *authV1Handler) ListAuditEvents(ctx context.Context, req *authv1.ListAuditEventsRequest) (*authv1.ListAuditEventsResponse, error) {
+	events, nextPageToken, err := h.auditService.List(
+		ctx,
+		req.UserUuid,
+		converter.AuditEventTypesFromProto(req.EventTypes),
+		int(req.PageSize),
+		req.PageToken,
+	)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+
+	return &authv1.ListAuditEventsResponse{
+		Events:        converter.AuditEventsToProto(events),
+		NextPageToken: nextPageToken,
 	}, nil
 }
 
@@ -43,6 +296,11 @@ func (h *authV1Handler) Whoami(ctx context.Context, req *authv1.WhoamiRequest) (
 }
 
 func (h *authV1Handler) handleError(err error) error {
+	// Сервисы, проверяющие сессии через Whoami, отличают невалидную сессию от недоступности IAM по коду
+	if errors.Is(err, model.ErrSessionNotFound) || errors.Is(err, model.ErrSessionExpired) {
+		return status.Error(codes.Unauthenticated, err.Error())
+	}
+
 	// TODO: Map domain errors to gRPC status codes
 	return err
 }
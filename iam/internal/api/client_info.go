@@ -0,0 +1,43 @@
+package api
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"github.com/linemk/rocket-shop/iam/internal/model"
+	"github.com/linemk/rocket-shop/iam/internal/service/audit"
+)
+
+// UnaryClientInfoInterceptor добавляет в контекст IP адрес и User-Agent клиента для событий аудита.
+// За Envoy исходный адрес клиента передается в X-Forwarded-For
+func UnaryClientInfoInterceptor(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	var client model.ClientInfo
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(HeaderUserAgent); len(values) > 0 {
+			client.UserAgent = values[0]
+		}
+
+		if values := md.Get(HeaderForwardedFor); len(values) > 0 {
+			first, _, _ := strings.Cut(values[0], ",")
+			client.IPAddress = strings.TrimSpace(first)
+		}
+	}
+
+	if client.IPAddress == "" {
+		if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+			host, _, err := net.SplitHostPort(p.Addr.String())
+			if err != nil {
+				host = p.Addr.String()
+			}
+			client.IPAddress = host
+		}
+	}
+
+	return handler(audit.ContextWithClientInfo(ctx, client), req)
+}
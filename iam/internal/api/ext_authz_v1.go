@@ -3,17 +3,23 @@ package api
 import (
 	"context"
 	"fmt"
-	"log"
 	"net/http"
 	"net/url"
+	"strings"
 
 	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	authv3 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
 	typev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"go.uber.org/zap"
 	statusv3 "google.golang.org/genproto/googleapis/rpc/status"
 	"google.golang.org/grpc/codes"
 
-	"github.com/linemk/rocket-shop/iam/internal/service/auth"
+	"github.com/linemk/rocket-shop/iam/internal/model"
+	"github.com/linemk/rocket-shop/iam/internal/service/api_key"
+	"github.com/linemk/rocket-shop/iam/internal/service/audit"
+	"github.com/linemk/rocket-shop/iam/internal/service/whoami_cache"
+	"github.com/linemk/rocket-shop/platform/pkg/logger"
+	grpcmiddleware "github.com/linemk/rocket-shop/platform/pkg/middleware/grpc"
 )
 
 const (
@@ -22,71 +28,198 @@ const (
 
 	HeaderUserUUID    = "X-User-Uuid"
 	HeaderUserLogin   = "X-User-Login"
+	HeaderAPIKeyScope = "X-Api-Key-Scopes"
 	HeaderContentType = "content-type"
 	HeaderAuthStatus  = "X-Auth-Status"
 
 	HeaderCookie        = "cookie"
 	HeaderAuthorization = "authorization"
+	HeaderUserAgent     = "user-agent"
+	HeaderForwardedFor  = "x-forwarded-for"
 
 	ContentTypeJSON = "application/json"
 
+	// AuthorizationAPIKeyScheme схема заголовка Authorization для API ключей
+	AuthorizationAPIKeyScheme = "ApiKey"
+
+	inventoryPathPrefix = "/api/v1/inventory"
+	ordersPathPrefix    = "/api/v1/orders"
+
 	AuthStatusDenied = "denied"
 )
 
 type extAuthzV1Handler struct {
-	authService auth.Service
+	whoamiCache   whoami_cache.Service
+	apiKeyService api_key.Service
+	auditService  audit.Service
 	authv3.UnimplementedAuthorizationServer
 }
 
-func NewExtAuthzV1Handler(authService auth.Service) authv3.AuthorizationServer {
+func NewExtAuthzV1Handler(whoamiCache whoami_cache.Service, apiKeyService api_key.Service, auditService audit.Service) authv3.AuthorizationServer {
 	return &extAuthzV1Handler{
-		authService: authService,
+		whoamiCache:   whoamiCache,
+		apiKeyService: apiKeyService,
+		auditService:  auditService,
 	}
 }
 
 func (h *extAuthzV1Handler) Check(ctx context.Context, req *authv3.CheckRequest) (*authv3.CheckResponse, error) {
-	log.Printf("External Authorization Check called")
+	ctx = audit.ContextWithClientInfo(ctx, clientInfoFromCheckRequest(req))
+	resource := requestResource(req)
+
+	logger.Debug(ctx, "External Authorization Check called", zap.String("resource", resource))
+
+	sessionUUID, apiKey, err := h.extractSessionUUID(ctx, req)
+	if err != nil {
+		return h.deny(ctx, &model.AuditEvent{Reason: err.Error(), Resource: resource}, "Missing or invalid session"), nil
+	}
+
+	if apiKey != "" {
+		return h.checkAPIKey(ctx, req, apiKey, resource), nil
+	}
+
+	user, err := h.whoamiCache.Whoami(ctx, sessionUUID)
+	if err != nil {
+		return h.deny(ctx, &model.AuditEvent{
+			SessionUUID: sessionUUID,
+			Reason:      err.Error(),
+			Resource:    resource,
+		}, "Invalid session"), nil
+	}
+
+	return h.allowRequest(user.UserUUID, user.Login, sessionUUID, ""), nil
+}
 
-	sessionUUID, err := h.extractSessionUUID(req)
+// checkAPIKey авторизует запрос по API ключу: ключ должен быть активен и иметь область доступа для запрошенного API
+func (h *extAuthzV1Handler) checkAPIKey(ctx context.Context, req *authv3.CheckRequest, apiKey, resource string) *authv3.CheckResponse {
+	user, key, err := h.apiKeyService.Authenticate(ctx, apiKey)
 	if err != nil {
-		log.Printf("Session extraction failed: %v", err)
-		return h.denyRequest("Missing or invalid session", 403), nil
+		return h.deny(ctx, &model.AuditEvent{Reason: err.Error(), Resource: resource}, "Invalid api key")
 	}
 
-	log.Printf("Extracted session_uuid: %s", sessionUUID)
+	httpReq := req.Attributes.Request.Http
+	scope := requiredAPIKeyScope(httpReq.Path, httpReq.Method)
+	if scope == "" || !key.HasScope(scope) {
+		return h.deny(ctx, &model.AuditEvent{
+			UserUUID: user.UserUUID,
+			Login:    user.Login,
+			Reason:   fmt.Sprintf("api key %s has no scope %q", key.APIKeyUUID, scope),
+			Resource: resource,
+		}, "Insufficient api key scope")
+	}
 
-	user, err := h.authService.Whoami(ctx, sessionUUID)
+	// Сервисы за Envoy проверяют сессию через IAM Whoami, поэтому запрос по ключу передается от имени сессии ключа
+	sessionUUID, err := h.apiKeyService.Session(ctx, key)
 	if err != nil {
-		log.Printf("Whoami failed: %v", err)
-		return h.denyRequest("Invalid session", 403), nil
+		logger.Error(ctx, "Failed to issue api key session", zap.String("api_key_uuid", key.APIKeyUUID), zap.Error(err))
+		return h.denyRequest("Failed to authorize api key", 503)
 	}
 
-	return h.allowRequest(user.UserUUID, user.Login), nil
+	return h.allowRequest(user.UserUUID, user.Login, sessionUUID, key.ScopesString())
 }
 
-func (h *extAuthzV1Handler) extractSessionUUID(req *authv3.CheckRequest) (string, error) {
-	if req.Attributes == nil || req.Attributes.Request == nil {
-		return "", fmt.Errorf("no HTTP request found")
+// deny фиксирует отказ в доступе в аудите и формирует ответ для Envoy
+func (h *extAuthzV1Handler) deny(ctx context.Context, event *model.AuditEvent, message string) *authv3.CheckResponse {
+	event.EventType = model.AuditEventAuthzDenied
+
+	logger.Info(ctx, "Request denied",
+		zap.String("reason", event.Reason),
+		zap.String("resource", event.Resource),
+		zap.String("user_uuid", event.UserUUID),
+	)
+
+	h.auditService.Record(ctx, event)
+
+	return h.denyRequest(message, 403)
+}
+
+// extractSessionUUID извлекает session UUID из заголовка или cookie.
+// Если запрос авторизован заголовком "Authorization: ApiKey <key>", вместо session UUID возвращается API ключ
+func (h *extAuthzV1Handler) extractSessionUUID(ctx context.Context, req *authv3.CheckRequest) (sessionUUID, apiKey string, err error) {
+	if req.Attributes == nil || req.Attributes.Request == nil || req.Attributes.Request.Http == nil {
+		return "", "", fmt.Errorf("no HTTP request found")
 	}
 
 	headers := req.Attributes.Request.Http.Headers
 
-	// Попытка 1: Извлечь из X-Session-UUID header
+	// Попытка 1: Извлечь API ключ из Authorization header
+	if authorization, ok := headers[HeaderAuthorization]; ok && authorization != "" {
+		scheme, value, found := strings.Cut(authorization, " ")
+		if found && strings.EqualFold(scheme, AuthorizationAPIKeyScheme) && strings.TrimSpace(value) != "" {
+			logger.Debug(ctx, "API key found in authorization header")
+			return "", strings.TrimSpace(value), nil
+		}
+	}
+
+	// Попытка 2: Извлечь из X-Session-UUID header
 	if sessionUUID, ok := headers[SessionHeaderName]; ok && sessionUUID != "" {
-		log.Printf("Session UUID found in header: %s", sessionUUID)
-		return sessionUUID, nil
+		logger.Debug(ctx, "Session UUID found in header", zap.String("session_uuid", sessionUUID))
+		return sessionUUID, "", nil
 	}
 
-	// Попытка 2: Извлечь из Cookie
+	// Попытка 3: Извлечь из Cookie
 	if cookieHeader, ok := headers[HeaderCookie]; ok && cookieHeader != "" {
 		sessionUUID := h.extractSessionFromCookies(cookieHeader)
 		if sessionUUID != "" {
-			log.Printf("Session UUID found in cookie: %s", sessionUUID)
-			return sessionUUID, nil
+			logger.Debug(ctx, "Session UUID found in cookie", zap.String("session_uuid", sessionUUID))
+			return sessionUUID, "", nil
 		}
 	}
 
-	return "", fmt.Errorf("session uuid not found in headers or cookies")
+	return "", "", fmt.Errorf("session uuid not found in headers or cookies")
+}
+
+// clientInfoFromCheckRequest извлекает IP адрес и User-Agent клиента из запроса Envoy
+func clientInfoFromCheckRequest(req *authv3.CheckRequest) model.ClientInfo {
+	var client model.ClientInfo
+
+	attrs := req.GetAttributes()
+	headers := attrs.GetRequest().GetHttp().GetHeaders()
+
+	client.UserAgent = headers[HeaderUserAgent]
+
+	// Первый адрес в X-Forwarded-For - исходный клиент
+	if forwardedFor := headers[HeaderForwardedFor]; forwardedFor != "" {
+		first, _, _ := strings.Cut(forwardedFor, ",")
+		client.IPAddress = strings.TrimSpace(first)
+	}
+
+	if client.IPAddress == "" {
+		client.IPAddress = attrs.GetSource().GetAddress().GetSocketAddress().GetAddress()
+	}
+
+	return client
+}
+
+// requestResource возвращает метод и путь запроса для аудита
+func requestResource(req *authv3.CheckRequest) string {
+	httpReq := req.GetAttributes().GetRequest().GetHttp()
+	if httpReq == nil {
+		return ""
+	}
+
+	return httpReq.GetMethod() + " " + httpReq.GetPath()
+}
+
+// requiredAPIKeyScope возвращает область доступа, необходимую для запроса.
+// Пустая строка означает, что API недоступен по API ключу
+func requiredAPIKeyScope(path, method string) string {
+	readOnly := method == http.MethodGet || method == http.MethodHead
+
+	switch {
+	case strings.HasPrefix(path, inventoryPathPrefix):
+		if readOnly {
+			return model.APIKeyScopeInventoryRead
+		}
+		return model.APIKeyScopeInventoryWrite
+	case strings.HasPrefix(path, ordersPathPrefix):
+		if readOnly {
+			return model.APIKeyScopeOrdersRead
+		}
+		return model.APIKeyScopeOrdersWrite
+	default:
+		return ""
+	}
 }
 
 func (h *extAuthzV1Handler) extractSessionFromCookies(cookieHeader string) string {
@@ -106,20 +239,24 @@ func (h *extAuthzV1Handler) extractSessionFromCookies(cookieHeader string) strin
 	return ""
 }
 
-func (h *extAuthzV1Handler) allowRequest(userUUID, userLogin string) *authv3.CheckResponse {
+// allowRequest пропускает запрос, передавая сервисам проверенную идентичность.
+// Заголовки ответа перезаписывают одноименные заголовки клиента: session UUID передается в виде,
+// который ожидают сервисы (gRPC metadata session-uuid для Inventory и X-Session-Uuid для Order),
+// а X-Api-Key-Scopes удаляется у запросов без API ключа, чтобы клиент не мог подставить свои области доступа
+func (h *extAuthzV1Handler) allowRequest(userUUID, userLogin, sessionUUID, apiKeyScopes string) *authv3.CheckResponse {
 	headers := []*corev3.HeaderValueOption{
-		{
-			Header: &corev3.HeaderValue{
-				Key:   HeaderUserUUID,
-				Value: userUUID,
-			},
-		},
-		{
-			Header: &corev3.HeaderValue{
-				Key:   HeaderUserLogin,
-				Value: userLogin,
-			},
-		},
+		overwriteHeader(HeaderUserUUID, userUUID),
+		overwriteHeader(HeaderUserLogin, userLogin),
+		overwriteHeader(SessionHeaderName, sessionUUID),
+		overwriteHeader(grpcmiddleware.SessionUUIDHeader, sessionUUID),
+	}
+
+	headersToRemove := []string{HeaderCookie, HeaderAuthorization}
+
+	if apiKeyScopes != "" {
+		headers = append(headers, overwriteHeader(HeaderAPIKeyScope, apiKeyScopes))
+	} else {
+		headersToRemove = append(headersToRemove, strings.ToLower(HeaderAPIKeyScope))
 	}
 
 	return &authv3.CheckResponse{
@@ -127,12 +264,23 @@ func (h *extAuthzV1Handler) allowRequest(userUUID, userLogin string) *authv3.Che
 		HttpResponse: &authv3.CheckResponse_OkResponse{
 			OkResponse: &authv3.OkHttpResponse{
 				Headers:         headers,
-				HeadersToRemove: []string{HeaderCookie, HeaderAuthorization},
+				HeadersToRemove: headersToRemove,
 			},
 		},
 	}
 }
 
+// overwriteHeader заменяет заголовок клиента с тем же именем, если он был передан
+func overwriteHeader(key, value string) *corev3.HeaderValueOption {
+	return &corev3.HeaderValueOption{
+		Header: &corev3.HeaderValue{
+			Key:   key,
+			Value: value,
+		},
+		AppendAction: corev3.HeaderValueOption_OVERWRITE_IF_EXISTS_OR_ADD,
+	}
+}
+
 func (h *extAuthzV1Handler) denyRequest(message string, statusCode int32) *authv3.CheckResponse {
 	return &authv3.CheckResponse{
 		Status: &statusv3.Status{Code: int32(codes.Unauthenticated)},
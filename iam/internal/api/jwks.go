@@ -0,0 +1,47 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/linemk/rocket-shop/iam/internal/service/access_token"
+	"github.com/linemk/rocket-shop/platform/pkg/logger"
+)
+
+// JWKSPath путь, по которому публикуются ключи проверки access токенов
+const JWKSPath = "/.well-known/jwks.json"
+
+type jwksHandler struct {
+	accessTokenService access_token.Service
+}
+
+// NewJWKSHandler создает HTTP обработчик, отдающий публичные ключи в формате JWKS
+func NewJWKSHandler(accessTokenService access_token.Service) http.Handler {
+	return &jwksHandler{
+		accessTokenService: accessTokenService,
+	}
+}
+
+func (h *jwksHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	jwks, err := h.accessTokenService.JWKS(r.Context())
+	if err != nil {
+		logger.Error(r.Context(), "Failed to get JWKS", zap.Error(err))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", ContentTypeJSON)
+	// Верификаторы перезапрашивают JWKS при встрече неизвестного kid, поэтому кеширование не мешает ротации
+	w.Header().Set("Cache-Control", "public, max-age=300")
+
+	if err := json.NewEncoder(w).Encode(jwks); err != nil {
+		logger.Error(r.Context(), "Failed to encode JWKS", zap.Error(err))
+	}
+}
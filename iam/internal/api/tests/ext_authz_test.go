@@ -0,0 +1,355 @@
+package tests
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+
+	authv3 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/linemk/rocket-shop/iam/internal/api"
+	"github.com/linemk/rocket-shop/iam/internal/mocks"
+	"github.com/linemk/rocket-shop/iam/internal/model"
+	"github.com/linemk/rocket-shop/iam/internal/service/api_key"
+	"github.com/linemk/rocket-shop/iam/internal/service/auth"
+	"github.com/linemk/rocket-shop/platform/pkg/logger"
+	grpcmiddleware "github.com/linemk/rocket-shop/platform/pkg/middleware/grpc"
+	"github.com/linemk/rocket-shop/shared/pkg/iamclient"
+	authv1 "github.com/linemk/rocket-shop/shared/pkg/proto/auth/v1"
+	inventory_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/inventory/v1"
+)
+
+// inventoryStub отвечает логином пользователя, которого session interceptor положил в контекст
+type inventoryStub struct {
+	inventory_v1.UnimplementedInventoryServiceServer
+}
+
+func (s *inventoryStub) ListParts(ctx context.Context, _ *inventory_v1.ListPartsRequest) (*inventory_v1.ListPartsResponse, error) {
+	user, ok := grpcmiddleware.UserFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Internal, "user not found in context")
+	}
+
+	return &inventory_v1.ListPartsResponse{
+		Parts: []*inventory_v1.Part{{Uuid: user.UUID, Name: user.Login}},
+	}, nil
+}
+
+// memorySessions хранит сессии в памяти вместо Redis
+type memorySessions struct {
+	mu            sync.Mutex
+	sessions      map[string]*model.Session
+	apiKeySession map[string]string
+}
+
+func newMemorySessions() *memorySessions {
+	return &memorySessions{
+		sessions:      make(map[string]*model.Session),
+		apiKeySession: make(map[string]string),
+	}
+}
+
+func (m *memorySessions) sessionRepo(ctrl *gomock.Controller) *mocks.MockSessionRepository {
+	repo := mocks.NewMockSessionRepository(ctrl)
+
+	repo.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, session *model.Session, _ interface{}) error {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			m.sessions[session.SessionUUID] = session
+			return nil
+		}).AnyTimes()
+	repo.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, sessionUUID string) (*model.Session, error) {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			session, ok := m.sessions[sessionUUID]
+			if !ok {
+				return nil, model.ErrSessionNotFound
+			}
+			return session, nil
+		}).AnyTimes()
+	repo.EXPECT().AddSessionToUserSet(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	return repo
+}
+
+func (m *memorySessions) apiKeySessionRepo(ctrl *gomock.Controller) *mocks.MockAPIKeySessionRepository {
+	repo := mocks.NewMockAPIKeySessionRepository(ctrl)
+
+	repo.EXPECT().Get(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, apiKeyUUID string) (string, error) {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			sessionUUID, ok := m.apiKeySession[apiKeyUUID]
+			if !ok {
+				return "", model.ErrSessionNotFound
+			}
+			return sessionUUID, nil
+		}).AnyTimes()
+	repo.EXPECT().Claim(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, apiKeyUUID, sessionUUID string, _ interface{}) (bool, error) {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			if _, ok := m.apiKeySession[apiKeyUUID]; ok {
+				return false, nil
+			}
+			m.apiKeySession[apiKeyUUID] = sessionUUID
+			return true, nil
+		}).AnyTimes()
+
+	return repo
+}
+
+func (m *memorySessions) count() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.sessions)
+}
+
+// forwardedMetadata повторяет обработку ответа ext_authz в Envoy: удаляет и перезаписывает заголовки
+// клиента, после чего gRPC-JSON transcoder передает их Inventory как gRPC metadata
+func forwardedMetadata(clientHeaders map[string]string, resp *authv3.CheckResponse) metadata.MD {
+	md := metadata.MD{}
+	for key, value := range clientHeaders {
+		md.Set(key, value)
+	}
+
+	ok := resp.GetOkResponse()
+	for _, key := range ok.GetHeadersToRemove() {
+		md.Delete(key)
+	}
+	for _, header := range ok.GetHeaders() {
+		md.Set(header.GetHeader().GetKey(), header.GetHeader().GetValue())
+	}
+
+	return md
+}
+
+func checkRequest(path, method string, headers map[string]string) *authv3.CheckRequest {
+	return &authv3.CheckRequest{
+		Attributes: &authv3.AttributeContext{
+			Request: &authv3.AttributeContext_Request{
+				Http: &authv3.AttributeContext_HttpRequest{
+					Path:    path,
+					Method:  method,
+					Headers: headers,
+				},
+			},
+		},
+	}
+}
+
+func serve(t *testing.T, srv *grpc.Server) *grpc.ClientConn {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return conn
+}
+
+// TestAPIKeyRequestReachesInventory проходит путь запроса по API ключу:
+// ext_authz в IAM -> заголовки Envoy -> session interceptor Inventory -> IAM Whoami
+func TestAPIKeyRequestReachesInventory(t *testing.T) {
+	logger.SetNopLogger()
+
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	user := &model.User{UserUUID: uuid.NewString(), Login: "robot", Roles: []string{model.RoleUser}}
+	key := &model.APIKey{
+		APIKeyUUID: uuid.NewString(),
+		UserUUID:   user.UserUUID,
+		Scopes:     []string{model.APIKeyScopeInventoryRead},
+	}
+
+	store := newMemorySessions()
+	sessionRepo := store.sessionRepo(ctrl)
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	userRepo.EXPECT().GetByID(gomock.Any(), user.UserUUID).Return(user, nil).AnyTimes()
+
+	apiKeyRepo := mocks.NewMockAPIKeyRepository(ctrl)
+	apiKeyRepo.EXPECT().GetByHash(gomock.Any(), gomock.Any()).Return(key, nil).AnyTimes()
+
+	auditSvc := mocks.NewMockAuditService(ctrl)
+	auditSvc.EXPECT().Record(gomock.Any(), gomock.Any()).AnyTimes()
+
+	whoamiCache := mocks.NewMockWhoamiCacheService(ctrl)
+	apiKeySvc := api_key.NewService(apiKeyRepo, store.apiKeySessionRepo(ctrl), sessionRepo, userRepo, whoamiCache)
+	authSvc := auth.NewService(userRepo, sessionRepo, nil, nil, nil, nil, auditSvc, nil, nil, nil, nil)
+	extAuthz := api.NewExtAuthzV1Handler(whoamiCache, apiKeySvc, auditSvc)
+
+	iamServer := grpc.NewServer()
+	authv1.RegisterAuthServiceServer(iamServer, api.NewAuthV1Handler(authSvc, nil, apiKeySvc, auditSvc, nil))
+	iamConn := serve(t, iamServer)
+
+	resolver := iamclient.NewSessionResolver(authv1.NewAuthServiceClient(iamConn), 0, 0)
+	inventoryServer := grpc.NewServer(grpc.UnaryInterceptor(grpcmiddleware.UnarySessionInterceptor(resolver)))
+	inventory_v1.RegisterInventoryServiceServer(inventoryServer, &inventoryStub{})
+	inventoryClient := inventory_v1.NewInventoryServiceClient(serve(t, inventoryServer))
+
+	clientHeaders := map[string]string{
+		api.HeaderAuthorization: "ApiKey rsk_test",
+		// Клиент пытается подставить чужую сессию — ext_authz должен ее перезаписать
+		grpcmiddleware.SessionUUIDHeader: "forged-session",
+	}
+
+	for i := 0; i < 2; i++ {
+		resp, err := extAuthz.Check(ctx, checkRequest("/api/v1/inventory/parts", "GET", clientHeaders))
+		require.NoError(t, err)
+		require.NotNil(t, resp.GetOkResponse(), "api key request must be allowed")
+
+		md := forwardedMetadata(clientHeaders, resp)
+		require.Empty(t, md.Get(api.HeaderAuthorization), "api key must not reach the service")
+		require.Equal(t, []string{model.APIKeyScopeInventoryRead}, md.Get(api.HeaderAPIKeyScope))
+
+		parts, err := inventoryClient.ListParts(metadata.NewOutgoingContext(ctx, md), &inventory_v1.ListPartsRequest{})
+		require.NoError(t, err)
+		require.Len(t, parts.GetParts(), 1)
+		require.Equal(t, user.UserUUID, parts.GetParts()[0].GetUuid())
+		require.Equal(t, user.Login, parts.GetParts()[0].GetName())
+	}
+
+	// Сессия ключа переиспользуется между запросами
+	require.Equal(t, 1, store.count())
+
+	// Без ext_authz заголовок с API ключом сервис не принимает
+	_, err := inventoryClient.ListParts(
+		metadata.NewOutgoingContext(ctx, metadata.New(clientHeaders)),
+		&inventory_v1.ListPartsRequest{},
+	)
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestExtAuthzCheck_Headers(t *testing.T) {
+	logger.SetNopLogger()
+
+	ctx := context.Background()
+	user := &model.User{UserUUID: uuid.NewString(), Login: "alice"}
+	sessionUUID := uuid.NewString()
+
+	tests := []struct {
+		name          string
+		path          string
+		method        string
+		headers       map[string]string
+		setup         func(whoami *mocks.MockWhoamiCacheService, apiKeys *mocks.MockAPIKeyRepository, audit *mocks.MockAuditService)
+		wantAllowed   bool
+		wantRemoveHdr []string
+	}{
+		{
+			name:   "session request strips client supplied api key scopes",
+			path:   "/api/v1/inventory/parts",
+			method: "POST",
+			headers: map[string]string{
+				api.SessionHeaderName: sessionUUID,
+				"x-api-key-scopes":    "inventory:write",
+			},
+			setup: func(whoami *mocks.MockWhoamiCacheService, _ *mocks.MockAPIKeyRepository, _ *mocks.MockAuditService) {
+				whoami.EXPECT().Whoami(gomock.Any(), sessionUUID).Return(user, nil)
+			},
+			wantAllowed:   true,
+			wantRemoveHdr: []string{"x-api-key-scopes"},
+		},
+		{
+			name:   "invalid session is denied",
+			path:   "/api/v1/inventory/parts",
+			method: "GET",
+			headers: map[string]string{
+				api.SessionHeaderName: sessionUUID,
+			},
+			setup: func(whoami *mocks.MockWhoamiCacheService, _ *mocks.MockAPIKeyRepository, audit *mocks.MockAuditService) {
+				whoami.EXPECT().Whoami(gomock.Any(), sessionUUID).Return(nil, model.ErrSessionNotFound)
+				audit.EXPECT().Record(gomock.Any(), gomock.Any())
+			},
+		},
+		{
+			name:   "api key without required scope is denied",
+			path:   "/api/v1/inventory/parts",
+			method: "POST",
+			headers: map[string]string{
+				api.HeaderAuthorization: "ApiKey rsk_test",
+			},
+			setup: func(_ *mocks.MockWhoamiCacheService, apiKeys *mocks.MockAPIKeyRepository, audit *mocks.MockAuditService) {
+				apiKeys.EXPECT().GetByHash(gomock.Any(), gomock.Any()).Return(&model.APIKey{
+					APIKeyUUID: uuid.NewString(),
+					UserUUID:   user.UserUUID,
+					Scopes:     []string{model.APIKeyScopeInventoryRead},
+				}, nil)
+				audit.EXPECT().Record(gomock.Any(), gomock.Any())
+			},
+		},
+		{
+			name:   "unknown api key is denied",
+			path:   "/api/v1/orders",
+			method: "GET",
+			headers: map[string]string{
+				api.HeaderAuthorization: "ApiKey rsk_unknown",
+			},
+			setup: func(_ *mocks.MockWhoamiCacheService, apiKeys *mocks.MockAPIKeyRepository, audit *mocks.MockAuditService) {
+				apiKeys.EXPECT().GetByHash(gomock.Any(), gomock.Any()).Return(nil, model.ErrAPIKeyNotFound)
+				audit.EXPECT().Record(gomock.Any(), gomock.Any())
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			whoami := mocks.NewMockWhoamiCacheService(ctrl)
+			apiKeyRepo := mocks.NewMockAPIKeyRepository(ctrl)
+			auditSvc := mocks.NewMockAuditService(ctrl)
+			userRepo := mocks.NewMockUserRepository(ctrl)
+			userRepo.EXPECT().GetByID(gomock.Any(), user.UserUUID).Return(user, nil).AnyTimes()
+			tt.setup(whoami, apiKeyRepo, auditSvc)
+
+			apiKeySvc := api_key.NewService(
+				apiKeyRepo,
+				mocks.NewMockAPIKeySessionRepository(ctrl),
+				mocks.NewMockSessionRepository(ctrl),
+				userRepo,
+				whoami,
+			)
+			handler := api.NewExtAuthzV1Handler(whoami, apiKeySvc, auditSvc)
+
+			resp, err := handler.Check(ctx, checkRequest(tt.path, tt.method, tt.headers))
+			require.NoError(t, err)
+
+			if !tt.wantAllowed {
+				require.Nil(t, resp.GetOkResponse())
+				require.NotNil(t, resp.GetDeniedResponse())
+				return
+			}
+
+			require.NotNil(t, resp.GetOkResponse())
+
+			md := forwardedMetadata(tt.headers, resp)
+			require.Equal(t, []string{user.UserUUID}, md.Get(api.HeaderUserUUID))
+			require.Equal(t, []string{sessionUUID}, md.Get(grpcmiddleware.SessionUUIDHeader))
+			require.Equal(t, []string{sessionUUID}, md.Get(api.SessionHeaderName))
+			for _, header := range tt.wantRemoveHdr {
+				require.Contains(t, resp.GetOkResponse().GetHeadersToRemove(), header)
+				require.Empty(t, md.Get(header))
+			}
+		})
+	}
+}
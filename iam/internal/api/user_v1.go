@@ -3,32 +3,37 @@ package api
 import (
 	"context"
 
+	"google.golang.org/protobuf/types/known/timestamppb"
+
 	userv1 "github.com/linemk/rocket-shop/shared/pkg/proto/user/v1"
 
-	"github.com/linemk/rocket-shop/iam/internal/model"
+	accountservice "github.com/linemk/rocket-shop/iam/internal/service/account"
 	"github.com/linemk/rocket-shop/iam/internal/service/converter"
 	userservice "github.com/linemk/rocket-shop/iam/internal/service/user"
+	userdataservice "github.com/linemk/rocket-shop/iam/internal/service/user_data"
 )
 
 type userV1Handler struct {
-	userService userservice.Service
+	userService     userservice.Service
+	accountService  accountservice.Service
+	userDataService userdataservice.Service
 	userv1.UnimplementedUserServiceServer
 }
 
-func NewUserV1Handler(userService userservice.Service) userv1.UserServiceServer {
+func NewUserV1Handler(
+	userService userservice.Service,
+	accountService accountservice.Service,
+	userDataService userdataservice.Service,
+) userv1.UserServiceServer {
 	return &userV1Handler{
-		userService: userService,
+		userService:     userService,
+		accountService:  accountService,
+		userDataService: userDataService,
 	}
 }
 
 func (h *userV1Handler) Register(ctx context.Context, req *userv1.RegisterRequest) (*userv1.RegisterResponse, error) {
-	notificationMethods := make([]model.NotificationMethod, len(req.NotificationMethods))
-	for i, method := range req.NotificationMethods {
-		notificationMethods[i] = model.NotificationMethod{
-			ProviderName: method.ProviderName,
-			Target:       method.Target,
-		}
-	}
+	notificationMethods := converter.NotificationMethodsFromProto(req.NotificationMethods)
 
 	user, err := h.userService.Register(ctx, req.Login, req.Password, req.Email, notificationMethods)
 	if err != nil {
@@ -51,6 +56,109 @@ func (h *userV1Handler) GetUser(ctx context.Context, req *userv1.GetUserRequest)
 	}, nil
 }
 
+func (h *userV1Handler) UpdateProfile(ctx context.Context, req *userv1.UpdateProfileRequest) (*userv1.UpdateProfileResponse, error) {
+	user, err := h.userService.UpdateProfile(ctx, req.UserUuid, req.Email)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+
+	return &userv1.UpdateProfileResponse{
+		User: converter.UserToProto(user),
+	}, nil
+}
+
+func (h *userV1Handler) ChangePassword(ctx context.Context, req *userv1.ChangePasswordRequest) (*userv1.ChangePasswordResponse, error) {
+	err := h.userService.ChangePassword(ctx, req.UserUuid, req.CurrentPassword, req.NewPassword, req.SessionUuid)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+
+	return &userv1.ChangePasswordResponse{}, nil
+}
+
+func (h *userV1Handler) UpdateNotificationMethods(ctx context.Context, req *userv1.UpdateNotificationMethodsRequest) (*userv1.UpdateNotificationMethodsResponse, error) {
+	notificationMethods := converter.NotificationMethodsFromProto(req.NotificationMethods)
+
+	user, err := h.userService.UpdateNotificationMethods(ctx, req.UserUuid, notificationMethods)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+
+	return &userv1.UpdateNotificationMethodsResponse{
+		User: converter.UserToProto(user),
+	}, nil
+}
+
+func (h *userV1Handler) DeleteUser(ctx context.Context, req *userv1.DeleteUserRequest) (*userv1.DeleteUserResponse, error) {
+	err := h.userService.DeleteUser(ctx, req.UserUuid)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+
+	return &userv1.DeleteUserResponse{}, nil
+}
+
+func (h *userV1Handler) RequestPasswordReset(ctx context.Context, req *userv1.RequestPasswordResetRequest) (*userv1.RequestPasswordResetResponse, error) {
+	err := h.accountService.RequestPasswordReset(ctx, req.Email)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+
+	return &userv1.RequestPasswordResetResponse{}, nil
+}
+
+func (h *userV1Handler) ConfirmPasswordReset(ctx context.Context, req *userv1.ConfirmPasswordResetRequest) (*userv1.ConfirmPasswordResetResponse, error) {
+	err := h.accountService.ConfirmPasswordReset(ctx, req.Token, req.NewPassword)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+
+	return &userv1.ConfirmPasswordResetResponse{}, nil
+}
+
+func (h *userV1Handler) SendVerificationEmail(ctx context.Context, req *userv1.SendVerificationEmailRequest) (*userv1.SendVerificationEmailResponse, error) {
+	err := h.accountService.SendVerificationEmail(ctx, req.UserUuid)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+
+	return &userv1.SendVerificationEmailResponse{}, nil
+}
+
+func (h *userV1Handler) VerifyEmail(ctx context.Context, req *userv1.VerifyEmailRequest) (*userv1.VerifyEmailResponse, error) {
+	user, err := h.accountService.VerifyEmail(ctx, req.Token)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+
+	return &userv1.VerifyEmailResponse{
+		User: converter.UserToProto(user),
+	}, nil
+}
+
+func (h *userV1Handler) ExportUserData(ctx context.Context, req *userv1.ExportUserDataRequest) (*userv1.ExportUserDataResponse, error) {
+	archive, generatedAt, err := h.userDataService.Export(ctx, req.UserUuid)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+
+	return &userv1.ExportUserDataResponse{
+		Archive:     archive,
+		GeneratedAt: timestamppb.New(generatedAt),
+	}, nil
+}
+
+func (h *userV1Handler) EraseUser(ctx context.Context, req *userv1.EraseUserRequest) (*userv1.EraseUserResponse, error) {
+	erasedAt, err := h.userDataService.Erase(ctx, req.UserUuid)
+	if err != nil {
+		return nil, h.handleError(err)
+	}
+
+	return &userv1.EraseUserResponse{
+		ErasedAt: timestamppb.New(erasedAt),
+	}, nil
+}
+
 func (h *userV1Handler) handleError(err error) error {
 	// TODO: Map domain errors to gRPC status codes
 	return err
@@ -5,14 +5,19 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"net/http"
 	"time"
 
+	"github.com/IBM/sarama"
 	authv3 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jackc/pgx/v5/stdlib"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 
+	"github.com/linemk/rocket-shop/iam/internal/api"
+	paymentClient "github.com/linemk/rocket-shop/iam/internal/client/grpc/payment/v1"
+	orderClient "github.com/linemk/rocket-shop/iam/internal/client/http/order/v1"
 	"github.com/linemk/rocket-shop/iam/internal/config"
 	"github.com/linemk/rocket-shop/iam/internal/di"
 	authv1 "github.com/linemk/rocket-shop/shared/pkg/proto/auth/v1"
@@ -22,15 +27,31 @@ import (
 	rediscache "github.com/linemk/rocket-shop/platform/pkg/cache/redis"
 	"github.com/linemk/rocket-shop/platform/pkg/closer"
 	"github.com/linemk/rocket-shop/platform/pkg/grpcserver"
+	platformKafka "github.com/linemk/rocket-shop/platform/pkg/kafka"
+	"github.com/linemk/rocket-shop/platform/pkg/kafka/consumer"
+	"github.com/linemk/rocket-shop/platform/pkg/kafka/producer"
 	"github.com/linemk/rocket-shop/platform/pkg/logger"
+	kafkaMiddleware "github.com/linemk/rocket-shop/platform/pkg/middleware/kafka"
 	"github.com/linemk/rocket-shop/platform/pkg/migrator/pg"
+	prommetrics "github.com/linemk/rocket-shop/platform/pkg/prometheus"
 )
 
+const readHeaderTimeout = 5 * time.Second
+
 type App struct {
 	grpcServer  *grpc.Server
+	httpServer  *http.Server
 	diContainer *di.Container
 	db          *pgxpool.Pool
 	cache       cache.Client
+
+	authTokenProducer  platformKafka.Producer
+	authAuditProducer  platformKafka.Producer
+	authAuditConsumer  platformKafka.Consumer
+	userErasedProducer platformKafka.Producer
+
+	orderClient   orderClient.OrderClient
+	paymentClient paymentClient.PaymentClient
 }
 
 func New(ctx context.Context) (*App, error) {
@@ -50,6 +71,37 @@ func (a *App) Run(ctx context.Context) error {
 		_ = logger.Sync()     //nolint:gosec // best-effort shutdown
 	}()
 
+	// Запускаем Kafka consumer событий аудита в отдельной горутине
+	go func() {
+		if err := a.diContainer.AuditConsumer.RunConsumer(ctx); err != nil {
+			logger.Error(ctx, fmt.Sprintf("Kafka consumer error: %v", err))
+		}
+	}()
+
+	// Слушаем инвалидации whoami кеша от других инстансов IAM
+	go func() {
+		if err := a.diContainer.WhoamiCache.RunInvalidationListener(ctx); err != nil {
+			logger.Error(ctx, fmt.Sprintf("Whoami cache invalidation listener error: %v", err))
+		}
+	}()
+
+	// Запускаем metrics HTTP server в отдельной горутине
+	go func() {
+		metricsPort := fmt.Sprintf(":%d", config.AppConfig().Metrics.Port())
+		if err := prommetrics.StartMetricsServer(ctx, metricsPort, a.diContainer.Metrics); err != nil {
+			logger.Error(ctx, fmt.Sprintf("Metrics server error: %v", err))
+		}
+	}()
+
+	// JWKS эндпоинт нужен только когда IAM выпускает access токены
+	if a.httpServer != nil {
+		go func() {
+			if err := a.runHTTPServer(ctx); err != nil {
+				logger.Error(ctx, fmt.Sprintf("HTTP server error: %v", err))
+			}
+		}()
+	}
+
 	return a.runGRPCServer(ctx)
 }
 
@@ -61,8 +113,11 @@ func (a *App) initDeps(ctx context.Context) error {
 		a.initCache,
 		a.initDatabase,
 		a.initMigrations,
+		a.initKafka,
+		a.initClients,
 		a.initDI,
 		a.initGRPCServer,
+		a.initHTTPServer,
 	}
 
 	for _, f := range inits {
@@ -165,14 +220,111 @@ func (a *App) initMigrations(ctx context.Context) error {
 	return nil
 }
 
+func (a *App) initKafka(_ context.Context) error {
+	saramaConfig := sarama.NewConfig()
+	saramaConfig.Version = sarama.V2_6_0_0
+	saramaConfig.Producer.Return.Successes = true
+	saramaConfig.Producer.RequiredAcks = sarama.WaitForAll
+	saramaConfig.Producer.Retry.Max = 5
+
+	syncProducer, err := sarama.NewSyncProducer(config.AppConfig().Kafka.Brokers(), saramaConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create Kafka sync producer: %w", err)
+	}
+
+	closer.AddNamed("Kafka sync producer", func(ctx context.Context) error {
+		return syncProducer.Close()
+	})
+
+	a.authTokenProducer = producer.NewProducer(
+		syncProducer,
+		config.AppConfig().Kafka.AuthTokenTopic(),
+		logger.Logger(),
+	)
+
+	a.authAuditProducer = producer.NewProducer(
+		syncProducer,
+		config.AppConfig().Kafka.AuthAuditTopic(),
+		logger.Logger(),
+	)
+
+	a.userErasedProducer = producer.NewProducer(
+		syncProducer,
+		config.AppConfig().Kafka.UserErasedTopic(),
+		logger.Logger(),
+	)
+
+	consumerConfig := sarama.NewConfig()
+	consumerConfig.Version = sarama.V2_6_0_0
+	consumerConfig.Consumer.Group.Rebalance.Strategy = sarama.NewBalanceStrategyRoundRobin()
+	consumerConfig.Consumer.Offsets.Initial = sarama.OffsetOldest
+
+	consumerGroup, err := sarama.NewConsumerGroup(
+		config.AppConfig().Kafka.Brokers(),
+		config.AppConfig().Kafka.AuthAuditConsumerGroupID(),
+		consumerConfig,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create Kafka consumer group for AuthAuditEvent: %w", err)
+	}
+
+	closer.AddNamed("Kafka consumer group for AuthAuditEvent", func(ctx context.Context) error {
+		return consumerGroup.Close()
+	})
+
+	a.authAuditConsumer = consumer.NewConsumer(
+		consumerGroup,
+		[]string{config.AppConfig().Kafka.AuthAuditTopic()},
+		logger.Logger(),
+		kafkaMiddleware.Logging(logger.Logger()),
+	)
+
+	return nil
+}
+
+func (a *App) initClients(_ context.Context) error {
+	orderHTTPClient, err := orderClient.NewClient(config.AppConfig().UserData.OrderHTTPURL())
+	if err != nil {
+		return fmt.Errorf("failed to create OrderService client: %w", err)
+	}
+
+	a.orderClient = orderHTTPClient
+
+	paymentGRPCClient, err := paymentClient.NewClient(config.AppConfig().UserData.PaymentGRPCAddress())
+	if err != nil {
+		return fmt.Errorf("failed to create PaymentService client: %w", err)
+	}
+
+	closer.AddNamed("PaymentService gRPC client", func(ctx context.Context) error {
+		return paymentGRPCClient.Close()
+	})
+
+	a.paymentClient = paymentGRPCClient
+
+	return nil
+}
+
 func (a *App) initDI(_ context.Context) error {
-	a.diContainer = di.New(a.db, a.cache)
+	a.diContainer = di.New(
+		a.db,
+		a.cache,
+		a.authTokenProducer,
+		a.authAuditProducer,
+		a.authAuditConsumer,
+		a.userErasedProducer,
+		a.orderClient,
+		a.paymentClient,
+	)
 	return nil
 }
 
 func (a *App) initGRPCServer(ctx context.Context) error {
 	opts := []grpc.ServerOption{
 		grpc.ConnectionTimeout(5 * time.Second),
+		grpc.ChainUnaryInterceptor(
+			prommetrics.NewGRPCMetrics(a.diContainer.Metrics).UnaryServerInterceptor(),
+			api.UnaryClientInfoInterceptor,
+		),
 	}
 
 	a.grpcServer = grpc.NewServer(opts...)
@@ -191,6 +343,38 @@ func (a *App) initGRPCServer(ctx context.Context) error {
 	return nil
 }
 
+func (a *App) initHTTPServer(_ context.Context) error {
+	if !config.AppConfig().JWT.Enabled() {
+		return nil
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(api.JWKSPath, a.diContainer.JWKSHandler)
+
+	a.httpServer = &http.Server{
+		Addr:              config.AppConfig().JWT.HTTPAddress(),
+		Handler:           mux,
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+
+	closer.AddNamed("HTTP server", func(ctx context.Context) error {
+		return a.httpServer.Shutdown(ctx)
+	})
+
+	return nil
+}
+
+func (a *App) runHTTPServer(ctx context.Context) error {
+	logger.Info(ctx, fmt.Sprintf("🔑 IAM JWKS HTTP server listening on %s", config.AppConfig().JWT.HTTPAddress()))
+
+	err := a.httpServer.ListenAndServe()
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
+
 func (a *App) runGRPCServer(ctx context.Context) error {
 	listener, err := grpcserver.NewListener(config.AppConfig().GRPC.Address())
 	if err != nil {
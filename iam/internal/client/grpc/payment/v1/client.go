@@ -0,0 +1,33 @@
+package v1
+
+import (
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	payment_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/payment/v1"
+)
+
+type Client struct {
+	client payment_v1.PaymentServiceClient
+	conn   *grpc.ClientConn
+}
+
+func NewClient(address string) (*Client, error) {
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to connect to PaymentService")
+	}
+
+	return &Client{
+		client: payment_v1.NewPaymentServiceClient(conn),
+		conn:   conn,
+	}, nil
+}
+
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// Проверяем, что Client реализует интерфейс PaymentClient
+var _ PaymentClient = (*Client)(nil)
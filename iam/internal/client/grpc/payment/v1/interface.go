@@ -0,0 +1,12 @@
+package v1
+
+import (
+	"context"
+
+	"github.com/linemk/rocket-shop/iam/internal/model"
+)
+
+type PaymentClient interface {
+	ListUserTransactions(ctx context.Context, userUUID string) ([]model.UserPayment, error)
+	Close() error
+}
@@ -0,0 +1,34 @@
+package v1
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/linemk/rocket-shop/iam/internal/model"
+	payment_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/payment/v1"
+)
+
+func (c *Client) ListUserTransactions(ctx context.Context, userUUID string) ([]model.UserPayment, error) {
+	resp, err := c.client.ListUserTransactions(ctx, &payment_v1.ListUserTransactionsRequest{
+		UserUuid: userUUID,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list user transactions")
+	}
+
+	payments := make([]model.UserPayment, 0, len(resp.Transactions))
+	for _, transaction := range resp.Transactions {
+		payments = append(payments, model.UserPayment{
+			TransactionUUID: transaction.TransactionUuid,
+			OrderUUID:       transaction.OrderUuid,
+			PaymentMethod:   transaction.PaymentMethod.String(),
+			Amount:          transaction.Amount,
+			Status:          transaction.Status,
+			CreatedAt:       transaction.CreatedAt.AsTime(),
+			UpdatedAt:       transaction.UpdatedAt.AsTime(),
+		})
+	}
+
+	return payments, nil
+}
@@ -0,0 +1,25 @@
+package v1
+
+import (
+	"github.com/pkg/errors"
+
+	order_v1 "github.com/linemk/rocket-shop/shared/pkg/openapi/order/v1"
+)
+
+type Client struct {
+	client *order_v1.Client
+}
+
+func NewClient(serverURL string) (*Client, error) {
+	client, err := order_v1.NewClient(serverURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create OrderService client")
+	}
+
+	return &Client{
+		client: client,
+	}, nil
+}
+
+// Проверяем, что Client реализует интерфейс OrderClient
+var _ OrderClient = (*Client)(nil)
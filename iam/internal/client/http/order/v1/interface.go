@@ -0,0 +1,11 @@
+package v1
+
+import (
+	"context"
+
+	"github.com/linemk/rocket-shop/iam/internal/model"
+)
+
+type OrderClient interface {
+	ListUserOrders(ctx context.Context, userUUID string) ([]model.UserOrder, error)
+}
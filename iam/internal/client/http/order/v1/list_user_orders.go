@@ -0,0 +1,49 @@
+package v1
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/linemk/rocket-shop/iam/internal/model"
+	order_v1 "github.com/linemk/rocket-shop/shared/pkg/openapi/order/v1"
+)
+
+func (c *Client) ListUserOrders(ctx context.Context, userUUID string) ([]model.UserOrder, error) {
+	parsedUUID, err := uuid.Parse(userUUID)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid user uuid")
+	}
+
+	resp, err := c.client.ListUserOrders(ctx, order_v1.ListUserOrdersParams{
+		UserUUID: parsedUUID,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list user orders")
+	}
+
+	orders := make([]model.UserOrder, 0, len(resp.Orders))
+	for _, order := range resp.Orders {
+		partUUIDs := make([]string, 0, len(order.PartUuids))
+		for _, partUUID := range order.PartUuids {
+			partUUIDs = append(partUUIDs, partUUID.String())
+		}
+
+		var transactionUUID string
+		if order.TransactionUUID != uuid.Nil {
+			transactionUUID = order.TransactionUUID.String()
+		}
+
+		orders = append(orders, model.UserOrder{
+			OrderUUID:       order.OrderUUID.String(),
+			PartUUIDs:       partUUIDs,
+			TotalPrice:      float64(order.TotalPrice),
+			TransactionUUID: transactionUUID,
+			PaymentMethod:   string(order.PaymentMethod),
+			Status:          string(order.Status),
+		})
+	}
+
+	return orders, nil
+}
@@ -11,11 +11,20 @@ import (
 var appConfig *config
 
 type config struct {
-	Logger   LoggerConfig
-	Postgres PostgresConfig
-	Redis    RedisConfig
-	GRPC     GRPCConfig
-	Session  SessionConfig
+	Logger      LoggerConfig
+	Postgres    PostgresConfig
+	Redis       RedisConfig
+	GRPC        GRPCConfig
+	Session     SessionConfig
+	Kafka       KafkaConfig
+	Token       TokenConfig
+	Password    PasswordConfig
+	OIDC        OIDCConfig
+	MFA         MFAConfig
+	JWT         JWTConfig
+	Metrics     MetricsConfig
+	WhoamiCache WhoamiCacheConfig
+	UserData    UserDataConfig
 }
 
 // Load загружает конфигурацию из переменных окружения
@@ -50,12 +59,66 @@ func Load(path ...string) error {
 		return err
 	}
 
+	kafkaCfg, err := env.NewKafkaConfig()
+	if err != nil {
+		return err
+	}
+
+	tokenCfg, err := env.NewTokenConfig()
+	if err != nil {
+		return err
+	}
+
+	passwordCfg, err := env.NewPasswordConfig()
+	if err != nil {
+		return err
+	}
+
+	oidcCfg, err := env.NewOIDCConfig()
+	if err != nil {
+		return err
+	}
+
+	mfaCfg, err := env.NewMFAConfig()
+	if err != nil {
+		return err
+	}
+
+	jwtCfg, err := env.NewJWTConfig()
+	if err != nil {
+		return err
+	}
+
+	metricsCfg, err := env.NewMetricsConfig()
+	if err != nil {
+		return err
+	}
+
+	whoamiCacheCfg, err := env.NewWhoamiCacheConfig()
+	if err != nil {
+		return err
+	}
+
+	userDataCfg, err := env.NewUserDataConfig()
+	if err != nil {
+		return err
+	}
+
 	appConfig = &config{
-		Logger:   loggerCfg,
-		Postgres: postgresCfg,
-		Redis:    redisCfg,
-		GRPC:     grpcCfg,
-		Session:  sessionCfg,
+		Logger:      loggerCfg,
+		Postgres:    postgresCfg,
+		Redis:       redisCfg,
+		GRPC:        grpcCfg,
+		Session:     sessionCfg,
+		Kafka:       kafkaCfg,
+		Token:       tokenCfg,
+		Password:    passwordCfg,
+		OIDC:        oidcCfg,
+		MFA:         mfaCfg,
+		JWT:         jwtCfg,
+		Metrics:     metricsCfg,
+		WhoamiCache: whoamiCacheCfg,
+		UserData:    userDataCfg,
 	}
 
 	return nil
@@ -0,0 +1,121 @@
+package env
+
+import (
+	"encoding/base64"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	jwtEnabledEnv             = "JWT_ENABLED"
+	jwtIssuerEnv              = "JWT_ISSUER"
+	jwtAccessTTLEnv           = "JWT_ACCESS_TTL"
+	jwtKeyRotationIntervalEnv = "JWT_KEY_ROTATION_INTERVAL"
+	jwtKeyEncryptionKeyEnv    = "JWT_KEY_ENCRYPTION_KEY"
+	jwksHTTPAddressEnv        = "IAM_HTTP_ADDRESS"
+
+	// jwtKeyEncryptionKeySize размер ключа AES-256 в байтах
+	jwtKeyEncryptionKeySize = 32
+)
+
+type jwtConfig struct {
+	enabled             bool
+	issuer              string
+	accessTTL           time.Duration
+	keyRotationInterval time.Duration
+	keyEncryptionKey    []byte
+	httpAddress         string
+}
+
+// NewJWTConfig создает конфигурацию access токенов из переменных окружения
+func NewJWTConfig() (*jwtConfig, error) {
+	enabled := false
+	if enabledStr := os.Getenv(jwtEnabledEnv); enabledStr != "" {
+		parsed, err := strconv.ParseBool(enabledStr)
+		if err == nil {
+			enabled = parsed
+		}
+	}
+
+	issuer := os.Getenv(jwtIssuerEnv)
+	if issuer == "" {
+		issuer = "rocket-shop-iam"
+	}
+
+	accessTTL := 15 * time.Minute // По умолчанию 15 минут
+	if ttlStr := os.Getenv(jwtAccessTTLEnv); ttlStr != "" {
+		parsed, err := time.ParseDuration(ttlStr)
+		if err == nil {
+			accessTTL = parsed
+		}
+	}
+
+	keyRotationInterval := 24 * time.Hour // По умолчанию 24 часа
+	if intervalStr := os.Getenv(jwtKeyRotationIntervalEnv); intervalStr != "" {
+		parsed, err := time.ParseDuration(intervalStr)
+		if err == nil {
+			keyRotationInterval = parsed
+		}
+	}
+
+	// Приватные ключи подписи хранятся в Redis зашифрованными, поэтому при включенных токенах ключ шифрования обязателен
+	var keyEncryptionKey []byte
+	if enabled {
+		encodedKey := os.Getenv(jwtKeyEncryptionKeyEnv)
+		if encodedKey == "" {
+			return nil, errors.Errorf("%s is required when %s=true", jwtKeyEncryptionKeyEnv, jwtEnabledEnv)
+		}
+
+		decoded, err := base64.StdEncoding.DecodeString(encodedKey)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decode %s", jwtKeyEncryptionKeyEnv)
+		}
+
+		if len(decoded) != jwtKeyEncryptionKeySize {
+			return nil, errors.Errorf("%s must be %d bytes long", jwtKeyEncryptionKeyEnv, jwtKeyEncryptionKeySize)
+		}
+
+		keyEncryptionKey = decoded
+	}
+
+	httpAddress := os.Getenv(jwksHTTPAddressEnv)
+	if httpAddress == "" {
+		httpAddress = ":8083"
+	}
+
+	return &jwtConfig{
+		enabled:             enabled,
+		issuer:              issuer,
+		accessTTL:           accessTTL,
+		keyRotationInterval: keyRotationInterval,
+		keyEncryptionKey:    keyEncryptionKey,
+		httpAddress:         httpAddress,
+	}, nil
+}
+
+func (c *jwtConfig) Enabled() bool {
+	return c.enabled
+}
+
+func (c *jwtConfig) Issuer() string {
+	return c.issuer
+}
+
+func (c *jwtConfig) AccessTTL() time.Duration {
+	return c.accessTTL
+}
+
+func (c *jwtConfig) KeyRotationInterval() time.Duration {
+	return c.keyRotationInterval
+}
+
+func (c *jwtConfig) KeyEncryptionKey() []byte {
+	return c.keyEncryptionKey
+}
+
+func (c *jwtConfig) HTTPAddress() string {
+	return c.httpAddress
+}
@@ -0,0 +1,84 @@
+package env
+
+import (
+	"os"
+	"strings"
+)
+
+const (
+	kafkaBrokersEnv           = "KAFKA_BROKERS"
+	authTokenProducerTopicEnv = "AUTH_TOKEN_PRODUCER_TOPIC"
+	defaultAuthTokenTopic     = "auth-token-issued"
+
+	authAuditTopicEnv           = "AUTH_AUDIT_TOPIC"
+	authAuditConsumerGroupIDEnv = "AUTH_AUDIT_CONSUMER_GROUP_ID"
+	defaultAuthAuditTopic       = "auth-audit"
+	defaultAuthAuditGroupID     = "iam-auth-audit"
+
+	userErasedTopicEnv     = "USER_ERASED_TOPIC"
+	defaultUserErasedTopic = "user-erased"
+)
+
+type kafkaConfig struct {
+	brokers                  []string
+	authTokenTopic           string
+	authAuditTopic           string
+	authAuditConsumerGroupID string
+	userErasedTopic          string
+}
+
+// NewKafkaConfig создает конфигурацию Kafka из переменных окружения
+func NewKafkaConfig() (*kafkaConfig, error) {
+	brokers := []string{"localhost:9092"}
+	if brokersStr := os.Getenv(kafkaBrokersEnv); brokersStr != "" {
+		brokers = strings.Split(brokersStr, ",")
+	}
+
+	authTokenTopic := os.Getenv(authTokenProducerTopicEnv)
+	if authTokenTopic == "" {
+		authTokenTopic = defaultAuthTokenTopic
+	}
+
+	authAuditTopic := os.Getenv(authAuditTopicEnv)
+	if authAuditTopic == "" {
+		authAuditTopic = defaultAuthAuditTopic
+	}
+
+	authAuditConsumerGroupID := os.Getenv(authAuditConsumerGroupIDEnv)
+	if authAuditConsumerGroupID == "" {
+		authAuditConsumerGroupID = defaultAuthAuditGroupID
+	}
+
+	userErasedTopic := os.Getenv(userErasedTopicEnv)
+	if userErasedTopic == "" {
+		userErasedTopic = defaultUserErasedTopic
+	}
+
+	return &kafkaConfig{
+		brokers:                  brokers,
+		authTokenTopic:           authTokenTopic,
+		authAuditTopic:           authAuditTopic,
+		authAuditConsumerGroupID: authAuditConsumerGroupID,
+		userErasedTopic:          userErasedTopic,
+	}, nil
+}
+
+func (c *kafkaConfig) Brokers() []string {
+	return c.brokers
+}
+
+func (c *kafkaConfig) AuthTokenTopic() string {
+	return c.authTokenTopic
+}
+
+func (c *kafkaConfig) AuthAuditTopic() string {
+	return c.authAuditTopic
+}
+
+func (c *kafkaConfig) AuthAuditConsumerGroupID() string {
+	return c.authAuditConsumerGroupID
+}
+
+func (c *kafkaConfig) UserErasedTopic() string {
+	return c.userErasedTopic
+}
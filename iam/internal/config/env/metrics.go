@@ -0,0 +1,33 @@
+package env
+
+import (
+	"os"
+	"strconv"
+)
+
+const metricsPortEnv = "IAM_METRICS_PORT"
+
+type metricsConfig struct {
+	port int
+}
+
+// NewMetricsConfig создает конфигурацию Prometheus метрик из переменных окружения
+func NewMetricsConfig() (*metricsConfig, error) {
+	portStr := os.Getenv(metricsPortEnv)
+	if portStr == "" {
+		portStr = "9095"
+	}
+
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &metricsConfig{
+		port: port,
+	}, nil
+}
+
+func (c *metricsConfig) Port() int {
+	return c.port
+}
@@ -0,0 +1,89 @@
+package env
+
+import (
+	"encoding/base64"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	mfaIssuerEnv        = "MFA_ISSUER"
+	mfaEncryptionKeyEnv = "MFA_ENCRYPTION_KEY"
+	mfaChallengeTTLEnv  = "MFA_CHALLENGE_TTL"
+	mfaMaxAttemptsEnv   = "MFA_MAX_ATTEMPTS"
+
+	// mfaEncryptionKeySize размер ключа AES-256 в байтах
+	mfaEncryptionKeySize = 32
+)
+
+type mfaConfig struct {
+	issuer        string
+	encryptionKey []byte
+	challengeTTL  time.Duration
+	maxAttempts   int
+}
+
+// NewMFAConfig создает конфигурацию двухфакторной аутентификации из переменных окружения
+func NewMFAConfig() (*mfaConfig, error) {
+	issuer := os.Getenv(mfaIssuerEnv)
+	if issuer == "" {
+		issuer = "Rocket Shop"
+	}
+
+	// Ключ шифрования TOTP секретов обязателен: без него секреты нельзя ни сохранить, ни прочитать
+	encodedKey := os.Getenv(mfaEncryptionKeyEnv)
+	if encodedKey == "" {
+		return nil, errors.Errorf("%s is required", mfaEncryptionKeyEnv)
+	}
+
+	encryptionKey, err := base64.StdEncoding.DecodeString(encodedKey)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decode %s", mfaEncryptionKeyEnv)
+	}
+
+	if len(encryptionKey) != mfaEncryptionKeySize {
+		return nil, errors.Errorf("%s must be %d bytes long", mfaEncryptionKeyEnv, mfaEncryptionKeySize)
+	}
+
+	challengeTTL := 5 * time.Minute // По умолчанию 5 минут
+	if ttlStr := os.Getenv(mfaChallengeTTLEnv); ttlStr != "" {
+		parsed, err := time.ParseDuration(ttlStr)
+		if err == nil {
+			challengeTTL = parsed
+		}
+	}
+
+	maxAttempts := 5
+	if attemptsStr := os.Getenv(mfaMaxAttemptsEnv); attemptsStr != "" {
+		parsed, err := strconv.Atoi(attemptsStr)
+		if err == nil && parsed > 0 {
+			maxAttempts = parsed
+		}
+	}
+
+	return &mfaConfig{
+		issuer:        issuer,
+		encryptionKey: encryptionKey,
+		challengeTTL:  challengeTTL,
+		maxAttempts:   maxAttempts,
+	}, nil
+}
+
+func (c *mfaConfig) Issuer() string {
+	return c.issuer
+}
+
+func (c *mfaConfig) EncryptionKey() []byte {
+	return c.encryptionKey
+}
+
+func (c *mfaConfig) ChallengeTTL() time.Duration {
+	return c.challengeTTL
+}
+
+func (c *mfaConfig) MaxAttempts() int {
+	return c.maxAttempts
+}
@@ -0,0 +1,128 @@
+package env
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	oidcProvidersEnv   = "OIDC_PROVIDERS"
+	oidcStateTTLEnv    = "OIDC_STATE_TTL"
+	oidcAllowSignupEnv = "OIDC_ALLOW_SIGNUP"
+
+	// Параметры провайдера задаются переменными OIDC_<ИМЯ>_*
+	oidcIssuerURLEnvFormat    = "OIDC_%s_ISSUER_URL"
+	oidcClientIDEnvFormat     = "OIDC_%s_CLIENT_ID"
+	oidcClientSecretEnvFormat = "OIDC_%s_CLIENT_SECRET"
+	oidcRedirectURLEnvFormat  = "OIDC_%s_REDIRECT_URL"
+	oidcScopesEnvFormat       = "OIDC_%s_SCOPES"
+)
+
+var oidcProviderNameRegexp = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// OIDCProvider настройки внешнего OIDC провайдера
+type OIDCProvider struct {
+	Name         string
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+}
+
+type oidcConfig struct {
+	providers   []OIDCProvider
+	stateTTL    time.Duration
+	allowSignup bool
+}
+
+// NewOIDCConfig создает конфигурацию входа через внешних OIDC провайдеров из переменных окружения
+func NewOIDCConfig() (*oidcConfig, error) {
+	var providers []OIDCProvider
+	for _, name := range strings.Split(os.Getenv(oidcProvidersEnv), ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		if !oidcProviderNameRegexp.MatchString(name) {
+			return nil, errors.Errorf("invalid oidc provider name %q in %s", name, oidcProvidersEnv)
+		}
+
+		provider, err := newOIDCProvider(name)
+		if err != nil {
+			return nil, err
+		}
+
+		providers = append(providers, provider)
+	}
+
+	stateTTL := 10 * time.Minute // По умолчанию 10 минут
+	if ttlStr := os.Getenv(oidcStateTTLEnv); ttlStr != "" {
+		parsed, err := time.ParseDuration(ttlStr)
+		if err == nil {
+			stateTTL = parsed
+		}
+	}
+
+	allowSignup := true
+	if allowSignupStr := os.Getenv(oidcAllowSignupEnv); allowSignupStr != "" {
+		parsed, err := strconv.ParseBool(allowSignupStr)
+		if err == nil {
+			allowSignup = parsed
+		}
+	}
+
+	return &oidcConfig{
+		providers:   providers,
+		stateTTL:    stateTTL,
+		allowSignup: allowSignup,
+	}, nil
+}
+
+func newOIDCProvider(name string) (OIDCProvider, error) {
+	envName := strings.ToUpper(name)
+
+	provider := OIDCProvider{
+		Name:         name,
+		IssuerURL:    os.Getenv(fmt.Sprintf(oidcIssuerURLEnvFormat, envName)),
+		ClientID:     os.Getenv(fmt.Sprintf(oidcClientIDEnvFormat, envName)),
+		ClientSecret: os.Getenv(fmt.Sprintf(oidcClientSecretEnvFormat, envName)),
+		RedirectURL:  os.Getenv(fmt.Sprintf(oidcRedirectURLEnvFormat, envName)),
+		Scopes:       []string{"openid", "email", "profile"},
+	}
+
+	if scopesStr := os.Getenv(fmt.Sprintf(oidcScopesEnvFormat, envName)); scopesStr != "" {
+		provider.Scopes = strings.Fields(strings.ReplaceAll(scopesStr, ",", " "))
+	}
+
+	if provider.IssuerURL == "" || provider.ClientID == "" || provider.RedirectURL == "" {
+		return OIDCProvider{}, errors.Errorf(
+			"oidc provider %q requires %s, %s and %s",
+			name,
+			fmt.Sprintf(oidcIssuerURLEnvFormat, envName),
+			fmt.Sprintf(oidcClientIDEnvFormat, envName),
+			fmt.Sprintf(oidcRedirectURLEnvFormat, envName),
+		)
+	}
+
+	return provider, nil
+}
+
+func (c *oidcConfig) Providers() []OIDCProvider {
+	return c.providers
+}
+
+func (c *oidcConfig) StateTTL() time.Duration {
+	return c.stateTTL
+}
+
+func (c *oidcConfig) AllowSignup() bool {
+	return c.allowSignup
+}
@@ -0,0 +1,98 @@
+package env
+
+import (
+	"os"
+	"strconv"
+)
+
+const (
+	passwordArgon2MemoryEnv      = "PASSWORD_ARGON2_MEMORY_KIB"
+	passwordArgon2IterationsEnv  = "PASSWORD_ARGON2_ITERATIONS"
+	passwordArgon2ParallelismEnv = "PASSWORD_ARGON2_PARALLELISM"
+	passwordMinLengthEnv         = "PASSWORD_MIN_LENGTH"
+	passwordMaxLengthEnv         = "PASSWORD_MAX_LENGTH"
+	passwordRequireLetterEnv     = "PASSWORD_REQUIRE_LETTER"
+	passwordRequireDigitEnv      = "PASSWORD_REQUIRE_DIGIT"
+	passwordRequireSymbolEnv     = "PASSWORD_REQUIRE_SYMBOL"
+)
+
+type passwordConfig struct {
+	argon2Memory      uint32
+	argon2Iterations  uint32
+	argon2Parallelism uint8
+	minLength         int
+	maxLength         int
+	requireLetter     bool
+	requireDigit      bool
+	requireSymbol     bool
+}
+
+// NewPasswordConfig создает конфигурацию хеширования паролей и парольной политики из переменных окружения
+func NewPasswordConfig() (*passwordConfig, error) {
+	// Параметры argon2id по умолчанию соответствуют рекомендациям OWASP
+	return &passwordConfig{
+		argon2Memory:      uint32(getUintEnv(passwordArgon2MemoryEnv, 64*1024, 32)),
+		argon2Iterations:  uint32(getUintEnv(passwordArgon2IterationsEnv, 3, 32)),
+		argon2Parallelism: uint8(getUintEnv(passwordArgon2ParallelismEnv, 2, 8)),
+		minLength:         int(getUintEnv(passwordMinLengthEnv, 8, 32)),
+		maxLength:         int(getUintEnv(passwordMaxLengthEnv, 128, 32)),
+		requireLetter:     getBoolEnv(passwordRequireLetterEnv, true),
+		requireDigit:      getBoolEnv(passwordRequireDigitEnv, true),
+		requireSymbol:     getBoolEnv(passwordRequireSymbolEnv, false),
+	}, nil
+}
+
+// getUintEnv читает положительное целое; некорректное значение заменяется значением по умолчанию
+func getUintEnv(key string, defaultValue uint64, bitSize int) uint64 {
+	if valueStr := os.Getenv(key); valueStr != "" {
+		parsed, err := strconv.ParseUint(valueStr, 10, bitSize)
+		if err == nil && parsed > 0 {
+			return parsed
+		}
+	}
+
+	return defaultValue
+}
+
+func getBoolEnv(key string, defaultValue bool) bool {
+	if valueStr := os.Getenv(key); valueStr != "" {
+		parsed, err := strconv.ParseBool(valueStr)
+		if err == nil {
+			return parsed
+		}
+	}
+
+	return defaultValue
+}
+
+func (c *passwordConfig) Argon2Memory() uint32 {
+	return c.argon2Memory
+}
+
+func (c *passwordConfig) Argon2Iterations() uint32 {
+	return c.argon2Iterations
+}
+
+func (c *passwordConfig) Argon2Parallelism() uint8 {
+	return c.argon2Parallelism
+}
+
+func (c *passwordConfig) MinLength() int {
+	return c.minLength
+}
+
+func (c *passwordConfig) MaxLength() int {
+	return c.maxLength
+}
+
+func (c *passwordConfig) RequireLetter() bool {
+	return c.requireLetter
+}
+
+func (c *passwordConfig) RequireDigit() bool {
+	return c.requireDigit
+}
+
+func (c *passwordConfig) RequireSymbol() bool {
+	return c.requireSymbol
+}
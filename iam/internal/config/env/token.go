@@ -0,0 +1,48 @@
+package env
+
+import (
+	"os"
+	"time"
+)
+
+const (
+	passwordResetTokenTTLEnv     = "PASSWORD_RESET_TOKEN_TTL"
+	emailVerificationTokenTTLEnv = "EMAIL_VERIFICATION_TOKEN_TTL"
+)
+
+type tokenConfig struct {
+	passwordResetTTL     time.Duration
+	emailVerificationTTL time.Duration
+}
+
+// NewTokenConfig создает конфигурацию одноразовых токенов из переменных окружения
+func NewTokenConfig() (*tokenConfig, error) {
+	passwordResetTTL := time.Hour // По умолчанию 1 час
+	if ttlStr := os.Getenv(passwordResetTokenTTLEnv); ttlStr != "" {
+		parsed, err := time.ParseDuration(ttlStr)
+		if err == nil {
+			passwordResetTTL = parsed
+		}
+	}
+
+	emailVerificationTTL := 24 * time.Hour // По умолчанию 24 часа
+	if ttlStr := os.Getenv(emailVerificationTokenTTLEnv); ttlStr != "" {
+		parsed, err := time.ParseDuration(ttlStr)
+		if err == nil {
+			emailVerificationTTL = parsed
+		}
+	}
+
+	return &tokenConfig{
+		passwordResetTTL:     passwordResetTTL,
+		emailVerificationTTL: emailVerificationTTL,
+	}, nil
+}
+
+func (c *tokenConfig) PasswordResetTTL() time.Duration {
+	return c.passwordResetTTL
+}
+
+func (c *tokenConfig) EmailVerificationTTL() time.Duration {
+	return c.emailVerificationTTL
+}
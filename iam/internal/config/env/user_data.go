@@ -0,0 +1,64 @@
+package env
+
+import (
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	orderHTTPURLEnv       = "ORDER_HTTP_URL"
+	paymentGRPCAddressEnv = "PAYMENT_GRPC_ADDRESS"
+	userDataTimeoutEnv    = "USER_DATA_REQUEST_TIMEOUT"
+
+	defaultOrderHTTPURL       = "http://localhost:8080"
+	defaultPaymentGRPCAddress = "localhost:50052"
+	defaultUserDataTimeout    = 10 * time.Second
+)
+
+type userDataConfig struct {
+	orderHTTPURL       string
+	paymentGRPCAddress string
+	requestTimeout     time.Duration
+}
+
+// NewUserDataConfig создает конфигурацию выгрузки и удаления данных пользователя из переменных окружения
+func NewUserDataConfig() (*userDataConfig, error) {
+	orderHTTPURL := os.Getenv(orderHTTPURLEnv)
+	if orderHTTPURL == "" {
+		orderHTTPURL = defaultOrderHTTPURL
+	}
+
+	paymentGRPCAddress := os.Getenv(paymentGRPCAddressEnv)
+	if paymentGRPCAddress == "" {
+		paymentGRPCAddress = defaultPaymentGRPCAddress
+	}
+
+	requestTimeout := defaultUserDataTimeout
+	if timeoutStr := os.Getenv(userDataTimeoutEnv); timeoutStr != "" {
+		timeout, err := time.ParseDuration(timeoutStr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid %s", userDataTimeoutEnv)
+		}
+		requestTimeout = timeout
+	}
+
+	return &userDataConfig{
+		orderHTTPURL:       orderHTTPURL,
+		paymentGRPCAddress: paymentGRPCAddress,
+		requestTimeout:     requestTimeout,
+	}, nil
+}
+
+func (c *userDataConfig) OrderHTTPURL() string {
+	return c.orderHTTPURL
+}
+
+func (c *userDataConfig) PaymentGRPCAddress() string {
+	return c.paymentGRPCAddress
+}
+
+func (c *userDataConfig) RequestTimeout() time.Duration {
+	return c.requestTimeout
+}
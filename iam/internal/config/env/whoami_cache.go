@@ -0,0 +1,76 @@
+package env
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	whoamiCacheEnabledEnv             = "WHOAMI_CACHE_ENABLED"
+	whoamiCacheTTLEnv                 = "WHOAMI_CACHE_TTL"
+	whoamiCacheMaxEntriesEnv          = "WHOAMI_CACHE_MAX_ENTRIES"
+	whoamiCacheInvalidationChannelEnv = "WHOAMI_CACHE_INVALIDATION_CHANNEL"
+)
+
+type whoamiCacheConfig struct {
+	enabled             bool
+	ttl                 time.Duration
+	maxEntries          int
+	invalidationChannel string
+}
+
+// NewWhoamiCacheConfig создает конфигурацию локального кеша whoami из переменных окружения
+func NewWhoamiCacheConfig() (*whoamiCacheConfig, error) {
+	enabled := true
+	if enabledStr := os.Getenv(whoamiCacheEnabledEnv); enabledStr != "" {
+		parsed, err := strconv.ParseBool(enabledStr)
+		if err == nil {
+			enabled = parsed
+		}
+	}
+
+	ttl := 30 * time.Second // По умолчанию 30 секунд
+	if ttlStr := os.Getenv(whoamiCacheTTLEnv); ttlStr != "" {
+		parsed, err := time.ParseDuration(ttlStr)
+		if err == nil {
+			ttl = parsed
+		}
+	}
+
+	maxEntries := 10000
+	if maxEntriesStr := os.Getenv(whoamiCacheMaxEntriesEnv); maxEntriesStr != "" {
+		parsed, err := strconv.Atoi(maxEntriesStr)
+		if err == nil && parsed > 0 {
+			maxEntries = parsed
+		}
+	}
+
+	invalidationChannel := os.Getenv(whoamiCacheInvalidationChannelEnv)
+	if invalidationChannel == "" {
+		invalidationChannel = "iam:whoami-cache:invalidate"
+	}
+
+	return &whoamiCacheConfig{
+		enabled:             enabled,
+		ttl:                 ttl,
+		maxEntries:          maxEntries,
+		invalidationChannel: invalidationChannel,
+	}, nil
+}
+
+func (c *whoamiCacheConfig) Enabled() bool {
+	return c.enabled
+}
+
+func (c *whoamiCacheConfig) TTL() time.Duration {
+	return c.ttl
+}
+
+func (c *whoamiCacheConfig) MaxEntries() int {
+	return c.maxEntries
+}
+
+func (c *whoamiCacheConfig) InvalidationChannel() string {
+	return c.invalidationChannel
+}
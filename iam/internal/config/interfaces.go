@@ -1,6 +1,10 @@
 package config
 
-import "time"
+import (
+	"time"
+
+	"github.com/linemk/rocket-shop/iam/internal/config/env"
+)
 
 // LoggerConfig интерфейс конфигурации логгера
 type LoggerConfig interface {
@@ -37,3 +41,84 @@ type GRPCConfig interface {
 type SessionConfig interface {
 	TTL() time.Duration
 }
+
+// KafkaConfig интерфейс конфигурации Kafka
+type KafkaConfig interface {
+	Brokers() []string
+	AuthTokenTopic() string
+	// AuthAuditTopic топик событий аудита; IAM и публикует, и читает его в хранилище аудита
+	AuthAuditTopic() string
+	AuthAuditConsumerGroupID() string
+	// UserErasedTopic топик событий об удалении данных пользователя
+	UserErasedTopic() string
+}
+
+// MFAConfig интерфейс конфигурации двухфакторной аутентификации
+type MFAConfig interface {
+	Issuer() string
+	EncryptionKey() []byte
+	ChallengeTTL() time.Duration
+	MaxAttempts() int
+}
+
+// TokenConfig интерфейс конфигурации одноразовых токенов
+type TokenConfig interface {
+	PasswordResetTTL() time.Duration
+	EmailVerificationTTL() time.Duration
+}
+
+// OIDCConfig интерфейс конфигурации входа через внешних OIDC провайдеров
+type OIDCConfig interface {
+	Providers() []env.OIDCProvider
+	StateTTL() time.Duration
+	// AllowSignup разрешает создавать пользователя при первом входе, если email еще не зарегистрирован
+	AllowSignup() bool
+}
+
+// PasswordConfig интерфейс конфигурации хеширования паролей и парольной политики
+type PasswordConfig interface {
+	// Argon2Memory объем памяти argon2id в KiB
+	Argon2Memory() uint32
+	Argon2Iterations() uint32
+	Argon2Parallelism() uint8
+	MinLength() int
+	MaxLength() int
+	RequireLetter() bool
+	RequireDigit() bool
+	RequireSymbol() bool
+}
+
+// JWTConfig интерфейс конфигурации подписанных access токенов
+type JWTConfig interface {
+	Enabled() bool
+	Issuer() string
+	AccessTTL() time.Duration
+	KeyRotationInterval() time.Duration
+	// KeyEncryptionKey ключ AES-256 для шифрования приватных ключей подписи в Redis
+	KeyEncryptionKey() []byte
+	// HTTPAddress адрес HTTP сервера с JWKS эндпоинтом
+	HTTPAddress() string
+}
+
+// MetricsConfig интерфейс конфигурации Prometheus метрик
+type MetricsConfig interface {
+	Port() int
+}
+
+// WhoamiCacheConfig интерфейс конфигурации локального кеша whoami
+type WhoamiCacheConfig interface {
+	Enabled() bool
+	TTL() time.Duration
+	MaxEntries() int
+	// InvalidationChannel Redis Pub/Sub канал для инвалидации кеша во всех инстансах IAM
+	InvalidationChannel() string
+}
+
+// UserDataConfig интерфейс конфигурации выгрузки и удаления данных пользователя
+type UserDataConfig interface {
+	// OrderHTTPURL базовый URL HTTP API OrderService
+	OrderHTTPURL() string
+	PaymentGRPCAddress() string
+	// RequestTimeout таймаут запроса к каждому сервису при сборе данных
+	RequestTimeout() time.Duration
+}
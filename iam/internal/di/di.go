@@ -1,45 +1,225 @@
 package di
 
 import (
+	"net/http"
+
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	authv3 "github.com/envoyproxy/go-control-plane/envoy/service/auth/v3"
 
 	"github.com/linemk/rocket-shop/iam/internal/api"
+	paymentClient "github.com/linemk/rocket-shop/iam/internal/client/grpc/payment/v1"
+	orderClient "github.com/linemk/rocket-shop/iam/internal/client/http/order/v1"
 	"github.com/linemk/rocket-shop/iam/internal/config"
+	iammetrics "github.com/linemk/rocket-shop/iam/internal/metrics"
+	apikeyrepo "github.com/linemk/rocket-shop/iam/internal/repository/api_key"
+	apikeysessionrepo "github.com/linemk/rocket-shop/iam/internal/repository/api_key_session"
+	auditeventrepo "github.com/linemk/rocket-shop/iam/internal/repository/audit_event"
+	identityrepo "github.com/linemk/rocket-shop/iam/internal/repository/identity"
+	mfachallengerepo "github.com/linemk/rocket-shop/iam/internal/repository/mfa_challenge"
+	oidcstaterepo "github.com/linemk/rocket-shop/iam/internal/repository/oidc_state"
 	sessionrepo "github.com/linemk/rocket-shop/iam/internal/repository/session"
+	signingkeyrepo "github.com/linemk/rocket-shop/iam/internal/repository/signing_key"
+	tokenrepo "github.com/linemk/rocket-shop/iam/internal/repository/token"
 	userrepo "github.com/linemk/rocket-shop/iam/internal/repository/user"
+	accesstokenservice "github.com/linemk/rocket-shop/iam/internal/service/access_token"
+	accountservice "github.com/linemk/rocket-shop/iam/internal/service/account"
+	apikeyservice "github.com/linemk/rocket-shop/iam/internal/service/api_key"
+	auditservice "github.com/linemk/rocket-shop/iam/internal/service/audit"
 	authservice "github.com/linemk/rocket-shop/iam/internal/service/auth"
+	"github.com/linemk/rocket-shop/iam/internal/service/consumer/audit_consumer"
+	mfaservice "github.com/linemk/rocket-shop/iam/internal/service/mfa"
+	oidcservice "github.com/linemk/rocket-shop/iam/internal/service/oidc"
+	passwordservice "github.com/linemk/rocket-shop/iam/internal/service/password"
+	"github.com/linemk/rocket-shop/iam/internal/service/producer/audit_producer"
+	"github.com/linemk/rocket-shop/iam/internal/service/producer/token_producer"
+	"github.com/linemk/rocket-shop/iam/internal/service/producer/user_erased_producer"
 	userservice "github.com/linemk/rocket-shop/iam/internal/service/user"
+	userdataservice "github.com/linemk/rocket-shop/iam/internal/service/user_data"
+	whoamicacheservice "github.com/linemk/rocket-shop/iam/internal/service/whoami_cache"
 	"github.com/linemk/rocket-shop/platform/pkg/cache"
+	platformKafka "github.com/linemk/rocket-shop/platform/pkg/kafka"
+	"github.com/linemk/rocket-shop/platform/pkg/logger"
+	prommetrics "github.com/linemk/rocket-shop/platform/pkg/prometheus"
 	authv1 "github.com/linemk/rocket-shop/shared/pkg/proto/auth/v1"
 	userv1 "github.com/linemk/rocket-shop/shared/pkg/proto/user/v1"
 )
 
 type Container struct {
-	UserRepo        userrepo.Repository
-	SessionRepo     sessionrepo.Repository
-	UserService     userservice.Service
-	AuthService     authservice.Service
-	UserHandler     userv1.UserServiceServer
-	AuthHandler     authv1.AuthServiceServer
-	ExtAuthzHandler authv3.AuthorizationServer
+	UserRepo           userrepo.Repository
+	SessionRepo        sessionrepo.Repository
+	TokenRepo          tokenrepo.Repository
+	MFAChallengeRepo   mfachallengerepo.Repository
+	SigningKeyRepo     signingkeyrepo.Repository
+	APIKeyRepo         apikeyrepo.Repository
+	APIKeySessionRepo  apikeysessionrepo.Repository
+	AuditEventRepo     auditeventrepo.Repository
+	IdentityRepo       identityrepo.Repository
+	OIDCStateRepo      oidcstaterepo.Repository
+	TokenProducer      token_producer.Producer
+	AuditProducer      audit_producer.Producer
+	AuditConsumer      *audit_consumer.Consumer
+	UserErasedProducer user_erased_producer.Producer
+	UserService        userservice.Service
+	UserDataService    userdataservice.Service
+	AuthService        authservice.Service
+	AccountService     accountservice.Service
+	MFAService         mfaservice.Service
+	PasswordService    passwordservice.Service
+	OIDCService        oidcservice.Service
+	AccessTokenSvc     accesstokenservice.Service
+	APIKeyService      apikeyservice.Service
+	AuditService       auditservice.Service
+	WhoamiCache        whoamicacheservice.Service
+	Metrics            *prommetrics.Metrics
+	UserHandler        userv1.UserServiceServer
+	AuthHandler        authv1.AuthServiceServer
+	ExtAuthzHandler    authv3.AuthorizationServer
+	JWKSHandler        http.Handler
 }
 
-func New(db *pgxpool.Pool, cacheClient cache.Client) *Container {
+func New(
+	db *pgxpool.Pool,
+	cacheClient cache.Client,
+	authTokenProducer platformKafka.Producer,
+	authAuditProducer platformKafka.Producer,
+	authAuditConsumer platformKafka.Consumer,
+	userErasedKafkaProducer platformKafka.Producer,
+	orderClient orderClient.OrderClient,
+	paymentClient paymentClient.PaymentClient,
+) *Container {
 	userRepository := userrepo.NewRepository(db)
 	sessionRepository := sessionrepo.NewRepository(cacheClient)
+	tokenRepository := tokenrepo.NewRepository(cacheClient)
+	mfaChallengeRepository := mfachallengerepo.NewRepository(cacheClient)
+	signingKeyRepository := signingkeyrepo.NewRepository(cacheClient, config.AppConfig().JWT.KeyEncryptionKey())
+	apiKeyRepository := apikeyrepo.NewRepository(db)
+	apiKeySessionRepository := apikeysessionrepo.NewRepository(cacheClient)
+	auditEventRepository := auditeventrepo.NewRepository(db)
+	identityRepository := identityrepo.NewRepository(db)
+	oidcStateRepository := oidcstaterepo.NewRepository(cacheClient)
+
+	tokenProducer := token_producer.NewProducer(authTokenProducer, logger.Logger())
+	auditProducer := audit_producer.NewProducer(authAuditProducer, logger.Logger())
+	userErasedProducer := user_erased_producer.NewProducer(userErasedKafkaProducer, logger.Logger())
+
+	auditSvc := auditservice.NewService(auditEventRepository, auditProducer)
+	auditConsumer := audit_consumer.NewConsumer(
+		authAuditConsumer,
+		audit_consumer.NewHandler(auditSvc, logger.Logger()),
+		logger.Logger(),
+	)
+
+	mfaSvc := mfaservice.NewService(userRepository, config.AppConfig().MFA)
+	passwordSvc := passwordservice.NewService(config.AppConfig().Password)
+	accessTokenSvc := accesstokenservice.NewService(signingKeyRepository, config.AppConfig().JWT)
+	authSvc := authservice.NewService(
+		userRepository,
+		sessionRepository,
+		mfaChallengeRepository,
+		mfaSvc,
+		passwordSvc,
+		accessTokenSvc,
+		auditSvc,
+		config.AppConfig().Session,
+		config.AppConfig().MFA,
+		config.AppConfig().JWT,
+		logger.Logger(),
+	)
+	oidcSvc := oidcservice.NewService(
+		userRepository,
+		identityRepository,
+		oidcStateRepository,
+		authSvc,
+		auditSvc,
+		config.AppConfig().OIDC,
+	)
 
-	userSvc := userservice.NewService(userRepository)
-	authSvc := authservice.NewService(userRepository, sessionRepository, config.AppConfig().Session)
+	prometheusMetrics := prommetrics.New()
+	whoamiCache := whoamicacheservice.NewService(
+		authSvc,
+		cacheClient.PubSubOperator(),
+		newWhoamiCacheMetrics(prometheusMetrics),
+		config.AppConfig().WhoamiCache,
+		logger.Logger(),
+	)
+
+	apiKeySvc := apikeyservice.NewService(apiKeyRepository, apiKeySessionRepository, sessionRepository, userRepository, whoamiCache)
+	userSvc := userservice.NewService(userRepository, sessionRepository, passwordSvc, auditSvc, whoamiCache)
+	accountSvc := accountservice.NewService(
+		userRepository,
+		sessionRepository,
+		tokenRepository,
+		tokenProducer,
+		passwordSvc,
+		auditSvc,
+		whoamiCache,
+		config.AppConfig().Token,
+	)
+	userDataSvc := userdataservice.NewService(
+		userRepository,
+		sessionRepository,
+		apiKeyRepository,
+		identityRepository,
+		auditEventRepository,
+		orderClient,
+		paymentClient,
+		userErasedProducer,
+		auditSvc,
+		whoamiCache,
+		config.AppConfig().UserData,
+	)
 
 	return &Container{
-		UserRepo:        userRepository,
-		SessionRepo:     sessionRepository,
-		UserService:     userSvc,
-		AuthService:     authSvc,
-		UserHandler:     api.NewUserV1Handler(userSvc),
-		AuthHandler:     api.NewAuthV1Handler(authSvc),
-		ExtAuthzHandler: api.NewExtAuthzV1Handler(authSvc),
+		UserRepo:           userRepository,
+		SessionRepo:        sessionRepository,
+		TokenRepo:          tokenRepository,
+		MFAChallengeRepo:   mfaChallengeRepository,
+		SigningKeyRepo:     signingKeyRepository,
+		APIKeyRepo:         apiKeyRepository,
+		APIKeySessionRepo:  apiKeySessionRepository,
+		AuditEventRepo:     auditEventRepository,
+		IdentityRepo:       identityRepository,
+		OIDCStateRepo:      oidcStateRepository,
+		TokenProducer:      tokenProducer,
+		AuditProducer:      auditProducer,
+		AuditConsumer:      auditConsumer,
+		UserErasedProducer: userErasedProducer,
+		UserService:        userSvc,
+		UserDataService:    userDataSvc,
+		AuthService:        authSvc,
+		AccountService:     accountSvc,
+		MFAService:         mfaSvc,
+		PasswordService:    passwordSvc,
+		OIDCService:        oidcSvc,
+		AccessTokenSvc:     accessTokenSvc,
+		APIKeyService:      apiKeySvc,
+		AuditService:       auditSvc,
+		WhoamiCache:        whoamiCache,
+		Metrics:            prometheusMetrics,
+		UserHandler:        api.NewUserV1Handler(userSvc, accountSvc, userDataSvc),
+		AuthHandler:        api.NewAuthV1Handler(authSvc, mfaSvc, apiKeySvc, auditSvc, oidcSvc),
+		ExtAuthzHandler:    api.NewExtAuthzV1Handler(whoamiCache, apiKeySvc, auditSvc),
+		JWKSHandler:        api.NewJWKSHandler(accessTokenSvc),
+	}
+}
+
+func newWhoamiCacheMetrics(pm *prommetrics.Metrics) *iammetrics.WhoamiCacheMetrics {
+	return &iammetrics.WhoamiCacheMetrics{
+		Requests: pm.NewCounter(
+			"iam_whoami_cache_requests_total",
+			"Total number of whoami cache lookups in the ext_authz check path",
+			[]string{"result"},
+		),
+		Invalidations: pm.NewCounter(
+			"iam_whoami_cache_invalidations_total",
+			"Total number of whoami cache entries dropped by invalidation",
+			[]string{"kind"},
+		),
+		Evictions: pm.NewCounter(
+			"iam_whoami_cache_evictions_total",
+			"Total number of whoami cache entries evicted because the cache is full",
+			nil,
+		),
 	}
 }
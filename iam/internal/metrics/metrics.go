@@ -0,0 +1,13 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// WhoamiCacheMetrics holds metrics of the in-process whoami cache
+type WhoamiCacheMetrics struct {
+	// Requests counts cache lookups by result (hit, miss)
+	Requests *prometheus.CounterVec
+	// Invalidations counts invalidated entries by kind (session, user)
+	Invalidations *prometheus.CounterVec
+	// Evictions counts entries evicted because the cache is full
+	Evictions *prometheus.CounterVec
+}
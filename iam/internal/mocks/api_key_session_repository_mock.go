@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/linemk/rocket-shop/iam/internal/repository/api_key_session (interfaces: Repository)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAPIKeySessionRepository is a mock of Repository interface.
+type MockAPIKeySessionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPIKeySessionRepositoryMockRecorder
+}
+
+// MockAPIKeySessionRepositoryMockRecorder is the mock recorder for MockAPIKeySessionRepository.
+type MockAPIKeySessionRepositoryMockRecorder struct {
+	mock *MockAPIKeySessionRepository
+}
+
+// NewMockAPIKeySessionRepository creates a new mock instance.
+func NewMockAPIKeySessionRepository(ctrl *gomock.Controller) *MockAPIKeySessionRepository {
+	mock := &MockAPIKeySessionRepository{ctrl: ctrl}
+	mock.recorder = &MockAPIKeySessionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPIKeySessionRepository) EXPECT() *MockAPIKeySessionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Claim mocks base method.
+func (m *MockAPIKeySessionRepository) Claim(arg0 context.Context, arg1, arg2 string, arg3 time.Duration) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockAPIKeySessionRepositoryMockRecorder) Claim(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockAPIKeySessionRepository)(nil).Claim), arg0, arg1, arg2, arg3)
+}
+
+// Delete mocks base method.
+func (m *MockAPIKeySessionRepository) Delete(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockAPIKeySessionRepositoryMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAPIKeySessionRepository)(nil).Delete), arg0, arg1)
+}
+
+// Get mocks base method.
+func (m *MockAPIKeySessionRepository) Get(arg0 context.Context, arg1 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockAPIKeySessionRepositoryMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockAPIKeySessionRepository)(nil).Get), arg0, arg1)
+}
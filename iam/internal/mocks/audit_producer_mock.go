@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/linemk/rocket-shop/iam/internal/service/producer/audit_producer (interfaces: Producer)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/linemk/rocket-shop/iam/internal/model"
+)
+
+// MockAuditProducer is a mock of Producer interface.
+type MockAuditProducer struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditProducerMockRecorder
+}
+
+// MockAuditProducerMockRecorder is the mock recorder for MockAuditProducer.
+type MockAuditProducerMockRecorder struct {
+	mock *MockAuditProducer
+}
+
+// NewMockAuditProducer creates a new mock instance.
+func NewMockAuditProducer(ctrl *gomock.Controller) *MockAuditProducer {
+	mock := &MockAuditProducer{ctrl: ctrl}
+	mock.recorder = &MockAuditProducerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditProducer) EXPECT() *MockAuditProducerMockRecorder {
+	return m.recorder
+}
+
+// SendAuthAuditEvent mocks base method.
+func (m *MockAuditProducer) SendAuthAuditEvent(arg0 context.Context, arg1 *model.AuditEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendAuthAuditEvent", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendAuthAuditEvent indicates an expected call of SendAuthAuditEvent.
+func (mr *MockAuditProducerMockRecorder) SendAuthAuditEvent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendAuthAuditEvent", reflect.TypeOf((*MockAuditProducer)(nil).SendAuthAuditEvent), arg0, arg1)
+}
@@ -0,0 +1,13 @@
+package mocks
+
+//go:generate mockgen --package mocks --destination user_repository_mock.go --mock_names Repository=MockUserRepository github.com/linemk/rocket-shop/iam/internal/repository/user Repository
+//go:generate mockgen --package mocks --destination session_repository_mock.go --mock_names Repository=MockSessionRepository github.com/linemk/rocket-shop/iam/internal/repository/session Repository
+//go:generate mockgen --package mocks --destination api_key_repository_mock.go --mock_names Repository=MockAPIKeyRepository github.com/linemk/rocket-shop/iam/internal/repository/api_key Repository
+//go:generate mockgen --package mocks --destination api_key_session_repository_mock.go --mock_names Repository=MockAPIKeySessionRepository github.com/linemk/rocket-shop/iam/internal/repository/api_key_session Repository
+//go:generate mockgen --package mocks --destination audit_service_mock.go --mock_names Service=MockAuditService github.com/linemk/rocket-shop/iam/internal/service/audit Service
+//go:generate mockgen --package mocks --destination whoami_cache_service_mock.go --mock_names Service=MockWhoamiCacheService github.com/linemk/rocket-shop/iam/internal/service/whoami_cache Service
+//go:generate mockgen --package mocks --destination identity_repository_mock.go --mock_names Repository=MockIdentityRepository github.com/linemk/rocket-shop/iam/internal/repository/identity Repository
+//go:generate mockgen --package mocks --destination oidc_state_repository_mock.go --mock_names Repository=MockOIDCStateRepository github.com/linemk/rocket-shop/iam/internal/repository/oidc_state Repository
+//go:generate mockgen --package mocks --destination auth_service_mock.go --mock_names Service=MockAuthService github.com/linemk/rocket-shop/iam/internal/service/auth Service
+//go:generate mockgen --package mocks --destination audit_event_repository_mock.go --mock_names Repository=MockAuditEventRepository github.com/linemk/rocket-shop/iam/internal/repository/audit_event Repository
+//go:generate mockgen --package mocks --destination audit_producer_mock.go --mock_names Producer=MockAuditProducer github.com/linemk/rocket-shop/iam/internal/service/producer/audit_producer Producer
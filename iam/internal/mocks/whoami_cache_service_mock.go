@@ -0,0 +1,89 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/linemk/rocket-shop/iam/internal/service/whoami_cache (interfaces: Service)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/linemk/rocket-shop/iam/internal/model"
+)
+
+// MockWhoamiCacheService is a mock of Service interface.
+type MockWhoamiCacheService struct {
+	ctrl     *gomock.Controller
+	recorder *MockWhoamiCacheServiceMockRecorder
+}
+
+// MockWhoamiCacheServiceMockRecorder is the mock recorder for MockWhoamiCacheService.
+type MockWhoamiCacheServiceMockRecorder struct {
+	mock *MockWhoamiCacheService
+}
+
+// NewMockWhoamiCacheService creates a new mock instance.
+func NewMockWhoamiCacheService(ctrl *gomock.Controller) *MockWhoamiCacheService {
+	mock := &MockWhoamiCacheService{ctrl: ctrl}
+	mock.recorder = &MockWhoamiCacheServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWhoamiCacheService) EXPECT() *MockWhoamiCacheServiceMockRecorder {
+	return m.recorder
+}
+
+// InvalidateSession mocks base method.
+func (m *MockWhoamiCacheService) InvalidateSession(arg0 context.Context, arg1 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "InvalidateSession", arg0, arg1)
+}
+
+// InvalidateSession indicates an expected call of InvalidateSession.
+func (mr *MockWhoamiCacheServiceMockRecorder) InvalidateSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateSession", reflect.TypeOf((*MockWhoamiCacheService)(nil).InvalidateSession), arg0, arg1)
+}
+
+// InvalidateUser mocks base method.
+func (m *MockWhoamiCacheService) InvalidateUser(arg0 context.Context, arg1 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "InvalidateUser", arg0, arg1)
+}
+
+// InvalidateUser indicates an expected call of InvalidateUser.
+func (mr *MockWhoamiCacheServiceMockRecorder) InvalidateUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateUser", reflect.TypeOf((*MockWhoamiCacheService)(nil).InvalidateUser), arg0, arg1)
+}
+
+// RunInvalidationListener mocks base method.
+func (m *MockWhoamiCacheService) RunInvalidationListener(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunInvalidationListener", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RunInvalidationListener indicates an expected call of RunInvalidationListener.
+func (mr *MockWhoamiCacheServiceMockRecorder) RunInvalidationListener(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunInvalidationListener", reflect.TypeOf((*MockWhoamiCacheService)(nil).RunInvalidationListener), arg0)
+}
+
+// Whoami mocks base method.
+func (m *MockWhoamiCacheService) Whoami(arg0 context.Context, arg1 string) (*model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Whoami", arg0, arg1)
+	ret0, _ := ret[0].(*model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Whoami indicates an expected call of Whoami.
+func (mr *MockWhoamiCacheServiceMockRecorder) Whoami(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Whoami", reflect.TypeOf((*MockWhoamiCacheService)(nil).Whoami), arg0, arg1)
+}
@@ -0,0 +1,23 @@
+package model
+
+import (
+	"crypto/ecdsa"
+	"time"
+)
+
+// AccessToken представляет подписанный JWT access токен
+type AccessToken struct {
+	Token     string
+	ExpiresAt time.Time
+}
+
+// SigningKey представляет ключ подписи access токенов
+type SigningKey struct {
+	KeyID      string
+	PrivateKey *ecdsa.PrivateKey
+	CreatedAt  time.Time
+	// RotateAt момент, после которого ключ больше не используется для подписи
+	RotateAt time.Time
+	// ExpiresAt момент, после которого ключ удаляется из JWKS
+	ExpiresAt time.Time
+}
@@ -0,0 +1,71 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+// Поддерживаемые области доступа API ключей
+const (
+	APIKeyScopeInventoryRead  = "inventory:read"
+	APIKeyScopeInventoryWrite = "inventory:write"
+	APIKeyScopeOrdersRead     = "orders:read"
+	APIKeyScopeOrdersWrite    = "orders:write"
+)
+
+// IsKnownAPIKeyScope проверяет, поддерживается ли область доступа API ключа
+func IsKnownAPIKeyScope(scope string) bool {
+	switch scope {
+	case APIKeyScopeInventoryRead, APIKeyScopeInventoryWrite, APIKeyScopeOrdersRead, APIKeyScopeOrdersWrite:
+		return true
+	default:
+		return false
+	}
+}
+
+// APIKey представляет API ключ для межсервисных клиентов.
+// Сам ключ не хранится: известен только его хеш и префикс для отображения
+type APIKey struct {
+	APIKeyUUID string
+	UserUUID   string
+	Name       string
+	KeyPrefix  string
+	KeyHash    string
+	Scopes     []string
+	// ExpiresAt nil для бессрочных ключей
+	ExpiresAt *time.Time
+	RevokedAt *time.Time
+	CreatedAt time.Time
+}
+
+// IsActive проверяет, что ключ не отозван и не истек
+func (k *APIKey) IsActive(now time.Time) bool {
+	if k.RevokedAt != nil {
+		return false
+	}
+
+	return k.ExpiresAt == nil || now.Before(*k.ExpiresAt)
+}
+
+// HasScope проверяет наличие у ключа области доступа
+func (k *APIKey) HasScope(scope string) bool {
+	for _, s := range k.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ScopesString возвращает области доступа ключа через запятую
+func (k *APIKey) ScopesString() string {
+	return strings.Join(k.Scopes, ",")
+}
+
+// CreatedAPIKey представляет только что созданный ключ вместе с его значением,
+// которое показывается пользователю один раз
+type CreatedAPIKey struct {
+	APIKey *APIKey
+	Key    string
+}
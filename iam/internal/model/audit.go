@@ -0,0 +1,43 @@
+package model
+
+import "time"
+
+// AuditEventType тип события аудита аутентификации
+type AuditEventType string
+
+const (
+	AuditEventLoginSucceeded AuditEventType = "login_succeeded"
+	AuditEventLoginFailed    AuditEventType = "login_failed"
+	AuditEventSessionCreated AuditEventType = "session_created"
+	AuditEventSessionRevoked AuditEventType = "session_revoked"
+	AuditEventAuthzDenied    AuditEventType = "authz_denied"
+	AuditEventDataExported   AuditEventType = "user_data_exported"
+	AuditEventUserErased     AuditEventType = "user_erased"
+)
+
+// ClientInfo описывает клиента, выполнившего запрос
+type ClientInfo struct {
+	IPAddress string
+	UserAgent string
+}
+
+// AuditEvent представляет событие аудита аутентификации и авторизации
+type AuditEvent struct {
+	EventUUID   string
+	EventType   AuditEventType
+	UserUUID    string
+	Login       string
+	SessionUUID string
+	Client      ClientInfo
+	Reason      string
+	Resource    string
+	OccurredAt  time.Time
+}
+
+// AuditEventFilter параметры выборки событий аудита
+type AuditEventFilter struct {
+	UserUUID   string
+	EventTypes []AuditEventType
+	Limit      int
+	Offset     int
+}
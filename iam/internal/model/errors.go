@@ -23,4 +23,89 @@ var (
 
 	// ErrInvalidUserUUID возвращается когда user UUID невалиден
 	ErrInvalidUserUUID = errors.New("invalid user UUID")
+
+	// ErrInvalidPassword возвращается когда текущий пароль указан неверно
+	ErrInvalidPassword = errors.New("invalid password")
+
+	// ErrWeakPassword возвращается когда пароль не соответствует парольной политике
+	ErrWeakPassword = errors.New("password does not satisfy password policy")
+
+	// ErrUnknownOIDCProvider возвращается когда OIDC провайдер не настроен
+	ErrUnknownOIDCProvider = errors.New("unknown oidc provider")
+
+	// ErrInvalidOIDCState возвращается когда state OIDC входа не найден, истек или выдан другому провайдеру
+	ErrInvalidOIDCState = errors.New("invalid or expired oidc state")
+
+	// ErrOIDCLoginFailed возвращается когда провайдер не подтвердил вход (обмен кода или проверка ID токена)
+	ErrOIDCLoginFailed = errors.New("oidc login failed")
+
+	// ErrOIDCEmailNotVerified возвращается когда провайдер не подтвердил email пользователя
+	ErrOIDCEmailNotVerified = errors.New("oidc email is not verified")
+
+	// ErrOIDCAccountNotLinkable возвращается когда email занят пользователем с неподтвержденным email
+	// или регистрация через OIDC отключена
+	ErrOIDCAccountNotLinkable = errors.New("oidc account cannot be linked")
+
+	// ErrIdentityNotFound возвращается когда внешняя учетная запись не привязана ни к одному пользователю
+	ErrIdentityNotFound = errors.New("identity not found")
+
+	// ErrUnsupportedPasswordHash возвращается когда формат сохраненного хеша пароля не распознан
+	ErrUnsupportedPasswordHash = errors.New("unsupported password hash")
+
+	// ErrUnknownNotificationProvider возвращается когда провайдер уведомлений не поддерживается
+	ErrUnknownNotificationProvider = errors.New("unknown notification provider")
+
+	// ErrInvalidToken возвращается когда одноразовый токен не найден, истек или уже использован
+	ErrInvalidToken = errors.New("invalid or expired token")
+
+	// ErrEmailAlreadyVerified возвращается когда email пользователя уже подтвержден
+	ErrEmailAlreadyVerified = errors.New("email already verified")
+
+	// ErrEmptyEmail возвращается когда у пользователя не указан email
+	ErrEmptyEmail = errors.New("email is empty")
+
+	// ErrEmailAlreadyTaken возвращается когда email уже подтвержден другим пользователем
+	ErrEmailAlreadyTaken = errors.New("email already verified by another user")
+
+	// ErrMFAAlreadyEnabled возвращается когда двухфакторная аутентификация уже включена
+	ErrMFAAlreadyEnabled = errors.New("mfa already enabled")
+
+	// ErrMFANotEnabled возвращается когда двухфакторная аутентификация не включена
+	ErrMFANotEnabled = errors.New("mfa not enabled")
+
+	// ErrMFANotEnrolled возвращается когда TOTP секрет еще не сгенерирован
+	ErrMFANotEnrolled = errors.New("mfa not enrolled")
+
+	// ErrInvalidMFACode возвращается при неверном TOTP коде или коде восстановления
+	ErrInvalidMFACode = errors.New("invalid mfa code")
+
+	// ErrMFAChallengeNotFound возвращается когда MFA челлендж не найден или истек
+	ErrMFAChallengeNotFound = errors.New("mfa challenge not found")
+
+	// ErrTooManyMFAAttempts возвращается когда исчерпано количество попыток ввода кода
+	ErrTooManyMFAAttempts = errors.New("too many mfa attempts")
+
+	// ErrAccessTokensDisabled возвращается когда выпуск access токенов выключен в конфигурации
+	ErrAccessTokensDisabled = errors.New("access tokens are disabled")
+
+	// ErrAPIKeyNotFound возвращается когда API ключ не найден
+	ErrAPIKeyNotFound = errors.New("api key not found")
+
+	// ErrInvalidAPIKey возвращается когда API ключ неизвестен, отозван или истек
+	ErrInvalidAPIKey = errors.New("invalid api key")
+
+	// ErrUnknownAPIKeyScope возвращается при неизвестной области доступа API ключа
+	ErrUnknownAPIKeyScope = errors.New("unknown api key scope")
+
+	// ErrEmptyAPIKeyScopes возвращается когда для API ключа не указаны области доступа
+	ErrEmptyAPIKeyScopes = errors.New("api key scopes are empty")
+
+	// ErrInvalidAPIKeyExpiration возвращается когда срок действия API ключа уже в прошлом
+	ErrInvalidAPIKeyExpiration = errors.New("api key expiration is in the past")
+
+	// ErrInvalidPageToken возвращается при некорректном токене страницы
+	ErrInvalidPageToken = errors.New("invalid page token")
+
+	// ErrUserDataUnavailable возвращается когда сервис с данными пользователя недоступен при выгрузке
+	ErrUserDataUnavailable = errors.New("user data source unavailable")
 )
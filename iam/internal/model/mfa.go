@@ -0,0 +1,25 @@
+package model
+
+import "time"
+
+// MFAChallenge представляет незавершенный вход, ожидающий второй фактор
+type MFAChallenge struct {
+	ChallengeUUID string
+	UserUUID      string
+	CreatedAt     time.Time
+	ExpiresAt     time.Time
+}
+
+// LoginResult представляет результат входа: либо сессию, либо MFA челлендж.
+// AccessToken заполняется вместе с сессией, если выпуск access токенов включен
+type LoginResult struct {
+	Session      *Session
+	MFAChallenge *MFAChallenge
+	AccessToken  *AccessToken
+}
+
+// TOTPEnrollment представляет данные для добавления TOTP секрета в приложение-аутентификатор
+type TOTPEnrollment struct {
+	Secret string
+	URI    string
+}
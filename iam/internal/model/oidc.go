@@ -0,0 +1,42 @@
+package model
+
+import "time"
+
+// OIDCState представляет незавершенный вход через OIDC провайдера.
+// Хранится до возврата пользователя на redirect_uri и используется один раз
+type OIDCState struct {
+	State    string
+	Provider string
+	Nonce    string
+	// CodeVerifier PKCE верификатор для обмена кода авторизации
+	CodeVerifier string
+	// BindingHash хеш значения, выданного браузеру в cookie при старте входа.
+	// Без него ответ провайдера можно было бы подсунуть чужому браузеру (login CSRF)
+	BindingHash string
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+}
+
+// OIDCLoginStart результат начала входа через OIDC провайдера
+type OIDCLoginStart struct {
+	AuthorizationURL string
+	// Binding значение для cookie браузера; без него вход завершить нельзя
+	Binding   string
+	ExpiresAt time.Time
+}
+
+// ExternalIdentity представляет учетную запись внешнего провайдера, привязанную к пользователю
+type ExternalIdentity struct {
+	Provider  string
+	Subject   string
+	UserUUID  string
+	Email     string
+	CreatedAt time.Time
+}
+
+// OIDCClaims данные пользователя из проверенного ID токена
+type OIDCClaims struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+}
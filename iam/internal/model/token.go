@@ -0,0 +1,30 @@
+package model
+
+// TokenPurpose определяет назначение одноразового токена
+type TokenPurpose string
+
+const (
+	// TokenPurposePasswordReset токен для сброса пароля
+	TokenPurposePasswordReset TokenPurpose = "password_reset"
+
+	// TokenPurposeEmailVerification токен для подтверждения email
+	TokenPurposeEmailVerification TokenPurpose = "email_verification"
+)
+
+// Token представляет одноразовый токен, выданный пользователю
+type Token struct {
+	Token    string
+	Purpose  TokenPurpose
+	UserUUID string
+	Email    string
+}
+
+// AuthTokenIssuedEvent представляет событие о выпуске одноразового токена
+type AuthTokenIssuedEvent struct {
+	EventUUID string
+	UserUUID  string
+	Email     string
+	Purpose   TokenPurpose
+	Token     string
+	TTLSec    int64
+}
@@ -1,5 +1,25 @@
 package model
 
+// RoleUser роль, назначаемая пользователю при регистрации
+const RoleUser = "user"
+
+// Поддерживаемые провайдеры уведомлений
+const (
+	NotificationProviderTelegram = "telegram"
+	NotificationProviderEmail    = "email"
+	NotificationProviderPush     = "push"
+)
+
+// IsKnownNotificationProvider проверяет, поддерживается ли провайдер уведомлений
+func IsKnownNotificationProvider(providerName string) bool {
+	switch providerName {
+	case NotificationProviderTelegram, NotificationProviderEmail, NotificationProviderPush:
+		return true
+	default:
+		return false
+	}
+}
+
 // NotificationMethod представляет метод уведомления пользователя
 type NotificationMethod struct {
 	ProviderName string
@@ -12,5 +32,12 @@ type User struct {
 	Login               string
 	PasswordHash        string
 	Email               string
+	EmailVerified       bool
 	NotificationMethods []NotificationMethod
+	// TOTPSecret зашифрованный TOTP секрет
+	TOTPSecret  []byte
+	TOTPEnabled bool
+	// RecoveryCodeHashes хеши неиспользованных кодов восстановления
+	RecoveryCodeHashes []string
+	Roles              []string
 }
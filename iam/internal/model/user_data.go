@@ -0,0 +1,31 @@
+package model
+
+import "time"
+
+// UserOrder заказ пользователя, полученный из OrderService для выгрузки данных
+type UserOrder struct {
+	OrderUUID       string
+	PartUUIDs       []string
+	TotalPrice      float64
+	TransactionUUID string
+	PaymentMethod   string
+	Status          string
+}
+
+// UserPayment транзакция пользователя, полученная из PaymentService для выгрузки данных
+type UserPayment struct {
+	TransactionUUID string
+	OrderUUID       string
+	PaymentMethod   string
+	Amount          float64
+	Status          string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// UserErasedEvent представляет событие об удалении персональных данных пользователя
+type UserErasedEvent struct {
+	EventUUID string
+	UserUUID  string
+	ErasedAt  time.Time
+}
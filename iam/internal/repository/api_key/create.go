@@ -0,0 +1,58 @@
+package api_key
+
+import (
+	"context"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/linemk/rocket-shop/iam/internal/model"
+	repoConverter "github.com/linemk/rocket-shop/iam/internal/repository/converter"
+	repoModel "github.com/linemk/rocket-shop/iam/internal/repository/model"
+)
+
+func (r *repository) Create(ctx context.Context, key *model.APIKey) error {
+	repoKey := repoConverter.ToRepoAPIKey(key)
+	if repoKey == nil {
+		return errors.New("failed to convert api key to repository model")
+	}
+
+	scopesJSON, err := repoModel.APIKeyScopesToJSON(repoKey.Scopes)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal api key scopes")
+	}
+
+	query, args, err := sq.Insert("api_keys").
+		PlaceholderFormat(sq.Dollar).
+		Columns(
+			"api_key_uuid",
+			"user_uuid",
+			"name",
+			"key_prefix",
+			"key_hash",
+			"scopes",
+			"expires_at",
+			"created_at",
+		).
+		Values(
+			repoKey.APIKeyUUID,
+			repoKey.UserUUID,
+			repoKey.Name,
+			repoKey.KeyPrefix,
+			repoKey.KeyHash,
+			scopesJSON,
+			repoKey.ExpiresAt,
+			repoKey.CreatedAt,
+		).
+		ToSql()
+	if err != nil {
+		return errors.Wrap(err, "failed to build insert query")
+	}
+
+	_, err = r.db.Exec(ctx, query, args...)
+	if err != nil {
+		return errors.Wrap(err, "failed to insert api key")
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package api_key
+
+import (
+	"context"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+
+	"github.com/linemk/rocket-shop/iam/internal/model"
+	repoConverter "github.com/linemk/rocket-shop/iam/internal/repository/converter"
+	repoModel "github.com/linemk/rocket-shop/iam/internal/repository/model"
+)
+
+var apiKeyColumns = []string{
+	"api_key_uuid",
+	"user_uuid",
+	"name",
+	"key_prefix",
+	"key_hash",
+	"scopes",
+	"expires_at",
+	"revoked_at",
+	"created_at",
+}
+
+func (r *repository) GetByHash(ctx context.Context, keyHash string) (*model.APIKey, error) {
+	query, args, err := sq.Select(apiKeyColumns...).
+		From("api_keys").
+		Where(sq.Eq{"key_hash": keyHash}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build select query")
+	}
+
+	key, err := scanAPIKey(r.db.QueryRow(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, model.ErrAPIKeyNotFound
+		}
+		return nil, err
+	}
+
+	return key, nil
+}
+
+func scanAPIKey(row pgx.Row) (*model.APIKey, error) {
+	var repoKey repoModel.APIKey
+	var scopesJSON []byte
+
+	err := row.Scan(
+		&repoKey.APIKeyUUID,
+		&repoKey.UserUUID,
+		&repoKey.Name,
+		&repoKey.KeyPrefix,
+		&repoKey.KeyHash,
+		&scopesJSON,
+		&repoKey.ExpiresAt,
+		&repoKey.RevokedAt,
+		&repoKey.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, err
+		}
+		return nil, errors.Wrap(err, "failed to scan api key")
+	}
+
+	repoKey.Scopes, err = repoModel.APIKeyScopesFromJSON(scopesJSON)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal api key scopes")
+	}
+
+	return repoConverter.ToInternalAPIKey(&repoKey), nil
+}
@@ -0,0 +1,43 @@
+package api_key
+
+import (
+	"context"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/linemk/rocket-shop/iam/internal/model"
+)
+
+func (r *repository) ListByUser(ctx context.Context, userUUID string) ([]*model.APIKey, error) {
+	query, args, err := sq.Select(apiKeyColumns...).
+		From("api_keys").
+		Where(sq.Eq{"user_uuid": userUUID}).
+		OrderBy("created_at DESC").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build select query")
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query api keys")
+	}
+	defer rows.Close()
+
+	var keys []*model.APIKey
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to iterate api keys")
+	}
+
+	return keys, nil
+}
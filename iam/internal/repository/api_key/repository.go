@@ -0,0 +1,27 @@
+package api_key
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/linemk/rocket-shop/iam/internal/model"
+)
+
+type Repository interface {
+	Create(ctx context.Context, key *model.APIKey) error
+	GetByHash(ctx context.Context, keyHash string) (*model.APIKey, error)
+	ListByUser(ctx context.Context, userUUID string) ([]*model.APIKey, error)
+	Revoke(ctx context.Context, userUUID, apiKeyUUID string) error
+	RevokeAllByUser(ctx context.Context, userUUID string) error
+}
+
+type repository struct {
+	db *pgxpool.Pool
+}
+
+func NewRepository(db *pgxpool.Pool) Repository {
+	return &repository{
+		db: db,
+	}
+}
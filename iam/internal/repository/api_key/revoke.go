@@ -0,0 +1,38 @@
+package api_key
+
+import (
+	"context"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/linemk/rocket-shop/iam/internal/model"
+)
+
+// Revoke отзывает ключ пользователя; уже отозванный ключ считается не найденным
+func (r *repository) Revoke(ctx context.Context, userUUID, apiKeyUUID string) error {
+	query, args, err := sq.Update("api_keys").
+		PlaceholderFormat(sq.Dollar).
+		Set("revoked_at", time.Now()).
+		Where(sq.Eq{
+			"api_key_uuid": apiKeyUUID,
+			"user_uuid":    userUUID,
+			"revoked_at":   nil,
+		}).
+		ToSql()
+	if err != nil {
+		return errors.Wrap(err, "failed to build update query")
+	}
+
+	tag, err := r.db.Exec(ctx, query, args...)
+	if err != nil {
+		return errors.Wrap(err, "failed to revoke api key")
+	}
+
+	if tag.RowsAffected() == 0 {
+		return model.ErrAPIKeyNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,31 @@
+package api_key
+
+import (
+	"context"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+)
+
+// RevokeAllByUser отзывает все действующие ключи пользователя
+func (r *repository) RevokeAllByUser(ctx context.Context, userUUID string) error {
+	query, args, err := sq.Update("api_keys").
+		PlaceholderFormat(sq.Dollar).
+		Set("revoked_at", time.Now()).
+		Where(sq.Eq{
+			"user_uuid":  userUUID,
+			"revoked_at": nil,
+		}).
+		ToSql()
+	if err != nil {
+		return errors.Wrap(err, "failed to build update query")
+	}
+
+	_, err = r.db.Exec(ctx, query, args...)
+	if err != nil {
+		return errors.Wrap(err, "failed to revoke api keys")
+	}
+
+	return nil
+}
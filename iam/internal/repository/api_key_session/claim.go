@@ -0,0 +1,20 @@
+package api_key_session
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func (r *repository) Claim(ctx context.Context, apiKeyUUID, sessionUUID string, ttl time.Duration) (bool, error) {
+	key := fmt.Sprintf("%s%s", apiKeySessionKeyPrefix, apiKeyUUID)
+
+	claimed, err := r.cache.SetNX(ctx, key, []byte(sessionUUID), ttl)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to claim api key session in Redis")
+	}
+
+	return claimed, nil
+}
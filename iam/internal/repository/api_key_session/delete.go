@@ -0,0 +1,19 @@
+package api_key_session
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+func (r *repository) Delete(ctx context.Context, apiKeyUUID string) error {
+	key := fmt.Sprintf("%s%s", apiKeySessionKeyPrefix, apiKeyUUID)
+
+	err := r.cache.Del(ctx, key)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete api key session from Redis")
+	}
+
+	return nil
+}
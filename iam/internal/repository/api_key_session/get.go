@@ -0,0 +1,27 @@
+package api_key_session
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/linemk/rocket-shop/iam/internal/model"
+	"github.com/linemk/rocket-shop/platform/pkg/cache/redis"
+)
+
+const apiKeySessionKeyPrefix = "api_key_session:"
+
+func (r *repository) Get(ctx context.Context, apiKeyUUID string) (string, error) {
+	key := fmt.Sprintf("%s%s", apiKeySessionKeyPrefix, apiKeyUUID)
+
+	sessionUUID, err := r.cache.Get(ctx, key)
+	if err != nil {
+		if errors.Is(err, redis.ErrKeyNotFound) {
+			return "", model.ErrSessionNotFound
+		}
+		return "", errors.Wrap(err, "failed to get api key session from Redis")
+	}
+
+	return string(sessionUUID), nil
+}
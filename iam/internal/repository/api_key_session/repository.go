@@ -0,0 +1,28 @@
+package api_key_session
+
+import (
+	"context"
+	"time"
+
+	"github.com/linemk/rocket-shop/platform/pkg/cache"
+)
+
+// Repository хранит сессию, выпущенную для API ключа, чтобы не создавать новую на каждый запрос
+type Repository interface {
+	// Get возвращает UUID сессии API ключа или model.ErrSessionNotFound
+	Get(ctx context.Context, apiKeyUUID string) (string, error)
+	// Claim закрепляет сессию за ключом, только если у ключа еще нет сессии; false означает,
+	// что сессию ключа уже закрепил параллельный запрос
+	Claim(ctx context.Context, apiKeyUUID, sessionUUID string, ttl time.Duration) (bool, error)
+	Delete(ctx context.Context, apiKeyUUID string) error
+}
+
+type repository struct {
+	cache cache.Client
+}
+
+func NewRepository(cache cache.Client) Repository {
+	return &repository{
+		cache: cache,
+	}
+}
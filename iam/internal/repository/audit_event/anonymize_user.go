@@ -0,0 +1,30 @@
+package audit_event
+
+import (
+	"context"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+)
+
+// AnonymizeUser очищает логин и данные клиента в событиях пользователя.
+// Сами события остаются в хранилище аудита
+func (r *repository) AnonymizeUser(ctx context.Context, userUUID string) error {
+	query, args, err := sq.Update("auth_audit_events").
+		PlaceholderFormat(sq.Dollar).
+		Set("login", "").
+		Set("ip_address", "").
+		Set("user_agent", "").
+		Where(sq.Eq{"user_uuid": userUUID}).
+		ToSql()
+	if err != nil {
+		return errors.Wrap(err, "failed to build update query")
+	}
+
+	_, err = r.db.Exec(ctx, query, args...)
+	if err != nil {
+		return errors.Wrap(err, "failed to anonymize audit events")
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package audit_event
+
+import (
+	"context"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/linemk/rocket-shop/iam/internal/model"
+	repoConverter "github.com/linemk/rocket-shop/iam/internal/repository/converter"
+)
+
+func (r *repository) Create(ctx context.Context, event *model.AuditEvent) error {
+	repoEvent := repoConverter.ToRepoAuditEvent(event)
+	if repoEvent == nil {
+		return errors.New("failed to convert audit event to repository model")
+	}
+
+	query, args, err := sq.Insert("auth_audit_events").
+		PlaceholderFormat(sq.Dollar).
+		Columns(
+			"event_uuid",
+			"event_type",
+			"user_uuid",
+			"login",
+			"session_uuid",
+			"ip_address",
+			"user_agent",
+			"reason",
+			"resource",
+			"occurred_at",
+		).
+		Values(
+			repoEvent.EventUUID,
+			repoEvent.EventType,
+			repoEvent.UserUUID,
+			repoEvent.Login,
+			repoEvent.SessionUUID,
+			repoEvent.IPAddress,
+			repoEvent.UserAgent,
+			repoEvent.Reason,
+			repoEvent.Resource,
+			repoEvent.OccurredAt,
+		).
+		// События доставляются из Kafka "как минимум один раз"
+		Suffix("ON CONFLICT (event_uuid) DO NOTHING").
+		ToSql()
+	if err != nil {
+		return errors.Wrap(err, "failed to build insert query")
+	}
+
+	_, err = r.db.Exec(ctx, query, args...)
+	if err != nil {
+		return errors.Wrap(err, "failed to insert audit event")
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package audit_event
+
+import (
+	"context"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/linemk/rocket-shop/iam/internal/model"
+	repoConverter "github.com/linemk/rocket-shop/iam/internal/repository/converter"
+	repoModel "github.com/linemk/rocket-shop/iam/internal/repository/model"
+)
+
+func (r *repository) List(ctx context.Context, filter model.AuditEventFilter) ([]*model.AuditEvent, error) {
+	builder := sq.Select(
+		"event_uuid",
+		"event_type",
+		"user_uuid",
+		"login",
+		"session_uuid",
+		"ip_address",
+		"user_agent",
+		"reason",
+		"resource",
+		"occurred_at",
+	).
+		From("auth_audit_events").
+		Where(sq.Eq{"user_uuid": filter.UserUUID}).
+		OrderBy("occurred_at DESC", "event_uuid").
+		Limit(uint64(filter.Limit)).   //nolint:gosec // limit проверен в сервисе
+		Offset(uint64(filter.Offset)). //nolint:gosec // offset проверен в сервисе
+		PlaceholderFormat(sq.Dollar)
+
+	if len(filter.EventTypes) > 0 {
+		eventTypes := make([]string, len(filter.EventTypes))
+		for i, eventType := range filter.EventTypes {
+			eventTypes[i] = string(eventType)
+		}
+		builder = builder.Where(sq.Eq{"event_type": eventTypes})
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build select query")
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query audit events")
+	}
+	defer rows.Close()
+
+	var events []*model.AuditEvent
+	for rows.Next() {
+		var repoEvent repoModel.AuditEvent
+
+		err = rows.Scan(
+			&repoEvent.EventUUID,
+			&repoEvent.EventType,
+			&repoEvent.UserUUID,
+			&repoEvent.Login,
+			&repoEvent.SessionUUID,
+			&repoEvent.IPAddress,
+			&repoEvent.UserAgent,
+			&repoEvent.Reason,
+			&repoEvent.Resource,
+			&repoEvent.OccurredAt,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to scan audit event")
+		}
+
+		events = append(events, repoConverter.ToInternalAuditEvent(&repoEvent))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to iterate audit events")
+	}
+
+	return events, nil
+}
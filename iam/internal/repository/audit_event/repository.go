@@ -0,0 +1,26 @@
+package audit_event
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/linemk/rocket-shop/iam/internal/model"
+)
+
+type Repository interface {
+	// Create сохраняет событие; повторное сохранение события с тем же UUID игнорируется
+	Create(ctx context.Context, event *model.AuditEvent) error
+	List(ctx context.Context, filter model.AuditEventFilter) ([]*model.AuditEvent, error)
+	AnonymizeUser(ctx context.Context, userUUID string) error
+}
+
+type repository struct {
+	db *pgxpool.Pool
+}
+
+func NewRepository(db *pgxpool.Pool) Repository {
+	return &repository{
+		db: db,
+	}
+}
@@ -0,0 +1,63 @@
+package converter
+
+import (
+	"database/sql"
+	"time"
+
+	internalModel "github.com/linemk/rocket-shop/iam/internal/model"
+	repoModel "github.com/linemk/rocket-shop/iam/internal/repository/model"
+)
+
+// ToInternalAPIKey конвертирует repository APIKey в internal APIKey
+func ToInternalAPIKey(key *repoModel.APIKey) *internalModel.APIKey {
+	if key == nil {
+		return nil
+	}
+
+	return &internalModel.APIKey{
+		APIKeyUUID: key.APIKeyUUID,
+		UserUUID:   key.UserUUID,
+		Name:       key.Name,
+		KeyPrefix:  key.KeyPrefix,
+		KeyHash:    key.KeyHash,
+		Scopes:     key.Scopes,
+		ExpiresAt:  fromNullTime(key.ExpiresAt),
+		RevokedAt:  fromNullTime(key.RevokedAt),
+		CreatedAt:  key.CreatedAt,
+	}
+}
+
+// ToRepoAPIKey конвертирует internal APIKey в repository APIKey
+func ToRepoAPIKey(key *internalModel.APIKey) *repoModel.APIKey {
+	if key == nil {
+		return nil
+	}
+
+	return &repoModel.APIKey{
+		APIKeyUUID: key.APIKeyUUID,
+		UserUUID:   key.UserUUID,
+		Name:       key.Name,
+		KeyPrefix:  key.KeyPrefix,
+		KeyHash:    key.KeyHash,
+		Scopes:     key.Scopes,
+		ExpiresAt:  toNullTime(key.ExpiresAt),
+		RevokedAt:  toNullTime(key.RevokedAt),
+		CreatedAt:  key.CreatedAt,
+	}
+}
+
+func fromNullTime(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+
+	return &t.Time
+}
+
+func toNullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+
+	return sql.NullTime{Time: *t, Valid: true}
+}
@@ -0,0 +1,50 @@
+package converter
+
+import (
+	"database/sql"
+
+	internalModel "github.com/linemk/rocket-shop/iam/internal/model"
+	repoModel "github.com/linemk/rocket-shop/iam/internal/repository/model"
+)
+
+// ToInternalAuditEvent конвертирует repository AuditEvent в internal AuditEvent
+func ToInternalAuditEvent(event *repoModel.AuditEvent) *internalModel.AuditEvent {
+	if event == nil {
+		return nil
+	}
+
+	return &internalModel.AuditEvent{
+		EventUUID:   event.EventUUID,
+		EventType:   internalModel.AuditEventType(event.EventType),
+		UserUUID:    event.UserUUID.String,
+		Login:       event.Login,
+		SessionUUID: event.SessionUUID,
+		Client: internalModel.ClientInfo{
+			IPAddress: event.IPAddress,
+			UserAgent: event.UserAgent,
+		},
+		Reason:     event.Reason,
+		Resource:   event.Resource,
+		OccurredAt: event.OccurredAt,
+	}
+}
+
+// ToRepoAuditEvent конвертирует internal AuditEvent в repository AuditEvent
+func ToRepoAuditEvent(event *internalModel.AuditEvent) *repoModel.AuditEvent {
+	if event == nil {
+		return nil
+	}
+
+	return &repoModel.AuditEvent{
+		EventUUID:   event.EventUUID,
+		EventType:   string(event.EventType),
+		UserUUID:    sql.NullString{String: event.UserUUID, Valid: event.UserUUID != ""},
+		Login:       event.Login,
+		SessionUUID: event.SessionUUID,
+		IPAddress:   event.Client.IPAddress,
+		UserAgent:   event.Client.UserAgent,
+		Reason:      event.Reason,
+		Resource:    event.Resource,
+		OccurredAt:  event.OccurredAt,
+	}
+}
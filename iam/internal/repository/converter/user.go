@@ -24,7 +24,12 @@ func ToInternalUser(user *repoModel.User) *internalModel.User {
 		Login:               user.Login,
 		PasswordHash:        user.PasswordHash,
 		Email:               user.Email,
+		EmailVerified:       user.EmailVerified,
 		NotificationMethods: notificationMethods,
+		TOTPSecret:          user.TOTPSecret,
+		TOTPEnabled:         user.TOTPEnabled,
+		RecoveryCodeHashes:  user.RecoveryCodeHashes,
+		Roles:               user.Roles,
 	}
 }
 
@@ -47,6 +52,11 @@ func ToRepoUser(user *internalModel.User) *repoModel.User {
 		Login:               user.Login,
 		PasswordHash:        user.PasswordHash,
 		Email:               user.Email,
+		EmailVerified:       user.EmailVerified,
 		NotificationMethods: notificationMethods,
+		TOTPSecret:          user.TOTPSecret,
+		TOTPEnabled:         user.TOTPEnabled,
+		RecoveryCodeHashes:  user.RecoveryCodeHashes,
+		Roles:               user.Roles,
 	}
 }
@@ -0,0 +1,40 @@
+package identity
+
+import (
+	"context"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/linemk/rocket-shop/iam/internal/model"
+)
+
+func (r *repository) Create(ctx context.Context, identity *model.ExternalIdentity) error {
+	query, args, err := sq.Insert("user_identities").
+		PlaceholderFormat(sq.Dollar).
+		Columns(
+			"provider",
+			"subject",
+			"user_uuid",
+			"email",
+			"created_at",
+		).
+		Values(
+			identity.Provider,
+			identity.Subject,
+			identity.UserUUID,
+			identity.Email,
+			identity.CreatedAt,
+		).
+		ToSql()
+	if err != nil {
+		return errors.Wrap(err, "failed to build insert query")
+	}
+
+	_, err = r.db.Exec(ctx, query, args...)
+	if err != nil {
+		return errors.Wrap(err, "failed to insert identity")
+	}
+
+	return nil
+}
@@ -0,0 +1,26 @@
+package identity
+
+import (
+	"context"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+)
+
+// DeleteByUser отвязывает от пользователя все внешние учетные записи
+func (r *repository) DeleteByUser(ctx context.Context, userUUID string) error {
+	query, args, err := sq.Delete("user_identities").
+		PlaceholderFormat(sq.Dollar).
+		Where(sq.Eq{"user_uuid": userUUID}).
+		ToSql()
+	if err != nil {
+		return errors.Wrap(err, "failed to build delete query")
+	}
+
+	_, err = r.db.Exec(ctx, query, args...)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete identities")
+	}
+
+	return nil
+}
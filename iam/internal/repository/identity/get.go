@@ -0,0 +1,52 @@
+package identity
+
+import (
+	"context"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/pkg/errors"
+
+	"github.com/linemk/rocket-shop/iam/internal/model"
+	repoModel "github.com/linemk/rocket-shop/iam/internal/repository/model"
+)
+
+func (r *repository) Get(ctx context.Context, provider, subject string) (*model.ExternalIdentity, error) {
+	query, args, err := sq.Select(
+		"provider",
+		"subject",
+		"user_uuid",
+		"email",
+		"created_at",
+	).
+		From("user_identities").
+		Where(sq.Eq{"provider": provider, "subject": subject}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build select query")
+	}
+
+	var repoIdentity repoModel.ExternalIdentity
+	err = r.db.QueryRow(ctx, query, args...).Scan(
+		&repoIdentity.Provider,
+		&repoIdentity.Subject,
+		&repoIdentity.UserUUID,
+		&repoIdentity.Email,
+		&repoIdentity.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, model.ErrIdentityNotFound
+		}
+		return nil, errors.Wrap(err, "failed to get identity")
+	}
+
+	return &model.ExternalIdentity{
+		Provider:  repoIdentity.Provider,
+		Subject:   repoIdentity.Subject,
+		UserUUID:  repoIdentity.UserUUID,
+		Email:     repoIdentity.Email,
+		CreatedAt: repoIdentity.CreatedAt,
+	}, nil
+}
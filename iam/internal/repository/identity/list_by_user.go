@@ -0,0 +1,64 @@
+package identity
+
+import (
+	"context"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/linemk/rocket-shop/iam/internal/model"
+	repoModel "github.com/linemk/rocket-shop/iam/internal/repository/model"
+)
+
+func (r *repository) ListByUser(ctx context.Context, userUUID string) ([]*model.ExternalIdentity, error) {
+	query, args, err := sq.Select(
+		"provider",
+		"subject",
+		"user_uuid",
+		"email",
+		"created_at",
+	).
+		From("user_identities").
+		Where(sq.Eq{"user_uuid": userUUID}).
+		OrderBy("created_at").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build select query")
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to query identities")
+	}
+	defer rows.Close()
+
+	var identities []*model.ExternalIdentity
+	for rows.Next() {
+		var repoIdentity repoModel.ExternalIdentity
+		err = rows.Scan(
+			&repoIdentity.Provider,
+			&repoIdentity.Subject,
+			&repoIdentity.UserUUID,
+			&repoIdentity.Email,
+			&repoIdentity.CreatedAt,
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to scan identity")
+		}
+
+		identities = append(identities, &model.ExternalIdentity{
+			Provider:  repoIdentity.Provider,
+			Subject:   repoIdentity.Subject,
+			UserUUID:  repoIdentity.UserUUID,
+			Email:     repoIdentity.Email,
+			CreatedAt: repoIdentity.CreatedAt,
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to iterate identities")
+	}
+
+	return identities, nil
+}
@@ -0,0 +1,26 @@
+package identity
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/linemk/rocket-shop/iam/internal/model"
+)
+
+type Repository interface {
+	Create(ctx context.Context, identity *model.ExternalIdentity) error
+	Get(ctx context.Context, provider, subject string) (*model.ExternalIdentity, error)
+	ListByUser(ctx context.Context, userUUID string) ([]*model.ExternalIdentity, error)
+	DeleteByUser(ctx context.Context, userUUID string) error
+}
+
+type repository struct {
+	db *pgxpool.Pool
+}
+
+func NewRepository(db *pgxpool.Pool) Repository {
+	return &repository{
+		db: db,
+	}
+}
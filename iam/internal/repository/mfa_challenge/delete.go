@@ -0,0 +1,20 @@
+package mfa_challenge
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+func (r *repository) Delete(ctx context.Context, challengeUUID string) error {
+	err := r.cache.Del(ctx,
+		fmt.Sprintf("%s%s", challengeKeyPrefix, challengeUUID),
+		fmt.Sprintf("%s%s", attemptsKeyPrefix, challengeUUID),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete mfa challenge from Redis")
+	}
+
+	return nil
+}
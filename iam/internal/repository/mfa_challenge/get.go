@@ -0,0 +1,38 @@
+package mfa_challenge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/linemk/rocket-shop/iam/internal/model"
+	repoModel "github.com/linemk/rocket-shop/iam/internal/repository/model"
+	"github.com/linemk/rocket-shop/platform/pkg/cache/redis"
+)
+
+func (r *repository) Get(ctx context.Context, challengeUUID string) (*model.MFAChallenge, error) {
+	key := fmt.Sprintf("%s%s", challengeKeyPrefix, challengeUUID)
+
+	challengeJSON, err := r.cache.Get(ctx, key)
+	if err != nil {
+		if errors.Is(err, redis.ErrKeyNotFound) {
+			return nil, model.ErrMFAChallengeNotFound
+		}
+		return nil, errors.Wrap(err, "failed to get mfa challenge from Redis")
+	}
+
+	var repoChallenge repoModel.MFAChallenge
+	err = json.Unmarshal(challengeJSON, &repoChallenge)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal mfa challenge")
+	}
+
+	return &model.MFAChallenge{
+		ChallengeUUID: repoChallenge.ChallengeUUID,
+		UserUUID:      repoChallenge.UserUUID,
+		CreatedAt:     repoChallenge.CreatedAt,
+		ExpiresAt:     repoChallenge.ExpiresAt,
+	}, nil
+}
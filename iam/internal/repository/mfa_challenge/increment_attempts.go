@@ -0,0 +1,37 @@
+package mfa_challenge
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/linemk/rocket-shop/iam/internal/model"
+)
+
+const attemptsKeyPrefix = "mfa_challenge_attempts:"
+
+// IncrementAttempts атомарно увеличивает счетчик попыток челленджа и возвращает его новое значение.
+// Счетчик хранится отдельным ключом, чтобы параллельные попытки не перезаписывали друг друга
+func (r *repository) IncrementAttempts(ctx context.Context, challenge *model.MFAChallenge) (int, error) {
+	ttl := time.Until(challenge.ExpiresAt)
+	if ttl <= 0 {
+		return 0, model.ErrMFAChallengeNotFound
+	}
+
+	key := fmt.Sprintf("%s%s", attemptsKeyPrefix, challenge.ChallengeUUID)
+
+	attempts, err := r.cache.Incr(ctx, key)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to increment mfa challenge attempts in Redis")
+	}
+
+	// Счетчик живет не дольше самого челленджа
+	err = r.cache.Expire(ctx, key, ttl)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to set mfa challenge attempts ttl in Redis")
+	}
+
+	return int(attempts), nil
+}
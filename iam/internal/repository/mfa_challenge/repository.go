@@ -0,0 +1,26 @@
+package mfa_challenge
+
+import (
+	"context"
+
+	"github.com/linemk/rocket-shop/iam/internal/model"
+	"github.com/linemk/rocket-shop/platform/pkg/cache"
+)
+
+type Repository interface {
+	Save(ctx context.Context, challenge *model.MFAChallenge) error
+	Get(ctx context.Context, challengeUUID string) (*model.MFAChallenge, error)
+	// IncrementAttempts атомарно учитывает попытку ввода кода и возвращает число попыток с ее учетом
+	IncrementAttempts(ctx context.Context, challenge *model.MFAChallenge) (int, error)
+	Delete(ctx context.Context, challengeUUID string) error
+}
+
+type repository struct {
+	cache cache.Client
+}
+
+func NewRepository(cache cache.Client) Repository {
+	return &repository{
+		cache: cache,
+	}
+}
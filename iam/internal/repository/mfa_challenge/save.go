@@ -0,0 +1,42 @@
+package mfa_challenge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/linemk/rocket-shop/iam/internal/model"
+	repoModel "github.com/linemk/rocket-shop/iam/internal/repository/model"
+)
+
+const challengeKeyPrefix = "mfa_challenge:"
+
+// Save сохраняет челлендж до момента его истечения (ExpiresAt)
+func (r *repository) Save(ctx context.Context, challenge *model.MFAChallenge) error {
+	ttl := time.Until(challenge.ExpiresAt)
+	if ttl <= 0 {
+		return model.ErrMFAChallengeNotFound
+	}
+
+	challengeJSON, err := json.Marshal(repoModel.MFAChallenge{
+		ChallengeUUID: challenge.ChallengeUUID,
+		UserUUID:      challenge.UserUUID,
+		CreatedAt:     challenge.CreatedAt,
+		ExpiresAt:     challenge.ExpiresAt,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal mfa challenge")
+	}
+
+	key := fmt.Sprintf("%s%s", challengeKeyPrefix, challenge.ChallengeUUID)
+
+	err = r.cache.Set(ctx, key, challengeJSON, ttl)
+	if err != nil {
+		return errors.Wrap(err, "failed to save mfa challenge to Redis")
+	}
+
+	return nil
+}
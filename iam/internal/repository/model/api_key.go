@@ -0,0 +1,38 @@
+package model
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+)
+
+// APIKey представляет API ключ в БД
+type APIKey struct {
+	APIKeyUUID string
+	UserUUID   string
+	Name       string
+	KeyPrefix  string
+	KeyHash    string
+	Scopes     []string
+	ExpiresAt  sql.NullTime
+	RevokedAt  sql.NullTime
+	CreatedAt  time.Time
+}
+
+// APIKeyScopesToJSON конвертирует области доступа API ключа в JSONB для PostgreSQL
+func APIKeyScopesToJSON(scopes []string) ([]byte, error) {
+	if len(scopes) == 0 {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(scopes)
+}
+
+// APIKeyScopesFromJSON парсит JSONB из PostgreSQL в области доступа API ключа
+func APIKeyScopesFromJSON(data []byte) ([]string, error) {
+	var scopes []string
+	if len(data) == 0 {
+		return scopes, nil
+	}
+	err := json.Unmarshal(data, &scopes)
+	return scopes, err
+}
@@ -0,0 +1,20 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+)
+
+// AuditEvent представляет событие аудита в БД
+type AuditEvent struct {
+	EventUUID   string
+	EventType   string
+	UserUUID    sql.NullString
+	Login       string
+	SessionUUID string
+	IPAddress   string
+	UserAgent   string
+	Reason      string
+	Resource    string
+	OccurredAt  time.Time
+}
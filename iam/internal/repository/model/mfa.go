@@ -0,0 +1,11 @@
+package model
+
+import "time"
+
+// MFAChallenge представляет MFA челлендж в Redis
+type MFAChallenge struct {
+	ChallengeUUID string
+	UserUUID      string
+	CreatedAt     time.Time
+	ExpiresAt     time.Time
+}
@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+// OIDCState представляет state OIDC входа в Redis
+type OIDCState struct {
+	Provider     string
+	Nonce        string
+	CodeVerifier string
+	BindingHash  string
+	CreatedAt    time.Time
+	ExpiresAt    time.Time
+}
+
+// ExternalIdentity представляет внешнюю учетную запись в БД
+type ExternalIdentity struct {
+	Provider  string
+	Subject   string
+	UserUUID  string
+	Email     string
+	CreatedAt time.Time
+}
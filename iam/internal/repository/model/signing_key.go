@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+// SigningKey представляет ключ подписи access токенов в Redis
+type SigningKey struct {
+	KeyID string `json:"key_id"`
+	// EncryptedPrivateKey приватный ключ в формате PKCS#8 DER, зашифрованный AES-GCM
+	EncryptedPrivateKey []byte    `json:"encrypted_private_key"`
+	CreatedAt           time.Time `json:"created_at"`
+	RotateAt            time.Time `json:"rotate_at"`
+	ExpiresAt           time.Time `json:"expires_at"`
+}
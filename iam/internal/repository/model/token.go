@@ -0,0 +1,7 @@
+package model
+
+// Token представляет одноразовый токен в Redis
+type Token struct {
+	UserUUID string
+	Email    string
+}
@@ -17,7 +17,12 @@ type User struct {
 	Login               string
 	PasswordHash        string
 	Email               string
+	EmailVerified       bool
 	NotificationMethods []NotificationMethod
+	TOTPSecret          []byte
+	TOTPEnabled         bool
+	RecoveryCodeHashes  []string
+	Roles               []string
 	CreatedAt           sql.NullTime
 	UpdatedAt           sql.NullTime
 }
@@ -30,6 +35,42 @@ func NotificationMethodsToJSON(methods []NotificationMethod) ([]byte, error) {
 	return json.Marshal(methods)
 }
 
+// RecoveryCodesToJSON конвертирует хеши кодов восстановления в JSONB для PostgreSQL
+func RecoveryCodesToJSON(codes []string) ([]byte, error) {
+	if len(codes) == 0 {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(codes)
+}
+
+// RecoveryCodesFromJSON парсит JSONB из PostgreSQL в хеши кодов восстановления
+func RecoveryCodesFromJSON(data []byte) ([]string, error) {
+	var codes []string
+	if len(data) == 0 {
+		return codes, nil
+	}
+	err := json.Unmarshal(data, &codes)
+	return codes, err
+}
+
+// RolesToJSON конвертирует роли пользователя в JSONB для PostgreSQL
+func RolesToJSON(roles []string) ([]byte, error) {
+	if len(roles) == 0 {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(roles)
+}
+
+// RolesFromJSON парсит JSONB из PostgreSQL в роли пользователя
+func RolesFromJSON(data []byte) ([]string, error) {
+	var roles []string
+	if len(data) == 0 {
+		return roles, nil
+	}
+	err := json.Unmarshal(data, &roles)
+	return roles, err
+}
+
 // NotificationMethodsFromJSON парсит JSONB из PostgreSQL в NotificationMethods
 func NotificationMethodsFromJSON(data []byte) ([]NotificationMethod, error) {
 	var methods []NotificationMethod
@@ -0,0 +1,41 @@
+package oidc_state
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/linemk/rocket-shop/iam/internal/model"
+	repoModel "github.com/linemk/rocket-shop/iam/internal/repository/model"
+	"github.com/linemk/rocket-shop/platform/pkg/cache/redis"
+)
+
+func (r *repository) Consume(ctx context.Context, state string) (*model.OIDCState, error) {
+	key := fmt.Sprintf("%s%s", stateKeyPrefix, state)
+
+	stateJSON, err := r.cache.GetDel(ctx, key)
+	if err != nil {
+		if errors.Is(err, redis.ErrKeyNotFound) {
+			return nil, model.ErrInvalidOIDCState
+		}
+		return nil, errors.Wrap(err, "failed to get oidc state from Redis")
+	}
+
+	var repoState repoModel.OIDCState
+	err = json.Unmarshal(stateJSON, &repoState)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal oidc state")
+	}
+
+	return &model.OIDCState{
+		State:        state,
+		Provider:     repoState.Provider,
+		Nonce:        repoState.Nonce,
+		CodeVerifier: repoState.CodeVerifier,
+		BindingHash:  repoState.BindingHash,
+		CreatedAt:    repoState.CreatedAt,
+		ExpiresAt:    repoState.ExpiresAt,
+	}, nil
+}
@@ -0,0 +1,24 @@
+package oidc_state
+
+import (
+	"context"
+
+	"github.com/linemk/rocket-shop/iam/internal/model"
+	"github.com/linemk/rocket-shop/platform/pkg/cache"
+)
+
+type Repository interface {
+	Save(ctx context.Context, state *model.OIDCState) error
+	// Consume возвращает state и сразу удаляет его, чтобы ответ провайдера нельзя было использовать повторно
+	Consume(ctx context.Context, state string) (*model.OIDCState, error)
+}
+
+type repository struct {
+	cache cache.Client
+}
+
+func NewRepository(cache cache.Client) Repository {
+	return &repository{
+		cache: cache,
+	}
+}
@@ -0,0 +1,44 @@
+package oidc_state
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/linemk/rocket-shop/iam/internal/model"
+	repoModel "github.com/linemk/rocket-shop/iam/internal/repository/model"
+)
+
+const stateKeyPrefix = "oidc_state:"
+
+// Save сохраняет state до момента его истечения (ExpiresAt)
+func (r *repository) Save(ctx context.Context, state *model.OIDCState) error {
+	ttl := time.Until(state.ExpiresAt)
+	if ttl <= 0 {
+		return model.ErrInvalidOIDCState
+	}
+
+	stateJSON, err := json.Marshal(repoModel.OIDCState{
+		Provider:     state.Provider,
+		Nonce:        state.Nonce,
+		CodeVerifier: state.CodeVerifier,
+		BindingHash:  state.BindingHash,
+		CreatedAt:    state.CreatedAt,
+		ExpiresAt:    state.ExpiresAt,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal oidc state")
+	}
+
+	key := fmt.Sprintf("%s%s", stateKeyPrefix, state.State)
+
+	err = r.cache.Set(ctx, key, stateJSON, ttl)
+	if err != nil {
+		return errors.Wrap(err, "failed to save oidc state to Redis")
+	}
+
+	return nil
+}
@@ -0,0 +1,31 @@
+package session
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+func (r *repository) DeleteUserSessions(ctx context.Context, userUUID string) error {
+	sessionUUIDs, err := r.GetUserSessions(ctx, userUUID)
+	if err != nil {
+		return err
+	}
+
+	for _, sessionUUID := range sessionUUIDs {
+		err = r.Delete(ctx, sessionUUID)
+		if err != nil {
+			return err
+		}
+	}
+
+	key := fmt.Sprintf("%s%s", userSessionsKeyPrefix, userUUID)
+
+	err = r.cache.Del(ctx, key)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete user sessions set")
+	}
+
+	return nil
+}
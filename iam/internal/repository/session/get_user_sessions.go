@@ -0,0 +1,19 @@
+package session
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+func (r *repository) GetUserSessions(ctx context.Context, userUUID string) ([]string, error) {
+	key := fmt.Sprintf("%s%s", userSessionsKeyPrefix, userUUID)
+
+	sessionUUIDs, err := r.cache.SetOperator().SMembers(ctx, key)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get user sessions")
+	}
+
+	return sessionUUIDs, nil
+}
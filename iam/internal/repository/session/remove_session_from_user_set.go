@@ -0,0 +1,19 @@
+package session
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+func (r *repository) RemoveSessionFromUserSet(ctx context.Context, userUUID, sessionUUID string) error {
+	key := fmt.Sprintf("%s%s", userSessionsKeyPrefix, userUUID)
+
+	err := r.cache.SetOperator().SRem(ctx, key, sessionUUID)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove session from user set")
+	}
+
+	return nil
+}
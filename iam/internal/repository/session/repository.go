@@ -13,6 +13,9 @@ type Repository interface {
 	Get(ctx context.Context, sessionUUID string) (*model.Session, error)
 	Delete(ctx context.Context, sessionUUID string) error
 	AddSessionToUserSet(ctx context.Context, userUUID, sessionUUID string) error
+	GetUserSessions(ctx context.Context, userUUID string) ([]string, error)
+	RemoveSessionFromUserSet(ctx context.Context, userUUID, sessionUUID string) error
+	DeleteUserSessions(ctx context.Context, userUUID string) error
 }
 
 type repository struct {
@@ -0,0 +1,77 @@
+package signing_key
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/linemk/rocket-shop/iam/internal/model"
+	repoModel "github.com/linemk/rocket-shop/iam/internal/repository/model"
+	"github.com/linemk/rocket-shop/platform/pkg/aesgcm"
+	"github.com/linemk/rocket-shop/platform/pkg/cache/redis"
+)
+
+func (r *repository) List(ctx context.Context) ([]*model.SigningKey, error) {
+	keyIDs, err := r.cache.SetOperator().SMembers(ctx, signingKeysSet)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get signing keys set")
+	}
+
+	keys := make([]*model.SigningKey, 0, len(keyIDs))
+	for _, keyID := range keyIDs {
+		keyJSON, err := r.cache.Get(ctx, fmt.Sprintf("%s%s", signingKeyPrefix, keyID))
+		if err != nil {
+			if errors.Is(err, redis.ErrKeyNotFound) {
+				// Ключ истек по TTL, убираем его из набора
+				if remErr := r.cache.SetOperator().SRem(ctx, signingKeysSet, keyID); remErr != nil {
+					return nil, errors.Wrap(remErr, "failed to remove expired signing key from set")
+				}
+				continue
+			}
+			return nil, errors.Wrap(err, "failed to get signing key from Redis")
+		}
+
+		var repoKey repoModel.SigningKey
+		err = json.Unmarshal(keyJSON, &repoKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal signing key")
+		}
+
+		// Ключи, сохраненные до шифрования, не используем: они будут заменены при ротации
+		if len(repoKey.EncryptedPrivateKey) == 0 {
+			if remErr := r.cache.SetOperator().SRem(ctx, signingKeysSet, keyID); remErr != nil {
+				return nil, errors.Wrap(remErr, "failed to remove unencrypted signing key from set")
+			}
+			continue
+		}
+
+		privateKeyDER, err := aesgcm.Decrypt(r.encryptionKey, repoKey.EncryptedPrivateKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decrypt private key")
+		}
+
+		parsed, err := x509.ParsePKCS8PrivateKey(privateKeyDER)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse private key")
+		}
+
+		privateKey, ok := parsed.(*ecdsa.PrivateKey)
+		if !ok {
+			return nil, errors.Errorf("unexpected private key type %T", parsed)
+		}
+
+		keys = append(keys, &model.SigningKey{
+			KeyID:      repoKey.KeyID,
+			PrivateKey: privateKey,
+			CreatedAt:  repoKey.CreatedAt,
+			RotateAt:   repoKey.RotateAt,
+			ExpiresAt:  repoKey.ExpiresAt,
+		})
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,27 @@
+package signing_key
+
+import (
+	"context"
+
+	"github.com/linemk/rocket-shop/iam/internal/model"
+	"github.com/linemk/rocket-shop/platform/pkg/cache"
+)
+
+type Repository interface {
+	Save(ctx context.Context, key *model.SigningKey) error
+	// List возвращает все неистекшие ключи подписи
+	List(ctx context.Context) ([]*model.SigningKey, error)
+}
+
+type repository struct {
+	cache cache.Client
+	// encryptionKey ключ шифрования приватных ключей: доступ на чтение Redis не должен позволять выпускать токены
+	encryptionKey []byte
+}
+
+func NewRepository(cache cache.Client, encryptionKey []byte) Repository {
+	return &repository{
+		cache:         cache,
+		encryptionKey: encryptionKey,
+	}
+}
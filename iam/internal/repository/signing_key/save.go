@@ -0,0 +1,61 @@
+package signing_key
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/linemk/rocket-shop/iam/internal/model"
+	repoModel "github.com/linemk/rocket-shop/iam/internal/repository/model"
+	"github.com/linemk/rocket-shop/platform/pkg/aesgcm"
+)
+
+const (
+	signingKeyPrefix = "signing_key:"
+	signingKeysSet   = "signing_keys"
+)
+
+// Save сохраняет ключ до момента его истечения (ExpiresAt) и добавляет его в набор ключей
+func (r *repository) Save(ctx context.Context, key *model.SigningKey) error {
+	ttl := time.Until(key.ExpiresAt)
+	if ttl <= 0 {
+		return errors.New("signing key is already expired")
+	}
+
+	privateKey, err := x509.MarshalPKCS8PrivateKey(key.PrivateKey)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal private key")
+	}
+
+	encryptedPrivateKey, err := aesgcm.Encrypt(r.encryptionKey, privateKey)
+	if err != nil {
+		return errors.Wrap(err, "failed to encrypt private key")
+	}
+
+	keyJSON, err := json.Marshal(repoModel.SigningKey{
+		KeyID:               key.KeyID,
+		EncryptedPrivateKey: encryptedPrivateKey,
+		CreatedAt:           key.CreatedAt,
+		RotateAt:            key.RotateAt,
+		ExpiresAt:           key.ExpiresAt,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal signing key")
+	}
+
+	err = r.cache.Set(ctx, fmt.Sprintf("%s%s", signingKeyPrefix, key.KeyID), keyJSON, ttl)
+	if err != nil {
+		return errors.Wrap(err, "failed to save signing key to Redis")
+	}
+
+	err = r.cache.SetOperator().SAdd(ctx, signingKeysSet, key.KeyID)
+	if err != nil {
+		return errors.Wrap(err, "failed to add signing key to set")
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package token
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/linemk/rocket-shop/iam/internal/model"
+	repoModel "github.com/linemk/rocket-shop/iam/internal/repository/model"
+	"github.com/linemk/rocket-shop/platform/pkg/cache/redis"
+)
+
+// Consume возвращает токен и сразу удаляет его, чтобы токен нельзя было использовать повторно
+func (r *repository) Consume(ctx context.Context, purpose model.TokenPurpose, token string) (*model.Token, error) {
+	tokenJSON, err := r.cache.GetDel(ctx, tokenKey(purpose, token))
+	if err != nil {
+		if errors.Is(err, redis.ErrKeyNotFound) {
+			return nil, model.ErrInvalidToken
+		}
+		return nil, errors.Wrap(err, "failed to get token from Redis")
+	}
+
+	var repoToken repoModel.Token
+	err = json.Unmarshal(tokenJSON, &repoToken)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal token")
+	}
+
+	return &model.Token{
+		Token:    token,
+		Purpose:  purpose,
+		UserUUID: repoToken.UserUUID,
+		Email:    repoToken.Email,
+	}, nil
+}
@@ -0,0 +1,40 @@
+package token
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/linemk/rocket-shop/iam/internal/model"
+	repoModel "github.com/linemk/rocket-shop/iam/internal/repository/model"
+)
+
+const tokenKeyPrefix = "auth_token:"
+
+func (r *repository) Create(ctx context.Context, token *model.Token, ttl time.Duration) error {
+	tokenJSON, err := json.Marshal(repoModel.Token{
+		UserUUID: token.UserUUID,
+		Email:    token.Email,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal token")
+	}
+
+	err = r.cache.Set(ctx, tokenKey(token.Purpose, token.Token), tokenJSON, ttl)
+	if err != nil {
+		return errors.Wrap(err, "failed to save token to Redis")
+	}
+
+	return nil
+}
+
+// tokenKey формирует ключ Redis для токена; сам токен в Redis не хранится, только его хеш
+func tokenKey(purpose model.TokenPurpose, token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return fmt.Sprintf("%s%s:%s", tokenKeyPrefix, purpose, hex.EncodeToString(sum[:]))
+}
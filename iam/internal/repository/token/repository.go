@@ -0,0 +1,24 @@
+package token
+
+import (
+	"context"
+	"time"
+
+	"github.com/linemk/rocket-shop/iam/internal/model"
+	"github.com/linemk/rocket-shop/platform/pkg/cache"
+)
+
+type Repository interface {
+	Create(ctx context.Context, token *model.Token, ttl time.Duration) error
+	Consume(ctx context.Context, purpose model.TokenPurpose, token string) (*model.Token, error)
+}
+
+type repository struct {
+	cache cache.Client
+}
+
+func NewRepository(cache cache.Client) Repository {
+	return &repository{
+		cache: cache,
+	}
+}
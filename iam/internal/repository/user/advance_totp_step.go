@@ -0,0 +1,27 @@
+package user
+
+import (
+	"context"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+)
+
+func (r *repository) AdvanceTOTPStep(ctx context.Context, userUUID string, step int64) (bool, error) {
+	query, args, err := sq.Update("users").
+		PlaceholderFormat(sq.Dollar).
+		Set("totp_last_step", step).
+		Where(sq.Eq{"user_uuid": userUUID}).
+		Where(sq.Lt{"totp_last_step": step}).
+		ToSql()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to build advance totp step query")
+	}
+
+	tag, err := r.db.Exec(ctx, query, args...)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to advance totp step")
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
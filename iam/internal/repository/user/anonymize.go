@@ -0,0 +1,46 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/linemk/rocket-shop/iam/internal/model"
+)
+
+// erasedLoginPrefix префикс логина анонимизированной учетной записи; логин должен оставаться уникальным
+const erasedLoginPrefix = "erased-"
+
+func (r *repository) Anonymize(ctx context.Context, userUUID string, erasedAt time.Time) error {
+	query, args, err := sq.Update("users").
+		PlaceholderFormat(sq.Dollar).
+		Set("login", erasedLoginPrefix+userUUID).
+		Set("password_hash", "").
+		Set("email", "").
+		Set("email_verified", false).
+		Set("notification_methods", []byte("[]")).
+		Set("totp_secret", nil).
+		Set("totp_enabled", false).
+		Set("recovery_codes", []byte("[]")).
+		Set("roles", []byte("[]")).
+		Set("erased_at", sq.Expr("COALESCE(erased_at, ?)", erasedAt)).
+		Set("updated_at", erasedAt).
+		Where(sq.Eq{"user_uuid": userUUID}).
+		ToSql()
+	if err != nil {
+		return errors.Wrap(err, "failed to build update query")
+	}
+
+	tag, err := r.db.Exec(ctx, query, args...)
+	if err != nil {
+		return errors.Wrap(err, "failed to anonymize user")
+	}
+
+	if tag.RowsAffected() == 0 {
+		return model.ErrUserNotFound
+	}
+
+	return nil
+}
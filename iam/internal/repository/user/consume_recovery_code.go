@@ -0,0 +1,31 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+)
+
+func (r *repository) ConsumeRecoveryCode(ctx context.Context, userUUID, codeHash string) (bool, error) {
+	// Хеш удаляется одним запросом и только если он еще есть в массиве,
+	// поэтому из параллельных проверок одного кода успешной будет только одна
+	query, args, err := sq.Update("users").
+		PlaceholderFormat(sq.Dollar).
+		Set("recovery_codes", sq.Expr("recovery_codes - ?::text", codeHash)).
+		Set("updated_at", time.Now()).
+		Where(sq.Eq{"user_uuid": userUUID}).
+		Where(sq.Expr("recovery_codes @> jsonb_build_array(?::text)", codeHash)).
+		ToSql()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to build consume recovery code query")
+	}
+
+	tag, err := r.db.Exec(ctx, query, args...)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to consume recovery code")
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
@@ -23,6 +23,11 @@ func (r *repository) Create(ctx context.Context, user *model.User) error {
 		return errors.Wrap(err, "failed to marshal notification methods")
 	}
 
+	rolesJSON, err := repoModel.RolesToJSON(repoUser.Roles)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal roles")
+	}
+
 	now := time.Now()
 
 	query, args, err := sq.Insert("users").
@@ -32,7 +37,9 @@ func (r *repository) Create(ctx context.Context, user *model.User) error {
 			"login",
 			"password_hash",
 			"email",
+			"email_verified",
 			"notification_methods",
+			"roles",
 			"created_at",
 		).
 		Values(
@@ -40,7 +47,9 @@ func (r *repository) Create(ctx context.Context, user *model.User) error {
 			repoUser.Login,
 			repoUser.PasswordHash,
 			repoUser.Email,
+			repoUser.EmailVerified,
 			notificationMethodsJSON,
+			rolesJSON,
 			now,
 		).
 		ToSql()
@@ -50,6 +59,9 @@ func (r *repository) Create(ctx context.Context, user *model.User) error {
 
 	_, err = r.db.Exec(ctx, query, args...)
 	if err != nil {
+		if mapped := mapWriteError(err); mapped != nil {
+			return mapped
+		}
 		return errors.Wrap(err, "failed to insert user")
 	}
 
@@ -0,0 +1,31 @@
+package user
+
+import (
+	"context"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/linemk/rocket-shop/iam/internal/model"
+)
+
+func (r *repository) Delete(ctx context.Context, userUUID string) error {
+	query, args, err := sq.Delete("users").
+		PlaceholderFormat(sq.Dollar).
+		Where(sq.Eq{"user_uuid": userUUID}).
+		ToSql()
+	if err != nil {
+		return errors.Wrap(err, "failed to build delete query")
+	}
+
+	tag, err := r.db.Exec(ctx, query, args...)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete user")
+	}
+
+	if tag.RowsAffected() == 0 {
+		return model.ErrUserNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,25 @@
+package user
+
+import (
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pkg/errors"
+
+	"github.com/linemk/rocket-shop/iam/internal/model"
+)
+
+const (
+	// uniqueViolationCode код ошибки PostgreSQL при нарушении уникальности
+	uniqueViolationCode = "23505"
+	// verifiedEmailIndex уникальный индекс подтвержденных email
+	verifiedEmailIndex = "idx_users_verified_email"
+)
+
+// mapWriteError переводит нарушение уникальности подтвержденного email в доменную ошибку
+func mapWriteError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode && pgErr.ConstraintName == verifiedEmailIndex {
+		return model.ErrEmailAlreadyTaken
+	}
+
+	return nil
+}
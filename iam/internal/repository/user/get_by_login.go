@@ -16,13 +16,18 @@ func (r *repository) GetByLogin(ctx context.Context, login string) (*model.User,
 	return r.queryUser(ctx, sq.Eq{"login": login})
 }
 
-func (r *repository) queryUser(ctx context.Context, where sq.Eq) (*model.User, error) {
+func (r *repository) queryUser(ctx context.Context, where sq.Sqlizer) (*model.User, error) {
 	query, args, err := sq.Select(
 		"user_uuid",
 		"login",
 		"password_hash",
 		"email",
+		"email_verified",
 		"notification_methods",
+		"totp_secret",
+		"totp_enabled",
+		"recovery_codes",
+		"roles",
 		"created_at",
 		"updated_at",
 	).
@@ -36,13 +41,20 @@ func (r *repository) queryUser(ctx context.Context, where sq.Eq) (*model.User, e
 
 	var repoUser repoModel.User
 	var notificationMethodsJSON []byte
+	var recoveryCodesJSON []byte
+	var rolesJSON []byte
 
 	err = r.db.QueryRow(ctx, query, args...).Scan(
 		&repoUser.UserUUID,
 		&repoUser.Login,
 		&repoUser.PasswordHash,
 		&repoUser.Email,
+		&repoUser.EmailVerified,
 		&notificationMethodsJSON,
+		&repoUser.TOTPSecret,
+		&repoUser.TOTPEnabled,
+		&recoveryCodesJSON,
+		&rolesJSON,
 		&repoUser.CreatedAt,
 		&repoUser.UpdatedAt,
 	)
@@ -58,5 +70,15 @@ func (r *repository) queryUser(ctx context.Context, where sq.Eq) (*model.User, e
 		return nil, errors.Wrap(err, "failed to unmarshal notification methods")
 	}
 
+	repoUser.RecoveryCodeHashes, err = repoModel.RecoveryCodesFromJSON(recoveryCodesJSON)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal recovery codes")
+	}
+
+	repoUser.Roles, err = repoModel.RolesFromJSON(rolesJSON)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal roles")
+	}
+
 	return repoConverter.ToInternalUser(&repoUser), nil
 }
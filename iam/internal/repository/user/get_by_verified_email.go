@@ -0,0 +1,17 @@
+package user
+
+import (
+	"context"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/linemk/rocket-shop/iam/internal/model"
+)
+
+// GetByVerifiedEmail ищет пользователя, подтвердившего email; уникальный индекс гарантирует не более одной записи
+func (r *repository) GetByVerifiedEmail(ctx context.Context, email string) (*model.User, error) {
+	return r.queryUser(ctx, sq.And{
+		sq.Expr("lower(email) = lower(?)", email),
+		sq.Eq{"email_verified": true},
+	})
+}
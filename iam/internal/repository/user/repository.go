@@ -2,6 +2,7 @@ package user
 
 import (
 	"context"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 
@@ -12,6 +13,22 @@ type Repository interface {
 	Create(ctx context.Context, user *model.User) error
 	GetByID(ctx context.Context, userUUID string) (*model.User, error)
 	GetByLogin(ctx context.Context, login string) (*model.User, error)
+	// GetByVerifiedEmail возвращает единственного пользователя, подтвердившего email
+	GetByVerifiedEmail(ctx context.Context, email string) (*model.User, error)
+	// Update обновляет профиль пользователя; пароль и настройки 2FA меняются отдельными методами,
+	// чтобы запись профиля не возвращала устаревший хеш пароля или погашенный код восстановления
+	Update(ctx context.Context, user *model.User) error
+	// UpdatePasswordHash заменяет только хеш пароля, не затрагивая остальные поля
+	UpdatePasswordHash(ctx context.Context, userUUID, passwordHash string) error
+	// AdvanceTOTPStep запоминает принятый шаг TOTP, если он позже сохраненного; false означает повтор кода
+	AdvanceTOTPStep(ctx context.Context, userUUID string, step int64) (bool, error)
+	// UpdateMFA заменяет секрет TOTP, признак включенной 2FA и хеши кодов восстановления
+	UpdateMFA(ctx context.Context, user *model.User) error
+	// ConsumeRecoveryCode удаляет хеш кода восстановления, если он еще не использован; false означает, что код уже погашен
+	ConsumeRecoveryCode(ctx context.Context, userUUID, codeHash string) (bool, error)
+	Delete(ctx context.Context, userUUID string) error
+	// Anonymize удаляет персональные данные пользователя, сохраняя запись с его UUID
+	Anonymize(ctx context.Context, userUUID string, erasedAt time.Time) error
 }
 
 type repository struct {
@@ -0,0 +1,51 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/linemk/rocket-shop/iam/internal/model"
+	repoConverter "github.com/linemk/rocket-shop/iam/internal/repository/converter"
+	repoModel "github.com/linemk/rocket-shop/iam/internal/repository/model"
+)
+
+func (r *repository) Update(ctx context.Context, user *model.User) error {
+	repoUser := repoConverter.ToRepoUser(user)
+	if repoUser == nil {
+		return errors.New("failed to convert user to repository model")
+	}
+
+	notificationMethodsJSON, err := repoModel.NotificationMethodsToJSON(repoUser.NotificationMethods)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal notification methods")
+	}
+
+	query, args, err := sq.Update("users").
+		PlaceholderFormat(sq.Dollar).
+		Set("email", repoUser.Email).
+		Set("email_verified", repoUser.EmailVerified).
+		Set("notification_methods", notificationMethodsJSON).
+		Set("updated_at", time.Now()).
+		Where(sq.Eq{"user_uuid": repoUser.UserUUID}).
+		ToSql()
+	if err != nil {
+		return errors.Wrap(err, "failed to build update query")
+	}
+
+	tag, err := r.db.Exec(ctx, query, args...)
+	if err != nil {
+		if mapped := mapWriteError(err); mapped != nil {
+			return mapped
+		}
+		return errors.Wrap(err, "failed to update user")
+	}
+
+	if tag.RowsAffected() == 0 {
+		return model.ErrUserNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/linemk/rocket-shop/iam/internal/model"
+	repoModel "github.com/linemk/rocket-shop/iam/internal/repository/model"
+)
+
+func (r *repository) UpdateMFA(ctx context.Context, user *model.User) error {
+	recoveryCodesJSON, err := repoModel.RecoveryCodesToJSON(user.RecoveryCodeHashes)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal recovery codes")
+	}
+
+	query, args, err := sq.Update("users").
+		PlaceholderFormat(sq.Dollar).
+		Set("totp_secret", user.TOTPSecret).
+		Set("totp_enabled", user.TOTPEnabled).
+		Set("recovery_codes", recoveryCodesJSON).
+		Set("updated_at", time.Now()).
+		Where(sq.Eq{"user_uuid": user.UserUUID}).
+		ToSql()
+	if err != nil {
+		return errors.Wrap(err, "failed to build update mfa query")
+	}
+
+	tag, err := r.db.Exec(ctx, query, args...)
+	if err != nil {
+		return errors.Wrap(err, "failed to update mfa")
+	}
+
+	if tag.RowsAffected() == 0 {
+		return model.ErrUserNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,34 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/linemk/rocket-shop/iam/internal/model"
+)
+
+func (r *repository) UpdatePasswordHash(ctx context.Context, userUUID, passwordHash string) error {
+	query, args, err := sq.Update("users").
+		PlaceholderFormat(sq.Dollar).
+		Set("password_hash", passwordHash).
+		Set("updated_at", time.Now()).
+		Where(sq.Eq{"user_uuid": userUUID}).
+		ToSql()
+	if err != nil {
+		return errors.Wrap(err, "failed to build update password hash query")
+	}
+
+	tag, err := r.db.Exec(ctx, query, args...)
+	if err != nil {
+		return errors.Wrap(err, "failed to update password hash")
+	}
+
+	if tag.RowsAffected() == 0 {
+		return model.ErrUserNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,152 @@
+package access_token
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"sync"
+	"time"
+
+	gojwt "github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/linemk/rocket-shop/iam/internal/config"
+	"github.com/linemk/rocket-shop/iam/internal/model"
+	"github.com/linemk/rocket-shop/iam/internal/repository/signing_key"
+	"github.com/linemk/rocket-shop/platform/pkg/jwt"
+)
+
+type Service interface {
+	// Issue выпускает подписанный access токен для пользователя в рамках сессии
+	Issue(ctx context.Context, user *model.User, sessionUUID string) (*model.AccessToken, error)
+	// JWKS возвращает публичные ключи для офлайн проверки access токенов
+	JWKS(ctx context.Context) (*jwt.JWKS, error)
+}
+
+type service struct {
+	signingKeyRepo signing_key.Repository
+	cfg            config.JWTConfig
+
+	mu      sync.Mutex
+	current *model.SigningKey
+}
+
+func NewService(signingKeyRepo signing_key.Repository, cfg config.JWTConfig) Service {
+	return &service{
+		signingKeyRepo: signingKeyRepo,
+		cfg:            cfg,
+	}
+}
+
+func (s *service) Issue(ctx context.Context, user *model.User, sessionUUID string) (*model.AccessToken, error) {
+	key, err := s.currentKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(s.cfg.AccessTTL())
+
+	claims := &jwt.Claims{
+		RegisteredClaims: gojwt.RegisteredClaims{
+			ID:        uuid.New().String(),
+			Issuer:    s.cfg.Issuer(),
+			Subject:   user.UserUUID,
+			IssuedAt:  gojwt.NewNumericDate(now),
+			NotBefore: gojwt.NewNumericDate(now),
+			ExpiresAt: gojwt.NewNumericDate(expiresAt),
+		},
+		Login:       user.Login,
+		Roles:       user.Roles,
+		SessionUUID: sessionUUID,
+	}
+
+	token := gojwt.NewWithClaims(gojwt.SigningMethodES256, claims)
+	token.Header["kid"] = key.KeyID
+
+	signed, err := token.SignedString(key.PrivateKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to sign access token")
+	}
+
+	return &model.AccessToken{
+		Token:     signed,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+func (s *service) JWKS(ctx context.Context) (*jwt.JWKS, error) {
+	keys, err := s.signingKeyRepo.List(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list signing keys")
+	}
+
+	jwks := &jwt.JWKS{Keys: make([]jwt.JWK, 0, len(keys))}
+	for _, key := range keys {
+		jwks.Keys = append(jwks.Keys, jwt.NewJWK(key.KeyID, &key.PrivateKey.PublicKey))
+	}
+
+	return jwks, nil
+}
+
+// currentKey возвращает ключ для подписи, при необходимости выполняя ротацию.
+// Ключи хранятся в Redis, поэтому все инстансы IAM подписывают токены общим набором ключей
+func (s *service) currentKey(ctx context.Context) (*model.SigningKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if s.current != nil && now.Before(s.current.RotateAt) {
+		return s.current, nil
+	}
+
+	keys, err := s.signingKeyRepo.List(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list signing keys")
+	}
+
+	var current *model.SigningKey
+	for _, key := range keys {
+		if now.Before(key.RotateAt) && (current == nil || key.CreatedAt.After(current.CreatedAt)) {
+			current = key
+		}
+	}
+
+	if current == nil {
+		current, err = s.generateKey(ctx, now)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	s.current = current
+
+	return current, nil
+}
+
+func (s *service) generateKey(ctx context.Context, now time.Time) (*model.SigningKey, error) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate signing key")
+	}
+
+	rotateAt := now.Add(s.cfg.KeyRotationInterval())
+
+	key := &model.SigningKey{
+		KeyID:      uuid.New().String(),
+		PrivateKey: privateKey,
+		CreatedAt:  now,
+		RotateAt:   rotateAt,
+		// Ключ остается в JWKS, пока не истекут подписанные им токены
+		ExpiresAt: rotateAt.Add(s.cfg.AccessTTL()),
+	}
+
+	err = s.signingKeyRepo.Save(ctx, key)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to save signing key")
+	}
+
+	return key, nil
+}
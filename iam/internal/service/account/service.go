@@ -0,0 +1,218 @@
+package account
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/linemk/rocket-shop/iam/internal/config"
+	"github.com/linemk/rocket-shop/iam/internal/model"
+	"github.com/linemk/rocket-shop/iam/internal/repository/session"
+	"github.com/linemk/rocket-shop/iam/internal/repository/token"
+	"github.com/linemk/rocket-shop/iam/internal/repository/user"
+	"github.com/linemk/rocket-shop/iam/internal/service/audit"
+	"github.com/linemk/rocket-shop/iam/internal/service/password"
+	"github.com/linemk/rocket-shop/iam/internal/service/producer/token_producer"
+	"github.com/linemk/rocket-shop/iam/internal/service/whoami_cache"
+)
+
+// tokenSize размер одноразового токена в байтах
+const tokenSize = 32
+
+type Service interface {
+	RequestPasswordReset(ctx context.Context, email string) error
+	ConfirmPasswordReset(ctx context.Context, token, newPassword string) error
+	SendVerificationEmail(ctx context.Context, userUUID string) error
+	VerifyEmail(ctx context.Context, token string) (*model.User, error)
+}
+
+type service struct {
+	userRepo        user.Repository
+	sessionRepo     session.Repository
+	tokenRepo       token.Repository
+	tokenProducer   token_producer.Producer
+	passwordService password.Service
+	auditService    audit.Service
+	whoamiCache     whoami_cache.Invalidator
+	tokenCfg        config.TokenConfig
+}
+
+func NewService(
+	userRepo user.Repository,
+	sessionRepo session.Repository,
+	tokenRepo token.Repository,
+	tokenProducer token_producer.Producer,
+	passwordService password.Service,
+	auditService audit.Service,
+	whoamiCache whoami_cache.Invalidator,
+	tokenCfg config.TokenConfig,
+) Service {
+	return &service{
+		userRepo:        userRepo,
+		sessionRepo:     sessionRepo,
+		tokenRepo:       tokenRepo,
+		tokenProducer:   tokenProducer,
+		passwordService: passwordService,
+		auditService:    auditService,
+		whoamiCache:     whoamiCache,
+		tokenCfg:        tokenCfg,
+	}
+}
+
+// RequestPasswordReset отправляет токен только на подтвержденный email: неподтвержденный адрес
+// может принадлежать нескольким аккаунтам, и токен ушел бы произвольному из них
+func (s *service) RequestPasswordReset(ctx context.Context, email string) error {
+	user, err := s.userRepo.GetByVerifiedEmail(ctx, email)
+	if err != nil {
+		// Не раскрываем, зарегистрирован ли такой email
+		if errors.Is(err, model.ErrUserNotFound) {
+			return nil
+		}
+		return errors.Wrap(err, "failed to get user")
+	}
+
+	return s.issueToken(ctx, user, model.TokenPurposePasswordReset, s.tokenCfg.PasswordResetTTL())
+}
+
+func (s *service) ConfirmPasswordReset(ctx context.Context, token, newPassword string) error {
+	// Проверяем политику до погашения токена, чтобы пользователь мог повторить попытку с тем же токеном
+	err := s.passwordService.Validate(newPassword)
+	if err != nil {
+		return err
+	}
+
+	resetToken, err := s.tokenRepo.Consume(ctx, model.TokenPurposePasswordReset, token)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, resetToken.UserUUID)
+	if err != nil {
+		return errors.Wrap(err, "failed to get user")
+	}
+
+	// Ссылка сброса отправлялась на конкретный адрес: если email с тех пор сменился, старое письмо не должно давать доступ
+	if user.Email != resetToken.Email {
+		return model.ErrInvalidToken
+	}
+
+	passwordHash, err := s.passwordService.Hash(newPassword)
+	if err != nil {
+		return errors.Wrap(err, "failed to hash password")
+	}
+
+	err = s.userRepo.UpdatePasswordHash(ctx, user.UserUUID, passwordHash)
+	if err != nil {
+		return errors.Wrap(err, "failed to update password hash")
+	}
+
+	// После сброса пароля завершаем все сессии пользователя
+	err = s.sessionRepo.DeleteUserSessions(ctx, user.UserUUID)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete user sessions")
+	}
+
+	s.whoamiCache.InvalidateUser(ctx, user.UserUUID)
+
+	s.auditService.Record(ctx, &model.AuditEvent{
+		EventType: model.AuditEventSessionRevoked,
+		UserUUID:  user.UserUUID,
+		Reason:    "password reset",
+	})
+
+	return nil
+}
+
+func (s *service) SendVerificationEmail(ctx context.Context, userUUID string) error {
+	user, err := s.userRepo.GetByID(ctx, userUUID)
+	if err != nil {
+		return errors.Wrap(err, "failed to get user")
+	}
+
+	if user.Email == "" {
+		return model.ErrEmptyEmail
+	}
+
+	if user.EmailVerified {
+		return model.ErrEmailAlreadyVerified
+	}
+
+	return s.issueToken(ctx, user, model.TokenPurposeEmailVerification, s.tokenCfg.EmailVerificationTTL())
+}
+
+func (s *service) VerifyEmail(ctx context.Context, token string) (*model.User, error) {
+	verificationToken, err := s.tokenRepo.Consume(ctx, model.TokenPurposeEmailVerification, token)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, verificationToken.UserUUID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get user")
+	}
+
+	// Токен выдавался на конкретный адрес: если email с тех пор сменился, подтверждать нечего
+	if user.Email != verificationToken.Email {
+		return nil, model.ErrInvalidToken
+	}
+
+	if user.EmailVerified {
+		return user, nil
+	}
+
+	user.EmailVerified = true
+
+	err = s.userRepo.Update(ctx, user)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to update user")
+	}
+
+	s.whoamiCache.InvalidateUser(ctx, user.UserUUID)
+
+	return user, nil
+}
+
+// issueToken создает одноразовый токен и публикует событие для его доставки пользователю
+func (s *service) issueToken(ctx context.Context, user *model.User, purpose model.TokenPurpose, ttl time.Duration) error {
+	value, err := generateToken()
+	if err != nil {
+		return errors.Wrap(err, "failed to generate token")
+	}
+
+	err = s.tokenRepo.Create(ctx, &model.Token{
+		Token:    value,
+		Purpose:  purpose,
+		UserUUID: user.UserUUID,
+		Email:    user.Email,
+	}, ttl)
+	if err != nil {
+		return errors.Wrap(err, "failed to save token")
+	}
+
+	err = s.tokenProducer.SendAuthTokenIssued(ctx, &model.AuthTokenIssuedEvent{
+		EventUUID: uuid.New().String(),
+		UserUUID:  user.UserUUID,
+		Email:     user.Email,
+		Purpose:   purpose,
+		Token:     value,
+		TTLSec:    int64(ttl.Seconds()),
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to publish token event")
+	}
+
+	return nil
+}
+
+func generateToken() (string, error) {
+	buf := make([]byte, tokenSize)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,287 @@
+package api_key
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/linemk/rocket-shop/iam/internal/model"
+	"github.com/linemk/rocket-shop/iam/internal/repository/api_key"
+	"github.com/linemk/rocket-shop/iam/internal/repository/api_key_session"
+	"github.com/linemk/rocket-shop/iam/internal/repository/session"
+	"github.com/linemk/rocket-shop/iam/internal/repository/user"
+	"github.com/linemk/rocket-shop/iam/internal/service/whoami_cache"
+)
+
+const (
+	// keyPrefix префикс, по которому API ключ легко отличить от других секретов
+	keyPrefix = "rsk_"
+	// keySize размер случайной части ключа в байтах
+	keySize = 32
+	// displayPrefixLen длина видимой части ключа, сохраняемой для отображения в списке
+	displayPrefixLen = len(keyPrefix) + 8
+	// sessionTTL время жизни сессии, выпускаемой для API ключа
+	sessionTTL = 15 * time.Minute
+)
+
+type Service interface {
+	// Create создает API ключ; значение ключа возвращается только один раз
+	Create(ctx context.Context, userUUID, name string, scopes []string, expiresAt *time.Time) (*model.CreatedAPIKey, error)
+	List(ctx context.Context, userUUID string) ([]*model.APIKey, error)
+	Revoke(ctx context.Context, userUUID, apiKeyUUID string) error
+	// Authenticate находит активный API ключ по его значению и возвращает владельца
+	Authenticate(ctx context.Context, key string) (*model.User, *model.APIKey, error)
+	// Session возвращает сессию, от имени которой запрос по API ключу передается сервисам.
+	// Сервисы проверяют сессии через IAM Whoami, поэтому запрос по ключу должен нести обычную сессию
+	Session(ctx context.Context, apiKey *model.APIKey) (string, error)
+}
+
+type service struct {
+	apiKeyRepo        api_key.Repository
+	apiKeySessionRepo api_key_session.Repository
+	sessionRepo       session.Repository
+	userRepo          user.Repository
+	whoamiCache       whoami_cache.Service
+}
+
+func NewService(
+	apiKeyRepo api_key.Repository,
+	apiKeySessionRepo api_key_session.Repository,
+	sessionRepo session.Repository,
+	userRepo user.Repository,
+	whoamiCache whoami_cache.Service,
+) Service {
+	return &service{
+		apiKeyRepo:        apiKeyRepo,
+		apiKeySessionRepo: apiKeySessionRepo,
+		sessionRepo:       sessionRepo,
+		userRepo:          userRepo,
+		whoamiCache:       whoamiCache,
+	}
+}
+
+func (s *service) Create(ctx context.Context, userUUID, name string, scopes []string, expiresAt *time.Time) (*model.CreatedAPIKey, error) {
+	err := validateScopes(scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if expiresAt != nil && !expiresAt.After(now) {
+		return nil, model.ErrInvalidAPIKeyExpiration
+	}
+
+	_, err = s.userRepo.GetByID(ctx, userUUID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get user")
+	}
+
+	key, err := generateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey := &model.APIKey{
+		APIKeyUUID: uuid.New().String(),
+		UserUUID:   userUUID,
+		Name:       name,
+		KeyPrefix:  key[:displayPrefixLen],
+		KeyHash:    hashKey(key),
+		Scopes:     scopes,
+		ExpiresAt:  expiresAt,
+		CreatedAt:  now,
+	}
+
+	err = s.apiKeyRepo.Create(ctx, apiKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create api key")
+	}
+
+	return &model.CreatedAPIKey{
+		APIKey: apiKey,
+		Key:    key,
+	}, nil
+}
+
+func (s *service) List(ctx context.Context, userUUID string) ([]*model.APIKey, error) {
+	keys, err := s.apiKeyRepo.ListByUser(ctx, userUUID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list api keys")
+	}
+
+	return keys, nil
+}
+
+func (s *service) Revoke(ctx context.Context, userUUID, apiKeyUUID string) error {
+	err := s.apiKeyRepo.Revoke(ctx, userUUID, apiKeyUUID)
+	if err != nil {
+		return err
+	}
+
+	// Сессия ключа не должна переживать его отзыв
+	sessionUUID, err := s.apiKeySessionRepo.Get(ctx, apiKeyUUID)
+	if err != nil {
+		if errors.Is(err, model.ErrSessionNotFound) {
+			return nil
+		}
+		return errors.Wrap(err, "failed to get api key session")
+	}
+
+	err = s.deleteSession(ctx, userUUID, sessionUUID)
+	if err != nil {
+		return err
+	}
+
+	err = s.apiKeySessionRepo.Delete(ctx, apiKeyUUID)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete api key session")
+	}
+
+	return nil
+}
+
+func (s *service) Authenticate(ctx context.Context, key string) (*model.User, *model.APIKey, error) {
+	apiKey, err := s.apiKeyRepo.GetByHash(ctx, hashKey(key))
+	if err != nil {
+		if errors.Is(err, model.ErrAPIKeyNotFound) {
+			return nil, nil, model.ErrInvalidAPIKey
+		}
+		return nil, nil, errors.Wrap(err, "failed to get api key")
+	}
+
+	if !apiKey.IsActive(time.Now()) {
+		return nil, nil, model.ErrInvalidAPIKey
+	}
+
+	user, err := s.userRepo.GetByID(ctx, apiKey.UserUUID)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to get user")
+	}
+
+	return user, apiKey, nil
+}
+
+func (s *service) Session(ctx context.Context, apiKey *model.APIKey) (string, error) {
+	sessionUUID, err := s.apiKeySessionRepo.Get(ctx, apiKey.APIKeyUUID)
+	switch {
+	case err == nil:
+		// Сессия могла быть завершена отдельно от ключа, например при сбросе пароля
+		_, err = s.sessionRepo.Get(ctx, sessionUUID)
+		if err == nil {
+			return sessionUUID, nil
+		}
+		if !errors.Is(err, model.ErrSessionNotFound) {
+			return "", errors.Wrap(err, "failed to get session")
+		}
+
+		// Освобождаем ключ от завершенной сессии, чтобы закрепить за ним новую
+		err = s.apiKeySessionRepo.Delete(ctx, apiKey.APIKeyUUID)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to delete api key session")
+		}
+	case !errors.Is(err, model.ErrSessionNotFound):
+		return "", errors.Wrap(err, "failed to get api key session")
+	}
+
+	now := time.Now()
+
+	ttl := sessionTTL
+	if apiKey.ExpiresAt != nil && apiKey.ExpiresAt.Sub(now) < ttl {
+		ttl = apiKey.ExpiresAt.Sub(now)
+	}
+
+	session := &model.Session{
+		SessionUUID: uuid.New().String(),
+		UserUUID:    apiKey.UserUUID,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(ttl),
+	}
+
+	err = s.sessionRepo.Create(ctx, session, ttl)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create session")
+	}
+
+	// Сессия попадает в набор сессий пользователя, чтобы завершаться вместе с остальными
+	err = s.sessionRepo.AddSessionToUserSet(ctx, apiKey.UserUUID, session.SessionUUID)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to add session to user set")
+	}
+
+	// Сессия создается до закрепления за ключом, поэтому закрепленная сессия всегда существует.
+	// Параллельные первые запросы создают по сессии, но закрепляется только одна: остальные удаляются,
+	// иначе Revoke не нашел бы их и они пережили бы отзыв ключа
+	claimed, err := s.apiKeySessionRepo.Claim(ctx, apiKey.APIKeyUUID, session.SessionUUID, ttl)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to claim api key session")
+	}
+	if claimed {
+		return session.SessionUUID, nil
+	}
+
+	err = s.deleteSession(ctx, apiKey.UserUUID, session.SessionUUID)
+	if err != nil {
+		return "", err
+	}
+
+	sessionUUID, err = s.apiKeySessionRepo.Get(ctx, apiKey.APIKeyUUID)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get api key session")
+	}
+
+	return sessionUUID, nil
+}
+
+// deleteSession завершает сессию ключа и сбрасывает ее из кэша Whoami
+func (s *service) deleteSession(ctx context.Context, userUUID, sessionUUID string) error {
+	err := s.sessionRepo.Delete(ctx, sessionUUID)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete api key session")
+	}
+
+	s.whoamiCache.InvalidateSession(ctx, sessionUUID)
+
+	err = s.sessionRepo.RemoveSessionFromUserSet(ctx, userUUID, sessionUUID)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove api key session from user set")
+	}
+
+	return nil
+}
+
+func validateScopes(scopes []string) error {
+	if len(scopes) == 0 {
+		return model.ErrEmptyAPIKeyScopes
+	}
+
+	for _, scope := range scopes {
+		if !model.IsKnownAPIKeyScope(scope) {
+			return errors.Wrapf(model.ErrUnknownAPIKeyScope, "scope %q", scope)
+		}
+	}
+
+	return nil
+}
+
+// generateKey генерирует новое значение API ключа
+func generateKey() (string, error) {
+	buf := make([]byte, keySize)
+	if _, err := rand.Read(buf); err != nil {
+		return "", errors.Wrap(err, "failed to generate api key")
+	}
+
+	return keyPrefix + base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// hashKey возвращает хеш ключа для хранения и поиска.
+// Ключ содержит 256 бит случайности, поэтому медленный хеш вроде bcrypt не нужен
+func hashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,22 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/linemk/rocket-shop/iam/internal/model"
+)
+
+type contextKey string
+
+const clientInfoContextKey contextKey = "client-info"
+
+// ContextWithClientInfo добавляет в контекст информацию о клиенте для событий аудита
+func ContextWithClientInfo(ctx context.Context, client model.ClientInfo) context.Context {
+	return context.WithValue(ctx, clientInfoContextKey, client)
+}
+
+// ClientInfoFromContext извлекает информацию о клиенте из контекста
+func ClientInfoFromContext(ctx context.Context) model.ClientInfo {
+	client, _ := ctx.Value(clientInfoContextKey).(model.ClientInfo)
+	return client
+}
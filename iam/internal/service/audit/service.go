@@ -0,0 +1,99 @@
+package audit
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/linemk/rocket-shop/iam/internal/model"
+	"github.com/linemk/rocket-shop/iam/internal/repository/audit_event"
+	"github.com/linemk/rocket-shop/iam/internal/service/producer/audit_producer"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 500
+)
+
+type Service interface {
+	// Record публикует событие аудита. Ошибка публикации логируется и не прерывает аутентификацию
+	Record(ctx context.Context, event *model.AuditEvent)
+	// Save сохраняет событие, полученное из Kafka, в хранилище аудита
+	Save(ctx context.Context, event *model.AuditEvent) error
+	// List возвращает события пользователя (новые первыми) и токен следующей страницы
+	List(ctx context.Context, userUUID string, eventTypes []model.AuditEventType, pageSize int, pageToken string) ([]*model.AuditEvent, string, error)
+}
+
+type service struct {
+	auditEventRepo audit_event.Repository
+	auditProducer  audit_producer.Producer
+}
+
+func NewService(auditEventRepo audit_event.Repository, auditProducer audit_producer.Producer) Service {
+	return &service{
+		auditEventRepo: auditEventRepo,
+		auditProducer:  auditProducer,
+	}
+}
+
+func (s *service) Record(ctx context.Context, event *model.AuditEvent) {
+	event.EventUUID = uuid.New().String()
+	event.OccurredAt = time.Now()
+
+	if event.Client == (model.ClientInfo{}) {
+		event.Client = ClientInfoFromContext(ctx)
+	}
+
+	// Ошибка отправки события уже логируется в auditProducer
+	_ = s.auditProducer.SendAuthAuditEvent(ctx, event)
+}
+
+func (s *service) Save(ctx context.Context, event *model.AuditEvent) error {
+	err := s.auditEventRepo.Create(ctx, event)
+	if err != nil {
+		return errors.Wrap(err, "failed to save audit event")
+	}
+
+	return nil
+}
+
+func (s *service) List(ctx context.Context, userUUID string, eventTypes []model.AuditEventType, pageSize int, pageToken string) ([]*model.AuditEvent, string, error) {
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	// Токен страницы - смещение от начала истории
+	offset := 0
+	if pageToken != "" {
+		parsed, err := strconv.Atoi(pageToken)
+		if err != nil || parsed < 0 {
+			return nil, "", model.ErrInvalidPageToken
+		}
+		offset = parsed
+	}
+
+	// Запрашиваем на одно событие больше, чтобы понять, есть ли следующая страница
+	events, err := s.auditEventRepo.List(ctx, model.AuditEventFilter{
+		UserUUID:   userUUID,
+		EventTypes: eventTypes,
+		Limit:      pageSize + 1,
+		Offset:     offset,
+	})
+	if err != nil {
+		return nil, "", errors.Wrap(err, "failed to list audit events")
+	}
+
+	nextPageToken := ""
+	if len(events) > pageSize {
+		events = events[:pageSize]
+		nextPageToken = strconv.Itoa(offset + pageSize)
+	}
+
+	return events, nextPageToken, nil
+}
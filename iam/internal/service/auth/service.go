@@ -6,91 +6,350 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
-	"golang.org/x/crypto/bcrypt"
+	"go.uber.org/zap"
 
 	"github.com/linemk/rocket-shop/iam/internal/config"
 	"github.com/linemk/rocket-shop/iam/internal/model"
+	"github.com/linemk/rocket-shop/iam/internal/repository/mfa_challenge"
 	"github.com/linemk/rocket-shop/iam/internal/repository/session"
 	"github.com/linemk/rocket-shop/iam/internal/repository/user"
+	"github.com/linemk/rocket-shop/iam/internal/service/access_token"
+	"github.com/linemk/rocket-shop/iam/internal/service/audit"
+	"github.com/linemk/rocket-shop/iam/internal/service/mfa"
+	"github.com/linemk/rocket-shop/iam/internal/service/password"
 )
 
 type Service interface {
-	Login(ctx context.Context, login, password string) (*model.Session, error)
+	// Login проверяет пароль и создает сессию; при включенной 2FA вместо сессии возвращается MFA челлендж
+	Login(ctx context.Context, login, password string) (*model.LoginResult, error)
+	// LoginExternal завершает вход пользователя, подтвержденного внешним провайдером (OIDC).
+	// Как и при входе по паролю, при включенной 2FA возвращается MFA челлендж
+	LoginExternal(ctx context.Context, user *model.User) (*model.LoginResult, error)
+	// VerifyMFA проверяет второй фактор для MFA челленджа и создает сессию
+	VerifyMFA(ctx context.Context, challengeUUID, code string) (*model.LoginResult, error)
+	// RefreshAccessToken выпускает новый access токен для активной сессии
+	RefreshAccessToken(ctx context.Context, sessionUUID string) (*model.AccessToken, error)
 	Whoami(ctx context.Context, sessionUUID string) (*model.User, error)
+	// WhoamiSession возвращает пользователя вместе с его сессией, чтобы вызывающий мог учесть срок действия сессии
+	WhoamiSession(ctx context.Context, sessionUUID string) (*model.User, *model.Session, error)
+}
+
+type Logger interface {
+	Warn(ctx context.Context, msg string, fields ...zap.Field)
 }
 
 type service struct {
-	userRepo    user.Repository
-	sessionRepo session.Repository
-	sessionCfg  config.SessionConfig
+	userRepo         user.Repository
+	sessionRepo      session.Repository
+	mfaChallengeRepo mfa_challenge.Repository
+	mfaService       mfa.Service
+	passwordService  password.Service
+	accessTokenSvc   access_token.Service
+	auditService     audit.Service
+	sessionCfg       config.SessionConfig
+	mfaCfg           config.MFAConfig
+	jwtCfg           config.JWTConfig
+	logger           Logger
 }
 
-func NewService(userRepo user.Repository, sessionRepo session.Repository, sessionCfg config.SessionConfig) Service {
+func NewService(
+	userRepo user.Repository,
+	sessionRepo session.Repository,
+	mfaChallengeRepo mfa_challenge.Repository,
+	mfaService mfa.Service,
+	passwordService password.Service,
+	accessTokenSvc access_token.Service,
+	auditService audit.Service,
+	sessionCfg config.SessionConfig,
+	mfaCfg config.MFAConfig,
+	jwtCfg config.JWTConfig,
+	logger Logger,
+) Service {
 	return &service{
-		userRepo:    userRepo,
-		sessionRepo: sessionRepo,
-		sessionCfg:  sessionCfg,
+		userRepo:         userRepo,
+		sessionRepo:      sessionRepo,
+		mfaChallengeRepo: mfaChallengeRepo,
+		mfaService:       mfaService,
+		passwordService:  passwordService,
+		accessTokenSvc:   accessTokenSvc,
+		auditService:     auditService,
+		sessionCfg:       sessionCfg,
+		mfaCfg:           mfaCfg,
+		jwtCfg:           jwtCfg,
+		logger:           logger,
 	}
 }
 
-func (s *service) Login(ctx context.Context, login, password string) (*model.Session, error) {
+func (s *service) Login(ctx context.Context, login, password string) (*model.LoginResult, error) {
 	user, err := s.userRepo.GetByLogin(ctx, login)
 	if err != nil {
 		if errors.Is(err, model.ErrUserNotFound) {
+			s.auditService.Record(ctx, &model.AuditEvent{
+				EventType: model.AuditEventLoginFailed,
+				Login:     login,
+				Reason:    "unknown login",
+			})
 			return nil, model.ErrInvalidCredentials
 		}
 		return nil, errors.Wrap(err, "failed to get user")
 	}
 
-	err = bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password))
+	needsRehash, err := s.passwordService.Verify(user.PasswordHash, password)
 	if err != nil {
+		if !errors.Is(err, model.ErrInvalidPassword) {
+			return nil, errors.Wrap(err, "failed to verify password")
+		}
+
+		s.auditService.Record(ctx, &model.AuditEvent{
+			EventType: model.AuditEventLoginFailed,
+			UserUUID:  user.UserUUID,
+			Login:     login,
+			Reason:    "invalid password",
+		})
 		return nil, model.ErrInvalidCredentials
 	}
 
+	if needsRehash {
+		s.rehashPassword(ctx, user, password)
+	}
+
+	return s.loginAuthenticated(ctx, user)
+}
+
+func (s *service) LoginExternal(ctx context.Context, user *model.User) (*model.LoginResult, error) {
+	return s.loginAuthenticated(ctx, user)
+}
+
+// loginAuthenticated продолжает вход после проверки первого фактора
+func (s *service) loginAuthenticated(ctx context.Context, user *model.User) (*model.LoginResult, error) {
+	if user.TOTPEnabled {
+		challenge, err := s.createMFAChallenge(ctx, user.UserUUID)
+		if err != nil {
+			return nil, err
+		}
+
+		return &model.LoginResult{MFAChallenge: challenge}, nil
+	}
+
+	return s.completeLogin(ctx, user)
+}
+
+func (s *service) VerifyMFA(ctx context.Context, challengeUUID, code string) (*model.LoginResult, error) {
+	challenge, err := s.mfaChallengeRepo.Get(ctx, challengeUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, challenge.UserUUID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get user")
+	}
+
+	// Попытка учитывается атомарно до проверки кода: параллельные запросы получают разные номера попыток,
+	// и ни один из них не проверяет код сверх MaxAttempts
+	attempts, err := s.mfaChallengeRepo.IncrementAttempts(ctx, challenge)
+	if err != nil {
+		if errors.Is(err, model.ErrMFAChallengeNotFound) {
+			return nil, err
+		}
+		return nil, errors.Wrap(err, "failed to count mfa attempt")
+	}
+	if attempts > s.mfaCfg.MaxAttempts() {
+		return nil, s.rejectMFAChallenge(ctx, user, challengeUUID)
+	}
+
+	err = s.mfaService.Verify(ctx, user, code)
+	if err != nil {
+		if !errors.Is(err, model.ErrInvalidMFACode) {
+			return nil, err
+		}
+
+		if attempts == s.mfaCfg.MaxAttempts() {
+			return nil, s.rejectMFAChallenge(ctx, user, challengeUUID)
+		}
+
+		s.recordLoginFailed(ctx, user, "invalid mfa code")
+
+		return nil, err
+	}
+
+	err = s.mfaChallengeRepo.Delete(ctx, challengeUUID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to delete mfa challenge")
+	}
+
+	return s.completeLogin(ctx, user)
+}
+
+// rejectMFAChallenge удаляет челлендж, исчерпавший попытки ввода кода
+func (s *service) rejectMFAChallenge(ctx context.Context, user *model.User, challengeUUID string) error {
+	err := s.mfaChallengeRepo.Delete(ctx, challengeUUID)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete mfa challenge")
+	}
+
+	s.recordLoginFailed(ctx, user, "too many mfa attempts")
+
+	return model.ErrTooManyMFAAttempts
+}
+
+func (s *service) RefreshAccessToken(ctx context.Context, sessionUUID string) (*model.AccessToken, error) {
+	if !s.jwtCfg.Enabled() {
+		return nil, model.ErrAccessTokensDisabled
+	}
+
+	user, err := s.Whoami(ctx, sessionUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	accessToken, err := s.accessTokenSvc.Issue(ctx, user, sessionUUID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to issue access token")
+	}
+
+	return accessToken, nil
+}
+
+// completeLogin создает сессию и, если включено, выпускает для нее access токен
+func (s *service) completeLogin(ctx context.Context, user *model.User) (*model.LoginResult, error) {
+	session, err := s.createSession(ctx, user.UserUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &model.LoginResult{Session: session}
+
+	s.auditService.Record(ctx, &model.AuditEvent{
+		EventType:   model.AuditEventLoginSucceeded,
+		UserUUID:    user.UserUUID,
+		Login:       user.Login,
+		SessionUUID: session.SessionUUID,
+	})
+
+	if s.jwtCfg.Enabled() {
+		result.AccessToken, err = s.accessTokenSvc.Issue(ctx, user, session.SessionUUID)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to issue access token")
+		}
+	}
+
+	return result, nil
+}
+
+func (s *service) createMFAChallenge(ctx context.Context, userUUID string) (*model.MFAChallenge, error) {
+	now := time.Now()
+
+	challenge := &model.MFAChallenge{
+		ChallengeUUID: uuid.New().String(),
+		UserUUID:      userUUID,
+		CreatedAt:     now,
+		ExpiresAt:     now.Add(s.mfaCfg.ChallengeTTL()),
+	}
+
+	err := s.mfaChallengeRepo.Save(ctx, challenge)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to save mfa challenge")
+	}
+
+	return challenge, nil
+}
+
+func (s *service) createSession(ctx context.Context, userUUID string) (*model.Session, error) {
 	now := time.Now()
 	expiresAt := now.Add(s.sessionCfg.TTL())
 
 	session := &model.Session{
 		SessionUUID: uuid.New().String(),
-		UserUUID:    user.UserUUID,
+		UserUUID:    userUUID,
 		CreatedAt:   now,
 		ExpiresAt:   expiresAt,
 	}
 
-	err = s.sessionRepo.Create(ctx, session, s.sessionCfg.TTL())
+	err := s.sessionRepo.Create(ctx, session, s.sessionCfg.TTL())
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create session")
 	}
 
-	err = s.sessionRepo.AddSessionToUserSet(ctx, user.UserUUID, session.SessionUUID)
+	err = s.sessionRepo.AddSessionToUserSet(ctx, userUUID, session.SessionUUID)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to add session to user set")
 	}
 
+	s.auditService.Record(ctx, &model.AuditEvent{
+		EventType:   model.AuditEventSessionCreated,
+		UserUUID:    userUUID,
+		SessionUUID: session.SessionUUID,
+	})
+
 	return session, nil
 }
 
+// rehashPassword пересчитывает устаревший хеш пароля. Ошибка не прерывает вход:
+// хеш будет пересчитан при следующем успешном входе
+func (s *service) rehashPassword(ctx context.Context, user *model.User, password string) {
+	passwordHash, err := s.passwordService.Hash(password)
+	if err == nil {
+		err = s.userRepo.UpdatePasswordHash(ctx, user.UserUUID, passwordHash)
+	}
+	if err != nil {
+		s.logger.Warn(ctx, "Failed to upgrade password hash",
+			zap.String("user_uuid", user.UserUUID),
+			zap.Error(err),
+		)
+		return
+	}
+
+	user.PasswordHash = passwordHash
+}
+
+func (s *service) recordLoginFailed(ctx context.Context, user *model.User, reason string) {
+	s.auditService.Record(ctx, &model.AuditEvent{
+		EventType: model.AuditEventLoginFailed,
+		UserUUID:  user.UserUUID,
+		Login:     user.Login,
+		Reason:    reason,
+	})
+}
+
 func (s *service) Whoami(ctx context.Context, sessionUUID string) (*model.User, error) {
+	user, _, err := s.WhoamiSession(ctx, sessionUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (s *service) WhoamiSession(ctx context.Context, sessionUUID string) (*model.User, *model.Session, error) {
 	session, err := s.sessionRepo.Get(ctx, sessionUUID)
 	if err != nil {
 		if errors.Is(err, model.ErrSessionNotFound) {
-			return nil, model.ErrSessionNotFound
+			return nil, nil, model.ErrSessionNotFound
 		}
-		return nil, errors.Wrap(err, "failed to get session")
+		return nil, nil, errors.Wrap(err, "failed to get session")
 	}
 
 	if time.Now().After(session.ExpiresAt) {
 		err := s.sessionRepo.Delete(ctx, sessionUUID)
 		if err != nil {
-			return nil, errors.Wrap(err, "failed to delete expired session")
+			return nil, nil, errors.Wrap(err, "failed to delete expired session")
 		}
-		return nil, model.ErrSessionExpired
+
+		s.auditService.Record(ctx, &model.AuditEvent{
+			EventType:   model.AuditEventSessionRevoked,
+			UserUUID:    session.UserUUID,
+			SessionUUID: sessionUUID,
+			Reason:      "expired",
+		})
+
+		return nil, nil, model.ErrSessionExpired
 	}
 
 	user, err := s.userRepo.GetByID(ctx, session.UserUUID)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to get user")
+		return nil, nil, errors.Wrap(err, "failed to get user")
 	}
 
-	return user, nil
+	return user, session, nil
 }
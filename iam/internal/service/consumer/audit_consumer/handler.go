@@ -0,0 +1,48 @@
+package audit_consumer
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/linemk/rocket-shop/iam/internal/service/audit"
+	"github.com/linemk/rocket-shop/iam/internal/service/converter"
+	platformKafka "github.com/linemk/rocket-shop/platform/pkg/kafka"
+	eventsv1 "github.com/linemk/rocket-shop/shared/pkg/proto/events/v1"
+)
+
+type handler struct {
+	auditService audit.Service
+	logger       Logger
+}
+
+func NewHandler(auditService audit.Service, logger Logger) platformKafka.MessageHandler {
+	h := &handler{
+		auditService: auditService,
+		logger:       logger,
+	}
+
+	return h.Handle
+}
+
+func (h *handler) Handle(ctx context.Context, msg platformKafka.Message) error {
+	var protoEvent eventsv1.AuthAuditEvent
+	if err := protojson.Unmarshal(msg.Value, &protoEvent); err != nil {
+		h.logger.Error(ctx, "Failed to decode AuthAuditEvent event", zap.Error(err))
+		return errors.Wrap(err, "failed to decode AuthAuditEvent event")
+	}
+
+	event := converter.AuthAuditEventFromProto(&protoEvent)
+
+	if err := h.auditService.Save(ctx, event); err != nil {
+		h.logger.Error(ctx, "Failed to save audit event",
+			zap.String("event_uuid", event.EventUUID),
+			zap.Error(err),
+		)
+		return errors.Wrap(err, "failed to save audit event")
+	}
+
+	return nil
+}
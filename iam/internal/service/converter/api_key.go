@@ -0,0 +1,42 @@
+package converter
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	authv1 "github.com/linemk/rocket-shop/shared/pkg/proto/auth/v1"
+
+	"github.com/linemk/rocket-shop/iam/internal/model"
+)
+
+func APIKeyToProto(key *model.APIKey) *authv1.APIKey {
+	if key == nil {
+		return nil
+	}
+
+	protoKey := &authv1.APIKey{
+		ApiKeyUuid: key.APIKeyUUID,
+		Name:       key.Name,
+		KeyPrefix:  key.KeyPrefix,
+		Scopes:     key.Scopes,
+		CreatedAt:  timestamppb.New(key.CreatedAt),
+	}
+
+	if key.ExpiresAt != nil {
+		protoKey.ExpiresAt = timestamppb.New(*key.ExpiresAt)
+	}
+
+	if key.RevokedAt != nil {
+		protoKey.RevokedAt = timestamppb.New(*key.RevokedAt)
+	}
+
+	return protoKey
+}
+
+func APIKeysToProto(keys []*model.APIKey) []*authv1.APIKey {
+	protoKeys := make([]*authv1.APIKey, len(keys))
+	for i, key := range keys {
+		protoKeys[i] = APIKeyToProto(key)
+	}
+
+	return protoKeys
+}
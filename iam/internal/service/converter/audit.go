@@ -0,0 +1,38 @@
+package converter
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	authv1 "github.com/linemk/rocket-shop/shared/pkg/proto/auth/v1"
+
+	"github.com/linemk/rocket-shop/iam/internal/model"
+)
+
+func AuditEventsToProto(events []*model.AuditEvent) []*authv1.AuditEvent {
+	protoEvents := make([]*authv1.AuditEvent, len(events))
+	for i, event := range events {
+		protoEvents[i] = &authv1.AuditEvent{
+			EventUuid:   event.EventUUID,
+			EventType:   string(event.EventType),
+			UserUuid:    event.UserUUID,
+			Login:       event.Login,
+			SessionUuid: event.SessionUUID,
+			IpAddress:   event.Client.IPAddress,
+			UserAgent:   event.Client.UserAgent,
+			Reason:      event.Reason,
+			Resource:    event.Resource,
+			OccurredAt:  timestamppb.New(event.OccurredAt),
+		}
+	}
+
+	return protoEvents
+}
+
+func AuditEventTypesFromProto(eventTypes []string) []model.AuditEventType {
+	types := make([]model.AuditEventType, len(eventTypes))
+	for i, eventType := range eventTypes {
+		types[i] = model.AuditEventType(eventType)
+	}
+
+	return types
+}
@@ -0,0 +1,76 @@
+package converter
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	eventsv1 "github.com/linemk/rocket-shop/shared/pkg/proto/events/v1"
+
+	"github.com/linemk/rocket-shop/iam/internal/model"
+)
+
+func AuthTokenIssuedToProto(event *model.AuthTokenIssuedEvent) *eventsv1.AuthTokenIssued {
+	if event == nil {
+		return nil
+	}
+
+	return &eventsv1.AuthTokenIssued{
+		EventUuid: event.EventUUID,
+		UserUuid:  event.UserUUID,
+		Email:     event.Email,
+		Purpose:   string(event.Purpose),
+		Token:     event.Token,
+		TtlSec:    event.TTLSec,
+	}
+}
+
+func UserErasedToProto(event *model.UserErasedEvent) *eventsv1.UserErased {
+	if event == nil {
+		return nil
+	}
+
+	return &eventsv1.UserErased{
+		EventUuid: event.EventUUID,
+		UserUuid:  event.UserUUID,
+		ErasedAt:  timestamppb.New(event.ErasedAt),
+	}
+}
+
+func AuthAuditEventToProto(event *model.AuditEvent) *eventsv1.AuthAuditEvent {
+	if event == nil {
+		return nil
+	}
+
+	return &eventsv1.AuthAuditEvent{
+		EventUuid:   event.EventUUID,
+		EventType:   string(event.EventType),
+		UserUuid:    event.UserUUID,
+		Login:       event.Login,
+		SessionUuid: event.SessionUUID,
+		IpAddress:   event.Client.IPAddress,
+		UserAgent:   event.Client.UserAgent,
+		Reason:      event.Reason,
+		Resource:    event.Resource,
+		OccurredAt:  timestamppb.New(event.OccurredAt),
+	}
+}
+
+func AuthAuditEventFromProto(event *eventsv1.AuthAuditEvent) *model.AuditEvent {
+	if event == nil {
+		return nil
+	}
+
+	return &model.AuditEvent{
+		EventUUID:   event.EventUuid,
+		EventType:   model.AuditEventType(event.EventType),
+		UserUUID:    event.UserUuid,
+		Login:       event.Login,
+		SessionUUID: event.SessionUuid,
+		Client: model.ClientInfo{
+			IPAddress: event.IpAddress,
+			UserAgent: event.UserAgent,
+		},
+		Reason:     event.Reason,
+		Resource:   event.Resource,
+		OccurredAt: event.OccurredAt.AsTime(),
+	}
+}
@@ -24,6 +24,8 @@ func UserToProto(user *model.User) *commonv1.User {
 		Login:               user.Login,
 		Email:               user.Email,
 		NotificationMethods: notificationMethods,
+		EmailVerified:       user.EmailVerified,
+		Roles:               user.Roles,
 	}
 }
 
@@ -32,18 +34,22 @@ func UserFromProto(user *commonv1.User) *model.User {
 		return nil
 	}
 
-	notificationMethods := make([]model.NotificationMethod, len(user.NotificationMethods))
-	for i, method := range user.NotificationMethods {
+	return &model.User{
+		UserUUID:            user.UserUuid,
+		Login:               user.Login,
+		Email:               user.Email,
+		NotificationMethods: NotificationMethodsFromProto(user.NotificationMethods),
+	}
+}
+
+func NotificationMethodsFromProto(methods []*commonv1.NotificationMethod) []model.NotificationMethod {
+	notificationMethods := make([]model.NotificationMethod, len(methods))
+	for i, method := range methods {
 		notificationMethods[i] = model.NotificationMethod{
 			ProviderName: method.ProviderName,
 			Target:       method.Target,
 		}
 	}
 
-	return &model.User{
-		UserUUID:            user.UserUuid,
-		Login:               user.Login,
-		Email:               user.Email,
-		NotificationMethods: notificationMethods,
-	}
+	return notificationMethods
 }
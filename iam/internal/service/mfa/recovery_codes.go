@@ -0,0 +1,60 @@
+package mfa
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/hex"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// recoveryCodesCount количество выпускаемых кодов восстановления
+	recoveryCodesCount = 10
+	// recoveryCodeSize размер кода восстановления в байтах
+	recoveryCodeSize = 5
+)
+
+// generateRecoveryCodes генерирует коды восстановления и возвращает их вместе с хешами для хранения
+func generateRecoveryCodes() (codes, hashes []string, err error) {
+	codes = make([]string, 0, recoveryCodesCount)
+	hashes = make([]string, 0, recoveryCodesCount)
+
+	for i := 0; i < recoveryCodesCount; i++ {
+		buf := make([]byte, recoveryCodeSize)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, nil, errors.Wrap(err, "failed to generate recovery code")
+		}
+
+		// 5 байт кодируются в 8 символов base32 без паддинга: XXXX-XXXX
+		encoded := strings.ToLower(base32.StdEncoding.EncodeToString(buf))
+		code := encoded[:4] + "-" + encoded[4:]
+
+		codes = append(codes, code)
+		hashes = append(hashes, hashRecoveryCode(code))
+	}
+
+	return codes, hashes, nil
+}
+
+// hashRecoveryCode хеширует код восстановления; коды случайные и длинные, поэтому соли не требуется
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+// matchRecoveryCode ищет код среди хешей и возвращает его индекс или -1
+func matchRecoveryCode(hashes []string, code string) int {
+	hash := hashRecoveryCode(code)
+	for i, h := range hashes {
+		if subtle.ConstantTimeCompare([]byte(h), []byte(hash)) == 1 {
+			return i
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,222 @@
+package mfa
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/pquerna/otp/totp"
+
+	"github.com/linemk/rocket-shop/iam/internal/config"
+	"github.com/linemk/rocket-shop/iam/internal/model"
+	"github.com/linemk/rocket-shop/iam/internal/repository/user"
+	"github.com/linemk/rocket-shop/platform/pkg/aesgcm"
+)
+
+type Service interface {
+	// Enroll генерирует новый TOTP секрет; 2FA включается только после Confirm
+	Enroll(ctx context.Context, userUUID string) (*model.TOTPEnrollment, error)
+	// Confirm проверяет код, включает 2FA и возвращает коды восстановления
+	Confirm(ctx context.Context, userUUID, code string) ([]string, error)
+	Disable(ctx context.Context, userUUID, code string) error
+	RegenerateRecoveryCodes(ctx context.Context, userUUID, code string) ([]string, error)
+	// Verify проверяет TOTP код или код восстановления; использованный код восстановления удаляется
+	Verify(ctx context.Context, user *model.User, code string) error
+}
+
+type service struct {
+	userRepo user.Repository
+	mfaCfg   config.MFAConfig
+}
+
+func NewService(userRepo user.Repository, mfaCfg config.MFAConfig) Service {
+	return &service{
+		userRepo: userRepo,
+		mfaCfg:   mfaCfg,
+	}
+}
+
+func (s *service) Enroll(ctx context.Context, userUUID string) (*model.TOTPEnrollment, error) {
+	user, err := s.userRepo.GetByID(ctx, userUUID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get user")
+	}
+
+	if user.TOTPEnabled {
+		return nil, model.ErrMFAAlreadyEnabled
+	}
+
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      s.mfaCfg.Issuer(),
+		AccountName: user.Login,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate totp secret")
+	}
+
+	encryptedSecret, err := aesgcm.Encrypt(s.mfaCfg.EncryptionKey(), []byte(key.Secret()))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encrypt totp secret")
+	}
+
+	user.TOTPSecret = encryptedSecret
+
+	err = s.userRepo.UpdateMFA(ctx, user)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to update mfa")
+	}
+
+	return &model.TOTPEnrollment{
+		Secret: key.Secret(),
+		URI:    key.URL(),
+	}, nil
+}
+
+func (s *service) Confirm(ctx context.Context, userUUID, code string) ([]string, error) {
+	user, err := s.userRepo.GetByID(ctx, userUUID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get user")
+	}
+
+	if user.TOTPEnabled {
+		return nil, model.ErrMFAAlreadyEnabled
+	}
+
+	err = s.validateTOTP(ctx, user, code)
+	if err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	user.TOTPEnabled = true
+	user.RecoveryCodeHashes = hashes
+
+	err = s.userRepo.UpdateMFA(ctx, user)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to update mfa")
+	}
+
+	return codes, nil
+}
+
+func (s *service) Disable(ctx context.Context, userUUID, code string) error {
+	user, err := s.userRepo.GetByID(ctx, userUUID)
+	if err != nil {
+		return errors.Wrap(err, "failed to get user")
+	}
+
+	err = s.Verify(ctx, user, code)
+	if err != nil {
+		return err
+	}
+
+	user.TOTPEnabled = false
+	user.TOTPSecret = nil
+	user.RecoveryCodeHashes = nil
+
+	err = s.userRepo.UpdateMFA(ctx, user)
+	if err != nil {
+		return errors.Wrap(err, "failed to update mfa")
+	}
+
+	return nil
+}
+
+func (s *service) RegenerateRecoveryCodes(ctx context.Context, userUUID, code string) ([]string, error) {
+	user, err := s.userRepo.GetByID(ctx, userUUID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get user")
+	}
+
+	if !user.TOTPEnabled {
+		return nil, model.ErrMFANotEnabled
+	}
+
+	// Перевыпуск допускается только по TOTP коду, а не по коду восстановления
+	err = s.validateTOTP(ctx, user, code)
+	if err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	user.RecoveryCodeHashes = hashes
+
+	err = s.userRepo.UpdateMFA(ctx, user)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to update mfa")
+	}
+
+	return codes, nil
+}
+
+func (s *service) Verify(ctx context.Context, user *model.User, code string) error {
+	if !user.TOTPEnabled {
+		return model.ErrMFANotEnabled
+	}
+
+	err := s.validateTOTP(ctx, user, code)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, model.ErrInvalidMFACode) {
+		return err
+	}
+
+	idx := matchRecoveryCode(user.RecoveryCodeHashes, code)
+	if idx == -1 {
+		return model.ErrInvalidMFACode
+	}
+
+	// Код восстановления одноразовый: гасим его условно, а не перезаписью массива из прочитанной копии,
+	// иначе параллельная проверка того же кода тоже прошла бы
+	consumed, err := s.userRepo.ConsumeRecoveryCode(ctx, user.UserUUID, user.RecoveryCodeHashes[idx])
+	if err != nil {
+		return errors.Wrap(err, "failed to consume recovery code")
+	}
+	if !consumed {
+		return model.ErrInvalidMFACode
+	}
+
+	user.RecoveryCodeHashes = append(user.RecoveryCodeHashes[:idx], user.RecoveryCodeHashes[idx+1:]...)
+
+	return nil
+}
+
+// validateTOTP проверяет TOTP код по расшифрованному секрету пользователя.
+// Код из уже принятого шага отклоняется, поэтому перехваченный код нельзя использовать повторно
+func (s *service) validateTOTP(ctx context.Context, user *model.User, code string) error {
+	if len(user.TOTPSecret) == 0 {
+		return model.ErrMFANotEnrolled
+	}
+
+	secret, err := aesgcm.Decrypt(s.mfaCfg.EncryptionKey(), user.TOTPSecret)
+	if err != nil {
+		return errors.Wrap(err, "failed to decrypt totp secret")
+	}
+
+	step, ok, err := matchTOTPStep(string(secret), code, time.Now())
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return model.ErrInvalidMFACode
+	}
+
+	advanced, err := s.userRepo.AdvanceTOTPStep(ctx, user.UserUUID, step)
+	if err != nil {
+		return errors.Wrap(err, "failed to save totp step")
+	}
+	if !advanced {
+		return model.ErrInvalidMFACode
+	}
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package mfa
+
+import (
+	"crypto/subtle"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+)
+
+const (
+	// totpPeriod длительность шага TOTP в секундах
+	totpPeriod = 30
+	// totpSkew количество соседних шагов, принимаемых для компенсации расхождения часов
+	totpSkew = 1
+)
+
+// matchTOTPStep возвращает шаг, которому соответствует код. Шаг нужен для защиты от повторного
+// использования: код принимается, только если его шаг позже последнего принятого
+func matchTOTPStep(secret, code string, now time.Time) (int64, bool, error) {
+	current := now.Unix() / totpPeriod
+
+	for offset := int64(-totpSkew); offset <= totpSkew; offset++ {
+		step := current + offset
+
+		expected, err := totp.GenerateCodeCustom(secret, time.Unix(step*totpPeriod, 0), totp.ValidateOpts{
+			Period:    totpPeriod,
+			Digits:    otp.DigitsSix,
+			Algorithm: otp.AlgorithmSHA1,
+		})
+		if err != nil {
+			return 0, false, errors.Wrap(err, "failed to generate totp code")
+		}
+
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true, nil
+		}
+	}
+
+	return 0, false, nil
+}
@@ -0,0 +1,90 @@
+package oidc
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/linemk/rocket-shop/iam/internal/model"
+)
+
+// resolveUser возвращает пользователя, привязанного к учетной записи провайдера.
+// Новая учетная запись привязывается к пользователю с тем же email, только если email
+// подтвержден и провайдером, и в IAM: иначе чужой аккаунт можно было бы захватить,
+// заранее зарегистрировав его email. Подтвержденный email уникален, поэтому кандидат не более одного
+func (s *service) resolveUser(ctx context.Context, providerName string, claims *model.OIDCClaims) (*model.User, error) {
+	linked, err := s.identityRepo.Get(ctx, providerName, claims.Subject)
+	if err == nil {
+		u, err := s.userRepo.GetByID(ctx, linked.UserUUID)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get linked user")
+		}
+		return u, nil
+	}
+	if !errors.Is(err, model.ErrIdentityNotFound) {
+		return nil, errors.Wrap(err, "failed to get identity")
+	}
+
+	if claims.Email == "" || !claims.EmailVerified {
+		return nil, model.ErrOIDCEmailNotVerified
+	}
+
+	u, err := s.userRepo.GetByVerifiedEmail(ctx, claims.Email)
+	switch {
+	case err == nil:
+	case errors.Is(err, model.ErrUserNotFound):
+		if !s.cfg.AllowSignup() {
+			return nil, model.ErrOIDCAccountNotLinkable
+		}
+
+		u, err = s.createUser(ctx, providerName, claims)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errors.Wrap(err, "failed to get user by email")
+	}
+
+	err = s.identityRepo.Create(ctx, &model.ExternalIdentity{
+		Provider:  providerName,
+		Subject:   claims.Subject,
+		UserUUID:  u.UserUUID,
+		Email:     claims.Email,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to link identity")
+	}
+
+	return u, nil
+}
+
+// createUser регистрирует пользователя без локального пароля; задать пароль можно через сброс пароля
+func (s *service) createUser(ctx context.Context, providerName string, claims *model.OIDCClaims) (*model.User, error) {
+	login := claims.Email
+
+	_, err := s.userRepo.GetByLogin(ctx, login)
+	switch {
+	case err == nil:
+		login = providerName + ":" + claims.Subject
+	case !errors.Is(err, model.ErrUserNotFound):
+		return nil, errors.Wrap(err, "failed to check if user exists")
+	}
+
+	newUser := &model.User{
+		UserUUID:      uuid.New().String(),
+		Login:         login,
+		Email:         claims.Email,
+		EmailVerified: true,
+		Roles:         []string{model.RoleUser},
+	}
+
+	err = s.userRepo.Create(ctx, newUser)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create user")
+	}
+
+	return newUser, nil
+}
@@ -0,0 +1,127 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	gooidc "github.com/coreos/go-oidc/v3/oidc"
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+
+	"github.com/linemk/rocket-shop/iam/internal/config/env"
+	"github.com/linemk/rocket-shop/iam/internal/model"
+)
+
+// httpTimeout ограничивает запросы к провайдеру: discovery, JWKS и обмен кода
+const httpTimeout = 10 * time.Second
+
+// provider клиент OIDC провайдера. Discovery выполняется при первом обращении,
+// чтобы недоступный провайдер не мешал запуску IAM
+type provider struct {
+	cfg        env.OIDCProvider
+	httpClient *http.Client
+
+	mu       sync.Mutex
+	oauth2   *oauth2.Config
+	verifier *gooidc.IDTokenVerifier
+}
+
+func newProvider(cfg env.OIDCProvider) *provider {
+	return &provider{
+		cfg:        cfg,
+		httpClient: &http.Client{Timeout: httpTimeout},
+	}
+}
+
+func (p *provider) clientContext(ctx context.Context) context.Context {
+	return gooidc.ClientContext(ctx, p.httpClient)
+}
+
+// discover загружает метаданные провайдера; при ошибке попытка повторяется на следующем запросе
+func (p *provider) discover(ctx context.Context) (*oauth2.Config, *gooidc.IDTokenVerifier, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.oauth2 != nil {
+		return p.oauth2, p.verifier, nil
+	}
+
+	// Ключи провайдера подгружаются и после завершения запроса, поэтому отвязываемся от его отмены
+	discoveryCtx := p.clientContext(context.WithoutCancel(ctx))
+
+	oidcProvider, err := gooidc.NewProvider(discoveryCtx, p.cfg.IssuerURL)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to discover oidc provider %q", p.cfg.Name)
+	}
+
+	p.oauth2 = &oauth2.Config{
+		ClientID:     p.cfg.ClientID,
+		ClientSecret: p.cfg.ClientSecret,
+		Endpoint:     oidcProvider.Endpoint(),
+		RedirectURL:  p.cfg.RedirectURL,
+		Scopes:       p.cfg.Scopes,
+	}
+	p.verifier = oidcProvider.Verifier(&gooidc.Config{ClientID: p.cfg.ClientID})
+
+	return p.oauth2, p.verifier, nil
+}
+
+// authCodeURL возвращает адрес авторизации с state, nonce и PKCE челленджем
+func (p *provider) authCodeURL(ctx context.Context, state *model.OIDCState) (string, error) {
+	oauth2Cfg, _, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return oauth2Cfg.AuthCodeURL(
+		state.State,
+		gooidc.Nonce(state.Nonce),
+		oauth2.S256ChallengeOption(state.CodeVerifier),
+	), nil
+}
+
+// exchange обменивает код авторизации на ID токен и возвращает проверенные данные пользователя
+func (p *provider) exchange(ctx context.Context, code string, state *model.OIDCState) (*model.OIDCClaims, error) {
+	oauth2Cfg, verifier, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = p.clientContext(ctx)
+
+	token, err := oauth2Cfg.Exchange(ctx, code, oauth2.VerifierOption(state.CodeVerifier))
+	if err != nil {
+		return nil, errors.Wrapf(model.ErrOIDCLoginFailed, "failed to exchange code: %v", err)
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return nil, errors.Wrap(model.ErrOIDCLoginFailed, "token response has no id_token")
+	}
+
+	idToken, err := verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, errors.Wrapf(model.ErrOIDCLoginFailed, "failed to verify id_token: %v", err)
+	}
+
+	if idToken.Nonce != state.Nonce {
+		return nil, errors.Wrap(model.ErrOIDCLoginFailed, "id_token nonce mismatch")
+	}
+
+	var claims struct {
+		Email         string `json:"email"`
+		EmailVerified bool   `json:"email_verified"`
+	}
+	err = idToken.Claims(&claims)
+	if err != nil {
+		return nil, errors.Wrapf(model.ErrOIDCLoginFailed, "failed to parse id_token claims: %v", err)
+	}
+
+	return &model.OIDCClaims{
+		Subject:       idToken.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified,
+	}, nil
+}
@@ -0,0 +1,177 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+
+	"github.com/linemk/rocket-shop/iam/internal/config"
+	"github.com/linemk/rocket-shop/iam/internal/model"
+	"github.com/linemk/rocket-shop/iam/internal/repository/identity"
+	"github.com/linemk/rocket-shop/iam/internal/repository/oidc_state"
+	"github.com/linemk/rocket-shop/iam/internal/repository/user"
+	"github.com/linemk/rocket-shop/iam/internal/service/audit"
+	"github.com/linemk/rocket-shop/iam/internal/service/auth"
+)
+
+// randomValueSize размер state и nonce в байтах
+const randomValueSize = 32
+
+type Service interface {
+	// Start создает state для входа через провайдера и возвращает адрес авторизации
+	// вместе со значением, которое нужно сохранить в cookie браузера
+	Start(ctx context.Context, providerName string) (*model.OIDCLoginStart, error)
+	// Complete проверяет ответ провайдера и cookie браузера, находит или создает пользователя и выполняет вход
+	Complete(ctx context.Context, providerName, state, code, binding string) (*model.LoginResult, error)
+}
+
+type service struct {
+	userRepo     user.Repository
+	identityRepo identity.Repository
+	stateRepo    oidc_state.Repository
+	authService  auth.Service
+	auditService audit.Service
+	providers    map[string]*provider
+	cfg          config.OIDCConfig
+}
+
+func NewService(
+	userRepo user.Repository,
+	identityRepo identity.Repository,
+	stateRepo oidc_state.Repository,
+	authService auth.Service,
+	auditService audit.Service,
+	cfg config.OIDCConfig,
+) Service {
+	providers := make(map[string]*provider, len(cfg.Providers()))
+	for _, providerCfg := range cfg.Providers() {
+		providers[providerCfg.Name] = newProvider(providerCfg)
+	}
+
+	return &service{
+		userRepo:     userRepo,
+		identityRepo: identityRepo,
+		stateRepo:    stateRepo,
+		authService:  authService,
+		auditService: auditService,
+		providers:    providers,
+		cfg:          cfg,
+	}
+}
+
+func (s *service) Start(ctx context.Context, providerName string) (*model.OIDCLoginStart, error) {
+	p, ok := s.providers[providerName]
+	if !ok {
+		return nil, model.ErrUnknownOIDCProvider
+	}
+
+	stateValue, err := randomValue()
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := randomValue()
+	if err != nil {
+		return nil, err
+	}
+
+	binding, err := randomValue()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	state := &model.OIDCState{
+		State:        stateValue,
+		Provider:     providerName,
+		Nonce:        nonce,
+		CodeVerifier: oauth2.GenerateVerifier(),
+		BindingHash:  hashBinding(binding),
+		CreatedAt:    now,
+		ExpiresAt:    now.Add(s.cfg.StateTTL()),
+	}
+
+	authURL, err := p.authCodeURL(ctx, state)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.stateRepo.Save(ctx, state)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to save oidc state")
+	}
+
+	return &model.OIDCLoginStart{
+		AuthorizationURL: authURL,
+		Binding:          binding,
+		ExpiresAt:        state.ExpiresAt,
+	}, nil
+}
+
+func (s *service) Complete(ctx context.Context, providerName, stateValue, code, binding string) (*model.LoginResult, error) {
+	p, ok := s.providers[providerName]
+	if !ok {
+		return nil, model.ErrUnknownOIDCProvider
+	}
+
+	state, err := s.stateRepo.Consume(ctx, stateValue)
+	if err != nil {
+		return nil, err
+	}
+
+	if state.Provider != providerName {
+		return nil, model.ErrInvalidOIDCState
+	}
+
+	// state должен вернуться в тот же браузер, который начинал вход
+	if binding == "" || subtle.ConstantTimeCompare([]byte(hashBinding(binding)), []byte(state.BindingHash)) != 1 {
+		return nil, model.ErrInvalidOIDCState
+	}
+
+	claims, err := p.exchange(ctx, code, state)
+	if err != nil {
+		s.recordLoginFailed(ctx, nil, providerName, err)
+		return nil, err
+	}
+
+	u, err := s.resolveUser(ctx, providerName, claims)
+	if err != nil {
+		s.recordLoginFailed(ctx, claims, providerName, err)
+		return nil, err
+	}
+
+	return s.authService.LoginExternal(ctx, u)
+}
+
+func (s *service) recordLoginFailed(ctx context.Context, claims *model.OIDCClaims, providerName string, err error) {
+	event := &model.AuditEvent{
+		EventType: model.AuditEventLoginFailed,
+		Reason:    "oidc " + providerName + ": " + err.Error(),
+	}
+	if claims != nil {
+		event.Login = claims.Email
+	}
+
+	s.auditService.Record(ctx, event)
+}
+
+func hashBinding(binding string) string {
+	sum := sha256.Sum256([]byte(binding))
+	return hex.EncodeToString(sum[:])
+}
+
+func randomValue() (string, error) {
+	buf := make([]byte, randomValueSize)
+	if _, err := rand.Read(buf); err != nil {
+		return "", errors.Wrap(err, "failed to generate random value")
+	}
+
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
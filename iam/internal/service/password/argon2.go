@@ -0,0 +1,87 @@
+package password
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/argon2"
+
+	"github.com/linemk/rocket-shop/iam/internal/model"
+)
+
+const (
+	argon2idPrefix = "$argon2id$"
+
+	argon2SaltSize = 16
+	argon2KeySize  = 32
+)
+
+// argon2Params параметры argon2id, которые кодируются в хеш вместе с солью
+type argon2Params struct {
+	memory      uint32
+	iterations  uint32
+	parallelism uint8
+}
+
+// hashArgon2id возвращает хеш в PHC формате: $argon2id$v=19$m=65536,t=3,p=2$<salt>$<hash>
+func hashArgon2id(password string, params argon2Params) (string, error) {
+	salt := make([]byte, argon2SaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return "", errors.Wrap(err, "failed to generate salt")
+	}
+
+	key := argon2.IDKey([]byte(password), salt, params.iterations, params.memory, params.parallelism, argon2KeySize)
+
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2idPrefix,
+		argon2.Version,
+		params.memory,
+		params.iterations,
+		params.parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// verifyArgon2id проверяет пароль и возвращает параметры, с которыми был получен хеш
+func verifyArgon2id(encodedHash, password string) (argon2Params, error) {
+	var params argon2Params
+
+	// "", "argon2id", "v=19", "m=...,t=...,p=...", salt, hash
+	parts := strings.Split(encodedHash, "$")
+	if len(parts) != 6 {
+		return params, errors.Wrap(model.ErrUnsupportedPasswordHash, "malformed argon2id hash")
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return params, errors.Wrap(model.ErrUnsupportedPasswordHash, "unsupported argon2 version")
+	}
+
+	_, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.memory, &params.iterations, &params.parallelism)
+	if err != nil {
+		return params, errors.Wrap(model.ErrUnsupportedPasswordHash, "malformed argon2id parameters")
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params, errors.Wrap(model.ErrUnsupportedPasswordHash, "malformed argon2id salt")
+	}
+
+	expected, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return params, errors.Wrap(model.ErrUnsupportedPasswordHash, "malformed argon2id hash")
+	}
+
+	key := argon2.IDKey([]byte(password), salt, params.iterations, params.memory, params.parallelism, uint32(len(expected))) //nolint:gosec // длина хеша ограничена форматом
+
+	if subtle.ConstantTimeCompare(key, expected) != 1 {
+		return params, model.ErrInvalidPassword
+	}
+
+	return params, nil
+}
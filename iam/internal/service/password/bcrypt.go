@@ -0,0 +1,35 @@
+package password
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/linemk/rocket-shop/iam/internal/model"
+)
+
+// bcryptPrefixes префиксы хешей bcrypt, созданных до перехода на argon2id
+var bcryptPrefixes = []string{"$2a$", "$2b$", "$2y$"}
+
+func isBcryptHash(encodedHash string) bool {
+	for _, prefix := range bcryptPrefixes {
+		if strings.HasPrefix(encodedHash, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func verifyBcrypt(encodedHash, password string) error {
+	err := bcrypt.CompareHashAndPassword([]byte(encodedHash), []byte(password))
+	if err != nil {
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return model.ErrInvalidPassword
+		}
+		return errors.Wrap(model.ErrUnsupportedPasswordHash, err.Error())
+	}
+
+	return nil
+}
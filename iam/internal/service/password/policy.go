@@ -0,0 +1,44 @@
+package password
+
+import (
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+
+	"github.com/linemk/rocket-shop/iam/internal/model"
+)
+
+func (s *service) Validate(password string) error {
+	length := utf8.RuneCountInString(password)
+	if length < s.cfg.MinLength() {
+		return errors.Wrapf(model.ErrWeakPassword, "password must be at least %d characters long", s.cfg.MinLength())
+	}
+	if length > s.cfg.MaxLength() {
+		return errors.Wrapf(model.ErrWeakPassword, "password must be at most %d characters long", s.cfg.MaxLength())
+	}
+
+	var hasLetter, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLetter(r):
+			hasLetter = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			hasSymbol = true
+		}
+	}
+
+	if s.cfg.RequireLetter() && !hasLetter {
+		return errors.Wrap(model.ErrWeakPassword, "password must contain a letter")
+	}
+	if s.cfg.RequireDigit() && !hasDigit {
+		return errors.Wrap(model.ErrWeakPassword, "password must contain a digit")
+	}
+	if s.cfg.RequireSymbol() && !hasSymbol {
+		return errors.Wrap(model.ErrWeakPassword, "password must contain a special character")
+	}
+
+	return nil
+}
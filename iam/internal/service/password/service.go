@@ -0,0 +1,63 @@
+package password
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/linemk/rocket-shop/iam/internal/config"
+	"github.com/linemk/rocket-shop/iam/internal/model"
+)
+
+type Service interface {
+	// Hash хеширует пароль текущим алгоритмом (argon2id) с параметрами из конфигурации
+	Hash(password string) (string, error)
+	// Verify сверяет пароль с сохраненным хешем. needsRehash сообщает, что хеш получен
+	// устаревшим алгоритмом или параметрами и его стоит пересчитать после успешной проверки
+	Verify(encodedHash, password string) (needsRehash bool, err error)
+	// Validate проверяет пароль на соответствие парольной политике
+	Validate(password string) error
+}
+
+type service struct {
+	argon2 argon2Params
+	cfg    config.PasswordConfig
+}
+
+func NewService(cfg config.PasswordConfig) Service {
+	return &service{
+		argon2: argon2Params{
+			memory:      cfg.Argon2Memory(),
+			iterations:  cfg.Argon2Iterations(),
+			parallelism: cfg.Argon2Parallelism(),
+		},
+		cfg: cfg,
+	}
+}
+
+func (s *service) Hash(password string) (string, error) {
+	return hashArgon2id(password, s.argon2)
+}
+
+func (s *service) Verify(encodedHash, password string) (bool, error) {
+	switch {
+	case encodedHash == "":
+		// У пользователей, созданных через внешнего провайдера, локального пароля нет
+		return false, model.ErrInvalidPassword
+	case strings.HasPrefix(encodedHash, argon2idPrefix):
+		params, err := verifyArgon2id(encodedHash, password)
+		if err != nil {
+			return false, err
+		}
+		return params != s.argon2, nil
+	case isBcryptHash(encodedHash):
+		err := verifyBcrypt(encodedHash, password)
+		if err != nil {
+			return false, err
+		}
+		// Хеши bcrypt остались от предыдущей версии и переводятся на argon2id при входе
+		return true, nil
+	default:
+		return false, errors.Wrap(model.ErrUnsupportedPasswordHash, "unknown hash prefix")
+	}
+}
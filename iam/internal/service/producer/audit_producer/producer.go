@@ -0,0 +1,59 @@
+package audit_producer
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/linemk/rocket-shop/iam/internal/model"
+	"github.com/linemk/rocket-shop/iam/internal/service/converter"
+	platformKafka "github.com/linemk/rocket-shop/platform/pkg/kafka"
+)
+
+type Producer interface {
+	SendAuthAuditEvent(ctx context.Context, event *model.AuditEvent) error
+}
+
+type Logger interface {
+	Info(ctx context.Context, msg string, fields ...zap.Field)
+	Error(ctx context.Context, msg string, fields ...zap.Field)
+}
+
+type producer struct {
+	kafkaProducer platformKafka.Producer
+	logger        Logger
+}
+
+func NewProducer(kafkaProducer platformKafka.Producer, logger Logger) Producer {
+	return &producer{
+		kafkaProducer: kafkaProducer,
+		logger:        logger,
+	}
+}
+
+func (p *producer) SendAuthAuditEvent(ctx context.Context, event *model.AuditEvent) error {
+	data, err := protojson.Marshal(converter.AuthAuditEventToProto(event))
+	if err != nil {
+		return errors.Wrap(err, "failed to encode AuthAuditEvent event")
+	}
+
+	// Ключ сообщения - UUID пользователя (или логин, если пользователь не определен),
+	// чтобы история одного пользователя сохраняла порядок
+	key := event.UserUUID
+	if key == "" {
+		key = event.Login
+	}
+
+	if err := p.kafkaProducer.Send(ctx, []byte(key), data); err != nil {
+		p.logger.Error(ctx, "Failed to send AuthAuditEvent event to Kafka",
+			zap.String("event_type", string(event.EventType)),
+			zap.String("user_uuid", event.UserUUID),
+			zap.Error(err),
+		)
+		return errors.Wrap(err, "failed to send AuthAuditEvent event")
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package token_producer
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/linemk/rocket-shop/iam/internal/model"
+	"github.com/linemk/rocket-shop/iam/internal/service/converter"
+	platformKafka "github.com/linemk/rocket-shop/platform/pkg/kafka"
+)
+
+type Producer interface {
+	SendAuthTokenIssued(ctx context.Context, event *model.AuthTokenIssuedEvent) error
+}
+
+type Logger interface {
+	Info(ctx context.Context, msg string, fields ...zap.Field)
+	Error(ctx context.Context, msg string, fields ...zap.Field)
+}
+
+type producer struct {
+	kafkaProducer platformKafka.Producer
+	logger        Logger
+}
+
+func NewProducer(kafkaProducer platformKafka.Producer, logger Logger) Producer {
+	return &producer{
+		kafkaProducer: kafkaProducer,
+		logger:        logger,
+	}
+}
+
+func (p *producer) SendAuthTokenIssued(ctx context.Context, event *model.AuthTokenIssuedEvent) error {
+	data, err := protojson.Marshal(converter.AuthTokenIssuedToProto(event))
+	if err != nil {
+		return errors.Wrap(err, "failed to encode AuthTokenIssued event")
+	}
+
+	// Ключ сообщения - UUID пользователя, чтобы события одного пользователя попадали в одну партицию
+	if err := p.kafkaProducer.Send(ctx, []byte(event.UserUUID), data); err != nil {
+		p.logger.Error(ctx, "Failed to send AuthTokenIssued event to Kafka", zap.Error(err))
+		return errors.Wrap(err, "failed to send AuthTokenIssued event")
+	}
+
+	p.logger.Info(ctx, "AuthTokenIssued event sent successfully",
+		zap.String("user_uuid", event.UserUUID),
+		zap.String("purpose", string(event.Purpose)),
+	)
+
+	return nil
+}
@@ -0,0 +1,365 @@
+package tests
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/linemk/rocket-shop/iam/internal/mocks"
+	"github.com/linemk/rocket-shop/iam/internal/model"
+	"github.com/linemk/rocket-shop/iam/internal/service/api_key"
+)
+
+type apiKeyMocks struct {
+	apiKeyRepo        *mocks.MockAPIKeyRepository
+	apiKeySessionRepo *mocks.MockAPIKeySessionRepository
+	sessionRepo       *mocks.MockSessionRepository
+	userRepo          *mocks.MockUserRepository
+	whoamiCache       *mocks.MockWhoamiCacheService
+}
+
+func newAPIKeyTestService(t *testing.T) (api_key.Service, apiKeyMocks) {
+	ctrl := gomock.NewController(t)
+
+	m := apiKeyMocks{
+		apiKeyRepo:        mocks.NewMockAPIKeyRepository(ctrl),
+		apiKeySessionRepo: mocks.NewMockAPIKeySessionRepository(ctrl),
+		sessionRepo:       mocks.NewMockSessionRepository(ctrl),
+		userRepo:          mocks.NewMockUserRepository(ctrl),
+		whoamiCache:       mocks.NewMockWhoamiCacheService(ctrl),
+	}
+
+	return api_key.NewService(m.apiKeyRepo, m.apiKeySessionRepo, m.sessionRepo, m.userRepo, m.whoamiCache), m
+}
+
+func sha256Hex(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestAPIKeyCreate(t *testing.T) {
+	userUUID := uuid.NewString()
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name      string
+		scopes    []string
+		expiresAt *time.Time
+		setup     func(m apiKeyMocks)
+		wantErr   error
+	}{
+		{
+			name:      "stores only the hash of the key",
+			scopes:    []string{model.APIKeyScopeInventoryRead, model.APIKeyScopeOrdersWrite},
+			expiresAt: &future,
+			setup: func(m apiKeyMocks) {
+				m.userRepo.EXPECT().GetByID(gomock.Any(), userUUID).Return(&model.User{UserUUID: userUUID}, nil)
+				m.apiKeyRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:    "empty scopes are rejected",
+			wantErr: model.ErrEmptyAPIKeyScopes,
+		},
+		{
+			name:    "unknown scope is rejected",
+			scopes:  []string{model.APIKeyScopeInventoryRead, "admin:all"},
+			wantErr: model.ErrUnknownAPIKeyScope,
+		},
+		{
+			name:      "expiration in the past is rejected",
+			scopes:    []string{model.APIKeyScopeInventoryRead},
+			expiresAt: &past,
+			wantErr:   model.ErrInvalidAPIKeyExpiration,
+		},
+		{
+			name:   "unknown user is rejected",
+			scopes: []string{model.APIKeyScopeInventoryRead},
+			setup: func(m apiKeyMocks) {
+				m.userRepo.EXPECT().GetByID(gomock.Any(), userUUID).Return(nil, model.ErrUserNotFound)
+			},
+			wantErr: model.ErrUserNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newAPIKeyTestService(t)
+			if tt.setup != nil {
+				tt.setup(m)
+			}
+
+			created, err := svc.Create(context.Background(), userUUID, "ci", tt.scopes, tt.expiresAt)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.True(t, strings.HasPrefix(created.Key, "rsk_"))
+			require.True(t, strings.HasPrefix(created.Key, created.APIKey.KeyPrefix))
+			require.Less(t, len(created.APIKey.KeyPrefix), len(created.Key))
+			require.Equal(t, sha256Hex(created.Key), created.APIKey.KeyHash)
+			require.NotContains(t, created.APIKey.KeyHash, created.Key)
+			require.Equal(t, tt.scopes, created.APIKey.Scopes)
+		})
+	}
+}
+
+func TestAPIKeyAuthenticate(t *testing.T) {
+	const key = "rsk_test-key"
+
+	userUUID := uuid.NewString()
+	past := time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		name    string
+		apiKey  *model.APIKey
+		repoErr error
+		wantErr error
+	}{
+		{
+			name:   "active key returns its owner",
+			apiKey: &model.APIKey{APIKeyUUID: uuid.NewString(), UserUUID: userUUID},
+		},
+		{
+			name:    "unknown key is rejected",
+			repoErr: model.ErrAPIKeyNotFound,
+			wantErr: model.ErrInvalidAPIKey,
+		},
+		{
+			name:    "revoked key is rejected",
+			apiKey:  &model.APIKey{APIKeyUUID: uuid.NewString(), UserUUID: userUUID, RevokedAt: &past},
+			wantErr: model.ErrInvalidAPIKey,
+		},
+		{
+			name:    "expired key is rejected",
+			apiKey:  &model.APIKey{APIKeyUUID: uuid.NewString(), UserUUID: userUUID, ExpiresAt: &past},
+			wantErr: model.ErrInvalidAPIKey,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newAPIKeyTestService(t)
+
+			m.apiKeyRepo.EXPECT().GetByHash(gomock.Any(), sha256Hex(key)).Return(tt.apiKey, tt.repoErr)
+			if tt.wantErr == nil {
+				m.userRepo.EXPECT().GetByID(gomock.Any(), userUUID).Return(&model.User{UserUUID: userUUID}, nil)
+			}
+
+			user, apiKey, err := svc.Authenticate(context.Background(), key)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, userUUID, user.UserUUID)
+			require.Equal(t, tt.apiKey, apiKey)
+		})
+	}
+}
+
+func TestAPIKeyHasScope(t *testing.T) {
+	apiKey := &model.APIKey{Scopes: []string{model.APIKeyScopeInventoryRead, model.APIKeyScopeOrdersRead}}
+
+	tests := []struct {
+		scope string
+		want  bool
+	}{
+		{scope: model.APIKeyScopeInventoryRead, want: true},
+		{scope: model.APIKeyScopeOrdersRead, want: true},
+		{scope: model.APIKeyScopeInventoryWrite, want: false},
+		{scope: "inventory", want: false},
+		{scope: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.scope, func(t *testing.T) {
+			require.Equal(t, tt.want, apiKey.HasScope(tt.scope))
+		})
+	}
+
+	require.Equal(t, "inventory:read,orders:read", apiKey.ScopesString())
+}
+
+func TestAPIKeySession(t *testing.T) {
+	userUUID := uuid.NewString()
+	apiKeyUUID := uuid.NewString()
+	existing := uuid.NewString()
+
+	tests := []struct {
+		name      string
+		expiresIn time.Duration
+		setup     func(m apiKeyMocks)
+		// wantReuse ожидает, что вернется уже выпущенная сессия ключа
+		wantReuse bool
+		wantTTL   time.Duration
+	}{
+		{
+			name: "reuses the live session of the key",
+			setup: func(m apiKeyMocks) {
+				m.apiKeySessionRepo.EXPECT().Get(gomock.Any(), apiKeyUUID).Return(existing, nil)
+				m.sessionRepo.EXPECT().Get(gomock.Any(), existing).Return(&model.Session{SessionUUID: existing}, nil)
+			},
+			wantReuse: true,
+		},
+		{
+			name: "issues a new session when the old one was terminated",
+			setup: func(m apiKeyMocks) {
+				m.apiKeySessionRepo.EXPECT().Get(gomock.Any(), apiKeyUUID).Return(existing, nil)
+				m.sessionRepo.EXPECT().Get(gomock.Any(), existing).Return(nil, model.ErrSessionNotFound)
+				m.apiKeySessionRepo.EXPECT().Delete(gomock.Any(), apiKeyUUID).Return(nil)
+			},
+			wantTTL: 15 * time.Minute,
+		},
+		{
+			name: "issues a new session for a key without one",
+			setup: func(m apiKeyMocks) {
+				m.apiKeySessionRepo.EXPECT().Get(gomock.Any(), apiKeyUUID).Return("", model.ErrSessionNotFound)
+			},
+			wantTTL: 15 * time.Minute,
+		},
+		{
+			name:      "session does not outlive the key",
+			expiresIn: time.Minute,
+			setup: func(m apiKeyMocks) {
+				m.apiKeySessionRepo.EXPECT().Get(gomock.Any(), apiKeyUUID).Return("", model.ErrSessionNotFound)
+			},
+			wantTTL: time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newAPIKeyTestService(t)
+			tt.setup(m)
+
+			apiKey := &model.APIKey{APIKeyUUID: apiKeyUUID, UserUUID: userUUID}
+			if tt.expiresIn > 0 {
+				expiresAt := time.Now().Add(tt.expiresIn)
+				apiKey.ExpiresAt = &expiresAt
+			}
+
+			var created *model.Session
+			if !tt.wantReuse {
+				m.sessionRepo.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, session *model.Session, ttl time.Duration) error {
+						require.Equal(t, userUUID, session.UserUUID)
+						require.LessOrEqual(t, ttl, tt.wantTTL)
+						require.Greater(t, ttl, tt.wantTTL-time.Second)
+						created = session
+						return nil
+					})
+				m.sessionRepo.EXPECT().AddSessionToUserSet(gomock.Any(), userUUID, gomock.Any()).Return(nil)
+				m.apiKeySessionRepo.EXPECT().Claim(gomock.Any(), apiKeyUUID, gomock.Any(), gomock.Any()).Return(true, nil)
+			}
+
+			sessionUUID, err := svc.Session(context.Background(), apiKey)
+			require.NoError(t, err)
+
+			if tt.wantReuse {
+				require.Equal(t, existing, sessionUUID)
+				return
+			}
+
+			require.NotEqual(t, existing, sessionUUID)
+			require.Equal(t, created.SessionUUID, sessionUUID)
+		})
+	}
+}
+
+func TestAPIKeySession_ConcurrentClaim(t *testing.T) {
+	svc, m := newAPIKeyTestService(t)
+
+	userUUID := uuid.NewString()
+	apiKey := &model.APIKey{APIKeyUUID: uuid.NewString(), UserUUID: userUUID}
+	winner := uuid.NewString()
+
+	var created string
+	gomock.InOrder(
+		m.apiKeySessionRepo.EXPECT().Get(gomock.Any(), apiKey.APIKeyUUID).Return("", model.ErrSessionNotFound),
+		m.sessionRepo.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, session *model.Session, _ time.Duration) error {
+				created = session.SessionUUID
+				return nil
+			}),
+		m.sessionRepo.EXPECT().AddSessionToUserSet(gomock.Any(), userUUID, gomock.Any()).Return(nil),
+		// Параллельный запрос успел закрепить свою сессию за ключом
+		m.apiKeySessionRepo.EXPECT().Claim(gomock.Any(), apiKey.APIKeyUUID, gomock.Any(), gomock.Any()).Return(false, nil),
+		m.sessionRepo.EXPECT().Delete(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, sessionUUID string) error {
+				require.Equal(t, created, sessionUUID, "only the losing session is deleted")
+				return nil
+			}),
+		m.whoamiCache.EXPECT().InvalidateSession(gomock.Any(), gomock.Any()),
+		m.sessionRepo.EXPECT().RemoveSessionFromUserSet(gomock.Any(), userUUID, gomock.Any()).Return(nil),
+		m.apiKeySessionRepo.EXPECT().Get(gomock.Any(), apiKey.APIKeyUUID).Return(winner, nil),
+	)
+
+	sessionUUID, err := svc.Session(context.Background(), apiKey)
+	require.NoError(t, err)
+	require.Equal(t, winner, sessionUUID)
+}
+
+func TestAPIKeyRevoke(t *testing.T) {
+	userUUID := uuid.NewString()
+	apiKeyUUID := uuid.NewString()
+	sessionUUID := uuid.NewString()
+
+	tests := []struct {
+		name    string
+		setup   func(m apiKeyMocks)
+		wantErr error
+	}{
+		{
+			name: "terminates the session of the key",
+			setup: func(m apiKeyMocks) {
+				m.apiKeyRepo.EXPECT().Revoke(gomock.Any(), userUUID, apiKeyUUID).Return(nil)
+				m.apiKeySessionRepo.EXPECT().Get(gomock.Any(), apiKeyUUID).Return(sessionUUID, nil)
+				m.sessionRepo.EXPECT().Delete(gomock.Any(), sessionUUID).Return(nil)
+				m.whoamiCache.EXPECT().InvalidateSession(gomock.Any(), sessionUUID)
+				m.sessionRepo.EXPECT().RemoveSessionFromUserSet(gomock.Any(), userUUID, sessionUUID).Return(nil)
+				m.apiKeySessionRepo.EXPECT().Delete(gomock.Any(), apiKeyUUID).Return(nil)
+			},
+		},
+		{
+			name: "key without a session is revoked",
+			setup: func(m apiKeyMocks) {
+				m.apiKeyRepo.EXPECT().Revoke(gomock.Any(), userUUID, apiKeyUUID).Return(nil)
+				m.apiKeySessionRepo.EXPECT().Get(gomock.Any(), apiKeyUUID).Return("", model.ErrSessionNotFound)
+			},
+		},
+		{
+			name: "foreign or unknown key is not found",
+			setup: func(m apiKeyMocks) {
+				m.apiKeyRepo.EXPECT().Revoke(gomock.Any(), userUUID, apiKeyUUID).Return(model.ErrAPIKeyNotFound)
+			},
+			wantErr: model.ErrAPIKeyNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m := newAPIKeyTestService(t)
+			tt.setup(m)
+
+			err := svc.Revoke(context.Background(), userUUID, apiKeyUUID)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/linemk/rocket-shop/iam/internal/mocks"
+	"github.com/linemk/rocket-shop/iam/internal/model"
+	"github.com/linemk/rocket-shop/iam/internal/service/audit"
+	"github.com/linemk/rocket-shop/iam/internal/service/consumer/audit_consumer"
+	"github.com/linemk/rocket-shop/iam/internal/service/converter"
+	platformKafka "github.com/linemk/rocket-shop/platform/pkg/kafka"
+)
+
+func TestAuditRecord(t *testing.T) {
+	userUUID := uuid.NewString()
+	fromContext := model.ClientInfo{IPAddress: "10.0.0.1", UserAgent: "curl/8.0"}
+	explicit := model.ClientInfo{IPAddress: "192.168.0.1", UserAgent: "envoy"}
+
+	tests := []struct {
+		name       string
+		client     model.ClientInfo
+		sendErr    error
+		wantClient model.ClientInfo
+	}{
+		{
+			name:       "client is taken from the context",
+			wantClient: fromContext,
+		},
+		{
+			name:       "explicit client is kept",
+			client:     explicit,
+			wantClient: explicit,
+		},
+		{
+			name:       "send error does not interrupt the caller",
+			sendErr:    errors.New("kafka is down"),
+			wantClient: fromContext,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			producer := mocks.NewMockAuditProducer(ctrl)
+			svc := audit.NewService(mocks.NewMockAuditEventRepository(ctrl), producer)
+
+			ctx := audit.ContextWithClientInfo(context.Background(), fromContext)
+			before := time.Now()
+
+			var sent *model.AuditEvent
+			producer.EXPECT().SendAuthAuditEvent(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, event *model.AuditEvent) error {
+					sent = event
+					return tt.sendErr
+				})
+
+			svc.Record(ctx, &model.AuditEvent{
+				EventType: model.AuditEventLoginFailed,
+				UserUUID:  userUUID,
+				Client:    tt.client,
+				Reason:    "invalid password",
+			})
+
+			require.NotNil(t, sent)
+			_, err := uuid.Parse(sent.EventUUID)
+			require.NoError(t, err, "event uuid must be generated")
+			require.False(t, sent.OccurredAt.Before(before))
+			require.Equal(t, tt.wantClient, sent.Client)
+			require.Equal(t, model.AuditEventLoginFailed, sent.EventType)
+			require.Equal(t, userUUID, sent.UserUUID)
+			require.Equal(t, "invalid password", sent.Reason)
+		})
+	}
+}
+
+func TestAuditConsumer(t *testing.T) {
+	ctx := context.Background()
+
+	event := &model.AuditEvent{
+		EventUUID:   uuid.NewString(),
+		EventType:   model.AuditEventSessionRevoked,
+		UserUUID:    uuid.NewString(),
+		SessionUUID: uuid.NewString(),
+		Client:      model.ClientInfo{IPAddress: "10.0.0.1", UserAgent: "curl/8.0"},
+		Reason:      "password changed",
+		OccurredAt:  time.Now().UTC().Truncate(time.Microsecond),
+	}
+	value, err := protojson.Marshal(converter.AuthAuditEventToProto(event))
+	require.NoError(t, err)
+
+	t.Run("redelivered event is stored once", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := mocks.NewMockAuditEventRepository(ctrl)
+
+		// Хранилище повторяет ON CONFLICT (event_uuid) DO NOTHING
+		var mu sync.Mutex
+		stored := make(map[string]*model.AuditEvent)
+		repo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, e *model.AuditEvent) error {
+				mu.Lock()
+				defer mu.Unlock()
+				if _, ok := stored[e.EventUUID]; !ok {
+					stored[e.EventUUID] = e
+				}
+				return nil
+			}).Times(2)
+
+		handle := audit_consumer.NewHandler(audit.NewService(repo, nil), noopLogger{})
+
+		// Kafka доставляет событие как минимум один раз: сообщение может прийти повторно
+		for i := 0; i < 2; i++ {
+			require.NoError(t, handle(ctx, platformKafka.Message{Value: value}))
+		}
+
+		require.Len(t, stored, 1)
+		require.Equal(t, event, stored[event.EventUUID], "event must be stored as it was recorded")
+	})
+
+	t.Run("malformed message is rejected", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		handle := audit_consumer.NewHandler(audit.NewService(mocks.NewMockAuditEventRepository(ctrl), nil), noopLogger{})
+
+		require.Error(t, handle(ctx, platformKafka.Message{Value: []byte("{not json")}))
+	})
+
+	t.Run("storage error is returned for redelivery", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := mocks.NewMockAuditEventRepository(ctrl)
+		repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(errors.New("postgres is down"))
+
+		handle := audit_consumer.NewHandler(audit.NewService(repo, nil), noopLogger{})
+
+		require.Error(t, handle(ctx, platformKafka.Message{Value: value}))
+	})
+}
@@ -0,0 +1,250 @@
+package tests
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/pquerna/otp/totp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/linemk/rocket-shop/iam/internal/mocks"
+	"github.com/linemk/rocket-shop/iam/internal/model"
+	"github.com/linemk/rocket-shop/iam/internal/service/mfa"
+)
+
+type mfaTestConfig struct{}
+
+func (mfaTestConfig) Issuer() string { return "rocket-shop" }
+
+func (mfaTestConfig) EncryptionKey() []byte { return []byte("0123456789abcdef0123456789abcdef") }
+
+func (mfaTestConfig) ChallengeTTL() time.Duration { return 5 * time.Minute }
+
+func (mfaTestConfig) MaxAttempts() int { return 5 }
+
+// memoryUser хранит одного пользователя и последний принятый шаг TOTP вместо Postgres
+type memoryUser struct {
+	user     *model.User
+	lastStep int64
+}
+
+func newMFATestService(t *testing.T) (mfa.Service, *memoryUser) {
+	ctrl := gomock.NewController(t)
+
+	store := &memoryUser{
+		user: &model.User{UserUUID: uuid.NewString(), Login: "alice"},
+	}
+
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	userRepo.EXPECT().GetByID(gomock.Any(), store.user.UserUUID).DoAndReturn(
+		func(_ context.Context, _ string) (*model.User, error) {
+			copied := *store.user
+			copied.RecoveryCodeHashes = append([]string(nil), store.user.RecoveryCodeHashes...)
+			return &copied, nil
+		}).AnyTimes()
+	userRepo.EXPECT().UpdateMFA(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, u *model.User) error {
+			copied := *u
+			copied.RecoveryCodeHashes = append([]string(nil), u.RecoveryCodeHashes...)
+			store.user = &copied
+			return nil
+		}).AnyTimes()
+	userRepo.EXPECT().ConsumeRecoveryCode(gomock.Any(), store.user.UserUUID, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, codeHash string) (bool, error) {
+			for i, hash := range store.user.RecoveryCodeHashes {
+				if hash == codeHash {
+					copied := *store.user
+					copied.RecoveryCodeHashes = append(append([]string(nil), store.user.RecoveryCodeHashes[:i]...), store.user.RecoveryCodeHashes[i+1:]...)
+					store.user = &copied
+					return true, nil
+				}
+			}
+			return false, nil
+		}).AnyTimes()
+	userRepo.EXPECT().AdvanceTOTPStep(gomock.Any(), store.user.UserUUID, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, step int64) (bool, error) {
+			if step <= store.lastStep {
+				return false, nil
+			}
+			store.lastStep = step
+			return true, nil
+		}).AnyTimes()
+
+	return mfa.NewService(userRepo, mfaTestConfig{}), store
+}
+
+// enrolledMFA включает 2FA и возвращает секрет и коды восстановления.
+// Шаг подтверждения сдвигается в прошлое, чтобы текущий код оставался доступен тесту
+func enrolledMFA(t *testing.T, svc mfa.Service, store *memoryUser) (string, []string) {
+	t.Helper()
+	ctx := context.Background()
+
+	enrollment, err := svc.Enroll(ctx, store.user.UserUUID)
+	require.NoError(t, err)
+	require.NotEmpty(t, enrollment.URI)
+	require.NotEqual(t, enrollment.Secret, string(store.user.TOTPSecret), "secret must be stored encrypted")
+
+	code, err := totp.GenerateCode(enrollment.Secret, time.Now())
+	require.NoError(t, err)
+
+	recoveryCodes, err := svc.Confirm(ctx, store.user.UserUUID, code)
+	require.NoError(t, err)
+	require.Len(t, recoveryCodes, 10)
+	require.True(t, store.user.TOTPEnabled)
+
+	store.lastStep = 0
+
+	return enrollment.Secret, recoveryCodes
+}
+
+func TestMFAEnrollment(t *testing.T) {
+	tests := []struct {
+		name    string
+		code    func(secret string) string
+		wantErr error
+	}{
+		{
+			name: "valid code enables 2fa",
+			code: func(secret string) string {
+				code, _ := totp.GenerateCode(secret, time.Now())
+				return code
+			},
+		},
+		{
+			name:    "wrong code is rejected",
+			code:    func(string) string { return "000000" },
+			wantErr: model.ErrInvalidMFACode,
+		},
+		{
+			name: "code from a distant step is rejected",
+			code: func(secret string) string {
+				code, _ := totp.GenerateCode(secret, time.Now().Add(-10*time.Minute))
+				return code
+			},
+			wantErr: model.ErrInvalidMFACode,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, store := newMFATestService(t)
+			ctx := context.Background()
+
+			enrollment, err := svc.Enroll(ctx, store.user.UserUUID)
+			require.NoError(t, err)
+
+			codes, err := svc.Confirm(ctx, store.user.UserUUID, tt.code(enrollment.Secret))
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				require.False(t, store.user.TOTPEnabled)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, codes, 10)
+			require.True(t, store.user.TOTPEnabled)
+
+			_, err = svc.Enroll(ctx, store.user.UserUUID)
+			require.ErrorIs(t, err, model.ErrMFAAlreadyEnabled)
+		})
+	}
+}
+
+func TestMFAConfirm_NotEnrolled(t *testing.T) {
+	svc, store := newMFATestService(t)
+
+	_, err := svc.Confirm(context.Background(), store.user.UserUUID, "123456")
+	require.ErrorIs(t, err, model.ErrMFANotEnrolled)
+}
+
+func TestMFAVerify(t *testing.T) {
+	svc, store := newMFATestService(t)
+	ctx := context.Background()
+
+	secret, recoveryCodes := enrolledMFA(t, svc, store)
+
+	code, err := totp.GenerateCode(secret, time.Now())
+	require.NoError(t, err)
+
+	require.NoError(t, svc.Verify(ctx, store.user, code))
+	require.ErrorIs(t, svc.Verify(ctx, store.user, code), model.ErrInvalidMFACode, "replayed totp code must be rejected")
+
+	previous, err := totp.GenerateCode(secret, time.Now().Add(-30*time.Second))
+	require.NoError(t, err)
+	require.ErrorIs(t, svc.Verify(ctx, store.user, previous), model.ErrInvalidMFACode, "code from an earlier step must be rejected")
+
+	require.ErrorIs(t, svc.Verify(ctx, store.user, "not-a-code"), model.ErrInvalidMFACode)
+
+	recoveryCode := recoveryCodes[0]
+	require.NoError(t, svc.Verify(ctx, store.user, recoveryCode))
+	require.Len(t, store.user.RecoveryCodeHashes, 9)
+	require.ErrorIs(t, svc.Verify(ctx, store.user, recoveryCode), model.ErrInvalidMFACode, "recovery code is single use")
+
+	// Код восстановления принимается без учета регистра и дефиса
+	require.NoError(t, svc.Verify(ctx, store.user, " "+strings.ToUpper(strings.ReplaceAll(recoveryCodes[1], "-", ""))+" "))
+}
+
+func TestMFAVerify_RecoveryCodeFromStaleCopy(t *testing.T) {
+	svc, store := newMFATestService(t)
+	ctx := context.Background()
+
+	_, recoveryCodes := enrolledMFA(t, svc, store)
+
+	// Две параллельные проверки читают пользователя до того, как любая из них погасила код
+	first := *store.user
+	first.RecoveryCodeHashes = append([]string(nil), store.user.RecoveryCodeHashes...)
+	second := *store.user
+	second.RecoveryCodeHashes = append([]string(nil), store.user.RecoveryCodeHashes...)
+
+	require.NoError(t, svc.Verify(ctx, &first, recoveryCodes[0]))
+	require.ErrorIs(t, svc.Verify(ctx, &second, recoveryCodes[0]), model.ErrInvalidMFACode, "recovery code must be consumed only once")
+	require.Len(t, store.user.RecoveryCodeHashes, 9)
+}
+
+func TestMFAVerify_NotEnabled(t *testing.T) {
+	svc, store := newMFATestService(t)
+
+	require.ErrorIs(t, svc.Verify(context.Background(), store.user, "123456"), model.ErrMFANotEnabled)
+}
+
+func TestMFARegenerateRecoveryCodes(t *testing.T) {
+	svc, store := newMFATestService(t)
+	ctx := context.Background()
+
+	secret, recoveryCodes := enrolledMFA(t, svc, store)
+
+	_, err := svc.RegenerateRecoveryCodes(ctx, store.user.UserUUID, recoveryCodes[0])
+	require.ErrorIs(t, err, model.ErrInvalidMFACode, "recovery code must not regenerate recovery codes")
+
+	code, err := totp.GenerateCode(secret, time.Now())
+	require.NoError(t, err)
+
+	fresh, err := svc.RegenerateRecoveryCodes(ctx, store.user.UserUUID, code)
+	require.NoError(t, err)
+	require.Len(t, fresh, 10)
+
+	require.ErrorIs(t, svc.Verify(ctx, store.user, recoveryCodes[1]), model.ErrInvalidMFACode, "old recovery codes must stop working")
+	require.NoError(t, svc.Verify(ctx, store.user, fresh[0]))
+}
+
+func TestMFADisable(t *testing.T) {
+	svc, store := newMFATestService(t)
+	ctx := context.Background()
+
+	_, recoveryCodes := enrolledMFA(t, svc, store)
+
+	require.ErrorIs(t, svc.Disable(ctx, store.user.UserUUID, "000000"), model.ErrInvalidMFACode)
+	require.True(t, store.user.TOTPEnabled)
+
+	require.NoError(t, svc.Disable(ctx, store.user.UserUUID, recoveryCodes[0]))
+	require.False(t, store.user.TOTPEnabled)
+	require.Empty(t, store.user.TOTPSecret)
+	require.Empty(t, store.user.RecoveryCodeHashes)
+
+	_, err := svc.RegenerateRecoveryCodes(ctx, store.user.UserUUID, "123456")
+	require.ErrorIs(t, err, model.ErrMFANotEnabled)
+}
@@ -0,0 +1,195 @@
+package tests
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	stubClientID     = "rocket-shop"
+	stubClientSecret = "rocket-shop-secret"
+	stubKeyID        = "stub-key"
+)
+
+// stubIDClaims данные пользователя, которые провайдер положит в ID токен
+type stubIDClaims struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+}
+
+type stubAuthorization struct {
+	challenge string
+	nonce     string
+	claims    stubIDClaims
+}
+
+// stubOIDCProvider минимальный OIDC провайдер: discovery, JWKS и token endpoint с проверкой PKCE
+type stubOIDCProvider struct {
+	t      *testing.T
+	server *httptest.Server
+	key    *rsa.PrivateKey
+
+	mu    sync.Mutex
+	codes map[string]stubAuthorization
+}
+
+func newStubOIDCProvider(t *testing.T) *stubOIDCProvider {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	p := &stubOIDCProvider{
+		t:     t,
+		key:   key,
+		codes: make(map[string]stubAuthorization),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", p.discovery)
+	mux.HandleFunc("/jwks", p.jwks)
+	mux.HandleFunc("/token", p.token)
+
+	p.server = httptest.NewServer(mux)
+	t.Cleanup(p.server.Close)
+
+	return p
+}
+
+func (p *stubOIDCProvider) issuer() string {
+	return p.server.URL
+}
+
+// authorize имитирует вход пользователя у провайдера: запоминает PKCE челлендж и nonce
+// из адреса авторизации и возвращает код. nonceOverride подменяет nonce в ID токене
+func (p *stubOIDCProvider) authorize(authURL string, claims stubIDClaims, nonceOverride string) (state, code string) {
+	parsed, err := url.Parse(authURL)
+	require.NoError(p.t, err)
+
+	query := parsed.Query()
+	require.Equal(p.t, "S256", query.Get("code_challenge_method"))
+	require.Equal(p.t, stubClientID, query.Get("client_id"))
+
+	nonce := query.Get("nonce")
+	if nonceOverride != "" {
+		nonce = nonceOverride
+	}
+
+	code = base64.RawURLEncoding.EncodeToString([]byte(claims.Subject + time.Now().String()))
+
+	p.mu.Lock()
+	p.codes[code] = stubAuthorization{
+		challenge: query.Get("code_challenge"),
+		nonce:     nonce,
+		claims:    claims,
+	}
+	p.mu.Unlock()
+
+	return query.Get("state"), code
+}
+
+func (p *stubOIDCProvider) discovery(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"issuer":                                p.issuer(),
+		"authorization_endpoint":                p.issuer() + "/authorize",
+		"token_endpoint":                        p.issuer() + "/token",
+		"jwks_uri":                              p.issuer() + "/jwks",
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+	})
+}
+
+func (p *stubOIDCProvider) jwks(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{
+		Key:       &p.key.PublicKey,
+		KeyID:     stubKeyID,
+		Algorithm: string(jose.RS256),
+		Use:       "sig",
+	}}})
+}
+
+func (p *stubOIDCProvider) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+
+	clientID, clientSecret, ok := r.BasicAuth()
+	if !ok {
+		clientID, clientSecret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	}
+	if clientID != stubClientID || clientSecret != stubClientSecret {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	p.mu.Lock()
+	authorization, ok := p.codes[r.PostForm.Get("code")]
+	delete(p.codes, r.PostForm.Get("code"))
+	p.mu.Unlock()
+	if !ok {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	// PKCE: верификатор должен соответствовать челленджу из адреса авторизации
+	sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if base64.RawURLEncoding.EncodeToString(sum[:]) != authorization.challenge {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": "stub-access-token",
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     p.signIDToken(authorization),
+	})
+}
+
+func (p *stubOIDCProvider) signIDToken(authorization stubAuthorization) string {
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: jose.RS256, Key: p.key},
+		(&jose.SignerOptions{}).WithType("JWT").WithHeader("kid", stubKeyID),
+	)
+	require.NoError(p.t, err)
+
+	now := time.Now()
+	payload, err := json.Marshal(map[string]interface{}{
+		"iss":            p.issuer(),
+		"sub":            authorization.claims.Subject,
+		"aud":            stubClientID,
+		"iat":            now.Unix(),
+		"exp":            now.Add(time.Hour).Unix(),
+		"nonce":          authorization.nonce,
+		"email":          authorization.claims.Email,
+		"email_verified": authorization.claims.EmailVerified,
+	})
+	require.NoError(p.t, err)
+
+	signed, err := signer.Sign(payload)
+	require.NoError(p.t, err)
+
+	compact, err := signed.CompactSerialize()
+	require.NoError(p.t, err)
+
+	return compact
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
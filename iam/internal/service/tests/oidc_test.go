@@ -0,0 +1,243 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/linemk/rocket-shop/iam/internal/config/env"
+	"github.com/linemk/rocket-shop/iam/internal/mocks"
+	"github.com/linemk/rocket-shop/iam/internal/model"
+	"github.com/linemk/rocket-shop/iam/internal/service/oidc"
+)
+
+const stubProviderName = "mock"
+
+type oidcTestConfig struct {
+	providers   []env.OIDCProvider
+	allowSignup bool
+}
+
+func (c *oidcTestConfig) Providers() []env.OIDCProvider { return c.providers }
+func (c *oidcTestConfig) StateTTL() time.Duration       { return 5 * time.Minute }
+func (c *oidcTestConfig) AllowSignup() bool             { return c.allowSignup }
+
+type oidcMocks struct {
+	userRepo     *mocks.MockUserRepository
+	identityRepo *mocks.MockIdentityRepository
+	authService  *mocks.MockAuthService
+}
+
+func TestOIDCLogin(t *testing.T) {
+	provider := newStubOIDCProvider(t)
+
+	subject := uuid.NewString()
+	verifiedClaims := stubIDClaims{Subject: subject, Email: "alice@example.com", EmailVerified: true}
+	localUser := &model.User{UserUUID: uuid.NewString(), Login: "alice", Email: "alice@example.com", EmailVerified: true}
+	loginResult := &model.LoginResult{Session: &model.Session{SessionUUID: uuid.NewString()}}
+
+	tests := []struct {
+		name          string
+		claims        stubIDClaims
+		allowSignup   bool
+		completeAs    string
+		nonceOverride string
+		// tamperState подменяет сохраненный state перед завершением входа
+		tamperState func(state *model.OIDCState)
+		// badBinding имитирует чужой браузер без cookie привязки
+		badBinding bool
+		setup      func(m oidcMocks)
+		wantErr    error
+	}{
+		{
+			name:   "already linked identity logs in its user",
+			claims: verifiedClaims,
+			setup: func(m oidcMocks) {
+				m.identityRepo.EXPECT().Get(gomock.Any(), stubProviderName, subject).
+					Return(&model.ExternalIdentity{UserUUID: localUser.UserUUID}, nil)
+				m.userRepo.EXPECT().GetByID(gomock.Any(), localUser.UserUUID).Return(localUser, nil)
+				m.authService.EXPECT().LoginExternal(gomock.Any(), localUser).Return(loginResult, nil)
+			},
+		},
+		{
+			name:   "links the account with the same verified email",
+			claims: verifiedClaims,
+			setup: func(m oidcMocks) {
+				m.identityRepo.EXPECT().Get(gomock.Any(), stubProviderName, subject).Return(nil, model.ErrIdentityNotFound)
+				m.userRepo.EXPECT().GetByVerifiedEmail(gomock.Any(), verifiedClaims.Email).Return(localUser, nil)
+				m.identityRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, identity *model.ExternalIdentity) error {
+						require.Equal(t, localUser.UserUUID, identity.UserUUID)
+						require.Equal(t, subject, identity.Subject)
+						return nil
+					})
+				m.authService.EXPECT().LoginExternal(gomock.Any(), localUser).Return(loginResult, nil)
+			},
+		},
+		{
+			name:        "creates a user when signup is allowed",
+			claims:      verifiedClaims,
+			allowSignup: true,
+			setup: func(m oidcMocks) {
+				m.identityRepo.EXPECT().Get(gomock.Any(), stubProviderName, subject).Return(nil, model.ErrIdentityNotFound)
+				m.userRepo.EXPECT().GetByVerifiedEmail(gomock.Any(), verifiedClaims.Email).Return(nil, model.ErrUserNotFound)
+				m.userRepo.EXPECT().GetByLogin(gomock.Any(), verifiedClaims.Email).Return(nil, model.ErrUserNotFound)
+				m.userRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, u *model.User) error {
+						require.True(t, u.EmailVerified)
+						require.Equal(t, verifiedClaims.Email, u.Email)
+						return nil
+					})
+				m.identityRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+				m.authService.EXPECT().LoginExternal(gomock.Any(), gomock.Any()).Return(loginResult, nil)
+			},
+		},
+		{
+			name:   "refuses to create a user when signup is disabled",
+			claims: verifiedClaims,
+			setup: func(m oidcMocks) {
+				m.identityRepo.EXPECT().Get(gomock.Any(), stubProviderName, subject).Return(nil, model.ErrIdentityNotFound)
+				m.userRepo.EXPECT().GetByVerifiedEmail(gomock.Any(), verifiedClaims.Email).Return(nil, model.ErrUserNotFound)
+			},
+			wantErr: model.ErrOIDCAccountNotLinkable,
+		},
+		{
+			name:   "email not verified by the provider is not linked",
+			claims: stubIDClaims{Subject: subject, Email: "alice@example.com"},
+			setup: func(m oidcMocks) {
+				m.identityRepo.EXPECT().Get(gomock.Any(), stubProviderName, subject).Return(nil, model.ErrIdentityNotFound)
+			},
+			wantErr: model.ErrOIDCEmailNotVerified,
+		},
+		{
+			name:          "id token with another nonce is rejected",
+			claims:        verifiedClaims,
+			nonceOverride: "replayed-nonce",
+			wantErr:       model.ErrOIDCLoginFailed,
+		},
+		{
+			name:   "code exchange with a wrong PKCE verifier is rejected",
+			claims: verifiedClaims,
+			tamperState: func(state *model.OIDCState) {
+				state.CodeVerifier = "attacker-verifier-attacker-verifier-attacker"
+			},
+			wantErr: model.ErrOIDCLoginFailed,
+		},
+		{
+			name:       "state returned to another browser is rejected",
+			claims:     verifiedClaims,
+			badBinding: true,
+			wantErr:    model.ErrInvalidOIDCState,
+		},
+		{
+			name:       "state issued for another provider is rejected",
+			claims:     verifiedClaims,
+			completeAs: "other",
+			wantErr:    model.ErrInvalidOIDCState,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			ctx := context.Background()
+
+			m := oidcMocks{
+				userRepo:     mocks.NewMockUserRepository(ctrl),
+				identityRepo: mocks.NewMockIdentityRepository(ctrl),
+				authService:  mocks.NewMockAuthService(ctrl),
+			}
+			if tt.setup != nil {
+				tt.setup(m)
+			}
+
+			auditSvc := mocks.NewMockAuditService(ctrl)
+			auditSvc.EXPECT().Record(gomock.Any(), gomock.Any()).AnyTimes()
+
+			var saved *model.OIDCState
+			stateRepo := mocks.NewMockOIDCStateRepository(ctrl)
+			stateRepo.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, state *model.OIDCState) error {
+					saved = state
+					return nil
+				})
+			stateRepo.EXPECT().Consume(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, state string) (*model.OIDCState, error) {
+					if saved == nil || saved.State != state {
+						return nil, model.ErrInvalidOIDCState
+					}
+					consumed := *saved
+					saved = nil
+					if tt.tamperState != nil {
+						tt.tamperState(&consumed)
+					}
+					return &consumed, nil
+				})
+
+			providerCfg := env.OIDCProvider{
+				Name:         stubProviderName,
+				IssuerURL:    provider.issuer(),
+				ClientID:     stubClientID,
+				ClientSecret: stubClientSecret,
+				RedirectURL:  "http://localhost:8080/auth/oidc/mock/callback",
+				Scopes:       []string{"openid", "email"},
+			}
+			otherCfg := providerCfg
+			otherCfg.Name = "other"
+
+			svc := oidc.NewService(m.userRepo, m.identityRepo, stateRepo, m.authService, auditSvc, &oidcTestConfig{
+				providers:   []env.OIDCProvider{providerCfg, otherCfg},
+				allowSignup: tt.allowSignup,
+			})
+
+			start, err := svc.Start(ctx, stubProviderName)
+			require.NoError(t, err)
+			require.NotEmpty(t, start.Binding)
+			require.Equal(t, start.Binding != "", saved != nil && saved.BindingHash != "")
+
+			state, code := provider.authorize(start.AuthorizationURL, tt.claims, tt.nonceOverride)
+
+			binding := start.Binding
+			if tt.badBinding {
+				binding = ""
+			}
+
+			completeAs := stubProviderName
+			if tt.completeAs != "" {
+				completeAs = tt.completeAs
+			}
+
+			result, err := svc.Complete(ctx, completeAs, state, code, binding)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, loginResult, result)
+		})
+	}
+}
+
+func TestOIDCStart_UnknownProvider(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	svc := oidc.NewService(
+		mocks.NewMockUserRepository(ctrl),
+		mocks.NewMockIdentityRepository(ctrl),
+		mocks.NewMockOIDCStateRepository(ctrl),
+		mocks.NewMockAuthService(ctrl),
+		mocks.NewMockAuditService(ctrl),
+		&oidcTestConfig{},
+	)
+
+	_, err := svc.Start(context.Background(), "unknown")
+	require.ErrorIs(t, err, model.ErrUnknownOIDCProvider)
+
+	_, err = svc.Complete(context.Background(), "unknown", "state", "code", "binding")
+	require.ErrorIs(t, err, model.ErrUnknownOIDCProvider)
+}
@@ -0,0 +1,293 @@
+package tests
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/linemk/rocket-shop/iam/internal/mocks"
+	"github.com/linemk/rocket-shop/iam/internal/model"
+	"github.com/linemk/rocket-shop/iam/internal/service/auth"
+	"github.com/linemk/rocket-shop/iam/internal/service/password"
+)
+
+// passwordTestConfig использует облегченные параметры argon2id, чтобы тесты работали быстро
+type passwordTestConfig struct {
+	memory        uint32
+	requireSymbol bool
+}
+
+func (c passwordTestConfig) Argon2Memory() uint32 {
+	if c.memory == 0 {
+		return 1024
+	}
+	return c.memory
+}
+
+func (passwordTestConfig) Argon2Iterations() uint32 { return 1 }
+
+func (passwordTestConfig) Argon2Parallelism() uint8 { return 1 }
+
+func (passwordTestConfig) MinLength() int { return 8 }
+
+func (passwordTestConfig) MaxLength() int { return 64 }
+
+func (passwordTestConfig) RequireLetter() bool { return true }
+
+func (passwordTestConfig) RequireDigit() bool { return true }
+
+func (c passwordTestConfig) RequireSymbol() bool { return c.requireSymbol }
+
+func TestPasswordVerify(t *testing.T) {
+	const secret = "correct-horse-1"
+
+	svc := password.NewService(passwordTestConfig{})
+
+	argon2Hash, err := svc.Hash(secret)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(argon2Hash, "$argon2id$v=19$m=1024,t=1,p=1$"))
+
+	otherHash, err := svc.Hash(secret)
+	require.NoError(t, err)
+	require.NotEqual(t, argon2Hash, otherHash, "hash must be salted")
+
+	weakerHash, err := password.NewService(passwordTestConfig{memory: 512}).Hash(secret)
+	require.NoError(t, err)
+
+	bcryptHash, err := bcrypt.GenerateFromPassword([]byte(secret), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name            string
+		hash            string
+		password        string
+		wantNeedsRehash bool
+		wantErr         error
+	}{
+		{
+			name:     "current argon2id hash",
+			hash:     argon2Hash,
+			password: secret,
+		},
+		{
+			name:     "wrong password for argon2id hash",
+			hash:     argon2Hash,
+			password: "correct-horse-2",
+			wantErr:  model.ErrInvalidPassword,
+		},
+		{
+			name:            "argon2id hash with outdated parameters needs rehash",
+			hash:            weakerHash,
+			password:        secret,
+			wantNeedsRehash: true,
+		},
+		{
+			name:            "legacy bcrypt hash needs rehash",
+			hash:            string(bcryptHash),
+			password:        secret,
+			wantNeedsRehash: true,
+		},
+		{
+			name:     "wrong password for bcrypt hash",
+			hash:     string(bcryptHash),
+			password: "correct-horse-2",
+			wantErr:  model.ErrInvalidPassword,
+		},
+		{
+			name:     "user without a local password",
+			password: secret,
+			wantErr:  model.ErrInvalidPassword,
+		},
+		{
+			name:     "malformed argon2id hash",
+			hash:     "$argon2id$v=19$m=1024,t=1,p=1$not-base64!",
+			password: secret,
+			wantErr:  model.ErrUnsupportedPasswordHash,
+		},
+		{
+			name:     "unknown hash algorithm",
+			hash:     "$1$md5$hash",
+			password: secret,
+			wantErr:  model.ErrUnsupportedPasswordHash,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			needsRehash, err := svc.Verify(tt.hash, tt.password)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.wantNeedsRehash, needsRehash)
+		})
+	}
+}
+
+func TestPasswordValidate(t *testing.T) {
+	tests := []struct {
+		name          string
+		password      string
+		requireSymbol bool
+		wantErr       error
+	}{
+		{name: "letters and digits", password: "rocket2025"},
+		{name: "too short", password: "rock1", wantErr: model.ErrWeakPassword},
+		{name: "too long", password: strings.Repeat("a1", 33), wantErr: model.ErrWeakPassword},
+		{name: "no digit", password: "rocketship", wantErr: model.ErrWeakPassword},
+		{name: "no letter", password: "1234567890", wantErr: model.ErrWeakPassword},
+		{name: "length counts runes", password: "ракета12"},
+		{name: "symbol required", password: "rocket2025", requireSymbol: true, wantErr: model.ErrWeakPassword},
+		{name: "symbol present", password: "rocket-2025", requireSymbol: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := password.NewService(passwordTestConfig{requireSymbol: tt.requireSymbol})
+
+			err := svc.Validate(tt.password)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+type authTestSessionConfig struct{}
+
+func (authTestSessionConfig) TTL() time.Duration { return time.Hour }
+
+type authTestJWTConfig struct{}
+
+func (authTestJWTConfig) Enabled() bool { return false }
+
+func (authTestJWTConfig) Issuer() string { return "" }
+
+func (authTestJWTConfig) AccessTTL() time.Duration { return 0 }
+
+func (authTestJWTConfig) KeyRotationInterval() time.Duration { return 0 }
+
+func (authTestJWTConfig) KeyEncryptionKey() []byte { return nil }
+
+func (authTestJWTConfig) HTTPAddress() string { return "" }
+
+type noopLogger struct{}
+
+func (noopLogger) Warn(context.Context, string, ...zap.Field) {}
+
+func TestLogin_RehashesPassword(t *testing.T) {
+	const secret = "correct-horse-1"
+
+	passwordSvc := password.NewService(passwordTestConfig{})
+
+	bcryptHash, err := bcrypt.GenerateFromPassword([]byte(secret), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	argon2Hash, err := passwordSvc.Hash(secret)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		hash        string
+		password    string
+		wantRehash  bool
+		rehashErr   error
+		wantErr     error
+		wantSession bool
+	}{
+		{
+			name:        "bcrypt hash is upgraded to argon2id",
+			hash:        string(bcryptHash),
+			password:    secret,
+			wantRehash:  true,
+			wantSession: true,
+		},
+		{
+			name:        "failed rehash does not block login",
+			hash:        string(bcryptHash),
+			password:    secret,
+			wantRehash:  true,
+			rehashErr:   context.DeadlineExceeded,
+			wantSession: true,
+		},
+		{
+			name:        "current argon2id hash is left as is",
+			hash:        argon2Hash,
+			password:    secret,
+			wantSession: true,
+		},
+		{
+			name:     "wrong password is not rehashed",
+			hash:     string(bcryptHash),
+			password: "correct-horse-2",
+			wantErr:  model.ErrInvalidCredentials,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			ctx := context.Background()
+
+			user := &model.User{UserUUID: uuid.NewString(), Login: "alice", PasswordHash: tt.hash}
+
+			userRepo := mocks.NewMockUserRepository(ctrl)
+			userRepo.EXPECT().GetByLogin(gomock.Any(), user.Login).Return(user, nil)
+			if tt.wantRehash {
+				userRepo.EXPECT().UpdatePasswordHash(gomock.Any(), user.UserUUID, gomock.Any()).DoAndReturn(
+					func(_ context.Context, _ string, passwordHash string) error {
+						require.True(t, strings.HasPrefix(passwordHash, "$argon2id$"))
+						needsRehash, err := passwordSvc.Verify(passwordHash, secret)
+						require.NoError(t, err)
+						require.False(t, needsRehash)
+						return tt.rehashErr
+					})
+			}
+
+			sessionRepo := mocks.NewMockSessionRepository(ctrl)
+			if tt.wantSession {
+				sessionRepo.EXPECT().Create(gomock.Any(), gomock.Any(), time.Hour).Return(nil)
+				sessionRepo.EXPECT().AddSessionToUserSet(gomock.Any(), user.UserUUID, gomock.Any()).Return(nil)
+			}
+
+			auditSvc := mocks.NewMockAuditService(ctrl)
+			auditSvc.EXPECT().Record(gomock.Any(), gomock.Any()).AnyTimes()
+
+			svc := auth.NewService(
+				userRepo,
+				sessionRepo,
+				nil,
+				nil,
+				passwordSvc,
+				nil,
+				auditSvc,
+				authTestSessionConfig{},
+				mfaTestConfig{},
+				authTestJWTConfig{},
+				noopLogger{},
+			)
+
+			result, err := svc.Login(ctx, user.Login, tt.password)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, result.Session)
+			require.Equal(t, user.UserUUID, result.Session.UserUUID)
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/linemk/rocket-shop/iam/internal/mocks"
+	"github.com/linemk/rocket-shop/iam/internal/model"
+	"github.com/linemk/rocket-shop/iam/internal/service/password"
+	"github.com/linemk/rocket-shop/iam/internal/service/user"
+)
+
+type userMocks struct {
+	userRepo    *mocks.MockUserRepository
+	sessionRepo *mocks.MockSessionRepository
+	audit       *mocks.MockAuditService
+	whoamiCache *mocks.MockWhoamiCacheService
+}
+
+func newUserTestService(t *testing.T) (user.Service, userMocks, password.Service) {
+	ctrl := gomock.NewController(t)
+
+	m := userMocks{
+		userRepo:    mocks.NewMockUserRepository(ctrl),
+		sessionRepo: mocks.NewMockSessionRepository(ctrl),
+		audit:       mocks.NewMockAuditService(ctrl),
+		whoamiCache: mocks.NewMockWhoamiCacheService(ctrl),
+	}
+	passwordSvc := password.NewService(passwordTestConfig{})
+
+	return user.NewService(m.userRepo, m.sessionRepo, passwordSvc, m.audit, m.whoamiCache), m, passwordSvc
+}
+
+func TestUpdateProfile(t *testing.T) {
+	userUUID := uuid.NewString()
+
+	tests := []struct {
+		name              string
+		email             string
+		wantEmailVerified bool
+	}{
+		{
+			name:              "new email resets verification",
+			email:             "new@example.com",
+			wantEmailVerified: false,
+		},
+		{
+			name:              "same email keeps verification",
+			email:             "old@example.com",
+			wantEmailVerified: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, m, _ := newUserTestService(t)
+			ctx := context.Background()
+
+			m.userRepo.EXPECT().GetByID(gomock.Any(), userUUID).Return(&model.User{
+				UserUUID:      userUUID,
+				Email:         "old@example.com",
+				EmailVerified: true,
+			}, nil)
+			m.userRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, u *model.User) error {
+					require.Equal(t, tt.email, u.Email)
+					require.Equal(t, tt.wantEmailVerified, u.EmailVerified)
+					return nil
+				})
+			m.whoamiCache.EXPECT().InvalidateUser(gomock.Any(), userUUID)
+
+			updated, err := svc.UpdateProfile(ctx, userUUID, tt.email)
+			require.NoError(t, err)
+			require.Equal(t, tt.email, updated.Email)
+			require.Equal(t, tt.wantEmailVerified, updated.EmailVerified)
+		})
+	}
+}
+
+func TestChangePassword(t *testing.T) {
+	const (
+		currentPassword = "correct-horse-1"
+		newPassword     = "battery-staple-2"
+	)
+
+	userUUID := uuid.NewString()
+	currentSession := uuid.NewString()
+	otherSessions := []string{uuid.NewString(), uuid.NewString()}
+
+	t.Run("keeps the current session and revokes the others", func(t *testing.T) {
+		svc, m, passwordSvc := newUserTestService(t)
+		ctx := context.Background()
+
+		currentHash, err := passwordSvc.Hash(currentPassword)
+		require.NoError(t, err)
+
+		m.userRepo.EXPECT().GetByID(gomock.Any(), userUUID).Return(&model.User{UserUUID: userUUID, PasswordHash: currentHash}, nil)
+		m.userRepo.EXPECT().UpdatePasswordHash(gomock.Any(), userUUID, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ string, passwordHash string) error {
+				_, err := passwordSvc.Verify(passwordHash, newPassword)
+				require.NoError(t, err, "stored hash must match the new password")
+				return nil
+			})
+		m.sessionRepo.EXPECT().GetUserSessions(gomock.Any(), userUUID).
+			Return(append([]string{currentSession}, otherSessions...), nil)
+		for _, sessionUUID := range otherSessions {
+			m.sessionRepo.EXPECT().Delete(gomock.Any(), sessionUUID).Return(nil)
+			m.whoamiCache.EXPECT().InvalidateSession(gomock.Any(), sessionUUID)
+			m.sessionRepo.EXPECT().RemoveSessionFromUserSet(gomock.Any(), userUUID, sessionUUID).Return(nil)
+			m.audit.EXPECT().Record(gomock.Any(), gomock.Any()).Do(
+				func(_ context.Context, event *model.AuditEvent) {
+					require.Equal(t, model.AuditEventSessionRevoked, event.EventType)
+					require.NotEqual(t, currentSession, event.SessionUUID)
+				})
+		}
+
+		require.NoError(t, svc.ChangePassword(ctx, userUUID, currentPassword, newPassword, currentSession))
+	})
+
+	t.Run("wrong current password changes nothing", func(t *testing.T) {
+		svc, m, passwordSvc := newUserTestService(t)
+
+		currentHash, err := passwordSvc.Hash(currentPassword)
+		require.NoError(t, err)
+
+		m.userRepo.EXPECT().GetByID(gomock.Any(), userUUID).Return(&model.User{UserUUID: userUUID, PasswordHash: currentHash}, nil)
+
+		err = svc.ChangePassword(context.Background(), userUUID, "wrong-password-3", newPassword, currentSession)
+		require.ErrorIs(t, err, model.ErrInvalidPassword)
+	})
+}
+
+func TestDeleteUser(t *testing.T) {
+	svc, m, _ := newUserTestService(t)
+	userUUID := uuid.NewString()
+
+	gomock.InOrder(
+		m.userRepo.EXPECT().Delete(gomock.Any(), userUUID).Return(nil),
+		m.sessionRepo.EXPECT().DeleteUserSessions(gomock.Any(), userUUID).Return(nil),
+		m.whoamiCache.EXPECT().InvalidateUser(gomock.Any(), userUUID),
+		m.audit.EXPECT().Record(gomock.Any(), gomock.Any()).Do(
+			func(_ context.Context, event *model.AuditEvent) {
+				require.Equal(t, model.AuditEventSessionRevoked, event.EventType)
+				require.Equal(t, userUUID, event.UserUUID)
+			}),
+	)
+
+	require.NoError(t, svc.DeleteUser(context.Background(), userUUID))
+}
@@ -0,0 +1,280 @@
+package tests
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/linemk/rocket-shop/iam/internal/metrics"
+	"github.com/linemk/rocket-shop/iam/internal/mocks"
+	"github.com/linemk/rocket-shop/iam/internal/model"
+	"github.com/linemk/rocket-shop/iam/internal/service/whoami_cache"
+)
+
+func (noopLogger) Info(context.Context, string, ...zap.Field) {}
+
+func (noopLogger) Error(context.Context, string, ...zap.Field) {}
+
+type whoamiCacheTestConfig struct {
+	ttl        time.Duration
+	maxEntries int
+}
+
+func (whoamiCacheTestConfig) Enabled() bool { return true }
+
+func (c whoamiCacheTestConfig) TTL() time.Duration { return c.ttl }
+
+func (c whoamiCacheTestConfig) MaxEntries() int { return c.maxEntries }
+
+func (whoamiCacheTestConfig) InvalidationChannel() string { return "iam:whoami:invalidate" }
+
+// memoryPubSub доставляет сообщения всем подписчикам канала вместо Redis Pub/Sub
+type memoryPubSub struct {
+	mu          sync.Mutex
+	subscribers map[string][]func(message []byte)
+	subscribed  chan struct{}
+}
+
+func newMemoryPubSub() *memoryPubSub {
+	return &memoryPubSub{
+		subscribers: make(map[string][]func(message []byte)),
+		subscribed:  make(chan struct{}, 8),
+	}
+}
+
+func (p *memoryPubSub) Publish(_ context.Context, channel string, message []byte) error {
+	p.mu.Lock()
+	handlers := append([]func(message []byte){}, p.subscribers[channel]...)
+	p.mu.Unlock()
+
+	for _, handler := range handlers {
+		handler(message)
+	}
+
+	return nil
+}
+
+func (p *memoryPubSub) Subscribe(ctx context.Context, channel string, handler func(message []byte)) error {
+	p.mu.Lock()
+	p.subscribers[channel] = append(p.subscribers[channel], handler)
+	p.mu.Unlock()
+
+	p.subscribed <- struct{}{}
+	<-ctx.Done()
+
+	return nil
+}
+
+func newWhoamiCacheTestMetrics() *metrics.WhoamiCacheMetrics {
+	return &metrics.WhoamiCacheMetrics{
+		Requests:      prometheus.NewCounterVec(prometheus.CounterOpts{Name: "requests"}, []string{"result"}),
+		Invalidations: prometheus.NewCounterVec(prometheus.CounterOpts{Name: "invalidations"}, []string{"kind"}),
+		Evictions:     prometheus.NewCounterVec(prometheus.CounterOpts{Name: "evictions"}, []string{}),
+	}
+}
+
+func newWhoamiCacheTestService(t *testing.T, cfg whoamiCacheTestConfig, pubSub *memoryPubSub) (whoami_cache.Service, *mocks.MockAuthService) {
+	ctrl := gomock.NewController(t)
+	authSvc := mocks.NewMockAuthService(ctrl)
+
+	return whoami_cache.NewService(authSvc, pubSub, newWhoamiCacheTestMetrics(), cfg, noopLogger{}), authSvc
+}
+
+func liveSession(sessionUUID, userUUID string) *model.Session {
+	return &model.Session{SessionUUID: sessionUUID, UserUUID: userUUID, ExpiresAt: time.Now().Add(time.Hour)}
+}
+
+func TestWhoamiCache(t *testing.T) {
+	ctx := context.Background()
+	cfg := whoamiCacheTestConfig{ttl: time.Minute, maxEntries: 2}
+
+	user := &model.User{UserUUID: uuid.NewString(), Login: "alice"}
+	sessionUUID := uuid.NewString()
+
+	t.Run("second lookup is served from the cache", func(t *testing.T) {
+		svc, authSvc := newWhoamiCacheTestService(t, cfg, newMemoryPubSub())
+		authSvc.EXPECT().WhoamiSession(gomock.Any(), sessionUUID).Return(user, liveSession(sessionUUID, user.UserUUID), nil).Times(1)
+
+		for i := 0; i < 2; i++ {
+			got, err := svc.Whoami(ctx, sessionUUID)
+			require.NoError(t, err)
+			require.Equal(t, user, got)
+		}
+	})
+
+	t.Run("errors are not cached", func(t *testing.T) {
+		svc, authSvc := newWhoamiCacheTestService(t, cfg, newMemoryPubSub())
+		authSvc.EXPECT().WhoamiSession(gomock.Any(), sessionUUID).Return(nil, nil, model.ErrSessionNotFound).Times(2)
+
+		for i := 0; i < 2; i++ {
+			_, err := svc.Whoami(ctx, sessionUUID)
+			require.ErrorIs(t, err, model.ErrSessionNotFound)
+		}
+	})
+
+	t.Run("entry does not outlive the session", func(t *testing.T) {
+		svc, authSvc := newWhoamiCacheTestService(t, cfg, newMemoryPubSub())
+		session := liveSession(sessionUUID, user.UserUUID)
+		session.ExpiresAt = time.Now().Add(50 * time.Millisecond)
+		authSvc.EXPECT().WhoamiSession(gomock.Any(), sessionUUID).Return(user, session, nil)
+
+		_, err := svc.Whoami(ctx, sessionUUID)
+		require.NoError(t, err)
+
+		time.Sleep(100 * time.Millisecond)
+
+		authSvc.EXPECT().WhoamiSession(gomock.Any(), sessionUUID).Return(nil, nil, model.ErrSessionExpired)
+		_, err = svc.Whoami(ctx, sessionUUID)
+		require.ErrorIs(t, err, model.ErrSessionExpired)
+	})
+
+	t.Run("least recently used entry is evicted", func(t *testing.T) {
+		svc, authSvc := newWhoamiCacheTestService(t, cfg, newMemoryPubSub())
+		first, second, third := uuid.NewString(), uuid.NewString(), uuid.NewString()
+		authSvc.EXPECT().WhoamiSession(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, sessionUUID string) (*model.User, *model.Session, error) {
+				return user, liveSession(sessionUUID, user.UserUUID), nil
+			}).Times(4)
+
+		for _, s := range []string{first, second, first, third} {
+			_, err := svc.Whoami(ctx, s)
+			require.NoError(t, err)
+		}
+
+		// first использовалась позже second, поэтому при добавлении third вытеснена second
+		_, err := svc.Whoami(ctx, first)
+		require.NoError(t, err)
+		_, err = svc.Whoami(ctx, second)
+		require.NoError(t, err)
+	})
+}
+
+func TestWhoamiCacheInvalidation(t *testing.T) {
+	ctx := context.Background()
+	cfg := whoamiCacheTestConfig{ttl: time.Minute, maxEntries: 10}
+
+	user := &model.User{UserUUID: uuid.NewString(), Login: "alice"}
+	sessionUUID := uuid.NewString()
+	otherSessionUUID := uuid.NewString()
+
+	tests := []struct {
+		name       string
+		invalidate func(svc whoami_cache.Service)
+		// wantReload сессии, которые после инвалидации снова читаются из auth сервиса
+		wantReload []string
+	}{
+		{
+			name:       "session invalidation drops only that session",
+			invalidate: func(svc whoami_cache.Service) { svc.InvalidateSession(ctx, sessionUUID) },
+			wantReload: []string{sessionUUID},
+		},
+		{
+			name:       "user invalidation drops every session of the user",
+			invalidate: func(svc whoami_cache.Service) { svc.InvalidateUser(ctx, user.UserUUID) },
+			wantReload: []string{sessionUUID, otherSessionUUID},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, authSvc := newWhoamiCacheTestService(t, cfg, newMemoryPubSub())
+			authSvc.EXPECT().WhoamiSession(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, sessionUUID string) (*model.User, *model.Session, error) {
+					return user, liveSession(sessionUUID, user.UserUUID), nil
+				}).Times(2 + len(tt.wantReload))
+
+			for _, s := range []string{sessionUUID, otherSessionUUID} {
+				_, err := svc.Whoami(ctx, s)
+				require.NoError(t, err)
+			}
+
+			tt.invalidate(svc)
+
+			for _, s := range []string{sessionUUID, otherSessionUUID} {
+				_, err := svc.Whoami(ctx, s)
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestWhoamiCacheInvalidationFromOtherInstance(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cfg := whoamiCacheTestConfig{ttl: time.Minute, maxEntries: 10}
+	pubSub := newMemoryPubSub()
+
+	user := &model.User{UserUUID: uuid.NewString(), Login: "alice"}
+	sessionUUID := uuid.NewString()
+
+	listener, authSvc := newWhoamiCacheTestService(t, cfg, pubSub)
+	publisher, _ := newWhoamiCacheTestService(t, cfg, pubSub)
+
+	go func() {
+		_ = listener.RunInvalidationListener(ctx)
+	}()
+	<-pubSub.subscribed
+
+	authSvc.EXPECT().WhoamiSession(gomock.Any(), sessionUUID).Return(user, liveSession(sessionUUID, user.UserUUID), nil).Times(2)
+
+	_, err := listener.Whoami(ctx, sessionUUID)
+	require.NoError(t, err)
+
+	// Сессию отозвал другой инстанс IAM: запись сбрасывается через Pub/Sub
+	publisher.InvalidateSession(ctx, sessionUUID)
+
+	_, err = listener.Whoami(ctx, sessionUUID)
+	require.NoError(t, err)
+}
+
+func TestWhoamiCacheInvalidationDuringLoad(t *testing.T) {
+	ctx := context.Background()
+	cfg := whoamiCacheTestConfig{ttl: time.Minute, maxEntries: 10}
+
+	user := &model.User{UserUUID: uuid.NewString(), Login: "alice"}
+	sessionUUID := uuid.NewString()
+
+	tests := []struct {
+		name       string
+		invalidate func(svc whoami_cache.Service)
+	}{
+		{
+			name:       "session revoked while it is read",
+			invalidate: func(svc whoami_cache.Service) { svc.InvalidateSession(ctx, sessionUUID) },
+		},
+		{
+			name:       "user changed while the session is read",
+			invalidate: func(svc whoami_cache.Service) { svc.InvalidateUser(ctx, user.UserUUID) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, authSvc := newWhoamiCacheTestService(t, cfg, newMemoryPubSub())
+
+			gomock.InOrder(
+				// Сессию отзывают после того, как загрузка прочитала ее из Redis, но до записи в кеш
+				authSvc.EXPECT().WhoamiSession(gomock.Any(), sessionUUID).DoAndReturn(
+					func(_ context.Context, _ string) (*model.User, *model.Session, error) {
+						tt.invalidate(svc)
+						return user, liveSession(sessionUUID, user.UserUUID), nil
+					}),
+				authSvc.EXPECT().WhoamiSession(gomock.Any(), sessionUUID).Return(nil, nil, model.ErrSessionNotFound),
+			)
+
+			_, err := svc.Whoami(ctx, sessionUUID)
+			require.NoError(t, err)
+
+			_, err = svc.Whoami(ctx, sessionUUID)
+			require.ErrorIs(t, err, model.ErrSessionNotFound, "revoked session must not be served from the cache")
+		})
+	}
+}
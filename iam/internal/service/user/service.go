@@ -5,28 +5,59 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
-	"golang.org/x/crypto/bcrypt"
 
 	"github.com/linemk/rocket-shop/iam/internal/model"
+	"github.com/linemk/rocket-shop/iam/internal/repository/session"
 	"github.com/linemk/rocket-shop/iam/internal/repository/user"
+	"github.com/linemk/rocket-shop/iam/internal/service/audit"
+	"github.com/linemk/rocket-shop/iam/internal/service/password"
+	"github.com/linemk/rocket-shop/iam/internal/service/whoami_cache"
 )
 
 type Service interface {
 	Register(ctx context.Context, login, password, email string, notificationMethods []model.NotificationMethod) (*model.User, error)
 	GetUser(ctx context.Context, userUUID string) (*model.User, error)
+	UpdateProfile(ctx context.Context, userUUID, email string) (*model.User, error)
+	ChangePassword(ctx context.Context, userUUID, currentPassword, newPassword, currentSessionUUID string) error
+	UpdateNotificationMethods(ctx context.Context, userUUID string, notificationMethods []model.NotificationMethod) (*model.User, error)
+	DeleteUser(ctx context.Context, userUUID string) error
 }
 
 type service struct {
-	userRepo user.Repository
+	userRepo        user.Repository
+	sessionRepo     session.Repository
+	passwordService password.Service
+	auditService    audit.Service
+	whoamiCache     whoami_cache.Invalidator
 }
 
-func NewService(userRepo user.Repository) Service {
+func NewService(
+	userRepo user.Repository,
+	sessionRepo session.Repository,
+	passwordService password.Service,
+	auditService audit.Service,
+	whoamiCache whoami_cache.Invalidator,
+) Service {
 	return &service{
-		userRepo: userRepo,
+		userRepo:        userRepo,
+		sessionRepo:     sessionRepo,
+		passwordService: passwordService,
+		auditService:    auditService,
+		whoamiCache:     whoamiCache,
 	}
 }
 
 func (s *service) Register(ctx context.Context, login, password, email string, notificationMethods []model.NotificationMethod) (*model.User, error) {
+	err := validateNotificationMethods(notificationMethods)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.passwordService.Validate(password)
+	if err != nil {
+		return nil, err
+	}
+
 	existingUser, err := s.userRepo.GetByLogin(ctx, login)
 	if err != nil && !errors.Is(err, model.ErrUserNotFound) {
 		return nil, errors.Wrap(err, "failed to check if user exists")
@@ -36,7 +67,7 @@ func (s *service) Register(ctx context.Context, login, password, email string, n
 		return nil, model.ErrUserAlreadyExists
 	}
 
-	passwordHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	passwordHash, err := s.passwordService.Hash(password)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to hash password")
 	}
@@ -44,9 +75,10 @@ func (s *service) Register(ctx context.Context, login, password, email string, n
 	newUser := &model.User{
 		UserUUID:            uuid.New().String(),
 		Login:               login,
-		PasswordHash:        string(passwordHash),
+		PasswordHash:        passwordHash,
 		Email:               email,
 		NotificationMethods: notificationMethods,
+		Roles:               []string{model.RoleUser},
 	}
 
 	err = s.userRepo.Create(ctx, newUser)
@@ -65,3 +97,143 @@ func (s *service) GetUser(ctx context.Context, userUUID string) (*model.User, er
 
 	return user, nil
 }
+
+func (s *service) UpdateProfile(ctx context.Context, userUUID, email string) (*model.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userUUID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get user")
+	}
+
+	if user.Email != email {
+		user.Email = email
+		user.EmailVerified = false
+	}
+
+	err = s.userRepo.Update(ctx, user)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to update user")
+	}
+
+	s.whoamiCache.InvalidateUser(ctx, userUUID)
+
+	return user, nil
+}
+
+func (s *service) ChangePassword(ctx context.Context, userUUID, currentPassword, newPassword, currentSessionUUID string) error {
+	user, err := s.userRepo.GetByID(ctx, userUUID)
+	if err != nil {
+		return errors.Wrap(err, "failed to get user")
+	}
+
+	_, err = s.passwordService.Verify(user.PasswordHash, currentPassword)
+	if err != nil {
+		if errors.Is(err, model.ErrInvalidPassword) {
+			return model.ErrInvalidPassword
+		}
+		return errors.Wrap(err, "failed to verify password")
+	}
+
+	err = s.passwordService.Validate(newPassword)
+	if err != nil {
+		return err
+	}
+
+	passwordHash, err := s.passwordService.Hash(newPassword)
+	if err != nil {
+		return errors.Wrap(err, "failed to hash password")
+	}
+
+	err = s.userRepo.UpdatePasswordHash(ctx, user.UserUUID, passwordHash)
+	if err != nil {
+		return errors.Wrap(err, "failed to update password hash")
+	}
+
+	// Завершаем все сессии пользователя, кроме текущей
+	sessionUUIDs, err := s.sessionRepo.GetUserSessions(ctx, userUUID)
+	if err != nil {
+		return errors.Wrap(err, "failed to get user sessions")
+	}
+
+	for _, sessionUUID := range sessionUUIDs {
+		if sessionUUID == currentSessionUUID {
+			continue
+		}
+
+		err = s.sessionRepo.Delete(ctx, sessionUUID)
+		if err != nil {
+			return errors.Wrap(err, "failed to delete session")
+		}
+
+		s.whoamiCache.InvalidateSession(ctx, sessionUUID)
+
+		err = s.sessionRepo.RemoveSessionFromUserSet(ctx, userUUID, sessionUUID)
+		if err != nil {
+			return errors.Wrap(err, "failed to remove session from user set")
+		}
+
+		s.auditService.Record(ctx, &model.AuditEvent{
+			EventType:   model.AuditEventSessionRevoked,
+			UserUUID:    userUUID,
+			SessionUUID: sessionUUID,
+			Reason:      "password changed",
+		})
+	}
+
+	return nil
+}
+
+func (s *service) UpdateNotificationMethods(ctx context.Context, userUUID string, notificationMethods []model.NotificationMethod) (*model.User, error) {
+	err := validateNotificationMethods(notificationMethods)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userUUID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get user")
+	}
+
+	user.NotificationMethods = notificationMethods
+
+	err = s.userRepo.Update(ctx, user)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to update user")
+	}
+
+	s.whoamiCache.InvalidateUser(ctx, userUUID)
+
+	return user, nil
+}
+
+func (s *service) DeleteUser(ctx context.Context, userUUID string) error {
+	err := s.userRepo.Delete(ctx, userUUID)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete user")
+	}
+
+	err = s.sessionRepo.DeleteUserSessions(ctx, userUUID)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete user sessions")
+	}
+
+	s.whoamiCache.InvalidateUser(ctx, userUUID)
+
+	s.auditService.Record(ctx, &model.AuditEvent{
+		EventType: model.AuditEventSessionRevoked,
+		UserUUID:  userUUID,
+		Reason:    "user deleted",
+	})
+
+	return nil
+}
+
+// validateNotificationMethods проверяет, что все провайдеры уведомлений поддерживаются
+func validateNotificationMethods(notificationMethods []model.NotificationMethod) error {
+	for _, method := range notificationMethods {
+		if !model.IsKnownNotificationProvider(method.ProviderName) {
+			return errors.Wrapf(model.ErrUnknownNotificationProvider, "provider %q", method.ProviderName)
+		}
+	}
+
+	return nil
+}
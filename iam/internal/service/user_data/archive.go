@@ -0,0 +1,113 @@
+package user_data
+
+import (
+	"time"
+
+	"github.com/linemk/rocket-shop/iam/internal/model"
+)
+
+// archive формат JSON архива с данными пользователя
+type archive struct {
+	GeneratedAt time.Time         `json:"generated_at"`
+	Profile     archiveProfile    `json:"profile"`
+	Identities  []archiveIdentity `json:"identities"`
+	Orders      []archiveOrder    `json:"orders"`
+	Payments    []archivePayment  `json:"payments"`
+}
+
+type archiveProfile struct {
+	UserUUID            string                      `json:"user_uuid"`
+	Login               string                      `json:"login"`
+	Email               string                      `json:"email,omitempty"`
+	EmailVerified       bool                        `json:"email_verified"`
+	NotificationMethods []archiveNotificationMethod `json:"notification_methods"`
+	TOTPEnabled         bool                        `json:"totp_enabled"`
+	Roles               []string                    `json:"roles"`
+}
+
+type archiveNotificationMethod struct {
+	ProviderName string `json:"provider_name"`
+	Target       string `json:"target"`
+}
+
+type archiveIdentity struct {
+	Provider  string    `json:"provider"`
+	Subject   string    `json:"subject"`
+	Email     string    `json:"email,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type archiveOrder struct {
+	OrderUUID       string   `json:"order_uuid"`
+	PartUUIDs       []string `json:"part_uuids"`
+	TotalPrice      float64  `json:"total_price"`
+	TransactionUUID string   `json:"transaction_uuid,omitempty"`
+	PaymentMethod   string   `json:"payment_method,omitempty"`
+	Status          string   `json:"status"`
+}
+
+type archivePayment struct {
+	TransactionUUID string    `json:"transaction_uuid"`
+	OrderUUID       string    `json:"order_uuid"`
+	PaymentMethod   string    `json:"payment_method"`
+	Amount          float64   `json:"amount"`
+	Status          string    `json:"status"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+func newArchive(
+	user *model.User,
+	identities []*model.ExternalIdentity,
+	orders []model.UserOrder,
+	payments []model.UserPayment,
+	generatedAt time.Time,
+) *archive {
+	// Пустые списки сериализуем как [], а не null
+	notificationMethods := make([]archiveNotificationMethod, 0, len(user.NotificationMethods))
+	for _, method := range user.NotificationMethods {
+		notificationMethods = append(notificationMethods, archiveNotificationMethod{
+			ProviderName: method.ProviderName,
+			Target:       method.Target,
+		})
+	}
+
+	roles := make([]string, 0, len(user.Roles))
+	roles = append(roles, user.Roles...)
+
+	archiveIdentities := make([]archiveIdentity, 0, len(identities))
+	for _, identity := range identities {
+		archiveIdentities = append(archiveIdentities, archiveIdentity{
+			Provider:  identity.Provider,
+			Subject:   identity.Subject,
+			Email:     identity.Email,
+			CreatedAt: identity.CreatedAt,
+		})
+	}
+
+	archiveOrders := make([]archiveOrder, 0, len(orders))
+	for _, order := range orders {
+		archiveOrders = append(archiveOrders, archiveOrder(order))
+	}
+
+	archivePayments := make([]archivePayment, 0, len(payments))
+	for _, payment := range payments {
+		archivePayments = append(archivePayments, archivePayment(payment))
+	}
+
+	return &archive{
+		GeneratedAt: generatedAt,
+		Profile: archiveProfile{
+			UserUUID:            user.UserUUID,
+			Login:               user.Login,
+			Email:               user.Email,
+			EmailVerified:       user.EmailVerified,
+			NotificationMethods: notificationMethods,
+			TOTPEnabled:         user.TOTPEnabled,
+			Roles:               roles,
+		},
+		Identities: archiveIdentities,
+		Orders:     archiveOrders,
+		Payments:   archivePayments,
+	}
+}
@@ -0,0 +1,188 @@
+package user_data
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	paymentClient "github.com/linemk/rocket-shop/iam/internal/client/grpc/payment/v1"
+	orderClient "github.com/linemk/rocket-shop/iam/internal/client/http/order/v1"
+	"github.com/linemk/rocket-shop/iam/internal/config"
+	"github.com/linemk/rocket-shop/iam/internal/model"
+	"github.com/linemk/rocket-shop/iam/internal/repository/api_key"
+	"github.com/linemk/rocket-shop/iam/internal/repository/audit_event"
+	"github.com/linemk/rocket-shop/iam/internal/repository/identity"
+	"github.com/linemk/rocket-shop/iam/internal/repository/session"
+	"github.com/linemk/rocket-shop/iam/internal/repository/user"
+	"github.com/linemk/rocket-shop/iam/internal/service/audit"
+	"github.com/linemk/rocket-shop/iam/internal/service/producer/user_erased_producer"
+	"github.com/linemk/rocket-shop/iam/internal/service/whoami_cache"
+)
+
+type Service interface {
+	// Export собирает профиль, заказы и платежи пользователя в JSON архив
+	Export(ctx context.Context, userUUID string) ([]byte, time.Time, error)
+	// Erase удаляет персональные данные пользователя в IAM и публикует событие UserErased для остальных сервисов.
+	// Все шаги идемпотентны, поэтому при ошибке запрос можно безопасно повторить
+	Erase(ctx context.Context, userUUID string) (time.Time, error)
+}
+
+type service struct {
+	userRepo           user.Repository
+	sessionRepo        session.Repository
+	apiKeyRepo         api_key.Repository
+	identityRepo       identity.Repository
+	auditEventRepo     audit_event.Repository
+	orderClient        orderClient.OrderClient
+	paymentClient      paymentClient.PaymentClient
+	userErasedProducer user_erased_producer.Producer
+	auditService       audit.Service
+	whoamiCache        whoami_cache.Invalidator
+	userDataCfg        config.UserDataConfig
+}
+
+func NewService(
+	userRepo user.Repository,
+	sessionRepo session.Repository,
+	apiKeyRepo api_key.Repository,
+	identityRepo identity.Repository,
+	auditEventRepo audit_event.Repository,
+	orderClient orderClient.OrderClient,
+	paymentClient paymentClient.PaymentClient,
+	userErasedProducer user_erased_producer.Producer,
+	auditService audit.Service,
+	whoamiCache whoami_cache.Invalidator,
+	userDataCfg config.UserDataConfig,
+) Service {
+	return &service{
+		userRepo:           userRepo,
+		sessionRepo:        sessionRepo,
+		apiKeyRepo:         apiKeyRepo,
+		identityRepo:       identityRepo,
+		auditEventRepo:     auditEventRepo,
+		orderClient:        orderClient,
+		paymentClient:      paymentClient,
+		userErasedProducer: userErasedProducer,
+		auditService:       auditService,
+		whoamiCache:        whoamiCache,
+		userDataCfg:        userDataCfg,
+	}
+}
+
+func (s *service) Export(ctx context.Context, userUUID string) ([]byte, time.Time, error) {
+	user, err := s.userRepo.GetByID(ctx, userUUID)
+	if err != nil {
+		return nil, time.Time{}, errors.Wrap(err, "failed to get user")
+	}
+
+	identities, err := s.identityRepo.ListByUser(ctx, userUUID)
+	if err != nil {
+		return nil, time.Time{}, errors.Wrap(err, "failed to list user identities")
+	}
+
+	orders, err := s.listOrders(ctx, userUUID)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	payments, err := s.listPayments(ctx, userUUID)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	generatedAt := time.Now().UTC()
+
+	data, err := json.MarshalIndent(newArchive(user, identities, orders, payments, generatedAt), "", "  ")
+	if err != nil {
+		return nil, time.Time{}, errors.Wrap(err, "failed to encode user data archive")
+	}
+
+	s.auditService.Record(ctx, &model.AuditEvent{
+		EventType: model.AuditEventDataExported,
+		UserUUID:  user.UserUUID,
+		Login:     user.Login,
+	})
+
+	return data, generatedAt, nil
+}
+
+func (s *service) Erase(ctx context.Context, userUUID string) (time.Time, error) {
+	// Проверяем, что пользователь существует, до удаления данных в остальных хранилищах
+	_, err := s.userRepo.GetByID(ctx, userUUID)
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "failed to get user")
+	}
+
+	err = s.sessionRepo.DeleteUserSessions(ctx, userUUID)
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "failed to delete user sessions")
+	}
+
+	s.whoamiCache.InvalidateUser(ctx, userUUID)
+
+	err = s.apiKeyRepo.RevokeAllByUser(ctx, userUUID)
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "failed to revoke user api keys")
+	}
+
+	err = s.identityRepo.DeleteByUser(ctx, userUUID)
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "failed to delete user identities")
+	}
+
+	erasedAt := time.Now().UTC()
+
+	err = s.userRepo.Anonymize(ctx, userUUID, erasedAt)
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "failed to anonymize user")
+	}
+
+	err = s.auditEventRepo.AnonymizeUser(ctx, userUUID)
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "failed to anonymize user audit events")
+	}
+
+	// Без события заказы и платежи пользователя останутся неанонимизированными, поэтому ошибку возвращаем клиенту
+	err = s.userErasedProducer.SendUserErased(ctx, &model.UserErasedEvent{
+		EventUUID: uuid.NewString(),
+		UserUUID:  userUUID,
+		ErasedAt:  erasedAt,
+	})
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	s.auditService.Record(ctx, &model.AuditEvent{
+		EventType: model.AuditEventUserErased,
+		UserUUID:  userUUID,
+	})
+
+	return erasedAt, nil
+}
+
+func (s *service) listOrders(ctx context.Context, userUUID string) ([]model.UserOrder, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.userDataCfg.RequestTimeout())
+	defer cancel()
+
+	orders, err := s.orderClient.ListUserOrders(ctx, userUUID)
+	if err != nil {
+		return nil, errors.Wrapf(model.ErrUserDataUnavailable, "orders: %v", err)
+	}
+
+	return orders, nil
+}
+
+func (s *service) listPayments(ctx context.Context, userUUID string) ([]model.UserPayment, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.userDataCfg.RequestTimeout())
+	defer cancel()
+
+	payments, err := s.paymentClient.ListUserTransactions(ctx, userUUID)
+	if err != nil {
+		return nil, errors.Wrapf(model.ErrUserDataUnavailable, "payments: %v", err)
+	}
+
+	return payments, nil
+}
@@ -0,0 +1,206 @@
+package whoami_cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/linemk/rocket-shop/iam/internal/model"
+)
+
+type entry struct {
+	sessionUUID string
+	user        *model.User
+	expiresAt   time.Time
+}
+
+// lru ограниченный по размеру кеш session -> user с вытеснением давно неиспользуемых записей
+type lru struct {
+	mu         sync.Mutex
+	maxEntries int
+	ttl        time.Duration
+	order      *list.List
+	sessions   map[string]*list.Element
+	// users индекс сессий пользователя для инвалидации по user UUID
+	users map[string]map[string]struct{}
+
+	// generation номер последней инвалидации; загрузка запоминает его перед чтением из Redis
+	generation uint64
+	// invalidatedSessions и invalidatedUsers хранят номер последней инвалидации ключа,
+	// пока идут загрузки, начатые до нее
+	invalidatedSessions map[string]uint64
+	invalidatedUsers    map[string]uint64
+	// loads число незавершенных загрузок по номеру поколения, с которого они начались
+	loads map[uint64]int
+}
+
+func newLRU(maxEntries int, ttl time.Duration) *lru {
+	return &lru{
+		maxEntries:          maxEntries,
+		ttl:                 ttl,
+		order:               list.New(),
+		sessions:            make(map[string]*list.Element),
+		users:               make(map[string]map[string]struct{}),
+		invalidatedSessions: make(map[string]uint64),
+		invalidatedUsers:    make(map[string]uint64),
+		loads:               make(map[uint64]int),
+	}
+}
+
+// beginLoad отмечает начало загрузки и возвращает поколение, которое нужно передать в set и endLoad
+func (c *lru) beginLoad() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.loads[c.generation]++
+
+	return c.generation
+}
+
+// endLoad завершает загрузку и забывает инвалидации, которые уже не могут повлиять на незавершенные загрузки
+func (c *lru) endLoad(generation uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.loads[generation]--
+	if c.loads[generation] <= 0 {
+		delete(c.loads, generation)
+	}
+
+	oldest := c.generation
+	for started := range c.loads {
+		if started < oldest {
+			oldest = started
+		}
+	}
+
+	for sessionUUID, invalidated := range c.invalidatedSessions {
+		if invalidated <= oldest {
+			delete(c.invalidatedSessions, sessionUUID)
+		}
+	}
+	for userUUID, invalidated := range c.invalidatedUsers {
+		if invalidated <= oldest {
+			delete(c.invalidatedUsers, userUUID)
+		}
+	}
+}
+
+func (c *lru) get(sessionUUID string) (*model.User, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.sessions[sessionUUID]
+	if !ok {
+		return nil, false
+	}
+
+	e := elem.Value.(*entry)
+	if time.Now().After(e.expiresAt) {
+		c.removeElement(elem)
+		return nil, false
+	}
+
+	c.order.MoveToFront(elem)
+
+	return e.user, true
+}
+
+// set сохраняет пользователя не дольше срока действия сессии и возвращает число вытесненных записей.
+// Запись не сохраняется, если сессию или пользователя инвалидировали после начала загрузки generation:
+// иначе отозванная во время чтения из Redis сессия попала бы в кеш и проходила бы проверку до истечения TTL
+func (c *lru) set(sessionUUID string, user *model.User, sessionExpiresAt time.Time, generation uint64) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.invalidatedSessions[sessionUUID] > generation || c.invalidatedUsers[user.UserUUID] > generation {
+		return 0
+	}
+
+	if elem, ok := c.sessions[sessionUUID]; ok {
+		c.removeElement(elem)
+	}
+
+	expiresAt := time.Now().Add(c.ttl)
+	if sessionExpiresAt.Before(expiresAt) {
+		expiresAt = sessionExpiresAt
+	}
+
+	elem := c.order.PushFront(&entry{
+		sessionUUID: sessionUUID,
+		user:        user,
+		expiresAt:   expiresAt,
+	})
+	c.sessions[sessionUUID] = elem
+
+	userSessions, ok := c.users[user.UserUUID]
+	if !ok {
+		userSessions = make(map[string]struct{})
+		c.users[user.UserUUID] = userSessions
+	}
+	userSessions[sessionUUID] = struct{}{}
+
+	evicted := 0
+	for c.order.Len() > c.maxEntries {
+		c.removeElement(c.order.Back())
+		evicted++
+	}
+
+	return evicted
+}
+
+// removeSession удаляет запись сессии и возвращает true, если она была в кеше
+func (c *lru) removeSession(sessionUUID string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	if len(c.loads) > 0 {
+		c.invalidatedSessions[sessionUUID] = c.generation
+	}
+
+	elem, ok := c.sessions[sessionUUID]
+	if !ok {
+		return false
+	}
+
+	c.removeElement(elem)
+
+	return true
+}
+
+// removeUser удаляет все сессии пользователя и возвращает число удаленных записей
+func (c *lru) removeUser(userUUID string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	if len(c.loads) > 0 {
+		c.invalidatedUsers[userUUID] = c.generation
+	}
+
+	userSessions := c.users[userUUID]
+	removed := 0
+	for sessionUUID := range userSessions {
+		if elem, ok := c.sessions[sessionUUID]; ok {
+			c.removeElement(elem)
+			removed++
+		}
+	}
+
+	return removed
+}
+
+func (c *lru) removeElement(elem *list.Element) {
+	e := elem.Value.(*entry)
+
+	c.order.Remove(elem)
+	delete(c.sessions, e.sessionUUID)
+
+	if userSessions, ok := c.users[e.user.UserUUID]; ok {
+		delete(userSessions, e.sessionUUID)
+		if len(userSessions) == 0 {
+			delete(c.users, e.user.UserUUID)
+		}
+	}
+}
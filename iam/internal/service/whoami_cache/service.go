@@ -0,0 +1,182 @@
+package whoami_cache
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/linemk/rocket-shop/iam/internal/config"
+	"github.com/linemk/rocket-shop/iam/internal/metrics"
+	"github.com/linemk/rocket-shop/iam/internal/model"
+	"github.com/linemk/rocket-shop/iam/internal/service/auth"
+	"github.com/linemk/rocket-shop/platform/pkg/cache"
+)
+
+const (
+	resultHit  = "hit"
+	resultMiss = "miss"
+
+	kindSession = "session"
+	kindUser    = "user"
+)
+
+// Invalidator сбрасывает закешированные ответы whoami во всех инстансах IAM
+type Invalidator interface {
+	// InvalidateSession вызывается при отзыве сессии
+	InvalidateSession(ctx context.Context, sessionUUID string)
+	// InvalidateUser вызывается при изменении или удалении пользователя
+	InvalidateUser(ctx context.Context, userUUID string)
+}
+
+type Service interface {
+	Invalidator
+	// Whoami возвращает пользователя сессии из локального кеша или из auth сервиса
+	Whoami(ctx context.Context, sessionUUID string) (*model.User, error)
+	// RunInvalidationListener применяет инвалидации других инстансов. Блокирует до отмены контекста
+	RunInvalidationListener(ctx context.Context) error
+}
+
+type Logger interface {
+	Info(ctx context.Context, msg string, fields ...zap.Field)
+	Error(ctx context.Context, msg string, fields ...zap.Field)
+}
+
+// invalidationMessage сообщение инвалидации в Redis Pub/Sub канале
+type invalidationMessage struct {
+	Kind string `json:"kind"`
+	ID   string `json:"id"`
+}
+
+type service struct {
+	authService auth.Service
+	pubSub      cache.PubSubOperator
+	cache       *lru
+	metrics     *metrics.WhoamiCacheMetrics
+	cfg         config.WhoamiCacheConfig
+	logger      Logger
+}
+
+func NewService(
+	authService auth.Service,
+	pubSub cache.PubSubOperator,
+	whoamiMetrics *metrics.WhoamiCacheMetrics,
+	cfg config.WhoamiCacheConfig,
+	logger Logger,
+) Service {
+	return &service{
+		authService: authService,
+		pubSub:      pubSub,
+		cache:       newLRU(cfg.MaxEntries(), cfg.TTL()),
+		metrics:     whoamiMetrics,
+		cfg:         cfg,
+		logger:      logger,
+	}
+}
+
+func (s *service) Whoami(ctx context.Context, sessionUUID string) (*model.User, error) {
+	if !s.cfg.Enabled() {
+		return s.authService.Whoami(ctx, sessionUUID)
+	}
+
+	if user, ok := s.cache.get(sessionUUID); ok {
+		s.metrics.Requests.WithLabelValues(resultHit).Inc()
+		return user, nil
+	}
+
+	s.metrics.Requests.WithLabelValues(resultMiss).Inc()
+
+	generation := s.cache.beginLoad()
+	defer s.cache.endLoad(generation)
+
+	// Ошибки не кешируем: отсутствующая или истекшая сессия всегда проверяется в Redis
+	user, session, err := s.authService.WhoamiSession(ctx, sessionUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	evicted := s.cache.set(sessionUUID, user, session.ExpiresAt, generation)
+	if evicted > 0 {
+		s.metrics.Evictions.WithLabelValues().Add(float64(evicted))
+	}
+
+	return user, nil
+}
+
+func (s *service) InvalidateSession(ctx context.Context, sessionUUID string) {
+	s.invalidate(ctx, invalidationMessage{Kind: kindSession, ID: sessionUUID})
+}
+
+func (s *service) InvalidateUser(ctx context.Context, userUUID string) {
+	s.invalidate(ctx, invalidationMessage{Kind: kindUser, ID: userUUID})
+}
+
+// invalidate сбрасывает запись локально и оповещает остальные инстансы.
+// Ошибка публикации только логируется: запись все равно истечет по TTL
+func (s *service) invalidate(ctx context.Context, msg invalidationMessage) {
+	if !s.cfg.Enabled() {
+		return
+	}
+
+	s.apply(msg)
+
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to marshal whoami cache invalidation", zap.Error(err))
+		return
+	}
+
+	err = s.pubSub.Publish(ctx, s.cfg.InvalidationChannel(), payload)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to publish whoami cache invalidation",
+			zap.String("kind", msg.Kind),
+			zap.String("id", msg.ID),
+			zap.Error(err),
+		)
+	}
+}
+
+func (s *service) RunInvalidationListener(ctx context.Context) error {
+	if !s.cfg.Enabled() {
+		return nil
+	}
+
+	s.logger.Info(ctx, "Starting whoami cache invalidation listener",
+		zap.String("channel", s.cfg.InvalidationChannel()),
+	)
+
+	err := s.pubSub.Subscribe(ctx, s.cfg.InvalidationChannel(), func(payload []byte) {
+		var msg invalidationMessage
+		if err := json.Unmarshal(payload, &msg); err != nil {
+			s.logger.Error(ctx, "Failed to unmarshal whoami cache invalidation", zap.Error(err))
+			return
+		}
+
+		s.apply(msg)
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to subscribe to whoami cache invalidation channel")
+	}
+
+	return nil
+}
+
+func (s *service) apply(msg invalidationMessage) {
+	removed := 0
+
+	switch msg.Kind {
+	case kindSession:
+		if s.cache.removeSession(msg.ID) {
+			removed = 1
+		}
+	case kindUser:
+		removed = s.cache.removeUser(msg.ID)
+	default:
+		return
+	}
+
+	if removed > 0 {
+		s.metrics.Invalidations.WithLabelValues(msg.Kind).Add(float64(removed))
+	}
+}
@@ -0,0 +1,7 @@
+-- +goose Up
+-- добавляем признак подтвержденного email
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email_verified BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- +goose Down
+-- удаляем признак подтвержденного email
+ALTER TABLE users DROP COLUMN IF EXISTS email_verified;
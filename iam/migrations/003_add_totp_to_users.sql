@@ -0,0 +1,13 @@
+-- +goose Up
+-- добавляем поля для двухфакторной аутентификации (TOTP)
+-- totp_secret хранится в зашифрованном виде (AES-GCM)
+-- recovery_codes хранит только хеши кодов восстановления
+ALTER TABLE users ADD COLUMN IF NOT EXISTS totp_secret BYTEA;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS totp_enabled BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS recovery_codes JSONB NOT NULL DEFAULT '[]'::jsonb;
+
+-- +goose Down
+-- удаляем поля двухфакторной аутентификации
+ALTER TABLE users DROP COLUMN IF EXISTS recovery_codes;
+ALTER TABLE users DROP COLUMN IF EXISTS totp_enabled;
+ALTER TABLE users DROP COLUMN IF EXISTS totp_secret;
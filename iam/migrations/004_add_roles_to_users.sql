@@ -0,0 +1,7 @@
+-- +goose Up
+-- добавляем роли пользователя, они передаются в claims access токенов
+ALTER TABLE users ADD COLUMN IF NOT EXISTS roles JSONB NOT NULL DEFAULT '["user"]'::jsonb;
+
+-- +goose Down
+-- удаляем роли пользователя
+ALTER TABLE users DROP COLUMN IF EXISTS roles;
@@ -0,0 +1,25 @@
+-- +goose Up
+-- создаем таблицу API ключей для межсервисных клиентов
+-- сам ключ не хранится, только его sha256 хеш
+CREATE TABLE IF NOT EXISTS api_keys
+(
+    api_key_uuid UUID PRIMARY KEY,
+    user_uuid    UUID         NOT NULL REFERENCES users (user_uuid) ON DELETE CASCADE,
+    name         VARCHAR(255) NOT NULL,
+    key_prefix   VARCHAR(32)  NOT NULL,
+    key_hash     VARCHAR(64)  NOT NULL UNIQUE,
+    scopes       JSONB        NOT NULL DEFAULT '[]'::jsonb,
+    expires_at   TIMESTAMP,
+    revoked_at   TIMESTAMP,
+    created_at   TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- создаем индекс для выборки ключей пользователя
+CREATE INDEX IF NOT EXISTS idx_api_keys_user_uuid ON api_keys(user_uuid);
+
+-- +goose Down
+-- удаляем индекс
+DROP INDEX IF EXISTS idx_api_keys_user_uuid;
+
+-- удаляем таблицу API ключей
+DROP TABLE IF EXISTS api_keys;
@@ -0,0 +1,26 @@
+-- +goose Up
+-- создаем таблицу событий аудита аутентификации
+-- заполняется из Kafka топика с событиями AuthAuditEvent
+CREATE TABLE IF NOT EXISTS auth_audit_events
+(
+    event_uuid   UUID PRIMARY KEY,
+    event_type   VARCHAR(32)  NOT NULL,
+    user_uuid    UUID,
+    login        VARCHAR(255) NOT NULL DEFAULT '',
+    session_uuid VARCHAR(64)  NOT NULL DEFAULT '',
+    ip_address   VARCHAR(64)  NOT NULL DEFAULT '',
+    user_agent   TEXT         NOT NULL DEFAULT '',
+    reason       TEXT         NOT NULL DEFAULT '',
+    resource     TEXT         NOT NULL DEFAULT '',
+    occurred_at  TIMESTAMP    NOT NULL
+);
+
+-- создаем индекс для выборки истории пользователя
+CREATE INDEX IF NOT EXISTS idx_auth_audit_events_user_occurred ON auth_audit_events(user_uuid, occurred_at DESC);
+
+-- +goose Down
+-- удаляем индекс
+DROP INDEX IF EXISTS idx_auth_audit_events_user_occurred;
+
+-- удаляем таблицу событий аудита
+DROP TABLE IF EXISTS auth_audit_events;
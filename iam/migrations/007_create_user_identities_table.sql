@@ -0,0 +1,21 @@
+-- +goose Up
+-- создаем таблицу внешних учетных записей (OIDC), привязанных к пользователям
+CREATE TABLE IF NOT EXISTS user_identities
+(
+    provider   VARCHAR(64)  NOT NULL,
+    subject    VARCHAR(255) NOT NULL,
+    user_uuid  UUID         NOT NULL REFERENCES users (user_uuid) ON DELETE CASCADE,
+    email      VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (provider, subject)
+);
+
+-- создаем индекс для выборки учетных записей пользователя
+CREATE INDEX IF NOT EXISTS idx_user_identities_user_uuid ON user_identities(user_uuid);
+
+-- +goose Down
+-- удаляем индекс
+DROP INDEX IF EXISTS idx_user_identities_user_uuid;
+
+-- удаляем таблицу внешних учетных записей
+DROP TABLE IF EXISTS user_identities;
@@ -0,0 +1,7 @@
+-- +goose Up
+-- добавляем время анонимизации учетной записи по запросу на удаление данных
+ALTER TABLE users ADD COLUMN IF NOT EXISTS erased_at TIMESTAMP;
+
+-- +goose Down
+-- удаляем время анонимизации учетной записи
+ALTER TABLE users DROP COLUMN IF EXISTS erased_at;
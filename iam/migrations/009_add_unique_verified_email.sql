@@ -0,0 +1,19 @@
+-- +goose Up
+-- снимаем подтверждение с дублирующихся адресов: подтвержденным остается только самый ранний аккаунт
+UPDATE users u
+SET email_verified = FALSE
+WHERE u.email_verified
+  AND EXISTS (
+    SELECT 1
+    FROM users o
+    WHERE o.email_verified
+      AND lower(o.email) = lower(u.email)
+      AND (o.created_at, o.user_uuid) < (u.created_at, u.user_uuid)
+);
+
+-- подтвержденный email однозначно определяет пользователя: на нем строятся сброс пароля и привязка OIDC
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_verified_email ON users (lower(email)) WHERE email_verified;
+
+-- +goose Down
+-- удаляем уникальный индекс подтвержденных email
+DROP INDEX IF EXISTS idx_users_verified_email;
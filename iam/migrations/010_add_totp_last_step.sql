@@ -0,0 +1,7 @@
+-- +goose Up
+-- храним последний принятый шаг TOTP, чтобы один и тот же код нельзя было использовать повторно
+ALTER TABLE users ADD COLUMN IF NOT EXISTS totp_last_step BIGINT NOT NULL DEFAULT 0;
+
+-- +goose Down
+-- удаляем последний принятый шаг TOTP
+ALTER TABLE users DROP COLUMN IF EXISTS totp_last_step;
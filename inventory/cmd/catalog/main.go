@@ -0,0 +1,361 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"go.uber.org/zap"
+
+	"github.com/linemk/rocket-shop/inventory/internal/app"
+	"github.com/linemk/rocket-shop/inventory/internal/catalog"
+	"github.com/linemk/rocket-shop/inventory/internal/config"
+	"github.com/linemk/rocket-shop/inventory/internal/entyties/models"
+	"github.com/linemk/rocket-shop/inventory/internal/repository"
+	"github.com/linemk/rocket-shop/inventory/internal/usecase"
+	"github.com/linemk/rocket-shop/platform/pkg/closer"
+	"github.com/linemk/rocket-shop/platform/pkg/logger"
+)
+
+const usage = `Импорт и экспорт каталога деталей в CSV и JSON Lines, управление правилами совместимости деталей.
+
+  catalog import -file parts.csv [-format csv|jsonl] [-key uuid|natural] [-batch 100] [-dry-run] [-report catalog-import-report.txt]
+  catalog export -file parts.jsonl [-format csv|jsonl] [-batch 100]
+  catalog rules-import -file rules.jsonl [-dry-run]
+  catalog rules-export -file rules.jsonl
+  catalog rules-delete -uuid <uuid>
+
+Правило совместимости - строка JSON Lines, например:
+  {"type":"incompatible","subject":{"part_uuid":"..."},"object":{"category":"WING"},"reason":"..."}
+  {"type":"requires","subject":{"category":"ENGINE"},"object":{"category":"FUEL"}}
+  {"type":"required_category","category":"ENGINE","min_count":1}
+Правила с UUID заменяют существующие, правила без UUID создаются.
+
+Импорт записывает детали так же, как сервис: сбрасывает их в кеше деталей и публикует
+события об изменениях, если кеш и события включены в конфигурации.
+`
+
+// repositories подключаются к хранилищам только после разбора флагов команды
+type repositories struct {
+	parts func(ctx context.Context) (catalog.Repository, error)
+	rules func(ctx context.Context) catalog.RuleRepository
+}
+
+// catalogRepository читает детали из хранилища, а записывает через usecase
+type catalogRepository struct {
+	repository.InventoryRepository
+	useCase usecase.InventoryUseCase
+}
+
+func (r catalogRepository) UpsertParts(ctx context.Context, parts []models.Part) error {
+	return r.useCase.UpsertParts(ctx, parts)
+}
+
+// errInvalidRows импорт остановлен из-за ошибок в строках файла
+var errInvalidRows = errors.New("catalog has invalid rows, see report")
+
+// Импорт и экспорт каталога деталей.
+// Пример: go run ./cmd/catalog import -file parts.csv -key natural -dry-run
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	var command func(ctx context.Context, repos repositories, args []string) error
+	switch os.Args[1] {
+	case "import":
+		command = runImport
+	case "export":
+		command = runExport
+	case "rules-import":
+		command = runRulesImport
+	case "rules-export":
+		command = runRulesExport
+	case "rules-delete":
+		command = runRulesDelete
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	ctx := context.Background()
+
+	if err := config.Load(); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to load config: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := logger.Init(ctx, config.AppConfig().Logger.Level(), false, false, "", "inventory-catalog"); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to init logger: %v\n", err)
+		os.Exit(1)
+	}
+	defer func() {
+		_ = closer.CloseAll(ctx) //nolint:gosec // best-effort shutdown
+		_ = logger.Close(ctx)    //nolint:gosec // best-effort shutdown
+		_ = logger.Sync()        //nolint:gosec // best-effort shutdown
+	}()
+
+	di := app.NewDiContainer()
+	repos := repositories{
+		parts: func(ctx context.Context) (catalog.Repository, error) {
+			if err := di.InitCache(ctx); err != nil {
+				return nil, err
+			}
+
+			return catalogRepository{
+				InventoryRepository: di.InventoryRepository(ctx),
+				useCase:             di.InventoryUseCase(ctx),
+			}, nil
+		},
+		rules: func(ctx context.Context) catalog.RuleRepository {
+			return di.CompatibilityRepository(ctx)
+		},
+	}
+
+	if err := command(ctx, repos, os.Args[2:]); err != nil {
+		logger.Error(ctx, "Catalog command failed", zap.String("command", os.Args[1]), zap.Error(err))
+		os.Exit(1) //nolint:gocritic // ресурсы освобождаются процессом
+	}
+}
+
+func runImport(ctx context.Context, repos repositories, args []string) error {
+	flags := flag.NewFlagSet("import", flag.ExitOnError)
+	file := flags.String("file", "", "файл каталога")
+	formatName := flags.String("format", "", "формат файла: csv или jsonl (по умолчанию по расширению)")
+	keyName := flags.String("key", string(catalog.KeyUUID), "сопоставление с деталями: uuid или natural (название и производитель)")
+	batchSize := flags.Int("batch", catalog.DefaultBatchSize, "размер пачки чтения и записи")
+	dryRun := flags.Bool("dry-run", false, "только показать изменения, ничего не записывать")
+	out := flags.String("report", "catalog-import-report.txt", "файл отчета об изменениях")
+	_ = flags.Parse(args) //nolint:gosec // при ошибке flag.ExitOnError завершает процесс
+
+	if *file == "" {
+		return errors.New("-file is required")
+	}
+
+	if *batchSize <= 0 {
+		return fmt.Errorf("-batch must be positive, got %d", *batchSize)
+	}
+
+	format, err := catalog.ParseFormat(*formatName, *file)
+	if err != nil {
+		return err
+	}
+
+	keyMode, err := catalog.ParseKeyMode(*keyName)
+	if err != nil {
+		return err
+	}
+
+	input, err := os.Open(*file) //nolint:gosec // путь задает оператор
+	if err != nil {
+		return fmt.Errorf("failed to open catalog: %w", err)
+	}
+	defer func() {
+		_ = input.Close() //nolint:gosec // файл только читается
+	}()
+
+	rows, rowErrors, err := catalog.Decode(input, format)
+	if err != nil {
+		return err
+	}
+
+	partRepository, err := repos.parts(ctx)
+	if err != nil {
+		return err
+	}
+
+	report, importErr := catalog.NewImporter(partRepository, keyMode, *batchSize, *dryRun).Import(ctx, rows, rowErrors)
+
+	// Отчет пишется и при сбое хранилища: в нем видно, какие пачки успели записаться
+	if err := writeReport(*out, report); err != nil {
+		return err
+	}
+
+	logger.Info(ctx, "Catalog import finished",
+		zap.Int("created", report.Count(catalog.ActionCreate)),
+		zap.Int("updated", report.Count(catalog.ActionUpdate)),
+		zap.Int("unchanged", report.Count(catalog.ActionUnchanged)),
+		zap.Int("errors", len(report.Errors)),
+		zap.Bool("written", report.Written),
+		zap.String("report", *out),
+	)
+
+	if importErr != nil {
+		return importErr
+	}
+
+	if len(report.Errors) > 0 {
+		return errInvalidRows
+	}
+
+	return nil
+}
+
+// writeReport пишет отчет в файл: stdout занят логами
+func writeReport(path string, report catalog.Report) error {
+	file, err := os.Create(path) //nolint:gosec // путь задает оператор
+	if err != nil {
+		return fmt.Errorf("failed to create report file: %w", err)
+	}
+
+	if err := catalog.WriteReport(file, report); err != nil {
+		_ = file.Close() //nolint:gosec // исходная ошибка важнее
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+
+	return nil
+}
+
+func runExport(ctx context.Context, repos repositories, args []string) error {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	file := flags.String("file", "", "файл каталога")
+	formatName := flags.String("format", "", "формат файла: csv или jsonl (по умолчанию по расширению)")
+	batchSize := flags.Int("batch", catalog.DefaultBatchSize, "размер пачки чтения")
+	_ = flags.Parse(args) //nolint:gosec // при ошибке flag.ExitOnError завершает процесс
+
+	if *file == "" {
+		return errors.New("-file is required")
+	}
+
+	if *batchSize <= 0 {
+		return fmt.Errorf("-batch must be positive, got %d", *batchSize)
+	}
+
+	format, err := catalog.ParseFormat(*formatName, *file)
+	if err != nil {
+		return err
+	}
+
+	partRepository, err := repos.parts(ctx)
+	if err != nil {
+		return err
+	}
+
+	output, err := os.Create(*file) //nolint:gosec // путь задает оператор
+	if err != nil {
+		return fmt.Errorf("failed to create catalog: %w", err)
+	}
+
+	encoder, err := catalog.NewEncoder(output, format)
+	if err != nil {
+		_ = output.Close() //nolint:gosec // исходная ошибка важнее
+		return err
+	}
+
+	total, err := catalog.Export(ctx, partRepository, encoder, *batchSize)
+	if err != nil {
+		_ = output.Close() //nolint:gosec // исходная ошибка важнее
+		return err
+	}
+
+	if err := output.Close(); err != nil {
+		return fmt.Errorf("failed to write catalog: %w", err)
+	}
+
+	logger.Info(ctx, "Catalog export finished", zap.Int("parts", total), zap.String("file", *file))
+
+	return nil
+}
+
+func runRulesImport(ctx context.Context, repos repositories, args []string) error {
+	flags := flag.NewFlagSet("rules-import", flag.ExitOnError)
+	file := flags.String("file", "", "файл правил в JSON Lines")
+	dryRun := flags.Bool("dry-run", false, "только проверить правила, ничего не записывать")
+	_ = flags.Parse(args) //nolint:gosec // при ошибке flag.ExitOnError завершает процесс
+
+	if *file == "" {
+		return errors.New("-file is required")
+	}
+
+	input, err := os.Open(*file) //nolint:gosec // путь задает оператор
+	if err != nil {
+		return fmt.Errorf("failed to open rules: %w", err)
+	}
+	defer func() {
+		_ = input.Close() //nolint:gosec // файл только читается
+	}()
+
+	rows, rowErrors, err := catalog.DecodeRules(input)
+	if err != nil {
+		return err
+	}
+
+	// Правила проверяют каждый заказ, поэтому файл с ошибками не применяется даже частично
+	if len(rowErrors) > 0 {
+		for _, rowError := range rowErrors {
+			logger.Error(ctx, "Invalid compatibility rule", zap.Int("line", rowError.Line), zap.Error(rowError.Err))
+		}
+		return fmt.Errorf("rules file has %d invalid rules", len(rowErrors))
+	}
+
+	report, err := catalog.ImportRules(ctx, repos.rules(ctx), rows, *dryRun)
+	if err != nil {
+		return err
+	}
+
+	logger.Info(ctx, "Compatibility rules import finished",
+		zap.Int("created", report.Created),
+		zap.Int("replaced", report.Replaced),
+		zap.Bool("written", report.Written),
+	)
+
+	return nil
+}
+
+func runRulesExport(ctx context.Context, repos repositories, args []string) error {
+	flags := flag.NewFlagSet("rules-export", flag.ExitOnError)
+	file := flags.String("file", "", "файл правил в JSON Lines")
+	_ = flags.Parse(args) //nolint:gosec // при ошибке flag.ExitOnError завершает процесс
+
+	if *file == "" {
+		return errors.New("-file is required")
+	}
+
+	rules, err := repos.rules(ctx).ListRules(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list rules: %w", err)
+	}
+
+	output, err := os.Create(*file) //nolint:gosec // путь задает оператор
+	if err != nil {
+		return fmt.Errorf("failed to create rules file: %w", err)
+	}
+
+	if err := catalog.EncodeRules(output, rules); err != nil {
+		_ = output.Close() //nolint:gosec // исходная ошибка важнее
+		return fmt.Errorf("failed to write rules: %w", err)
+	}
+
+	if err := output.Close(); err != nil {
+		return fmt.Errorf("failed to write rules: %w", err)
+	}
+
+	logger.Info(ctx, "Compatibility rules export finished", zap.Int("rules", len(rules)), zap.String("file", *file))
+
+	return nil
+}
+
+func runRulesDelete(ctx context.Context, repos repositories, args []string) error {
+	flags := flag.NewFlagSet("rules-delete", flag.ExitOnError)
+	ruleUUID := flags.String("uuid", "", "UUID удаляемого правила")
+	_ = flags.Parse(args) //nolint:gosec // при ошибке flag.ExitOnError завершает процесс
+
+	if *ruleUUID == "" {
+		return errors.New("-uuid is required")
+	}
+
+	if err := repos.rules(ctx).DeleteRule(ctx, *ruleUUID); err != nil {
+		return fmt.Errorf("failed to delete rule %s: %w", *ruleUUID, err)
+	}
+
+	logger.Info(ctx, "Compatibility rule deleted", zap.String("rule_uuid", *ruleUUID))
+
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/brianvoe/gofakeit/v7"
 	"github.com/google/uuid"
 	"github.com/joho/godotenv"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.uber.org/zap"
@@ -102,10 +103,41 @@ func run(ctx context.Context) error {
 		logger.Info(ctx, "✅ Создана деталь", zap.Int("index", i+1), zap.Int("total", len(parts)), zap.String("name", part.Name), zap.String("uuid", part.UUID))
 	}
 
+	// Правила совместимости имеют постоянные UUID, поэтому повторный запуск их не дублирует
+	rulesCollection := client.Database(mongoDatabase).Collection("compatibility_rules")
+	for _, rule := range defaultCompatibilityRules() {
+		_, err := rulesCollection.ReplaceOne(opCtx, bson.M{"uuid": rule.UUID}, rule, options.Replace().SetUpsert(true))
+		if err != nil {
+			logger.Error(ctx, "⚠️  Ошибка при сохранении правила совместимости", zap.String("uuid", rule.UUID), zap.Error(err))
+			continue
+		}
+		logger.Info(ctx, "✅ Сохранено правило совместимости", zap.String("uuid", rule.UUID), zap.String("type", string(rule.Type)))
+	}
+
 	logger.Info(ctx, "🎉 База данных успешно заполнена!")
 	return nil
 }
 
+// defaultCompatibilityRules минимальный состав ракеты: двигатель и топливный бак
+func defaultCompatibilityRules() []models.CompatibilityRule {
+	return []models.CompatibilityRule{
+		{
+			UUID:     "6f1c2a4e-0b7d-4c52-9a51-3d8e1f0a7b01",
+			Type:     models.CompatibilityRuleRequiredCategory,
+			Category: inventory_v1.Category_CATEGORY_ENGINE,
+			MinCount: 1,
+			Reason:   "rocket needs at least one engine",
+		},
+		{
+			UUID:     "6f1c2a4e-0b7d-4c52-9a51-3d8e1f0a7b02",
+			Type:     models.CompatibilityRuleRequiredCategory,
+			Category: inventory_v1.Category_CATEGORY_FUEL,
+			MinCount: 1,
+			Reason:   "rocket needs at least one fuel tank",
+		},
+	}
+}
+
 func generateParts(count int) []models.Part {
 	parts := make([]models.Part, 0, count)
 	now := time.Now()
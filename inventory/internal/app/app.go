@@ -60,6 +60,7 @@ func (a *App) initDeps(ctx context.Context) error {
 		a.initLogger,
 		a.initCloser,
 		a.initDI,
+		a.initCache,
 		a.initListener,
 		a.initGRPCServer,
 	}
@@ -99,6 +100,10 @@ func (a *App) initDI(_ context.Context) error {
 	return nil
 }
 
+func (a *App) initCache(ctx context.Context) error {
+	return a.diContainer.InitCache(ctx)
+}
+
 func (a *App) initListener(_ context.Context) error {
 	listener, err := net.Listen("tcp", config.AppConfig().InventoryGRPC.Address())
 	if err != nil {
@@ -115,10 +120,20 @@ func (a *App) initListener(_ context.Context) error {
 }
 
 func (a *App) initGRPCServer(ctx context.Context) error {
+	unaryAuth := grpc.UnaryServerInterceptor(grpcmiddleware.UnaryAuthInterceptor)
+	streamAuth := grpc.StreamServerInterceptor(grpcmiddleware.StreamAuthInterceptor)
+	if config.AppConfig().IAMGRPC.SessionValidationEnabled() {
+		unaryAuth = grpcmiddleware.UnarySessionInterceptor(a.diContainer.SessionResolver(ctx))
+		streamAuth = grpcmiddleware.StreamSessionInterceptor(a.diContainer.SessionResolver(ctx))
+	}
+
+	// Метрики стоят первыми, чтобы учитывать и запросы, отклоненные проверкой сессии
+	grpcMetrics := prommetrics.NewGRPCMetrics(a.diContainer.PrometheusMetrics())
+
 	a.grpcServer = grpc.NewServer(
 		grpc.Creds(insecure.NewCredentials()),
-		grpc.UnaryInterceptor(grpcmiddleware.UnaryAuthInterceptor),
-		grpc.StreamInterceptor(grpcmiddleware.StreamAuthInterceptor),
+		grpc.ChainUnaryInterceptor(grpcMetrics.UnaryServerInterceptor(), unaryAuth),
+		grpc.ChainStreamInterceptor(grpcMetrics.StreamServerInterceptor(), streamAuth),
 	)
 
 	closer.AddNamed("gRPC server", func(ctx context.Context) error {
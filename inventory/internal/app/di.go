@@ -4,16 +4,26 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/IBM/sarama"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
 
 	"github.com/linemk/rocket-shop/inventory/internal/config"
 	v1 "github.com/linemk/rocket-shop/inventory/internal/delivery/v1"
+	inventorymetrics "github.com/linemk/rocket-shop/inventory/internal/metrics"
 	"github.com/linemk/rocket-shop/inventory/internal/repository"
+	"github.com/linemk/rocket-shop/inventory/internal/repository/cached"
+	compatibilityRepository "github.com/linemk/rocket-shop/inventory/internal/repository/compatibility"
 	inventoryRepository "github.com/linemk/rocket-shop/inventory/internal/repository/inventory"
+	"github.com/linemk/rocket-shop/inventory/internal/service"
+	"github.com/linemk/rocket-shop/inventory/internal/service/producer/part_producer"
 	"github.com/linemk/rocket-shop/inventory/internal/usecase"
+	"github.com/linemk/rocket-shop/platform/pkg/cache"
+	rediscache "github.com/linemk/rocket-shop/platform/pkg/cache/redis"
 	"github.com/linemk/rocket-shop/platform/pkg/closer"
+	"github.com/linemk/rocket-shop/platform/pkg/kafka/producer"
+	"github.com/linemk/rocket-shop/platform/pkg/logger"
 	prommetrics "github.com/linemk/rocket-shop/platform/pkg/prometheus"
 	iamclient "github.com/linemk/rocket-shop/shared/pkg/iamclient"
 	inventory_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/inventory/v1"
@@ -24,18 +34,66 @@ type diContainer struct {
 
 	inventoryUseCase usecase.InventoryUseCase
 
-	inventoryRepository repository.InventoryRepository
+	inventoryRepository     repository.InventoryRepository
+	compatibilityRepository repository.CompatibilityRepository
+
+	partProducerService service.PartProducerService
 
 	mongoDBClient     *mongo.Client
 	mongoDBHandle     *mongo.Database
 	iamClient         *iamclient.Client
+	sessionResolver   *iamclient.SessionResolver
+	cacheClient       cache.Client
 	prometheusMetrics *prommetrics.Metrics
+	partCacheMetrics  *inventorymetrics.PartCacheMetrics
 }
 
 func NewDiContainer() *diContainer {
 	return &diContainer{}
 }
 
+func (d *diContainer) SetCacheClient(cacheClient cache.Client) {
+	d.cacheClient = cacheClient
+}
+
+// InitCache подключается к Redis, если кеш деталей включен. Вызывается до первого обращения к InventoryRepository
+func (d *diContainer) InitCache(ctx context.Context) error {
+	// Redis нужен только кешу деталей
+	if !config.AppConfig().PartCache.Enabled() {
+		logger.Info(ctx, "Part cache disabled")
+		return nil
+	}
+
+	redisConfig := config.AppConfig().Redis
+	cacheClient, err := rediscache.NewClient(cache.Config{
+		Addr:         redisConfig.Addr(),
+		Password:     redisConfig.Password(),
+		DB:           redisConfig.DB(),
+		DialTimeout:  redisConfig.DialTimeout(),
+		ReadTimeout:  redisConfig.ReadTimeout(),
+		WriteTimeout: redisConfig.WriteTimeout(),
+		PoolSize:     redisConfig.PoolSize(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create cache client: %w", err)
+	}
+
+	if err := cacheClient.Ping(ctx); err != nil {
+		return fmt.Errorf("failed to ping Redis: %w", err)
+	}
+
+	logger.Info(ctx, "Successfully connected to Redis")
+
+	// Сохраняем клиент в DI контейнер для кеша деталей
+	d.SetCacheClient(cacheClient)
+
+	closer.AddNamed("Redis cache", func(ctx context.Context) error {
+		return cacheClient.Close()
+	})
+
+	return nil
+}
+
 func (d *diContainer) InventoryV1API(ctx context.Context) inventory_v1.InventoryServiceServer {
 	if d.inventoryV1API == nil {
 		d.inventoryV1API = v1.NewAPI(d.InventoryUseCase(ctx))
@@ -46,20 +104,68 @@ func (d *diContainer) InventoryV1API(ctx context.Context) inventory_v1.Inventory
 
 func (d *diContainer) InventoryUseCase(ctx context.Context) usecase.InventoryUseCase {
 	if d.inventoryUseCase == nil {
-		d.inventoryUseCase = usecase.NewUseCase(d.InventoryRepository(ctx))
+		// Без публикации событий Kafka не нужна: usecase получает nil producer
+		var partProducer service.PartProducerService
+		if config.AppConfig().Kafka.PartEventsEnabled() {
+			partProducer = d.PartProducerService(ctx)
+		}
+
+		d.inventoryUseCase = usecase.NewUseCase(d.InventoryRepository(ctx), d.CompatibilityRepository(ctx), partProducer)
 	}
 
 	return d.inventoryUseCase
 }
 
+func (d *diContainer) PartProducerService(ctx context.Context) service.PartProducerService {
+	if d.partProducerService == nil {
+		saramaConfig := sarama.NewConfig()
+		saramaConfig.Version = sarama.V2_6_0_0
+		saramaConfig.Producer.Return.Successes = true
+		saramaConfig.Producer.RequiredAcks = sarama.WaitForAll
+		saramaConfig.Producer.Retry.Max = 5
+
+		syncProducer, err := sarama.NewSyncProducer(config.AppConfig().Kafka.Brokers(), saramaConfig)
+		if err != nil {
+			panic(fmt.Sprintf("failed to create Kafka sync producer: %s\n", err.Error()))
+		}
+
+		closer.AddNamed("Kafka sync producer", func(ctx context.Context) error {
+			return syncProducer.Close()
+		})
+
+		kafkaProducer := producer.NewProducer(
+			syncProducer,
+			config.AppConfig().Kafka.PartEventsTopic(),
+			logger.Logger(),
+		)
+
+		d.partProducerService = part_producer.NewProducer(kafkaProducer, logger.Logger())
+	}
+
+	return d.partProducerService
+}
+
 func (d *diContainer) InventoryRepository(ctx context.Context) repository.InventoryRepository {
 	if d.inventoryRepository == nil {
-		d.inventoryRepository = inventoryRepository.NewMongoRepository(ctx, d.MongoDBHandle(ctx))
+		var repo repository.InventoryRepository = inventoryRepository.NewMongoRepository(ctx, d.MongoDBHandle(ctx))
+		if config.AppConfig().PartCache.Enabled() {
+			repo = cached.NewRepository(repo, d.cacheClient, config.AppConfig().PartCache.TTL(), d.PartCacheMetrics())
+		}
+
+		d.inventoryRepository = repo
 	}
 
 	return d.inventoryRepository
 }
 
+func (d *diContainer) CompatibilityRepository(ctx context.Context) repository.CompatibilityRepository {
+	if d.compatibilityRepository == nil {
+		d.compatibilityRepository = compatibilityRepository.NewMongoRepository(ctx, d.MongoDBHandle(ctx))
+	}
+
+	return d.compatibilityRepository
+}
+
 func (d *diContainer) MongoDBClient(ctx context.Context) *mongo.Client {
 	if d.mongoDBClient == nil {
 		client, err := mongo.Connect(ctx, options.Client().ApplyURI(config.AppConfig().Mongo.URI()))
@@ -104,6 +210,17 @@ func (d *diContainer) IAMClient(ctx context.Context) *iamclient.Client {
 	return d.iamClient
 }
 
+func (d *diContainer) SessionResolver(ctx context.Context) *iamclient.SessionResolver {
+	if d.sessionResolver == nil {
+		d.sessionResolver = d.IAMClient(ctx).SessionResolver(
+			config.AppConfig().IAMGRPC.SessionCacheTTL(),
+			config.AppConfig().IAMGRPC.SessionCacheMaxEntries(),
+		)
+	}
+
+	return d.sessionResolver
+}
+
 func (d *diContainer) PrometheusMetrics() *prommetrics.Metrics {
 	if d.prometheusMetrics == nil {
 		d.prometheusMetrics = prommetrics.New()
@@ -111,3 +228,23 @@ func (d *diContainer) PrometheusMetrics() *prommetrics.Metrics {
 
 	return d.prometheusMetrics
 }
+
+func (d *diContainer) PartCacheMetrics() *inventorymetrics.PartCacheMetrics {
+	if d.partCacheMetrics == nil {
+		pm := d.PrometheusMetrics()
+		d.partCacheMetrics = &inventorymetrics.PartCacheMetrics{
+			Requests: pm.NewCounter(
+				"inventory_part_cache_requests_total",
+				"Total number of part cache lookups",
+				[]string{"result"},
+			),
+			Invalidations: pm.NewCounter(
+				"inventory_part_cache_invalidations_total",
+				"Total number of parts dropped from the cache after update, delete or import",
+				nil,
+			),
+		}
+	}
+
+	return d.partCacheMetrics
+}
@@ -0,0 +1,76 @@
+// Package catalog импортирует и экспортирует каталог деталей в CSV и JSON Lines.
+// Импорт проверяет строки, сопоставляет их с деталями в хранилище по UUID или естественному ключу
+// и записывает изменения пачками; в режиме dry-run только строит отчет о различиях
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/linemk/rocket-shop/inventory/internal/entyties/models"
+)
+
+// DefaultBatchSize размер пачки по умолчанию для импорта и экспорта
+const DefaultBatchSize = 100
+
+// Format формат файла каталога
+type Format string
+
+const (
+	FormatCSV   Format = "csv"
+	FormatJSONL Format = "jsonl"
+)
+
+// ParseFormat разбирает название формата; пустое значение определяет формат по расширению файла
+func ParseFormat(name, path string) (Format, error) {
+	if name == "" {
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".csv":
+			return FormatCSV, nil
+		case ".jsonl", ".ndjson":
+			return FormatJSONL, nil
+		}
+
+		return "", fmt.Errorf("cannot detect format of %q, set it explicitly", path)
+	}
+
+	switch Format(strings.ToLower(name)) {
+	case FormatCSV:
+		return FormatCSV, nil
+	case FormatJSONL:
+		return FormatJSONL, nil
+	}
+
+	return "", fmt.Errorf("unknown format %q", name)
+}
+
+// KeyMode способ сопоставления строк импорта с деталями в хранилище
+type KeyMode string
+
+const (
+	// KeyUUID сопоставляет по UUID; строки без UUID создают новые детали
+	KeyUUID KeyMode = "uuid"
+	// KeyNatural сопоставляет по названию детали и названию производителя
+	KeyNatural KeyMode = "natural"
+)
+
+// ParseKeyMode разбирает способ сопоставления строк
+func ParseKeyMode(name string) (KeyMode, error) {
+	switch KeyMode(strings.ToLower(name)) {
+	case KeyUUID:
+		return KeyUUID, nil
+	case KeyNatural:
+		return KeyNatural, nil
+	}
+
+	return "", fmt.Errorf("unknown key mode %q", name)
+}
+
+// Repository хранилище деталей, с которым работают импорт и экспорт
+type Repository interface {
+	ListParts(ctx context.Context, filter models.PartFilter, opts models.PartListOptions) ([]models.Part, error)
+	// UpsertParts создает или заменяет детали с указанными UUID
+	UpsertParts(ctx context.Context, parts []models.Part) error
+}
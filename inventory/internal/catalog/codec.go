@@ -0,0 +1,366 @@
+package catalog
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/linemk/rocket-shop/inventory/internal/entyties/models"
+)
+
+// Колонки CSV. Порядок колонок во входном файле может быть любым, обязательны только requiredColumns
+const (
+	columnUUID                = "uuid"
+	columnName                = "name"
+	columnDescription         = "description"
+	columnPrice               = "price"
+	columnStockQuantity       = "stock_quantity"
+	columnCategory            = "category"
+	columnLength              = "length"
+	columnWidth               = "width"
+	columnHeight              = "height"
+	columnWeight              = "weight"
+	columnManufacturerName    = "manufacturer_name"
+	columnManufacturerCountry = "manufacturer_country"
+	columnManufacturerWebsite = "manufacturer_website"
+	columnTags                = "tags"
+	columnMetadata            = "metadata"
+)
+
+// tagsSeparator разделяет теги в одной ячейке CSV
+const tagsSeparator = "|"
+
+// maxJSONLineSize максимальная длина строки JSON Lines
+const maxJSONLineSize = 1 << 20
+
+var csvColumns = []string{
+	columnUUID,
+	columnName,
+	columnDescription,
+	columnPrice,
+	columnStockQuantity,
+	columnCategory,
+	columnLength,
+	columnWidth,
+	columnHeight,
+	columnWeight,
+	columnManufacturerName,
+	columnManufacturerCountry,
+	columnManufacturerWebsite,
+	columnTags,
+	columnMetadata,
+}
+
+var requiredColumns = []string{columnName, columnPrice, columnStockQuantity, columnCategory}
+
+// Decode читает и проверяет строки каталога. Ошибки в отдельных строках возвращаются списком,
+// ошибка верхнего уровня означает, что файл прочитать нельзя
+func Decode(r io.Reader, format Format) ([]Row, []RowError, error) {
+	switch format {
+	case FormatCSV:
+		return decodeCSV(r)
+	case FormatJSONL:
+		return decodeJSONL(r)
+	}
+
+	return nil, nil, fmt.Errorf("unknown format %q", format)
+}
+
+func decodeCSV(r io.Reader) ([]Row, []RowError, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil, errors.New("csv header is missing")
+		}
+		return nil, nil, fmt.Errorf("failed to read csv header: %w", err)
+	}
+
+	columns, err := parseHeader(header)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		rows      []Row
+		rowErrors []RowError
+	)
+	for {
+		fields, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			rowErrors = append(rowErrors, RowError{Line: parseErr.StartLine, Err: parseErr.Err})
+			continue
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read csv: %w", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+
+		rec, err := csvRecord(fields, columns)
+		if err == nil {
+			var part models.Part
+			if part, err = rec.toPart(); err == nil {
+				rows = append(rows, Row{Line: line, Part: part})
+				continue
+			}
+		}
+
+		rowErrors = append(rowErrors, RowError{Line: line, Err: err})
+	}
+
+	return rows, rowErrors, nil
+}
+
+// parseHeader возвращает индексы колонок по их названиям
+func parseHeader(header []string) (map[string]int, error) {
+	known := make(map[string]bool, len(csvColumns))
+	for _, column := range csvColumns {
+		known[column] = true
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, column := range header {
+		column = strings.ToLower(strings.TrimSpace(column))
+		if !known[column] {
+			return nil, fmt.Errorf("unknown csv column %q", column)
+		}
+		if _, ok := columns[column]; ok {
+			return nil, fmt.Errorf("duplicate csv column %q", column)
+		}
+		columns[column] = i
+	}
+
+	for _, column := range requiredColumns {
+		if _, ok := columns[column]; !ok {
+			return nil, fmt.Errorf("required csv column %q is missing", column)
+		}
+	}
+
+	return columns, nil
+}
+
+// csvRecord разбирает ячейки строки; пустые колонки размеров и производителя означают их отсутствие
+func csvRecord(fields []string, columns map[string]int) (record, error) {
+	get := func(column string) string {
+		i, ok := columns[column]
+		if !ok {
+			return ""
+		}
+		return strings.TrimSpace(fields[i])
+	}
+
+	var errs []error
+	parseFloat := func(column string) float64 {
+		value := get(column)
+		if value == "" {
+			return 0
+		}
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s %q is not a number", column, value))
+		}
+		return parsed
+	}
+
+	rec := record{
+		UUID:        get(columnUUID),
+		Name:        get(columnName),
+		Description: get(columnDescription),
+		Price:       parseFloat(columnPrice),
+		Category:    get(columnCategory),
+	}
+
+	if value := get(columnStockQuantity); value != "" {
+		stock, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s %q is not an integer", columnStockQuantity, value))
+		}
+		rec.StockQuantity = stock
+	}
+
+	if anySet(get, columnLength, columnWidth, columnHeight, columnWeight) {
+		rec.Dimensions = &dimensions{
+			Length: parseFloat(columnLength),
+			Width:  parseFloat(columnWidth),
+			Height: parseFloat(columnHeight),
+			Weight: parseFloat(columnWeight),
+		}
+	}
+
+	if anySet(get, columnManufacturerName, columnManufacturerCountry, columnManufacturerWebsite) {
+		rec.Manufacturer = &manufacturer{
+			Name:    get(columnManufacturerName),
+			Country: get(columnManufacturerCountry),
+			Website: get(columnManufacturerWebsite),
+		}
+	}
+
+	if value := get(columnTags); value != "" {
+		rec.Tags = strings.Split(value, tagsSeparator)
+	}
+
+	if value := get(columnMetadata); value != "" {
+		if err := json.Unmarshal([]byte(value), &rec.Metadata); err != nil {
+			errs = append(errs, fmt.Errorf("%s must be a JSON object: %w", columnMetadata, err))
+		}
+	}
+
+	return rec, errors.Join(errs...)
+}
+
+func anySet(get func(string) string, columns ...string) bool {
+	for _, column := range columns {
+		if get(column) != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func decodeJSONL(r io.Reader) ([]Row, []RowError, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxJSONLineSize)
+
+	var (
+		rows      []Row
+		rowErrors []RowError
+	)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		var rec record
+		decoder := json.NewDecoder(strings.NewReader(text))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&rec); err != nil {
+			rowErrors = append(rowErrors, RowError{Line: line, Err: err})
+			continue
+		}
+		if decoder.More() {
+			rowErrors = append(rowErrors, RowError{Line: line, Err: errors.New("unexpected data after JSON object")})
+			continue
+		}
+
+		part, err := rec.toPart()
+		if err != nil {
+			rowErrors = append(rowErrors, RowError{Line: line, Err: err})
+			continue
+		}
+
+		rows = append(rows, Row{Line: line, Part: part})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to read json lines: %w", err)
+	}
+
+	return rows, rowErrors, nil
+}
+
+// Encoder записывает детали в файл каталога
+type Encoder interface {
+	Encode(part models.Part) error
+	// Flush дописывает буферизованные данные
+	Flush() error
+}
+
+// NewEncoder создает Encoder для формата; для CSV сразу записывает заголовок
+func NewEncoder(w io.Writer, format Format) (Encoder, error) {
+	switch format {
+	case FormatCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(csvColumns); err != nil {
+			return nil, err
+		}
+		return &csvEncoder{writer: writer}, nil
+	case FormatJSONL:
+		buffered := bufio.NewWriter(w)
+		return &jsonlEncoder{buffered: buffered, encoder: json.NewEncoder(buffered)}, nil
+	}
+
+	return nil, fmt.Errorf("unknown format %q", format)
+}
+
+type csvEncoder struct {
+	writer *csv.Writer
+}
+
+func (e *csvEncoder) Encode(part models.Part) error {
+	rec := recordFromPart(part)
+
+	values := map[string]string{
+		columnUUID:          rec.UUID,
+		columnName:          rec.Name,
+		columnDescription:   rec.Description,
+		columnPrice:         formatFloat(rec.Price),
+		columnStockQuantity: strconv.FormatInt(rec.StockQuantity, 10),
+		columnCategory:      rec.Category,
+		columnTags:          strings.Join(rec.Tags, tagsSeparator),
+	}
+
+	if rec.Dimensions != nil {
+		values[columnLength] = formatFloat(rec.Dimensions.Length)
+		values[columnWidth] = formatFloat(rec.Dimensions.Width)
+		values[columnHeight] = formatFloat(rec.Dimensions.Height)
+		values[columnWeight] = formatFloat(rec.Dimensions.Weight)
+	}
+
+	if rec.Manufacturer != nil {
+		values[columnManufacturerName] = rec.Manufacturer.Name
+		values[columnManufacturerCountry] = rec.Manufacturer.Country
+		values[columnManufacturerWebsite] = rec.Manufacturer.Website
+	}
+
+	if len(rec.Metadata) > 0 {
+		metadata, err := json.Marshal(rec.Metadata)
+		if err != nil {
+			return fmt.Errorf("failed to encode metadata of part %s: %w", part.UUID, err)
+		}
+		values[columnMetadata] = string(metadata)
+	}
+
+	fields := make([]string, len(csvColumns))
+	for i, column := range csvColumns {
+		fields[i] = values[column]
+	}
+
+	return e.writer.Write(fields)
+}
+
+func (e *csvEncoder) Flush() error {
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+type jsonlEncoder struct {
+	buffered *bufio.Writer
+	encoder  *json.Encoder
+}
+
+func (e *jsonlEncoder) Encode(part models.Part) error {
+	return e.encoder.Encode(recordFromPart(part))
+}
+
+func (e *jsonlEncoder) Flush() error {
+	return e.buffered.Flush()
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
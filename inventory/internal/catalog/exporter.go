@@ -0,0 +1,42 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/linemk/rocket-shop/inventory/internal/entyties/models"
+	inventory_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/inventory/v1"
+)
+
+// Export выгружает весь каталог пачками по batchSize в порядке названий и возвращает число деталей
+func Export(ctx context.Context, repository Repository, encoder Encoder, batchSize int) (int, error) {
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+
+	total := 0
+	for {
+		parts, err := repository.ListParts(ctx, models.PartFilter{}, models.PartListOptions{
+			SortField:     inventory_v1.PartsSortField_PARTS_SORT_FIELD_NAME,
+			SortDirection: inventory_v1.SortDirection_SORT_DIRECTION_ASC,
+			Offset:        total,
+			Limit:         batchSize,
+		})
+		if err != nil {
+			return total, fmt.Errorf("failed to list parts from offset %d: %w", total, err)
+		}
+
+		for _, part := range parts {
+			if err := encoder.Encode(part); err != nil {
+				return total, fmt.Errorf("failed to encode part %s: %w", part.UUID, err)
+			}
+			total++
+		}
+
+		if len(parts) < batchSize {
+			break
+		}
+	}
+
+	return total, encoder.Flush()
+}
@@ -0,0 +1,316 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/linemk/rocket-shop/inventory/internal/entyties/models"
+)
+
+// Action действие импорта над деталью
+type Action string
+
+const (
+	ActionCreate    Action = "create"
+	ActionUpdate    Action = "update"
+	ActionUnchanged Action = "unchanged"
+)
+
+// FieldChange изменение поля детали в отчете импорта
+type FieldChange struct {
+	Field string
+	Old   string
+	New   string
+}
+
+// Change результат сопоставления строки импорта с хранилищем
+type Change struct {
+	Line   int
+	Action Action
+	// Part состояние детали после импорта
+	Part models.Part
+	// Fields измененные поля; заполняется только для ActionUpdate
+	Fields []FieldChange
+}
+
+// Report отчет импорта
+type Report struct {
+	Changes []Change
+	Errors  []RowError
+	// Written true, если изменения записаны в хранилище; false для dry-run и при ошибках в строках
+	Written bool
+}
+
+// Count возвращает число деталей с указанным действием
+func (r Report) Count(action Action) int {
+	count := 0
+	for _, change := range r.Changes {
+		if change.Action == action {
+			count++
+		}
+	}
+
+	return count
+}
+
+// Importer записывает строки каталога в хранилище пачками
+type Importer struct {
+	repository Repository
+	keyMode    KeyMode
+	batchSize  int
+	dryRun     bool
+}
+
+// NewImporter создает Importer. В режиме KeyNatural строки с UUID все равно сопоставляются по UUID,
+// а по названию и производителю ищутся только строки без него
+func NewImporter(repository Repository, keyMode KeyMode, batchSize int, dryRun bool) *Importer {
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+
+	return &Importer{
+		repository: repository,
+		keyMode:    keyMode,
+		batchSize:  batchSize,
+		dryRun:     dryRun,
+	}
+}
+
+// Import сопоставляет строки с деталями в хранилище и записывает созданные и измененные детали.
+// Ошибки в строках и повторяющиеся ключи переводят импорт в режим dry-run, чтобы файл
+// не применился частично. Неоднозначный естественный ключ выясняется только при сопоставлении пачки,
+// такая строка пропускается с ошибкой. Ошибка возвращается только при сбое хранилища
+func (i *Importer) Import(ctx context.Context, rows []Row, rowErrors []RowError) (Report, error) {
+	report := Report{
+		Errors: append(append([]RowError(nil), rowErrors...), i.duplicateKeys(rows)...),
+	}
+	write := !i.dryRun && len(report.Errors) == 0
+
+	for start := 0; start < len(rows); start += i.batchSize {
+		batch := rows[start:min(start+i.batchSize, len(rows))]
+
+		existing, err := i.lookup(ctx, batch)
+		if err != nil {
+			return report, fmt.Errorf("failed to look up parts: %w", err)
+		}
+
+		now := time.Now()
+		var changed []models.Part
+		for _, row := range batch {
+			change, err := plan(row, existing, i.keyMode, now)
+			if err != nil {
+				report.Errors = append(report.Errors, RowError{Line: row.Line, Err: err})
+				continue
+			}
+
+			report.Changes = append(report.Changes, change)
+			if change.Action != ActionUnchanged {
+				changed = append(changed, change.Part)
+			}
+		}
+
+		if !write || len(changed) == 0 {
+			continue
+		}
+
+		if err := i.repository.UpsertParts(ctx, changed); err != nil {
+			return report, fmt.Errorf("failed to write parts from line %d: %w", batch[0].Line, err)
+		}
+		report.Written = true
+	}
+
+	sort.SliceStable(report.Errors, func(a, b int) bool {
+		return report.Errors[a].Line < report.Errors[b].Line
+	})
+
+	return report, nil
+}
+
+// duplicateKeys находит строки, ключ которых уже встречался в файле
+func (i *Importer) duplicateKeys(rows []Row) []RowError {
+	var errs []RowError
+	seen := make(map[string]int, len(rows))
+	for _, row := range rows {
+		key := "uuid " + row.Part.UUID
+		if row.Part.UUID == "" {
+			if i.keyMode != KeyNatural {
+				continue
+			}
+			key = fmt.Sprintf("name %q and manufacturer %q", row.Part.Name, manufacturerName(row.Part))
+		}
+
+		if line, ok := seen[key]; ok {
+			errs = append(errs, RowError{Line: row.Line, Err: fmt.Errorf("%s already used on line %d", key, line)})
+			continue
+		}
+		seen[key] = row.Line
+	}
+
+	return errs
+}
+
+// existingParts детали хранилища, найденные для пачки строк
+type existingParts struct {
+	byUUID map[string]models.Part
+	byKey  map[string][]models.Part
+}
+
+func (i *Importer) lookup(ctx context.Context, batch []Row) (existingParts, error) {
+	existing := existingParts{
+		byUUID: make(map[string]models.Part),
+		byKey:  make(map[string][]models.Part),
+	}
+
+	var uuids, names []string
+	for _, row := range batch {
+		if row.Part.UUID != "" {
+			uuids = append(uuids, row.Part.UUID)
+		} else if i.keyMode == KeyNatural {
+			names = append(names, row.Part.Name)
+		}
+	}
+
+	// Пустой фильтр вернул бы весь каталог, поэтому без ключей хранилище не запрашивается
+	if len(uuids) > 0 {
+		parts, err := i.repository.ListParts(ctx, models.PartFilter{UUIDs: uuids}, models.PartListOptions{})
+		if err != nil {
+			return existingParts{}, err
+		}
+		for _, part := range parts {
+			existing.byUUID[part.UUID] = part
+		}
+	}
+
+	if len(names) > 0 {
+		parts, err := i.repository.ListParts(ctx, models.PartFilter{Names: names}, models.PartListOptions{})
+		if err != nil {
+			return existingParts{}, err
+		}
+		for _, part := range parts {
+			key := naturalKey(part)
+			existing.byKey[key] = append(existing.byKey[key], part)
+		}
+	}
+
+	return existing, nil
+}
+
+// plan определяет действие над деталью и ее итоговое состояние
+func plan(row Row, existing existingParts, keyMode KeyMode, now time.Time) (Change, error) {
+	part := row.Part
+
+	var (
+		current models.Part
+		found   bool
+	)
+	if part.UUID != "" {
+		current, found = existing.byUUID[part.UUID]
+	} else if keyMode == KeyNatural {
+		matches := existing.byKey[naturalKey(part)]
+		if len(matches) > 1 {
+			return Change{}, fmt.Errorf("name %q and manufacturer %q match %d parts, set uuid to choose one",
+				part.Name, manufacturerName(part), len(matches))
+		}
+		if len(matches) == 1 {
+			current, found = matches[0], true
+		}
+	}
+
+	if !found {
+		if part.UUID == "" {
+			part.UUID = uuid.New().String()
+		}
+		part.CreatedAt = now
+		part.UpdatedAt = now
+
+		return Change{Line: row.Line, Action: ActionCreate, Part: part}, nil
+	}
+
+	part.UUID = current.UUID
+	part.CreatedAt = current.CreatedAt
+	part.UpdatedAt = current.UpdatedAt
+	// Метаданные редко ведут в таблицах, поэтому строка без них сохраняет метаданные детали
+	if part.Metadata == nil {
+		part.Metadata = current.Metadata
+	}
+
+	fields := diffParts(current, part)
+	if len(fields) == 0 {
+		return Change{Line: row.Line, Action: ActionUnchanged, Part: current}, nil
+	}
+
+	part.UpdatedAt = now
+
+	return Change{Line: row.Line, Action: ActionUpdate, Part: part, Fields: fields}, nil
+}
+
+func naturalKey(part models.Part) string {
+	return part.Name + "\x00" + manufacturerName(part)
+}
+
+func manufacturerName(part models.Part) string {
+	if part.Manufacturer == nil {
+		return ""
+	}
+
+	return part.Manufacturer.Name
+}
+
+// diffParts сравнивает поля, которые задаются файлом каталога
+func diffParts(old, updated models.Part) []FieldChange {
+	var fields []FieldChange
+	compare := func(field, oldValue, newValue string) {
+		if oldValue != newValue {
+			fields = append(fields, FieldChange{Field: field, Old: oldValue, New: newValue})
+		}
+	}
+
+	compare(columnName, old.Name, updated.Name)
+	compare(columnDescription, old.Description, updated.Description)
+	compare(columnPrice, formatFloat(old.Price), formatFloat(updated.Price))
+	compare(columnStockQuantity, fmt.Sprint(old.StockQuantity), fmt.Sprint(updated.StockQuantity))
+	compare(columnCategory, categoryName(old.Category), categoryName(updated.Category))
+	compare("dimensions", formatDimensions(old.Dimensions), formatDimensions(updated.Dimensions))
+	compare("manufacturer", formatManufacturer(old.Manufacturer), formatManufacturer(updated.Manufacturer))
+	compare(columnTags, strings.Join(old.Tags, tagsSeparator), strings.Join(updated.Tags, tagsSeparator))
+	compare(columnMetadata, formatMetadata(old.Metadata), formatMetadata(updated.Metadata))
+
+	return fields
+}
+
+func formatDimensions(d *models.Dimensions) string {
+	if d == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%sx%sx%s, weight %s",
+		formatFloat(d.Length), formatFloat(d.Width), formatFloat(d.Height), formatFloat(d.Weight))
+}
+
+func formatManufacturer(m *models.Manufacturer) string {
+	if m == nil {
+		return ""
+	}
+
+	return strings.Join([]string{m.Name, m.Country, m.Website}, ", ")
+}
+
+// formatMetadata кодирует метаданные в JSON: ключи сортируются, а числа из BSON и JSON сравниваются по значению
+func formatMetadata(metadata map[string]interface{}) string {
+	if len(metadata) == 0 {
+		return ""
+	}
+
+	encoded, err := json.Marshal(normalizeMetadata(metadata))
+	if err != nil {
+		return fmt.Sprint(metadata)
+	}
+
+	return string(encoded)
+}
@@ -0,0 +1,289 @@
+package catalog
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/linemk/rocket-shop/inventory/internal/entyties/models"
+	inventory_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/inventory/v1"
+)
+
+const categoryPrefix = "CATEGORY_"
+
+// Row проверенная строка импорта
+type Row struct {
+	// Line номер строки во входном файле
+	Line int
+	Part models.Part
+}
+
+// RowError ошибка в строке импорта
+type RowError struct {
+	Line int
+	Err  error
+}
+
+func (e RowError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e RowError) Unwrap() error {
+	return e.Err
+}
+
+// record деталь в представлении файла каталога
+type record struct {
+	UUID          string                 `json:"uuid,omitempty"`
+	Name          string                 `json:"name"`
+	Description   string                 `json:"description,omitempty"`
+	Price         float64                `json:"price"`
+	StockQuantity int64                  `json:"stock_quantity"`
+	Category      string                 `json:"category"`
+	Dimensions    *dimensions            `json:"dimensions,omitempty"`
+	Manufacturer  *manufacturer          `json:"manufacturer,omitempty"`
+	Tags          []string               `json:"tags,omitempty"`
+	Metadata      map[string]interface{} `json:"metadata,omitempty"`
+}
+
+type dimensions struct {
+	Length float64 `json:"length"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+	Weight float64 `json:"weight"`
+}
+
+type manufacturer struct {
+	Name    string `json:"name"`
+	Country string `json:"country"`
+	Website string `json:"website,omitempty"`
+}
+
+// toPart проверяет запись и преобразует ее в деталь; возвращает все найденные в записи ошибки
+func (r record) toPart() (models.Part, error) {
+	var errs []error
+
+	partUUID := strings.TrimSpace(r.UUID)
+	if partUUID != "" {
+		if _, err := uuid.Parse(partUUID); err != nil {
+			errs = append(errs, fmt.Errorf("uuid %q is invalid", partUUID))
+		}
+	}
+
+	name := strings.TrimSpace(r.Name)
+	if name == "" {
+		errs = append(errs, errors.New("name is required"))
+	}
+
+	if !isFinite(r.Price) || r.Price <= 0 {
+		errs = append(errs, fmt.Errorf("price must be positive, got %v", r.Price))
+	}
+
+	if r.StockQuantity < 0 {
+		errs = append(errs, fmt.Errorf("stock_quantity must not be negative, got %d", r.StockQuantity))
+	}
+
+	category, err := parseCategory(r.Category)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	if r.Dimensions != nil {
+		errs = append(errs, r.Dimensions.validate()...)
+	}
+
+	var partManufacturer *models.Manufacturer
+	if r.Manufacturer != nil {
+		errs = append(errs, r.Manufacturer.validate()...)
+		partManufacturer = &models.Manufacturer{
+			Name:    strings.TrimSpace(r.Manufacturer.Name),
+			Country: strings.TrimSpace(r.Manufacturer.Country),
+			Website: strings.TrimSpace(r.Manufacturer.Website),
+		}
+	}
+
+	if len(errs) > 0 {
+		return models.Part{}, errors.Join(errs...)
+	}
+
+	part := models.Part{
+		UUID:          partUUID,
+		Name:          name,
+		Description:   strings.TrimSpace(r.Description),
+		Price:         r.Price,
+		StockQuantity: r.StockQuantity,
+		Category:      category,
+		Manufacturer:  partManufacturer,
+		Tags:          normalizeTags(r.Tags),
+		Metadata:      r.Metadata,
+	}
+	if r.Dimensions != nil {
+		part.Dimensions = &models.Dimensions{
+			Length: r.Dimensions.Length,
+			Width:  r.Dimensions.Width,
+			Height: r.Dimensions.Height,
+			Weight: r.Dimensions.Weight,
+		}
+	}
+
+	return part, nil
+}
+
+func (d dimensions) validate() []error {
+	var errs []error
+	for _, field := range []struct {
+		name  string
+		value float64
+	}{
+		{"length", d.Length},
+		{"width", d.Width},
+		{"height", d.Height},
+		{"weight", d.Weight},
+	} {
+		if !isFinite(field.value) || field.value <= 0 {
+			errs = append(errs, fmt.Errorf("dimensions %s must be positive, got %v", field.name, field.value))
+		}
+	}
+
+	return errs
+}
+
+func (m manufacturer) validate() []error {
+	var errs []error
+	if strings.TrimSpace(m.Name) == "" {
+		errs = append(errs, errors.New("manufacturer name is required"))
+	}
+
+	if strings.TrimSpace(m.Country) == "" {
+		errs = append(errs, errors.New("manufacturer country is required"))
+	}
+
+	if website := strings.TrimSpace(m.Website); website != "" {
+		u, err := url.Parse(website)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs = append(errs, fmt.Errorf("manufacturer website %q must be an http(s) URL", website))
+		}
+	}
+
+	return errs
+}
+
+// recordFromPart преобразует деталь в запись файла каталога
+func recordFromPart(part models.Part) record {
+	r := record{
+		UUID:          part.UUID,
+		Name:          part.Name,
+		Description:   part.Description,
+		Price:         part.Price,
+		StockQuantity: part.StockQuantity,
+		Category:      categoryName(part.Category),
+		Tags:          part.Tags,
+		Metadata:      normalizeMetadata(part.Metadata),
+	}
+	if part.Dimensions != nil {
+		r.Dimensions = &dimensions{
+			Length: part.Dimensions.Length,
+			Width:  part.Dimensions.Width,
+			Height: part.Dimensions.Height,
+			Weight: part.Dimensions.Weight,
+		}
+	}
+	if part.Manufacturer != nil {
+		r.Manufacturer = &manufacturer{
+			Name:    part.Manufacturer.Name,
+			Country: part.Manufacturer.Country,
+			Website: part.Manufacturer.Website,
+		}
+	}
+
+	return r
+}
+
+// parseCategory принимает название категории без префикса (ENGINE) или с ним (CATEGORY_ENGINE) в любом регистре
+func parseCategory(name string) (inventory_v1.Category, error) {
+	normalized := strings.ToUpper(strings.TrimSpace(name))
+	if normalized == "" {
+		return inventory_v1.Category_CATEGORY_UNSPECIFIED, errors.New("category is required")
+	}
+
+	if !strings.HasPrefix(normalized, categoryPrefix) {
+		normalized = categoryPrefix + normalized
+	}
+
+	value, ok := inventory_v1.Category_value[normalized]
+	if !ok || inventory_v1.Category(value) == inventory_v1.Category_CATEGORY_UNSPECIFIED {
+		return inventory_v1.Category_CATEGORY_UNSPECIFIED, fmt.Errorf("category %q is unknown", name)
+	}
+
+	return inventory_v1.Category(value), nil
+}
+
+// categoryName возвращает название категории без префикса, как его пишут в таблицах
+func categoryName(category inventory_v1.Category) string {
+	return strings.TrimPrefix(category.String(), categoryPrefix)
+}
+
+func normalizeTags(tags []string) []string {
+	var normalized []string
+	for _, tag := range tags {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			normalized = append(normalized, tag)
+		}
+	}
+
+	return normalized
+}
+
+// normalizeMetadata заменяет вложенные BSON документы и массивы, которые драйвер MongoDB
+// возвращает для interface{}, на map и slice, чтобы они кодировались в JSON как объекты
+func normalizeMetadata(metadata map[string]interface{}) map[string]interface{} {
+	if metadata == nil {
+		return nil
+	}
+
+	normalized := make(map[string]interface{}, len(metadata))
+	for key, value := range metadata {
+		normalized[key] = normalizeValue(value)
+	}
+
+	return normalized
+}
+
+func normalizeValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case bson.D:
+		m := make(map[string]interface{}, len(v))
+		for _, elem := range v {
+			m[elem.Key] = normalizeValue(elem.Value)
+		}
+		return m
+	case bson.M:
+		return normalizeMetadata(v)
+	case map[string]interface{}:
+		return normalizeMetadata(v)
+	case bson.A:
+		return normalizeSlice(v)
+	case []interface{}:
+		return normalizeSlice(v)
+	default:
+		return v
+	}
+}
+
+func normalizeSlice(values []interface{}) []interface{} {
+	normalized := make([]interface{}, len(values))
+	for i, value := range values {
+		normalized[i] = normalizeValue(value)
+	}
+
+	return normalized
+}
+
+func isFinite(value float64) bool {
+	return !math.IsNaN(value) && !math.IsInf(value, 0)
+}
@@ -0,0 +1,40 @@
+package catalog
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteReport печатает отчет импорта: созданные и измененные детали с различиями по полям,
+// ошибки строк и итог. Неизмененные детали в отчет не попадают
+func WriteReport(w io.Writer, report Report) error {
+	var b strings.Builder
+
+	for _, change := range report.Changes {
+		switch change.Action {
+		case ActionCreate:
+			fmt.Fprintf(&b, "+ line %d: create %s %q\n", change.Line, change.Part.UUID, change.Part.Name)
+		case ActionUpdate:
+			fmt.Fprintf(&b, "~ line %d: update %s %q\n", change.Line, change.Part.UUID, change.Part.Name)
+			for _, field := range change.Fields {
+				fmt.Fprintf(&b, "    %s: %q -> %q\n", field.Field, field.Old, field.New)
+			}
+		}
+	}
+
+	for _, rowErr := range report.Errors {
+		// errors.Join разделяет ошибки переводом строки, в отчете ошибки строки выводятся в одну строку
+		fmt.Fprintf(&b, "! line %d: %s\n", rowErr.Line, strings.ReplaceAll(rowErr.Err.Error(), "\n", "; "))
+	}
+
+	fmt.Fprintf(&b, "created: %d, updated: %d, unchanged: %d, errors: %d\n",
+		report.Count(ActionCreate), report.Count(ActionUpdate), report.Count(ActionUnchanged), len(report.Errors))
+
+	if !report.Written {
+		b.WriteString("nothing was written\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,265 @@
+package catalog
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/linemk/rocket-shop/inventory/internal/entyties/models"
+	inventory_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/inventory/v1"
+)
+
+// RuleRepository хранилище правил совместимости деталей
+type RuleRepository interface {
+	ListRules(ctx context.Context) ([]models.CompatibilityRule, error)
+	// UpsertRules создает или заменяет правила с указанными UUID
+	UpsertRules(ctx context.Context, rules []models.CompatibilityRule) error
+	DeleteRule(ctx context.Context, uuid string) error
+}
+
+// RuleRow проверенное правило из файла правил
+type RuleRow struct {
+	// Line номер строки во входном файле
+	Line int
+	Rule models.CompatibilityRule
+}
+
+// ruleRecord правило совместимости в представлении файла правил
+type ruleRecord struct {
+	UUID     string          `json:"uuid,omitempty"`
+	Type     string          `json:"type"`
+	Subject  *selectorRecord `json:"subject,omitempty"`
+	Object   *selectorRecord `json:"object,omitempty"`
+	Category string          `json:"category,omitempty"`
+	MinCount int             `json:"min_count,omitempty"`
+	Reason   string          `json:"reason,omitempty"`
+}
+
+// selectorRecord выбирает деталь по UUID или категорию деталей
+type selectorRecord struct {
+	PartUUID string `json:"part_uuid,omitempty"`
+	Category string `json:"category,omitempty"`
+}
+
+// toRule проверяет запись и преобразует ее в правило; возвращает все найденные в записи ошибки
+func (r ruleRecord) toRule() (models.CompatibilityRule, error) {
+	var errs []error
+
+	rule := models.CompatibilityRule{
+		UUID:   strings.TrimSpace(r.UUID),
+		Type:   models.CompatibilityRuleType(strings.ToLower(strings.TrimSpace(r.Type))),
+		Reason: strings.TrimSpace(r.Reason),
+	}
+	if rule.UUID != "" {
+		if _, err := uuid.Parse(rule.UUID); err != nil {
+			errs = append(errs, fmt.Errorf("uuid %q is invalid", rule.UUID))
+		}
+	}
+
+	switch rule.Type {
+	case models.CompatibilityRuleIncompatible, models.CompatibilityRuleRequires:
+		var err error
+		if rule.Subject, err = r.Subject.toSelector("subject"); err != nil {
+			errs = append(errs, err)
+		}
+		if rule.Object, err = r.Object.toSelector("object"); err != nil {
+			errs = append(errs, err)
+		}
+		if r.Category != "" || r.MinCount != 0 {
+			errs = append(errs, fmt.Errorf("category and min_count are not used by %s rules", rule.Type))
+		}
+	case models.CompatibilityRuleRequiredCategory:
+		var err error
+		if rule.Category, err = parseCategory(r.Category); err != nil {
+			errs = append(errs, err)
+		}
+		if r.MinCount < 0 {
+			errs = append(errs, fmt.Errorf("min_count must not be negative, got %d", r.MinCount))
+		}
+		rule.MinCount = r.MinCount
+		if r.Subject != nil || r.Object != nil {
+			errs = append(errs, fmt.Errorf("subject and object are not used by %s rules", rule.Type))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("type %q is unknown, expected %s, %s or %s", r.Type,
+			models.CompatibilityRuleIncompatible, models.CompatibilityRuleRequires, models.CompatibilityRuleRequiredCategory))
+	}
+
+	if len(errs) > 0 {
+		return models.CompatibilityRule{}, errors.Join(errs...)
+	}
+
+	return rule, nil
+}
+
+// toSelector проверяет, что задан ровно один из part_uuid и category
+func (s *selectorRecord) toSelector(field string) (models.PartSelector, error) {
+	if s == nil {
+		return models.PartSelector{}, fmt.Errorf("%s is required", field)
+	}
+
+	partUUID := strings.TrimSpace(s.PartUUID)
+	switch {
+	case partUUID != "" && s.Category != "":
+		return models.PartSelector{}, fmt.Errorf("%s must set either part_uuid or category, not both", field)
+	case partUUID != "":
+		if _, err := uuid.Parse(partUUID); err != nil {
+			return models.PartSelector{}, fmt.Errorf("%s part_uuid %q is invalid", field, partUUID)
+		}
+		return models.PartSelector{PartUUID: partUUID}, nil
+	}
+
+	category, err := parseCategory(s.Category)
+	if err != nil {
+		return models.PartSelector{}, fmt.Errorf("%s: %w", field, err)
+	}
+
+	return models.PartSelector{Category: category}, nil
+}
+
+// ruleRecordFromRule преобразует правило в запись файла правил
+func ruleRecordFromRule(rule models.CompatibilityRule) ruleRecord {
+	r := ruleRecord{
+		UUID:     rule.UUID,
+		Type:     string(rule.Type),
+		MinCount: rule.MinCount,
+		Reason:   rule.Reason,
+	}
+	if rule.Category != inventory_v1.Category_CATEGORY_UNSPECIFIED {
+		r.Category = categoryName(rule.Category)
+	}
+	if rule.Type != models.CompatibilityRuleRequiredCategory {
+		r.Subject = selectorRecordFromSelector(rule.Subject)
+		r.Object = selectorRecordFromSelector(rule.Object)
+	}
+
+	return r
+}
+
+func selectorRecordFromSelector(selector models.PartSelector) *selectorRecord {
+	if selector.PartUUID != "" {
+		return &selectorRecord{PartUUID: selector.PartUUID}
+	}
+
+	return &selectorRecord{Category: categoryName(selector.Category)}
+}
+
+// DecodeRules читает правила из JSON Lines: по одному правилу в строке. Ошибки в строках возвращаются
+// отдельно от ошибки чтения, чтобы показать оператору все некорректные правила сразу
+func DecodeRules(r io.Reader) ([]RuleRow, []RowError, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxJSONLineSize)
+
+	var (
+		rows      []RuleRow
+		rowErrors []RowError
+	)
+	seen := make(map[string]int)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		var rec ruleRecord
+		decoder := json.NewDecoder(strings.NewReader(text))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&rec); err != nil {
+			rowErrors = append(rowErrors, RowError{Line: line, Err: err})
+			continue
+		}
+		if decoder.More() {
+			rowErrors = append(rowErrors, RowError{Line: line, Err: errors.New("unexpected data after JSON object")})
+			continue
+		}
+
+		rule, err := rec.toRule()
+		if err != nil {
+			rowErrors = append(rowErrors, RowError{Line: line, Err: err})
+			continue
+		}
+
+		if rule.UUID != "" {
+			if first, ok := seen[rule.UUID]; ok {
+				rowErrors = append(rowErrors, RowError{Line: line, Err: fmt.Errorf("uuid %s already used on line %d", rule.UUID, first)})
+				continue
+			}
+			seen[rule.UUID] = line
+		}
+
+		rows = append(rows, RuleRow{Line: line, Rule: rule})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to read json lines: %w", err)
+	}
+
+	return rows, rowErrors, nil
+}
+
+// EncodeRules записывает правила в JSON Lines в том же виде, в каком их читает DecodeRules
+func EncodeRules(w io.Writer, rules []models.CompatibilityRule) error {
+	buffered := bufio.NewWriter(w)
+	encoder := json.NewEncoder(buffered)
+	for _, rule := range rules {
+		if err := encoder.Encode(ruleRecordFromRule(rule)); err != nil {
+			return err
+		}
+	}
+
+	return buffered.Flush()
+}
+
+// RulesReport итог импорта правил
+type RulesReport struct {
+	Created  int
+	Replaced int
+	// Written true, если правила записаны в хранилище; false для dry-run
+	Written bool
+}
+
+// ImportRules создает правила без UUID или с новым UUID и заменяет правила с уже существующим UUID;
+// в режиме dry-run только считает их. Правила действуют для ValidateBuild сразу после записи
+func ImportRules(ctx context.Context, repository RuleRepository, rows []RuleRow, dryRun bool) (RulesReport, error) {
+	existing, err := repository.ListRules(ctx)
+	if err != nil {
+		return RulesReport{}, fmt.Errorf("failed to list rules: %w", err)
+	}
+
+	known := make(map[string]bool, len(existing))
+	for _, rule := range existing {
+		known[rule.UUID] = true
+	}
+
+	var report RulesReport
+	rules := make([]models.CompatibilityRule, 0, len(rows))
+	for _, row := range rows {
+		rule := row.Rule
+		if known[rule.UUID] {
+			report.Replaced++
+		} else {
+			report.Created++
+		}
+		if rule.UUID == "" {
+			rule.UUID = uuid.New().String()
+		}
+		rules = append(rules, rule)
+	}
+
+	if dryRun || len(rules) == 0 {
+		return report, nil
+	}
+
+	if err := repository.UpsertRules(ctx, rules); err != nil {
+		return report, fmt.Errorf("failed to write rules: %w", err)
+	}
+	report.Written = true
+
+	return report, nil
+}
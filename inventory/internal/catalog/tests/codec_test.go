@@ -0,0 +1,146 @@
+package tests
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/linemk/rocket-shop/inventory/internal/catalog"
+	"github.com/linemk/rocket-shop/inventory/internal/entyties/models"
+	inventory_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/inventory/v1"
+)
+
+func TestDecodeCSV(t *testing.T) {
+	input := strings.Join([]string{
+		"name,price,stock_quantity,category,length,width,height,weight,manufacturer_name,manufacturer_country,manufacturer_website,tags",
+		"Ion Thruster,1500.5,12,engine,120,80,60,250,Orbital Works,Germany,https://orbital.example,engine|ion",
+		"Plasma Fuel Tank,900,0,CATEGORY_FUEL,,,,,,,,",
+		"Broken Wing,-1,3,wings,10,0,10,10,Acme,,ftp://acme.example,",
+		"Short Row,100",
+	}, "\n")
+
+	rows, rowErrors, err := catalog.Decode(strings.NewReader(input), catalog.FormatCSV)
+	require.NoError(t, err)
+
+	require.Len(t, rows, 2)
+	require.Equal(t, 2, rows[0].Line)
+	require.Equal(t, models.Part{
+		Name:          "Ion Thruster",
+		Price:         1500.5,
+		StockQuantity: 12,
+		Category:      inventory_v1.Category_CATEGORY_ENGINE,
+		Dimensions:    &models.Dimensions{Length: 120, Width: 80, Height: 60, Weight: 250},
+		Manufacturer:  &models.Manufacturer{Name: "Orbital Works", Country: "Germany", Website: "https://orbital.example"},
+		Tags:          []string{"engine", "ion"},
+	}, rows[0].Part)
+	require.Equal(t, inventory_v1.Category_CATEGORY_FUEL, rows[1].Part.Category)
+	require.Nil(t, rows[1].Part.Dimensions)
+	require.Nil(t, rows[1].Part.Manufacturer)
+
+	require.Len(t, rowErrors, 2)
+	require.Equal(t, 4, rowErrors[0].Line)
+	for _, want := range []string{
+		"price must be positive",
+		`category "wings" is unknown`,
+		"dimensions width must be positive",
+		"manufacturer country is required",
+		"manufacturer website",
+	} {
+		require.Contains(t, rowErrors[0].Error(), want)
+	}
+	require.Equal(t, 5, rowErrors[1].Line)
+}
+
+func TestDecodeCSVHeader(t *testing.T) {
+	_, _, err := catalog.Decode(strings.NewReader("name,price,category\n"), catalog.FormatCSV)
+	require.ErrorContains(t, err, `"stock_quantity" is missing`)
+
+	_, _, err = catalog.Decode(strings.NewReader("name,price,stock_quantity,category,colour\n"), catalog.FormatCSV)
+	require.ErrorContains(t, err, `unknown csv column "colour"`)
+}
+
+func TestDecodeJSONL(t *testing.T) {
+	input := strings.Join([]string{
+		`{"uuid":"5b7c3b1e-7d0a-4f4e-9d59-0d6a2c1f8e11","name":"Warp Drive","price":42000,"stock_quantity":1,"category":"ENGINE","metadata":{"certified":true}}`,
+		``,
+		`{"name":"Viewport","price":300,"stock_quantity":4,"category":"PORTHOLE","colour":"blue"}`,
+		`{"uuid":"not-a-uuid","name":"","price":300,"stock_quantity":4,"category":"PORTHOLE"}`,
+	}, "\n")
+
+	rows, rowErrors, err := catalog.Decode(strings.NewReader(input), catalog.FormatJSONL)
+	require.NoError(t, err)
+
+	require.Len(t, rows, 1)
+	require.Equal(t, "5b7c3b1e-7d0a-4f4e-9d59-0d6a2c1f8e11", rows[0].Part.UUID)
+	require.Equal(t, map[string]interface{}{"certified": true}, rows[0].Part.Metadata)
+
+	require.Len(t, rowErrors, 2)
+	require.Equal(t, 3, rowErrors[0].Line)
+	require.ErrorContains(t, rowErrors[0], `unknown field "colour"`)
+	require.Equal(t, 4, rowErrors[1].Line)
+	require.ErrorContains(t, rowErrors[1], "uuid")
+	require.ErrorContains(t, rowErrors[1], "name is required")
+}
+
+func TestEncodeDecodeRoundTrip(t *testing.T) {
+	parts := []models.Part{
+		{
+			UUID:          "0f8fad5b-d9cb-469f-a165-70867728950e",
+			Name:          "Carbon Fiber Wing, \"Mk II\"",
+			Description:   "Lightweight wing",
+			Price:         12500.75,
+			StockQuantity: 3,
+			Category:      inventory_v1.Category_CATEGORY_WING,
+			Dimensions:    &models.Dimensions{Length: 300, Width: 120, Height: 15, Weight: 80.5},
+			Manufacturer:  &models.Manufacturer{Name: "Skyline", Country: "Japan"},
+			Tags:          []string{"wing", "carbon"},
+			Metadata:      map[string]interface{}{"batch": "A1"},
+		},
+		{
+			UUID:          "7c9e6679-7425-40de-944b-e07fc1f90ae7",
+			Name:          "Armored Viewport",
+			Price:         640,
+			StockQuantity: 0,
+			Category:      inventory_v1.Category_CATEGORY_PORTHOLE,
+		},
+	}
+
+	for _, format := range []catalog.Format{catalog.FormatCSV, catalog.FormatJSONL} {
+		t.Run(string(format), func(t *testing.T) {
+			var buf bytes.Buffer
+			encoder, err := catalog.NewEncoder(&buf, format)
+			require.NoError(t, err)
+			for _, part := range parts {
+				require.NoError(t, encoder.Encode(part))
+			}
+			require.NoError(t, encoder.Flush())
+
+			rows, rowErrors, err := catalog.Decode(&buf, format)
+			require.NoError(t, err)
+			require.Empty(t, rowErrors)
+			require.Len(t, rows, len(parts))
+			for i, row := range rows {
+				require.Equal(t, parts[i], row.Part)
+			}
+		})
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	format, err := catalog.ParseFormat("", "parts.CSV")
+	require.NoError(t, err)
+	require.Equal(t, catalog.FormatCSV, format)
+
+	format, err = catalog.ParseFormat("", "parts.ndjson")
+	require.NoError(t, err)
+	require.Equal(t, catalog.FormatJSONL, format)
+
+	format, err = catalog.ParseFormat("jsonl", "parts.txt")
+	require.NoError(t, err)
+	require.Equal(t, catalog.FormatJSONL, format)
+
+	_, err = catalog.ParseFormat("", "parts.xlsx")
+	require.Error(t, err)
+}
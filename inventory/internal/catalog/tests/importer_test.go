@@ -0,0 +1,232 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/linemk/rocket-shop/inventory/internal/catalog"
+	"github.com/linemk/rocket-shop/inventory/internal/entyties/models"
+	"github.com/linemk/rocket-shop/inventory/internal/mocks"
+	inventory_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/inventory/v1"
+)
+
+func TestImport(t *testing.T) {
+	ctx := context.Background()
+	createdAt := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	existing := models.Part{
+		UUID:          uuid.New().String(),
+		Name:          "Ion Thruster",
+		Price:         1500,
+		StockQuantity: 12,
+		Category:      inventory_v1.Category_CATEGORY_ENGINE,
+		Manufacturer:  &models.Manufacturer{Name: "Orbital Works", Country: "Germany"},
+		Metadata:      map[string]interface{}{"version": int32(2)},
+		CreatedAt:     createdAt,
+		UpdatedAt:     createdAt,
+	}
+
+	updatedRow := existing
+	updatedRow.Price = 1750
+	updatedRow.Metadata = nil
+
+	newRow := models.Part{
+		Name:          "Plasma Fuel Tank",
+		Price:         900,
+		StockQuantity: 5,
+		Category:      inventory_v1.Category_CATEGORY_FUEL,
+	}
+
+	t.Run("upsert by uuid writes created and updated parts", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := mocks.NewMockCatalogRepository(ctrl)
+		repo.EXPECT().ListParts(ctx, models.PartFilter{UUIDs: []string{existing.UUID}}, models.PartListOptions{}).
+			Return([]models.Part{existing}, nil)
+		repo.EXPECT().UpsertParts(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, parts []models.Part) error {
+				require.Len(t, parts, 2)
+
+				require.Equal(t, existing.UUID, parts[0].UUID)
+				require.Equal(t, 1750.0, parts[0].Price)
+				require.Equal(t, createdAt, parts[0].CreatedAt)
+				require.True(t, parts[0].UpdatedAt.After(createdAt))
+				require.Equal(t, existing.Metadata, parts[0].Metadata, "row without metadata keeps stored metadata")
+
+				require.NotEmpty(t, parts[1].UUID)
+				require.Equal(t, "Plasma Fuel Tank", parts[1].Name)
+				require.False(t, parts[1].CreatedAt.IsZero())
+				return nil
+			})
+
+		report, err := catalog.NewImporter(repo, catalog.KeyUUID, 10, false).
+			Import(ctx, []catalog.Row{{Line: 2, Part: updatedRow}, {Line: 3, Part: newRow}}, nil)
+		require.NoError(t, err)
+		require.True(t, report.Written)
+		require.Equal(t, 1, report.Count(catalog.ActionUpdate))
+		require.Equal(t, 1, report.Count(catalog.ActionCreate))
+		require.Equal(t, []catalog.FieldChange{{Field: "price", Old: "1500", New: "1750"}}, report.Changes[0].Fields)
+	})
+
+	t.Run("natural key matches part without uuid and skips unchanged", func(t *testing.T) {
+		row := existing
+		row.UUID = ""
+
+		ctrl := gomock.NewController(t)
+		repo := mocks.NewMockCatalogRepository(ctrl)
+		repo.EXPECT().ListParts(ctx, models.PartFilter{Names: []string{"Ion Thruster"}}, models.PartListOptions{}).
+			Return([]models.Part{existing}, nil)
+
+		report, err := catalog.NewImporter(repo, catalog.KeyNatural, 10, false).
+			Import(ctx, []catalog.Row{{Line: 2, Part: row}}, nil)
+		require.NoError(t, err)
+		require.False(t, report.Written)
+		require.Equal(t, 1, report.Count(catalog.ActionUnchanged))
+		require.Equal(t, existing.UUID, report.Changes[0].Part.UUID)
+	})
+
+	t.Run("ambiguous natural key is reported", func(t *testing.T) {
+		row := existing
+		row.UUID = ""
+		twin := existing
+		twin.UUID = uuid.New().String()
+
+		ctrl := gomock.NewController(t)
+		repo := mocks.NewMockCatalogRepository(ctrl)
+		repo.EXPECT().ListParts(ctx, gomock.Any(), gomock.Any()).Return([]models.Part{existing, twin}, nil)
+
+		report, err := catalog.NewImporter(repo, catalog.KeyNatural, 10, false).
+			Import(ctx, []catalog.Row{{Line: 7, Part: row}}, nil)
+		require.NoError(t, err)
+		require.Len(t, report.Errors, 1)
+		require.Equal(t, 7, report.Errors[0].Line)
+		require.ErrorContains(t, report.Errors[0], "match 2 parts")
+	})
+
+	t.Run("dry run plans without writing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := mocks.NewMockCatalogRepository(ctrl)
+		repo.EXPECT().ListParts(ctx, gomock.Any(), gomock.Any()).Return([]models.Part{existing}, nil)
+
+		report, err := catalog.NewImporter(repo, catalog.KeyUUID, 10, true).
+			Import(ctx, []catalog.Row{{Line: 2, Part: updatedRow}, {Line: 3, Part: newRow}}, nil)
+		require.NoError(t, err)
+		require.False(t, report.Written)
+		require.Equal(t, 1, report.Count(catalog.ActionUpdate))
+		require.Equal(t, 1, report.Count(catalog.ActionCreate))
+	})
+
+	t.Run("invalid rows and duplicate keys prevent writing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := mocks.NewMockCatalogRepository(ctrl)
+		repo.EXPECT().ListParts(ctx, gomock.Any(), gomock.Any()).Return([]models.Part{existing}, nil)
+
+		rows := []catalog.Row{{Line: 2, Part: updatedRow}, {Line: 4, Part: updatedRow}}
+		rowErrors := []catalog.RowError{{Line: 3, Err: errors.New("price must be positive")}}
+
+		report, err := catalog.NewImporter(repo, catalog.KeyUUID, 10, false).Import(ctx, rows, rowErrors)
+		require.NoError(t, err)
+		require.False(t, report.Written)
+		require.Len(t, report.Errors, 2)
+		require.Equal(t, 3, report.Errors[0].Line)
+		require.Equal(t, 4, report.Errors[1].Line)
+		require.ErrorContains(t, report.Errors[1], "already used on line 2")
+	})
+
+	t.Run("rows are written in batches", func(t *testing.T) {
+		rows := make([]catalog.Row, 5)
+		for i := range rows {
+			part := newRow
+			part.Name = uuid.New().String()
+			rows[i] = catalog.Row{Line: i + 2, Part: part}
+		}
+
+		ctrl := gomock.NewController(t)
+		repo := mocks.NewMockCatalogRepository(ctrl)
+
+		var batches []int
+		repo.EXPECT().UpsertParts(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, parts []models.Part) error {
+				batches = append(batches, len(parts))
+				return nil
+			}).Times(3)
+
+		report, err := catalog.NewImporter(repo, catalog.KeyUUID, 2, false).Import(ctx, rows, nil)
+		require.NoError(t, err)
+		require.Equal(t, []int{2, 2, 1}, batches)
+		require.Equal(t, 5, report.Count(catalog.ActionCreate))
+	})
+
+	t.Run("repository failure stops import", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := mocks.NewMockCatalogRepository(ctrl)
+		repo.EXPECT().UpsertParts(ctx, gomock.Any()).Return(errors.New("connection reset"))
+
+		_, err := catalog.NewImporter(repo, catalog.KeyUUID, 1, false).
+			Import(ctx, []catalog.Row{{Line: 2, Part: newRow}, {Line: 3, Part: newRow}}, nil)
+		require.ErrorContains(t, err, "line 2")
+	})
+}
+
+func TestWriteReport(t *testing.T) {
+	report := catalog.Report{
+		Changes: []catalog.Change{
+			{Line: 2, Action: catalog.ActionCreate, Part: models.Part{UUID: "a", Name: "Warp Drive"}},
+			{Line: 3, Action: catalog.ActionUpdate, Part: models.Part{UUID: "b", Name: "Viewport"}, Fields: []catalog.FieldChange{
+				{Field: "price", Old: "300", New: "320"},
+			}},
+			{Line: 4, Action: catalog.ActionUnchanged, Part: models.Part{UUID: "c", Name: "Fuel Cell"}},
+		},
+		Errors: []catalog.RowError{{Line: 5, Err: errors.Join(errors.New("name is required"), errors.New("price must be positive"))}},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, catalog.WriteReport(&buf, report))
+	require.Equal(t, `+ line 2: create a "Warp Drive"
+~ line 3: update b "Viewport"
+    price: "300" -> "320"
+! line 5: name is required; price must be positive
+created: 1, updated: 1, unchanged: 1, errors: 1
+nothing was written
+`, buf.String())
+}
+
+func TestExport(t *testing.T) {
+	ctx := context.Background()
+
+	part := func(name string) models.Part {
+		return models.Part{UUID: uuid.New().String(), Name: name, Price: 10, Category: inventory_v1.Category_CATEGORY_WING}
+	}
+
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockCatalogRepository(ctrl)
+	gomock.InOrder(
+		repo.EXPECT().ListParts(ctx, models.PartFilter{}, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ models.PartFilter, opts models.PartListOptions) ([]models.Part, error) {
+				require.Equal(t, 0, opts.Offset)
+				require.Equal(t, 2, opts.Limit)
+				require.Equal(t, inventory_v1.PartsSortField_PARTS_SORT_FIELD_NAME, opts.SortField)
+				return []models.Part{part("A"), part("B")}, nil
+			}),
+		repo.EXPECT().ListParts(ctx, models.PartFilter{}, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ models.PartFilter, opts models.PartListOptions) ([]models.Part, error) {
+				require.Equal(t, 2, opts.Offset)
+				return []models.Part{part("C")}, nil
+			}),
+	)
+
+	var buf bytes.Buffer
+	encoder, err := catalog.NewEncoder(&buf, catalog.FormatJSONL)
+	require.NoError(t, err)
+
+	total, err := catalog.Export(ctx, repo, encoder, 2)
+	require.NoError(t, err)
+	require.Equal(t, 3, total)
+	require.Equal(t, 3, bytes.Count(buf.Bytes(), []byte("\n")))
+}
@@ -0,0 +1,133 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/linemk/rocket-shop/inventory/internal/catalog"
+	"github.com/linemk/rocket-shop/inventory/internal/entyties/models"
+	"github.com/linemk/rocket-shop/inventory/internal/mocks"
+	inventory_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/inventory/v1"
+)
+
+func TestDecodeRules(t *testing.T) {
+	partUUID := uuid.New().String()
+	ruleUUID := uuid.New().String()
+
+	input := strings.Join([]string{
+		`{"uuid":"` + ruleUUID + `","type":"incompatible","subject":{"part_uuid":"` + partUUID + `"},"object":{"category":"wing"},"reason":"porthole does not fit"}`,
+		`{"type":"required_category","category":"CATEGORY_ENGINE","min_count":2}`,
+		``,
+		`{"type":"requires","subject":{"category":"ENGINE"}}`,
+		`{"type":"required_category","category":"ENGINE","subject":{"category":"FUEL"}}`,
+		`{"type":"forbidden"}`,
+		`{"uuid":"` + ruleUUID + `","type":"required_category","category":"FUEL"}`,
+		`{"type":"requires","subject":{"part_uuid":"` + partUUID + `","category":"ENGINE"},"object":{"category":"FUEL"}}`,
+	}, "\n")
+
+	rows, rowErrors, err := catalog.DecodeRules(strings.NewReader(input))
+	require.NoError(t, err)
+
+	require.Len(t, rows, 2)
+	require.Equal(t, 1, rows[0].Line)
+	require.Equal(t, models.CompatibilityRule{
+		UUID:    ruleUUID,
+		Type:    models.CompatibilityRuleIncompatible,
+		Subject: models.PartSelector{PartUUID: partUUID},
+		Object:  models.PartSelector{Category: inventory_v1.Category_CATEGORY_WING},
+		Reason:  "porthole does not fit",
+	}, rows[0].Rule)
+	require.Equal(t, models.CompatibilityRule{
+		Type:     models.CompatibilityRuleRequiredCategory,
+		Category: inventory_v1.Category_CATEGORY_ENGINE,
+		MinCount: 2,
+	}, rows[1].Rule)
+
+	lines := make([]int, 0, len(rowErrors))
+	for _, rowError := range rowErrors {
+		lines = append(lines, rowError.Line)
+	}
+	require.Equal(t, []int{4, 5, 6, 7, 8}, lines)
+	require.ErrorContains(t, rowErrors[0], "object is required")
+	require.ErrorContains(t, rowErrors[3], "already used on line 1")
+}
+
+func TestEncodeDecodeRulesRoundTrip(t *testing.T) {
+	rules := []models.CompatibilityRule{
+		{
+			UUID:    uuid.New().String(),
+			Type:    models.CompatibilityRuleRequires,
+			Subject: models.PartSelector{Category: inventory_v1.Category_CATEGORY_ENGINE},
+			Object:  models.PartSelector{PartUUID: uuid.New().String()},
+		},
+		{
+			UUID:     uuid.New().String(),
+			Type:     models.CompatibilityRuleRequiredCategory,
+			Category: inventory_v1.Category_CATEGORY_FUEL,
+			Reason:   "rocket needs fuel",
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, catalog.EncodeRules(&buf, rules))
+
+	rows, rowErrors, err := catalog.DecodeRules(&buf)
+	require.NoError(t, err)
+	require.Empty(t, rowErrors)
+	require.Len(t, rows, len(rules))
+	for i, row := range rows {
+		require.Equal(t, rules[i], row.Rule)
+	}
+}
+
+func TestImportRules(t *testing.T) {
+	ctx := context.Background()
+
+	existing := models.CompatibilityRule{
+		UUID:     uuid.New().String(),
+		Type:     models.CompatibilityRuleRequiredCategory,
+		Category: inventory_v1.Category_CATEGORY_ENGINE,
+	}
+	replaced := existing
+	replaced.MinCount = 2
+
+	created := models.CompatibilityRule{
+		Type:     models.CompatibilityRuleRequiredCategory,
+		Category: inventory_v1.Category_CATEGORY_FUEL,
+	}
+	rows := []catalog.RuleRow{{Line: 1, Rule: replaced}, {Line: 2, Rule: created}}
+
+	t.Run("replaces rules by uuid and creates the rest", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repository := mocks.NewMockCompatibilityRepository(ctrl)
+		repository.EXPECT().ListRules(ctx).Return([]models.CompatibilityRule{existing}, nil)
+		repository.EXPECT().UpsertRules(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, rules []models.CompatibilityRule) error {
+				require.Len(t, rules, 2)
+				require.Equal(t, replaced, rules[0])
+				require.NotEmpty(t, rules[1].UUID)
+				require.Equal(t, inventory_v1.Category_CATEGORY_FUEL, rules[1].Category)
+				return nil
+			})
+
+		report, err := catalog.ImportRules(ctx, repository, rows, false)
+		require.NoError(t, err)
+		require.Equal(t, catalog.RulesReport{Created: 1, Replaced: 1, Written: true}, report)
+	})
+
+	t.Run("dry run writes nothing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repository := mocks.NewMockCompatibilityRepository(ctrl)
+		repository.EXPECT().ListRules(ctx).Return(nil, nil)
+
+		report, err := catalog.ImportRules(ctx, repository, rows, true)
+		require.NoError(t, err)
+		require.Equal(t, catalog.RulesReport{Created: 2}, report)
+	})
+}
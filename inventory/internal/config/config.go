@@ -16,6 +16,9 @@ type config struct {
 	IAMGRPC       IAMGRPCConfig
 	Mongo         MongoConfig
 	Metrics       MetricsConfig
+	Redis         RedisConfig
+	PartCache     PartCacheConfig
+	Kafka         KafkaConfig
 }
 
 // Load загружает конфигурацию из переменных окружения
@@ -50,12 +53,30 @@ func Load(path ...string) error {
 		return err
 	}
 
+	redisCfg, err := env.NewRedisConfig()
+	if err != nil {
+		return err
+	}
+
+	partCacheCfg, err := env.NewPartCacheConfig()
+	if err != nil {
+		return err
+	}
+
+	kafkaCfg, err := env.NewKafkaConfig()
+	if err != nil {
+		return err
+	}
+
 	appConfig = &config{
 		Logger:        loggerCfg,
 		InventoryGRPC: inventoryGRPCCfg,
 		IAMGRPC:       iamGRPCCfg,
 		Mongo:         mongoCfg,
 		Metrics:       metricsCfg,
+		Redis:         redisCfg,
+		PartCache:     partCacheCfg,
+		Kafka:         kafkaCfg,
 	}
 
 	return nil
@@ -1,11 +1,23 @@
 package env
 
-import "os"
+import (
+	"os"
+	"strconv"
+	"time"
+)
 
-const iamGRPCAddressEnv = "IAM_GRPC_ADDRESS"
+const (
+	iamGRPCAddressEnv          = "IAM_GRPC_ADDRESS"
+	iamSessionValidationEnv    = "IAM_SESSION_VALIDATION_ENABLED"
+	iamSessionCacheTTLEnv      = "IAM_SESSION_CACHE_TTL"
+	iamSessionCacheMaxEntryEnv = "IAM_SESSION_CACHE_MAX_ENTRIES"
+)
 
 type iamGRPCConfig struct {
-	address string
+	address                  string
+	sessionValidationEnabled bool
+	sessionCacheTTL          time.Duration
+	sessionCacheMaxEntries   int
 }
 
 func NewIAMGRPCConfig() (*iamGRPCConfig, error) {
@@ -13,11 +25,51 @@ func NewIAMGRPCConfig() (*iamGRPCConfig, error) {
 	if address == "" {
 		address = "localhost:50051"
 	}
+
+	sessionValidationEnabled := true
+	if enabledStr := os.Getenv(iamSessionValidationEnv); enabledStr != "" {
+		parsed, err := strconv.ParseBool(enabledStr)
+		if err == nil {
+			sessionValidationEnabled = parsed
+		}
+	}
+
+	sessionCacheTTL := 30 * time.Second
+	if ttlStr := os.Getenv(iamSessionCacheTTLEnv); ttlStr != "" {
+		parsed, err := time.ParseDuration(ttlStr)
+		if err == nil {
+			sessionCacheTTL = parsed
+		}
+	}
+
+	sessionCacheMaxEntries := 10000
+	if maxEntriesStr := os.Getenv(iamSessionCacheMaxEntryEnv); maxEntriesStr != "" {
+		parsed, err := strconv.Atoi(maxEntriesStr)
+		if err == nil && parsed > 0 {
+			sessionCacheMaxEntries = parsed
+		}
+	}
+
 	return &iamGRPCConfig{
-		address: address,
+		address:                  address,
+		sessionValidationEnabled: sessionValidationEnabled,
+		sessionCacheTTL:          sessionCacheTTL,
+		sessionCacheMaxEntries:   sessionCacheMaxEntries,
 	}, nil
 }
 
 func (c *iamGRPCConfig) Address() string {
 	return c.address
 }
+
+func (c *iamGRPCConfig) SessionValidationEnabled() bool {
+	return c.sessionValidationEnabled
+}
+
+func (c *iamGRPCConfig) SessionCacheTTL() time.Duration {
+	return c.sessionCacheTTL
+}
+
+func (c *iamGRPCConfig) SessionCacheMaxEntries() int {
+	return c.sessionCacheMaxEntries
+}
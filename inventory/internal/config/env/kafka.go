@@ -0,0 +1,59 @@
+package env
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	kafkaBrokersEnv            = "KAFKA_BROKERS"
+	partEventsEnabledEnv       = "PART_EVENTS_ENABLED"
+	partEventsProducerTopicEnv = "PART_EVENTS_PRODUCER_TOPIC"
+	defaultPartEventsTopic     = "inventory.events"
+)
+
+type kafkaConfig struct {
+	brokers           []string
+	partEventsEnabled bool
+	partEventsTopic   string
+}
+
+// NewKafkaConfig создает конфигурацию Kafka из переменных окружения
+func NewKafkaConfig() (*kafkaConfig, error) {
+	brokers := []string{"localhost:9092"}
+	if brokersStr := os.Getenv(kafkaBrokersEnv); brokersStr != "" {
+		brokers = strings.Split(brokersStr, ",")
+	}
+
+	partEventsEnabled := false
+	if enabledStr := os.Getenv(partEventsEnabledEnv); enabledStr != "" {
+		parsed, err := strconv.ParseBool(enabledStr)
+		if err == nil {
+			partEventsEnabled = parsed
+		}
+	}
+
+	partEventsTopic := os.Getenv(partEventsProducerTopicEnv)
+	if partEventsTopic == "" {
+		partEventsTopic = defaultPartEventsTopic
+	}
+
+	return &kafkaConfig{
+		brokers:           brokers,
+		partEventsEnabled: partEventsEnabled,
+		partEventsTopic:   partEventsTopic,
+	}, nil
+}
+
+func (c *kafkaConfig) Brokers() []string {
+	return c.brokers
+}
+
+func (c *kafkaConfig) PartEventsEnabled() bool {
+	return c.partEventsEnabled
+}
+
+func (c *kafkaConfig) PartEventsTopic() string {
+	return c.partEventsTopic
+}
@@ -0,0 +1,49 @@
+package env
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	partCacheEnabledEnv = "PART_CACHE_ENABLED"
+	partCacheTTLEnv     = "PART_CACHE_TTL"
+)
+
+type partCacheConfig struct {
+	enabled bool
+	ttl     time.Duration
+}
+
+// NewPartCacheConfig создает конфигурацию Redis кеша деталей из переменных окружения
+func NewPartCacheConfig() (*partCacheConfig, error) {
+	enabled := false
+	if enabledStr := os.Getenv(partCacheEnabledEnv); enabledStr != "" {
+		parsed, err := strconv.ParseBool(enabledStr)
+		if err == nil {
+			enabled = parsed
+		}
+	}
+
+	ttl := 5 * time.Minute // По умолчанию 5 минут
+	if ttlStr := os.Getenv(partCacheTTLEnv); ttlStr != "" {
+		parsed, err := time.ParseDuration(ttlStr)
+		if err == nil && parsed > 0 {
+			ttl = parsed
+		}
+	}
+
+	return &partCacheConfig{
+		enabled: enabled,
+		ttl:     ttl,
+	}, nil
+}
+
+func (c *partCacheConfig) Enabled() bool {
+	return c.enabled
+}
+
+func (c *partCacheConfig) TTL() time.Duration {
+	return c.ttl
+}
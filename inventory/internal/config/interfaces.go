@@ -1,5 +1,7 @@
 package config
 
+import "time"
+
 // LoggerConfig интерфейс конфигурации логгера
 type LoggerConfig interface {
 	Level() string
@@ -17,6 +19,10 @@ type InventoryGRPCConfig interface {
 // IAMGRPCConfig интерфейс конфигурации gRPC клиента IAM
 type IAMGRPCConfig interface {
 	Address() string
+	// SessionValidationEnabled включает проверку сессий через IAM Whoami; при false проверяется только наличие session-uuid
+	SessionValidationEnabled() bool
+	SessionCacheTTL() time.Duration
+	SessionCacheMaxEntries() int
 }
 
 // MongoConfig интерфейс конфигурации MongoDB
@@ -25,6 +31,34 @@ type MongoConfig interface {
 	DatabaseName() string
 }
 
+// RedisConfig интерфейс конфигурации Redis, в котором кешируются детали
+type RedisConfig interface {
+	Addr() string
+	Password() string
+	DB() int
+	DialTimeout() time.Duration
+	ReadTimeout() time.Duration
+	WriteTimeout() time.Duration
+	PoolSize() int
+}
+
+// PartCacheConfig интерфейс конфигурации кеша деталей
+type PartCacheConfig interface {
+	// Enabled включает кеширование GetPart в Redis; при false Redis не используется
+	Enabled() bool
+	// TTL время жизни детали в кеше
+	TTL() time.Duration
+}
+
+// KafkaConfig интерфейс конфигурации Kafka
+type KafkaConfig interface {
+	Brokers() []string
+	// PartEventsEnabled включает публикацию событий об изменениях деталей; при false Kafka не используется
+	PartEventsEnabled() bool
+	// PartEventsTopic топик событий PartCreated, PartUpdated, PartDeleted и StockChanged
+	PartEventsTopic() string
+}
+
 // MetricsConfig интерфейс конфигурации Prometheus метрик
 type MetricsConfig interface {
 	Port() int
@@ -1,6 +1,7 @@
 package converter
 
 import (
+	"strings"
 	"time"
 
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -104,3 +105,41 @@ func ProtoToPartFilter(protoFilter *inventory_v1.PartsFilter) models.PartFilter
 		Tags:                  protoFilter.Tags,
 	}
 }
+
+// ListPartsRequestToFilter конвертирует фильтр, поиск и ограничения запроса ListParts в модель PartFilter
+func ListPartsRequestToFilter(req *inventory_v1.ListPartsRequest) models.PartFilter {
+	filter := ProtoToPartFilter(req.GetFilter())
+	filter.Query = strings.TrimSpace(req.GetQuery())
+	filter.MinPrice = req.GetMinPrice()
+	filter.MaxPrice = req.GetMaxPrice()
+	filter.InStockOnly = req.GetInStockOnly()
+
+	return filter
+}
+
+// ListPartsRequestToPage конвертирует сортировку и страницу запроса ListParts в модель PartPageRequest
+func ListPartsRequestToPage(req *inventory_v1.ListPartsRequest) models.PartPageRequest {
+	return models.PartPageRequest{
+		SortField:     req.GetSortField(),
+		SortDirection: req.GetSortDirection(),
+		PageSize:      int(req.GetPageSize()),
+		PageToken:     req.GetPageToken(),
+	}
+}
+
+// BuildViolationsToProto конвертирует нарушения правил сборки в proto
+func BuildViolationsToProto(violations []models.BuildViolation) []*inventory_v1.BuildViolation {
+	protoViolations := make([]*inventory_v1.BuildViolation, 0, len(violations))
+	for _, violation := range violations {
+		protoViolations = append(protoViolations, &inventory_v1.BuildViolation{
+			Type:             violation.Type,
+			PartUuids:        violation.PartUUIDs,
+			Category:         violation.Category,
+			RequiredPartUuid: violation.RequiredPartUUID,
+			RuleUuid:         violation.RuleUUID,
+			Message:          violation.Message,
+		})
+	}
+
+	return protoViolations
+}
@@ -0,0 +1,75 @@
+package kafka
+
+import (
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/linemk/rocket-shop/inventory/internal/entyties/events"
+	events_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/events/v1"
+)
+
+// EncodePartCreated кодирует событие PartCreated в конверте InventoryEvent
+func EncodePartCreated(event *events.PartCreatedEvent) ([]byte, error) {
+	return protojson.Marshal(&events_v1.InventoryEvent{
+		Event: &events_v1.InventoryEvent_PartCreated{
+			PartCreated: &events_v1.PartCreated{
+				EventUuid:     event.EventUUID,
+				PartUuid:      event.PartUUID,
+				Name:          event.Name,
+				Category:      event.Category,
+				Price:         event.Price,
+				StockQuantity: event.StockQuantity,
+				Tags:          event.Tags,
+				OccurredAt:    timestamppb.New(event.OccurredAt),
+			},
+		},
+	})
+}
+
+// EncodePartUpdated кодирует событие PartUpdated в конверте InventoryEvent
+func EncodePartUpdated(event *events.PartUpdatedEvent) ([]byte, error) {
+	return protojson.Marshal(&events_v1.InventoryEvent{
+		Event: &events_v1.InventoryEvent_PartUpdated{
+			PartUpdated: &events_v1.PartUpdated{
+				EventUuid:     event.EventUUID,
+				PartUuid:      event.PartUUID,
+				Name:          event.Name,
+				Category:      event.Category,
+				Price:         event.Price,
+				PreviousPrice: event.PreviousPrice,
+				StockQuantity: event.StockQuantity,
+				Tags:          event.Tags,
+				OccurredAt:    timestamppb.New(event.OccurredAt),
+			},
+		},
+	})
+}
+
+// EncodePartDeleted кодирует событие PartDeleted в конверте InventoryEvent
+func EncodePartDeleted(event *events.PartDeletedEvent) ([]byte, error) {
+	return protojson.Marshal(&events_v1.InventoryEvent{
+		Event: &events_v1.InventoryEvent_PartDeleted{
+			PartDeleted: &events_v1.PartDeleted{
+				EventUuid:  event.EventUUID,
+				PartUuid:   event.PartUUID,
+				OccurredAt: timestamppb.New(event.OccurredAt),
+			},
+		},
+	})
+}
+
+// EncodeStockChanged кодирует событие StockChanged в конверте InventoryEvent
+func EncodeStockChanged(event *events.StockChangedEvent) ([]byte, error) {
+	return protojson.Marshal(&events_v1.InventoryEvent{
+		Event: &events_v1.InventoryEvent_StockChanged{
+			StockChanged: &events_v1.StockChanged{
+				EventUuid:        event.EventUUID,
+				PartUuid:         event.PartUUID,
+				PreviousQuantity: event.PreviousQuantity,
+				Quantity:         event.Quantity,
+				Available:        event.Quantity > 0,
+				OccurredAt:       timestamppb.New(event.OccurredAt),
+			},
+		},
+	})
+}
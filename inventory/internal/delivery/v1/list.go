@@ -2,19 +2,25 @@ package v1
 
 import (
 	"context"
+	"errors"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	"github.com/linemk/rocket-shop/inventory/internal/converter"
+	"github.com/linemk/rocket-shop/inventory/internal/entyties/apperrors"
 	inventory_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/inventory/v1"
 )
 
 func (a *API) ListParts(ctx context.Context, req *inventory_v1.ListPartsRequest) (*inventory_v1.ListPartsResponse, error) {
-	filter := converter.ProtoToPartFilter(req.Filter)
+	filter := converter.ListPartsRequestToFilter(req)
+	page := converter.ListPartsRequestToPage(req)
 
-	partInfos, err := a.inventoryUseCase.ListParts(ctx, filter)
+	partInfos, nextPageToken, err := a.inventoryUseCase.ListParts(ctx, filter, page)
 	if err != nil {
+		if errors.Is(err, apperrors.ErrInvalidFilter) || errors.Is(err, apperrors.ErrInvalidPageToken) {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid list parts request: %v", err)
+		}
 		return nil, status.Errorf(codes.Internal, "Failed to list parts: %v", err)
 	}
 
@@ -26,6 +32,7 @@ func (a *API) ListParts(ctx context.Context, req *inventory_v1.ListPartsRequest)
 	}
 
 	return &inventory_v1.ListPartsResponse{
-		Parts: protoParts,
+		Parts:         protoParts,
+		NextPageToken: nextPageToken,
 	}, nil
 }
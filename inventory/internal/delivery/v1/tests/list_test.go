@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	v1 "github.com/linemk/rocket-shop/inventory/internal/delivery/v1"
+	"github.com/linemk/rocket-shop/inventory/internal/entyties/apperrors"
 	"github.com/linemk/rocket-shop/inventory/internal/entyties/models"
 	"github.com/linemk/rocket-shop/inventory/internal/mocks"
 	inventory_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/inventory/v1"
@@ -47,7 +48,7 @@ func TestListPartsAPI(t *testing.T) {
 			fields: fields{
 				useCaseMock: func() *mocks.MockInventoryUseCase {
 					mockUseCase := mocks.NewMockInventoryUseCase(gomock.NewController(t))
-					mockUseCase.EXPECT().ListParts(ctx, gomock.Any()).Return([]models.PartInfo{part1, part2}, nil)
+					mockUseCase.EXPECT().ListParts(ctx, gomock.Any(), gomock.Any()).Return([]models.PartInfo{part1, part2}, "", nil)
 					return mockUseCase
 				},
 			},
@@ -58,12 +59,23 @@ func TestListPartsAPI(t *testing.T) {
 			fields: fields{
 				useCaseMock: func() *mocks.MockInventoryUseCase {
 					mockUseCase := mocks.NewMockInventoryUseCase(gomock.NewController(t))
-					mockUseCase.EXPECT().ListParts(ctx, gomock.Any()).Return([]models.PartInfo{}, nil)
+					mockUseCase.EXPECT().ListParts(ctx, gomock.Any(), gomock.Any()).Return([]models.PartInfo{}, "", nil)
 					return mockUseCase
 				},
 			},
 			wantErr: false,
 		},
+		{
+			name: "invalid page token",
+			fields: fields{
+				useCaseMock: func() *mocks.MockInventoryUseCase {
+					mockUseCase := mocks.NewMockInventoryUseCase(gomock.NewController(t))
+					mockUseCase.EXPECT().ListParts(ctx, gomock.Any(), gomock.Any()).Return(nil, "", apperrors.ErrInvalidPageToken)
+					return mockUseCase
+				},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
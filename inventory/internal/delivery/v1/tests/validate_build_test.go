@@ -0,0 +1,64 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	v1 "github.com/linemk/rocket-shop/inventory/internal/delivery/v1"
+	"github.com/linemk/rocket-shop/inventory/internal/entyties/apperrors"
+	"github.com/linemk/rocket-shop/inventory/internal/entyties/models"
+	"github.com/linemk/rocket-shop/inventory/internal/mocks"
+	inventory_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/inventory/v1"
+)
+
+func TestValidateBuildAPI(t *testing.T) {
+	ctx := context.Background()
+	partUUIDs := []string{uuid.New().String()}
+
+	t.Run("violations are returned in response", func(t *testing.T) {
+		mockUseCase := mocks.NewMockInventoryUseCase(gomock.NewController(t))
+		mockUseCase.EXPECT().ValidateBuild(ctx, partUUIDs).Return([]models.BuildViolation{{
+			Type:     inventory_v1.BuildViolationType_BUILD_VIOLATION_TYPE_MISSING_CATEGORY,
+			Category: inventory_v1.Category_CATEGORY_FUEL,
+			RuleUUID: "r-fuel",
+			Message:  "build needs at least 1 part(s) of category CATEGORY_FUEL, got 0",
+		}}, nil)
+
+		resp, err := v1.NewAPI(mockUseCase).ValidateBuild(ctx, &inventory_v1.ValidateBuildRequest{PartUuids: partUUIDs})
+		require.NoError(t, err)
+		require.False(t, resp.Valid)
+		require.Len(t, resp.Violations, 1)
+		require.Equal(t, inventory_v1.Category_CATEGORY_FUEL, resp.Violations[0].Category)
+		require.Equal(t, "r-fuel", resp.Violations[0].RuleUuid)
+	})
+
+	t.Run("valid build", func(t *testing.T) {
+		mockUseCase := mocks.NewMockInventoryUseCase(gomock.NewController(t))
+		mockUseCase.EXPECT().ValidateBuild(ctx, partUUIDs).Return(nil, nil)
+
+		resp, err := v1.NewAPI(mockUseCase).ValidateBuild(ctx, &inventory_v1.ValidateBuildRequest{PartUuids: partUUIDs})
+		require.NoError(t, err)
+		require.True(t, resp.Valid)
+		require.Empty(t, resp.Violations)
+	})
+
+	t.Run("errors map to grpc codes", func(t *testing.T) {
+		for err, code := range map[error]codes.Code{
+			apperrors.ErrInvalidBuild:       codes.InvalidArgument,
+			errors.New("mongo unavailable"): codes.Internal,
+		} {
+			mockUseCase := mocks.NewMockInventoryUseCase(gomock.NewController(t))
+			mockUseCase.EXPECT().ValidateBuild(ctx, partUUIDs).Return(nil, err)
+
+			_, gotErr := v1.NewAPI(mockUseCase).ValidateBuild(ctx, &inventory_v1.ValidateBuildRequest{PartUuids: partUUIDs})
+			require.Equal(t, code, status.Code(gotErr))
+		}
+	})
+}
@@ -0,0 +1,28 @@
+package v1
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/linemk/rocket-shop/inventory/internal/converter"
+	"github.com/linemk/rocket-shop/inventory/internal/entyties/apperrors"
+	inventory_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/inventory/v1"
+)
+
+func (a *API) ValidateBuild(ctx context.Context, req *inventory_v1.ValidateBuildRequest) (*inventory_v1.ValidateBuildResponse, error) {
+	violations, err := a.inventoryUseCase.ValidateBuild(ctx, req.PartUuids)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrInvalidBuild) {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid build: %v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "Failed to validate build: %v", err)
+	}
+
+	return &inventory_v1.ValidateBuildResponse{
+		Valid:      len(violations) == 0,
+		Violations: converter.BuildViolationsToProto(violations),
+	}, nil
+}
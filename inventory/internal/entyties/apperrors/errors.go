@@ -8,4 +8,6 @@ var (
 	ErrInvalidFilter     = errors.New("invalid filter")
 	ErrInvalidUUID       = errors.New("invalid UUID format")
 	ErrPartAlreadyExists = errors.New("part already exists")
+	ErrInvalidPageToken  = errors.New("invalid page token")
+	ErrInvalidBuild      = errors.New("invalid build")
 )
@@ -0,0 +1,44 @@
+package events
+
+import "time"
+
+// PartCreatedEvent представляет событие о добавлении детали в каталог
+type PartCreatedEvent struct {
+	EventUUID     string
+	PartUUID      string
+	Name          string
+	Category      string
+	Price         float64
+	StockQuantity int64
+	Tags          []string
+	OccurredAt    time.Time
+}
+
+// PartUpdatedEvent представляет событие об изменении детали
+type PartUpdatedEvent struct {
+	EventUUID     string
+	PartUUID      string
+	Name          string
+	Category      string
+	Price         float64
+	PreviousPrice float64
+	StockQuantity int64
+	Tags          []string
+	OccurredAt    time.Time
+}
+
+// PartDeletedEvent представляет событие об удалении детали из каталога
+type PartDeletedEvent struct {
+	EventUUID  string
+	PartUUID   string
+	OccurredAt time.Time
+}
+
+// StockChangedEvent представляет событие об изменении остатка детали на складе
+type StockChangedEvent struct {
+	EventUUID        string
+	PartUUID         string
+	PreviousQuantity int64
+	Quantity         int64
+	OccurredAt       time.Time
+}
@@ -20,6 +20,8 @@ type Part struct {
 	Metadata      map[string]interface{} `bson:"metadata,omitempty"`
 	CreatedAt     time.Time              `bson:"created_at"`
 	UpdatedAt     time.Time              `bson:"updated_at"`
+	// Score релевантность детали поисковому запросу; заполняется только в выборке по текстовому индексу и не хранится
+	Score float64 `bson:"score,omitempty"`
 }
 
 // Dimensions представляет размеры детали
@@ -44,6 +46,57 @@ type PartFilter struct {
 	Categories            []inventory_v1.Category
 	ManufacturerCountries []string
 	Tags                  []string
+
+	// Query полнотекстовый поиск по названию, описанию и тегам
+	Query string
+	// MinPrice и MaxPrice границы цены включительно (0 - без ограничения)
+	MinPrice float64
+	MaxPrice float64
+	// InStockOnly оставляет только детали, которые есть на складе
+	InStockOnly bool
+}
+
+// PartPageRequest порядок и страница списка деталей, запрошенные клиентом
+type PartPageRequest struct {
+	SortField     inventory_v1.PartsSortField
+	SortDirection inventory_v1.SortDirection
+	// PageSize размер страницы (0 - размер по умолчанию)
+	PageSize int
+	// PageToken непрозрачный токен страницы из предыдущего ответа
+	PageToken string
+}
+
+// PartMatchMode способ поиска деталей по запросу PartFilter.Query
+type PartMatchMode int
+
+const (
+	// PartMatchAuto репозиторий выбирает способ сам: текстовый индекс, а если по нему ничего не найдено - начало названия
+	PartMatchAuto PartMatchMode = iota
+	// PartMatchText поиск по целым словам текстового индекса
+	PartMatchText
+	// PartMatchNamePrefix поиск по началу названия без учета регистра
+	PartMatchNamePrefix
+)
+
+// PartCursor последняя деталь предыдущей страницы: выборка продолжается с деталей, идущих после нее
+type PartCursor struct {
+	// Value значение поля сортировки у детали: string для названия, float64 для цены и релевантности,
+	// int64 для количества на складе, time.Time для времени создания
+	Value interface{}
+	UUID  string
+}
+
+// PartListOptions порядок и окно выборки деталей в репозитории
+type PartListOptions struct {
+	SortField     inventory_v1.PartsSortField
+	SortDirection inventory_v1.SortDirection
+	// MatchMode способ поиска по запросу фильтра
+	MatchMode PartMatchMode
+	// After позиция, после которой начинается выборка (nil - с начала)
+	After  *PartCursor
+	Offset int
+	// Limit максимальное число деталей (0 - без ограничения)
+	Limit int
 }
 
 // PartInfo представляет информацию о детали для клиентов
@@ -60,4 +113,47 @@ type PartInfo struct {
 	Metadata      map[string]interface{}
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
+	Score         float64
+}
+
+// CompatibilityRuleType тип правила совместимости деталей
+type CompatibilityRuleType string
+
+const (
+	// CompatibilityRuleIncompatible детали Subject и Object не могут входить в одну сборку
+	CompatibilityRuleIncompatible CompatibilityRuleType = "incompatible"
+	// CompatibilityRuleRequires сборка с деталью Subject должна содержать деталь Object
+	CompatibilityRuleRequires CompatibilityRuleType = "requires"
+	// CompatibilityRuleRequiredCategory сборка должна содержать не меньше MinCount деталей категории Category
+	CompatibilityRuleRequiredCategory CompatibilityRuleType = "required_category"
+)
+
+// PartSelector выбирает конкретную деталь по UUID или все детали категории
+type PartSelector struct {
+	PartUUID string                `bson:"part_uuid,omitempty"`
+	Category inventory_v1.Category `bson:"category,omitempty"`
+}
+
+// CompatibilityRule правило совместимости деталей в сборке ракеты
+type CompatibilityRule struct {
+	UUID string                `bson:"uuid"`
+	Type CompatibilityRuleType `bson:"type"`
+	// Subject и Object детали, связанные правилами incompatible и requires
+	Subject PartSelector `bson:"subject"`
+	Object  PartSelector `bson:"object"`
+	// Category и MinCount требование правила required_category (MinCount 0 означает 1)
+	Category inventory_v1.Category `bson:"category,omitempty"`
+	MinCount int                   `bson:"min_count,omitempty"`
+	// Reason пояснение для клиента; без него сообщение о нарушении формируется из правила
+	Reason string `bson:"reason,omitempty"`
+}
+
+// BuildViolation нарушение правила сборки
+type BuildViolation struct {
+	Type             inventory_v1.BuildViolationType
+	PartUUIDs        []string
+	Category         inventory_v1.Category
+	RequiredPartUUID string
+	RuleUUID         string
+	Message          string
 }
@@ -0,0 +1,11 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// PartCacheMetrics holds metrics of the Redis parts cache
+type PartCacheMetrics struct {
+	// Requests counts cache lookups by result (hit, miss, error)
+	Requests *prometheus.CounterVec
+	// Invalidations counts parts dropped from the cache after update or delete
+	Invalidations *prometheus.CounterVec
+}
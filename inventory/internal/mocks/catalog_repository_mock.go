@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/linemk/rocket-shop/inventory/internal/catalog (interfaces: Repository)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/linemk/rocket-shop/inventory/internal/entyties/models"
+)
+
+// MockCatalogRepository is a mock of Repository interface.
+type MockCatalogRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCatalogRepositoryMockRecorder
+}
+
+// MockCatalogRepositoryMockRecorder is the mock recorder for MockCatalogRepository.
+type MockCatalogRepositoryMockRecorder struct {
+	mock *MockCatalogRepository
+}
+
+// NewMockCatalogRepository creates a new mock instance.
+func NewMockCatalogRepository(ctrl *gomock.Controller) *MockCatalogRepository {
+	mock := &MockCatalogRepository{ctrl: ctrl}
+	mock.recorder = &MockCatalogRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCatalogRepository) EXPECT() *MockCatalogRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ListParts mocks base method.
+func (m *MockCatalogRepository) ListParts(arg0 context.Context, arg1 models.PartFilter, arg2 models.PartListOptions) ([]models.Part, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListParts", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]models.Part)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListParts indicates an expected call of ListParts.
+func (mr *MockCatalogRepositoryMockRecorder) ListParts(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListParts", reflect.TypeOf((*MockCatalogRepository)(nil).ListParts), arg0, arg1, arg2)
+}
+
+// UpsertParts mocks base method.
+func (m *MockCatalogRepository) UpsertParts(arg0 context.Context, arg1 []models.Part) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertParts", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertParts indicates an expected call of UpsertParts.
+func (mr *MockCatalogRepositoryMockRecorder) UpsertParts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertParts", reflect.TypeOf((*MockCatalogRepository)(nil).UpsertParts), arg0, arg1)
+}
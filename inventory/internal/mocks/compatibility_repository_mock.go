@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/linemk/rocket-shop/inventory/internal/repository (interfaces: CompatibilityRepository)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/linemk/rocket-shop/inventory/internal/entyties/models"
+)
+
+// MockCompatibilityRepository is a mock of CompatibilityRepository interface.
+type MockCompatibilityRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCompatibilityRepositoryMockRecorder
+}
+
+// MockCompatibilityRepositoryMockRecorder is the mock recorder for MockCompatibilityRepository.
+type MockCompatibilityRepositoryMockRecorder struct {
+	mock *MockCompatibilityRepository
+}
+
+// NewMockCompatibilityRepository creates a new mock instance.
+func NewMockCompatibilityRepository(ctrl *gomock.Controller) *MockCompatibilityRepository {
+	mock := &MockCompatibilityRepository{ctrl: ctrl}
+	mock.recorder = &MockCompatibilityRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCompatibilityRepository) EXPECT() *MockCompatibilityRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteRule mocks base method.
+func (m *MockCompatibilityRepository) DeleteRule(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRule", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRule indicates an expected call of DeleteRule.
+func (mr *MockCompatibilityRepositoryMockRecorder) DeleteRule(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRule", reflect.TypeOf((*MockCompatibilityRepository)(nil).DeleteRule), arg0, arg1)
+}
+
+// ListRules mocks base method.
+func (m *MockCompatibilityRepository) ListRules(arg0 context.Context) ([]models.CompatibilityRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRules", arg0)
+	ret0, _ := ret[0].([]models.CompatibilityRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRules indicates an expected call of ListRules.
+func (mr *MockCompatibilityRepositoryMockRecorder) ListRules(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRules", reflect.TypeOf((*MockCompatibilityRepository)(nil).ListRules), arg0)
+}
+
+// UpsertRules mocks base method.
+func (m *MockCompatibilityRepository) UpsertRules(arg0 context.Context, arg1 []models.CompatibilityRule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertRules", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertRules indicates an expected call of UpsertRules.
+func (mr *MockCompatibilityRepositoryMockRecorder) UpsertRules(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertRules", reflect.TypeOf((*MockCompatibilityRepository)(nil).UpsertRules), arg0, arg1)
+}
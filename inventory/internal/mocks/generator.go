@@ -2,3 +2,7 @@ package mocks
 
 //go:generate mockgen --package mocks --destination inventory_repository_mock.go github.com/linemk/rocket-shop/inventory/internal/repository InventoryRepository
 //go:generate mockgen --package mocks --destination inventory_usecase_mock.go github.com/linemk/rocket-shop/inventory/internal/usecase InventoryUseCase
+//go:generate mockgen --package mocks --destination cache_client_mock.go github.com/linemk/rocket-shop/platform/pkg/cache Client
+//go:generate mockgen --package mocks --destination part_producer_service_mock.go github.com/linemk/rocket-shop/inventory/internal/service PartProducerService
+//go:generate mockgen --package mocks --destination catalog_repository_mock.go --mock_names Repository=MockCatalogRepository github.com/linemk/rocket-shop/inventory/internal/catalog Repository
+//go:generate mockgen --package mocks --destination compatibility_repository_mock.go github.com/linemk/rocket-shop/inventory/internal/repository CompatibilityRepository
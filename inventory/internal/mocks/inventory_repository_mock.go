@@ -79,26 +79,42 @@ func (mr *MockInventoryRepositoryMockRecorder) GetPart(arg0, arg1 interface{}) *
 }
 
 // ListParts mocks base method.
-func (m *MockInventoryRepository) ListParts(arg0 context.Context, arg1 models.PartFilter) ([]models.Part, error) {
+func (m *MockInventoryRepository) ListParts(arg0 context.Context, arg1 models.PartFilter, arg2 models.PartListOptions) ([]models.Part, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListParts", arg0, arg1)
+	ret := m.ctrl.Call(m, "ListParts", arg0, arg1, arg2)
 	ret0, _ := ret[0].([]models.Part)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListParts indicates an expected call of ListParts.
-func (mr *MockInventoryRepositoryMockRecorder) ListParts(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockInventoryRepositoryMockRecorder) ListParts(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListParts", reflect.TypeOf((*MockInventoryRepository)(nil).ListParts), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListParts", reflect.TypeOf((*MockInventoryRepository)(nil).ListParts), arg0, arg1, arg2)
+}
+
+// ResolveMatchMode mocks base method.
+func (m *MockInventoryRepository) ResolveMatchMode(arg0 context.Context, arg1 models.PartFilter) (models.PartMatchMode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveMatchMode", arg0, arg1)
+	ret0, _ := ret[0].(models.PartMatchMode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveMatchMode indicates an expected call of ResolveMatchMode.
+func (mr *MockInventoryRepositoryMockRecorder) ResolveMatchMode(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveMatchMode", reflect.TypeOf((*MockInventoryRepository)(nil).ResolveMatchMode), arg0, arg1)
 }
 
 // UpdatePart mocks base method.
-func (m *MockInventoryRepository) UpdatePart(arg0 context.Context, arg1 string, arg2 models.Part) error {
+func (m *MockInventoryRepository) UpdatePart(arg0 context.Context, arg1 string, arg2 models.Part) (models.Part, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "UpdatePart", arg0, arg1, arg2)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(models.Part)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // UpdatePart indicates an expected call of UpdatePart.
@@ -106,3 +122,17 @@ func (mr *MockInventoryRepositoryMockRecorder) UpdatePart(arg0, arg1, arg2 inter
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePart", reflect.TypeOf((*MockInventoryRepository)(nil).UpdatePart), arg0, arg1, arg2)
 }
+
+// UpsertParts mocks base method.
+func (m *MockInventoryRepository) UpsertParts(arg0 context.Context, arg1 []models.Part) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertParts", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertParts indicates an expected call of UpsertParts.
+func (mr *MockInventoryRepositoryMockRecorder) UpsertParts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertParts", reflect.TypeOf((*MockInventoryRepository)(nil).UpsertParts), arg0, arg1)
+}
@@ -79,18 +79,19 @@ func (mr *MockInventoryUseCaseMockRecorder) GetPart(arg0, arg1 interface{}) *gom
 }
 
 // ListParts mocks base method.
-func (m *MockInventoryUseCase) ListParts(arg0 context.Context, arg1 models.PartFilter) ([]models.PartInfo, error) {
+func (m *MockInventoryUseCase) ListParts(arg0 context.Context, arg1 models.PartFilter, arg2 models.PartPageRequest) ([]models.PartInfo, string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListParts", arg0, arg1)
+	ret := m.ctrl.Call(m, "ListParts", arg0, arg1, arg2)
 	ret0, _ := ret[0].([]models.PartInfo)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // ListParts indicates an expected call of ListParts.
-func (mr *MockInventoryUseCaseMockRecorder) ListParts(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockInventoryUseCaseMockRecorder) ListParts(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListParts", reflect.TypeOf((*MockInventoryUseCase)(nil).ListParts), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListParts", reflect.TypeOf((*MockInventoryUseCase)(nil).ListParts), arg0, arg1, arg2)
 }
 
 // UpdatePart mocks base method.
@@ -106,3 +107,32 @@ func (mr *MockInventoryUseCaseMockRecorder) UpdatePart(arg0, arg1, arg2 interfac
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePart", reflect.TypeOf((*MockInventoryUseCase)(nil).UpdatePart), arg0, arg1, arg2)
 }
+
+// UpsertParts mocks base method.
+func (m *MockInventoryUseCase) UpsertParts(arg0 context.Context, arg1 []models.Part) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertParts", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertParts indicates an expected call of UpsertParts.
+func (mr *MockInventoryUseCaseMockRecorder) UpsertParts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertParts", reflect.TypeOf((*MockInventoryUseCase)(nil).UpsertParts), arg0, arg1)
+}
+
+// ValidateBuild mocks base method.
+func (m *MockInventoryUseCase) ValidateBuild(arg0 context.Context, arg1 []string) ([]models.BuildViolation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateBuild", arg0, arg1)
+	ret0, _ := ret[0].([]models.BuildViolation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateBuild indicates an expected call of ValidateBuild.
+func (mr *MockInventoryUseCaseMockRecorder) ValidateBuild(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateBuild", reflect.TypeOf((*MockInventoryUseCase)(nil).ValidateBuild), arg0, arg1)
+}
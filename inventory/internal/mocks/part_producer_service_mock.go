@@ -0,0 +1,92 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/linemk/rocket-shop/inventory/internal/service (interfaces: PartProducerService)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	events "github.com/linemk/rocket-shop/inventory/internal/entyties/events"
+)
+
+// MockPartProducerService is a mock of PartProducerService interface.
+type MockPartProducerService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPartProducerServiceMockRecorder
+}
+
+// MockPartProducerServiceMockRecorder is the mock recorder for MockPartProducerService.
+type MockPartProducerServiceMockRecorder struct {
+	mock *MockPartProducerService
+}
+
+// NewMockPartProducerService creates a new mock instance.
+func NewMockPartProducerService(ctrl *gomock.Controller) *MockPartProducerService {
+	mock := &MockPartProducerService{ctrl: ctrl}
+	mock.recorder = &MockPartProducerServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPartProducerService) EXPECT() *MockPartProducerServiceMockRecorder {
+	return m.recorder
+}
+
+// SendPartCreated mocks base method.
+func (m *MockPartProducerService) SendPartCreated(arg0 context.Context, arg1 *events.PartCreatedEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendPartCreated", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendPartCreated indicates an expected call of SendPartCreated.
+func (mr *MockPartProducerServiceMockRecorder) SendPartCreated(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendPartCreated", reflect.TypeOf((*MockPartProducerService)(nil).SendPartCreated), arg0, arg1)
+}
+
+// SendPartDeleted mocks base method.
+func (m *MockPartProducerService) SendPartDeleted(arg0 context.Context, arg1 *events.PartDeletedEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendPartDeleted", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendPartDeleted indicates an expected call of SendPartDeleted.
+func (mr *MockPartProducerServiceMockRecorder) SendPartDeleted(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendPartDeleted", reflect.TypeOf((*MockPartProducerService)(nil).SendPartDeleted), arg0, arg1)
+}
+
+// SendPartUpdated mocks base method.
+func (m *MockPartProducerService) SendPartUpdated(arg0 context.Context, arg1 *events.PartUpdatedEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendPartUpdated", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendPartUpdated indicates an expected call of SendPartUpdated.
+func (mr *MockPartProducerServiceMockRecorder) SendPartUpdated(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendPartUpdated", reflect.TypeOf((*MockPartProducerService)(nil).SendPartUpdated), arg0, arg1)
+}
+
+// SendStockChanged mocks base method.
+func (m *MockPartProducerService) SendStockChanged(arg0 context.Context, arg1 *events.StockChangedEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendStockChanged", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendStockChanged indicates an expected call of SendStockChanged.
+func (mr *MockPartProducerServiceMockRecorder) SendStockChanged(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendStockChanged", reflect.TypeOf((*MockPartProducerService)(nil).SendStockChanged), arg0, arg1)
+}
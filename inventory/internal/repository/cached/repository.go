@@ -0,0 +1,197 @@
+package cached
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
+
+	"github.com/linemk/rocket-shop/inventory/internal/entyties/models"
+	"github.com/linemk/rocket-shop/inventory/internal/metrics"
+	"github.com/linemk/rocket-shop/inventory/internal/repository"
+	"github.com/linemk/rocket-shop/platform/pkg/cache"
+	rediscache "github.com/linemk/rocket-shop/platform/pkg/cache/redis"
+	"github.com/linemk/rocket-shop/platform/pkg/logger"
+)
+
+const (
+	partKeyPrefix = "inventory:part:"
+
+	// loadTimeout ограничивает общую загрузку детали: она не отменяется вместе с запросом, который ее начал
+	loadTimeout = 5 * time.Second
+
+	resultHit   = "hit"
+	resultMiss  = "miss"
+	resultError = "error"
+)
+
+// Repository кеширует детали в Redis поверх основного репозитория (read-through).
+// Кеш только ускоряет чтение: при его недоступности запросы обслуживает основной репозиторий
+type Repository struct {
+	next    repository.InventoryRepository
+	cache   cache.Client
+	ttl     time.Duration
+	metrics *metrics.PartCacheMetrics
+	// group объединяет одновременные промахи по одной детали в один запрос к основному репозиторию
+	group singleflight.Group
+}
+
+var _ repository.InventoryRepository = (*Repository)(nil)
+
+// NewRepository создает кеширующий репозиторий; ttl задает время жизни детали в кеше
+func NewRepository(next repository.InventoryRepository, cacheClient cache.Client, ttl time.Duration, partCacheMetrics *metrics.PartCacheMetrics) *Repository {
+	return &Repository{
+		next:    next,
+		cache:   cacheClient,
+		ttl:     ttl,
+		metrics: partCacheMetrics,
+	}
+}
+
+// GetPart возвращает деталь из кеша, а при промахе загружает ее из основного репозитория и кеширует.
+// Ошибки не кешируются: отсутствующая деталь всегда ищется в основном репозитории
+func (r *Repository) GetPart(ctx context.Context, uuid string) (models.Part, error) {
+	key := partKey(uuid)
+
+	value, err := r.cache.Get(ctx, key)
+	switch {
+	case err == nil:
+		var part models.Part
+		if err := json.Unmarshal(value, &part); err == nil {
+			r.observe(resultHit)
+			return part, nil
+		}
+		logger.Warn(ctx, "Failed to decode cached part", zap.String("part_uuid", uuid), zap.Error(err))
+		r.observe(resultError)
+	case errors.Is(err, rediscache.ErrKeyNotFound):
+		r.observe(resultMiss)
+	default:
+		logger.Warn(ctx, "Failed to read part from cache", zap.String("part_uuid", uuid), zap.Error(err))
+		r.observe(resultError)
+	}
+
+	// Загрузку разделяют все ожидающие запросы, поэтому она выполняется без отмены первого из них,
+	// а каждый запрос ждет ее не дольше собственного контекста
+	loaded := r.group.DoChan(key, func() (interface{}, error) {
+		loadCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), loadTimeout)
+		defer cancel()
+
+		part, err := r.next.GetPart(loadCtx, uuid)
+		if err != nil {
+			return models.Part{}, err
+		}
+
+		r.store(loadCtx, key, part)
+
+		return part, nil
+	})
+
+	select {
+	case result := <-loaded:
+		if result.Err != nil {
+			return models.Part{}, result.Err
+		}
+		return result.Val.(models.Part), nil
+	case <-ctx.Done():
+		return models.Part{}, ctx.Err()
+	}
+}
+
+// ListParts не кешируется: выборки зависят от фильтра, сортировки и страницы
+func (r *Repository) ListParts(ctx context.Context, filter models.PartFilter, opts models.PartListOptions) ([]models.Part, error) {
+	return r.next.ListParts(ctx, filter, opts)
+}
+
+func (r *Repository) ResolveMatchMode(ctx context.Context, filter models.PartFilter) (models.PartMatchMode, error) {
+	return r.next.ResolveMatchMode(ctx, filter)
+}
+
+func (r *Repository) CreatePart(ctx context.Context, part models.Part) error {
+	return r.next.CreatePart(ctx, part)
+}
+
+func (r *Repository) UpdatePart(ctx context.Context, uuid string, part models.Part) (models.Part, error) {
+	// Прежнее состояние берется из основного хранилища, а не из кеша
+	previous, err := r.next.UpdatePart(ctx, uuid, part)
+	if err != nil {
+		return models.Part{}, err
+	}
+
+	r.invalidate(ctx, uuid)
+
+	return previous, nil
+}
+
+func (r *Repository) DeletePart(ctx context.Context, uuid string) error {
+	if err := r.next.DeletePart(ctx, uuid); err != nil {
+		return err
+	}
+
+	r.invalidate(ctx, uuid)
+
+	return nil
+}
+
+func (r *Repository) UpsertParts(ctx context.Context, parts []models.Part) error {
+	if err := r.next.UpsertParts(ctx, parts); err != nil {
+		return err
+	}
+
+	uuids := make([]string, 0, len(parts))
+	for _, part := range parts {
+		uuids = append(uuids, part.UUID)
+	}
+	r.invalidate(ctx, uuids...)
+
+	return nil
+}
+
+// store сохраняет деталь в кеш. Ошибка только логируется: деталь будет загружена при следующем запросе
+func (r *Repository) store(ctx context.Context, key string, part models.Part) {
+	value, err := json.Marshal(part)
+	if err != nil {
+		logger.Warn(ctx, "Failed to encode part for cache", zap.String("part_uuid", part.UUID), zap.Error(err))
+		return
+	}
+
+	if err := r.cache.Set(ctx, key, value, r.ttl); err != nil {
+		logger.Warn(ctx, "Failed to write part to cache", zap.String("part_uuid", part.UUID), zap.Error(err))
+	}
+}
+
+// invalidate удаляет детали из кеша после изменения. Загрузка, начатая до изменения, больше не
+// переиспользуется новыми запросами; если она все же успеет записать старую версию, та истечет по TTL
+func (r *Repository) invalidate(ctx context.Context, uuids ...string) {
+	if len(uuids) == 0 {
+		return
+	}
+
+	keys := make([]string, 0, len(uuids))
+	for _, uuid := range uuids {
+		key := partKey(uuid)
+		r.group.Forget(key)
+		keys = append(keys, key)
+	}
+
+	if err := r.cache.Del(ctx, keys...); err != nil {
+		logger.Error(ctx, "Failed to invalidate cached parts", zap.Strings("part_uuids", uuids), zap.Error(err))
+		return
+	}
+
+	if r.metrics != nil {
+		r.metrics.Invalidations.WithLabelValues().Add(float64(len(keys)))
+	}
+}
+
+func (r *Repository) observe(result string) {
+	if r.metrics != nil {
+		r.metrics.Requests.WithLabelValues(result).Inc()
+	}
+}
+
+func partKey(uuid string) string {
+	return partKeyPrefix + uuid
+}
@@ -0,0 +1,93 @@
+package compatibility
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.uber.org/zap"
+
+	"github.com/linemk/rocket-shop/inventory/internal/entyties/models"
+	"github.com/linemk/rocket-shop/platform/pkg/logger"
+)
+
+// MongoRepository представляет MongoDB репозиторий для правил совместимости
+type MongoRepository struct {
+	collection *mongo.Collection
+}
+
+// NewMongoRepository создает новый MongoDB репозиторий правил совместимости
+func NewMongoRepository(ctx context.Context, db *mongo.Database) *MongoRepository {
+	collection := db.Collection("compatibility_rules")
+
+	indexCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	_, err := collection.Indexes().CreateOne(indexCtx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "uuid", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		logger.Error(ctx, "Failed to create index", zap.Error(err))
+		panic(err)
+	}
+
+	return &MongoRepository{
+		collection: collection,
+	}
+}
+
+// ListRules возвращает все правила совместимости. Правил немного, поэтому они читаются целиком
+func (r *MongoRepository) ListRules(ctx context.Context) ([]models.CompatibilityRule, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "uuid", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := cursor.Close(ctx); err != nil {
+			logger.Warn(ctx, "cursor close error", zap.Error(err))
+		}
+	}()
+
+	var rules []models.CompatibilityRule
+	if err := cursor.All(ctx, &rules); err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+// UpsertRules создает или заменяет правила одной пачкой; правило определяется по UUID
+func (r *MongoRepository) UpsertRules(ctx context.Context, rules []models.CompatibilityRule) error {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	writes := make([]mongo.WriteModel, 0, len(rules))
+	for _, rule := range rules {
+		writes = append(writes, mongo.NewReplaceOneModel().
+			SetFilter(bson.M{"uuid": rule.UUID}).
+			SetReplacement(rule).
+			SetUpsert(true))
+	}
+
+	_, err := r.collection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	return err
+}
+
+// DeleteRule удаляет правило по UUID
+func (r *MongoRepository) DeleteRule(ctx context.Context, uuid string) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"uuid": uuid})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return errors.New("rule not found")
+	}
+
+	return nil
+}
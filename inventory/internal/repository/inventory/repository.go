@@ -1,9 +1,13 @@
 package inventory
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/linemk/rocket-shop/inventory/internal/entyties/models"
 	inventory_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/inventory/v1"
@@ -32,29 +36,54 @@ func (r *Repository) GetPart(ctx context.Context, uuid string) (models.Part, err
 	return part, nil
 }
 
-func (r *Repository) ListParts(ctx context.Context, filter models.PartFilter) ([]models.Part, error) {
+func (r *Repository) ListParts(ctx context.Context, filter models.PartFilter, opts models.PartListOptions) ([]models.Part, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	// Если фильтр пустой, возвращаем все детали
-	if isEmptyFilter(filter) {
-		result := make([]models.Part, 0, len(r.parts))
-		for _, part := range r.parts {
-			result = append(result, part)
-		}
-		return result, nil
+	mode := opts.MatchMode
+	if mode == models.PartMatchAuto {
+		mode = r.resolveMatchMode(filter)
 	}
 
 	// Применяем фильтрацию
-	candidates := r.applyFilters(r.parts, filter)
+	candidates := r.applyFilters(r.parts, filter, mode)
 
 	// Преобразуем результат в слайс
+	textSearch := mode == models.PartMatchText && len(queryTerms(filter.Query)) > 0
 	result := make([]models.Part, 0, len(candidates))
 	for _, part := range candidates {
+		if textSearch {
+			part.Score = float64(relevance(part, filter.Query))
+		}
 		result = append(result, part)
 	}
 
-	return result, nil
+	field, desc := sortOrder(opts, textSearch)
+	sortParts(result, field, desc)
+	if opts.After != nil {
+		result = afterCursor(result, opts.After, field, desc)
+	}
+
+	return paginate(result, opts.Offset, opts.Limit), nil
+}
+
+func (r *Repository) ResolveMatchMode(ctx context.Context, filter models.PartFilter) (models.PartMatchMode, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.resolveMatchMode(filter), nil
+}
+
+// resolveMatchMode повторяет выбор MongoDB репозитория: поиск по словам, а если по ним ничего не найдено - по началу названия
+func (r *Repository) resolveMatchMode(filter models.PartFilter) models.PartMatchMode {
+	if filter.Query == "" {
+		return models.PartMatchAuto
+	}
+
+	if len(r.applyFilters(r.parts, filter, models.PartMatchText)) > 0 {
+		return models.PartMatchText
+	}
+	return models.PartMatchNamePrefix
 }
 
 func (r *Repository) CreatePart(ctx context.Context, part models.Part) error {
@@ -69,16 +98,17 @@ func (r *Repository) CreatePart(ctx context.Context, part models.Part) error {
 	return nil
 }
 
-func (r *Repository) UpdatePart(ctx context.Context, uuid string, part models.Part) error {
+func (r *Repository) UpdatePart(ctx context.Context, uuid string, part models.Part) (models.Part, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	if _, exists := r.parts[uuid]; !exists {
-		return fmt.Errorf("part with UUID %s not found", uuid)
+	previous, exists := r.parts[uuid]
+	if !exists {
+		return models.Part{}, fmt.Errorf("part with UUID %s not found", uuid)
 	}
 
 	r.parts[uuid] = part
-	return nil
+	return previous, nil
 }
 
 func (r *Repository) DeletePart(ctx context.Context, uuid string) error {
@@ -93,8 +123,18 @@ func (r *Repository) DeletePart(ctx context.Context, uuid string) error {
 	return nil
 }
 
+func (r *Repository) UpsertParts(ctx context.Context, parts []models.Part) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, part := range parts {
+		r.parts[part.UUID] = part
+	}
+	return nil
+}
+
 // applyFilters применяет все фильтры к деталям
-func (r *Repository) applyFilters(parts map[string]models.Part, filter models.PartFilter) map[string]models.Part {
+func (r *Repository) applyFilters(parts map[string]models.Part, filter models.PartFilter, mode models.PartMatchMode) map[string]models.Part {
 	candidates := make(map[string]models.Part)
 
 	// Копируем все детали как кандидаты
@@ -108,6 +148,9 @@ func (r *Repository) applyFilters(parts map[string]models.Part, filter models.Pa
 	candidates = r.filterByCategories(candidates, filter.Categories)
 	candidates = r.filterByManufacturerCountries(candidates, filter.ManufacturerCountries)
 	candidates = r.filterByTags(candidates, filter.Tags)
+	candidates = r.filterByQuery(candidates, filter.Query, mode)
+	candidates = r.filterByPrice(candidates, filter.MinPrice, filter.MaxPrice)
+	candidates = r.filterByStock(candidates, filter.InStockOnly)
 
 	return candidates
 }
@@ -205,11 +248,168 @@ func (r *Repository) filterByTags(candidates map[string]models.Part, tags []stri
 	return filtered
 }
 
-// isEmptyFilter проверяет, пустой ли фильтр
-func isEmptyFilter(filter models.PartFilter) bool {
-	return len(filter.UUIDs) == 0 &&
-		len(filter.Names) == 0 &&
-		len(filter.Categories) == 0 &&
-		len(filter.ManufacturerCountries) == 0 &&
-		len(filter.Tags) == 0
+// filterByQuery оставляет детали, в названии, описании или тегах которых встречается хотя бы одно слово запроса,
+// а при поиске по началу названия - детали, название которых начинается с запроса
+func (r *Repository) filterByQuery(candidates map[string]models.Part, query string, mode models.PartMatchMode) map[string]models.Part {
+	if len(queryTerms(query)) == 0 {
+		return candidates
+	}
+
+	prefix := strings.ToLower(strings.TrimSpace(query))
+
+	filtered := make(map[string]models.Part)
+	for uuid, part := range candidates {
+		if mode == models.PartMatchNamePrefix {
+			if strings.HasPrefix(strings.ToLower(part.Name), prefix) {
+				filtered[uuid] = part
+			}
+			continue
+		}
+		if relevance(part, query) > 0 {
+			filtered[uuid] = part
+		}
+	}
+	return filtered
+}
+
+// filterByPrice фильтрует детали по границам цены
+func (r *Repository) filterByPrice(candidates map[string]models.Part, minPrice, maxPrice float64) map[string]models.Part {
+	if minPrice == 0 && maxPrice == 0 {
+		return candidates
+	}
+
+	filtered := make(map[string]models.Part)
+	for uuid, part := range candidates {
+		if part.Price < minPrice || (maxPrice > 0 && part.Price > maxPrice) {
+			continue
+		}
+		filtered[uuid] = part
+	}
+	return filtered
+}
+
+// filterByStock оставляет детали, которые есть на складе
+func (r *Repository) filterByStock(candidates map[string]models.Part, inStockOnly bool) map[string]models.Part {
+	if !inStockOnly {
+		return candidates
+	}
+
+	filtered := make(map[string]models.Part)
+	for uuid, part := range candidates {
+		if part.StockQuantity > 0 {
+			filtered[uuid] = part
+		}
+	}
+	return filtered
+}
+
+// queryTerms разбивает поисковый запрос на слова без учета регистра
+func queryTerms(query string) []string {
+	return strings.Fields(strings.ToLower(query))
+}
+
+// relevance приближает текстовый индекс MongoDB: считает вхождения слов запроса с весами полей
+func relevance(part models.Part, query string) int {
+	name := strings.ToLower(part.Name)
+	description := strings.ToLower(part.Description)
+
+	score := 0
+	for _, term := range queryTerms(query) {
+		if strings.Contains(name, term) {
+			score += nameWeight
+		}
+		for _, tag := range part.Tags {
+			if strings.Contains(strings.ToLower(tag), term) {
+				score += tagsWeight
+				break
+			}
+		}
+		if strings.Contains(description, term) {
+			score += descriptionWeight
+		}
+	}
+	return score
+}
+
+// sortOrder возвращает поле и направление сортировки; как и в MongoDB, без поиска по словам
+// релевантность не вычисляется, и детали упорядочиваются по названию
+func sortOrder(opts models.PartListOptions, textSearch bool) (inventory_v1.PartsSortField, bool) {
+	if opts.SortField == inventory_v1.PartsSortField_PARTS_SORT_FIELD_RELEVANCE {
+		if !textSearch {
+			return inventory_v1.PartsSortField_PARTS_SORT_FIELD_NAME, false
+		}
+		// Релевантность всегда сортируется по убыванию
+		return opts.SortField, true
+	}
+
+	return opts.SortField, opts.SortDirection == inventory_v1.SortDirection_SORT_DIRECTION_DESC
+}
+
+// compareParts сравнивает детали по полю сортировки; при равенстве значений - по UUID, чтобы страницы не пересекались
+func compareParts(a, b models.Part, field inventory_v1.PartsSortField, desc bool) int {
+	var order int
+	switch field {
+	case inventory_v1.PartsSortField_PARTS_SORT_FIELD_PRICE:
+		order = cmp.Compare(a.Price, b.Price)
+	case inventory_v1.PartsSortField_PARTS_SORT_FIELD_STOCK_QUANTITY:
+		order = cmp.Compare(a.StockQuantity, b.StockQuantity)
+	case inventory_v1.PartsSortField_PARTS_SORT_FIELD_CREATED_AT:
+		order = a.CreatedAt.Compare(b.CreatedAt)
+	case inventory_v1.PartsSortField_PARTS_SORT_FIELD_RELEVANCE:
+		order = cmp.Compare(a.Score, b.Score)
+	default:
+		order = strings.Compare(a.Name, b.Name)
+	}
+
+	if desc {
+		order = -order
+	}
+	if order != 0 {
+		return order
+	}
+	return strings.Compare(a.UUID, b.UUID)
+}
+
+// sortParts упорядочивает детали по полю сортировки
+func sortParts(parts []models.Part, field inventory_v1.PartsSortField, desc bool) {
+	sort.Slice(parts, func(i, j int) bool {
+		return compareParts(parts[i], parts[j], field, desc) < 0
+	})
+}
+
+// afterCursor оставляет упорядоченные детали, идущие после детали курсора
+func afterCursor(parts []models.Part, cursor *models.PartCursor, field inventory_v1.PartsSortField, desc bool) []models.Part {
+	anchor := models.Part{UUID: cursor.UUID}
+	switch value := cursor.Value.(type) {
+	case string:
+		anchor.Name = value
+	case int64:
+		anchor.StockQuantity = value
+	case time.Time:
+		anchor.CreatedAt = value
+	case float64:
+		if field == inventory_v1.PartsSortField_PARTS_SORT_FIELD_RELEVANCE {
+			anchor.Score = value
+		} else {
+			anchor.Price = value
+		}
+	}
+
+	idx := sort.Search(len(parts), func(i int) bool {
+		return compareParts(parts[i], anchor, field, desc) > 0
+	})
+	return parts[idx:]
+}
+
+// paginate возвращает окно выборки [offset, offset+limit); limit 0 - до конца списка
+func paginate(parts []models.Part, offset, limit int) []models.Part {
+	if offset >= len(parts) {
+		return []models.Part{}
+	}
+
+	parts = parts[offset:]
+	if limit > 0 && limit < len(parts) {
+		parts = parts[:limit]
+	}
+	return parts
 }
@@ -3,6 +3,8 @@ package inventory
 import (
 	"context"
 	"errors"
+	"regexp"
+	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -12,6 +14,14 @@ import (
 
 	"github.com/linemk/rocket-shop/inventory/internal/entyties/models"
 	"github.com/linemk/rocket-shop/platform/pkg/logger"
+	inventory_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/inventory/v1"
+)
+
+// Веса полей текстового индекса: совпадение в названии важнее совпадения в тегах и описании
+const (
+	nameWeight        = 10
+	tagsWeight        = 5
+	descriptionWeight = 1
 )
 
 // MongoRepository представляет MongoDB репозиторий для деталей
@@ -41,6 +51,25 @@ func NewMongoRepository(ctx context.Context, db *mongo.Database) *MongoRepositor
 		{
 			Keys: bson.D{{Key: "tags", Value: 1}},
 		},
+		{
+			Keys: bson.D{{Key: "price", Value: 1}},
+		},
+		{
+			Keys: bson.D{
+				{Key: "name", Value: "text"},
+				{Key: "description", Value: "text"},
+				{Key: "tags", Value: "text"},
+			},
+			// Каталог смешивает русские и английские названия, поэтому стемминг отключен
+			Options: options.Index().
+				SetName("parts_text").
+				SetDefaultLanguage("none").
+				SetWeights(bson.D{
+					{Key: "name", Value: nameWeight},
+					{Key: "tags", Value: tagsWeight},
+					{Key: "description", Value: descriptionWeight},
+				}),
+		},
 	}
 
 	_, err := collection.Indexes().CreateMany(indexCtx, indexModels)
@@ -68,9 +97,85 @@ func (r *MongoRepository) GetPart(ctx context.Context, uuid string) (models.Part
 	return part, nil
 }
 
-// ListParts возвращает список деталей с применением фильтров
-func (r *MongoRepository) ListParts(ctx context.Context, filter models.PartFilter) ([]models.Part, error) {
-	// Формируем фильтр для MongoDB
+// ListParts возвращает список деталей с применением фильтров, сортировки и окна выборки
+func (r *MongoRepository) ListParts(ctx context.Context, filter models.PartFilter, opts models.PartListOptions) ([]models.Part, error) {
+	mongoFilter := mongoPartFilter(filter)
+
+	textSearch := false
+	if filter.Query != "" {
+		mode := opts.MatchMode
+		if mode == models.PartMatchAuto {
+			var err error
+			mode, err = r.ResolveMatchMode(ctx, filter)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		textSearch = mode == models.PartMatchText
+		applyQuery(mongoFilter, filter.Query, mode)
+	}
+
+	sort := mongoSort(opts, textSearch)
+
+	pipeline := mongo.Pipeline{{{Key: "$match", Value: mongoFilter}}}
+	if textSearch {
+		// Релевантность сохраняется в выборке: по ней сортируются детали и продолжается следующая страница
+		pipeline = append(pipeline, bson.D{{Key: "$addFields", Value: bson.M{"score": bson.M{"$meta": "textScore"}}}})
+	}
+	if opts.After != nil {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: mongoAfterCursor(sort, opts.After)}})
+	}
+	pipeline = append(pipeline, bson.D{{Key: "$sort", Value: sort}})
+	if opts.Offset > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$skip", Value: int64(opts.Offset)}})
+	}
+	if opts.Limit > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$limit", Value: int64(opts.Limit)}})
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := cursor.Close(ctx); err != nil {
+			logger.Warn(ctx, "cursor close error", zap.Error(err))
+		}
+	}()
+
+	var parts []models.Part
+	if err := cursor.All(ctx, &parts); err != nil {
+		return nil, err
+	}
+
+	return parts, nil
+}
+
+// ResolveMatchMode выбирает текстовый индекс, если по нему находится хотя бы одна деталь.
+// $text находит только целые слова, поэтому иначе детали ищутся по началу названия без учета регистра:
+// так неполное слово ("quas") находит "Quasar stabilizer"
+func (r *MongoRepository) ResolveMatchMode(ctx context.Context, filter models.PartFilter) (models.PartMatchMode, error) {
+	if filter.Query == "" {
+		return models.PartMatchAuto, nil
+	}
+
+	mongoFilter := mongoPartFilter(filter)
+	mongoFilter["$text"] = bson.M{"$search": filter.Query}
+
+	err := r.collection.FindOne(ctx, mongoFilter, options.FindOne().SetProjection(bson.M{"_id": 1})).Err()
+	if err == nil {
+		return models.PartMatchText, nil
+	}
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		return models.PartMatchAuto, err
+	}
+
+	return models.PartMatchNamePrefix, nil
+}
+
+// mongoPartFilter переводит фильтр деталей, кроме поискового запроса, в фильтр MongoDB
+func mongoPartFilter(filter models.PartFilter) bson.M {
 	mongoFilter := bson.M{}
 
 	if len(filter.UUIDs) > 0 {
@@ -98,22 +203,85 @@ func (r *MongoRepository) ListParts(ctx context.Context, filter models.PartFilte
 		mongoFilter["tags"] = bson.M{"$in": filter.Tags}
 	}
 
-	cursor, err := r.collection.Find(ctx, mongoFilter)
-	if err != nil {
-		return nil, err
+	if filter.MinPrice > 0 || filter.MaxPrice > 0 {
+		price := bson.M{"$gte": filter.MinPrice}
+		if filter.MaxPrice > 0 {
+			price["$lte"] = filter.MaxPrice
+		}
+		mongoFilter["price"] = price
 	}
-	defer func() {
-		if err := cursor.Close(ctx); err != nil {
-			logger.Warn(ctx, "cursor close error", zap.Error(err))
+
+	if filter.InStockOnly {
+		mongoFilter["stock_quantity"] = bson.M{"$gt": 0}
+	}
+
+	return mongoFilter
+}
+
+// applyQuery добавляет в фильтр поисковый запрос выбранным способом поиска
+func applyQuery(mongoFilter bson.M, query string, mode models.PartMatchMode) {
+	if mode == models.PartMatchText {
+		mongoFilter["$text"] = bson.M{"$search": query}
+		return
+	}
+
+	name, ok := mongoFilter["name"].(bson.M)
+	if !ok {
+		name = bson.M{}
+	}
+	name["$regex"] = "^" + regexp.QuoteMeta(strings.TrimSpace(query))
+	name["$options"] = "i"
+	mongoFilter["name"] = name
+}
+
+// mongoSort формирует порядок выборки; при равенстве значений детали упорядочиваются по UUID,
+// чтобы страницы не пересекались. Без текстового индекса релевантность не вычисляется,
+// и найденные по началу названия детали упорядочиваются по названию
+func mongoSort(opts models.PartListOptions, textSearch bool) bson.D {
+	if opts.SortField == inventory_v1.PartsSortField_PARTS_SORT_FIELD_RELEVANCE && textSearch {
+		// Релевантность сортируется только по убыванию
+		return bson.D{
+			{Key: "score", Value: -1},
+			{Key: "uuid", Value: 1},
 		}
-	}()
+	}
 
-	var parts []models.Part
-	if err := cursor.All(ctx, &parts); err != nil {
-		return nil, err
+	field := "name"
+	switch opts.SortField {
+	case inventory_v1.PartsSortField_PARTS_SORT_FIELD_PRICE:
+		field = "price"
+	case inventory_v1.PartsSortField_PARTS_SORT_FIELD_STOCK_QUANTITY:
+		field = "stock_quantity"
+	case inventory_v1.PartsSortField_PARTS_SORT_FIELD_CREATED_AT:
+		field = "created_at"
 	}
 
-	return parts, nil
+	direction := 1
+	if opts.SortDirection == inventory_v1.SortDirection_SORT_DIRECTION_DESC &&
+		opts.SortField != inventory_v1.PartsSortField_PARTS_SORT_FIELD_RELEVANCE {
+		direction = -1
+	}
+
+	return bson.D{
+		{Key: field, Value: direction},
+		{Key: "uuid", Value: 1},
+	}
+}
+
+// mongoAfterCursor условие выборки деталей, идущих в порядке sort после детали курсора.
+// В отличие от пропуска смещения, позиция не сдвигается, когда перед ней добавляются или удаляются детали
+func mongoAfterCursor(sort bson.D, cursor *models.PartCursor) bson.M {
+	field := sort[0].Key
+
+	op := "$gt"
+	if sort[0].Value == -1 {
+		op = "$lt"
+	}
+
+	return bson.M{"$or": bson.A{
+		bson.M{field: bson.M{op: cursor.Value}},
+		bson.M{field: cursor.Value, "uuid": bson.M{"$gt": cursor.UUID}},
+	}}
 }
 
 // CreatePart создает новую деталь
@@ -130,23 +298,45 @@ func (r *MongoRepository) CreatePart(ctx context.Context, part models.Part) erro
 }
 
 // UpdatePart обновляет существующую деталь
-func (r *MongoRepository) UpdatePart(ctx context.Context, uuid string, part models.Part) error {
+func (r *MongoRepository) UpdatePart(ctx context.Context, uuid string, part models.Part) (models.Part, error) {
 	part.UpdatedAt = time.Now()
 
-	result, err := r.collection.UpdateOne(
+	// Прежнее состояние возвращается той же атомарной операцией, что и запись,
+	// поэтому оно не может разойтись с тем, что заменило обновление
+	var previous models.Part
+	err := r.collection.FindOneAndUpdate(
 		ctx,
 		bson.M{"uuid": uuid},
 		bson.M{"$set": part},
-	)
+		options.FindOneAndUpdate().SetReturnDocument(options.Before),
+	).Decode(&previous)
 	if err != nil {
-		return err
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return models.Part{}, errors.New("part not found")
+		}
+		return models.Part{}, err
 	}
 
-	if result.MatchedCount == 0 {
-		return errors.New("part not found")
+	return previous, nil
+}
+
+// UpsertParts создает или заменяет детали одной пачкой; деталь определяется по UUID.
+// Время создания и обновления задает вызывающий код
+func (r *MongoRepository) UpsertParts(ctx context.Context, parts []models.Part) error {
+	if len(parts) == 0 {
+		return nil
 	}
 
-	return nil
+	writes := make([]mongo.WriteModel, 0, len(parts))
+	for _, part := range parts {
+		writes = append(writes, mongo.NewReplaceOneModel().
+			SetFilter(bson.M{"uuid": part.UUID}).
+			SetReplacement(part).
+			SetUpsert(true))
+	}
+
+	_, err := r.collection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	return err
 }
 
 // DeletePart удаляет деталь по UUID
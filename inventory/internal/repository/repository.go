@@ -9,8 +9,22 @@ import (
 // InventoryRepository определяет интерфейс для работы с инвентарем
 type InventoryRepository interface {
 	GetPart(ctx context.Context, uuid string) (models.Part, error)
-	ListParts(ctx context.Context, filter models.PartFilter) ([]models.Part, error)
+	// ListParts возвращает детали, подходящие под фильтр, в порядке и окне выборки из opts
+	ListParts(ctx context.Context, filter models.PartFilter, opts models.PartListOptions) ([]models.Part, error)
+	// ResolveMatchMode выбирает способ поиска по запросу фильтра, которым затем выбираются все страницы списка
+	ResolveMatchMode(ctx context.Context, filter models.PartFilter) (models.PartMatchMode, error)
 	CreatePart(ctx context.Context, part models.Part) error
-	UpdatePart(ctx context.Context, uuid string, part models.Part) error
+	// UpdatePart заменяет деталь и возвращает ее состояние до замены, прочитанное той же операцией
+	UpdatePart(ctx context.Context, uuid string, part models.Part) (models.Part, error)
 	DeletePart(ctx context.Context, uuid string) error
+	// UpsertParts создает или заменяет детали с указанными UUID одной пачкой
+	UpsertParts(ctx context.Context, parts []models.Part) error
+}
+
+// CompatibilityRepository определяет интерфейс для работы с правилами совместимости деталей
+type CompatibilityRepository interface {
+	ListRules(ctx context.Context) ([]models.CompatibilityRule, error)
+	// UpsertRules создает или заменяет правила с указанными UUID одной пачкой
+	UpsertRules(ctx context.Context, rules []models.CompatibilityRule) error
+	DeleteRule(ctx context.Context, uuid string) error
 }
@@ -0,0 +1,269 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/linemk/rocket-shop/inventory/internal/entyties/models"
+	"github.com/linemk/rocket-shop/inventory/internal/metrics"
+	"github.com/linemk/rocket-shop/inventory/internal/mocks"
+	"github.com/linemk/rocket-shop/inventory/internal/repository/cached"
+	rediscache "github.com/linemk/rocket-shop/platform/pkg/cache/redis"
+	"github.com/linemk/rocket-shop/platform/pkg/logger"
+	prommetrics "github.com/linemk/rocket-shop/platform/pkg/prometheus"
+	inventory_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/inventory/v1"
+)
+
+const partCacheTTL = time.Minute
+
+func newPartCacheMetrics() *metrics.PartCacheMetrics {
+	pm := prommetrics.New()
+	return &metrics.PartCacheMetrics{
+		Requests:      pm.NewCounter("part_cache_requests_total", "", []string{"result"}),
+		Invalidations: pm.NewCounter("part_cache_invalidations_total", "", nil),
+	}
+}
+
+func TestCachedGetPart(t *testing.T) {
+	ctx := context.Background()
+	if err := logger.Init(ctx, "info", false, false, "", "inventory-test"); err != nil {
+		t.Fatalf("failed to init logger: %v", err)
+	}
+
+	part := models.Part{
+		UUID:          uuid.New().String(),
+		Name:          "Ion thruster",
+		Price:         100,
+		StockQuantity: 3,
+		Category:      inventory_v1.Category_CATEGORY_ENGINE,
+		Tags:          []string{"engine"},
+	}
+	key := "inventory:part:" + part.UUID
+
+	encoded, err := json.Marshal(part)
+	require.NoError(t, err)
+
+	t.Run("hit is served from cache", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		cacheClient := mocks.NewMockClient(ctrl)
+		cacheClient.EXPECT().Get(ctx, key).Return(encoded, nil)
+
+		partCacheMetrics := newPartCacheMetrics()
+		repo := cached.NewRepository(mocks.NewMockInventoryRepository(ctrl), cacheClient, partCacheTTL, partCacheMetrics)
+
+		got, err := repo.GetPart(ctx, part.UUID)
+		require.NoError(t, err)
+		require.Equal(t, part, got)
+		require.Equal(t, float64(1), testutil.ToFloat64(partCacheMetrics.Requests.WithLabelValues("hit")))
+	})
+
+	t.Run("miss loads part and stores it with ttl", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		cacheClient := mocks.NewMockClient(ctrl)
+		cacheClient.EXPECT().Get(ctx, key).Return(nil, rediscache.ErrKeyNotFound)
+		cacheClient.EXPECT().Set(gomock.Any(), key, encoded, partCacheTTL).Return(nil)
+
+		next := mocks.NewMockInventoryRepository(ctrl)
+		next.EXPECT().GetPart(gomock.Any(), part.UUID).Return(part, nil)
+
+		partCacheMetrics := newPartCacheMetrics()
+		repo := cached.NewRepository(next, cacheClient, partCacheTTL, partCacheMetrics)
+
+		got, err := repo.GetPart(ctx, part.UUID)
+		require.NoError(t, err)
+		require.Equal(t, part, got)
+		require.Equal(t, float64(1), testutil.ToFloat64(partCacheMetrics.Requests.WithLabelValues("miss")))
+	})
+
+	t.Run("unavailable cache falls back to repository", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		cacheClient := mocks.NewMockClient(ctrl)
+		cacheClient.EXPECT().Get(ctx, key).Return(nil, errors.New("connection refused"))
+		cacheClient.EXPECT().Set(gomock.Any(), key, encoded, partCacheTTL).Return(errors.New("connection refused"))
+
+		next := mocks.NewMockInventoryRepository(ctrl)
+		next.EXPECT().GetPart(gomock.Any(), part.UUID).Return(part, nil)
+
+		partCacheMetrics := newPartCacheMetrics()
+		repo := cached.NewRepository(next, cacheClient, partCacheTTL, partCacheMetrics)
+
+		got, err := repo.GetPart(ctx, part.UUID)
+		require.NoError(t, err)
+		require.Equal(t, part, got)
+		require.Equal(t, float64(1), testutil.ToFloat64(partCacheMetrics.Requests.WithLabelValues("error")))
+	})
+
+	t.Run("missing part is not cached", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		cacheClient := mocks.NewMockClient(ctrl)
+		cacheClient.EXPECT().Get(ctx, key).Return(nil, rediscache.ErrKeyNotFound)
+
+		next := mocks.NewMockInventoryRepository(ctrl)
+		next.EXPECT().GetPart(gomock.Any(), part.UUID).Return(models.Part{}, errors.New("part not found"))
+
+		repo := cached.NewRepository(next, cacheClient, partCacheTTL, newPartCacheMetrics())
+
+		_, err := repo.GetPart(ctx, part.UUID)
+		require.Error(t, err)
+	})
+
+	t.Run("concurrent misses load part once", func(t *testing.T) {
+		const callers = 10
+
+		ctrl := gomock.NewController(t)
+
+		var lookups atomic.Int32
+		cacheClient := mocks.NewMockClient(ctrl)
+		cacheClient.EXPECT().Get(gomock.Any(), key).
+			DoAndReturn(func(context.Context, string) ([]byte, error) {
+				lookups.Add(1)
+				return nil, rediscache.ErrKeyNotFound
+			}).Times(callers)
+		cacheClient.EXPECT().Set(gomock.Any(), key, encoded, partCacheTTL).Return(nil)
+
+		release := make(chan struct{})
+		next := mocks.NewMockInventoryRepository(ctrl)
+		next.EXPECT().GetPart(gomock.Any(), part.UUID).
+			DoAndReturn(func(context.Context, string) (models.Part, error) {
+				<-release
+				return part, nil
+			}).Times(1)
+
+		repo := cached.NewRepository(next, cacheClient, partCacheTTL, newPartCacheMetrics())
+
+		var wg sync.WaitGroup
+		for i := 0; i < callers; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				got, err := repo.GetPart(ctx, part.UUID)
+				require.NoError(t, err)
+				require.Equal(t, part, got)
+			}()
+		}
+
+		// Загрузка отпускается, когда все вызовы промахнулись мимо кеша и ждут ее результата
+		require.Eventually(t, func() bool { return lookups.Load() == callers }, time.Second, time.Millisecond)
+		time.Sleep(50 * time.Millisecond)
+		close(release)
+
+		wg.Wait()
+	})
+
+	t.Run("cancelled caller does not fail shared load", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		var lookups atomic.Int32
+		cacheClient := mocks.NewMockClient(ctrl)
+		cacheClient.EXPECT().Get(gomock.Any(), key).
+			DoAndReturn(func(context.Context, string) ([]byte, error) {
+				lookups.Add(1)
+				return nil, rediscache.ErrKeyNotFound
+			}).Times(2)
+		cacheClient.EXPECT().Set(gomock.Any(), key, encoded, partCacheTTL).Return(nil)
+
+		release := make(chan struct{})
+		next := mocks.NewMockInventoryRepository(ctrl)
+		next.EXPECT().GetPart(gomock.Any(), part.UUID).
+			DoAndReturn(func(loadCtx context.Context, _ string) (models.Part, error) {
+				<-release
+				// Загрузка продолжается после отмены запроса, который ее начал
+				if err := loadCtx.Err(); err != nil {
+					return models.Part{}, err
+				}
+				return part, nil
+			}).Times(1)
+
+		repo := cached.NewRepository(next, cacheClient, partCacheTTL, newPartCacheMetrics())
+
+		firstCtx, cancelFirst := context.WithCancel(ctx)
+		firstErr := make(chan error, 1)
+		go func() {
+			_, err := repo.GetPart(firstCtx, part.UUID)
+			firstErr <- err
+		}()
+		require.Eventually(t, func() bool { return lookups.Load() == 1 }, time.Second, time.Millisecond)
+
+		second := make(chan models.Part, 1)
+		go func() {
+			got, err := repo.GetPart(ctx, part.UUID)
+			require.NoError(t, err)
+			second <- got
+		}()
+		require.Eventually(t, func() bool { return lookups.Load() == 2 }, time.Second, time.Millisecond)
+		time.Sleep(50 * time.Millisecond)
+
+		cancelFirst()
+		require.ErrorIs(t, <-firstErr, context.Canceled)
+
+		close(release)
+		require.Equal(t, part, <-second)
+	})
+}
+
+func TestCachedInvalidation(t *testing.T) {
+	ctx := context.Background()
+	partUUID := uuid.New().String()
+	key := "inventory:part:" + partUUID
+
+	t.Run("update and delete drop cached part", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		cacheClient := mocks.NewMockClient(ctrl)
+		cacheClient.EXPECT().Del(ctx, key).Return(nil).Times(2)
+
+		next := mocks.NewMockInventoryRepository(ctrl)
+		previous := models.Part{UUID: partUUID, Price: 100}
+		next.EXPECT().UpdatePart(ctx, partUUID, gomock.Any()).Return(previous, nil)
+		next.EXPECT().DeletePart(ctx, partUUID).Return(nil)
+
+		partCacheMetrics := newPartCacheMetrics()
+		repo := cached.NewRepository(next, cacheClient, partCacheTTL, partCacheMetrics)
+
+		// Прежнее состояние приходит из основного хранилища; кеш при этом не читается
+		got, err := repo.UpdatePart(ctx, partUUID, models.Part{UUID: partUUID})
+		require.NoError(t, err)
+		require.Equal(t, previous, got)
+		require.NoError(t, repo.DeletePart(ctx, partUUID))
+		require.Equal(t, float64(2), testutil.ToFloat64(partCacheMetrics.Invalidations.WithLabelValues()))
+	})
+
+	t.Run("failed update keeps cached part", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		next := mocks.NewMockInventoryRepository(ctrl)
+		next.EXPECT().UpdatePart(ctx, partUUID, gomock.Any()).Return(models.Part{}, errors.New("part not found"))
+
+		repo := cached.NewRepository(next, mocks.NewMockClient(ctrl), partCacheTTL, newPartCacheMetrics())
+
+		_, err := repo.UpdatePart(ctx, partUUID, models.Part{UUID: partUUID})
+		require.Error(t, err)
+	})
+
+	t.Run("upsert drops every written part", func(t *testing.T) {
+		otherUUID := uuid.New().String()
+		parts := []models.Part{{UUID: partUUID}, {UUID: otherUUID}}
+
+		ctrl := gomock.NewController(t)
+		cacheClient := mocks.NewMockClient(ctrl)
+		cacheClient.EXPECT().Del(ctx, key, "inventory:part:"+otherUUID).Return(nil)
+
+		next := mocks.NewMockInventoryRepository(ctrl)
+		next.EXPECT().UpsertParts(ctx, parts).Return(nil)
+
+		partCacheMetrics := newPartCacheMetrics()
+		repo := cached.NewRepository(next, cacheClient, partCacheTTL, partCacheMetrics)
+
+		require.NoError(t, repo.UpsertParts(ctx, parts))
+		require.Equal(t, float64(2), testutil.ToFloat64(partCacheMetrics.Invalidations.WithLabelValues()))
+	})
+}
@@ -3,6 +3,7 @@ package tests
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
@@ -25,35 +26,135 @@ func TestListParts(t *testing.T) {
 	require.NoError(t, repo.CreatePart(ctx, body))
 
 	t.Run("Empty filter returns all", func(t *testing.T) {
-		got, err := repo.ListParts(ctx, models.PartFilter{})
+		got, err := repo.ListParts(ctx, models.PartFilter{}, models.PartListOptions{})
 		require.NoError(t, err)
 		require.Len(t, got, 3)
 	})
 
 	t.Run("Filter by UUIDs", func(t *testing.T) {
-		got, err := repo.ListParts(ctx, models.PartFilter{UUIDs: []string{engine1.UUID}})
+		got, err := repo.ListParts(ctx, models.PartFilter{UUIDs: []string{engine1.UUID}}, models.PartListOptions{})
 		require.NoError(t, err)
 		require.Len(t, got, 1)
 		require.Equal(t, engine1.UUID, got[0].UUID)
 	})
 
 	t.Run("Filter by Names", func(t *testing.T) {
-		got, err := repo.ListParts(ctx, models.PartFilter{Names: []string{"Engine-2"}})
+		got, err := repo.ListParts(ctx, models.PartFilter{Names: []string{"Engine-2"}}, models.PartListOptions{})
 		require.NoError(t, err)
 		require.Len(t, got, 1)
 		require.Equal(t, engine2.UUID, got[0].UUID)
 	})
 
 	t.Run("Filter by Categories", func(t *testing.T) {
-		got, err := repo.ListParts(ctx, models.PartFilter{Categories: []inventory_v1.Category{inventory_v1.Category_CATEGORY_ENGINE}})
+		got, err := repo.ListParts(ctx, models.PartFilter{Categories: []inventory_v1.Category{inventory_v1.Category_CATEGORY_ENGINE}}, models.PartListOptions{})
 		require.NoError(t, err)
 		require.Len(t, got, 2)
 	})
 
 	t.Run("Filter by Tags", func(t *testing.T) {
-		got, err := repo.ListParts(ctx, models.PartFilter{Tags: []string{"plastic"}})
+		got, err := repo.ListParts(ctx, models.PartFilter{Tags: []string{"plastic"}}, models.PartListOptions{})
 		require.NoError(t, err)
 		require.Len(t, got, 1)
 		require.Equal(t, body.UUID, got[0].UUID)
 	})
 }
+
+func TestListPartsSearchAndSort(t *testing.T) {
+	ctx := context.Background()
+	repo := repoimpl.NewRepository()
+
+	now := time.Now()
+	nozzle := models.Part{UUID: uuid.New().String(), Name: "Ion nozzle", Description: "Engine nozzle", Price: 300, StockQuantity: 2, Tags: []string{"engine"}, CreatedAt: now}
+	thruster := models.Part{UUID: uuid.New().String(), Name: "Ion thruster", Description: "Main engine", Price: 100, StockQuantity: 0, CreatedAt: now.Add(time.Minute)}
+	window := models.Part{UUID: uuid.New().String(), Name: "Porthole", Description: "Glass for the ion lab", Price: 200, StockQuantity: 7, CreatedAt: now.Add(2 * time.Minute)}
+	require.NoError(t, repo.CreatePart(ctx, nozzle))
+	require.NoError(t, repo.CreatePart(ctx, thruster))
+	require.NoError(t, repo.CreatePart(ctx, window))
+
+	uuids := func(parts []models.Part) []string {
+		result := make([]string, 0, len(parts))
+		for _, part := range parts {
+			result = append(result, part.UUID)
+		}
+		return result
+	}
+
+	t.Run("Query matches name, description and tags case-insensitively", func(t *testing.T) {
+		got, err := repo.ListParts(ctx, models.PartFilter{Query: "ENGINE"}, models.PartListOptions{})
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{nozzle.UUID, thruster.UUID}, uuids(got))
+	})
+
+	t.Run("Relevance ranks name matches first", func(t *testing.T) {
+		got, err := repo.ListParts(ctx, models.PartFilter{Query: "ion nozzle"}, models.PartListOptions{
+			SortField: inventory_v1.PartsSortField_PARTS_SORT_FIELD_RELEVANCE,
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{nozzle.UUID, thruster.UUID, window.UUID}, uuids(got))
+	})
+
+	t.Run("Price range and in stock only", func(t *testing.T) {
+		got, err := repo.ListParts(ctx, models.PartFilter{MinPrice: 100, MaxPrice: 250, InStockOnly: true}, models.PartListOptions{})
+		require.NoError(t, err)
+		require.Equal(t, []string{window.UUID}, uuids(got))
+	})
+
+	t.Run("Sort by price descending", func(t *testing.T) {
+		got, err := repo.ListParts(ctx, models.PartFilter{}, models.PartListOptions{
+			SortField:     inventory_v1.PartsSortField_PARTS_SORT_FIELD_PRICE,
+			SortDirection: inventory_v1.SortDirection_SORT_DIRECTION_DESC,
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{nozzle.UUID, window.UUID, thruster.UUID}, uuids(got))
+	})
+
+	t.Run("Offset and limit", func(t *testing.T) {
+		got, err := repo.ListParts(ctx, models.PartFilter{}, models.PartListOptions{
+			SortField: inventory_v1.PartsSortField_PARTS_SORT_FIELD_CREATED_AT,
+			Offset:    1,
+			Limit:     1,
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{thruster.UUID}, uuids(got))
+
+		got, err = repo.ListParts(ctx, models.PartFilter{}, models.PartListOptions{Offset: 3})
+		require.NoError(t, err)
+		require.Empty(t, got)
+	})
+	t.Run("After cursor continues from the last part of the previous page", func(t *testing.T) {
+		opts := models.PartListOptions{
+			SortField:     inventory_v1.PartsSortField_PARTS_SORT_FIELD_PRICE,
+			SortDirection: inventory_v1.SortDirection_SORT_DIRECTION_DESC,
+			After:         &models.PartCursor{Value: nozzle.Price, UUID: nozzle.UUID},
+		}
+		got, err := repo.ListParts(ctx, models.PartFilter{}, opts)
+		require.NoError(t, err)
+		require.Equal(t, []string{window.UUID, thruster.UUID}, uuids(got))
+
+		opts.After = &models.PartCursor{Value: thruster.CreatedAt, UUID: thruster.UUID}
+		opts.SortField = inventory_v1.PartsSortField_PARTS_SORT_FIELD_CREATED_AT
+		opts.SortDirection = inventory_v1.SortDirection_SORT_DIRECTION_ASC
+		got, err = repo.ListParts(ctx, models.PartFilter{}, opts)
+		require.NoError(t, err)
+		require.Equal(t, []string{window.UUID}, uuids(got))
+	})
+
+	t.Run("After cursor by relevance uses the score of the last part", func(t *testing.T) {
+		filter := models.PartFilter{Query: "ion nozzle"}
+		opts := models.PartListOptions{
+			SortField: inventory_v1.PartsSortField_PARTS_SORT_FIELD_RELEVANCE,
+			MatchMode: models.PartMatchText,
+			Limit:     1,
+		}
+		first, err := repo.ListParts(ctx, filter, opts)
+		require.NoError(t, err)
+		require.Equal(t, []string{nozzle.UUID}, uuids(first))
+		require.Positive(t, first[0].Score)
+
+		opts.After = &models.PartCursor{Value: first[0].Score, UUID: first[0].UUID}
+		opts.Limit = 0
+		got, err := repo.ListParts(ctx, filter, opts)
+		require.NoError(t, err)
+		require.Equal(t, []string{thruster.UUID, window.UUID}, uuids(got))
+	})
+}
@@ -0,0 +1,15 @@
+package service
+
+import (
+	"context"
+
+	"github.com/linemk/rocket-shop/inventory/internal/entyties/events"
+)
+
+// PartProducerService публикует события об изменениях каталога деталей
+type PartProducerService interface {
+	SendPartCreated(ctx context.Context, event *events.PartCreatedEvent) error
+	SendPartUpdated(ctx context.Context, event *events.PartUpdatedEvent) error
+	SendPartDeleted(ctx context.Context, event *events.PartDeletedEvent) error
+	SendStockChanged(ctx context.Context, event *events.StockChangedEvent) error
+}
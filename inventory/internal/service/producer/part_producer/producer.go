@@ -0,0 +1,107 @@
+package part_producer
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/linemk/rocket-shop/inventory/internal/converter/kafka"
+	"github.com/linemk/rocket-shop/inventory/internal/entyties/events"
+	platformKafka "github.com/linemk/rocket-shop/platform/pkg/kafka"
+)
+
+type Logger interface {
+	Info(ctx context.Context, msg string, fields ...zap.Field)
+	Error(ctx context.Context, msg string, fields ...zap.Field)
+}
+
+type producer struct {
+	kafkaProducer platformKafka.Producer
+	logger        Logger
+}
+
+func NewProducer(kafkaProducer platformKafka.Producer, logger Logger) *producer {
+	return &producer{
+		kafkaProducer: kafkaProducer,
+		logger:        logger,
+	}
+}
+
+func (p *producer) SendPartCreated(ctx context.Context, event *events.PartCreatedEvent) error {
+	data, err := kafka.EncodePartCreated(event)
+	if err != nil {
+		p.logger.Error(ctx, "Failed to encode PartCreated event", zap.Error(err))
+		return err
+	}
+
+	// Ключ сообщения - UUID детали, чтобы события одной детали обрабатывались по порядку
+	if err := p.kafkaProducer.Send(ctx, []byte(event.PartUUID), data); err != nil {
+		p.logger.Error(ctx, "Failed to send PartCreated event to Kafka", zap.Error(err))
+		return err
+	}
+
+	p.logger.Info(ctx, "PartCreated event sent successfully",
+		zap.String("part_uuid", event.PartUUID),
+	)
+
+	return nil
+}
+
+func (p *producer) SendPartUpdated(ctx context.Context, event *events.PartUpdatedEvent) error {
+	data, err := kafka.EncodePartUpdated(event)
+	if err != nil {
+		p.logger.Error(ctx, "Failed to encode PartUpdated event", zap.Error(err))
+		return err
+	}
+
+	if err := p.kafkaProducer.Send(ctx, []byte(event.PartUUID), data); err != nil {
+		p.logger.Error(ctx, "Failed to send PartUpdated event to Kafka", zap.Error(err))
+		return err
+	}
+
+	p.logger.Info(ctx, "PartUpdated event sent successfully",
+		zap.String("part_uuid", event.PartUUID),
+	)
+
+	return nil
+}
+
+func (p *producer) SendPartDeleted(ctx context.Context, event *events.PartDeletedEvent) error {
+	data, err := kafka.EncodePartDeleted(event)
+	if err != nil {
+		p.logger.Error(ctx, "Failed to encode PartDeleted event", zap.Error(err))
+		return err
+	}
+
+	if err := p.kafkaProducer.Send(ctx, []byte(event.PartUUID), data); err != nil {
+		p.logger.Error(ctx, "Failed to send PartDeleted event to Kafka", zap.Error(err))
+		return err
+	}
+
+	p.logger.Info(ctx, "PartDeleted event sent successfully",
+		zap.String("part_uuid", event.PartUUID),
+	)
+
+	return nil
+}
+
+func (p *producer) SendStockChanged(ctx context.Context, event *events.StockChangedEvent) error {
+	data, err := kafka.EncodeStockChanged(event)
+	if err != nil {
+		p.logger.Error(ctx, "Failed to encode StockChanged event", zap.Error(err))
+		return err
+	}
+
+	if err := p.kafkaProducer.Send(ctx, []byte(event.PartUUID), data); err != nil {
+		p.logger.Error(ctx, "Failed to send StockChanged event to Kafka", zap.Error(err))
+		return err
+	}
+
+	p.logger.Info(ctx, "StockChanged event sent successfully",
+		zap.String("part_uuid", event.PartUUID),
+		zap.Int64("previous_quantity", event.PreviousQuantity),
+		zap.Int64("quantity", event.Quantity),
+	)
+
+	return nil
+}
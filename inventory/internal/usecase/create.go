@@ -11,5 +11,8 @@ func (uc *useCase) CreatePart(ctx context.Context, part models.Part) error {
 	if err := uc.inventoryRepository.CreatePart(ctx, part); err != nil {
 		return apperrors.ErrPartAlreadyExists
 	}
+
+	uc.publishPartCreated(ctx, part)
+
 	return nil
 }
@@ -10,5 +10,8 @@ func (uc *useCase) DeletePart(ctx context.Context, uuid string) error {
 	if err := uc.inventoryRepository.DeletePart(ctx, uuid); err != nil {
 		return apperrors.ErrPartNotFound
 	}
+
+	uc.publishPartDeleted(ctx, uuid)
+
 	return nil
 }
@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/linemk/rocket-shop/inventory/internal/entyties/events"
+	"github.com/linemk/rocket-shop/inventory/internal/entyties/models"
+)
+
+// Ошибки отправки событий уже логируются в partProducer. Изменение каталога к этому моменту
+// сохранено, поэтому операция не прерывается
+
+func (uc *useCase) publishPartCreated(ctx context.Context, part models.Part) {
+	if uc.partProducer == nil {
+		return
+	}
+
+	_ = uc.partProducer.SendPartCreated(ctx, &events.PartCreatedEvent{
+		EventUUID:     uuid.New().String(),
+		PartUUID:      part.UUID,
+		Name:          part.Name,
+		Category:      part.Category.String(),
+		Price:         part.Price,
+		StockQuantity: part.StockQuantity,
+		Tags:          part.Tags,
+		OccurredAt:    time.Now(),
+	})
+}
+
+func (uc *useCase) publishPartUpdated(ctx context.Context, partUUID string, previous, part models.Part) {
+	if uc.partProducer == nil {
+		return
+	}
+
+	now := time.Now()
+
+	_ = uc.partProducer.SendPartUpdated(ctx, &events.PartUpdatedEvent{
+		EventUUID:     uuid.New().String(),
+		PartUUID:      partUUID,
+		Name:          part.Name,
+		Category:      part.Category.String(),
+		Price:         part.Price,
+		PreviousPrice: previous.Price,
+		StockQuantity: part.StockQuantity,
+		Tags:          part.Tags,
+		OccurredAt:    now,
+	})
+
+	if previous.StockQuantity == part.StockQuantity {
+		return
+	}
+
+	_ = uc.partProducer.SendStockChanged(ctx, &events.StockChangedEvent{
+		EventUUID:        uuid.New().String(),
+		PartUUID:         partUUID,
+		PreviousQuantity: previous.StockQuantity,
+		Quantity:         part.StockQuantity,
+		OccurredAt:       now,
+	})
+}
+
+func (uc *useCase) publishPartDeleted(ctx context.Context, partUUID string) {
+	if uc.partProducer == nil {
+		return
+	}
+
+	_ = uc.partProducer.SendPartDeleted(ctx, &events.PartDeletedEvent{
+		EventUUID:  uuid.New().String(),
+		PartUUID:   partUUID,
+		OccurredAt: time.Now(),
+	})
+}
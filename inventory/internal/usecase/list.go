@@ -3,13 +3,46 @@ package usecase
 import (
 	"context"
 
+	"github.com/linemk/rocket-shop/inventory/internal/entyties/apperrors"
 	"github.com/linemk/rocket-shop/inventory/internal/entyties/models"
+	inventory_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/inventory/v1"
 )
 
-func (uc *useCase) ListParts(ctx context.Context, filter models.PartFilter) ([]models.PartInfo, error) {
-	parts, err := uc.inventoryRepository.ListParts(ctx, filter)
+const (
+	// defaultPartsPageSize размер страницы, если клиент его не указал
+	defaultPartsPageSize = 50
+	// maxPartsPageSize максимальный размер страницы
+	maxPartsPageSize = 500
+)
+
+func (uc *useCase) ListParts(ctx context.Context, filter models.PartFilter, page models.PartPageRequest) ([]models.PartInfo, string, error) {
+	opts, hash, err := listOptions(filter, page)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if filter.Query != "" && opts.After == nil {
+		// Способ поиска выбирается один раз на первой странице и передается в токене,
+		// иначе страницы одного списка могли бы искаться разными способами
+		opts.MatchMode, err = uc.inventoryRepository.ResolveMatchMode(ctx, filter)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	pageSize := opts.Limit
+	// Лишняя деталь показывает, что за страницей есть следующая
+	opts.Limit++
+
+	parts, err := uc.inventoryRepository.ListParts(ctx, filter, opts)
 	if err != nil {
-		return nil, err
+		return nil, "", err
+	}
+
+	nextPageToken := ""
+	if len(parts) > pageSize {
+		parts = parts[:pageSize]
+		nextPageToken = encodePageToken(hash, opts, parts[pageSize-1])
 	}
 
 	// Конвертируем []Part в []PartInfo
@@ -19,5 +52,47 @@ func (uc *useCase) ListParts(ctx context.Context, filter models.PartFilter) ([]m
 		partInfos = append(partInfos, partInfo)
 	}
 
-	return partInfos, nil
+	return partInfos, nextPageToken, nil
+}
+
+// listOptions проверяет фильтр и запрошенную страницу и переводит их в окно выборки репозитория.
+// Вместе с окном возвращается отпечаток фильтра, для которого выдаются токены страниц
+func listOptions(filter models.PartFilter, page models.PartPageRequest) (models.PartListOptions, string, error) {
+	if filter.MinPrice < 0 || filter.MaxPrice < 0 || (filter.MaxPrice > 0 && filter.MinPrice > filter.MaxPrice) {
+		return models.PartListOptions{}, "", apperrors.ErrInvalidFilter
+	}
+	if page.PageSize < 0 {
+		return models.PartListOptions{}, "", apperrors.ErrInvalidFilter
+	}
+
+	sortField := page.SortField
+	switch {
+	case sortField == inventory_v1.PartsSortField_PARTS_SORT_FIELD_RELEVANCE && filter.Query == "":
+		return models.PartListOptions{}, "", apperrors.ErrInvalidFilter
+	case sortField == inventory_v1.PartsSortField_PARTS_SORT_FIELD_UNSPECIFIED && filter.Query != "":
+		sortField = inventory_v1.PartsSortField_PARTS_SORT_FIELD_RELEVANCE
+	case sortField == inventory_v1.PartsSortField_PARTS_SORT_FIELD_UNSPECIFIED:
+		sortField = inventory_v1.PartsSortField_PARTS_SORT_FIELD_NAME
+	}
+
+	pageSize := page.PageSize
+	switch {
+	case pageSize == 0:
+		pageSize = defaultPartsPageSize
+	case pageSize > maxPartsPageSize:
+		pageSize = maxPartsPageSize
+	}
+
+	opts := models.PartListOptions{
+		SortField:     sortField,
+		SortDirection: page.SortDirection,
+		Limit:         pageSize,
+	}
+
+	hash := filterHash(filter, sortField, page.SortDirection)
+	if err := decodePageToken(page.PageToken, hash, &opts); err != nil {
+		return models.PartListOptions{}, "", err
+	}
+
+	return opts, hash, nil
 }
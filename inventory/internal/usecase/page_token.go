@@ -0,0 +1,151 @@
+package usecase
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/linemk/rocket-shop/inventory/internal/entyties/apperrors"
+	"github.com/linemk/rocket-shop/inventory/internal/entyties/models"
+	inventory_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/inventory/v1"
+)
+
+// pageToken содержимое непрозрачного токена страницы списка деталей
+type pageToken struct {
+	// Filter отпечаток фильтра и сортировки, для которых выдан токен
+	Filter string `json:"f"`
+	// Mode способ поиска, выбранный на первой странице: следующие страницы ищутся так же
+	Mode models.PartMatchMode `json:"m,omitempty"`
+	// Value и UUID значение поля сортировки и UUID последней детали страницы
+	Value json.RawMessage `json:"v"`
+	UUID  string          `json:"u"`
+}
+
+// encodePageToken кодирует в токен позицию последней детали страницы
+func encodePageToken(filterHash string, opts models.PartListOptions, last models.Part) string {
+	value, err := json.Marshal(sortValue(last, cursorSortField(opts)))
+	if err != nil {
+		return ""
+	}
+
+	data, err := json.Marshal(pageToken{
+		Filter: filterHash,
+		Mode:   opts.MatchMode,
+		Value:  value,
+		UUID:   last.UUID,
+	})
+	if err != nil {
+		return ""
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodePageToken дополняет opts позицией и способом поиска из токена; пустой токен означает первую страницу.
+// Токен, выданный для другого фильтра или сортировки, отклоняется
+func decodePageToken(token, filterHash string, opts *models.PartListOptions) error {
+	if token == "" {
+		return nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return apperrors.ErrInvalidPageToken
+	}
+
+	var decoded pageToken
+	if err := json.Unmarshal(data, &decoded); err != nil || decoded.UUID == "" || decoded.Filter != filterHash {
+		return apperrors.ErrInvalidPageToken
+	}
+
+	switch decoded.Mode {
+	case models.PartMatchAuto, models.PartMatchText, models.PartMatchNamePrefix:
+	default:
+		return apperrors.ErrInvalidPageToken
+	}
+	opts.MatchMode = decoded.Mode
+
+	value, err := decodeSortValue(decoded.Value, cursorSortField(*opts))
+	if err != nil {
+		return apperrors.ErrInvalidPageToken
+	}
+	opts.After = &models.PartCursor{Value: value, UUID: decoded.UUID}
+
+	return nil
+}
+
+// cursorSortField возвращает поле, по которому фактически упорядочена выборка: без поиска по словам
+// релевантность не вычисляется, и репозиторий упорядочивает детали по названию
+func cursorSortField(opts models.PartListOptions) inventory_v1.PartsSortField {
+	if opts.SortField == inventory_v1.PartsSortField_PARTS_SORT_FIELD_RELEVANCE && opts.MatchMode != models.PartMatchText {
+		return inventory_v1.PartsSortField_PARTS_SORT_FIELD_NAME
+	}
+	return opts.SortField
+}
+
+// sortValue возвращает значение поля сортировки у детали
+func sortValue(part models.Part, field inventory_v1.PartsSortField) interface{} {
+	switch field {
+	case inventory_v1.PartsSortField_PARTS_SORT_FIELD_PRICE:
+		return part.Price
+	case inventory_v1.PartsSortField_PARTS_SORT_FIELD_STOCK_QUANTITY:
+		return part.StockQuantity
+	case inventory_v1.PartsSortField_PARTS_SORT_FIELD_CREATED_AT:
+		return part.CreatedAt
+	case inventory_v1.PartsSortField_PARTS_SORT_FIELD_RELEVANCE:
+		return part.Score
+	default:
+		return part.Name
+	}
+}
+
+// decodeSortValue восстанавливает значение поля сортировки с тем типом, с которым его сравнивает репозиторий
+func decodeSortValue(data json.RawMessage, field inventory_v1.PartsSortField) (interface{}, error) {
+	switch field {
+	case inventory_v1.PartsSortField_PARTS_SORT_FIELD_PRICE, inventory_v1.PartsSortField_PARTS_SORT_FIELD_RELEVANCE:
+		var value float64
+		err := json.Unmarshal(data, &value)
+		return value, err
+	case inventory_v1.PartsSortField_PARTS_SORT_FIELD_STOCK_QUANTITY:
+		var value int64
+		err := json.Unmarshal(data, &value)
+		return value, err
+	case inventory_v1.PartsSortField_PARTS_SORT_FIELD_CREATED_AT:
+		var value time.Time
+		err := json.Unmarshal(data, &value)
+		return value, err
+	default:
+		var value string
+		err := json.Unmarshal(data, &value)
+		return value, err
+	}
+}
+
+// filterHash отпечаток фильтра и порядка сортировки. Порядок значений в списках фильтра не важен
+func filterHash(filter models.PartFilter, sortField inventory_v1.PartsSortField, sortDirection inventory_v1.SortDirection) string {
+	filter.UUIDs = sortedCopy(filter.UUIDs)
+	filter.Names = sortedCopy(filter.Names)
+	filter.Categories = sortedCopy(filter.Categories)
+	filter.ManufacturerCountries = sortedCopy(filter.ManufacturerCountries)
+	filter.Tags = sortedCopy(filter.Tags)
+
+	data, err := json.Marshal(struct {
+		Filter        models.PartFilter
+		SortField     inventory_v1.PartsSortField
+		SortDirection inventory_v1.SortDirection
+	}{filter, sortField, sortDirection})
+	if err != nil {
+		return ""
+	}
+
+	sum := sha256.Sum256(data)
+	return base64.RawURLEncoding.EncodeToString(sum[:16])
+}
+
+func sortedCopy[T string | inventory_v1.Category](values []T) []T {
+	values = slices.Clone(values)
+	slices.Sort(values)
+	return values
+}
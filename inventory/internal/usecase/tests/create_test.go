@@ -53,7 +53,7 @@ func TestCreate(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			inventoryRepo := tt.fields.repoMock()
 
-			uc := usecase.NewUseCase(inventoryRepo)
+			uc := usecase.NewUseCase(inventoryRepo, nil, nil)
 
 			err := uc.CreatePart(ctx, part)
 			if tt.wantErr {
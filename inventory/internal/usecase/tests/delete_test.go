@@ -53,7 +53,7 @@ func TestDeletePart(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			inventoryRepo := tt.fields.repoMock()
-			uc := usecase.NewUseCase(inventoryRepo)
+			uc := usecase.NewUseCase(inventoryRepo, nil, nil)
 
 			err := uc.DeletePart(ctx, testUUID)
 
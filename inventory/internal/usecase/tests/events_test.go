@@ -0,0 +1,181 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/linemk/rocket-shop/inventory/internal/entyties/apperrors"
+	"github.com/linemk/rocket-shop/inventory/internal/entyties/events"
+	"github.com/linemk/rocket-shop/inventory/internal/entyties/models"
+	"github.com/linemk/rocket-shop/inventory/internal/mocks"
+	"github.com/linemk/rocket-shop/inventory/internal/usecase"
+	inventory_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/inventory/v1"
+)
+
+func TestPartEvents(t *testing.T) {
+	ctx := context.Background()
+	testUUID := uuid.New().String()
+
+	previous := models.Part{
+		UUID:          testUUID,
+		Name:          "Engine Part",
+		Price:         100.0,
+		StockQuantity: 5,
+		Category:      inventory_v1.Category_CATEGORY_ENGINE,
+		Tags:          []string{"engine"},
+	}
+
+	t.Run("create publishes PartCreated keyed by part", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := mocks.NewMockInventoryRepository(ctrl)
+		repo.EXPECT().CreatePart(ctx, previous).Return(nil)
+
+		producer := mocks.NewMockPartProducerService(ctrl)
+		producer.EXPECT().SendPartCreated(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, event *events.PartCreatedEvent) error {
+				require.NotEmpty(t, event.EventUUID)
+				require.Equal(t, testUUID, event.PartUUID)
+				require.Equal(t, "CATEGORY_ENGINE", event.Category)
+				require.Equal(t, 100.0, event.Price)
+				require.Equal(t, int64(5), event.StockQuantity)
+				return nil
+			})
+
+		require.NoError(t, usecase.NewUseCase(repo, nil, producer).CreatePart(ctx, previous))
+	})
+
+	t.Run("update with new stock publishes PartUpdated and StockChanged", func(t *testing.T) {
+		updated := previous
+		updated.Price = 120.0
+		updated.StockQuantity = 0
+
+		ctrl := gomock.NewController(t)
+		repo := mocks.NewMockInventoryRepository(ctrl)
+		repo.EXPECT().UpdatePart(ctx, testUUID, updated).Return(previous, nil)
+
+		producer := mocks.NewMockPartProducerService(ctrl)
+		producer.EXPECT().SendPartUpdated(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, event *events.PartUpdatedEvent) error {
+				require.Equal(t, testUUID, event.PartUUID)
+				require.Equal(t, 120.0, event.Price)
+				require.Equal(t, 100.0, event.PreviousPrice)
+				require.Equal(t, int64(0), event.StockQuantity)
+				return nil
+			})
+		producer.EXPECT().SendStockChanged(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, event *events.StockChangedEvent) error {
+				require.Equal(t, testUUID, event.PartUUID)
+				require.Equal(t, int64(5), event.PreviousQuantity)
+				require.Equal(t, int64(0), event.Quantity)
+				return nil
+			})
+
+		require.NoError(t, usecase.NewUseCase(repo, nil, producer).UpdatePart(ctx, testUUID, updated))
+	})
+
+	t.Run("update with same stock publishes only PartUpdated", func(t *testing.T) {
+		updated := previous
+		updated.Name = "Renamed Engine Part"
+
+		ctrl := gomock.NewController(t)
+		repo := mocks.NewMockInventoryRepository(ctrl)
+		repo.EXPECT().UpdatePart(ctx, testUUID, updated).Return(previous, nil)
+
+		producer := mocks.NewMockPartProducerService(ctrl)
+		producer.EXPECT().SendPartUpdated(ctx, gomock.Any()).Return(nil)
+
+		require.NoError(t, usecase.NewUseCase(repo, nil, producer).UpdatePart(ctx, testUUID, updated))
+	})
+
+	t.Run("update of missing part publishes nothing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := mocks.NewMockInventoryRepository(ctrl)
+		repo.EXPECT().UpdatePart(ctx, testUUID, previous).Return(models.Part{}, errors.New("part not found"))
+
+		err := usecase.NewUseCase(repo, nil, mocks.NewMockPartProducerService(ctrl)).UpdatePart(ctx, testUUID, previous)
+		require.ErrorIs(t, err, apperrors.ErrPartNotFound)
+	})
+
+	t.Run("delete publishes PartDeleted", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := mocks.NewMockInventoryRepository(ctrl)
+		repo.EXPECT().DeletePart(ctx, testUUID).Return(nil)
+
+		producer := mocks.NewMockPartProducerService(ctrl)
+		producer.EXPECT().SendPartDeleted(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, event *events.PartDeletedEvent) error {
+				require.Equal(t, testUUID, event.PartUUID)
+				return nil
+			})
+
+		require.NoError(t, usecase.NewUseCase(repo, nil, producer).DeletePart(ctx, testUUID))
+	})
+
+	t.Run("failed delete publishes nothing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := mocks.NewMockInventoryRepository(ctrl)
+		repo.EXPECT().DeletePart(ctx, testUUID).Return(errors.New("part not found"))
+
+		err := usecase.NewUseCase(repo, nil, mocks.NewMockPartProducerService(ctrl)).DeletePart(ctx, testUUID)
+		require.ErrorIs(t, err, apperrors.ErrPartNotFound)
+	})
+
+	t.Run("upsert publishes PartCreated for new and PartUpdated for existing parts", func(t *testing.T) {
+		created := models.Part{UUID: uuid.New().String(), Name: "Fuel Tank", Price: 50.0, StockQuantity: 2}
+		updated := previous
+		updated.StockQuantity = 7
+		parts := []models.Part{created, updated}
+
+		ctrl := gomock.NewController(t)
+		repo := mocks.NewMockInventoryRepository(ctrl)
+		repo.EXPECT().ListParts(ctx, models.PartFilter{UUIDs: []string{created.UUID, testUUID}}, models.PartListOptions{}).
+			Return([]models.Part{previous}, nil)
+		repo.EXPECT().UpsertParts(ctx, parts).Return(nil)
+
+		producer := mocks.NewMockPartProducerService(ctrl)
+		producer.EXPECT().SendPartCreated(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, event *events.PartCreatedEvent) error {
+				require.Equal(t, created.UUID, event.PartUUID)
+				return nil
+			})
+		producer.EXPECT().SendPartUpdated(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, event *events.PartUpdatedEvent) error {
+				require.Equal(t, testUUID, event.PartUUID)
+				return nil
+			})
+		producer.EXPECT().SendStockChanged(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, event *events.StockChangedEvent) error {
+				require.Equal(t, int64(5), event.PreviousQuantity)
+				require.Equal(t, int64(7), event.Quantity)
+				return nil
+			})
+
+		require.NoError(t, usecase.NewUseCase(repo, nil, producer).UpsertParts(ctx, parts))
+	})
+
+	t.Run("failed upsert publishes nothing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := mocks.NewMockInventoryRepository(ctrl)
+		repo.EXPECT().ListParts(ctx, gomock.Any(), gomock.Any()).Return(nil, nil)
+		repo.EXPECT().UpsertParts(ctx, gomock.Any()).Return(errors.New("mongo unavailable"))
+
+		err := usecase.NewUseCase(repo, nil, mocks.NewMockPartProducerService(ctrl)).UpsertParts(ctx, []models.Part{previous})
+		require.Error(t, err)
+	})
+
+	t.Run("send failure does not fail saved change", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := mocks.NewMockInventoryRepository(ctrl)
+		repo.EXPECT().CreatePart(ctx, previous).Return(nil)
+
+		producer := mocks.NewMockPartProducerService(ctrl)
+		producer.EXPECT().SendPartCreated(ctx, gomock.Any()).Return(errors.New("kafka unavailable"))
+
+		require.NoError(t, usecase.NewUseCase(repo, nil, producer).CreatePart(ctx, previous))
+	})
+}
@@ -64,7 +64,7 @@ func TestGetPart(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			inventoryRepo := tt.fields.repoMock()
-			uc := usecase.NewUseCase(inventoryRepo)
+			uc := usecase.NewUseCase(inventoryRepo, nil, nil)
 
 			partInfo, err := uc.GetPart(ctx, testUUID)
 
@@ -8,8 +8,10 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 
+	"github.com/linemk/rocket-shop/inventory/internal/entyties/apperrors"
 	"github.com/linemk/rocket-shop/inventory/internal/entyties/models"
 	"github.com/linemk/rocket-shop/inventory/internal/mocks"
+	repoimpl "github.com/linemk/rocket-shop/inventory/internal/repository/inventory"
 	"github.com/linemk/rocket-shop/inventory/internal/usecase"
 	inventory_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/inventory/v1"
 )
@@ -51,7 +53,7 @@ func TestListParts(t *testing.T) {
 			fields: fields{
 				repoMock: func() *mocks.MockInventoryRepository {
 					mockRepo := mocks.NewMockInventoryRepository(gomock.NewController(t))
-					mockRepo.EXPECT().ListParts(ctx, filter).Return([]models.Part{part1, part2}, nil)
+					mockRepo.EXPECT().ListParts(ctx, filter, gomock.Any()).Return([]models.Part{part1, part2}, nil)
 					return mockRepo
 				},
 			},
@@ -62,7 +64,7 @@ func TestListParts(t *testing.T) {
 			fields: fields{
 				repoMock: func() *mocks.MockInventoryRepository {
 					mockRepo := mocks.NewMockInventoryRepository(gomock.NewController(t))
-					mockRepo.EXPECT().ListParts(ctx, filter).Return([]models.Part{}, nil)
+					mockRepo.EXPECT().ListParts(ctx, filter, gomock.Any()).Return([]models.Part{}, nil)
 					return mockRepo
 				},
 			},
@@ -73,9 +75,9 @@ func TestListParts(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			inventoryRepo := tt.fields.repoMock()
-			uc := usecase.NewUseCase(inventoryRepo)
+			uc := usecase.NewUseCase(inventoryRepo, nil, nil)
 
-			parts, err := uc.ListParts(ctx, filter)
+			parts, _, err := uc.ListParts(ctx, filter, models.PartPageRequest{})
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -87,3 +89,149 @@ func TestListParts(t *testing.T) {
 		})
 	}
 }
+
+func TestListPartsPagination(t *testing.T) {
+	ctx := context.Background()
+	repo := repoimpl.NewRepository()
+	uc := usecase.NewUseCase(repo, nil, nil)
+
+	var want []string
+	for _, name := range []string{"A", "B", "C", "D", "E"} {
+		part := models.Part{UUID: uuid.New().String(), Name: name, Price: 10}
+		require.NoError(t, repo.CreatePart(ctx, part))
+		want = append(want, part.UUID)
+	}
+
+	t.Run("pages follow next page token until the last page", func(t *testing.T) {
+		var got []string
+		page := models.PartPageRequest{PageSize: 2}
+		for pages := 0; ; pages++ {
+			require.Less(t, pages, 3)
+
+			parts, nextPageToken, err := uc.ListParts(ctx, models.PartFilter{}, page)
+			require.NoError(t, err)
+			require.LessOrEqual(t, len(parts), 2)
+			for _, part := range parts {
+				got = append(got, part.UUID)
+			}
+
+			if nextPageToken == "" {
+				break
+			}
+			page.PageToken = nextPageToken
+		}
+
+		require.Equal(t, want, got)
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		cases := []struct {
+			name    string
+			filter  models.PartFilter
+			page    models.PartPageRequest
+			wantErr error
+		}{
+			{name: "malformed page token", page: models.PartPageRequest{PageToken: "not-a-token"}, wantErr: apperrors.ErrInvalidPageToken},
+			{name: "min price above max price", filter: models.PartFilter{MinPrice: 20, MaxPrice: 10}, wantErr: apperrors.ErrInvalidFilter},
+			{name: "negative page size", page: models.PartPageRequest{PageSize: -1}, wantErr: apperrors.ErrInvalidFilter},
+			{
+				name:    "relevance without query",
+				page:    models.PartPageRequest{SortField: inventory_v1.PartsSortField_PARTS_SORT_FIELD_RELEVANCE},
+				wantErr: apperrors.ErrInvalidFilter,
+			},
+		}
+
+		for _, tc := range cases {
+			_, _, err := uc.ListParts(ctx, tc.filter, tc.page)
+			require.ErrorIs(t, err, tc.wantErr, tc.name)
+		}
+	})
+	t.Run("page token is rejected for another filter or sort", func(t *testing.T) {
+		_, nextPageToken, err := uc.ListParts(ctx, models.PartFilter{}, models.PartPageRequest{PageSize: 2})
+		require.NoError(t, err)
+		require.NotEmpty(t, nextPageToken)
+
+		_, _, err = uc.ListParts(ctx, models.PartFilter{InStockOnly: true}, models.PartPageRequest{PageSize: 2, PageToken: nextPageToken})
+		require.ErrorIs(t, err, apperrors.ErrInvalidPageToken)
+
+		_, _, err = uc.ListParts(ctx, models.PartFilter{}, models.PartPageRequest{
+			PageSize:      2,
+			PageToken:     nextPageToken,
+			SortField:     inventory_v1.PartsSortField_PARTS_SORT_FIELD_PRICE,
+			SortDirection: inventory_v1.SortDirection_SORT_DIRECTION_DESC,
+		})
+		require.ErrorIs(t, err, apperrors.ErrInvalidPageToken)
+	})
+}
+
+func TestListPartsPageTokenKeepsPosition(t *testing.T) {
+	ctx := context.Background()
+	repo := repoimpl.NewRepository()
+	uc := usecase.NewUseCase(repo, nil, nil)
+
+	parts := make(map[string]models.Part)
+	for _, name := range []string{"B", "C", "D", "E"} {
+		part := models.Part{UUID: uuid.New().String(), Name: name}
+		require.NoError(t, repo.CreatePart(ctx, part))
+		parts[name] = part
+	}
+
+	page := models.PartPageRequest{PageSize: 2}
+	first, nextPageToken, err := uc.ListParts(ctx, models.PartFilter{}, page)
+	require.NoError(t, err)
+	require.Equal(t, []string{parts["B"].UUID, parts["C"].UUID}, partInfoUUIDs(first))
+
+	// Деталь перед уже выданной страницей не сдвигает следующую страницу
+	require.NoError(t, repo.CreatePart(ctx, models.Part{UUID: uuid.New().String(), Name: "A"}))
+
+	page.PageToken = nextPageToken
+	second, _, err := uc.ListParts(ctx, models.PartFilter{}, page)
+	require.NoError(t, err)
+	require.Equal(t, []string{parts["D"].UUID, parts["E"].UUID}, partInfoUUIDs(second))
+}
+
+func TestListPartsPageTokenKeepsMatchMode(t *testing.T) {
+	ctx := context.Background()
+	repo := mocks.NewMockInventoryRepository(gomock.NewController(t))
+	uc := usecase.NewUseCase(repo, nil, nil)
+
+	filter := models.PartFilter{Query: "quas"}
+	first := models.Part{UUID: uuid.New().String(), Name: "Quasar stabilizer"}
+	second := models.Part{UUID: uuid.New().String(), Name: "Quasar valve"}
+
+	// Способ поиска выбирается только для первой страницы
+	repo.EXPECT().ResolveMatchMode(ctx, filter).Return(models.PartMatchNamePrefix, nil).Times(1)
+	gomock.InOrder(
+		repo.EXPECT().ListParts(ctx, filter, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ models.PartFilter, opts models.PartListOptions) ([]models.Part, error) {
+				require.Equal(t, models.PartMatchNamePrefix, opts.MatchMode)
+				require.Nil(t, opts.After)
+				return []models.Part{first, second}, nil
+			}),
+		repo.EXPECT().ListParts(ctx, filter, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ models.PartFilter, opts models.PartListOptions) ([]models.Part, error) {
+				require.Equal(t, models.PartMatchNamePrefix, opts.MatchMode)
+				// Без текстового индекса детали упорядочены по названию, поэтому курсор хранит название
+				require.Equal(t, &models.PartCursor{Value: first.Name, UUID: first.UUID}, opts.After)
+				return []models.Part{second}, nil
+			}),
+	)
+
+	page := models.PartPageRequest{PageSize: 1}
+	_, nextPageToken, err := uc.ListParts(ctx, filter, page)
+	require.NoError(t, err)
+	require.NotEmpty(t, nextPageToken)
+
+	page.PageToken = nextPageToken
+	_, nextPageToken, err = uc.ListParts(ctx, filter, page)
+	require.NoError(t, err)
+	require.Empty(t, nextPageToken)
+}
+
+func partInfoUUIDs(parts []models.PartInfo) []string {
+	result := make([]string, 0, len(parts))
+	for _, part := range parts {
+		result = append(result, part.UUID)
+	}
+	return result
+}
@@ -42,7 +42,7 @@ func TestUpdatePart(t *testing.T) {
 			fields: fields{
 				repoMock: func() *mocks.MockInventoryRepository {
 					mockRepo := mocks.NewMockInventoryRepository(gomock.NewController(t))
-					mockRepo.EXPECT().UpdatePart(ctx, testUUID, updatePart).Return(nil)
+					mockRepo.EXPECT().UpdatePart(ctx, testUUID, updatePart).Return(models.Part{UUID: testUUID}, nil)
 					return mockRepo
 				},
 			},
@@ -53,7 +53,7 @@ func TestUpdatePart(t *testing.T) {
 			fields: fields{
 				repoMock: func() *mocks.MockInventoryRepository {
 					mockRepo := mocks.NewMockInventoryRepository(gomock.NewController(t))
-					mockRepo.EXPECT().UpdatePart(ctx, testUUID, updatePart).Return(apperrors.ErrPartNotFound)
+					mockRepo.EXPECT().UpdatePart(ctx, testUUID, updatePart).Return(models.Part{}, apperrors.ErrPartNotFound)
 					return mockRepo
 				},
 			},
@@ -64,7 +64,7 @@ func TestUpdatePart(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			inventoryRepo := tt.fields.repoMock()
-			uc := usecase.NewUseCase(inventoryRepo)
+			uc := usecase.NewUseCase(inventoryRepo, nil, nil)
 
 			err := uc.UpdatePart(ctx, testUUID, updatePart)
 
@@ -0,0 +1,142 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/linemk/rocket-shop/inventory/internal/entyties/apperrors"
+	"github.com/linemk/rocket-shop/inventory/internal/entyties/models"
+	"github.com/linemk/rocket-shop/inventory/internal/mocks"
+	"github.com/linemk/rocket-shop/inventory/internal/usecase"
+	inventory_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/inventory/v1"
+)
+
+func TestValidateBuild(t *testing.T) {
+	ctx := context.Background()
+
+	part := func(name string, category inventory_v1.Category) models.Part {
+		return models.Part{UUID: uuid.New().String(), Name: name, Category: category}
+	}
+
+	engine := part("Ion Thruster", inventory_v1.Category_CATEGORY_ENGINE)
+	fuel := part("Plasma Fuel Tank", inventory_v1.Category_CATEGORY_FUEL)
+	porthole := part("Reinforced Porthole", inventory_v1.Category_CATEGORY_PORTHOLE)
+	wing := part("Carbon Fiber Wing", inventory_v1.Category_CATEGORY_WING)
+
+	requiredRules := []models.CompatibilityRule{
+		{UUID: "r-engine", Type: models.CompatibilityRuleRequiredCategory, Category: inventory_v1.Category_CATEGORY_ENGINE},
+		{UUID: "r-fuel", Type: models.CompatibilityRuleRequiredCategory, Category: inventory_v1.Category_CATEGORY_FUEL, MinCount: 2},
+	}
+
+	newUseCase := func(t *testing.T, found []models.Part, rules []models.CompatibilityRule) usecase.InventoryUseCase {
+		ctrl := gomock.NewController(t)
+		repo := mocks.NewMockInventoryRepository(ctrl)
+		repo.EXPECT().ListParts(ctx, gomock.Any(), models.PartListOptions{}).Return(found, nil)
+
+		compatibilityRepo := mocks.NewMockCompatibilityRepository(ctrl)
+		compatibilityRepo.EXPECT().ListRules(ctx).Return(rules, nil)
+
+		return usecase.NewUseCase(repo, compatibilityRepo, nil)
+	}
+
+	t.Run("valid build has no violations", func(t *testing.T) {
+		uc := newUseCase(t, []models.Part{engine, fuel}, requiredRules)
+
+		// Повторяющийся UUID считается второй деталью категории
+		violations, err := uc.ValidateBuild(ctx, []string{engine.UUID, fuel.UUID, fuel.UUID})
+		require.NoError(t, err)
+		require.Empty(t, violations)
+	})
+
+	t.Run("missing categories and unknown parts are reported", func(t *testing.T) {
+		unknownUUID := uuid.New().String()
+		uc := newUseCase(t, []models.Part{fuel}, requiredRules)
+
+		violations, err := uc.ValidateBuild(ctx, []string{fuel.UUID, unknownUUID})
+		require.NoError(t, err)
+		require.Len(t, violations, 3)
+
+		require.Equal(t, inventory_v1.BuildViolationType_BUILD_VIOLATION_TYPE_UNKNOWN_PART, violations[0].Type)
+		require.Equal(t, []string{unknownUUID}, violations[0].PartUUIDs)
+
+		require.Equal(t, inventory_v1.BuildViolationType_BUILD_VIOLATION_TYPE_MISSING_CATEGORY, violations[1].Type)
+		require.Equal(t, inventory_v1.Category_CATEGORY_ENGINE, violations[1].Category)
+		require.Equal(t, "r-engine", violations[1].RuleUUID)
+
+		require.Equal(t, inventory_v1.Category_CATEGORY_FUEL, violations[2].Category)
+		require.Contains(t, violations[2].Message, "at least 2 part(s)")
+	})
+
+	t.Run("incompatible parts and categories are reported once per pair", func(t *testing.T) {
+		rules := []models.CompatibilityRule{
+			{
+				UUID:    "r-porthole-wing",
+				Type:    models.CompatibilityRuleIncompatible,
+				Subject: models.PartSelector{PartUUID: porthole.UUID},
+				Object:  models.PartSelector{Category: inventory_v1.Category_CATEGORY_WING},
+				Reason:  "porthole does not fit carbon wings",
+			},
+		}
+		uc := newUseCase(t, []models.Part{wing, porthole, engine}, rules)
+
+		violations, err := uc.ValidateBuild(ctx, []string{wing.UUID, porthole.UUID, engine.UUID, porthole.UUID})
+		require.NoError(t, err)
+		require.Equal(t, []models.BuildViolation{{
+			Type:      inventory_v1.BuildViolationType_BUILD_VIOLATION_TYPE_INCOMPATIBLE_PARTS,
+			PartUUIDs: []string{wing.UUID, porthole.UUID},
+			RuleUUID:  "r-porthole-wing",
+			Message:   "porthole does not fit carbon wings",
+		}}, violations)
+	})
+
+	t.Run("missing dependency is reported", func(t *testing.T) {
+		rules := []models.CompatibilityRule{
+			{
+				UUID:    "r-porthole-needs-wing",
+				Type:    models.CompatibilityRuleRequires,
+				Subject: models.PartSelector{Category: inventory_v1.Category_CATEGORY_PORTHOLE},
+				Object:  models.PartSelector{Category: inventory_v1.Category_CATEGORY_WING},
+			},
+			{
+				UUID:    "r-engine-needs-fuel",
+				Type:    models.CompatibilityRuleRequires,
+				Subject: models.PartSelector{Category: inventory_v1.Category_CATEGORY_ENGINE},
+				Object:  models.PartSelector{PartUUID: fuel.UUID},
+			},
+		}
+		uc := newUseCase(t, []models.Part{porthole, engine, fuel}, rules)
+
+		violations, err := uc.ValidateBuild(ctx, []string{porthole.UUID, engine.UUID, fuel.UUID})
+		require.NoError(t, err)
+		require.Len(t, violations, 1)
+		require.Equal(t, inventory_v1.BuildViolationType_BUILD_VIOLATION_TYPE_MISSING_DEPENDENCY, violations[0].Type)
+		require.Equal(t, []string{porthole.UUID}, violations[0].PartUUIDs)
+		require.Equal(t, inventory_v1.Category_CATEGORY_WING, violations[0].Category)
+		require.Equal(t, `part "Reinforced Porthole" requires a part of category CATEGORY_WING`, violations[0].Message)
+	})
+
+	t.Run("empty build is invalid request", func(t *testing.T) {
+		uc := usecase.NewUseCase(nil, nil, nil)
+
+		_, err := uc.ValidateBuild(ctx, nil)
+		require.ErrorIs(t, err, apperrors.ErrInvalidBuild)
+	})
+
+	t.Run("rules loading failure is returned", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := mocks.NewMockInventoryRepository(ctrl)
+		repo.EXPECT().ListParts(ctx, gomock.Any(), gomock.Any()).Return([]models.Part{engine}, nil)
+
+		compatibilityRepo := mocks.NewMockCompatibilityRepository(ctrl)
+		compatibilityRepo.EXPECT().ListRules(ctx).Return(nil, errors.New("connection refused"))
+
+		_, err := usecase.NewUseCase(repo, compatibilityRepo, nil).ValidateBuild(ctx, []string{engine.UUID})
+		require.Error(t, err)
+		require.NotErrorIs(t, err, apperrors.ErrInvalidBuild)
+	})
+}
@@ -8,8 +8,14 @@ import (
 )
 
 func (uc *useCase) UpdatePart(ctx context.Context, uuid string, part models.Part) error {
-	if err := uc.inventoryRepository.UpdatePart(ctx, uuid, part); err != nil {
+	// Прежнее состояние нужно событиям: по нему определяются старая цена и изменение остатка.
+	// Репозиторий возвращает его из основного хранилища той же операцией, что и запись
+	previous, err := uc.inventoryRepository.UpdatePart(ctx, uuid, part)
+	if err != nil {
 		return apperrors.ErrPartNotFound
 	}
+
+	uc.publishPartUpdated(ctx, uuid, previous, part)
+
 	return nil
 }
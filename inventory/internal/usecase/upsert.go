@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/linemk/rocket-shop/inventory/internal/entyties/models"
+)
+
+func (uc *useCase) UpsertParts(ctx context.Context, parts []models.Part) error {
+	if len(parts) == 0 {
+		return nil
+	}
+
+	// Прежнее состояние нужно только событиям: по нему созданные детали отличаются от измененных
+	previous := make(map[string]models.Part)
+	if uc.partProducer != nil {
+		uuids := make([]string, 0, len(parts))
+		for _, part := range parts {
+			uuids = append(uuids, part.UUID)
+		}
+
+		existing, err := uc.inventoryRepository.ListParts(ctx, models.PartFilter{UUIDs: uuids}, models.PartListOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to load parts: %w", err)
+		}
+		for _, part := range existing {
+			previous[part.UUID] = part
+		}
+	}
+
+	if err := uc.inventoryRepository.UpsertParts(ctx, parts); err != nil {
+		return fmt.Errorf("failed to upsert parts: %w", err)
+	}
+
+	for _, part := range parts {
+		if current, ok := previous[part.UUID]; ok {
+			uc.publishPartUpdated(ctx, part.UUID, current, part)
+			continue
+		}
+		uc.publishPartCreated(ctx, part)
+	}
+
+	return nil
+}
@@ -5,22 +5,41 @@ import (
 
 	"github.com/linemk/rocket-shop/inventory/internal/entyties/models"
 	"github.com/linemk/rocket-shop/inventory/internal/repository"
+	"github.com/linemk/rocket-shop/inventory/internal/service"
 )
 
 type InventoryUseCase interface {
 	GetPart(ctx context.Context, uuid string) (models.PartInfo, error)
-	ListParts(ctx context.Context, filter models.PartFilter) ([]models.PartInfo, error)
+	// ListParts возвращает страницу деталей, подходящих под фильтр, и токен следующей страницы
+	// (пустой, если страница последняя). Без явной сортировки результаты поиска упорядочиваются
+	// по релевантности, остальные - по названию
+	ListParts(ctx context.Context, filter models.PartFilter, page models.PartPageRequest) ([]models.PartInfo, string, error)
 	CreatePart(ctx context.Context, part models.Part) error
 	UpdatePart(ctx context.Context, uuid string, part models.Part) error
 	DeletePart(ctx context.Context, uuid string) error
+	// UpsertParts создает или заменяет детали пачкой (импорт каталога) и публикует события о них так же,
+	// как CreatePart и UpdatePart
+	UpsertParts(ctx context.Context, parts []models.Part) error
+	// ValidateBuild проверяет сборку ракеты по правилам совместимости и возвращает найденные нарушения.
+	// Неизвестные детали тоже возвращаются нарушением, ошибка означает некорректный запрос или сбой хранилища
+	ValidateBuild(ctx context.Context, partUUIDs []string) ([]models.BuildViolation, error)
 }
 
 type useCase struct {
-	inventoryRepository repository.InventoryRepository
+	inventoryRepository     repository.InventoryRepository
+	compatibilityRepository repository.CompatibilityRepository
+	// partProducer публикует события об изменениях каталога; nil, если публикация отключена
+	partProducer service.PartProducerService
 }
 
-func NewUseCase(inventoryRepository repository.InventoryRepository) InventoryUseCase {
+func NewUseCase(
+	inventoryRepository repository.InventoryRepository,
+	compatibilityRepository repository.CompatibilityRepository,
+	partProducer service.PartProducerService,
+) InventoryUseCase {
 	return &useCase{
-		inventoryRepository: inventoryRepository,
+		inventoryRepository:     inventoryRepository,
+		compatibilityRepository: compatibilityRepository,
+		partProducer:            partProducer,
 	}
 }
@@ -0,0 +1,211 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/linemk/rocket-shop/inventory/internal/entyties/apperrors"
+	"github.com/linemk/rocket-shop/inventory/internal/entyties/models"
+	inventory_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/inventory/v1"
+)
+
+// maxBuildParts ограничивает размер проверяемой сборки
+const maxBuildParts = 500
+
+func (uc *useCase) ValidateBuild(ctx context.Context, partUUIDs []string) ([]models.BuildViolation, error) {
+	if len(partUUIDs) == 0 {
+		return nil, fmt.Errorf("%w: no parts specified", apperrors.ErrInvalidBuild)
+	}
+
+	if len(partUUIDs) > maxBuildParts {
+		return nil, fmt.Errorf("%w: build has %d parts, at most %d allowed", apperrors.ErrInvalidBuild, len(partUUIDs), maxBuildParts)
+	}
+
+	unique := uniqueStrings(partUUIDs)
+
+	found, err := uc.inventoryRepository.ListParts(ctx, models.PartFilter{UUIDs: unique}, models.PartListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load build parts: %w", err)
+	}
+
+	rules, err := uc.compatibilityRepository.ListRules(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load compatibility rules: %w", err)
+	}
+
+	byUUID := make(map[string]models.Part, len(found))
+	for _, part := range found {
+		byUUID[part.UUID] = part
+	}
+
+	var violations []models.BuildViolation
+
+	// Сборка - детали в порядке запроса; повторяющийся UUID учитывается в количестве деталей категории
+	build := make([]models.Part, 0, len(partUUIDs))
+	for _, partUUID := range partUUIDs {
+		if part, ok := byUUID[partUUID]; ok {
+			build = append(build, part)
+		}
+	}
+
+	for _, partUUID := range unique {
+		if _, ok := byUUID[partUUID]; !ok {
+			violations = append(violations, models.BuildViolation{
+				Type:      inventory_v1.BuildViolationType_BUILD_VIOLATION_TYPE_UNKNOWN_PART,
+				PartUUIDs: []string{partUUID},
+				Message:   fmt.Sprintf("part %s not found", partUUID),
+			})
+		}
+	}
+
+	for _, rule := range rules {
+		violations = append(violations, checkRule(rule, build)...)
+	}
+
+	return violations, nil
+}
+
+// checkRule проверяет сборку по одному правилу. Правила неизвестного типа пропускаются,
+// чтобы новые типы правил можно было добавить в хранилище раньше обновления сервиса
+func checkRule(rule models.CompatibilityRule, build []models.Part) []models.BuildViolation {
+	switch rule.Type {
+	case models.CompatibilityRuleRequiredCategory:
+		return checkRequiredCategory(rule, build)
+	case models.CompatibilityRuleIncompatible:
+		return checkIncompatible(rule, build)
+	case models.CompatibilityRuleRequires:
+		return checkRequires(rule, build)
+	}
+
+	return nil
+}
+
+func checkRequiredCategory(rule models.CompatibilityRule, build []models.Part) []models.BuildViolation {
+	need := max(rule.MinCount, 1)
+
+	count := 0
+	for _, part := range build {
+		if part.Category == rule.Category {
+			count++
+		}
+	}
+
+	if count >= need {
+		return nil
+	}
+
+	return []models.BuildViolation{{
+		Type:     inventory_v1.BuildViolationType_BUILD_VIOLATION_TYPE_MISSING_CATEGORY,
+		Category: rule.Category,
+		RuleUUID: rule.UUID,
+		Message: violationMessage(rule, fmt.Sprintf("build needs at least %d part(s) of category %s, got %d",
+			need, rule.Category, count)),
+	}}
+}
+
+// checkIncompatible сообщает о каждой паре несовместимых деталей; правило симметрично
+func checkIncompatible(rule models.CompatibilityRule, build []models.Part) []models.BuildViolation {
+	parts := uniqueParts(build)
+
+	var violations []models.BuildViolation
+	for i := 0; i < len(parts); i++ {
+		for j := i + 1; j < len(parts); j++ {
+			a, b := parts[i], parts[j]
+			if !(matches(rule.Subject, a) && matches(rule.Object, b)) && !(matches(rule.Subject, b) && matches(rule.Object, a)) {
+				continue
+			}
+
+			violations = append(violations, models.BuildViolation{
+				Type:      inventory_v1.BuildViolationType_BUILD_VIOLATION_TYPE_INCOMPATIBLE_PARTS,
+				PartUUIDs: []string{a.UUID, b.UUID},
+				RuleUUID:  rule.UUID,
+				Message:   violationMessage(rule, fmt.Sprintf("part %q is incompatible with part %q", a.Name, b.Name)),
+			})
+		}
+	}
+
+	return violations
+}
+
+func checkRequires(rule models.CompatibilityRule, build []models.Part) []models.BuildViolation {
+	var subjects []models.Part
+	for _, part := range uniqueParts(build) {
+		if matches(rule.Subject, part) {
+			subjects = append(subjects, part)
+		}
+	}
+
+	if len(subjects) == 0 {
+		return nil
+	}
+
+	for _, part := range build {
+		if matches(rule.Object, part) {
+			return nil
+		}
+	}
+
+	subjectUUIDs := make([]string, 0, len(subjects))
+	for _, part := range subjects {
+		subjectUUIDs = append(subjectUUIDs, part.UUID)
+	}
+
+	required := fmt.Sprintf("part %s", rule.Object.PartUUID)
+	if rule.Object.PartUUID == "" {
+		required = fmt.Sprintf("a part of category %s", rule.Object.Category)
+	}
+
+	return []models.BuildViolation{{
+		Type:             inventory_v1.BuildViolationType_BUILD_VIOLATION_TYPE_MISSING_DEPENDENCY,
+		PartUUIDs:        subjectUUIDs,
+		Category:         rule.Object.Category,
+		RequiredPartUUID: rule.Object.PartUUID,
+		RuleUUID:         rule.UUID,
+		Message:          violationMessage(rule, fmt.Sprintf("part %q requires %s", subjects[0].Name, required)),
+	}}
+}
+
+// matches проверяет, что деталь подходит под селектор; пустой селектор не подходит ни одной детали
+func matches(selector models.PartSelector, part models.Part) bool {
+	if selector.PartUUID != "" {
+		return selector.PartUUID == part.UUID
+	}
+
+	return selector.Category != inventory_v1.Category_CATEGORY_UNSPECIFIED && selector.Category == part.Category
+}
+
+func violationMessage(rule models.CompatibilityRule, fallback string) string {
+	if rule.Reason != "" {
+		return rule.Reason
+	}
+
+	return fallback
+}
+
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]struct{}, len(values))
+	unique := make([]string, 0, len(values))
+	for _, value := range values {
+		if _, ok := seen[value]; ok {
+			continue
+		}
+		seen[value] = struct{}{}
+		unique = append(unique, value)
+	}
+
+	return unique
+}
+
+func uniqueParts(parts []models.Part) []models.Part {
+	seen := make(map[string]struct{}, len(parts))
+	unique := make([]models.Part, 0, len(parts))
+	for _, part := range parts {
+		if _, ok := seen[part.UUID]; ok {
+			continue
+		}
+		seen[part.UUID] = struct{}{}
+		unique = append(unique, part)
+	}
+
+	return unique
+}
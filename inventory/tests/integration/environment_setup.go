@@ -30,6 +30,8 @@ const (
 	inventoryGRPCPortKey = "INVENTORY_GRPC_PORT"
 	inventoryMongoURIKey = "INVENTORY_MONGO_URI"
 	inventoryMongoDBKey  = "INVENTORY_MONGO_DB"
+	// IAM в тестовом окружении не поднимается, поэтому проверяем только наличие session-uuid
+	iamSessionValidationKey = "IAM_SESSION_VALIDATION_ENABLED"
 
 	// Значения переменных окружения
 	startupTimeout = 3 * time.Minute
@@ -85,8 +87,9 @@ func setupTestEnvironment(ctx context.Context) *TestEnvironment {
 
 	appEnv := map[string]string{
 		// Переопределяем MongoDB URI для подключения к контейнеру MongoDB из testcontainers
-		inventoryMongoURIKey: mongoURI,
-		inventoryMongoDBKey:  mongoDatabase,
+		inventoryMongoURIKey:    mongoURI,
+		inventoryMongoDBKey:     mongoDatabase,
+		iamSessionValidationKey: "false",
 	}
 
 	// Создаем настраиваемую стратегию ожидания с увеличенным таймаутом
@@ -146,6 +146,79 @@ var _ = Describe("InventoryService", func() {
 				Expect(part.Category).To(Equal(inventoryV1.Category_CATEGORY_ENGINE))
 			}
 		})
+
+		It("должен искать детали по тексту", func() {
+			testPart := env.GetTestPart()
+			testPart.Name = "Quasar stabilizer"
+			partUUID, err := env.InsertTestPartWithData(ctx, testPart)
+			Expect(err).ToNot(HaveOccurred())
+
+			resp, err := inventoryClient.ListParts(ctx, &inventoryV1.ListPartsRequest{
+				Query: "quasar",
+			})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.GetParts()).To(HaveLen(1))
+			Expect(resp.GetParts()[0].Uuid).To(Equal(partUUID))
+		})
+
+		It("должен искать детали по началу названия", func() {
+			testPart := env.GetTestPart()
+			testPart.Name = "Quasar stabilizer"
+			partUUID, err := env.InsertTestPartWithData(ctx, testPart)
+			Expect(err).ToNot(HaveOccurred())
+
+			resp, err := inventoryClient.ListParts(ctx, &inventoryV1.ListPartsRequest{
+				Query: "QUAS",
+			})
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.GetParts()).To(HaveLen(1))
+			Expect(resp.GetParts()[0].Uuid).To(Equal(partUUID))
+		})
+
+		It("должен возвращать детали постранично", func() {
+			seen := make(map[string]bool)
+			req := &inventoryV1.ListPartsRequest{
+				SortField: inventoryV1.PartsSortField_PARTS_SORT_FIELD_PRICE,
+				PageSize:  2,
+			}
+
+			for {
+				resp, err := inventoryClient.ListParts(ctx, req)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(len(resp.GetParts())).To(BeNumerically("<=", 2))
+
+				for _, part := range resp.GetParts() {
+					Expect(seen).ToNot(HaveKey(part.Uuid), "страницы не должны пересекаться")
+					seen[part.Uuid] = true
+				}
+
+				if resp.GetNextPageToken() == "" {
+					break
+				}
+				req.PageToken = resp.GetNextPageToken()
+			}
+
+			Expect(seen).To(HaveLen(5))
+		})
+
+		It("должен отклонять токен страницы, выданный для другого фильтра", func() {
+			resp, err := inventoryClient.ListParts(ctx, &inventoryV1.ListPartsRequest{
+				SortField: inventoryV1.PartsSortField_PARTS_SORT_FIELD_PRICE,
+				PageSize:  2,
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resp.GetNextPageToken()).ToNot(BeEmpty())
+
+			_, err = inventoryClient.ListParts(ctx, &inventoryV1.ListPartsRequest{
+				SortField:   inventoryV1.PartsSortField_PARTS_SORT_FIELD_PRICE,
+				InStockOnly: true,
+				PageSize:    2,
+				PageToken:   resp.GetNextPageToken(),
+			})
+			Expect(err).To(HaveOccurred())
+		})
 	})
 
 	Describe("Полный жизненный цикл", func() {
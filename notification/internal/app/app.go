@@ -2,10 +2,13 @@ package app
 
 import (
 	"context"
+	"fmt"
 
 	"go.uber.org/zap"
 
 	"github.com/linemk/rocket-shop/notification/internal/config"
+	"github.com/linemk/rocket-shop/platform/pkg/cache"
+	rediscache "github.com/linemk/rocket-shop/platform/pkg/cache/redis"
 	"github.com/linemk/rocket-shop/platform/pkg/closer"
 	"github.com/linemk/rocket-shop/platform/pkg/logger"
 )
@@ -35,7 +38,7 @@ func (a *App) Run(ctx context.Context) error {
 		closer.Wait()
 	}()
 
-	// Запускаем оба Kafka consumers параллельно
+	// Запускаем все Kafka consumers параллельно
 	return a.runConsumers(ctx)
 }
 
@@ -45,6 +48,7 @@ func (a *App) initDeps(ctx context.Context) error {
 		a.initLogger,
 		a.initCloser,
 		a.initDiContainer,
+		a.initCache,
 	}
 
 	for _, f := range inits {
@@ -87,9 +91,40 @@ func (a *App) initDiContainer(_ context.Context) error {
 	return nil
 }
 
+func (a *App) initCache(ctx context.Context) error {
+	redisConfig := config.AppConfig().Redis
+	cacheClient, err := rediscache.NewClient(cache.Config{
+		Addr:         redisConfig.Addr(),
+		Password:     redisConfig.Password(),
+		DB:           redisConfig.DB(),
+		DialTimeout:  redisConfig.DialTimeout(),
+		ReadTimeout:  redisConfig.ReadTimeout(),
+		WriteTimeout: redisConfig.WriteTimeout(),
+		PoolSize:     redisConfig.PoolSize(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create cache client: %w", err)
+	}
+
+	if err := cacheClient.Ping(ctx); err != nil {
+		return fmt.Errorf("failed to ping Redis: %w", err)
+	}
+
+	logger.Info(ctx, "Successfully connected to Redis")
+
+	// Сохраняем клиент в DI контейнер для реестра удаленных пользователей
+	a.diContainer.SetCacheClient(cacheClient)
+
+	closer.AddNamed("Redis cache", func(ctx context.Context) error {
+		return cacheClient.Close()
+	})
+
+	return nil
+}
+
 func (a *App) runConsumers(ctx context.Context) error {
-	// Запускаем оба consumer'а одновременно
-	errChan := make(chan error, 2)
+	// Запускаем все consumer'ы одновременно
+	errChan := make(chan error, 4)
 
 	go func() {
 		errChan <- a.diContainer.OrderPaidConsumer(ctx).RunConsumer(ctx)
@@ -99,6 +134,14 @@ func (a *App) runConsumers(ctx context.Context) error {
 		errChan <- a.diContainer.OrderAssembledConsumer(ctx).RunConsumer(ctx)
 	}()
 
+	go func() {
+		errChan <- a.diContainer.AuthTokenConsumer(ctx).RunConsumer(ctx)
+	}()
+
+	go func() {
+		errChan <- a.diContainer.UserErasedConsumer(ctx).RunConsumer(ctx)
+	}()
+
 	// Ждем первой ошибки
 	return <-errChan
 }
@@ -11,12 +11,18 @@ import (
 	"github.com/linemk/rocket-shop/notification/internal/client/http/telegram"
 	"github.com/linemk/rocket-shop/notification/internal/config"
 	"github.com/linemk/rocket-shop/notification/internal/service"
+	"github.com/linemk/rocket-shop/notification/internal/service/consumer/auth_token_consumer"
 	"github.com/linemk/rocket-shop/notification/internal/service/consumer/order_assembled_consumer"
 	"github.com/linemk/rocket-shop/notification/internal/service/consumer/order_paid_consumer"
+	"github.com/linemk/rocket-shop/notification/internal/service/consumer/user_erased_consumer"
+	emailService "github.com/linemk/rocket-shop/notification/internal/service/email"
+	erasedUsersService "github.com/linemk/rocket-shop/notification/internal/service/erased_users"
 	telegramService "github.com/linemk/rocket-shop/notification/internal/service/telegram"
+	"github.com/linemk/rocket-shop/platform/pkg/cache"
 	"github.com/linemk/rocket-shop/platform/pkg/closer"
 	"github.com/linemk/rocket-shop/platform/pkg/kafka/consumer"
 	"github.com/linemk/rocket-shop/platform/pkg/logger"
+	"github.com/linemk/rocket-shop/platform/pkg/mailer/smtp"
 	kafkaMiddleware "github.com/linemk/rocket-shop/platform/pkg/middleware/kafka"
 )
 
@@ -28,17 +34,31 @@ type LoggerInterface interface {
 type diContainer struct {
 	orderPaidConsumerOnce      sync.Once
 	orderAssembledConsumerOnce sync.Once
+	authTokenConsumerOnce      sync.Once
+	userErasedConsumerOnce     sync.Once
+	erasedUsersOnce            sync.Once
 	telegramServiceOnce        sync.Once
+	emailServiceOnce           sync.Once
 
 	orderPaidConsumer      *order_paid_consumer.Consumer
 	orderAssembledConsumer *order_assembled_consumer.Consumer
+	authTokenConsumer      *auth_token_consumer.Consumer
+	userErasedConsumer     *user_erased_consumer.Consumer
+	erasedUsers            service.ErasedUsersRegistry
 	telegramService        service.TelegramService
+	emailService           service.EmailService
+
+	cacheClient cache.Client
 }
 
 func NewDiContainer() *diContainer {
 	return &diContainer{}
 }
 
+func (d *diContainer) SetCacheClient(cacheClient cache.Client) {
+	d.cacheClient = cacheClient
+}
+
 //nolint:dupl // Дублирование с OrderAssembledConsumer неизбежно из-за разных типов
 func (d *diContainer) OrderPaidConsumer(ctx context.Context) *order_paid_consumer.Consumer {
 	d.orderPaidConsumerOnce.Do(func() {
@@ -70,7 +90,7 @@ func (d *diContainer) OrderPaidConsumer(ctx context.Context) *order_paid_consume
 		)
 
 		// Создаем handler
-		handler := order_paid_consumer.NewHandler(d.TelegramService(ctx), d.Logger(ctx))
+		handler := order_paid_consumer.NewHandler(d.TelegramService(ctx), d.ErasedUsers(ctx), d.Logger(ctx))
 
 		d.orderPaidConsumer = order_paid_consumer.NewConsumer(kafkaConsumer, handler, d.Logger(ctx))
 	})
@@ -109,7 +129,7 @@ func (d *diContainer) OrderAssembledConsumer(ctx context.Context) *order_assembl
 		)
 
 		// Создаем handler
-		handler := order_assembled_consumer.NewHandler(d.TelegramService(ctx), d.Logger(ctx))
+		handler := order_assembled_consumer.NewHandler(d.TelegramService(ctx), d.ErasedUsers(ctx), d.Logger(ctx))
 
 		d.orderAssembledConsumer = order_assembled_consumer.NewConsumer(kafkaConsumer, handler, d.Logger(ctx))
 	})
@@ -117,6 +137,106 @@ func (d *diContainer) OrderAssembledConsumer(ctx context.Context) *order_assembl
 	return d.orderAssembledConsumer
 }
 
+//nolint:dupl // Дублирование с OrderPaidConsumer неизбежно из-за разных типов
+func (d *diContainer) AuthTokenConsumer(ctx context.Context) *auth_token_consumer.Consumer {
+	d.authTokenConsumerOnce.Do(func() {
+		// Создаем Kafka consumer group
+		saramaConfig := sarama.NewConfig()
+		saramaConfig.Version = sarama.V2_6_0_0
+		saramaConfig.Consumer.Group.Rebalance.Strategy = sarama.NewBalanceStrategyRoundRobin()
+		saramaConfig.Consumer.Offsets.Initial = sarama.OffsetOldest
+
+		consumerGroup, err := sarama.NewConsumerGroup(
+			config.AppConfig().Kafka.Brokers(),
+			config.AppConfig().AuthTokenConsumer.GroupID(),
+			saramaConfig,
+		)
+		if err != nil {
+			panic(fmt.Sprintf("failed to create Kafka consumer group for AuthTokenIssued: %s\n", err.Error()))
+		}
+
+		closer.AddNamed("Kafka consumer group for AuthTokenIssued", func(ctx context.Context) error {
+			return consumerGroup.Close()
+		})
+
+		// Создаем Kafka consumer с middleware
+		kafkaConsumer := consumer.NewConsumer(
+			consumerGroup,
+			[]string{config.AppConfig().AuthTokenConsumer.Topic()},
+			d.Logger(ctx),
+			kafkaMiddleware.Logging(d.Logger(ctx)),
+		)
+
+		// Создаем handler
+		handler := auth_token_consumer.NewHandler(d.EmailService(ctx), d.ErasedUsers(ctx), d.Logger(ctx))
+
+		d.authTokenConsumer = auth_token_consumer.NewConsumer(kafkaConsumer, handler, d.Logger(ctx))
+	})
+
+	return d.authTokenConsumer
+}
+
+//nolint:dupl // Дублирование с OrderPaidConsumer неизбежно из-за разных типов
+func (d *diContainer) UserErasedConsumer(ctx context.Context) *user_erased_consumer.Consumer {
+	d.userErasedConsumerOnce.Do(func() {
+		// Создаем Kafka consumer group
+		saramaConfig := sarama.NewConfig()
+		saramaConfig.Version = sarama.V2_6_0_0
+		saramaConfig.Consumer.Group.Rebalance.Strategy = sarama.NewBalanceStrategyRoundRobin()
+		saramaConfig.Consumer.Offsets.Initial = sarama.OffsetOldest
+
+		consumerGroup, err := sarama.NewConsumerGroup(
+			config.AppConfig().Kafka.Brokers(),
+			config.AppConfig().UserErasedConsumer.GroupID(),
+			saramaConfig,
+		)
+		if err != nil {
+			panic(fmt.Sprintf("failed to create Kafka consumer group for UserErased: %s\n", err.Error()))
+		}
+
+		closer.AddNamed("Kafka consumer group for UserErased", func(ctx context.Context) error {
+			return consumerGroup.Close()
+		})
+
+		// Создаем Kafka consumer с middleware
+		kafkaConsumer := consumer.NewConsumer(
+			consumerGroup,
+			[]string{config.AppConfig().UserErasedConsumer.Topic()},
+			d.Logger(ctx),
+			kafkaMiddleware.Logging(d.Logger(ctx)),
+		)
+
+		// Создаем handler
+		handler := user_erased_consumer.NewHandler(d.ErasedUsers(ctx), d.Logger(ctx))
+
+		d.userErasedConsumer = user_erased_consumer.NewConsumer(kafkaConsumer, handler, d.Logger(ctx))
+	})
+
+	return d.userErasedConsumer
+}
+
+func (d *diContainer) ErasedUsers(_ context.Context) service.ErasedUsersRegistry {
+	d.erasedUsersOnce.Do(func() {
+		d.erasedUsers = erasedUsersService.NewService(d.cacheClient, config.AppConfig().UserErasedConsumer.SuppressionTTL())
+	})
+
+	return d.erasedUsers
+}
+
+func (d *diContainer) EmailService(ctx context.Context) service.EmailService {
+	d.emailServiceOnce.Do(func() {
+		sender := smtp.NewSender(smtp.Config{
+			Addr:     config.AppConfig().SMTP.Addr(),
+			From:     config.AppConfig().SMTP.From(),
+			Username: config.AppConfig().SMTP.Username(),
+			Password: config.AppConfig().SMTP.Password(),
+		})
+		d.emailService = emailService.NewService(sender, d.Logger(ctx))
+	})
+
+	return d.emailService
+}
+
 func (d *diContainer) TelegramService(ctx context.Context) service.TelegramService {
 	d.telegramServiceOnce.Do(func() {
 		telegramClient := telegram.NewClient(
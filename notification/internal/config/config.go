@@ -13,9 +13,13 @@ var appConfig *config
 type config struct {
 	Logger                 LoggerConfig
 	Kafka                  KafkaConfig
+	Redis                  RedisConfig
 	TelegramBot            TelegramBotConfig
+	SMTP                   SMTPConfig
 	OrderPaidConsumer      OrderPaidConsumerConfig
 	OrderAssembledConsumer OrderAssembledConsumerConfig
+	AuthTokenConsumer      AuthTokenConsumerConfig
+	UserErasedConsumer     UserErasedConsumerConfig
 }
 
 // Load загружает конфигурацию из переменных окружения
@@ -35,11 +39,21 @@ func Load(path ...string) error {
 		return err
 	}
 
+	redisCfg, err := env.NewRedisConfig()
+	if err != nil {
+		return err
+	}
+
 	telegramBotCfg, err := env.NewTelegramBotConfig()
 	if err != nil {
 		return err
 	}
 
+	smtpCfg, err := env.NewSMTPConfig()
+	if err != nil {
+		return err
+	}
+
 	orderPaidConsumerCfg, err := env.NewOrderPaidConsumerConfig()
 	if err != nil {
 		return err
@@ -50,12 +64,26 @@ func Load(path ...string) error {
 		return err
 	}
 
+	authTokenConsumerCfg, err := env.NewAuthTokenConsumerConfig()
+	if err != nil {
+		return err
+	}
+
+	userErasedConsumerCfg, err := env.NewUserErasedConsumerConfig()
+	if err != nil {
+		return err
+	}
+
 	appConfig = &config{
 		Logger:                 loggerCfg,
 		Kafka:                  kafkaCfg,
+		Redis:                  redisCfg,
 		TelegramBot:            telegramBotCfg,
+		SMTP:                   smtpCfg,
 		OrderPaidConsumer:      orderPaidConsumerCfg,
 		OrderAssembledConsumer: orderAssembledConsumerCfg,
+		AuthTokenConsumer:      authTokenConsumerCfg,
+		UserErasedConsumer:     userErasedConsumerCfg,
 	}
 
 	return nil
@@ -0,0 +1,42 @@
+package env
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	authTokenConsumerTopicEnvName   = "AUTH_TOKEN_CONSUMER_TOPIC"
+	authTokenConsumerGroupIDEnvName = "AUTH_TOKEN_CONSUMER_GROUP_ID"
+)
+
+type authTokenConsumerConfig struct {
+	topic   string
+	groupID string
+}
+
+func NewAuthTokenConsumerConfig() (*authTokenConsumerConfig, error) {
+	topic := os.Getenv(authTokenConsumerTopicEnvName)
+	if len(topic) == 0 {
+		return nil, errors.New("auth token consumer topic is required")
+	}
+
+	groupID := os.Getenv(authTokenConsumerGroupIDEnvName)
+	if len(groupID) == 0 {
+		return nil, errors.New("auth token consumer group id is required")
+	}
+
+	return &authTokenConsumerConfig{
+		topic:   topic,
+		groupID: groupID,
+	}, nil
+}
+
+func (cfg *authTokenConsumerConfig) Topic() string {
+	return cfg.topic
+}
+
+func (cfg *authTokenConsumerConfig) GroupID() string {
+	return cfg.groupID
+}
@@ -0,0 +1,56 @@
+package env
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	smtpAddrEnvName     = "SMTP_ADDR"
+	smtpFromEnvName     = "SMTP_FROM"
+	smtpUsernameEnvName = "SMTP_USERNAME"
+	smtpPasswordEnvName = "SMTP_PASSWORD" //nolint:gosec // G101: это имя переменной окружения, а не сам пароль
+)
+
+type smtpConfig struct {
+	addr     string
+	from     string
+	username string
+	password string
+}
+
+func NewSMTPConfig() (*smtpConfig, error) {
+	addr := os.Getenv(smtpAddrEnvName)
+	if len(addr) == 0 {
+		return nil, errors.New("smtp addr is required")
+	}
+
+	from := os.Getenv(smtpFromEnvName)
+	if len(from) == 0 {
+		return nil, errors.New("smtp from is required")
+	}
+
+	return &smtpConfig{
+		addr:     addr,
+		from:     from,
+		username: os.Getenv(smtpUsernameEnvName),
+		password: os.Getenv(smtpPasswordEnvName),
+	}, nil
+}
+
+func (cfg *smtpConfig) Addr() string {
+	return cfg.addr
+}
+
+func (cfg *smtpConfig) From() string {
+	return cfg.from
+}
+
+func (cfg *smtpConfig) Username() string {
+	return cfg.username
+}
+
+func (cfg *smtpConfig) Password() string {
+	return cfg.password
+}
@@ -0,0 +1,62 @@
+package env
+
+import (
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	userErasedConsumerTopicEnvName   = "USER_ERASED_CONSUMER_TOPIC"
+	userErasedConsumerGroupIDEnvName = "USER_ERASED_CONSUMER_GROUP_ID"
+	userErasedSuppressionTTLEnvName  = "USER_ERASED_SUPPRESSION_TTL"
+
+	defaultUserErasedSuppressionTTL = 24 * time.Hour
+)
+
+type userErasedConsumerConfig struct {
+	topic          string
+	groupID        string
+	suppressionTTL time.Duration
+}
+
+func NewUserErasedConsumerConfig() (*userErasedConsumerConfig, error) {
+	topic := os.Getenv(userErasedConsumerTopicEnvName)
+	if len(topic) == 0 {
+		return nil, errors.New("user erased consumer topic is required")
+	}
+
+	groupID := os.Getenv(userErasedConsumerGroupIDEnvName)
+	if len(groupID) == 0 {
+		return nil, errors.New("user erased consumer group id is required")
+	}
+
+	suppressionTTL := defaultUserErasedSuppressionTTL
+	if ttlStr := os.Getenv(userErasedSuppressionTTLEnvName); ttlStr != "" {
+		ttl, err := time.ParseDuration(ttlStr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid %s", userErasedSuppressionTTLEnvName)
+		}
+		suppressionTTL = ttl
+	}
+
+	return &userErasedConsumerConfig{
+		topic:          topic,
+		groupID:        groupID,
+		suppressionTTL: suppressionTTL,
+	}, nil
+}
+
+func (cfg *userErasedConsumerConfig) Topic() string {
+	return cfg.topic
+}
+
+func (cfg *userErasedConsumerConfig) GroupID() string {
+	return cfg.groupID
+}
+
+// SuppressionTTL возвращает время, в течение которого уведомления удаленному пользователю не отправляются
+func (cfg *userErasedConsumerConfig) SuppressionTTL() time.Duration {
+	return cfg.suppressionTTL
+}
@@ -1,5 +1,7 @@
 package config
 
+import "time"
+
 //go:generate ../../bin/mockery --name LoggerConfig --output ./mocks --outpkg mocks --filename mock_logger_config.go
 type LoggerConfig interface {
 	Level() string
@@ -14,12 +16,31 @@ type KafkaConfig interface {
 	Brokers() []string
 }
 
+//go:generate ../../bin/mockery --name RedisConfig --output ./mocks --outpkg mocks --filename mock_redis_config.go
+type RedisConfig interface {
+	Addr() string
+	Password() string
+	DB() int
+	DialTimeout() time.Duration
+	ReadTimeout() time.Duration
+	WriteTimeout() time.Duration
+	PoolSize() int
+}
+
 //go:generate ../../bin/mockery --name TelegramBotConfig --output ./mocks --outpkg mocks --filename mock_telegram_bot_config.go
 type TelegramBotConfig interface {
 	Token() string
 	ChatID() string
 }
 
+//go:generate ../../bin/mockery --name SMTPConfig --output ./mocks --outpkg mocks --filename mock_smtp_config.go
+type SMTPConfig interface {
+	Addr() string
+	From() string
+	Username() string
+	Password() string
+}
+
 //go:generate ../../bin/mockery --name OrderPaidConsumerConfig --output ./mocks --outpkg mocks --filename mock_order_paid_consumer_config.go
 type OrderPaidConsumerConfig interface {
 	Topic() string
@@ -31,3 +52,16 @@ type OrderAssembledConsumerConfig interface {
 	Topic() string
 	GroupID() string
 }
+
+//go:generate ../../bin/mockery --name AuthTokenConsumerConfig --output ./mocks --outpkg mocks --filename mock_auth_token_consumer_config.go
+type AuthTokenConsumerConfig interface {
+	Topic() string
+	GroupID() string
+}
+
+//go:generate ../../bin/mockery --name UserErasedConsumerConfig --output ./mocks --outpkg mocks --filename mock_user_erased_consumer_config.go
+type UserErasedConsumerConfig interface {
+	Topic() string
+	GroupID() string
+	SuppressionTTL() time.Duration
+}
@@ -0,0 +1,32 @@
+package decoder
+
+import (
+	"encoding/json"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/linemk/rocket-shop/notification/internal/model"
+	events_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/events/v1"
+)
+
+// DecodeAuthTokenIssued декодирует событие AuthTokenIssued из JSON
+func DecodeAuthTokenIssued(data []byte) (*model.AuthTokenIssuedEvent, error) {
+	var protoEvent events_v1.AuthTokenIssued
+
+	// Пробуем protojson
+	if err := protojson.Unmarshal(data, &protoEvent); err != nil {
+		// Если не получилось, пробуем обычный JSON
+		if err := json.Unmarshal(data, &protoEvent); err != nil {
+			return nil, err
+		}
+	}
+
+	return &model.AuthTokenIssuedEvent{
+		EventUUID: protoEvent.EventUuid,
+		UserUUID:  protoEvent.UserUuid,
+		Email:     protoEvent.Email,
+		Purpose:   protoEvent.Purpose,
+		Token:     protoEvent.Token,
+		TTLSec:    protoEvent.TtlSec,
+	}, nil
+}
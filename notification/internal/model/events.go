@@ -1,5 +1,7 @@
 package model
 
+import "time"
+
 // OrderPaidEvent представляет событие об оплате заказа
 type OrderPaidEvent struct {
 	EventUUID       string
@@ -16,3 +18,20 @@ type ShipAssembledEvent struct {
 	UserUUID     string
 	BuildTimeSec int64
 }
+
+// AuthTokenIssuedEvent представляет событие о выпуске одноразового токена для пользователя
+type AuthTokenIssuedEvent struct {
+	EventUUID string
+	UserUUID  string
+	Email     string
+	Purpose   string
+	Token     string
+	TTLSec    int64
+}
+
+// UserErasedEvent представляет событие об удалении персональных данных пользователя
+type UserErasedEvent struct {
+	EventUUID string
+	UserUUID  string
+	ErasedAt  time.Time
+}
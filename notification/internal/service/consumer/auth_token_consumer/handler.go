@@ -0,0 +1,65 @@
+package auth_token_consumer
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/linemk/rocket-shop/notification/internal/converter/kafka/decoder"
+	"github.com/linemk/rocket-shop/notification/internal/service"
+	platformKafka "github.com/linemk/rocket-shop/platform/pkg/kafka"
+)
+
+type handler struct {
+	emailService service.EmailService
+	erasedUsers  service.ErasedUsersRegistry
+	logger       Logger
+}
+
+func NewHandler(emailService service.EmailService, erasedUsers service.ErasedUsersRegistry, logger Logger) platformKafka.MessageHandler {
+	h := &handler{
+		emailService: emailService,
+		erasedUsers:  erasedUsers,
+		logger:       logger,
+	}
+
+	return h.Handle
+}
+
+func (h *handler) Handle(ctx context.Context, msg platformKafka.Message) error {
+	h.logger.Info(ctx, "Received AuthTokenIssued event", zap.String("topic", msg.Topic))
+
+	// Декодируем событие
+	event, err := decoder.DecodeAuthTokenIssued(msg.Value)
+	if err != nil {
+		h.logger.Error(ctx, "Failed to decode AuthTokenIssued event", zap.Error(err))
+		return err
+	}
+
+	erased, err := h.erasedUsers.IsErased(ctx, event.UserUUID)
+	if err != nil {
+		h.logger.Error(ctx, "Failed to check erased user", zap.Error(err))
+		return err
+	}
+
+	if erased {
+		h.logger.Info(ctx, "Skipping auth token email for erased user",
+			zap.String("event_uuid", event.EventUUID),
+		)
+		return nil
+	}
+
+	// Токен в логи не пишем
+	h.logger.Info(ctx, "Processing auth token email",
+		zap.String("user_uuid", event.UserUUID),
+		zap.String("purpose", event.Purpose),
+	)
+
+	// Отправляем письмо с токеном
+	if err := h.emailService.SendAuthToken(ctx, event); err != nil {
+		h.logger.Error(ctx, "Failed to send auth token email", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
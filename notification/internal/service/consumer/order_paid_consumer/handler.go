@@ -12,12 +12,14 @@ import (
 
 type handler struct {
 	telegramService service.TelegramService
+	erasedUsers     service.ErasedUsersRegistry
 	logger          Logger
 }
 
-func NewHandler(telegramService service.TelegramService, logger Logger) platformKafka.MessageHandler {
+func NewHandler(telegramService service.TelegramService, erasedUsers service.ErasedUsersRegistry, logger Logger) platformKafka.MessageHandler {
 	h := &handler{
 		telegramService: telegramService,
+		erasedUsers:     erasedUsers,
 		logger:          logger,
 	}
 
@@ -34,6 +36,19 @@ func (h *handler) Handle(ctx context.Context, msg platformKafka.Message) error {
 		return err
 	}
 
+	erased, err := h.erasedUsers.IsErased(ctx, event.UserUUID)
+	if err != nil {
+		h.logger.Error(ctx, "Failed to check erased user", zap.Error(err))
+		return err
+	}
+
+	if erased {
+		h.logger.Info(ctx, "Skipping order paid notification for erased user",
+			zap.String("event_uuid", event.EventUUID),
+		)
+		return nil
+	}
+
 	h.logger.Info(ctx, "Processing order paid notification",
 		zap.String("order_uuid", event.OrderUUID),
 		zap.String("user_uuid", event.UserUUID),
@@ -0,0 +1,48 @@
+package user_erased_consumer
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/linemk/rocket-shop/notification/internal/converter/kafka/decoder"
+	"github.com/linemk/rocket-shop/notification/internal/service"
+	platformKafka "github.com/linemk/rocket-shop/platform/pkg/kafka"
+)
+
+type handler struct {
+	erasedUsers service.ErasedUsersRegistry
+	logger      Logger
+}
+
+func NewHandler(erasedUsers service.ErasedUsersRegistry, logger Logger) platformKafka.MessageHandler {
+	h := &handler{
+		erasedUsers: erasedUsers,
+		logger:      logger,
+	}
+
+	return h.Handle
+}
+
+func (h *handler) Handle(ctx context.Context, msg platformKafka.Message) error {
+	h.logger.Info(ctx, "Received UserErased event", zap.String("topic", msg.Topic))
+
+	event, err := decoder.DecodeUserErased(msg.Value)
+	if err != nil {
+		h.logger.Error(ctx, "Failed to decode UserErased event", zap.Error(err))
+		return err
+	}
+
+	// Сервис не хранит данные пользователей, поэтому достаточно перестать
+	// отправлять уведомления по событиям, опубликованным до удаления
+	if err := h.erasedUsers.MarkErased(ctx, event.UserUUID); err != nil {
+		h.logger.Error(ctx, "Failed to mark user as erased", zap.Error(err))
+		return err
+	}
+
+	h.logger.Info(ctx, "Notifications suppressed for erased user",
+		zap.String("event_uuid", event.EventUUID),
+	)
+
+	return nil
+}
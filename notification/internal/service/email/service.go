@@ -0,0 +1,95 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"text/template"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/linemk/rocket-shop/notification/internal/model"
+	"github.com/linemk/rocket-shop/platform/pkg/mailer"
+)
+
+//go:embed templates/password_reset.tmpl
+var passwordResetTemplate string
+
+//go:embed templates/email_verification.tmpl
+var emailVerificationTemplate string
+
+const (
+	purposePasswordReset     = "password_reset"
+	purposeEmailVerification = "email_verification"
+)
+
+type Logger interface {
+	Info(ctx context.Context, msg string, fields ...zap.Field)
+	Error(ctx context.Context, msg string, fields ...zap.Field)
+}
+
+type service struct {
+	sender mailer.Sender
+	logger Logger
+}
+
+func NewService(sender mailer.Sender, logger Logger) *service {
+	return &service{
+		sender: sender,
+		logger: logger,
+	}
+}
+
+func (s *service) SendAuthToken(ctx context.Context, event *model.AuthTokenIssuedEvent) error {
+	s.logger.Info(ctx, "Sending auth token email",
+		zap.String("user_uuid", event.UserUUID),
+		zap.String("purpose", event.Purpose),
+	)
+
+	var subject, tmpl string
+	switch event.Purpose {
+	case purposePasswordReset:
+		subject, tmpl = "Сброс пароля", passwordResetTemplate
+	case purposeEmailVerification:
+		subject, tmpl = "Подтверждение email", emailVerificationTemplate
+	default:
+		return errors.Errorf("unknown auth token purpose %q", event.Purpose)
+	}
+
+	body, err := render(event.Purpose, tmpl, event)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to render auth token email", zap.Error(err))
+		return err
+	}
+
+	err = s.sender.Send(ctx, mailer.Message{
+		To:      []string{event.Email},
+		Subject: subject,
+		Body:    body,
+	})
+	if err != nil {
+		s.logger.Error(ctx, "Failed to send auth token email", zap.Error(err))
+		return err
+	}
+
+	s.logger.Info(ctx, "Auth token email sent successfully",
+		zap.String("user_uuid", event.UserUUID),
+	)
+
+	return nil
+}
+
+func render(name, text string, data any) (string, error) {
+	tmpl, err := template.New(name).Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
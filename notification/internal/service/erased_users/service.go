@@ -0,0 +1,54 @@
+package erased_users
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/linemk/rocket-shop/platform/pkg/cache"
+)
+
+const erasedUserKeyPrefix = "erased_user:"
+
+// service хранит в Redis пользователей, чьи данные удалены, чтобы не отправлять им
+// уведомления по событиям, которые уже находились в очереди в момент удаления.
+// Записи переживают перезапуск сервиса и истекают сами по TTL
+type service struct {
+	cache cache.Client
+	ttl   time.Duration
+}
+
+func NewService(cache cache.Client, ttl time.Duration) *service {
+	return &service{
+		cache: cache,
+		ttl:   ttl,
+	}
+}
+
+func (s *service) MarkErased(ctx context.Context, userUUID string) error {
+	err := s.cache.Set(ctx, erasedUserKey(userUUID), []byte{1}, s.ttl)
+	if err != nil {
+		return errors.Wrap(err, "failed to save erased user to Redis")
+	}
+
+	return nil
+}
+
+func (s *service) IsErased(ctx context.Context, userUUID string) (bool, error) {
+	if userUUID == "" {
+		return false, nil
+	}
+
+	exists, err := s.cache.Exists(ctx, erasedUserKey(userUUID))
+	if err != nil {
+		return false, errors.Wrap(err, "failed to check erased user in Redis")
+	}
+
+	return exists, nil
+}
+
+func erasedUserKey(userUUID string) string {
+	return fmt.Sprintf("%s%s", erasedUserKeyPrefix, userUUID)
+}
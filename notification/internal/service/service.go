@@ -16,3 +16,14 @@ type TelegramService interface {
 	SendOrderPaidNotification(ctx context.Context, event *model.OrderPaidEvent) error
 	SendOrderAssembledNotification(ctx context.Context, event *model.ShipAssembledEvent) error
 }
+
+//go:generate ../../bin/mockery --name EmailService --output ./mocks --outpkg mocks --filename mock_email_service.go
+type EmailService interface {
+	SendAuthToken(ctx context.Context, event *model.AuthTokenIssuedEvent) error
+}
+
+//go:generate ../../bin/mockery --name ErasedUsersRegistry --output ./mocks --outpkg mocks --filename mock_erased_users_registry.go
+type ErasedUsersRegistry interface {
+	MarkErased(ctx context.Context, userUUID string) error
+	IsErased(ctx context.Context, userUUID string) (bool, error)
+}
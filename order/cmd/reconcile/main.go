@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+
+	"github.com/linemk/rocket-shop/order/internal/app"
+	"github.com/linemk/rocket-shop/order/internal/config"
+	"github.com/linemk/rocket-shop/order/internal/converter/reconciliation"
+	"github.com/linemk/rocket-shop/order/internal/service"
+	"github.com/linemk/rocket-shop/order/internal/usecase"
+	"github.com/linemk/rocket-shop/platform/pkg/closer"
+	"github.com/linemk/rocket-shop/platform/pkg/logger"
+)
+
+// Сверка заказов с транзакциями PaymentService.
+// Пример: go run ./cmd/reconcile -since 72h -format csv -out report.csv
+func main() {
+	since := flag.Duration("since", 24*time.Hour, "сверять заказы, созданные за этот период")
+	grace := flag.Duration("grace", 10*time.Minute, "не считать потерянными результаты платежей моложе этого периода")
+	format := flag.String("format", "json", "формат отчета: json или csv")
+	out := flag.String("out", "", "файл отчета (по умолчанию reconciliation-report.<format>)")
+	fix := flag.Bool("fix", false, "применить пропущенные результаты платежей к заказам")
+	flag.Parse()
+
+	ctx := context.Background()
+
+	if err := config.Load(); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to load config: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := logger.Init(ctx, config.AppConfig().Logger.Level(), false, false, "", "order-reconcile"); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to init logger: %v\n", err)
+		os.Exit(1)
+	}
+	defer func() {
+		_ = closer.CloseAll(ctx) //nolint:gosec // best-effort shutdown
+		_ = logger.Close(ctx)    //nolint:gosec // best-effort shutdown
+		_ = logger.Sync()        //nolint:gosec // best-effort shutdown
+	}()
+
+	params := usecase.ReconcileParams{
+		Since:       time.Now().Add(-*since),
+		GracePeriod: *grace,
+		Fix:         *fix,
+	}
+
+	if err := run(ctx, params, *format, *out); err != nil {
+		logger.Error(ctx, "Reconciliation failed", zap.Error(err))
+		os.Exit(1) //nolint:gocritic // ресурсы освобождаются процессом
+	}
+}
+
+func run(ctx context.Context, params usecase.ReconcileParams, format, out string) error {
+	writeReport := reconciliation.WriteJSON
+	switch format {
+	case "json":
+	case "csv":
+		writeReport = reconciliation.WriteCSV
+	default:
+		return fmt.Errorf("unknown report format %q", format)
+	}
+
+	pool, err := pgxpool.New(ctx, config.AppConfig().Postgres.DSN())
+	if err != nil {
+		return fmt.Errorf("failed to create pool: %w", err)
+	}
+	closer.AddNamed("PostgreSQL pool", func(ctx context.Context) error {
+		pool.Close()
+		return nil
+	})
+
+	di := app.NewDiContainer()
+	di.SetDBPool(pool)
+
+	// Kafka нужна только для исправлений: переведенный в PAID заказ публикует OrderPaid
+	var orderProducer service.OrderProducerService
+	if params.Fix {
+		orderProducer = di.OrderProducerService(ctx)
+	}
+
+	orderUseCase := usecase.NewUseCase(di.OrderRepository(ctx), nil, di.PaymentClient(ctx), orderProducer, nil)
+
+	report, err := orderUseCase.Reconcile(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	// Отчет пишется в файл: stdout занят логами
+	if out == "" {
+		out = "reconciliation-report." + format
+	}
+
+	file, err := os.Create(out) //nolint:gosec // путь задает оператор
+	if err != nil {
+		return fmt.Errorf("failed to create report file: %w", err)
+	}
+
+	if err := writeReport(file, report); err != nil {
+		_ = file.Close() //nolint:gosec // исходная ошибка важнее
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+
+	logger.Info(ctx, "Reconciliation finished",
+		zap.Int("orders_checked", report.OrdersChecked),
+		zap.Int("discrepancies", len(report.Discrepancies)),
+		zap.Int("errors", len(report.Errors)),
+		zap.String("report", out),
+	)
+
+	return nil
+}
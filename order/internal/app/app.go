@@ -71,6 +71,27 @@ func (a *App) Run(ctx context.Context) error {
 		}
 	}()
 
+	// Запускаем Kafka consumer событий UserErased в отдельной горутине
+	go func() {
+		if err := a.diContainer.UserErasedConsumerService(ctx).RunConsumers(ctx); err != nil {
+			logger.Error(ctx, fmt.Sprintf("UserErased consumer error: %v", err))
+		}
+	}()
+
+	// Запускаем Kafka consumer событий оплаты в отдельной горутине
+	go func() {
+		if err := a.diContainer.PaymentConsumerService(ctx).RunConsumers(ctx); err != nil {
+			logger.Error(ctx, fmt.Sprintf("Payment events consumer error: %v", err))
+		}
+	}()
+
+	// Запускаем фоновую сверку заказов с платежами
+	go func() {
+		if err := a.diContainer.ReconciliationJob(ctx).Run(ctx); err != nil {
+			logger.Error(ctx, fmt.Sprintf("Reconciliation job error: %v", err))
+		}
+	}()
+
 	// Запускаем HTTP сервер
 	return a.runHTTPServer(ctx)
 }
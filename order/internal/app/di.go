@@ -15,6 +15,9 @@ import (
 	"github.com/linemk/rocket-shop/order/internal/repository"
 	"github.com/linemk/rocket-shop/order/internal/service"
 	"github.com/linemk/rocket-shop/order/internal/service/consumer/order_consumer"
+	"github.com/linemk/rocket-shop/order/internal/service/consumer/payment_consumer"
+	"github.com/linemk/rocket-shop/order/internal/service/consumer/user_erased_consumer"
+	"github.com/linemk/rocket-shop/order/internal/service/job/reconciliation_job"
 	"github.com/linemk/rocket-shop/order/internal/service/producer/order_producer"
 	"github.com/linemk/rocket-shop/order/internal/usecase"
 	"github.com/linemk/rocket-shop/platform/pkg/closer"
@@ -38,8 +41,11 @@ type diContainer struct {
 	paymentClient   paymentClient.PaymentClient
 	iamClient       *iamclient.Client
 
-	consumerService      service.ConsumerService
-	orderProducerService service.OrderProducerService
+	consumerService           service.ConsumerService
+	userErasedConsumerService service.ConsumerService
+	paymentConsumerService    service.ConsumerService
+	orderProducerService      service.OrderProducerService
+	reconciliationJob         service.JobService
 
 	prometheusMetrics *prommetrics.Metrics
 	orderMetrics      *ordermetrics.OrderMetrics
@@ -157,6 +163,88 @@ func (d *diContainer) ConsumerService(ctx context.Context) service.ConsumerServi
 	return d.consumerService
 }
 
+func (d *diContainer) UserErasedConsumerService(ctx context.Context) service.ConsumerService {
+	if d.userErasedConsumerService == nil {
+		saramaConfig := sarama.NewConfig()
+		saramaConfig.Version = sarama.V2_6_0_0
+		saramaConfig.Consumer.Group.Rebalance.Strategy = sarama.NewBalanceStrategyRoundRobin()
+		saramaConfig.Consumer.Offsets.Initial = sarama.OffsetOldest
+
+		consumerGroup, err := sarama.NewConsumerGroup(
+			config.AppConfig().Kafka.Brokers(),
+			config.AppConfig().UserErasedConsumer.GroupID(),
+			saramaConfig,
+		)
+		if err != nil {
+			panic(fmt.Sprintf("failed to create Kafka consumer group for UserErased: %s\n", err.Error()))
+		}
+
+		closer.AddNamed("Kafka consumer group for UserErased", func(ctx context.Context) error {
+			return consumerGroup.Close()
+		})
+
+		kafkaConsumer := consumer.NewConsumer(
+			consumerGroup,
+			[]string{config.AppConfig().UserErasedConsumer.Topic()},
+			logger.Logger(),
+			kafkaMiddleware.Logging(logger.Logger()),
+		)
+
+		handler := user_erased_consumer.NewHandler(d.OrderRepository(ctx), logger.Logger())
+
+		d.userErasedConsumerService = user_erased_consumer.NewConsumer(kafkaConsumer, handler, logger.Logger())
+	}
+
+	return d.userErasedConsumerService
+}
+
+func (d *diContainer) PaymentConsumerService(ctx context.Context) service.ConsumerService {
+	if d.paymentConsumerService == nil {
+		saramaConfig := sarama.NewConfig()
+		saramaConfig.Version = sarama.V2_6_0_0
+		saramaConfig.Consumer.Group.Rebalance.Strategy = sarama.NewBalanceStrategyRoundRobin()
+		saramaConfig.Consumer.Offsets.Initial = sarama.OffsetOldest
+
+		consumerGroup, err := sarama.NewConsumerGroup(
+			config.AppConfig().Kafka.Brokers(),
+			config.AppConfig().PaymentEventsConsumer.GroupID(),
+			saramaConfig,
+		)
+		if err != nil {
+			panic(fmt.Sprintf("failed to create Kafka consumer group for payment events: %s\n", err.Error()))
+		}
+
+		closer.AddNamed("Kafka consumer group for payment events", func(ctx context.Context) error {
+			return consumerGroup.Close()
+		})
+
+		kafkaConsumer := consumer.NewConsumer(
+			consumerGroup,
+			[]string{config.AppConfig().PaymentEventsConsumer.Topic()},
+			logger.Logger(),
+			kafkaMiddleware.Logging(logger.Logger()),
+		)
+
+		handler := payment_consumer.NewHandler(d.OrderUseCase(ctx), logger.Logger())
+
+		d.paymentConsumerService = payment_consumer.NewConsumer(kafkaConsumer, handler, logger.Logger())
+	}
+
+	return d.paymentConsumerService
+}
+
+func (d *diContainer) ReconciliationJob(ctx context.Context) service.JobService {
+	if d.reconciliationJob == nil {
+		d.reconciliationJob = reconciliation_job.NewJob(
+			d.OrderUseCase(ctx),
+			config.AppConfig().Reconciliation,
+			logger.Logger(),
+		)
+	}
+
+	return d.reconciliationJob
+}
+
 func (d *diContainer) OrderProducerService(ctx context.Context) service.OrderProducerService {
 	if d.orderProducerService == nil {
 		// Создаем Kafka sync producer
@@ -226,6 +314,21 @@ func (d *diContainer) OrderMetrics() *ordermetrics.OrderMetrics {
 				"Total revenue from orders",
 				[]string{"payment_method"},
 			),
+			ReconciliationRunsTotal: pm.NewCounter(
+				"orders_reconciliation_runs_total",
+				"Total number of order and payment reconciliation runs",
+				[]string{"result"},
+			),
+			ReconciliationDiscrepancies: pm.NewGauge(
+				"orders_reconciliation_discrepancies",
+				"Number of discrepancies found by the last reconciliation run",
+				[]string{"type"},
+			),
+			ReconciliationCorrectionsTotal: pm.NewCounter(
+				"orders_reconciliation_corrections_total",
+				"Total number of discrepancies corrected by reconciliation",
+				[]string{"type"},
+			),
 		}
 	}
 
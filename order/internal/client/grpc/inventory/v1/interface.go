@@ -8,5 +8,6 @@ import (
 
 type InventoryClient interface {
 	GetPart(ctx context.Context, partUUID uuid.UUID) (PartInfo, error)
+	ValidateBuild(ctx context.Context, partUUIDs []uuid.UUID) ([]BuildViolation, error)
 	Close() error
 }
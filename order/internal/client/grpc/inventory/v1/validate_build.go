@@ -0,0 +1,42 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	inventory_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/inventory/v1"
+)
+
+// BuildViolation нарушение правил совместимости деталей
+type BuildViolation struct {
+	Type      string
+	PartUUIDs []string
+	Message   string
+}
+
+func (c *Client) ValidateBuild(ctx context.Context, partUUIDs []uuid.UUID) ([]BuildViolation, error) {
+	uuids := make([]string, 0, len(partUUIDs))
+	for _, partUUID := range partUUIDs {
+		uuids = append(uuids, partUUID.String())
+	}
+
+	resp, err := c.client.ValidateBuild(ctx, &inventory_v1.ValidateBuildRequest{
+		PartUuids: uuids,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate build: %w", err)
+	}
+
+	violations := make([]BuildViolation, 0, len(resp.GetViolations()))
+	for _, v := range resp.GetViolations() {
+		violations = append(violations, BuildViolation{
+			Type:      v.GetType().String(),
+			PartUUIDs: v.GetPartUuids(),
+			Message:   v.GetMessage(),
+		})
+	}
+
+	return violations, nil
+}
@@ -1,6 +1,7 @@
 package converter
 
 import (
+	"github.com/linemk/rocket-shop/order/internal/entyties/models"
 	order_v1 "github.com/linemk/rocket-shop/shared/pkg/openapi/order/v1"
 	payment_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/payment/v1"
 )
@@ -21,3 +22,16 @@ func OpenAPIPaymentMethodToProto(openAPIMethod order_v1.PaymentMethod) payment_v
 		return payment_v1.PaymentMethod_PAYMENT_METHOD_UNSPECIFIED
 	}
 }
+
+// TendersToProto конвертирует части раздельной оплаты в protobuf
+func TendersToProto(tenders []models.Tender) []*payment_v1.Tender {
+	result := make([]*payment_v1.Tender, 0, len(tenders))
+	for _, tender := range tenders {
+		result = append(result, &payment_v1.Tender{
+			PaymentMethod: OpenAPIPaymentMethodToProto(tender.PaymentMethod),
+			Amount:        tender.Amount,
+		})
+	}
+
+	return result
+}
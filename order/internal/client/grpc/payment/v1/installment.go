@@ -0,0 +1,45 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/linemk/rocket-shop/order/internal/entyties/models"
+	payment_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/payment/v1"
+)
+
+func (c *Client) QuoteInstallments(ctx context.Context, amount float64) ([]models.InstallmentQuote, error) {
+	resp, err := c.client.QuoteInstallments(ctx, &payment_v1.QuoteInstallmentsRequest{
+		Amount: amount,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to quote installments: %w", err)
+	}
+
+	quotes := make([]models.InstallmentQuote, 0, len(resp.Quotes))
+	for _, quote := range resp.Quotes {
+		quotes = append(quotes, models.InstallmentQuote{
+			Installments: int(quote.GetInstallments()),
+			MonthlyRate:  quote.GetMonthlyRate(),
+			Payments:     quote.GetPayments(),
+			TotalAmount:  quote.GetTotalAmount(),
+			Overpayment:  quote.GetOverpayment(),
+		})
+	}
+
+	return quotes, nil
+}
+
+func (c *Client) CreateInstallmentPlan(ctx context.Context, orderUUID, userUUID string, amount float64, installments int) (string, error) {
+	resp, err := c.client.CreateInstallmentPlan(ctx, &payment_v1.CreateInstallmentPlanRequest{
+		OrderUuid:    orderUUID,
+		UserUuid:     userUUID,
+		Amount:       amount,
+		Installments: int32(installments), //nolint:gosec // число платежей проверяется PaymentService
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create installment plan: %w", err)
+	}
+
+	return resp.GetPlan().GetPlanUuid(), nil
+}
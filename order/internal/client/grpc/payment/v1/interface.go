@@ -3,10 +3,22 @@ package v1
 import (
 	"context"
 
+	"github.com/linemk/rocket-shop/order/internal/entyties/models"
 	payment_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/payment/v1"
 )
 
 type PaymentClient interface {
-	PayOrder(ctx context.Context, orderUUID, userUUID string, paymentMethod payment_v1.PaymentMethod) (string, error)
+	// PayOrder передает оплату заказа. Транзакция создается с UUID idempotencyKey: повтор с тем же ключом
+	// возвращает ее, а не списывает заказ повторно
+	PayOrder(ctx context.Context, orderUUID, userUUID string, paymentMethod payment_v1.PaymentMethod, amount float64, idempotencyKey string) (models.PaymentResult, error)
+	// PaySplit передает раздельную оплату заказа; в результате возвращается UUID группы транзакций частей и ее статус.
+	// Группа создается с UUID idempotencyKey, повтор с тем же ключом возвращает ее
+	PaySplit(ctx context.Context, orderUUID, userUUID string, amount float64, tenders []models.Tender, idempotencyKey string) (models.PaymentResult, error)
+	// ListTransactions возвращает все транзакции заказа
+	ListTransactions(ctx context.Context, orderUUID string) ([]models.PaymentTransaction, error)
+	// QuoteInstallments возвращает доступные варианты рассрочки по кредитной карте для суммы
+	QuoteInstallments(ctx context.Context, amount float64) ([]models.InstallmentQuote, error)
+	// CreateInstallmentPlan создает план рассрочки заказа и возвращает его UUID
+	CreateInstallmentPlan(ctx context.Context, orderUUID, userUUID string, amount float64, installments int) (string, error)
 	Close() error
 }
@@ -0,0 +1,34 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/linemk/rocket-shop/order/internal/entyties/models"
+	payment_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/payment/v1"
+)
+
+func (c *Client) ListTransactions(ctx context.Context, orderUUID string) ([]models.PaymentTransaction, error) {
+	resp, err := c.client.ListTransactions(ctx, &payment_v1.ListTransactionsRequest{
+		OrderUuid: orderUUID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list order transactions: %w", err)
+	}
+
+	transactions := make([]models.PaymentTransaction, 0, len(resp.Transactions))
+	for _, transaction := range resp.Transactions {
+		transactions = append(transactions, models.PaymentTransaction{
+			UUID:                transaction.GetTransactionUuid(),
+			Status:              transaction.GetStatus(),
+			Amount:              transaction.GetAmount(),
+			FailureReason:       transaction.GetFailureReason(),
+			InstallmentPlanUUID: transaction.GetInstallmentPlanUuid(),
+			SplitUUID:           transaction.GetSplitUuid(),
+			CreatedAt:           transaction.GetCreatedAt().AsTime(),
+			UpdatedAt:           transaction.GetUpdatedAt().AsTime(),
+		})
+	}
+
+	return transactions, nil
+}
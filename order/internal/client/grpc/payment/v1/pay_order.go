@@ -4,18 +4,24 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/linemk/rocket-shop/order/internal/entyties/models"
 	payment_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/payment/v1"
 )
 
-func (c *Client) PayOrder(ctx context.Context, orderUUID, userUUID string, paymentMethod payment_v1.PaymentMethod) (string, error) {
+func (c *Client) PayOrder(ctx context.Context, orderUUID, userUUID string, paymentMethod payment_v1.PaymentMethod, amount float64, idempotencyKey string) (models.PaymentResult, error) {
 	resp, err := c.client.PayOrder(ctx, &payment_v1.PayOrderRequest{
-		OrderUuid:     orderUUID,
-		UserUuid:      userUUID,
-		PaymentMethod: paymentMethod,
+		OrderUuid:      orderUUID,
+		UserUuid:       userUUID,
+		PaymentMethod:  paymentMethod,
+		Amount:         amount,
+		IdempotencyKey: idempotencyKey,
 	})
 	if err != nil {
-		return "", fmt.Errorf("failed to pay order: %w", err)
+		return models.PaymentResult{}, fmt.Errorf("failed to pay order: %w", err)
 	}
 
-	return resp.TransactionUuid, nil
+	return models.PaymentResult{
+		TransactionUUID: resp.TransactionUuid,
+		Status:          resp.Status,
+	}, nil
 }
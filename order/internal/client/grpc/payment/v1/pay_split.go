@@ -0,0 +1,28 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/linemk/rocket-shop/order/internal/client/grpc/payment/converter"
+	"github.com/linemk/rocket-shop/order/internal/entyties/models"
+	payment_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/payment/v1"
+)
+
+func (c *Client) PaySplit(ctx context.Context, orderUUID, userUUID string, amount float64, tenders []models.Tender, idempotencyKey string) (models.PaymentResult, error) {
+	resp, err := c.client.PaySplit(ctx, &payment_v1.PaySplitRequest{
+		OrderUuid:      orderUUID,
+		UserUuid:       userUUID,
+		Amount:         amount,
+		Tenders:        converter.TendersToProto(tenders),
+		IdempotencyKey: idempotencyKey,
+	})
+	if err != nil {
+		return models.PaymentResult{}, fmt.Errorf("failed to pay order in split: %w", err)
+	}
+
+	return models.PaymentResult{
+		TransactionUUID: resp.GetSplitUuid(),
+		Status:          resp.GetStatus(),
+	}, nil
+}
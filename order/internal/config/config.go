@@ -21,6 +21,9 @@ type config struct {
 	Kafka                  KafkaConfig
 	OrderPaidProducer      OrderPaidProducerConfig
 	OrderAssembledConsumer OrderAssembledConsumerConfig
+	UserErasedConsumer     UserErasedConsumerConfig
+	PaymentEventsConsumer  PaymentEventsConsumerConfig
+	Reconciliation         ReconciliationConfig
 }
 
 // Load загружает конфигурацию из переменных окружения
@@ -80,6 +83,21 @@ func Load(path ...string) error {
 		return err
 	}
 
+	userErasedConsumerCfg, err := env.NewUserErasedConsumerConfig()
+	if err != nil {
+		return err
+	}
+
+	paymentEventsConsumerCfg, err := env.NewPaymentEventsConsumerConfig()
+	if err != nil {
+		return err
+	}
+
+	reconciliationCfg, err := env.NewReconciliationConfig()
+	if err != nil {
+		return err
+	}
+
 	appConfig = &config{
 		Logger:                 loggerCfg,
 		OrderHTTP:              orderHTTPCfg,
@@ -91,6 +109,9 @@ func Load(path ...string) error {
 		Kafka:                  kafkaCfg,
 		OrderPaidProducer:      orderPaidProducerCfg,
 		OrderAssembledConsumer: orderAssembledConsumerCfg,
+		UserErasedConsumer:     userErasedConsumerCfg,
+		PaymentEventsConsumer:  paymentEventsConsumerCfg,
+		Reconciliation:         reconciliationCfg,
 	}
 
 	return nil
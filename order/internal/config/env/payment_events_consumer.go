@@ -0,0 +1,42 @@
+package env
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	paymentEventsConsumerTopicEnvName   = "PAYMENT_EVENTS_CONSUMER_TOPIC"
+	paymentEventsConsumerGroupIDEnvName = "PAYMENT_EVENTS_CONSUMER_GROUP_ID"
+)
+
+type paymentEventsConsumerConfig struct {
+	topic   string
+	groupID string
+}
+
+func NewPaymentEventsConsumerConfig() (*paymentEventsConsumerConfig, error) {
+	topic := os.Getenv(paymentEventsConsumerTopicEnvName)
+	if len(topic) == 0 {
+		return nil, errors.New("payment events consumer topic is required")
+	}
+
+	groupID := os.Getenv(paymentEventsConsumerGroupIDEnvName)
+	if len(groupID) == 0 {
+		return nil, errors.New("payment events consumer group id is required")
+	}
+
+	return &paymentEventsConsumerConfig{
+		topic:   topic,
+		groupID: groupID,
+	}, nil
+}
+
+func (cfg *paymentEventsConsumerConfig) Topic() string {
+	return cfg.topic
+}
+
+func (cfg *paymentEventsConsumerConfig) GroupID() string {
+	return cfg.groupID
+}
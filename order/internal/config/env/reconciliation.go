@@ -0,0 +1,80 @@
+package env
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+const (
+	reconciliationIntervalEnv    = "RECONCILIATION_INTERVAL"
+	reconciliationLookbackEnv    = "RECONCILIATION_LOOKBACK"
+	reconciliationGracePeriodEnv = "RECONCILIATION_GRACE_PERIOD"
+	reconciliationFixEnv         = "RECONCILIATION_FIX"
+
+	defaultReconciliationInterval    = time.Hour
+	defaultReconciliationLookback    = 24 * time.Hour
+	defaultReconciliationGracePeriod = 10 * time.Minute
+)
+
+type reconciliationConfig struct {
+	interval    time.Duration
+	lookback    time.Duration
+	gracePeriod time.Duration
+	fix         bool
+}
+
+// NewReconciliationConfig создает конфигурацию сверки заказов с платежами из переменных окружения
+func NewReconciliationConfig() (*reconciliationConfig, error) {
+	interval, err := durationEnv(reconciliationIntervalEnv, defaultReconciliationInterval)
+	if err != nil {
+		return nil, err
+	}
+
+	lookback, err := durationEnv(reconciliationLookbackEnv, defaultReconciliationLookback)
+	if err != nil {
+		return nil, err
+	}
+
+	gracePeriod, err := durationEnv(reconciliationGracePeriodEnv, defaultReconciliationGracePeriod)
+	if err != nil {
+		return nil, err
+	}
+
+	return &reconciliationConfig{
+		interval:    interval,
+		lookback:    lookback,
+		gracePeriod: gracePeriod,
+		fix:         os.Getenv(reconciliationFixEnv) == "true",
+	}, nil
+}
+
+func (c *reconciliationConfig) Interval() time.Duration {
+	return c.interval
+}
+
+func (c *reconciliationConfig) Lookback() time.Duration {
+	return c.lookback
+}
+
+func (c *reconciliationConfig) GracePeriod() time.Duration {
+	return c.gracePeriod
+}
+
+func (c *reconciliationConfig) Fix() bool {
+	return c.fix
+}
+
+func durationEnv(name string, defaultValue time.Duration) (time.Duration, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", name, err)
+	}
+
+	return duration, nil
+}
@@ -0,0 +1,42 @@
+package env
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	userErasedConsumerTopicEnvName   = "USER_ERASED_CONSUMER_TOPIC"
+	userErasedConsumerGroupIDEnvName = "USER_ERASED_CONSUMER_GROUP_ID"
+)
+
+type userErasedConsumerConfig struct {
+	topic   string
+	groupID string
+}
+
+func NewUserErasedConsumerConfig() (*userErasedConsumerConfig, error) {
+	topic := os.Getenv(userErasedConsumerTopicEnvName)
+	if len(topic) == 0 {
+		return nil, errors.New("user erased consumer topic is required")
+	}
+
+	groupID := os.Getenv(userErasedConsumerGroupIDEnvName)
+	if len(groupID) == 0 {
+		return nil, errors.New("user erased consumer group id is required")
+	}
+
+	return &userErasedConsumerConfig{
+		topic:   topic,
+		groupID: groupID,
+	}, nil
+}
+
+func (cfg *userErasedConsumerConfig) Topic() string {
+	return cfg.topic
+}
+
+func (cfg *userErasedConsumerConfig) GroupID() string {
+	return cfg.groupID
+}
@@ -1,5 +1,7 @@
 package config
 
+import "time"
+
 // LoggerConfig интерфейс конфигурации логгера
 type LoggerConfig interface {
 	Level() string
@@ -51,6 +53,30 @@ type OrderAssembledConsumerConfig interface {
 	GroupID() string
 }
 
+// UserErasedConsumerConfig интерфейс конфигурации Kafka consumer для UserErased
+type UserErasedConsumerConfig interface {
+	Topic() string
+	GroupID() string
+}
+
+// PaymentEventsConsumerConfig интерфейс конфигурации Kafka consumer для событий оплаты
+type PaymentEventsConsumerConfig interface {
+	Topic() string
+	GroupID() string
+}
+
+// ReconciliationConfig интерфейс конфигурации фоновой сверки заказов с платежами
+type ReconciliationConfig interface {
+	// Interval период запуска сверки, 0 отключает фоновую сверку
+	Interval() time.Duration
+	// Lookback сверяются заказы, созданные за этот период
+	Lookback() time.Duration
+	// GracePeriod время, в течение которого результат платежа еще может быть в пути
+	GracePeriod() time.Duration
+	// Fix применять пропущенные результаты платежей к заказам
+	Fix() bool
+}
+
 // IAMGRPCConfig интерфейс конфигурации gRPC клиента для IAM
 type IAMGRPCConfig interface {
 	Address() string
@@ -0,0 +1,88 @@
+package decoder
+
+import (
+	"errors"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/linemk/rocket-shop/order/internal/entyties/events"
+	events_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/events/v1"
+)
+
+// ErrUnknownPaymentEvent возвращается, если конверт PaymentEvent не содержит известного события
+var ErrUnknownPaymentEvent = errors.New("unknown payment event")
+
+// DecodePaymentEvent декодирует конверт PaymentEvent из JSON
+func DecodePaymentEvent(data []byte) (events.PaymentEvent, error) {
+	var protoEvent events_v1.PaymentEvent
+
+	if err := protojson.Unmarshal(data, &protoEvent); err != nil {
+		return events.PaymentEvent{}, err
+	}
+
+	switch event := protoEvent.Event.(type) {
+	case *events_v1.PaymentEvent_Completed:
+		return events.PaymentEvent{Completed: &events.PaymentCompletedEvent{
+			EventUUID:       event.Completed.EventUuid,
+			TransactionUUID: event.Completed.TransactionUuid,
+			OrderUUID:       event.Completed.OrderUuid,
+			UserUUID:        event.Completed.UserUuid,
+			PaymentMethod:   event.Completed.PaymentMethod,
+			Amount:          event.Completed.Amount,
+			CompletedAt:     event.Completed.CompletedAt.AsTime(),
+		}}, nil
+	case *events_v1.PaymentEvent_Failed:
+		return events.PaymentEvent{Failed: &events.PaymentFailedEvent{
+			EventUUID:       event.Failed.EventUuid,
+			TransactionUUID: event.Failed.TransactionUuid,
+			OrderUUID:       event.Failed.OrderUuid,
+			UserUUID:        event.Failed.UserUuid,
+			Status:          event.Failed.Status,
+			Reason:          event.Failed.Reason,
+			FailedAt:        event.Failed.FailedAt.AsTime(),
+		}}, nil
+	case *events_v1.PaymentEvent_InstallmentPaid:
+		return events.PaymentEvent{InstallmentPaid: &events.InstallmentPaidEvent{
+			EventUUID:         event.InstallmentPaid.EventUuid,
+			PlanUUID:          event.InstallmentPaid.PlanUuid,
+			TransactionUUID:   event.InstallmentPaid.TransactionUuid,
+			OrderUUID:         event.InstallmentPaid.OrderUuid,
+			UserUUID:          event.InstallmentPaid.UserUuid,
+			InstallmentNumber: int(event.InstallmentPaid.InstallmentNumber),
+			InstallmentsTotal: int(event.InstallmentPaid.InstallmentsTotal),
+			Amount:            event.InstallmentPaid.Amount,
+			PaidAmount:        event.InstallmentPaid.PaidAmount,
+			RemainingAmount:   event.InstallmentPaid.RemainingAmount,
+			PlanCompleted:     event.InstallmentPaid.PlanCompleted,
+			PaidAt:            event.InstallmentPaid.PaidAt.AsTime(),
+		}}, nil
+	case *events_v1.PaymentEvent_Overdue:
+		return events.PaymentEvent{Overdue: &events.PaymentOverdueEvent{
+			EventUUID:         event.Overdue.EventUuid,
+			PlanUUID:          event.Overdue.PlanUuid,
+			OrderUUID:         event.Overdue.OrderUuid,
+			UserUUID:          event.Overdue.UserUuid,
+			InstallmentNumber: int(event.Overdue.InstallmentNumber),
+			Amount:            event.Overdue.Amount,
+			DueAt:             event.Overdue.DueAt.AsTime(),
+			Reason:            event.Overdue.Reason,
+			DetectedAt:        event.Overdue.DetectedAt.AsTime(),
+		}}, nil
+	case *events_v1.PaymentEvent_ApprovalRequested:
+		return events.PaymentEvent{ApprovalRequested: &events.ApprovalRequestedEvent{
+			EventUUID:       event.ApprovalRequested.EventUuid,
+			ApprovalUUID:    event.ApprovalRequested.ApprovalUuid,
+			TransactionUUID: event.ApprovalRequested.TransactionUuid,
+			SplitUUID:       event.ApprovalRequested.SplitUuid,
+			OrderUUID:       event.ApprovalRequested.OrderUuid,
+			UserUUID:        event.ApprovalRequested.UserUuid,
+			Amount:          event.ApprovalRequested.Amount,
+			Approvers:       event.ApprovalRequested.Approvers,
+			Quorum:          int(event.ApprovalRequested.Quorum),
+			ExpiresAt:       event.ApprovalRequested.ExpiresAt.AsTime(),
+			RequestedAt:     event.ApprovalRequested.RequestedAt.AsTime(),
+		}}, nil
+	default:
+		return events.PaymentEvent{}, ErrUnknownPaymentEvent
+	}
+}
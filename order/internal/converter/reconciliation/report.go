@@ -0,0 +1,61 @@
+package reconciliation
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+
+	"github.com/linemk/rocket-shop/order/internal/entyties/models"
+)
+
+var csvHeader = []string{
+	"type",
+	"order_uuid",
+	"order_status",
+	"order_amount",
+	"transaction_uuid",
+	"transaction_status",
+	"transaction_amount",
+	"corrected",
+	"details",
+}
+
+// WriteJSON записывает отчет сверки целиком в JSON
+func WriteJSON(w io.Writer, report models.ReconciliationReport) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(report)
+}
+
+// WriteCSV записывает расхождения отчета сверки в CSV, по одной строке на расхождение
+func WriteCSV(w io.Writer, report models.ReconciliationReport) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for _, discrepancy := range report.Discrepancies {
+		record := []string{
+			string(discrepancy.Type),
+			discrepancy.OrderUUID,
+			string(discrepancy.OrderStatus),
+			strconv.FormatFloat(discrepancy.OrderAmount, 'f', 2, 64),
+			discrepancy.TransactionUUID,
+			discrepancy.TransactionStatus,
+			strconv.FormatFloat(discrepancy.TransactionAmount, 'f', 2, 64),
+			strconv.FormatBool(discrepancy.Corrected),
+			discrepancy.Details,
+		}
+
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
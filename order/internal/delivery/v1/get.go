@@ -32,6 +32,11 @@ func (a *api) GetOrder(ctx context.Context, params order_v1.GetOrderParams) (ord
 		TransactionUUID: transactionUUID,
 		PaymentMethod:   order.PaymentMethod,
 		Status:          order.Status,
+		Tenders:         tendersToAPI(order.Tenders),
+	}
+
+	if order.Installments > 0 {
+		response.Installments = order_v1.NewOptInt(order.Installments)
 	}
 
 	return response, nil
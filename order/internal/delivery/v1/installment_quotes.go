@@ -0,0 +1,37 @@
+package v1
+
+import (
+	"context"
+	"errors"
+
+	"github.com/linemk/rocket-shop/order/internal/entyties/apperrors"
+	order_v1 "github.com/linemk/rocket-shop/shared/pkg/openapi/order/v1"
+)
+
+func (a *api) GetInstallmentQuotes(ctx context.Context, params order_v1.GetInstallmentQuotesParams) (order_v1.GetInstallmentQuotesRes, error) {
+	quotes, err := a.orderUseCase.GetInstallmentQuotes(ctx, params.OrderUUID.String())
+	if err != nil {
+		if errors.Is(err, apperrors.ErrOrderNotFound) {
+			return &order_v1.NotFoundErr{
+				Code:    404,
+				Message: "Order not found",
+			}, nil
+		}
+		return nil, err
+	}
+
+	response := &order_v1.GetInstallmentQuotesResp{
+		Quotes: make([]order_v1.InstallmentQuote, 0, len(quotes)),
+	}
+	for _, quote := range quotes {
+		response.Quotes = append(response.Quotes, order_v1.InstallmentQuote{
+			Installments: quote.Installments,
+			MonthlyRate:  quote.MonthlyRate,
+			Payments:     quote.Payments,
+			TotalAmount:  quote.TotalAmount,
+			Overpayment:  quote.Overpayment,
+		})
+	}
+
+	return response, nil
+}
@@ -0,0 +1,50 @@
+package v1
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	order_v1 "github.com/linemk/rocket-shop/shared/pkg/openapi/order/v1"
+)
+
+func (a *api) ListUserOrders(ctx context.Context, params order_v1.ListUserOrdersParams) (*order_v1.ListUserOrdersResp, error) {
+	orders, err := a.orderUseCase.ListUserOrders(ctx, params.UserUUID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	response := &order_v1.ListUserOrdersResp{
+		Orders: make([]order_v1.GetOrderResp, 0, len(orders)),
+	}
+
+	for _, order := range orders {
+		// Поврежденная строка в БД не должна ронять обработчик: отдаем ошибку вместо паники
+		orderUUID, err := uuid.Parse(order.UUID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid order uuid %q", order.UUID)
+		}
+
+		var transactionUUID uuid.UUID
+		if order.TransactionID != "" {
+			transactionUUID, err = uuid.Parse(order.TransactionID)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid transaction uuid of order %s", order.UUID)
+			}
+		}
+
+		response.Orders = append(response.Orders, order_v1.GetOrderResp{
+			OrderUUID:       orderUUID,
+			UserUUID:        params.UserUUID,
+			PartUuids:       order.PartUUIDs,
+			TotalPrice:      order.TotalPrice,
+			TransactionUUID: transactionUUID,
+			PaymentMethod:   order.PaymentMethod,
+			Status:          order.Status,
+			Tenders:         tendersToAPI(order.Tenders),
+		})
+	}
+
+	return response, nil
+}
@@ -11,9 +11,11 @@ import (
 
 func (a *api) PayOrder(ctx context.Context, req *order_v1.PayOrderReq, params order_v1.PayOrderParams) (order_v1.PayOrderRes, error) {
 	orderID := params.OrderUUID.String()
-	paymentMethod := req.PaymentMethod
+	paymentMethod := req.PaymentMethod.Or(order_v1.PaymentMethodPAYMENTMETHODUNSPECIFIED)
 
-	transactionUUID, err := a.orderUseCase.PayOrder(ctx, orderID, paymentMethod)
+	installments := req.Installments.Or(0)
+
+	transactionUUID, err := a.orderUseCase.PayOrder(ctx, orderID, paymentMethod, installments, tendersFromAPI(req.Tenders))
 	if err != nil {
 		if err.Error() == "order not found" {
 			return &order_v1.NotFoundErr{
@@ -27,6 +29,13 @@ func (a *api) PayOrder(ctx context.Context, req *order_v1.PayOrderReq, params or
 				Message: "Order cannot be paid in current status",
 			}, nil
 		}
+		// Результат платежа не получен: повторная оплата тем же способом продолжит ту же попытку
+		if err.Error() == "order payment is in progress" {
+			return &order_v1.ConflictErr{
+				Code:    409,
+				Message: "Order payment is in progress, retry with the same payment method",
+			}, nil
+		}
 		return &order_v1.BadRequest{
 			Code:    400,
 			Message: fmt.Sprintf("Payment failed: %v", err),
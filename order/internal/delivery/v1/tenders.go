@@ -0,0 +1,40 @@
+package v1
+
+import (
+	"github.com/linemk/rocket-shop/order/internal/entyties/models"
+	order_v1 "github.com/linemk/rocket-shop/shared/pkg/openapi/order/v1"
+)
+
+// tendersFromAPI конвертирует части раздельной оплаты из запроса
+func tendersFromAPI(tenders []order_v1.Tender) []models.Tender {
+	if len(tenders) == 0 {
+		return nil
+	}
+
+	result := make([]models.Tender, 0, len(tenders))
+	for _, tender := range tenders {
+		result = append(result, models.Tender{
+			PaymentMethod: tender.PaymentMethod,
+			Amount:        tender.Amount,
+		})
+	}
+
+	return result
+}
+
+// tendersToAPI конвертирует части раздельной оплаты заказа для ответа
+func tendersToAPI(tenders []models.Tender) []order_v1.Tender {
+	if len(tenders) == 0 {
+		return nil
+	}
+
+	result := make([]order_v1.Tender, 0, len(tenders))
+	for _, tender := range tenders {
+		result = append(result, order_v1.Tender{
+			PaymentMethod: tender.PaymentMethod,
+			Amount:        tender.Amount,
+		})
+	}
+
+	return result
+}
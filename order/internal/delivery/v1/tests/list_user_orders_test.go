@@ -0,0 +1,69 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	v1 "github.com/linemk/rocket-shop/order/internal/delivery/v1"
+	"github.com/linemk/rocket-shop/order/internal/entyties/models"
+	"github.com/linemk/rocket-shop/order/internal/mocks"
+	order_v1 "github.com/linemk/rocket-shop/shared/pkg/openapi/order/v1"
+)
+
+func TestListUserOrders(t *testing.T) {
+	ctx := context.Background()
+	userUUID := uuid.New()
+	orderUUID := uuid.New()
+	transactionUUID := uuid.New()
+
+	tests := []struct {
+		name    string
+		orders  []models.Order
+		wantErr bool
+	}{
+		{
+			name: "successful list user orders",
+			orders: []models.Order{
+				{UUID: orderUUID.String(), TransactionID: transactionUUID.String(), TotalPrice: 300.0},
+				{UUID: uuid.NewString()},
+			},
+		},
+		{
+			name:    "invalid order uuid returns error",
+			orders:  []models.Order{{UUID: "not-a-uuid"}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid transaction uuid returns error",
+			orders:  []models.Order{{UUID: orderUUID.String(), TransactionID: "not-a-uuid"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orderUseCase := mocks.NewMockOrderUseCase(gomock.NewController(t))
+			orderUseCase.EXPECT().ListUserOrders(ctx, userUUID.String()).Return(tt.orders, nil)
+
+			api := v1.NewAPI(orderUseCase)
+
+			result, err := api.ListUserOrders(ctx, order_v1.ListUserOrdersParams{UserUUID: userUUID})
+
+			if tt.wantErr {
+				require.Error(t, err)
+				require.Nil(t, result)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, result.Orders, len(tt.orders))
+			require.Equal(t, orderUUID, result.Orders[0].OrderUUID)
+			require.Equal(t, transactionUUID, result.Orders[0].TransactionUUID)
+			require.Equal(t, userUUID, result.Orders[0].UserUUID)
+		})
+	}
+}
@@ -3,12 +3,17 @@ package apperrors
 import "errors"
 
 var (
-	ErrOrderNotFound     = errors.New("order not found")
-	ErrInvalidParts      = errors.New("invalid parts")
-	ErrPartOutOfStock    = errors.New("part out of stock")
-	ErrPaymentFailed     = errors.New("payment failed")
-	ErrOrderCannotBePaid = errors.New("order cannot be paid in current status")
-	ErrOrderAlreadyPaid  = errors.New("order already paid and cannot be cancelled")
-	ErrNoPartsSpecified  = errors.New("no parts specified")
-	ErrPartNotFound      = errors.New("part not found in inventory")
+	ErrOrderNotFound       = errors.New("order not found")
+	ErrInvalidParts        = errors.New("invalid parts")
+	ErrPartOutOfStock      = errors.New("part out of stock")
+	ErrPaymentFailed       = errors.New("payment failed")
+	ErrOrderCannotBePaid   = errors.New("order cannot be paid in current status")
+	ErrOrderAlreadyPaid    = errors.New("order already paid and cannot be cancelled")
+	ErrPaymentInProgress   = errors.New("order payment is in progress")
+	ErrNoPartsSpecified    = errors.New("no parts specified")
+	ErrPartNotFound        = errors.New("part not found in inventory")
+	ErrInvalidInstallments = errors.New("installments are available only for credit card payments")
+	ErrInvalidTenders      = errors.New("split payment needs at least two tenders with positive amounts that sum to the order total")
+	ErrNoPaymentMethod     = errors.New("payment method or tenders must be specified")
+	ErrInvalidBuild        = errors.New("parts do not form a valid build")
 )
@@ -1,5 +1,7 @@
 package events
 
+import "time"
+
 // OrderPaidEvent представляет событие об оплате заказа
 type OrderPaidEvent struct {
 	EventUUID       string
@@ -16,3 +18,86 @@ type ShipAssembledEvent struct {
 	UserUUID     string
 	BuildTimeSec int64
 }
+
+// UserErasedEvent представляет событие об удалении персональных данных пользователя
+type UserErasedEvent struct {
+	EventUUID string
+	UserUUID  string
+	ErasedAt  time.Time
+}
+
+// PaymentCompletedEvent представляет событие об успешном списании средств по заказу
+type PaymentCompletedEvent struct {
+	EventUUID       string
+	TransactionUUID string
+	OrderUUID       string
+	UserUUID        string
+	PaymentMethod   string
+	Amount          float64
+	CompletedAt     time.Time
+}
+
+// PaymentFailedEvent представляет событие о неуспешной оплате заказа
+type PaymentFailedEvent struct {
+	EventUUID       string
+	TransactionUUID string
+	OrderUUID       string
+	UserUUID        string
+	Status          string
+	Reason          string
+	FailedAt        time.Time
+}
+
+// InstallmentPaidEvent представляет событие об оплате платежа по графику рассрочки заказа
+type InstallmentPaidEvent struct {
+	EventUUID         string
+	PlanUUID          string
+	TransactionUUID   string
+	OrderUUID         string
+	UserUUID          string
+	InstallmentNumber int
+	InstallmentsTotal int
+	Amount            float64
+	PaidAmount        float64
+	RemainingAmount   float64
+	PlanCompleted     bool
+	PaidAt            time.Time
+}
+
+// PaymentOverdueEvent представляет событие о просрочке платежа по графику рассрочки заказа
+type PaymentOverdueEvent struct {
+	EventUUID         string
+	PlanUUID          string
+	OrderUUID         string
+	UserUUID          string
+	InstallmentNumber int
+	Amount            float64
+	DueAt             time.Time
+	Reason            string
+	DetectedAt        time.Time
+}
+
+// ApprovalRequestedEvent представляет событие о запросе на согласование платежа заказа средствами инвестора
+type ApprovalRequestedEvent struct {
+	EventUUID       string
+	ApprovalUUID    string
+	TransactionUUID string
+	OrderUUID       string
+	UserUUID        string
+	Amount          float64
+	Approvers       []string
+	Quorum          int
+	ExpiresAt       time.Time
+	RequestedAt     time.Time
+	// SplitUUID группа транзакций раздельной оплаты, если согласуемый платеж - часть оплаты заказа
+	SplitUUID string
+}
+
+// PaymentEvent событие из топика PaymentService; заполнено ровно одно поле
+type PaymentEvent struct {
+	Completed         *PaymentCompletedEvent
+	Failed            *PaymentFailedEvent
+	InstallmentPaid   *InstallmentPaidEvent
+	Overdue           *PaymentOverdueEvent
+	ApprovalRequested *ApprovalRequestedEvent
+}
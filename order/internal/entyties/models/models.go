@@ -8,10 +8,15 @@ import (
 	order_v1 "github.com/linemk/rocket-shop/shared/pkg/openapi/order/v1"
 )
 
+// ErasedUserID подставляется вместо user_id в заказах пользователя, чьи данные удалены по запросу
+const ErasedUserID = "00000000-0000-0000-0000-000000000000"
+
 type OrderUpdateInfo struct {
 	Status        *order_v1.OrderStatus
 	TransactionID *string
 	PaymentMethod *order_v1.PaymentMethod
+	Installments  *int
+	Tenders       *[]Tender
 }
 
 type Order struct {
@@ -22,6 +27,43 @@ type Order struct {
 	TransactionID string                 `json:"transaction_id"`
 	PaymentMethod order_v1.PaymentMethod `json:"payment_method"`
 	Status        order_v1.OrderStatus   `json:"status"`
-	CreatedAt     time.Time
-	UpdatedAt     *time.Time
+	// Installments число платежей рассрочки, 0 - заказ оплачивается целиком.
+	// При оплате в рассрочку TransactionID содержит UUID плана рассрочки
+	Installments int `json:"installments"`
+	// Tenders части раздельной оплаты; при раздельной оплате TransactionID содержит UUID группы транзакций
+	// частей, а PaymentMethod не задан. UUID транзакции или группы заказ выбирает сам до вызова PaymentService
+	// и передает его ключом идемпотентности, поэтому заказ ждет результат именно этой попытки оплаты
+	Tenders   []Tender `json:"tenders"`
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+}
+
+// Tender часть раздельной оплаты заказа
+type Tender struct {
+	PaymentMethod order_v1.PaymentMethod `json:"payment_method"`
+	Amount        float64                `json:"amount"`
+}
+
+// Статусы транзакций PaymentService
+const (
+	// PaymentStatusPending платеж обрабатывается или ждет подтверждения
+	PaymentStatusPending = "PENDING"
+	// PaymentStatusCompleted средства успешно списаны
+	PaymentStatusCompleted = "COMPLETED"
+)
+
+// PaymentResult результат передачи заказа на оплату в PaymentService
+type PaymentResult struct {
+	TransactionUUID string
+	// Status статус транзакции на момент ответа (обычно PENDING, итог приходит событием)
+	Status string
+}
+
+// InstallmentQuote вариант рассрочки, рассчитанный PaymentService для стоимости заказа
+type InstallmentQuote struct {
+	Installments int
+	MonthlyRate  float64
+	Payments     []float64
+	TotalAmount  float64
+	Overpayment  float64
 }
@@ -0,0 +1,66 @@
+package models
+
+import (
+	"time"
+
+	order_v1 "github.com/linemk/rocket-shop/shared/pkg/openapi/order/v1"
+)
+
+// PaymentTransaction транзакция PaymentService, относящаяся к заказу
+type PaymentTransaction struct {
+	UUID          string
+	Status        string
+	Amount        float64
+	FailureReason string
+	// InstallmentPlanUUID план рассрочки, по графику которого списан платеж
+	InstallmentPlanUUID string
+	// SplitUUID группа транзакций раздельной оплаты, в которую входит транзакция
+	SplitUUID string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// DiscrepancyType вид расхождения между заказом и платежами
+type DiscrepancyType string
+
+const (
+	// DiscrepancyPaidWithoutTransaction заказ оплачен, но его транзакции нет в PaymentService
+	DiscrepancyPaidWithoutTransaction DiscrepancyType = "PAID_WITHOUT_TRANSACTION"
+	// DiscrepancyPaidTransactionNotCompleted заказ оплачен, но его транзакция не завершена успешно
+	DiscrepancyPaidTransactionNotCompleted DiscrepancyType = "PAID_TRANSACTION_NOT_COMPLETED"
+	// DiscrepancyAmountMismatch сумма успешной транзакции не совпадает с суммой заказа
+	DiscrepancyAmountMismatch DiscrepancyType = "AMOUNT_MISMATCH"
+	// DiscrepancyMultipleCompletedPayments по заказу прошло несколько успешных платежей
+	DiscrepancyMultipleCompletedPayments DiscrepancyType = "MULTIPLE_COMPLETED_PAYMENTS"
+	// DiscrepancyCompletedPaymentOnCancelledOrder успешный платеж привязан к отмененному заказу
+	DiscrepancyCompletedPaymentOnCancelledOrder DiscrepancyType = "COMPLETED_PAYMENT_ON_CANCELLED_ORDER"
+	// DiscrepancyCompletedPaymentNotApplied платеж прошел, но заказ не переведен в PAID (потеряно событие PaymentCompleted)
+	DiscrepancyCompletedPaymentNotApplied DiscrepancyType = "COMPLETED_PAYMENT_NOT_APPLIED"
+	// DiscrepancyFailedPaymentNotApplied платеж не прошел, но заказ остался в PAYMENT_PROCESSING или AWAITING_APPROVAL (потеряно событие PaymentFailed)
+	DiscrepancyFailedPaymentNotApplied DiscrepancyType = "FAILED_PAYMENT_NOT_APPLIED"
+)
+
+// Discrepancy расхождение по одному заказу
+type Discrepancy struct {
+	Type              DiscrepancyType      `json:"type"`
+	OrderUUID         string               `json:"order_uuid"`
+	OrderStatus       order_v1.OrderStatus `json:"order_status"`
+	OrderAmount       float64              `json:"order_amount"`
+	TransactionUUID   string               `json:"transaction_uuid,omitempty"`
+	TransactionStatus string               `json:"transaction_status,omitempty"`
+	TransactionAmount float64              `json:"transaction_amount,omitempty"`
+	Details           string               `json:"details"`
+	// Corrected true, если расхождение исправлено применением пропущенного результата платежа
+	Corrected bool `json:"corrected"`
+}
+
+// ReconciliationReport отчет сверки заказов с платежами
+type ReconciliationReport struct {
+	StartedAt     time.Time     `json:"started_at"`
+	FinishedAt    time.Time     `json:"finished_at"`
+	Since         time.Time     `json:"since"`
+	OrdersChecked int           `json:"orders_checked"`
+	Discrepancies []Discrepancy `json:"discrepancies"`
+	// Errors заказы, которые не удалось сверить
+	Errors []string `json:"errors,omitempty"`
+}
@@ -6,4 +6,11 @@ import "github.com/prometheus/client_golang/prometheus"
 type OrderMetrics struct {
 	OrdersTotal  *prometheus.CounterVec
 	RevenueTotal *prometheus.CounterVec
+
+	// ReconciliationRunsTotal число запусков сверки заказов с платежами по результату
+	ReconciliationRunsTotal *prometheus.CounterVec
+	// ReconciliationDiscrepancies число расхождений каждого вида в последней сверке
+	ReconciliationDiscrepancies *prometheus.GaugeVec
+	// ReconciliationCorrectionsTotal число исправленных сверкой расхождений
+	ReconciliationCorrectionsTotal *prometheus.CounterVec
 }
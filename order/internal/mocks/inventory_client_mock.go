@@ -64,3 +64,18 @@ func (mr *MockInventoryClientMockRecorder) GetPart(arg0, arg1 interface{}) *gomo
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPart", reflect.TypeOf((*MockInventoryClient)(nil).GetPart), arg0, arg1)
 }
+
+// ValidateBuild mocks base method.
+func (m *MockInventoryClient) ValidateBuild(arg0 context.Context, arg1 []uuid.UUID) ([]v1.BuildViolation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateBuild", arg0, arg1)
+	ret0, _ := ret[0].([]v1.BuildViolation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateBuild indicates an expected call of ValidateBuild.
+func (mr *MockInventoryClientMockRecorder) ValidateBuild(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateBuild", reflect.TypeOf((*MockInventoryClient)(nil).ValidateBuild), arg0, arg1)
+}
@@ -7,6 +7,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	models "github.com/linemk/rocket-shop/order/internal/entyties/models"
@@ -35,6 +36,21 @@ func (m *MockOrderRepository) EXPECT() *MockOrderRepositoryMockRecorder {
 	return m.recorder
 }
 
+// AnonymizeUser mocks base method.
+func (m *MockOrderRepository) AnonymizeUser(arg0 context.Context, arg1, arg2 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnonymizeUser", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AnonymizeUser indicates an expected call of AnonymizeUser.
+func (mr *MockOrderRepositoryMockRecorder) AnonymizeUser(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnonymizeUser", reflect.TypeOf((*MockOrderRepository)(nil).AnonymizeUser), arg0, arg1, arg2)
+}
+
 // Create mocks base method.
 func (m *MockOrderRepository) Create(arg0 context.Context, arg1 models.Order) error {
 	m.ctrl.T.Helper()
@@ -64,6 +80,36 @@ func (mr *MockOrderRepositoryMockRecorder) Get(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockOrderRepository)(nil).Get), arg0, arg1)
 }
 
+// ListByUser mocks base method.
+func (m *MockOrderRepository) ListByUser(arg0 context.Context, arg1 string) ([]models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByUser", arg0, arg1)
+	ret0, _ := ret[0].([]models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByUser indicates an expected call of ListByUser.
+func (mr *MockOrderRepositoryMockRecorder) ListByUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUser", reflect.TypeOf((*MockOrderRepository)(nil).ListByUser), arg0, arg1)
+}
+
+// ListCreatedSince mocks base method.
+func (m *MockOrderRepository) ListCreatedSince(arg0 context.Context, arg1 time.Time) ([]models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCreatedSince", arg0, arg1)
+	ret0, _ := ret[0].([]models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCreatedSince indicates an expected call of ListCreatedSince.
+func (mr *MockOrderRepositoryMockRecorder) ListCreatedSince(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCreatedSince", reflect.TypeOf((*MockOrderRepository)(nil).ListCreatedSince), arg0, arg1)
+}
+
 // Update mocks base method.
 func (m *MockOrderRepository) Update(arg0 context.Context, arg1 string, arg2 models.OrderUpdateInfo) error {
 	m.ctrl.T.Helper()
@@ -9,6 +9,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	events "github.com/linemk/rocket-shop/order/internal/entyties/events"
 	models "github.com/linemk/rocket-shop/order/internal/entyties/models"
 	usecase "github.com/linemk/rocket-shop/order/internal/usecase"
 	order_v1 "github.com/linemk/rocket-shop/shared/pkg/openapi/order/v1"
@@ -37,6 +38,34 @@ func (m *MockOrderUseCase) EXPECT() *MockOrderUseCaseMockRecorder {
 	return m.recorder
 }
 
+// ApplyInstallmentPayment mocks base method.
+func (m *MockOrderUseCase) ApplyInstallmentPayment(arg0 context.Context, arg1 *events.InstallmentPaidEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyInstallmentPayment", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyInstallmentPayment indicates an expected call of ApplyInstallmentPayment.
+func (mr *MockOrderUseCaseMockRecorder) ApplyInstallmentPayment(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyInstallmentPayment", reflect.TypeOf((*MockOrderUseCase)(nil).ApplyInstallmentPayment), arg0, arg1)
+}
+
+// AwaitApproval mocks base method.
+func (m *MockOrderUseCase) AwaitApproval(arg0 context.Context, arg1 *events.ApprovalRequestedEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AwaitApproval", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AwaitApproval indicates an expected call of AwaitApproval.
+func (mr *MockOrderUseCaseMockRecorder) AwaitApproval(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AwaitApproval", reflect.TypeOf((*MockOrderUseCase)(nil).AwaitApproval), arg0, arg1)
+}
+
 // CancelOrder mocks base method.
 func (m *MockOrderUseCase) CancelOrder(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -51,6 +80,20 @@ func (mr *MockOrderUseCaseMockRecorder) CancelOrder(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelOrder", reflect.TypeOf((*MockOrderUseCase)(nil).CancelOrder), arg0, arg1)
 }
 
+// CompletePayment mocks base method.
+func (m *MockOrderUseCase) CompletePayment(arg0 context.Context, arg1 *events.PaymentCompletedEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompletePayment", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompletePayment indicates an expected call of CompletePayment.
+func (mr *MockOrderUseCaseMockRecorder) CompletePayment(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompletePayment", reflect.TypeOf((*MockOrderUseCase)(nil).CompletePayment), arg0, arg1)
+}
+
 // CreateOrder mocks base method.
 func (m *MockOrderUseCase) CreateOrder(arg0 context.Context, arg1 usecase.OrderInfo) (string, error) {
 	m.ctrl.T.Helper()
@@ -66,6 +109,35 @@ func (mr *MockOrderUseCaseMockRecorder) CreateOrder(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrder", reflect.TypeOf((*MockOrderUseCase)(nil).CreateOrder), arg0, arg1)
 }
 
+// FailPayment mocks base method.
+func (m *MockOrderUseCase) FailPayment(arg0 context.Context, arg1 *events.PaymentFailedEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailPayment", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FailPayment indicates an expected call of FailPayment.
+func (mr *MockOrderUseCaseMockRecorder) FailPayment(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailPayment", reflect.TypeOf((*MockOrderUseCase)(nil).FailPayment), arg0, arg1)
+}
+
+// GetInstallmentQuotes mocks base method.
+func (m *MockOrderUseCase) GetInstallmentQuotes(arg0 context.Context, arg1 string) ([]models.InstallmentQuote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInstallmentQuotes", arg0, arg1)
+	ret0, _ := ret[0].([]models.InstallmentQuote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInstallmentQuotes indicates an expected call of GetInstallmentQuotes.
+func (mr *MockOrderUseCaseMockRecorder) GetInstallmentQuotes(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInstallmentQuotes", reflect.TypeOf((*MockOrderUseCase)(nil).GetInstallmentQuotes), arg0, arg1)
+}
+
 // GetOrder mocks base method.
 func (m *MockOrderUseCase) GetOrder(arg0 context.Context, arg1 string) (models.Order, error) {
 	m.ctrl.T.Helper()
@@ -81,17 +153,47 @@ func (mr *MockOrderUseCaseMockRecorder) GetOrder(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrder", reflect.TypeOf((*MockOrderUseCase)(nil).GetOrder), arg0, arg1)
 }
 
+// ListUserOrders mocks base method.
+func (m *MockOrderUseCase) ListUserOrders(arg0 context.Context, arg1 string) ([]models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserOrders", arg0, arg1)
+	ret0, _ := ret[0].([]models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserOrders indicates an expected call of ListUserOrders.
+func (mr *MockOrderUseCaseMockRecorder) ListUserOrders(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserOrders", reflect.TypeOf((*MockOrderUseCase)(nil).ListUserOrders), arg0, arg1)
+}
+
 // PayOrder mocks base method.
-func (m *MockOrderUseCase) PayOrder(arg0 context.Context, arg1 string, arg2 order_v1.PaymentMethod) (string, error) {
+func (m *MockOrderUseCase) PayOrder(arg0 context.Context, arg1 string, arg2 order_v1.PaymentMethod, arg3 int, arg4 []models.Tender) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PayOrder", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "PayOrder", arg0, arg1, arg2, arg3, arg4)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // PayOrder indicates an expected call of PayOrder.
-func (mr *MockOrderUseCaseMockRecorder) PayOrder(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockOrderUseCaseMockRecorder) PayOrder(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PayOrder", reflect.TypeOf((*MockOrderUseCase)(nil).PayOrder), arg0, arg1, arg2, arg3, arg4)
+}
+
+// Reconcile mocks base method.
+func (m *MockOrderUseCase) Reconcile(arg0 context.Context, arg1 usecase.ReconcileParams) (models.ReconciliationReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reconcile", arg0, arg1)
+	ret0, _ := ret[0].(models.ReconciliationReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reconcile indicates an expected call of Reconcile.
+func (mr *MockOrderUseCaseMockRecorder) Reconcile(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PayOrder", reflect.TypeOf((*MockOrderUseCase)(nil).PayOrder), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*MockOrderUseCase)(nil).Reconcile), arg0, arg1)
 }
@@ -9,6 +9,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	models "github.com/linemk/rocket-shop/order/internal/entyties/models"
 	payment_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/payment/v1"
 )
 
@@ -49,17 +50,77 @@ func (mr *MockPaymentClientMockRecorder) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockPaymentClient)(nil).Close))
 }
 
-// PayOrder mocks base method.
-func (m *MockPaymentClient) PayOrder(arg0 context.Context, arg1, arg2 string, arg3 payment_v1.PaymentMethod) (string, error) {
+// CreateInstallmentPlan mocks base method.
+func (m *MockPaymentClient) CreateInstallmentPlan(arg0 context.Context, arg1, arg2 string, arg3 float64, arg4 int) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PayOrder", arg0, arg1, arg2, arg3)
+	ret := m.ctrl.Call(m, "CreateInstallmentPlan", arg0, arg1, arg2, arg3, arg4)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
+// CreateInstallmentPlan indicates an expected call of CreateInstallmentPlan.
+func (mr *MockPaymentClientMockRecorder) CreateInstallmentPlan(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInstallmentPlan", reflect.TypeOf((*MockPaymentClient)(nil).CreateInstallmentPlan), arg0, arg1, arg2, arg3, arg4)
+}
+
+// ListTransactions mocks base method.
+func (m *MockPaymentClient) ListTransactions(arg0 context.Context, arg1 string) ([]models.PaymentTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTransactions", arg0, arg1)
+	ret0, _ := ret[0].([]models.PaymentTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTransactions indicates an expected call of ListTransactions.
+func (mr *MockPaymentClientMockRecorder) ListTransactions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransactions", reflect.TypeOf((*MockPaymentClient)(nil).ListTransactions), arg0, arg1)
+}
+
+// PayOrder mocks base method.
+func (m *MockPaymentClient) PayOrder(arg0 context.Context, arg1, arg2 string, arg3 payment_v1.PaymentMethod, arg4 float64, arg5 string) (models.PaymentResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PayOrder", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(models.PaymentResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // PayOrder indicates an expected call of PayOrder.
-func (mr *MockPaymentClientMockRecorder) PayOrder(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+func (mr *MockPaymentClientMockRecorder) PayOrder(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PayOrder", reflect.TypeOf((*MockPaymentClient)(nil).PayOrder), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// PaySplit mocks base method.
+func (m *MockPaymentClient) PaySplit(arg0 context.Context, arg1, arg2 string, arg3 float64, arg4 []models.Tender, arg5 string) (models.PaymentResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PaySplit", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(models.PaymentResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PaySplit indicates an expected call of PaySplit.
+func (mr *MockPaymentClientMockRecorder) PaySplit(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PaySplit", reflect.TypeOf((*MockPaymentClient)(nil).PaySplit), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// QuoteInstallments mocks base method.
+func (m *MockPaymentClient) QuoteInstallments(arg0 context.Context, arg1 float64) ([]models.InstallmentQuote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QuoteInstallments", arg0, arg1)
+	ret0, _ := ret[0].([]models.InstallmentQuote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QuoteInstallments indicates an expected call of QuoteInstallments.
+func (mr *MockPaymentClientMockRecorder) QuoteInstallments(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PayOrder", reflect.TypeOf((*MockPaymentClient)(nil).PayOrder), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QuoteInstallments", reflect.TypeOf((*MockPaymentClient)(nil).QuoteInstallments), arg0, arg1)
 }
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+func (r *repository) AnonymizeUser(ctx context.Context, userID, replacement string) (int64, error) {
+	query, args, err := sq.Update("orders").
+		PlaceholderFormat(sq.Dollar).
+		Set("user_id", replacement).
+		Set("updated_at", time.Now()).
+		Where(sq.Eq{"user_id": userID}).
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := r.db.Exec(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected(), nil
+}
@@ -12,6 +12,11 @@ import (
 func (r *repository) Create(ctx context.Context, order models.Order) error {
 	now := time.Now()
 
+	tendersJSON, err := tendersToJSON(order.Tenders)
+	if err != nil {
+		return err
+	}
+
 	// Используем squirrel для type-safe query building
 	query, args, err := sq.Insert("orders").
 		PlaceholderFormat(sq.Dollar).
@@ -23,6 +28,8 @@ func (r *repository) Create(ctx context.Context, order models.Order) error {
 			"transaction_id",
 			"payment_method",
 			"status",
+			"installments",
+			"tenders",
 			"created_at",
 		).
 		Values(
@@ -33,6 +40,8 @@ func (r *repository) Create(ctx context.Context, order models.Order) error {
 			order.TransactionID,
 			string(order.PaymentMethod),
 			string(order.Status),
+			order.Installments,
+			tendersJSON,
 			now,
 		).
 		ToSql()
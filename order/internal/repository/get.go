@@ -23,6 +23,8 @@ func (r *repository) Get(ctx context.Context, orderUUID string) (models.Order, e
 		"transaction_id",
 		"payment_method",
 		"status",
+		"installments",
+		"tenders",
 		"created_at",
 		"updated_at",
 	).
@@ -38,6 +40,7 @@ func (r *repository) Get(ctx context.Context, orderUUID string) (models.Order, e
 	var partUUIDs []uuid.UUID
 	var paymentMethodStr, statusStr string
 	var updatedAt sql.NullTime
+	var tendersJSON []byte
 
 	err = r.db.QueryRow(ctx, query, args...).Scan(
 		&order.UUID,
@@ -47,6 +50,8 @@ func (r *repository) Get(ctx context.Context, orderUUID string) (models.Order, e
 		&order.TransactionID,
 		&paymentMethodStr,
 		&statusStr,
+		&order.Installments,
+		&tendersJSON,
 		&order.CreatedAt,
 		&updatedAt,
 	)
@@ -62,6 +67,11 @@ func (r *repository) Get(ctx context.Context, orderUUID string) (models.Order, e
 	order.PaymentMethod = order_v1.PaymentMethod(paymentMethodStr)
 	order.Status = order_v1.OrderStatus(statusStr)
 
+	order.Tenders, err = tendersFromJSON(tendersJSON)
+	if err != nil {
+		return models.Order{}, err
+	}
+
 	if updatedAt.Valid {
 		order.UpdatedAt = &updatedAt.Time
 	}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+
+	"github.com/linemk/rocket-shop/order/internal/entyties/models"
+	order_v1 "github.com/linemk/rocket-shop/shared/pkg/openapi/order/v1"
+)
+
+func (r *repository) ListByUser(ctx context.Context, userID string) ([]models.Order, error) {
+	query, args, err := sq.Select(
+		"uuid",
+		"user_id",
+		"part_uuids",
+		"total_price",
+		"transaction_id",
+		"payment_method",
+		"status",
+		"installments",
+		"tenders",
+		"created_at",
+		"updated_at",
+	).
+		From("orders").
+		PlaceholderFormat(sq.Dollar).
+		Where(sq.Eq{"user_id": userID}).
+		OrderBy("created_at").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	orders := make([]models.Order, 0)
+	for rows.Next() {
+		var order models.Order
+		var partUUIDs []uuid.UUID
+		var paymentMethodStr, statusStr string
+		var updatedAt sql.NullTime
+		var tendersJSON []byte
+
+		err = rows.Scan(
+			&order.UUID,
+			&order.UserID,
+			&partUUIDs,
+			&order.TotalPrice,
+			&order.TransactionID,
+			&paymentMethodStr,
+			&statusStr,
+			&order.Installments,
+			&tendersJSON,
+			&order.CreatedAt,
+			&updatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		order.PartUUIDs = partUUIDs
+		order.PaymentMethod = order_v1.PaymentMethod(paymentMethodStr)
+		order.Status = order_v1.OrderStatus(statusStr)
+
+		order.Tenders, err = tendersFromJSON(tendersJSON)
+		if err != nil {
+			return nil, err
+		}
+
+		if updatedAt.Valid {
+			order.UpdatedAt = &updatedAt.Time
+		}
+
+		orders = append(orders, order)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return orders, nil
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 
@@ -22,4 +23,9 @@ type OrderRepository interface {
 	Create(ctx context.Context, order models.Order) error
 	Get(ctx context.Context, uuid string) (models.Order, error)
 	Update(ctx context.Context, uuid string, updateInfo models.OrderUpdateInfo) error
+	ListByUser(ctx context.Context, userID string) ([]models.Order, error)
+	// ListCreatedSince возвращает заказы, созданные начиная с since, в порядке создания
+	ListCreatedSince(ctx context.Context, since time.Time) ([]models.Order, error)
+	// AnonymizeUser заменяет user_id во всех заказах пользователя и возвращает число измененных заказов
+	AnonymizeUser(ctx context.Context, userID, replacement string) (int64, error)
 }
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"encoding/json"
+
+	"github.com/linemk/rocket-shop/order/internal/entyties/models"
+)
+
+// tendersToJSON конвертирует части раздельной оплаты в JSONB для PostgreSQL
+func tendersToJSON(tenders []models.Tender) ([]byte, error) {
+	if len(tenders) == 0 {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(tenders)
+}
+
+// tendersFromJSON парсит JSONB из PostgreSQL в части раздельной оплаты
+func tendersFromJSON(data []byte) ([]models.Tender, error) {
+	var tenders []models.Tender
+	if len(data) == 0 {
+		return tenders, nil
+	}
+	err := json.Unmarshal(data, &tenders)
+	return tenders, err
+}
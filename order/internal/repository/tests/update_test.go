@@ -69,6 +69,17 @@ func TestUpdate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "successful update split tenders",
+			uuid: orderUUID.String(),
+			updateInfo: models.OrderUpdateInfo{
+				Tenders: &[]models.Tender{
+					{PaymentMethod: order_v1.PaymentMethodPAYMENTMETHODSBP, Amount: 60},
+					{PaymentMethod: order_v1.PaymentMethodPAYMENTMETHODINVESTORMONEY, Amount: 40},
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "error order not found",
 			uuid: uuid.New().String(),
@@ -102,6 +113,9 @@ func TestUpdate(t *testing.T) {
 			if tt.updateInfo.TransactionID != nil {
 				require.Equal(t, *tt.updateInfo.TransactionID, updatedOrder.TransactionID)
 			}
+			if tt.updateInfo.Tenders != nil {
+				require.Equal(t, *tt.updateInfo.Tenders, updatedOrder.Tenders)
+			}
 		})
 	}
 }
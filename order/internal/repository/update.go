@@ -30,6 +30,17 @@ func (r *repository) Update(ctx context.Context, uuid string, updateInfo models.
 	if updateInfo.PaymentMethod != nil {
 		order.PaymentMethod = *updateInfo.PaymentMethod
 	}
+	if updateInfo.Installments != nil {
+		order.Installments = *updateInfo.Installments
+	}
+	if updateInfo.Tenders != nil {
+		order.Tenders = *updateInfo.Tenders
+	}
+
+	tendersJSON, err := tendersToJSON(order.Tenders)
+	if err != nil {
+		return err
+	}
 
 	// Используем squirrel для type-safe query building
 	query, args, err := sq.Update("orders").
@@ -38,6 +49,8 @@ func (r *repository) Update(ctx context.Context, uuid string, updateInfo models.
 		Set("status", string(order.Status)).
 		Set("transaction_id", order.TransactionID).
 		Set("payment_method", string(order.PaymentMethod)).
+		Set("installments", order.Installments).
+		Set("tenders", tendersJSON).
 		Where(sq.Eq{"uuid": uuid}).
 		ToSql()
 	if err != nil {
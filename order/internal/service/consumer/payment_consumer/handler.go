@@ -0,0 +1,84 @@
+package payment_consumer
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/zap"
+
+	"github.com/linemk/rocket-shop/order/internal/converter/kafka/decoder"
+	"github.com/linemk/rocket-shop/order/internal/entyties/apperrors"
+	"github.com/linemk/rocket-shop/order/internal/usecase"
+	platformKafka "github.com/linemk/rocket-shop/platform/pkg/kafka"
+)
+
+type handler struct {
+	orderUseCase usecase.OrderUseCase
+	logger       Logger
+}
+
+func NewHandler(orderUseCase usecase.OrderUseCase, logger Logger) HandlerFunc {
+	h := &handler{
+		orderUseCase: orderUseCase,
+		logger:       logger,
+	}
+
+	return h.Handle
+}
+
+func (h *handler) Handle(ctx context.Context, msg platformKafka.Message) error {
+	h.logger.Info(ctx, "Received payment event", zap.String("topic", msg.Topic))
+
+	event, err := decoder.DecodePaymentEvent(msg.Value)
+	if err != nil {
+		h.logger.Error(ctx, "Failed to decode payment event", zap.Error(err))
+		return err
+	}
+
+	switch {
+	case event.Completed != nil:
+		err = h.orderUseCase.CompletePayment(ctx, event.Completed)
+		h.logResult(ctx, "PaymentCompleted", event.Completed.OrderUUID, event.Completed.TransactionUUID, err)
+	case event.Failed != nil:
+		err = h.orderUseCase.FailPayment(ctx, event.Failed)
+		h.logResult(ctx, "PaymentFailed", event.Failed.OrderUUID, event.Failed.TransactionUUID, err)
+	case event.InstallmentPaid != nil:
+		err = h.orderUseCase.ApplyInstallmentPayment(ctx, event.InstallmentPaid)
+		h.logResult(ctx, "InstallmentPaid", event.InstallmentPaid.OrderUUID, event.InstallmentPaid.TransactionUUID, err)
+	case event.ApprovalRequested != nil:
+		err = h.orderUseCase.AwaitApproval(ctx, event.ApprovalRequested)
+		h.logResult(ctx, "ApprovalRequested", event.ApprovalRequested.OrderUUID, event.ApprovalRequested.TransactionUUID, err)
+	case event.Overdue != nil:
+		// Просрочка не меняет статус заказа: план остается действующим, а платеж будет списан повторно
+		h.logger.Warn(ctx, "Order installment is overdue",
+			zap.String("order_uuid", event.Overdue.OrderUUID),
+			zap.String("plan_uuid", event.Overdue.PlanUUID),
+			zap.Int("installment_number", event.Overdue.InstallmentNumber),
+			zap.Float64("amount", event.Overdue.Amount),
+			zap.Time("due_at", event.Overdue.DueAt),
+			zap.String("reason", event.Overdue.Reason),
+		)
+	}
+
+	// Событие по неизвестному заказу повторная обработка не исправит
+	if errors.Is(err, apperrors.ErrOrderNotFound) {
+		return nil
+	}
+
+	return err
+}
+
+func (h *handler) logResult(ctx context.Context, eventType, orderUUID, transactionUUID string, err error) {
+	fields := []zap.Field{
+		zap.String("event_type", eventType),
+		zap.String("order_uuid", orderUUID),
+		zap.String("transaction_uuid", transactionUUID),
+	}
+
+	if err != nil {
+		h.logger.Error(ctx, "Failed to apply payment event to order", append(fields, zap.Error(err))...)
+		return
+	}
+
+	h.logger.Info(ctx, "Payment event applied to order", fields...)
+}
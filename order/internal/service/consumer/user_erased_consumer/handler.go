@@ -0,0 +1,52 @@
+package user_erased_consumer
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/linemk/rocket-shop/order/internal/converter/kafka/decoder"
+	"github.com/linemk/rocket-shop/order/internal/entyties/models"
+	"github.com/linemk/rocket-shop/order/internal/repository"
+	platformKafka "github.com/linemk/rocket-shop/platform/pkg/kafka"
+)
+
+type handler struct {
+	orderRepository repository.OrderRepository
+	logger          Logger
+}
+
+func NewHandler(orderRepository repository.OrderRepository, logger Logger) HandlerFunc {
+	h := &handler{
+		orderRepository: orderRepository,
+		logger:          logger,
+	}
+
+	return h.Handle
+}
+
+func (h *handler) Handle(ctx context.Context, msg platformKafka.Message) error {
+	h.logger.Info(ctx, "Received UserErased event", zap.String("topic", msg.Topic))
+
+	// Декодируем событие
+	event, err := decoder.DecodeUserErased(msg.Value)
+	if err != nil {
+		h.logger.Error(ctx, "Failed to decode UserErased event", zap.Error(err))
+		return err
+	}
+
+	// Заказы сохраняем для учета, но отвязываем от пользователя.
+	// Повторная обработка события безопасна: заказов с исходным user_id уже не останется
+	affected, err := h.orderRepository.AnonymizeUser(ctx, event.UserUUID, models.ErasedUserID)
+	if err != nil {
+		h.logger.Error(ctx, "Failed to anonymize user orders", zap.Error(err))
+		return err
+	}
+
+	h.logger.Info(ctx, "User orders anonymized successfully",
+		zap.String("event_uuid", event.EventUUID),
+		zap.Int64("orders", affected),
+	)
+
+	return nil
+}
@@ -15,3 +15,8 @@ type ConsumerService interface {
 type OrderProducerService interface {
 	SendOrderPaid(ctx context.Context, event *events.OrderPaidEvent) error
 }
+
+// JobService фоновая задача, работающая до отмены контекста
+type JobService interface {
+	Run(ctx context.Context) error
+}
@@ -0,0 +1,82 @@
+package reconciliation_job
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/linemk/rocket-shop/order/internal/config"
+	"github.com/linemk/rocket-shop/order/internal/usecase"
+)
+
+type Logger interface {
+	Info(ctx context.Context, msg string, fields ...zap.Field)
+	Warn(ctx context.Context, msg string, fields ...zap.Field)
+	Error(ctx context.Context, msg string, fields ...zap.Field)
+}
+
+type job struct {
+	orderUseCase usecase.OrderUseCase
+	config       config.ReconciliationConfig
+	logger       Logger
+}
+
+func NewJob(orderUseCase usecase.OrderUseCase, cfg config.ReconciliationConfig, logger Logger) *job {
+	return &job{
+		orderUseCase: orderUseCase,
+		config:       cfg,
+		logger:       logger,
+	}
+}
+
+// Run периодически сверяет заказы с платежами до отмены контекста
+func (j *job) Run(ctx context.Context) error {
+	if j.config.Interval() <= 0 {
+		j.logger.Info(ctx, "Order reconciliation job disabled")
+		return nil
+	}
+
+	j.logger.Info(ctx, "Starting order reconciliation job", zap.Duration("interval", j.config.Interval()))
+
+	ticker := time.NewTicker(j.config.Interval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			j.reconcile(ctx)
+		}
+	}
+}
+
+func (j *job) reconcile(ctx context.Context) {
+	report, err := j.orderUseCase.Reconcile(ctx, usecase.ReconcileParams{
+		Since:       time.Now().Add(-j.config.Lookback()),
+		GracePeriod: j.config.GracePeriod(),
+		Fix:         j.config.Fix(),
+	})
+	if err != nil {
+		j.logger.Error(ctx, "Order reconciliation failed", zap.Error(err))
+		return
+	}
+
+	for _, discrepancy := range report.Discrepancies {
+		j.logger.Warn(ctx, "Order reconciliation discrepancy",
+			zap.String("type", string(discrepancy.Type)),
+			zap.String("order_uuid", discrepancy.OrderUUID),
+			zap.String("transaction_uuid", discrepancy.TransactionUUID),
+			zap.Bool("corrected", discrepancy.Corrected),
+			zap.String("details", discrepancy.Details),
+		)
+	}
+
+	j.logger.Info(ctx, "Order reconciliation finished",
+		zap.Int("orders_checked", report.OrdersChecked),
+		zap.Int("discrepancies", len(report.Discrepancies)),
+		zap.Int("errors", len(report.Errors)),
+		zap.Duration("duration", report.FinishedAt.Sub(report.StartedAt)),
+	)
+}
@@ -19,6 +19,13 @@ func (uc *useCase) CancelOrder(ctx context.Context, uuid string) error {
 		return apperrors.ErrOrderAlreadyPaid
 	}
 
+	// Отмена во время оплаты или согласования привела бы к списанию средств за отмененный заказ,
+	// а заказ в рассрочку продолжает оплачиваться по графику
+	if order.Status == order_v1.OrderStatusPAYMENTPROCESSING || order.Status == order_v1.OrderStatusAWAITINGAPPROVAL ||
+		order.Status == order_v1.OrderStatusPARTIALLYPAID {
+		return apperrors.ErrPaymentInProgress
+	}
+
 	if order.Status == order_v1.OrderStatusPENDINGPAYMENT {
 		updateInfo := models.OrderUpdateInfo{
 			Status: &[]order_v1.OrderStatus{order_v1.OrderStatusCANCELLED}[0],
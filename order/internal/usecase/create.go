@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -28,6 +29,19 @@ func (uc *useCase) CreateOrder(ctx context.Context, info OrderInfo) (string, err
 		ctx = metadata.AppendToOutgoingContext(ctx, grpcmiddleware.SessionUUIDHeader, sessionUUID)
 	}
 
+	// Сборку проверяем до расчета цены: несовместимый заказ отклоняется без чтения каждой детали
+	violations, err := uc.inventoryClient.ValidateBuild(ctx, info.PartUUIDs)
+	if err != nil {
+		return "", fmt.Errorf("failed to validate build: %w", err)
+	}
+	if len(violations) > 0 {
+		messages := make([]string, 0, len(violations))
+		for _, v := range violations {
+			messages = append(messages, v.Message)
+		}
+		return "", fmt.Errorf("%w: %s", apperrors.ErrInvalidBuild, strings.Join(messages, "; "))
+	}
+
 	var totalPrice float32
 	for _, partUUID := range info.PartUUIDs {
 		partInfo, err := uc.inventoryClient.GetPart(ctx, partUUID)
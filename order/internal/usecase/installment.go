@@ -0,0 +1,23 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/linemk/rocket-shop/order/internal/entyties/apperrors"
+	"github.com/linemk/rocket-shop/order/internal/entyties/models"
+)
+
+func (uc *useCase) GetInstallmentQuotes(ctx context.Context, uuid string) ([]models.InstallmentQuote, error) {
+	order, err := uc.orderRepository.Get(ctx, uuid)
+	if err != nil {
+		return nil, apperrors.ErrOrderNotFound
+	}
+
+	quotes, err := uc.paymentClient.QuoteInstallments(ctx, float64(order.TotalPrice))
+	if err != nil {
+		return nil, fmt.Errorf("failed to quote installments: %w", err)
+	}
+
+	return quotes, nil
+}
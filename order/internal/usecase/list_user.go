@@ -0,0 +1,11 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/linemk/rocket-shop/order/internal/entyties/models"
+)
+
+func (uc *useCase) ListUserOrders(ctx context.Context, userID string) ([]models.Order, error) {
+	return uc.orderRepository.ListByUser(ctx, userID)
+}
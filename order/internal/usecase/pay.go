@@ -3,8 +3,12 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
 	uuidgen "github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/linemk/rocket-shop/order/internal/client/grpc/payment/converter"
 	"github.com/linemk/rocket-shop/order/internal/entyties/apperrors"
@@ -13,47 +17,239 @@ import (
 	order_v1 "github.com/linemk/rocket-shop/shared/pkg/openapi/order/v1"
 )
 
-func (uc *useCase) PayOrder(ctx context.Context, uuid string, paymentMethod order_v1.PaymentMethod) (string, error) {
+// minSplitTenders минимальное число частей раздельной оплаты
+const minSplitTenders = 2
+
+func (uc *useCase) PayOrder(ctx context.Context, uuid string, paymentMethod order_v1.PaymentMethod, installments int, tenders []models.Tender) (string, error) {
+	if len(tenders) > 0 {
+		// Раздельная оплата передается вместо способа оплаты и не совмещается с рассрочкой
+		if installments != 0 || (paymentMethod != "" && paymentMethod != order_v1.PaymentMethodPAYMENTMETHODUNSPECIFIED) {
+			return "", apperrors.ErrInvalidTenders
+		}
+		paymentMethod = order_v1.PaymentMethodPAYMENTMETHODUNSPECIFIED
+	} else if paymentMethod == "" || paymentMethod == order_v1.PaymentMethodPAYMENTMETHODUNSPECIFIED {
+		return "", apperrors.ErrNoPaymentMethod
+	}
+
+	if installments < 0 || (installments > 0 && paymentMethod != order_v1.PaymentMethodPAYMENTMETHODCREDITCARD) {
+		return "", apperrors.ErrInvalidInstallments
+	}
+
 	// 1. Получаем заказ
 	order, err := uc.orderRepository.Get(ctx, uuid)
 	if err != nil {
 		return "", apperrors.ErrOrderNotFound
 	}
 
+	// Заказ в PAYMENT_PROCESSING ждет результат попытки, ответ на которую мог не дойти. Повторная оплата тем же способом
+	// продолжает ее с тем же ключом: если платеж не дошел до PaymentService, он будет создан, иначе вернется уже созданный
+	if resumablePayment(order) {
+		if paymentMethod != order.PaymentMethod || installments != 0 || !slices.Equal(tenders, order.Tenders) {
+			return "", apperrors.ErrOrderCannotBePaid
+		}
+
+		return uc.sendPayment(ctx, order)
+	}
+
 	// 2. Бизнес-логика: проверяем статус
 	if order.Status != order_v1.OrderStatusPENDINGPAYMENT {
 		return "", apperrors.ErrOrderCannotBePaid
 	}
 
-	// 3. Вызываем PaymentService
-	protoPaymentMethod := converter.OpenAPIPaymentMethodToProto(paymentMethod)
-	transactionUUID, err := uc.paymentClient.PayOrder(ctx, order.UUID, order.UserID, protoPaymentMethod)
+	if len(tenders) > 0 && !validTenders(tenders, order.TotalPrice) {
+		return "", apperrors.ErrInvalidTenders
+	}
+
+	// 3. Переводим заказ в PAYMENT_PROCESSING до вызова PaymentService,
+	// чтобы событие о результате платежа не пришло раньше, чем заказ начнет его ждать.
+	// UUID транзакции или группы частей выбирается здесь же и заменяет транзакцию предыдущей неудачной попытки;
+	// UUID плана рассрочки заказ узнает из ответа PaymentService
+	paymentKey := ""
+	if installments == 0 {
+		paymentKey = uuidgen.New().String()
+	}
+	updateInfo := models.OrderUpdateInfo{
+		Status:        &[]order_v1.OrderStatus{order_v1.OrderStatusPAYMENTPROCESSING}[0],
+		TransactionID: &paymentKey,
+		PaymentMethod: &paymentMethod,
+		Installments:  &installments,
+		Tenders:       &tenders,
+	}
+
+	if err := uc.orderRepository.Update(ctx, uuid, updateInfo); err != nil {
+		return "", fmt.Errorf("failed to update order: %w", err)
+	}
+
+	if installments > 0 {
+		return uc.payInInstallments(ctx, order, installments)
+	}
+
+	order.TransactionID = paymentKey
+	order.PaymentMethod = paymentMethod
+	order.Tenders = tenders
+
+	// 4. Передаем платеж в PaymentService
+	return uc.sendPayment(ctx, order)
+}
+
+// resumablePayment возвращает true, если заказ ждет результат оплаты целиком или частями с выбранным UUID попытки
+func resumablePayment(order models.Order) bool {
+	return order.Status == order_v1.OrderStatusPAYMENTPROCESSING && order.TransactionID != "" && order.Installments == 0
+}
+
+// sendPayment передает в PaymentService попытку оплаты заказа с ключом order.TransactionID: раздельную оплату
+// группой частей, иначе оплату целиком. О группе приходит одно событие: PaymentCompleted после списания всех частей,
+// PaymentFailed - после отказа любой из них
+func (uc *useCase) sendPayment(ctx context.Context, order models.Order) (string, error) {
+	var payment models.PaymentResult
+	var err error
+	if len(order.Tenders) > 0 {
+		payment, err = uc.paymentClient.PaySplit(ctx, order.UUID, order.UserID, float64(order.TotalPrice), order.Tenders, order.TransactionID)
+	} else {
+		protoPaymentMethod := converter.OpenAPIPaymentMethodToProto(order.PaymentMethod)
+		payment, err = uc.paymentClient.PayOrder(ctx, order.UUID, order.UserID, protoPaymentMethod, float64(order.TotalPrice), order.TransactionID)
+	}
 	if err != nil {
+		return "", uc.paymentNotAccepted(ctx, order.UUID, err)
+	}
+
+	// Платеж мог завершиться сразу, иначе заказ ждет событие PaymentCompleted или PaymentFailed
+	if payment.Status == models.PaymentStatusCompleted {
+		if err := uc.markPaid(ctx, order, payment.TransactionUUID); err != nil {
+			return "", err
+		}
+
+		return payment.TransactionUUID, nil
+	}
+
+	// PaymentService возвращает ожидающую транзакцию прошлой попытки вместо новой: заказ ждет ее результат
+	if payment.TransactionUUID != order.TransactionID {
+		transactionInfo := models.OrderUpdateInfo{
+			TransactionID: &payment.TransactionUUID,
+		}
+
+		if err := uc.orderRepository.Update(ctx, order.UUID, transactionInfo); err != nil {
+			return "", fmt.Errorf("failed to update order: %w", err)
+		}
+	}
+
+	return payment.TransactionUUID, nil
+}
+
+// paymentNotAccepted обрабатывает ошибку вызова PaymentService. Если ответ не получен, платеж мог быть принят:
+// заказ остается в PAYMENT_PROCESSING и ждет его результат, а повторная оплата продолжит ту же попытку.
+// Иначе платеж не принят, и заказ снова можно оплатить
+func (uc *useCase) paymentNotAccepted(ctx context.Context, uuid string, err error) error {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return apperrors.ErrPaymentInProgress
+	}
+
+	if revertErr := uc.revertPayment(ctx, uuid); revertErr != nil {
+		return revertErr
+	}
+
+	return apperrors.ErrPaymentFailed
+}
+
+// payInInstallments создает план рассрочки заказа. Заказ ждет результат первого платежа по UUID плана:
+// событие InstallmentPaid переводит его в PARTIALLY_PAID, а PaymentFailed возвращает к оплате
+func (uc *useCase) payInInstallments(ctx context.Context, order models.Order, installments int) (string, error) {
+	planUUID, err := uc.paymentClient.CreateInstallmentPlan(ctx, order.UUID, order.UserID, float64(order.TotalPrice), installments)
+	if err != nil {
+		if revertErr := uc.revertPayment(ctx, order.UUID); revertErr != nil {
+			return "", revertErr
+		}
+
 		return "", apperrors.ErrPaymentFailed
 	}
 
-	// 4. Обновляем заказ
+	transactionInfo := models.OrderUpdateInfo{
+		TransactionID: &planUUID,
+	}
+
+	if err := uc.orderRepository.Update(ctx, order.UUID, transactionInfo); err != nil {
+		return "", fmt.Errorf("failed to update order: %w", err)
+	}
+
+	return planUUID, nil
+}
+
+// validTenders проверяет, что частей не меньше двух, у каждой задан способ оплаты и положительная сумма,
+// а вместе они покрывают стоимость заказа
+func validTenders(tenders []models.Tender, totalPrice float32) bool {
+	if len(tenders) < minSplitTenders {
+		return false
+	}
+
+	var total int64
+	for _, tender := range tenders {
+		if tender.Amount <= 0 || tender.PaymentMethod == "" || tender.PaymentMethod == order_v1.PaymentMethodPAYMENTMETHODUNSPECIFIED {
+			return false
+		}
+		total += toMinorUnits(tender.Amount)
+	}
+
+	return total == toMinorUnits(float64(totalPrice))
+}
+
+// paymentMethods возвращает способ оплаты заказа строкой, при раздельной оплате - способы частей через запятую
+func paymentMethods(order models.Order) string {
+	if len(order.Tenders) == 0 {
+		return string(order.PaymentMethod)
+	}
+
+	methods := make([]string, 0, len(order.Tenders))
+	for _, tender := range order.Tenders {
+		methods = append(methods, string(tender.PaymentMethod))
+	}
+
+	return strings.Join(methods, ",")
+}
+
+// revertPayment возвращает заказ к оплате, если PaymentService не принял платеж
+func (uc *useCase) revertPayment(ctx context.Context, uuid string) error {
+	noInstallments := 0
+	noTenders := []models.Tender{}
+	revertInfo := models.OrderUpdateInfo{
+		Status:       &[]order_v1.OrderStatus{order_v1.OrderStatusPENDINGPAYMENT}[0],
+		Installments: &noInstallments,
+		Tenders:      &noTenders,
+	}
+
+	if err := uc.orderRepository.Update(ctx, uuid, revertInfo); err != nil {
+		return fmt.Errorf("failed to revert order status: %w", err)
+	}
+
+	return nil
+}
+
+// markPaid переводит заказ в PAID и отправляет событие OrderPaid
+func (uc *useCase) markPaid(ctx context.Context, order models.Order, transactionUUID string) error {
 	updateInfo := models.OrderUpdateInfo{
 		Status:        &[]order_v1.OrderStatus{order_v1.OrderStatusPAID}[0],
 		TransactionID: &transactionUUID,
-		PaymentMethod: &paymentMethod,
 	}
 
-	if err := uc.orderRepository.Update(ctx, uuid, updateInfo); err != nil {
-		return "", fmt.Errorf("failed to update order: %w", err)
+	if err := uc.orderRepository.Update(ctx, order.UUID, updateInfo); err != nil {
+		return fmt.Errorf("failed to update order: %w", err)
 	}
 
 	if uc.metrics != nil {
 		uc.metrics.OrdersTotal.WithLabelValues("paid").Inc()
-		uc.metrics.RevenueTotal.WithLabelValues(string(paymentMethod)).Add(float64(order.TotalPrice))
+		if len(order.Tenders) == 0 {
+			uc.metrics.RevenueTotal.WithLabelValues(string(order.PaymentMethod)).Add(float64(order.TotalPrice))
+		}
+		for _, tender := range order.Tenders {
+			uc.metrics.RevenueTotal.WithLabelValues(string(tender.PaymentMethod)).Add(tender.Amount)
+		}
 	}
 
-	// 5. Отправляем событие OrderPaid
 	event := &events.OrderPaidEvent{
 		EventUUID:       uuidgen.New().String(),
 		OrderUUID:       order.UUID,
 		UserUUID:        order.UserID,
-		PaymentMethod:   string(paymentMethod),
+		PaymentMethod:   paymentMethods(order),
 		TransactionUUID: transactionUUID,
 	}
 
@@ -63,5 +259,5 @@ func (uc *useCase) PayOrder(ctx context.Context, uuid string, paymentMethod orde
 		_ = err
 	}
 
-	return transactionUUID, nil
+	return nil
 }
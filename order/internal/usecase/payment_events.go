@@ -0,0 +1,136 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/linemk/rocket-shop/order/internal/entyties/apperrors"
+	"github.com/linemk/rocket-shop/order/internal/entyties/events"
+	"github.com/linemk/rocket-shop/order/internal/entyties/models"
+	order_v1 "github.com/linemk/rocket-shop/shared/pkg/openapi/order/v1"
+)
+
+func (uc *useCase) CompletePayment(ctx context.Context, event *events.PaymentCompletedEvent) error {
+	order, ok, err := uc.awaitingPayment(ctx, event.OrderUUID, event.TransactionUUID)
+	if err != nil || !ok {
+		return err
+	}
+
+	return uc.markPaid(ctx, order, event.TransactionUUID)
+}
+
+func (uc *useCase) FailPayment(ctx context.Context, event *events.PaymentFailedEvent) error {
+	_, ok, err := uc.awaitingPayment(ctx, event.OrderUUID, event.TransactionUUID)
+	if err != nil || !ok {
+		return err
+	}
+
+	// Заказ возвращается к оплате, транзакция неудачного платежа (отмененный план рассрочки или группа частей раздельной оплаты)
+	// больше не относится к нему
+	emptyTransactionID := ""
+	noInstallments := 0
+	noTenders := []models.Tender{}
+	updateInfo := models.OrderUpdateInfo{
+		Status:        &[]order_v1.OrderStatus{order_v1.OrderStatusPENDINGPAYMENT}[0],
+		TransactionID: &emptyTransactionID,
+		Installments:  &noInstallments,
+		Tenders:       &noTenders,
+	}
+
+	if err := uc.orderRepository.Update(ctx, event.OrderUUID, updateInfo); err != nil {
+		return fmt.Errorf("failed to update order: %w", err)
+	}
+
+	if uc.metrics != nil {
+		uc.metrics.OrdersTotal.WithLabelValues("payment_failed").Inc()
+	}
+
+	return nil
+}
+
+func (uc *useCase) ApplyInstallmentPayment(ctx context.Context, event *events.InstallmentPaidEvent) error {
+	order, err := uc.orderRepository.Get(ctx, event.OrderUUID)
+	if err != nil {
+		return apperrors.ErrOrderNotFound
+	}
+
+	// Пропускаем события чужого или устаревшего плана, а также повторы после полной оплаты
+	if order.Status != order_v1.OrderStatusPAYMENTPROCESSING && order.Status != order_v1.OrderStatusPARTIALLYPAID {
+		return nil
+	}
+	if order.TransactionID != "" && order.TransactionID != event.PlanUUID {
+		return nil
+	}
+
+	if event.PlanCompleted {
+		return uc.markPaid(ctx, order, event.PlanUUID)
+	}
+
+	if order.Status == order_v1.OrderStatusPARTIALLYPAID {
+		return nil
+	}
+
+	updateInfo := models.OrderUpdateInfo{
+		Status:        &[]order_v1.OrderStatus{order_v1.OrderStatusPARTIALLYPAID}[0],
+		TransactionID: &event.PlanUUID,
+	}
+
+	if err := uc.orderRepository.Update(ctx, order.UUID, updateInfo); err != nil {
+		return fmt.Errorf("failed to update order: %w", err)
+	}
+
+	if uc.metrics != nil {
+		uc.metrics.OrdersTotal.WithLabelValues("partially_paid").Inc()
+	}
+
+	return nil
+}
+
+func (uc *useCase) AwaitApproval(ctx context.Context, event *events.ApprovalRequestedEvent) error {
+	// При раздельной оплате заказ ждет результат всей группы, а не согласуемой части
+	paymentUUID := event.TransactionUUID
+	if event.SplitUUID != "" {
+		paymentUUID = event.SplitUUID
+	}
+
+	order, ok, err := uc.awaitingPayment(ctx, event.OrderUUID, paymentUUID)
+	if err != nil || !ok || order.Status == order_v1.OrderStatusAWAITINGAPPROVAL {
+		return err
+	}
+
+	updateInfo := models.OrderUpdateInfo{
+		Status:        &[]order_v1.OrderStatus{order_v1.OrderStatusAWAITINGAPPROVAL}[0],
+		TransactionID: &paymentUUID,
+	}
+
+	if err := uc.orderRepository.Update(ctx, order.UUID, updateInfo); err != nil {
+		return fmt.Errorf("failed to update order: %w", err)
+	}
+
+	if uc.metrics != nil {
+		uc.metrics.OrdersTotal.WithLabelValues("awaiting_approval").Inc()
+	}
+
+	return nil
+}
+
+// awaitingPayment возвращает заказ, если он ожидает результат именно этой транзакции.
+// Повторные и устаревшие события (заказ уже оплачен или оплачивается другой транзакцией) пропускаются
+func (uc *useCase) awaitingPayment(ctx context.Context, orderUUID, transactionUUID string) (models.Order, bool, error) {
+	order, err := uc.orderRepository.Get(ctx, orderUUID)
+	if err != nil {
+		return models.Order{}, false, apperrors.ErrOrderNotFound
+	}
+
+	if order.Status != order_v1.OrderStatusPAYMENTPROCESSING && order.Status != order_v1.OrderStatusAWAITINGAPPROVAL {
+		return models.Order{}, false, nil
+	}
+
+	// UUID транзакции или группы частей заказ выбирает до вызова PaymentService, поэтому он известен и до ответа.
+	// Пустой transaction_id бывает только до создания плана рассрочки, событий которого еще нет
+	if order.TransactionID == "" || order.TransactionID != transactionUUID {
+		return models.Order{}, false, nil
+	}
+
+	return order, true, nil
+}
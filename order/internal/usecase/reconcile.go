@@ -0,0 +1,395 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	uuidgen "github.com/google/uuid"
+
+	"github.com/linemk/rocket-shop/order/internal/entyties/events"
+	"github.com/linemk/rocket-shop/order/internal/entyties/models"
+	order_v1 "github.com/linemk/rocket-shop/shared/pkg/openapi/order/v1"
+)
+
+// ReconcileParams параметры сверки заказов с транзакциями PaymentService
+type ReconcileParams struct {
+	// Since сверяются заказы, созданные начиная с этого момента
+	Since time.Time
+	// GracePeriod транзакции, измененные позже now-GracePeriod, еще могут ждать обработки события и не считаются потерянными
+	GracePeriod time.Duration
+	// Fix применять пропущенные результаты платежей к заказам, ожидающим результата оплаты
+	Fix bool
+}
+
+// discrepancyTypes все виды расхождений; используется для обнуления метрик
+var discrepancyTypes = []models.DiscrepancyType{
+	models.DiscrepancyPaidWithoutTransaction,
+	models.DiscrepancyPaidTransactionNotCompleted,
+	models.DiscrepancyAmountMismatch,
+	models.DiscrepancyMultipleCompletedPayments,
+	models.DiscrepancyCompletedPaymentOnCancelledOrder,
+	models.DiscrepancyCompletedPaymentNotApplied,
+	models.DiscrepancyFailedPaymentNotApplied,
+}
+
+func (uc *useCase) Reconcile(ctx context.Context, params ReconcileParams) (models.ReconciliationReport, error) {
+	report := models.ReconciliationReport{
+		StartedAt:     time.Now(),
+		Since:         params.Since,
+		Discrepancies: make([]models.Discrepancy, 0),
+	}
+
+	orders, err := uc.orderRepository.ListCreatedSince(ctx, params.Since)
+	if err != nil {
+		uc.recordReconciliationRun("error", report)
+		return models.ReconciliationReport{}, fmt.Errorf("failed to list orders: %w", err)
+	}
+
+	for _, order := range orders {
+		transactions, err := uc.paymentClient.ListTransactions(ctx, order.UUID)
+		if err != nil {
+			// Недоступность одной выборки не должна срывать всю сверку
+			report.Errors = append(report.Errors, fmt.Sprintf("order %s: %v", order.UUID, err))
+			continue
+		}
+
+		report.OrdersChecked++
+
+		for _, discrepancy := range findDiscrepancies(order, groupPayments(order, transactions), params.GracePeriod) {
+			if params.Fix {
+				discrepancy.Corrected, err = uc.correct(ctx, order, discrepancy)
+				if err != nil {
+					report.Errors = append(report.Errors, fmt.Sprintf("order %s: failed to correct %s: %v", order.UUID, discrepancy.Type, err))
+				}
+			}
+
+			report.Discrepancies = append(report.Discrepancies, discrepancy)
+		}
+	}
+
+	report.FinishedAt = time.Now()
+	uc.recordReconciliationRun("success", report)
+
+	return report, nil
+}
+
+// paymentPartiallyPaid статус плана рассрочки, по которому оплачена часть платежей графика
+const paymentPartiallyPaid = "PARTIALLY_PAID"
+
+// groupPayments сводит транзакции заказа в платежи: части раздельной оплаты - в группу, а платежи графика - в план
+// рассрочки. UUID такого платежа совпадает с transaction_id заказа, а статус и сумма считаются по его транзакциям
+func groupPayments(order models.Order, transactions []models.PaymentTransaction) []models.PaymentTransaction {
+	payments := make([]models.PaymentTransaction, 0, len(transactions))
+	groups := make(map[string][]models.PaymentTransaction)
+	for _, transaction := range transactions {
+		key := transaction.SplitUUID
+		if key == "" {
+			key = transaction.InstallmentPlanUUID
+		}
+		if key == "" {
+			payments = append(payments, transaction)
+			continue
+		}
+
+		if _, ok := groups[key]; !ok {
+			// Место группы в списке резервируется по ее первой транзакции
+			payments = append(payments, models.PaymentTransaction{UUID: key})
+		}
+		groups[key] = append(groups[key], transaction)
+	}
+
+	for i := range payments {
+		group, ok := groups[payments[i].UUID]
+		if !ok {
+			continue
+		}
+
+		if group[0].SplitUUID != "" {
+			payments[i] = splitPayment(group)
+			continue
+		}
+
+		installments := 0
+		if payments[i].UUID == order.TransactionID {
+			installments = order.Installments
+		}
+		payments[i] = planPayment(group, installments)
+	}
+
+	return payments
+}
+
+// splitPayment сводит части раздельной оплаты так же, как PaymentService: группа оплачена после списания всех
+// частей и не прошла после первого отказа. Списанные части не прошедшей группы возвращает PaymentService
+func splitPayment(tenders []models.PaymentTransaction) models.PaymentTransaction {
+	payment := models.PaymentTransaction{
+		UUID:      tenders[0].SplitUUID,
+		SplitUUID: tenders[0].SplitUUID,
+		Status:    models.PaymentStatusCompleted,
+		CreatedAt: tenders[0].CreatedAt,
+	}
+
+	var amount int64
+	for _, tender := range tenders {
+		amount += toMinorUnits(tender.Amount)
+		if tender.UpdatedAt.After(payment.UpdatedAt) {
+			payment.UpdatedAt = tender.UpdatedAt
+		}
+
+		switch {
+		case payment.Status != models.PaymentStatusCompleted && payment.Status != models.PaymentStatusPending:
+			// Итог группы определяет первый отказ
+		case tender.Status == models.PaymentStatusPending:
+			payment.Status = models.PaymentStatusPending
+		case tender.Status != models.PaymentStatusCompleted:
+			payment.Status = tender.Status
+			payment.FailureReason = tender.FailureReason
+		}
+	}
+	payment.Amount = float64(amount) / 100
+
+	return payment
+}
+
+// planPayment сводит платежи графика рассрочки: план оплачен после списания всех installments платежей,
+// частично оплачен после первого из них и не прошел, если первый платеж отклонен. Для устаревших планов
+// число платежей неизвестно (installments = 0), и оплаченный план считается частично оплаченным.
+// Сумма плана - сумма списанных платежей, она включает переплату по рассрочке
+func planPayment(transactions []models.PaymentTransaction, installments int) models.PaymentTransaction {
+	payment := models.PaymentTransaction{
+		UUID:                transactions[0].InstallmentPlanUUID,
+		InstallmentPlanUUID: transactions[0].InstallmentPlanUUID,
+		CreatedAt:           transactions[0].CreatedAt,
+	}
+
+	var amount int64
+	var completed int
+	var pending bool
+	var last *models.PaymentTransaction
+	for i := range transactions {
+		transaction := &transactions[i]
+		if transaction.UpdatedAt.After(payment.UpdatedAt) {
+			payment.UpdatedAt = transaction.UpdatedAt
+		}
+
+		switch transaction.Status {
+		case models.PaymentStatusCompleted:
+			completed++
+			amount += toMinorUnits(transaction.Amount)
+		case models.PaymentStatusPending:
+			pending = true
+		default:
+			if last == nil || transaction.UpdatedAt.After(last.UpdatedAt) {
+				last = transaction
+			}
+		}
+	}
+	payment.Amount = float64(amount) / 100
+
+	switch {
+	case completed > 0 && completed == installments:
+		payment.Status = models.PaymentStatusCompleted
+	case completed > 0:
+		payment.Status = paymentPartiallyPaid
+	case pending || last == nil:
+		payment.Status = models.PaymentStatusPending
+	default:
+		payment.Status = last.Status
+		payment.FailureReason = last.FailureReason
+	}
+
+	return payment
+}
+
+// findDiscrepancies сверяет статус заказа с его платежами
+func findDiscrepancies(order models.Order, transactions []models.PaymentTransaction, gracePeriod time.Duration) []models.Discrepancy {
+	var discrepancies []models.Discrepancy
+
+	newDiscrepancy := func(discrepancyType models.DiscrepancyType, transaction *models.PaymentTransaction, details string) models.Discrepancy {
+		discrepancy := models.Discrepancy{
+			Type:        discrepancyType,
+			OrderUUID:   order.UUID,
+			OrderStatus: order.Status,
+			OrderAmount: float64(order.TotalPrice),
+			Details:     details,
+		}
+		if transaction != nil {
+			discrepancy.TransactionUUID = transaction.UUID
+			discrepancy.TransactionStatus = transaction.Status
+			discrepancy.TransactionAmount = transaction.Amount
+		}
+		return discrepancy
+	}
+
+	var orderTransaction, lastCompleted *models.PaymentTransaction
+	completed := 0
+	for i := range transactions {
+		transaction := &transactions[i]
+		if transaction.UUID == order.TransactionID {
+			orderTransaction = transaction
+		}
+		if charged(transaction) {
+			completed++
+			lastCompleted = transaction
+		}
+	}
+
+	settled := func(transaction *models.PaymentTransaction) bool {
+		return time.Since(transaction.UpdatedAt) >= gracePeriod
+	}
+
+	switch order.Status {
+	case order_v1.OrderStatusPAID, order_v1.OrderStatusCOMPLETED:
+		switch {
+		case orderTransaction == nil:
+			discrepancies = append(discrepancies, newDiscrepancy(models.DiscrepancyPaidWithoutTransaction, nil,
+				fmt.Sprintf("transaction %q of paid order not found in payment service", order.TransactionID)))
+		case orderTransaction.Status != models.PaymentStatusCompleted:
+			discrepancies = append(discrepancies, newDiscrepancy(models.DiscrepancyPaidTransactionNotCompleted, orderTransaction,
+				"paid order references a transaction that is not completed"))
+		case order.Installments > 0 && toMinorUnits(orderTransaction.Amount) < toMinorUnits(float64(order.TotalPrice)):
+			discrepancies = append(discrepancies, newDiscrepancy(models.DiscrepancyAmountMismatch, orderTransaction,
+				"completed installment payments do not cover order total"))
+		case order.Installments == 0 && toMinorUnits(orderTransaction.Amount) != toMinorUnits(float64(order.TotalPrice)):
+			discrepancies = append(discrepancies, newDiscrepancy(models.DiscrepancyAmountMismatch, orderTransaction,
+				"completed transaction amount differs from order total"))
+		}
+
+	case order_v1.OrderStatusPARTIALLYPAID:
+		switch {
+		case orderTransaction == nil:
+			discrepancies = append(discrepancies, newDiscrepancy(models.DiscrepancyPaidWithoutTransaction, nil,
+				fmt.Sprintf("installment plan %q of partially paid order not found in payment service", order.TransactionID)))
+		case orderTransaction.Status == models.PaymentStatusCompleted && settled(orderTransaction):
+			discrepancies = append(discrepancies, newDiscrepancy(models.DiscrepancyCompletedPaymentNotApplied, orderTransaction,
+				"installment plan completed but order is not marked as paid"))
+		case !charged(orderTransaction):
+			discrepancies = append(discrepancies, newDiscrepancy(models.DiscrepancyPaidTransactionNotCompleted, orderTransaction,
+				"partially paid order references an installment plan without completed payments"))
+		}
+
+	case order_v1.OrderStatusCANCELLED:
+		for i := range transactions {
+			if charged(&transactions[i]) {
+				discrepancies = append(discrepancies, newDiscrepancy(models.DiscrepancyCompletedPaymentOnCancelledOrder, &transactions[i],
+					"cancelled order has a completed payment that must be refunded"))
+			}
+		}
+
+	case order_v1.OrderStatusPENDINGPAYMENT, order_v1.OrderStatusPAYMENTPROCESSING, order_v1.OrderStatusAWAITINGAPPROVAL:
+		switch {
+		case lastCompleted != nil && settled(lastCompleted):
+			discrepancies = append(discrepancies, newDiscrepancy(models.DiscrepancyCompletedPaymentNotApplied, lastCompleted,
+				"payment completed but order is not marked as paid"))
+		case order.Status != order_v1.OrderStatusPENDINGPAYMENT && orderTransaction != nil &&
+			orderTransaction.Status != models.PaymentStatusPending && !charged(orderTransaction) &&
+			settled(orderTransaction):
+			discrepancies = append(discrepancies, newDiscrepancy(models.DiscrepancyFailedPaymentNotApplied, orderTransaction,
+				"payment failed but order is still awaiting payment result"))
+		}
+	}
+
+	if completed > 1 {
+		discrepancies = append(discrepancies, newDiscrepancy(models.DiscrepancyMultipleCompletedPayments, nil,
+			fmt.Sprintf("order has %d completed payments", completed)))
+	}
+
+	return discrepancies
+}
+
+// charged возвращает true, если по платежу списаны средства: транзакция или группа частей завершена,
+// либо по плану рассрочки оплачен хотя бы один платеж
+func charged(transaction *models.PaymentTransaction) bool {
+	return transaction.Status == models.PaymentStatusCompleted || transaction.Status == paymentPartiallyPaid
+}
+
+// correct применяет к заказу пропущенный результат платежа так же, как это сделал бы consumer событий оплаты.
+// Остальные расхождения требуют ручного разбора (например, возврата средств) и только попадают в отчет
+func (uc *useCase) correct(ctx context.Context, order models.Order, discrepancy models.Discrepancy) (bool, error) {
+	if order.Status != order_v1.OrderStatusPAYMENTPROCESSING && order.Status != order_v1.OrderStatusAWAITINGAPPROVAL &&
+		order.Status != order_v1.OrderStatusPARTIALLYPAID {
+		return false, nil
+	}
+
+	var err error
+	switch {
+	case discrepancy.Type == models.DiscrepancyCompletedPaymentNotApplied && order.Installments > 0:
+		// Заказ в рассрочку оплачивается событием InstallmentPaid: частично после первого платежа, полностью - после последнего
+		err = uc.ApplyInstallmentPayment(ctx, &events.InstallmentPaidEvent{
+			EventUUID:         uuidgen.New().String(),
+			PlanUUID:          discrepancy.TransactionUUID,
+			OrderUUID:         order.UUID,
+			UserUUID:          order.UserID,
+			InstallmentsTotal: order.Installments,
+			PaidAmount:        discrepancy.TransactionAmount,
+			PlanCompleted:     discrepancy.TransactionStatus == models.PaymentStatusCompleted,
+			PaidAt:            time.Now(),
+		})
+	case order.Status == order_v1.OrderStatusPARTIALLYPAID:
+		return false, nil
+	case discrepancy.Type == models.DiscrepancyCompletedPaymentNotApplied:
+		err = uc.CompletePayment(ctx, &events.PaymentCompletedEvent{
+			EventUUID:       uuidgen.New().String(),
+			TransactionUUID: discrepancy.TransactionUUID,
+			OrderUUID:       order.UUID,
+			UserUUID:        order.UserID,
+			PaymentMethod:   string(order.PaymentMethod),
+			Amount:          discrepancy.TransactionAmount,
+			CompletedAt:     time.Now(),
+		})
+	case discrepancy.Type == models.DiscrepancyFailedPaymentNotApplied:
+		err = uc.FailPayment(ctx, &events.PaymentFailedEvent{
+			EventUUID:       uuidgen.New().String(),
+			TransactionUUID: discrepancy.TransactionUUID,
+			OrderUUID:       order.UUID,
+			UserUUID:        order.UserID,
+			Status:          discrepancy.TransactionStatus,
+			Reason:          "applied by reconciliation",
+			FailedAt:        time.Now(),
+		})
+	default:
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	// Событие пропускается, если заказ успел измениться; исправлением считается только фактическая смена статуса
+	updated, err := uc.orderRepository.Get(ctx, order.UUID)
+	if err != nil {
+		return false, err
+	}
+
+	corrected := updated.Status != order.Status
+	if corrected && uc.metrics != nil {
+		uc.metrics.ReconciliationCorrectionsTotal.WithLabelValues(string(discrepancy.Type)).Inc()
+	}
+
+	return corrected, nil
+}
+
+func (uc *useCase) recordReconciliationRun(result string, report models.ReconciliationReport) {
+	if uc.metrics == nil {
+		return
+	}
+
+	uc.metrics.ReconciliationRunsTotal.WithLabelValues(result).Inc()
+	if result != "success" {
+		return
+	}
+
+	counts := make(map[models.DiscrepancyType]int, len(discrepancyTypes))
+	for _, discrepancy := range report.Discrepancies {
+		counts[discrepancy.Type]++
+	}
+	for _, discrepancyType := range discrepancyTypes {
+		uc.metrics.ReconciliationDiscrepancies.WithLabelValues(string(discrepancyType)).Set(float64(counts[discrepancyType]))
+	}
+}
+
+// toMinorUnits переводит сумму в копейки, чтобы сравнивать суммы без ошибок округления
+func toMinorUnits(amount float64) int64 {
+	return int64(math.Round(amount * 100))
+}
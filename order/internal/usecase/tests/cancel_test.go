@@ -72,6 +72,21 @@ func TestCancel(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "error order awaiting approval",
+			fields: fields{
+				orderRepository: func() *mocks.MockOrderRepository {
+					mockClient := mocks.NewMockOrderRepository(gomock.NewController(t))
+					mockClient.EXPECT().Get(ctx, testUUID.String()).Return(models.Order{
+						UUID:   testUUID.String(),
+						Status: order_v1.OrderStatusAWAITINGAPPROVAL,
+					}, nil)
+
+					return mockClient
+				},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -34,9 +34,10 @@ func TestCreate(t *testing.T) {
 	}
 
 	tests := []struct {
-		name    string
-		fields  fields
-		wantErr bool
+		name      string
+		fields    fields
+		wantErr   bool
+		wantErrIs error
 	}{
 		{
 			name: "successfully create a part",
@@ -44,22 +45,25 @@ func TestCreate(t *testing.T) {
 				inventoryClient: func() *mocks.MockInventoryClient {
 					mockClient := mocks.NewMockInventoryClient(gomock.NewController(t)) // нужен для подсчета вызовов
 
-					mockClient.EXPECT().GetPart(gomock.Any(), partUUID1).Return(
-						v1.PartInfo{
-							UUID:          partUUID1.String(),
-							Name:          "Engine Part",
-							Price:         100.0,
-							StockQuantity: 5,
-						}, nil,
-					)
-
-					mockClient.EXPECT().GetPart(gomock.Any(), partUUID2).Return(
-						v1.PartInfo{
-							UUID:          partUUID2.String(),
-							Name:          "Wing Part",
-							Price:         200.0,
-							StockQuantity: 5,
-						}, nil,
+					// Сборка проверяется до чтения деталей
+					gomock.InOrder(
+						mockClient.EXPECT().ValidateBuild(gomock.Any(), []uuid.UUID{partUUID1, partUUID2}).Return(nil, nil),
+						mockClient.EXPECT().GetPart(gomock.Any(), partUUID1).Return(
+							v1.PartInfo{
+								UUID:          partUUID1.String(),
+								Name:          "Engine Part",
+								Price:         100.0,
+								StockQuantity: 5,
+							}, nil,
+						),
+						mockClient.EXPECT().GetPart(gomock.Any(), partUUID2).Return(
+							v1.PartInfo{
+								UUID:          partUUID2.String(),
+								Name:          "Wing Part",
+								Price:         200.0,
+								StockQuantity: 5,
+							}, nil,
+						),
 					)
 
 					return mockClient
@@ -85,6 +89,7 @@ func TestCreate(t *testing.T) {
 			fields: fields{
 				inventoryClient: func() *mocks.MockInventoryClient {
 					mockClient := mocks.NewMockInventoryClient(gomock.NewController(t))
+					mockClient.EXPECT().ValidateBuild(gomock.Any(), []uuid.UUID{partUUID1, partUUID2}).Return(nil, nil)
 					mockClient.EXPECT().GetPart(gomock.Any(), partUUID1).Return(v1.PartInfo{}, apperrors.ErrPartNotFound)
 
 					return mockClient
@@ -108,6 +113,7 @@ func TestCreate(t *testing.T) {
 			fields: fields{
 				inventoryClient: func() *mocks.MockInventoryClient {
 					mockClient := mocks.NewMockInventoryClient(gomock.NewController(t))
+					mockClient.EXPECT().ValidateBuild(gomock.Any(), []uuid.UUID{partUUID1, partUUID2}).Return(nil, nil)
 					mockClient.EXPECT().GetPart(gomock.Any(), partUUID1).Return(v1.PartInfo{}, apperrors.ErrPartOutOfStock)
 
 					return mockClient
@@ -150,6 +156,8 @@ func TestCreate(t *testing.T) {
 						}, nil,
 					)
 
+					mockClient.EXPECT().ValidateBuild(gomock.Any(), []uuid.UUID{partUUID1, partUUID2}).Return(nil, nil)
+
 					return mockClient
 				},
 
@@ -168,6 +176,61 @@ func TestCreate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "error build violates compatibility rules",
+			fields: fields{
+				inventoryClient: func() *mocks.MockInventoryClient {
+					mockClient := mocks.NewMockInventoryClient(gomock.NewController(t))
+
+					mockClient.EXPECT().ValidateBuild(gomock.Any(), []uuid.UUID{partUUID1, partUUID2}).Return(
+						[]v1.BuildViolation{{
+							Type:    "BUILD_VIOLATION_TYPE_MISSING_CATEGORY",
+							Message: "rocket needs at least one fuel tank",
+						}}, nil,
+					)
+
+					return mockClient
+				},
+
+				orderRepository: func() *mocks.MockOrderRepository {
+					mockClient := mocks.NewMockOrderRepository(gomock.NewController(t))
+
+					return mockClient
+				},
+				paymentClient: func() *mocks.MockPaymentClient {
+					mockClient := mocks.NewMockPaymentClient(gomock.NewController(t))
+
+					return mockClient
+				},
+			},
+			wantErr:   true,
+			wantErrIs: apperrors.ErrInvalidBuild,
+		},
+		{
+			name: "error validate build call failed",
+			fields: fields{
+				inventoryClient: func() *mocks.MockInventoryClient {
+					mockClient := mocks.NewMockInventoryClient(gomock.NewController(t))
+
+					mockClient.EXPECT().ValidateBuild(gomock.Any(), []uuid.UUID{partUUID1, partUUID2}).Return(nil, fmt.Errorf("inventory unavailable"))
+
+					return mockClient
+				},
+
+				orderRepository: func() *mocks.MockOrderRepository {
+					mockClient := mocks.NewMockOrderRepository(gomock.NewController(t))
+
+					return mockClient
+				},
+				paymentClient: func() *mocks.MockPaymentClient {
+					mockClient := mocks.NewMockPaymentClient(gomock.NewController(t))
+
+					return mockClient
+				},
+			},
+			wantErr:   true,
+			wantErrIs: nil,
+		},
 	}
 
 	for _, tt := range tests {
@@ -188,6 +251,9 @@ func TestCreate(t *testing.T) {
 
 			if tt.wantErr {
 				require.Error(t, err)
+				if tt.wantErrIs != nil {
+					require.ErrorIs(t, err, tt.wantErrIs)
+				}
 				return
 			}
 
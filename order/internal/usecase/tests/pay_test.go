@@ -8,6 +8,8 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/linemk/rocket-shop/order/internal/entyties/apperrors"
 	"github.com/linemk/rocket-shop/order/internal/entyties/models"
@@ -44,13 +46,17 @@ func TestPayOrder(t *testing.T) {
 						Status: order_v1.OrderStatusPENDINGPAYMENT,
 					}, nil)
 
-					mockClient.EXPECT().Update(ctx, testUUID, gomock.Any()).Return(nil)
+					// PAYMENT_PROCESSING, затем PAID
+					mockClient.EXPECT().Update(ctx, testUUID, gomock.Any()).Return(nil).Times(2)
 
 					return mockClient
 				},
 				paymentClient: func() *mocks.MockPaymentClient {
 					mockClient := mocks.NewMockPaymentClient(gomock.NewController(t))
-					mockClient.EXPECT().PayOrder(ctx, testUUID, "user-123", payment_v1.PaymentMethod_PAYMENT_METHOD_CARD).Return(transactionUUID, nil)
+					mockClient.EXPECT().PayOrder(ctx, testUUID, "user-123", payment_v1.PaymentMethod_PAYMENT_METHOD_CARD, float64(0), gomock.Any()).Return(models.PaymentResult{
+						TransactionUUID: transactionUUID,
+						Status:          models.PaymentStatusCompleted,
+					}, nil)
 
 					return mockClient
 				},
@@ -63,6 +69,40 @@ func TestPayOrder(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "payment returns pending transaction of previous attempt",
+			fields: fields{
+				orderRepository: func() *mocks.MockOrderRepository {
+					mockClient := mocks.NewMockOrderRepository(gomock.NewController(t))
+					mockClient.EXPECT().Get(ctx, testUUID).Return(models.Order{
+						UUID:   testUUID,
+						UserID: "user-123",
+						Status: order_v1.OrderStatusPENDINGPAYMENT,
+					}, nil)
+
+					// PAYMENT_PROCESSING, затем transaction_id транзакции, результат которой ждет заказ
+					mockClient.EXPECT().Update(ctx, testUUID, gomock.Any()).Return(nil).Times(2)
+
+					return mockClient
+				},
+				paymentClient: func() *mocks.MockPaymentClient {
+					mockClient := mocks.NewMockPaymentClient(gomock.NewController(t))
+					mockClient.EXPECT().PayOrder(ctx, testUUID, "user-123", payment_v1.PaymentMethod_PAYMENT_METHOD_CARD, float64(0), gomock.Any()).Return(models.PaymentResult{
+						TransactionUUID: transactionUUID,
+						Status:          "PENDING",
+					}, nil)
+
+					return mockClient
+				},
+				orderProducerService: func() *mocks.MockOrderProducerService {
+					// OrderPaid отправится после события PaymentCompleted
+					mockService := mocks.NewMockOrderProducerService(gomock.NewController(t))
+
+					return mockService
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "error order not found",
 			fields: fields{
@@ -121,11 +161,14 @@ func TestPayOrder(t *testing.T) {
 						Status: order_v1.OrderStatusPENDINGPAYMENT,
 					}, nil)
 
+					// PAYMENT_PROCESSING, затем возврат в PENDING_PAYMENT
+					mockClient.EXPECT().Update(ctx, testUUID, gomock.Any()).Return(nil).Times(2)
+
 					return mockClient
 				},
 				paymentClient: func() *mocks.MockPaymentClient {
 					mockClient := mocks.NewMockPaymentClient(gomock.NewController(t))
-					mockClient.EXPECT().PayOrder(ctx, testUUID, "user-123", payment_v1.PaymentMethod_PAYMENT_METHOD_CARD).Return("", fmt.Errorf("payment service error"))
+					mockClient.EXPECT().PayOrder(ctx, testUUID, "user-123", payment_v1.PaymentMethod_PAYMENT_METHOD_CARD, float64(0), gomock.Any()).Return(models.PaymentResult{}, fmt.Errorf("payment service error"))
 
 					return mockClient
 				},
@@ -147,7 +190,7 @@ func TestPayOrder(t *testing.T) {
 
 			uc := usecase.NewUseCase(orderRepository, nil, paymentClient, orderProducerService, nil)
 
-			result, err := uc.PayOrder(ctx, testUUID, order_v1.PaymentMethodPAYMENTMETHODCARD)
+			result, err := uc.PayOrder(ctx, testUUID, order_v1.PaymentMethodPAYMENTMETHODCARD, 0, nil)
 
 			if tt.wantErr {
 				require.Error(t, err)
@@ -159,3 +202,296 @@ func TestPayOrder(t *testing.T) {
 		})
 	}
 }
+
+func TestPayOrderIdempotency(t *testing.T) {
+	ctx := context.Background()
+	orderUUID := uuid.New().String()
+
+	pendingOrder := models.Order{
+		UUID:       orderUUID,
+		UserID:     "user-123",
+		TotalPrice: 1000,
+		Status:     order_v1.OrderStatusPENDINGPAYMENT,
+	}
+
+	t.Run("order saves payment key before payment", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		var paymentKey string
+		orderRepository := mocks.NewMockOrderRepository(ctrl)
+		orderRepository.EXPECT().Get(ctx, orderUUID).Return(pendingOrder, nil)
+		orderRepository.EXPECT().Update(ctx, orderUUID, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ string, info models.OrderUpdateInfo) error {
+				require.Equal(t, order_v1.OrderStatusPAYMENTPROCESSING, *info.Status)
+				require.NotEmpty(t, *info.TransactionID)
+				paymentKey = *info.TransactionID
+				return nil
+			})
+
+		paymentClient := mocks.NewMockPaymentClient(ctrl)
+		paymentClient.EXPECT().PayOrder(ctx, orderUUID, "user-123", payment_v1.PaymentMethod_PAYMENT_METHOD_CARD, float64(1000), gomock.Any()).DoAndReturn(
+			func(_ context.Context, _, _ string, _ payment_v1.PaymentMethod, _ float64, idempotencyKey string) (models.PaymentResult, error) {
+				require.Equal(t, paymentKey, idempotencyKey)
+				return models.PaymentResult{
+					TransactionUUID: idempotencyKey,
+					Status:          models.PaymentStatusPending,
+				}, nil
+			})
+
+		uc := usecase.NewUseCase(orderRepository, nil, paymentClient, mocks.NewMockOrderProducerService(ctrl), nil)
+
+		result, err := uc.PayOrder(ctx, orderUUID, order_v1.PaymentMethodPAYMENTMETHODCARD, 0, nil)
+		require.NoError(t, err)
+		require.Equal(t, paymentKey, result)
+	})
+
+	for _, code := range []codes.Code{codes.Unavailable, codes.DeadlineExceeded} {
+		t.Run(fmt.Sprintf("%s keeps order processing", code), func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			// Платеж мог быть принят: заказ не возвращается к оплате
+			orderRepository := mocks.NewMockOrderRepository(ctrl)
+			orderRepository.EXPECT().Get(ctx, orderUUID).Return(pendingOrder, nil)
+			orderRepository.EXPECT().Update(ctx, orderUUID, gomock.Any()).Return(nil)
+
+			paymentClient := mocks.NewMockPaymentClient(ctrl)
+			paymentClient.EXPECT().PayOrder(ctx, orderUUID, "user-123", payment_v1.PaymentMethod_PAYMENT_METHOD_CARD, float64(1000), gomock.Any()).
+				Return(models.PaymentResult{}, fmt.Errorf("failed to pay order: %w", status.Error(code, "payment service is not responding")))
+
+			uc := usecase.NewUseCase(orderRepository, nil, paymentClient, mocks.NewMockOrderProducerService(ctrl), nil)
+
+			_, err := uc.PayOrder(ctx, orderUUID, order_v1.PaymentMethodPAYMENTMETHODCARD, 0, nil)
+			require.ErrorIs(t, err, apperrors.ErrPaymentInProgress)
+		})
+	}
+
+	t.Run("retry resumes payment with the same key", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		paymentKey := uuid.New().String()
+
+		orderRepository := mocks.NewMockOrderRepository(ctrl)
+		orderRepository.EXPECT().Get(ctx, orderUUID).Return(models.Order{
+			UUID:          orderUUID,
+			UserID:        "user-123",
+			TotalPrice:    1000,
+			Status:        order_v1.OrderStatusPAYMENTPROCESSING,
+			PaymentMethod: order_v1.PaymentMethodPAYMENTMETHODCARD,
+			TransactionID: paymentKey,
+		}, nil)
+
+		paymentClient := mocks.NewMockPaymentClient(ctrl)
+		paymentClient.EXPECT().PayOrder(ctx, orderUUID, "user-123", payment_v1.PaymentMethod_PAYMENT_METHOD_CARD, float64(1000), paymentKey).
+			Return(models.PaymentResult{
+				TransactionUUID: paymentKey,
+				Status:          models.PaymentStatusPending,
+			}, nil)
+
+		uc := usecase.NewUseCase(orderRepository, nil, paymentClient, mocks.NewMockOrderProducerService(ctrl), nil)
+
+		result, err := uc.PayOrder(ctx, orderUUID, order_v1.PaymentMethodPAYMENTMETHODCARD, 0, nil)
+		require.NoError(t, err)
+		require.Equal(t, paymentKey, result)
+	})
+
+	t.Run("retry with another payment method is rejected", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		orderRepository := mocks.NewMockOrderRepository(ctrl)
+		orderRepository.EXPECT().Get(ctx, orderUUID).Return(models.Order{
+			UUID:          orderUUID,
+			UserID:        "user-123",
+			TotalPrice:    1000,
+			Status:        order_v1.OrderStatusPAYMENTPROCESSING,
+			PaymentMethod: order_v1.PaymentMethodPAYMENTMETHODCARD,
+			TransactionID: uuid.New().String(),
+		}, nil)
+
+		uc := usecase.NewUseCase(orderRepository, nil, mocks.NewMockPaymentClient(ctrl), mocks.NewMockOrderProducerService(ctrl), nil)
+
+		_, err := uc.PayOrder(ctx, orderUUID, order_v1.PaymentMethodPAYMENTMETHODSBP, 0, nil)
+		require.ErrorIs(t, err, apperrors.ErrOrderCannotBePaid)
+	})
+}
+
+func TestPayOrderInstallments(t *testing.T) {
+	ctx := context.Background()
+	orderUUID := uuid.New().String()
+	planUUID := uuid.New().String()
+
+	pendingOrder := models.Order{
+		UUID:       orderUUID,
+		UserID:     "user-123",
+		TotalPrice: 1000,
+		Status:     order_v1.OrderStatusPENDINGPAYMENT,
+	}
+
+	t.Run("order references installment plan", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		orderRepository := mocks.NewMockOrderRepository(ctrl)
+		orderRepository.EXPECT().Get(ctx, orderUUID).Return(pendingOrder, nil)
+		gomock.InOrder(
+			orderRepository.EXPECT().Update(ctx, orderUUID, gomock.Any()).DoAndReturn(
+				func(_ context.Context, _ string, info models.OrderUpdateInfo) error {
+					require.Equal(t, order_v1.OrderStatusPAYMENTPROCESSING, *info.Status)
+					require.Equal(t, 6, *info.Installments)
+					return nil
+				}),
+			orderRepository.EXPECT().Update(ctx, orderUUID, gomock.Any()).DoAndReturn(
+				func(_ context.Context, _ string, info models.OrderUpdateInfo) error {
+					require.Nil(t, info.Status)
+					require.Equal(t, planUUID, *info.TransactionID)
+					return nil
+				}),
+		)
+
+		paymentClient := mocks.NewMockPaymentClient(ctrl)
+		paymentClient.EXPECT().CreateInstallmentPlan(ctx, orderUUID, "user-123", float64(1000), 6).Return(planUUID, nil)
+
+		uc := usecase.NewUseCase(orderRepository, nil, paymentClient, mocks.NewMockOrderProducerService(ctrl), nil)
+
+		result, err := uc.PayOrder(ctx, orderUUID, order_v1.PaymentMethodPAYMENTMETHODCREDITCARD, 6, nil)
+		require.NoError(t, err)
+		require.Equal(t, planUUID, result)
+	})
+
+	t.Run("rejected plan returns order to payment", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		orderRepository := mocks.NewMockOrderRepository(ctrl)
+		orderRepository.EXPECT().Get(ctx, orderUUID).Return(pendingOrder, nil)
+		gomock.InOrder(
+			orderRepository.EXPECT().Update(ctx, orderUUID, gomock.Any()).Return(nil),
+			orderRepository.EXPECT().Update(ctx, orderUUID, gomock.Any()).DoAndReturn(
+				func(_ context.Context, _ string, info models.OrderUpdateInfo) error {
+					require.Equal(t, order_v1.OrderStatusPENDINGPAYMENT, *info.Status)
+					require.Zero(t, *info.Installments)
+					return nil
+				}),
+		)
+
+		paymentClient := mocks.NewMockPaymentClient(ctrl)
+		paymentClient.EXPECT().CreateInstallmentPlan(ctx, orderUUID, "user-123", float64(1000), 5).
+			Return("", fmt.Errorf("installment plan with this number of payments is not available"))
+
+		uc := usecase.NewUseCase(orderRepository, nil, paymentClient, mocks.NewMockOrderProducerService(ctrl), nil)
+
+		_, err := uc.PayOrder(ctx, orderUUID, order_v1.PaymentMethodPAYMENTMETHODCREDITCARD, 5, nil)
+		require.ErrorIs(t, err, apperrors.ErrPaymentFailed)
+	})
+
+	t.Run("installments require credit card", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		uc := usecase.NewUseCase(mocks.NewMockOrderRepository(ctrl), nil, mocks.NewMockPaymentClient(ctrl), nil, nil)
+
+		_, err := uc.PayOrder(ctx, orderUUID, order_v1.PaymentMethodPAYMENTMETHODSBP, 3, nil)
+		require.ErrorIs(t, err, apperrors.ErrInvalidInstallments)
+	})
+}
+
+func TestPayOrderSplit(t *testing.T) {
+	ctx := context.Background()
+	orderUUID := uuid.New().String()
+
+	pendingOrder := models.Order{
+		UUID:       orderUUID,
+		UserID:     "user-123",
+		TotalPrice: 1000,
+		Status:     order_v1.OrderStatusPENDINGPAYMENT,
+	}
+
+	tenders := []models.Tender{
+		{PaymentMethod: order_v1.PaymentMethodPAYMENTMETHODSBP, Amount: 600},
+		{PaymentMethod: order_v1.PaymentMethodPAYMENTMETHODINVESTORMONEY, Amount: 400},
+	}
+
+	t.Run("order references split group", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		// Заказ ждет группу частей с UUID, выбранным до вызова PaymentService
+		var paymentKey string
+		orderRepository := mocks.NewMockOrderRepository(ctrl)
+		orderRepository.EXPECT().Get(ctx, orderUUID).Return(pendingOrder, nil)
+		orderRepository.EXPECT().Update(ctx, orderUUID, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ string, info models.OrderUpdateInfo) error {
+				require.Equal(t, order_v1.OrderStatusPAYMENTPROCESSING, *info.Status)
+				require.Equal(t, order_v1.PaymentMethodPAYMENTMETHODUNSPECIFIED, *info.PaymentMethod)
+				require.Equal(t, tenders, *info.Tenders)
+				require.NotEmpty(t, *info.TransactionID)
+				paymentKey = *info.TransactionID
+				return nil
+			})
+
+		paymentClient := mocks.NewMockPaymentClient(ctrl)
+		paymentClient.EXPECT().PaySplit(ctx, orderUUID, "user-123", float64(1000), tenders, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _, _ string, _ float64, _ []models.Tender, idempotencyKey string) (models.PaymentResult, error) {
+				require.Equal(t, paymentKey, idempotencyKey)
+				return models.PaymentResult{
+					TransactionUUID: idempotencyKey,
+					Status:          models.PaymentStatusPending,
+				}, nil
+			})
+
+		uc := usecase.NewUseCase(orderRepository, nil, paymentClient, mocks.NewMockOrderProducerService(ctrl), nil)
+
+		result, err := uc.PayOrder(ctx, orderUUID, order_v1.PaymentMethodPAYMENTMETHODUNSPECIFIED, 0, tenders)
+		require.NoError(t, err)
+		require.Equal(t, paymentKey, result)
+	})
+
+	t.Run("rejected split returns order to payment", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		orderRepository := mocks.NewMockOrderRepository(ctrl)
+		orderRepository.EXPECT().Get(ctx, orderUUID).Return(pendingOrder, nil)
+		gomock.InOrder(
+			orderRepository.EXPECT().Update(ctx, orderUUID, gomock.Any()).Return(nil),
+			orderRepository.EXPECT().Update(ctx, orderUUID, gomock.Any()).DoAndReturn(
+				func(_ context.Context, _ string, info models.OrderUpdateInfo) error {
+					require.Equal(t, order_v1.OrderStatusPENDINGPAYMENT, *info.Status)
+					require.Empty(t, *info.Tenders)
+					return nil
+				}),
+		)
+
+		paymentClient := mocks.NewMockPaymentClient(ctrl)
+		paymentClient.EXPECT().PaySplit(ctx, orderUUID, "user-123", float64(1000), tenders, gomock.Any()).
+			Return(models.PaymentResult{}, fmt.Errorf("payment service error"))
+
+		uc := usecase.NewUseCase(orderRepository, nil, paymentClient, mocks.NewMockOrderProducerService(ctrl), nil)
+
+		_, err := uc.PayOrder(ctx, orderUUID, "", 0, tenders)
+		require.ErrorIs(t, err, apperrors.ErrPaymentFailed)
+	})
+
+	t.Run("tenders must cover order total", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		orderRepository := mocks.NewMockOrderRepository(ctrl)
+		orderRepository.EXPECT().Get(ctx, orderUUID).Return(pendingOrder, nil)
+
+		uc := usecase.NewUseCase(orderRepository, nil, mocks.NewMockPaymentClient(ctrl), nil, nil)
+
+		_, err := uc.PayOrder(ctx, orderUUID, "", 0, []models.Tender{
+			{PaymentMethod: order_v1.PaymentMethodPAYMENTMETHODSBP, Amount: 600},
+			{PaymentMethod: order_v1.PaymentMethodPAYMENTMETHODCARD, Amount: 300},
+		})
+		require.ErrorIs(t, err, apperrors.ErrInvalidTenders)
+	})
+
+	t.Run("tenders replace payment method and installments", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		uc := usecase.NewUseCase(mocks.NewMockOrderRepository(ctrl), nil, mocks.NewMockPaymentClient(ctrl), nil, nil)
+
+		_, err := uc.PayOrder(ctx, orderUUID, order_v1.PaymentMethodPAYMENTMETHODCARD, 0, tenders)
+		require.ErrorIs(t, err, apperrors.ErrInvalidTenders)
+
+		_, err = uc.PayOrder(ctx, orderUUID, "", 6, tenders)
+		require.ErrorIs(t, err, apperrors.ErrInvalidTenders)
+
+		_, err = uc.PayOrder(ctx, orderUUID, "", 0, nil)
+		require.ErrorIs(t, err, apperrors.ErrNoPaymentMethod)
+	})
+}
@@ -0,0 +1,395 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/linemk/rocket-shop/order/internal/entyties/apperrors"
+	"github.com/linemk/rocket-shop/order/internal/entyties/events"
+	"github.com/linemk/rocket-shop/order/internal/entyties/models"
+	"github.com/linemk/rocket-shop/order/internal/mocks"
+	"github.com/linemk/rocket-shop/order/internal/usecase"
+	order_v1 "github.com/linemk/rocket-shop/shared/pkg/openapi/order/v1"
+)
+
+func TestCompletePayment(t *testing.T) {
+	ctx := context.Background()
+	orderUUID := uuid.New().String()
+	transactionUUID := uuid.New().String()
+
+	event := &events.PaymentCompletedEvent{
+		EventUUID:       uuid.New().String(),
+		TransactionUUID: transactionUUID,
+		OrderUUID:       orderUUID,
+		UserUUID:        "user-123",
+	}
+
+	tests := []struct {
+		name                 string
+		orderRepository      func() *mocks.MockOrderRepository
+		orderProducerService func() *mocks.MockOrderProducerService
+		wantErr              error
+	}{
+		{
+			name: "order becomes paid",
+			orderRepository: func() *mocks.MockOrderRepository {
+				mockRepo := mocks.NewMockOrderRepository(gomock.NewController(t))
+				mockRepo.EXPECT().Get(ctx, orderUUID).Return(models.Order{
+					UUID:          orderUUID,
+					UserID:        "user-123",
+					Status:        order_v1.OrderStatusPAYMENTPROCESSING,
+					TransactionID: transactionUUID,
+				}, nil)
+				mockRepo.EXPECT().Update(ctx, orderUUID, gomock.Any()).DoAndReturn(
+					func(_ context.Context, _ string, info models.OrderUpdateInfo) error {
+						require.Equal(t, order_v1.OrderStatusPAID, *info.Status)
+						require.Equal(t, transactionUUID, *info.TransactionID)
+						return nil
+					})
+
+				return mockRepo
+			},
+			orderProducerService: func() *mocks.MockOrderProducerService {
+				mockService := mocks.NewMockOrderProducerService(gomock.NewController(t))
+				mockService.EXPECT().SendOrderPaid(ctx, gomock.Any()).Return(nil)
+
+				return mockService
+			},
+		},
+		{
+			// UUID транзакции выбирается до вызова PaymentService, поэтому пустой transaction_id
+			// бывает только до создания плана рассрочки и не совпадает ни с одной транзакцией
+			name: "order without transaction id skips event",
+			orderRepository: func() *mocks.MockOrderRepository {
+				mockRepo := mocks.NewMockOrderRepository(gomock.NewController(t))
+				mockRepo.EXPECT().Get(ctx, orderUUID).Return(models.Order{
+					UUID:   orderUUID,
+					UserID: "user-123",
+					Status: order_v1.OrderStatusPAYMENTPROCESSING,
+				}, nil)
+
+				return mockRepo
+			},
+			orderProducerService: func() *mocks.MockOrderProducerService {
+				return mocks.NewMockOrderProducerService(gomock.NewController(t))
+			},
+		},
+		{
+			name: "duplicate event for paid order is skipped",
+			orderRepository: func() *mocks.MockOrderRepository {
+				mockRepo := mocks.NewMockOrderRepository(gomock.NewController(t))
+				mockRepo.EXPECT().Get(ctx, orderUUID).Return(models.Order{
+					UUID:          orderUUID,
+					Status:        order_v1.OrderStatusPAID,
+					TransactionID: transactionUUID,
+				}, nil)
+
+				return mockRepo
+			},
+			orderProducerService: func() *mocks.MockOrderProducerService {
+				return mocks.NewMockOrderProducerService(gomock.NewController(t))
+			},
+		},
+		{
+			name: "event for another transaction is skipped",
+			orderRepository: func() *mocks.MockOrderRepository {
+				mockRepo := mocks.NewMockOrderRepository(gomock.NewController(t))
+				mockRepo.EXPECT().Get(ctx, orderUUID).Return(models.Order{
+					UUID:          orderUUID,
+					Status:        order_v1.OrderStatusPAYMENTPROCESSING,
+					TransactionID: uuid.New().String(),
+				}, nil)
+
+				return mockRepo
+			},
+			orderProducerService: func() *mocks.MockOrderProducerService {
+				return mocks.NewMockOrderProducerService(gomock.NewController(t))
+			},
+		},
+		{
+			name: "error order not found",
+			orderRepository: func() *mocks.MockOrderRepository {
+				mockRepo := mocks.NewMockOrderRepository(gomock.NewController(t))
+				mockRepo.EXPECT().Get(ctx, orderUUID).Return(models.Order{}, apperrors.ErrOrderNotFound)
+
+				return mockRepo
+			},
+			orderProducerService: func() *mocks.MockOrderProducerService {
+				return mocks.NewMockOrderProducerService(gomock.NewController(t))
+			},
+			wantErr: apperrors.ErrOrderNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := usecase.NewUseCase(tt.orderRepository(), nil, nil, tt.orderProducerService(), nil)
+
+			err := uc.CompletePayment(ctx, event)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestFailPayment(t *testing.T) {
+	ctx := context.Background()
+	orderUUID := uuid.New().String()
+	transactionUUID := uuid.New().String()
+
+	event := &events.PaymentFailedEvent{
+		EventUUID:       uuid.New().String(),
+		TransactionUUID: transactionUUID,
+		OrderUUID:       orderUUID,
+		Status:          "FAILED",
+		Reason:          "declined",
+	}
+
+	t.Run("order returns to pending payment", func(t *testing.T) {
+		mockRepo := mocks.NewMockOrderRepository(gomock.NewController(t))
+		mockRepo.EXPECT().Get(ctx, orderUUID).Return(models.Order{
+			UUID:          orderUUID,
+			Status:        order_v1.OrderStatusPAYMENTPROCESSING,
+			TransactionID: transactionUUID,
+		}, nil)
+		mockRepo.EXPECT().Update(ctx, orderUUID, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ string, info models.OrderUpdateInfo) error {
+				require.Equal(t, order_v1.OrderStatusPENDINGPAYMENT, *info.Status)
+				require.Empty(t, *info.TransactionID)
+				return nil
+			})
+
+		uc := usecase.NewUseCase(mockRepo, nil, nil, nil, nil)
+
+		require.NoError(t, uc.FailPayment(ctx, event))
+	})
+
+	t.Run("event for cancelled order is skipped", func(t *testing.T) {
+		mockRepo := mocks.NewMockOrderRepository(gomock.NewController(t))
+		mockRepo.EXPECT().Get(ctx, orderUUID).Return(models.Order{
+			UUID:   orderUUID,
+			Status: order_v1.OrderStatusCANCELLED,
+		}, nil)
+
+		uc := usecase.NewUseCase(mockRepo, nil, nil, nil, nil)
+
+		require.NoError(t, uc.FailPayment(ctx, event))
+	})
+}
+
+func TestApplyInstallmentPayment(t *testing.T) {
+	ctx := context.Background()
+	orderUUID := uuid.New().String()
+	planUUID := uuid.New().String()
+
+	event := &events.InstallmentPaidEvent{
+		EventUUID:         uuid.New().String(),
+		PlanUUID:          planUUID,
+		TransactionUUID:   uuid.New().String(),
+		OrderUUID:         orderUUID,
+		InstallmentNumber: 1,
+		InstallmentsTotal: 3,
+		Amount:            334,
+		PaidAmount:        334,
+		RemainingAmount:   666,
+	}
+
+	t.Run("first installment marks order partially paid", func(t *testing.T) {
+		mockRepo := mocks.NewMockOrderRepository(gomock.NewController(t))
+		mockRepo.EXPECT().Get(ctx, orderUUID).Return(models.Order{
+			UUID:          orderUUID,
+			Status:        order_v1.OrderStatusPAYMENTPROCESSING,
+			TransactionID: planUUID,
+			Installments:  3,
+		}, nil)
+		mockRepo.EXPECT().Update(ctx, orderUUID, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ string, info models.OrderUpdateInfo) error {
+				require.Equal(t, order_v1.OrderStatusPARTIALLYPAID, *info.Status)
+				require.Equal(t, planUUID, *info.TransactionID)
+				return nil
+			})
+
+		uc := usecase.NewUseCase(mockRepo, nil, nil, nil, nil)
+
+		require.NoError(t, uc.ApplyInstallmentPayment(ctx, event))
+	})
+
+	t.Run("completed plan marks order paid", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		mockRepo := mocks.NewMockOrderRepository(ctrl)
+		mockRepo.EXPECT().Get(ctx, orderUUID).Return(models.Order{
+			UUID:          orderUUID,
+			Status:        order_v1.OrderStatusPARTIALLYPAID,
+			TransactionID: planUUID,
+			Installments:  3,
+		}, nil)
+		mockRepo.EXPECT().Update(ctx, orderUUID, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ string, info models.OrderUpdateInfo) error {
+				require.Equal(t, order_v1.OrderStatusPAID, *info.Status)
+				return nil
+			})
+
+		mockProducer := mocks.NewMockOrderProducerService(ctrl)
+		mockProducer.EXPECT().SendOrderPaid(ctx, gomock.Any()).Return(nil)
+
+		uc := usecase.NewUseCase(mockRepo, nil, nil, mockProducer, nil)
+
+		completed := *event
+		completed.InstallmentNumber = 3
+		completed.RemainingAmount = 0
+		completed.PlanCompleted = true
+
+		require.NoError(t, uc.ApplyInstallmentPayment(ctx, &completed))
+	})
+
+	t.Run("event of another plan is skipped", func(t *testing.T) {
+		mockRepo := mocks.NewMockOrderRepository(gomock.NewController(t))
+		mockRepo.EXPECT().Get(ctx, orderUUID).Return(models.Order{
+			UUID:          orderUUID,
+			Status:        order_v1.OrderStatusPAYMENTPROCESSING,
+			TransactionID: uuid.New().String(),
+		}, nil)
+
+		uc := usecase.NewUseCase(mockRepo, nil, nil, nil, nil)
+
+		require.NoError(t, uc.ApplyInstallmentPayment(ctx, event))
+	})
+}
+
+func TestAwaitApproval(t *testing.T) {
+	ctx := context.Background()
+	orderUUID := uuid.New().String()
+	transactionUUID := uuid.New().String()
+
+	event := &events.ApprovalRequestedEvent{
+		EventUUID:       uuid.New().String(),
+		ApprovalUUID:    uuid.New().String(),
+		TransactionUUID: transactionUUID,
+		OrderUUID:       orderUUID,
+		Quorum:          2,
+	}
+
+	t.Run("order awaits approval", func(t *testing.T) {
+		mockRepo := mocks.NewMockOrderRepository(gomock.NewController(t))
+		mockRepo.EXPECT().Get(ctx, orderUUID).Return(models.Order{
+			UUID:          orderUUID,
+			Status:        order_v1.OrderStatusPAYMENTPROCESSING,
+			TransactionID: transactionUUID,
+		}, nil)
+		mockRepo.EXPECT().Update(ctx, orderUUID, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ string, info models.OrderUpdateInfo) error {
+				require.Equal(t, order_v1.OrderStatusAWAITINGAPPROVAL, *info.Status)
+				require.Equal(t, transactionUUID, *info.TransactionID)
+				return nil
+			})
+
+		uc := usecase.NewUseCase(mockRepo, nil, nil, nil, nil)
+
+		require.NoError(t, uc.AwaitApproval(ctx, event))
+	})
+
+	t.Run("event of another transaction is skipped", func(t *testing.T) {
+		mockRepo := mocks.NewMockOrderRepository(gomock.NewController(t))
+		mockRepo.EXPECT().Get(ctx, orderUUID).Return(models.Order{
+			UUID:          orderUUID,
+			Status:        order_v1.OrderStatusPAYMENTPROCESSING,
+			TransactionID: uuid.New().String(),
+		}, nil)
+
+		uc := usecase.NewUseCase(mockRepo, nil, nil, nil, nil)
+
+		require.NoError(t, uc.AwaitApproval(ctx, event))
+	})
+
+	t.Run("split tender approval keeps order on split group", func(t *testing.T) {
+		splitUUID := uuid.New().String()
+
+		mockRepo := mocks.NewMockOrderRepository(gomock.NewController(t))
+		mockRepo.EXPECT().Get(ctx, orderUUID).Return(models.Order{
+			UUID:          orderUUID,
+			Status:        order_v1.OrderStatusPAYMENTPROCESSING,
+			TransactionID: splitUUID,
+		}, nil)
+		mockRepo.EXPECT().Update(ctx, orderUUID, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ string, info models.OrderUpdateInfo) error {
+				require.Equal(t, order_v1.OrderStatusAWAITINGAPPROVAL, *info.Status)
+				require.Equal(t, splitUUID, *info.TransactionID)
+				return nil
+			})
+
+		uc := usecase.NewUseCase(mockRepo, nil, nil, nil, nil)
+
+		splitEvent := *event
+		splitEvent.SplitUUID = splitUUID
+		require.NoError(t, uc.AwaitApproval(ctx, &splitEvent))
+	})
+
+	t.Run("split payment completes order", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		splitUUID := uuid.New().String()
+
+		mockRepo := mocks.NewMockOrderRepository(ctrl)
+		mockRepo.EXPECT().Get(ctx, orderUUID).Return(models.Order{
+			UUID:          orderUUID,
+			Status:        order_v1.OrderStatusPAYMENTPROCESSING,
+			TransactionID: splitUUID,
+			Tenders: []models.Tender{
+				{PaymentMethod: order_v1.PaymentMethodPAYMENTMETHODSBP, Amount: 600},
+				{PaymentMethod: order_v1.PaymentMethodPAYMENTMETHODINVESTORMONEY, Amount: 400},
+			},
+		}, nil)
+		mockRepo.EXPECT().Update(ctx, orderUUID, gomock.Any()).Return(nil)
+
+		mockProducer := mocks.NewMockOrderProducerService(ctrl)
+		mockProducer.EXPECT().SendOrderPaid(ctx, gomock.Any()).DoAndReturn(
+			func(_ context.Context, event *events.OrderPaidEvent) error {
+				require.Equal(t, splitUUID, event.TransactionUUID)
+				require.Equal(t, "PAYMENT_METHOD_SBP,PAYMENT_METHOD_INVESTOR_MONEY", event.PaymentMethod)
+				return nil
+			})
+
+		uc := usecase.NewUseCase(mockRepo, nil, nil, mockProducer, nil)
+
+		require.NoError(t, uc.CompletePayment(ctx, &events.PaymentCompletedEvent{
+			EventUUID:       uuid.New().String(),
+			TransactionUUID: splitUUID,
+			OrderUUID:       orderUUID,
+		}))
+	})
+
+	t.Run("approved payment completes order", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		mockRepo := mocks.NewMockOrderRepository(ctrl)
+		mockRepo.EXPECT().Get(ctx, orderUUID).Return(models.Order{
+			UUID:          orderUUID,
+			Status:        order_v1.OrderStatusAWAITINGAPPROVAL,
+			TransactionID: transactionUUID,
+		}, nil)
+		mockRepo.EXPECT().Update(ctx, orderUUID, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ string, info models.OrderUpdateInfo) error {
+				require.Equal(t, order_v1.OrderStatusPAID, *info.Status)
+				return nil
+			})
+
+		mockProducer := mocks.NewMockOrderProducerService(ctrl)
+		mockProducer.EXPECT().SendOrderPaid(ctx, gomock.Any()).Return(nil)
+
+		uc := usecase.NewUseCase(mockRepo, nil, nil, mockProducer, nil)
+
+		require.NoError(t, uc.CompletePayment(ctx, &events.PaymentCompletedEvent{
+			EventUUID:       uuid.New().String(),
+			TransactionUUID: transactionUUID,
+			OrderUUID:       orderUUID,
+		}))
+	})
+}
@@ -0,0 +1,338 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/linemk/rocket-shop/order/internal/entyties/models"
+	"github.com/linemk/rocket-shop/order/internal/mocks"
+	"github.com/linemk/rocket-shop/order/internal/usecase"
+	order_v1 "github.com/linemk/rocket-shop/shared/pkg/openapi/order/v1"
+)
+
+func TestReconcile(t *testing.T) {
+	ctx := context.Background()
+	since := time.Now().Add(-24 * time.Hour)
+	settledAt := time.Now().Add(-time.Hour)
+
+	orderUUID := uuid.New().String()
+	transactionUUID := uuid.New().String()
+
+	transaction := func(status string, amount float64) models.PaymentTransaction {
+		return models.PaymentTransaction{
+			UUID:      transactionUUID,
+			Status:    status,
+			Amount:    amount,
+			UpdatedAt: settledAt,
+		}
+	}
+
+	splitUUID := uuid.New().String()
+	tender := func(status string, amount float64) models.PaymentTransaction {
+		return models.PaymentTransaction{
+			UUID:      uuid.New().String(),
+			Status:    status,
+			Amount:    amount,
+			SplitUUID: splitUUID,
+			UpdatedAt: settledAt,
+		}
+	}
+	tenders := []models.Tender{
+		{PaymentMethod: order_v1.PaymentMethodPAYMENTMETHODCARD, Amount: 60},
+		{PaymentMethod: order_v1.PaymentMethodPAYMENTMETHODSBP, Amount: 40},
+	}
+
+	planUUID := uuid.New().String()
+	installment := func(status string, amount float64) models.PaymentTransaction {
+		return models.PaymentTransaction{
+			UUID:                uuid.New().String(),
+			Status:              status,
+			Amount:              amount,
+			InstallmentPlanUUID: planUUID,
+			UpdatedAt:           settledAt,
+		}
+	}
+
+	tests := []struct {
+		name         string
+		order        models.Order
+		transactions []models.PaymentTransaction
+		want         []models.DiscrepancyType
+	}{
+		{
+			name:         "paid order matches completed transaction",
+			order:        models.Order{UUID: orderUUID, Status: order_v1.OrderStatusPAID, TransactionID: transactionUUID, TotalPrice: 100},
+			transactions: []models.PaymentTransaction{transaction("COMPLETED", 100)},
+		},
+		{
+			name:  "paid order without transaction",
+			order: models.Order{UUID: orderUUID, Status: order_v1.OrderStatusPAID, TransactionID: transactionUUID, TotalPrice: 100},
+			want:  []models.DiscrepancyType{models.DiscrepancyPaidWithoutTransaction},
+		},
+		{
+			name:         "paid order with failed transaction",
+			order:        models.Order{UUID: orderUUID, Status: order_v1.OrderStatusCOMPLETED, TransactionID: transactionUUID, TotalPrice: 100},
+			transactions: []models.PaymentTransaction{transaction("FAILED", 100)},
+			want:         []models.DiscrepancyType{models.DiscrepancyPaidTransactionNotCompleted},
+		},
+		{
+			name:         "paid order with different amount",
+			order:        models.Order{UUID: orderUUID, Status: order_v1.OrderStatusPAID, TransactionID: transactionUUID, TotalPrice: 100},
+			transactions: []models.PaymentTransaction{transaction("COMPLETED", 90)},
+			want:         []models.DiscrepancyType{models.DiscrepancyAmountMismatch},
+		},
+		{
+			name:         "completed payment on cancelled order",
+			order:        models.Order{UUID: orderUUID, Status: order_v1.OrderStatusCANCELLED, TotalPrice: 100},
+			transactions: []models.PaymentTransaction{transaction("COMPLETED", 100)},
+			want:         []models.DiscrepancyType{models.DiscrepancyCompletedPaymentOnCancelledOrder},
+		},
+		{
+			name:         "completed payment not applied to order",
+			order:        models.Order{UUID: orderUUID, Status: order_v1.OrderStatusPENDINGPAYMENT, TotalPrice: 100},
+			transactions: []models.PaymentTransaction{transaction("COMPLETED", 100)},
+			want:         []models.DiscrepancyType{models.DiscrepancyCompletedPaymentNotApplied},
+		},
+		{
+			name:  "recent completed payment is still in flight",
+			order: models.Order{UUID: orderUUID, Status: order_v1.OrderStatusPAYMENTPROCESSING, TotalPrice: 100},
+			transactions: []models.PaymentTransaction{{
+				UUID:      transactionUUID,
+				Status:    "COMPLETED",
+				Amount:    100,
+				UpdatedAt: time.Now(),
+			}},
+		},
+		{
+			name:         "failed payment not applied to order",
+			order:        models.Order{UUID: orderUUID, Status: order_v1.OrderStatusPAYMENTPROCESSING, TransactionID: transactionUUID, TotalPrice: 100},
+			transactions: []models.PaymentTransaction{transaction("FAILED", 100)},
+			want:         []models.DiscrepancyType{models.DiscrepancyFailedPaymentNotApplied},
+		},
+		{
+			name:  "order charged twice",
+			order: models.Order{UUID: orderUUID, Status: order_v1.OrderStatusPAID, TransactionID: transactionUUID, TotalPrice: 100},
+			transactions: []models.PaymentTransaction{
+				transaction("COMPLETED", 100),
+				{UUID: uuid.New().String(), Status: "COMPLETED", Amount: 100, UpdatedAt: settledAt},
+			},
+			want: []models.DiscrepancyType{models.DiscrepancyMultipleCompletedPayments},
+		},
+		{
+			name:         "paid split order matches completed tenders",
+			order:        models.Order{UUID: orderUUID, Status: order_v1.OrderStatusPAID, TransactionID: splitUUID, Tenders: tenders, TotalPrice: 100},
+			transactions: []models.PaymentTransaction{tender("COMPLETED", 60), tender("COMPLETED", 40)},
+		},
+		{
+			name:         "paid split order with refunded tender",
+			order:        models.Order{UUID: orderUUID, Status: order_v1.OrderStatusPAID, TransactionID: splitUUID, Tenders: tenders, TotalPrice: 100},
+			transactions: []models.PaymentTransaction{tender("COMPLETED", 60), tender("REFUNDED", 40)},
+			want:         []models.DiscrepancyType{models.DiscrepancyPaidTransactionNotCompleted},
+		},
+		{
+			name:         "paid split order with different amount",
+			order:        models.Order{UUID: orderUUID, Status: order_v1.OrderStatusPAID, TransactionID: splitUUID, Tenders: tenders, TotalPrice: 100},
+			transactions: []models.PaymentTransaction{tender("COMPLETED", 60), tender("COMPLETED", 30)},
+			want:         []models.DiscrepancyType{models.DiscrepancyAmountMismatch},
+		},
+		{
+			name:         "split completion not applied to order",
+			order:        models.Order{UUID: orderUUID, Status: order_v1.OrderStatusPAYMENTPROCESSING, TransactionID: splitUUID, Tenders: tenders, TotalPrice: 100},
+			transactions: []models.PaymentTransaction{tender("COMPLETED", 60), tender("COMPLETED", 40)},
+			want:         []models.DiscrepancyType{models.DiscrepancyCompletedPaymentNotApplied},
+		},
+		{
+			name:         "split with pending tender is still in flight",
+			order:        models.Order{UUID: orderUUID, Status: order_v1.OrderStatusPAYMENTPROCESSING, TransactionID: splitUUID, Tenders: tenders, TotalPrice: 100},
+			transactions: []models.PaymentTransaction{tender("COMPLETED", 60), tender("PENDING", 40)},
+		},
+		{
+			name:         "split failure not applied to order",
+			order:        models.Order{UUID: orderUUID, Status: order_v1.OrderStatusPAYMENTPROCESSING, TransactionID: splitUUID, Tenders: tenders, TotalPrice: 100},
+			transactions: []models.PaymentTransaction{tender("REFUNDED", 60), tender("FAILED", 40)},
+			want:         []models.DiscrepancyType{models.DiscrepancyFailedPaymentNotApplied},
+		},
+		{
+			name:         "fully paid installment order",
+			order:        models.Order{UUID: orderUUID, Status: order_v1.OrderStatusPAID, TransactionID: planUUID, Installments: 2, TotalPrice: 100},
+			transactions: []models.PaymentTransaction{installment("FAILED", 52), installment("COMPLETED", 52), installment("COMPLETED", 52)},
+		},
+		{
+			name:         "paid installment order with unpaid schedule",
+			order:        models.Order{UUID: orderUUID, Status: order_v1.OrderStatusPAID, TransactionID: planUUID, Installments: 2, TotalPrice: 100},
+			transactions: []models.PaymentTransaction{installment("COMPLETED", 52)},
+			want:         []models.DiscrepancyType{models.DiscrepancyPaidTransactionNotCompleted},
+		},
+		{
+			name:         "partially paid installment order",
+			order:        models.Order{UUID: orderUUID, Status: order_v1.OrderStatusPARTIALLYPAID, TransactionID: planUUID, Installments: 2, TotalPrice: 100},
+			transactions: []models.PaymentTransaction{installment("COMPLETED", 52), installment("FAILED", 52)},
+		},
+		{
+			name:         "partially paid order without paid installments",
+			order:        models.Order{UUID: orderUUID, Status: order_v1.OrderStatusPARTIALLYPAID, TransactionID: planUUID, Installments: 2, TotalPrice: 100},
+			transactions: []models.PaymentTransaction{installment("FAILED", 52)},
+			want:         []models.DiscrepancyType{models.DiscrepancyPaidTransactionNotCompleted},
+		},
+		{
+			name:         "installment plan completion not applied to order",
+			order:        models.Order{UUID: orderUUID, Status: order_v1.OrderStatusPARTIALLYPAID, TransactionID: planUUID, Installments: 2, TotalPrice: 100},
+			transactions: []models.PaymentTransaction{installment("COMPLETED", 52), installment("COMPLETED", 52)},
+			want:         []models.DiscrepancyType{models.DiscrepancyCompletedPaymentNotApplied},
+		},
+		{
+			name:         "first installment not applied to order",
+			order:        models.Order{UUID: orderUUID, Status: order_v1.OrderStatusPAYMENTPROCESSING, TransactionID: planUUID, Installments: 2, TotalPrice: 100},
+			transactions: []models.PaymentTransaction{installment("COMPLETED", 52)},
+			want:         []models.DiscrepancyType{models.DiscrepancyCompletedPaymentNotApplied},
+		},
+		{
+			name:         "first installment failure not applied to order",
+			order:        models.Order{UUID: orderUUID, Status: order_v1.OrderStatusPAYMENTPROCESSING, TransactionID: planUUID, Installments: 2, TotalPrice: 100},
+			transactions: []models.PaymentTransaction{installment("FAILED", 52)},
+			want:         []models.DiscrepancyType{models.DiscrepancyFailedPaymentNotApplied},
+		},
+		{
+			name:  "installment order charged in full as well",
+			order: models.Order{UUID: orderUUID, Status: order_v1.OrderStatusPARTIALLYPAID, TransactionID: planUUID, Installments: 2, TotalPrice: 100},
+			transactions: []models.PaymentTransaction{
+				installment("COMPLETED", 52),
+				transaction("COMPLETED", 100),
+			},
+			want: []models.DiscrepancyType{models.DiscrepancyMultipleCompletedPayments},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			orderRepository := mocks.NewMockOrderRepository(ctrl)
+			orderRepository.EXPECT().ListCreatedSince(ctx, since).Return([]models.Order{tt.order}, nil)
+
+			paymentClient := mocks.NewMockPaymentClient(ctrl)
+			paymentClient.EXPECT().ListTransactions(ctx, orderUUID).Return(tt.transactions, nil)
+
+			uc := usecase.NewUseCase(orderRepository, nil, paymentClient, nil, nil)
+
+			report, err := uc.Reconcile(ctx, usecase.ReconcileParams{Since: since, GracePeriod: 10 * time.Minute})
+			require.NoError(t, err)
+			require.Equal(t, 1, report.OrdersChecked)
+
+			got := make([]models.DiscrepancyType, 0, len(report.Discrepancies))
+			for _, discrepancy := range report.Discrepancies {
+				require.Equal(t, orderUUID, discrepancy.OrderUUID)
+				require.False(t, discrepancy.Corrected)
+				got = append(got, discrepancy.Type)
+			}
+			require.ElementsMatch(t, tt.want, got)
+		})
+	}
+}
+
+func TestReconcileFix(t *testing.T) {
+	ctx := context.Background()
+	since := time.Now().Add(-24 * time.Hour)
+
+	order := models.Order{
+		UUID:          uuid.New().String(),
+		UserID:        "user-123",
+		Status:        order_v1.OrderStatusPAYMENTPROCESSING,
+		TransactionID: uuid.New().String(),
+		TotalPrice:    100,
+	}
+
+	t.Run("missed payment completion marks order as paid", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		paid := order
+		paid.Status = order_v1.OrderStatusPAID
+
+		orderRepository := mocks.NewMockOrderRepository(ctrl)
+		orderRepository.EXPECT().ListCreatedSince(ctx, since).Return([]models.Order{order}, nil)
+		gomock.InOrder(
+			orderRepository.EXPECT().Get(ctx, order.UUID).Return(order, nil),
+			orderRepository.EXPECT().Update(ctx, order.UUID, gomock.Any()).Return(nil),
+			orderRepository.EXPECT().Get(ctx, order.UUID).Return(paid, nil),
+		)
+
+		paymentClient := mocks.NewMockPaymentClient(ctrl)
+		paymentClient.EXPECT().ListTransactions(ctx, order.UUID).Return([]models.PaymentTransaction{{
+			UUID:      order.TransactionID,
+			Status:    "COMPLETED",
+			Amount:    100,
+			UpdatedAt: time.Now().Add(-time.Hour),
+		}}, nil)
+
+		orderProducerService := mocks.NewMockOrderProducerService(ctrl)
+		orderProducerService.EXPECT().SendOrderPaid(ctx, gomock.Any()).Return(nil)
+
+		uc := usecase.NewUseCase(orderRepository, nil, paymentClient, orderProducerService, nil)
+
+		report, err := uc.Reconcile(ctx, usecase.ReconcileParams{Since: since, Fix: true})
+		require.NoError(t, err)
+		require.Len(t, report.Discrepancies, 1)
+		require.True(t, report.Discrepancies[0].Corrected)
+	})
+
+	t.Run("payment service errors are reported per order", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		orderRepository := mocks.NewMockOrderRepository(ctrl)
+		orderRepository.EXPECT().ListCreatedSince(ctx, since).Return([]models.Order{order}, nil)
+
+		paymentClient := mocks.NewMockPaymentClient(ctrl)
+		paymentClient.EXPECT().ListTransactions(ctx, order.UUID).Return(nil, errors.New("payment service unavailable"))
+
+		uc := usecase.NewUseCase(orderRepository, nil, paymentClient, nil, nil)
+
+		report, err := uc.Reconcile(ctx, usecase.ReconcileParams{Since: since, Fix: true})
+		require.NoError(t, err)
+		require.Zero(t, report.OrdersChecked)
+		require.Len(t, report.Errors, 1)
+	})
+
+	t.Run("missed installment payment marks order as partially paid", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		planOrder := order
+		planOrder.Installments = 3
+
+		partiallyPaid := planOrder
+		partiallyPaid.Status = order_v1.OrderStatusPARTIALLYPAID
+
+		orderRepository := mocks.NewMockOrderRepository(ctrl)
+		orderRepository.EXPECT().ListCreatedSince(ctx, since).Return([]models.Order{planOrder}, nil)
+		gomock.InOrder(
+			orderRepository.EXPECT().Get(ctx, order.UUID).Return(planOrder, nil),
+			orderRepository.EXPECT().Update(ctx, order.UUID, models.OrderUpdateInfo{
+				Status:        &[]order_v1.OrderStatus{order_v1.OrderStatusPARTIALLYPAID}[0],
+				TransactionID: &planOrder.TransactionID,
+			}).Return(nil),
+			orderRepository.EXPECT().Get(ctx, order.UUID).Return(partiallyPaid, nil),
+		)
+
+		paymentClient := mocks.NewMockPaymentClient(ctrl)
+		paymentClient.EXPECT().ListTransactions(ctx, order.UUID).Return([]models.PaymentTransaction{{
+			UUID:                uuid.New().String(),
+			Status:              "COMPLETED",
+			Amount:              35,
+			InstallmentPlanUUID: order.TransactionID,
+			UpdatedAt:           time.Now().Add(-time.Hour),
+		}}, nil)
+
+		uc := usecase.NewUseCase(orderRepository, nil, paymentClient, nil, nil)
+
+		report, err := uc.Reconcile(ctx, usecase.ReconcileParams{Since: since, Fix: true})
+		require.NoError(t, err)
+		require.Len(t, report.Discrepancies, 1)
+		require.Equal(t, order.TransactionID, report.Discrepancies[0].TransactionUUID)
+		require.True(t, report.Discrepancies[0].Corrected)
+	})
+}
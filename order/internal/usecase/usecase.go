@@ -7,6 +7,7 @@ import (
 
 	inventoryClient "github.com/linemk/rocket-shop/order/internal/client/grpc/inventory/v1"
 	paymentClient "github.com/linemk/rocket-shop/order/internal/client/grpc/payment/v1"
+	"github.com/linemk/rocket-shop/order/internal/entyties/events"
 	"github.com/linemk/rocket-shop/order/internal/entyties/models"
 	"github.com/linemk/rocket-shop/order/internal/metrics"
 	"github.com/linemk/rocket-shop/order/internal/repository"
@@ -17,8 +18,25 @@ import (
 type OrderUseCase interface {
 	CreateOrder(ctx context.Context, info OrderInfo) (string, error)
 	GetOrder(ctx context.Context, uuid string) (models.Order, error)
-	PayOrder(ctx context.Context, uuid string, paymentMethod order_v1.PaymentMethod) (string, error)
+	// PayOrder передает заказ на оплату и возвращает UUID транзакции.
+	// При installments > 0 заказ оплачивается в рассрочку по кредитной карте и возвращается UUID плана рассрочки,
+	// при переданных tenders - несколькими способами, и возвращается UUID группы транзакций частей
+	PayOrder(ctx context.Context, uuid string, paymentMethod order_v1.PaymentMethod, installments int, tenders []models.Tender) (string, error)
+	// GetInstallmentQuotes возвращает доступные варианты рассрочки для стоимости заказа
+	GetInstallmentQuotes(ctx context.Context, uuid string) ([]models.InstallmentQuote, error)
 	CancelOrder(ctx context.Context, uuid string) error
+	ListUserOrders(ctx context.Context, userID string) ([]models.Order, error)
+	// CompletePayment переводит заказ в PAID по событию PaymentCompleted
+	CompletePayment(ctx context.Context, event *events.PaymentCompletedEvent) error
+	// FailPayment возвращает заказ в PENDING_PAYMENT по событию PaymentFailed
+	FailPayment(ctx context.Context, event *events.PaymentFailedEvent) error
+	// AwaitApproval переводит заказ в AWAITING_APPROVAL по событию ApprovalRequested;
+	// итог согласования приходит событием PaymentCompleted или PaymentFailed
+	AwaitApproval(ctx context.Context, event *events.ApprovalRequestedEvent) error
+	// ApplyInstallmentPayment переводит заказ в PARTIALLY_PAID по событию InstallmentPaid, а после последнего платежа - в PAID
+	ApplyInstallmentPayment(ctx context.Context, event *events.InstallmentPaidEvent) error
+	// Reconcile сверяет заказы с транзакциями PaymentService и возвращает отчет о расхождениях
+	Reconcile(ctx context.Context, params ReconcileParams) (models.ReconciliationReport, error)
 }
 
 type OrderInfo struct {
@@ -0,0 +1,6 @@
+-- +goose Up
+-- число платежей рассрочки; 0 - заказ оплачивается целиком
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS installments INTEGER NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE orders DROP COLUMN IF EXISTS installments;
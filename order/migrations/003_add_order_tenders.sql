@@ -0,0 +1,6 @@
+-- +goose Up
+-- части раздельной оплаты: [{"payment_method": ..., "amount": ...}]; пусто - заказ оплачивается одним способом
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS tenders JSONB NOT NULL DEFAULT '[]'::jsonb;
+
+-- +goose Down
+ALTER TABLE orders DROP COLUMN IF EXISTS tenders;
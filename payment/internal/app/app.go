@@ -2,9 +2,13 @@ package app
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"net"
 
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/propagation"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
@@ -13,9 +17,14 @@ import (
 	"google.golang.org/grpc/reflection"
 
 	"github.com/linemk/rocket-shop/payment/internal/config"
+	"github.com/linemk/rocket-shop/platform/pkg/cache"
+	rediscache "github.com/linemk/rocket-shop/platform/pkg/cache/redis"
 	"github.com/linemk/rocket-shop/platform/pkg/closer"
 	"github.com/linemk/rocket-shop/platform/pkg/grpc/health"
 	"github.com/linemk/rocket-shop/platform/pkg/logger"
+	grpcmiddleware "github.com/linemk/rocket-shop/platform/pkg/middleware/grpc"
+	"github.com/linemk/rocket-shop/platform/pkg/migrator/pg"
+	prommetrics "github.com/linemk/rocket-shop/platform/pkg/prometheus"
 	"github.com/linemk/rocket-shop/platform/pkg/tracing"
 	payment_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/payment/v1"
 )
@@ -46,6 +55,42 @@ func (a *App) Run(ctx context.Context) error {
 		_ = logger.Sync()     //nolint:gosec // best-effort shutdown
 	}()
 
+	// Запускаем Kafka consumer событий UserErased в отдельной горутине
+	go func() {
+		if err := a.diContainer.UserErasedConsumerService(ctx).RunConsumers(ctx); err != nil {
+			logger.Error(ctx, fmt.Sprintf("UserErased consumer error: %v", err))
+		}
+	}()
+
+	// Запускаем фоновое списание платежей по графикам рассрочки
+	go func() {
+		if err := a.diContainer.InstallmentJob(ctx).Run(ctx); err != nil {
+			logger.Error(ctx, fmt.Sprintf("Installment job error: %v", err))
+		}
+	}()
+
+	// Запускаем фоновую отмену платежей, не согласованных до срока
+	go func() {
+		if err := a.diContainer.ApprovalJob(ctx).Run(ctx); err != nil {
+			logger.Error(ctx, fmt.Sprintf("Approval job error: %v", err))
+		}
+	}()
+
+	// Запускаем фоновую сверку транзакций с неизвестным исходом списания
+	go func() {
+		if err := a.diContainer.RecoveryJob(ctx).Run(ctx); err != nil {
+			logger.Error(ctx, fmt.Sprintf("Recovery job error: %v", err))
+		}
+	}()
+
+	// Запускаем metrics HTTP server в отдельной горутине
+	go func() {
+		metricsPort := fmt.Sprintf(":%d", config.AppConfig().Metrics.Port())
+		if err := prommetrics.StartMetricsServer(ctx, metricsPort, a.diContainer.PrometheusMetrics()); err != nil {
+			logger.Error(ctx, fmt.Sprintf("Metrics server error: %v", err))
+		}
+	}()
+
 	return a.runGRPCServer(ctx)
 }
 
@@ -56,6 +101,8 @@ func (a *App) initDeps(ctx context.Context) error {
 		a.initTracer,
 		a.initCloser,
 		a.initDI,
+		a.initMigrations,
+		a.initCache,
 		a.initListener,
 		a.initGRPCServer,
 	}
@@ -128,6 +175,79 @@ func (a *App) initDI(_ context.Context) error {
 	return nil
 }
 
+func (a *App) initMigrations(ctx context.Context) error {
+	// Создаем пул соединений с базой бухгалтерской книги
+	pool, err := pgxpool.New(ctx, config.AppConfig().Postgres.DSN())
+	if err != nil {
+		return fmt.Errorf("failed to create pool: %w", err)
+	}
+
+	// Проверяем соединение
+	if err := pool.Ping(ctx); err != nil {
+		return fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	logger.Info(ctx, "Successfully connected to PostgreSQL")
+
+	// Сохраняем pool в DI контейнер перед миграциями
+	a.diContainer.SetDBPool(pool)
+
+	// Получаем *sql.DB для миграций
+	sqlDB := stdlib.OpenDBFromPool(pool)
+
+	// Выполняем миграции
+	migrator := pg.NewMigrator(sqlDB, config.AppConfig().Postgres.MigrationsDir())
+	if err := migrator.Up(); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("failed to run migrations: %w", err)
+	}
+
+	logger.Info(ctx, "Migrations applied successfully")
+
+	// Закрываем sqlDB, так как мы будем использовать pool
+	if err := sqlDB.Close(); err != nil {
+		logger.Error(ctx, "Failed to close sqlDB")
+	}
+
+	// Регистрируем закрытие pool
+	closer.AddNamed("PostgreSQL pool", func(ctx context.Context) error {
+		pool.Close()
+		return nil
+	})
+
+	return nil
+}
+
+func (a *App) initCache(ctx context.Context) error {
+	redisConfig := config.AppConfig().Redis
+	cacheClient, err := rediscache.NewClient(cache.Config{
+		Addr:         redisConfig.Addr(),
+		Password:     redisConfig.Password(),
+		DB:           redisConfig.DB(),
+		DialTimeout:  redisConfig.DialTimeout(),
+		ReadTimeout:  redisConfig.ReadTimeout(),
+		WriteTimeout: redisConfig.WriteTimeout(),
+		PoolSize:     redisConfig.PoolSize(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create cache client: %w", err)
+	}
+
+	if err := cacheClient.Ping(ctx); err != nil {
+		return fmt.Errorf("failed to ping Redis: %w", err)
+	}
+
+	logger.Info(ctx, "Successfully connected to Redis")
+
+	// Сохраняем клиент в DI контейнер для истории платежей антифрод-проверки
+	a.diContainer.SetCacheClient(cacheClient)
+
+	closer.AddNamed("Redis cache", func(ctx context.Context) error {
+		return cacheClient.Close()
+	})
+
+	return nil
+}
+
 func (a *App) initListener(_ context.Context) error {
 	listener, err := net.Listen("tcp", config.AppConfig().PaymentGRPC.Address())
 	if err != nil {
@@ -144,17 +264,31 @@ func (a *App) initListener(_ context.Context) error {
 }
 
 func (a *App) initGRPCServer(ctx context.Context) error {
-	opts := []grpc.ServerOption{
-		grpc.Creds(insecure.NewCredentials()),
+	interceptors := []grpc.UnaryServerInterceptor{
+		prommetrics.NewGRPCMetrics(a.diContainer.PrometheusMetrics()).UnaryServerInterceptor(),
 	}
 
 	// Добавляем tracing interceptor если tracer инициализирован
 	if a.tracerProvider != nil {
-		opts = append(opts, grpc.UnaryInterceptor(tracing.UnaryServerInterceptor()))
+		interceptors = append(interceptors, tracing.UnaryServerInterceptor())
 		logger.Info(ctx, "✅ gRPC server tracing interceptor added")
 	}
 
-	a.grpcServer = grpc.NewServer(opts...)
+	// Согласующий, проверяющий и подтверждающий платеж покупатель определяются по сессии IAM.
+	// Остальные методы вызываются сервисами без сессии пользователя
+	interceptors = append(interceptors, grpcmiddleware.UnaryMethodsSessionInterceptor(
+		a.diContainer.SessionResolver(ctx),
+		payment_v1.PaymentService_DecideApproval_FullMethodName,
+		payment_v1.PaymentService_ListPendingApprovals_FullMethodName,
+		payment_v1.PaymentService_ReviewTransaction_FullMethodName,
+		payment_v1.PaymentService_ListReviewQueue_FullMethodName,
+		payment_v1.PaymentService_ConfirmTransaction_FullMethodName,
+	))
+
+	a.grpcServer = grpc.NewServer(
+		grpc.Creds(insecure.NewCredentials()),
+		grpc.ChainUnaryInterceptor(interceptors...),
+	)
 
 	closer.AddNamed("gRPC server", func(ctx context.Context) error {
 		a.grpcServer.GracefulStop()
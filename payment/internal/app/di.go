@@ -2,13 +2,40 @@ package app
 
 import (
 	"context"
+	"fmt"
+
+	"github.com/IBM/sarama"
+	"github.com/jackc/pgx/v5/pgxpool"
 
 	payment_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/payment/v1"
 
+	"github.com/linemk/rocket-shop/payment/internal/config"
 	v1 "github.com/linemk/rocket-shop/payment/internal/delivery/v1"
+	"github.com/linemk/rocket-shop/payment/internal/fraud"
+	paymentmetrics "github.com/linemk/rocket-shop/payment/internal/metrics"
+	"github.com/linemk/rocket-shop/payment/internal/provider"
+	"github.com/linemk/rocket-shop/payment/internal/provider/simulator"
 	"github.com/linemk/rocket-shop/payment/internal/repository"
+	approvalRepository "github.com/linemk/rocket-shop/payment/internal/repository/approval"
+	fraudRepository "github.com/linemk/rocket-shop/payment/internal/repository/fraud"
+	installmentRepository "github.com/linemk/rocket-shop/payment/internal/repository/installment"
+	ledgerRepository "github.com/linemk/rocket-shop/payment/internal/repository/ledger"
 	paymentRepository "github.com/linemk/rocket-shop/payment/internal/repository/payment"
+	"github.com/linemk/rocket-shop/payment/internal/service"
+	"github.com/linemk/rocket-shop/payment/internal/service/consumer/user_erased_consumer"
+	"github.com/linemk/rocket-shop/payment/internal/service/job/approval_job"
+	"github.com/linemk/rocket-shop/payment/internal/service/job/installment_job"
+	"github.com/linemk/rocket-shop/payment/internal/service/job/recovery_job"
+	"github.com/linemk/rocket-shop/payment/internal/service/producer/payment_producer"
 	"github.com/linemk/rocket-shop/payment/internal/usecase"
+	"github.com/linemk/rocket-shop/platform/pkg/cache"
+	"github.com/linemk/rocket-shop/platform/pkg/closer"
+	"github.com/linemk/rocket-shop/platform/pkg/kafka/consumer"
+	"github.com/linemk/rocket-shop/platform/pkg/kafka/producer"
+	"github.com/linemk/rocket-shop/platform/pkg/logger"
+	kafkaMiddleware "github.com/linemk/rocket-shop/platform/pkg/middleware/kafka"
+	prommetrics "github.com/linemk/rocket-shop/platform/pkg/prometheus"
+	"github.com/linemk/rocket-shop/shared/pkg/iamclient"
 )
 
 type diContainer struct {
@@ -16,13 +43,45 @@ type diContainer struct {
 
 	paymentUseCase usecase.PaymentUseCase
 
-	paymentRepository repository.PaymentRepository
+	paymentRepository     repository.PaymentRepository
+	ledgerRepository      repository.LedgerRepository
+	fraudRepository       repository.FraudRepository
+	installmentRepository repository.InstallmentRepository
+	approvalRepository    repository.ApprovalRepository
+
+	paymentProviders *provider.Registry
+	fraudScreener    fraud.Screener
+
+	userErasedConsumerService service.ConsumerService
+
+	paymentProducerService service.PaymentProducerService
+
+	installmentJob service.JobService
+	approvalJob    service.JobService
+	recoveryJob    service.JobService
+
+	dbPool      *pgxpool.Pool
+	cacheClient cache.Client
+
+	iamClient       *iamclient.Client
+	sessionResolver *iamclient.SessionResolver
+
+	prometheusMetrics *prommetrics.Metrics
+	paymentMetrics    *paymentmetrics.PaymentMetrics
 }
 
 func NewDiContainer() *diContainer {
 	return &diContainer{}
 }
 
+func (d *diContainer) SetDBPool(pool *pgxpool.Pool) {
+	d.dbPool = pool
+}
+
+func (d *diContainer) SetCacheClient(cacheClient cache.Client) {
+	d.cacheClient = cacheClient
+}
+
 func (d *diContainer) PaymentV1API(ctx context.Context) payment_v1.PaymentServiceServer {
 	if d.paymentV1API == nil {
 		d.paymentV1API = v1.NewAPI(d.PaymentUseCase(ctx))
@@ -33,16 +92,253 @@ func (d *diContainer) PaymentV1API(ctx context.Context) payment_v1.PaymentServic
 
 func (d *diContainer) PaymentUseCase(ctx context.Context) usecase.PaymentUseCase {
 	if d.paymentUseCase == nil {
-		d.paymentUseCase = usecase.NewUseCase(d.PaymentRepository(ctx))
+		d.paymentUseCase = usecase.NewUseCase(
+			d.PaymentRepository(ctx),
+			d.LedgerRepository(ctx),
+			d.InstallmentRepository(ctx),
+			d.ApprovalRepository(ctx),
+			d.PaymentProviders(ctx),
+			d.FraudScreener(ctx),
+			config.AppConfig().Provider,
+			config.AppConfig().Installment,
+			config.AppConfig().Approval,
+			d.PaymentProducerService(ctx),
+			d.PaymentMetrics(),
+		)
+
+		// Закрывается раньше продюсера, чтобы события о завершенных списаниях успели отправиться
+		uc := d.paymentUseCase
+		closer.AddNamed("Payment charges", func(ctx context.Context) error {
+			return uc.Drain(ctx)
+		})
 	}
 
 	return d.paymentUseCase
 }
 
-func (d *diContainer) PaymentRepository(ctx context.Context) repository.PaymentRepository {
+func (d *diContainer) PaymentProviders(_ context.Context) *provider.Registry {
+	if d.paymentProviders == nil {
+		// Пока реальных шлюзов нет, каждый способ оплаты обслуживает собственный экземпляр симулятора
+		d.paymentProviders = provider.NewRegistry(map[payment_v1.PaymentMethod]provider.PaymentProvider{
+			payment_v1.PaymentMethod_PAYMENT_METHOD_CARD:           simulator.NewProvider("simulator-card", config.AppConfig().Simulator),
+			payment_v1.PaymentMethod_PAYMENT_METHOD_SBP:            simulator.NewProvider("simulator-sbp", config.AppConfig().Simulator),
+			payment_v1.PaymentMethod_PAYMENT_METHOD_CREDIT_CARD:    simulator.NewProvider("simulator-credit-card", config.AppConfig().Simulator),
+			payment_v1.PaymentMethod_PAYMENT_METHOD_INVESTOR_MONEY: simulator.NewProvider("simulator-investor-money", config.AppConfig().Simulator),
+		})
+	}
+
+	return d.paymentProviders
+}
+
+func (d *diContainer) FraudScreener(ctx context.Context) fraud.Screener {
+	if d.fraudScreener == nil {
+		d.fraudScreener = fraud.NewScreener(d.FraudRepository(ctx), config.AppConfig().Fraud)
+	}
+
+	return d.fraudScreener
+}
+
+func (d *diContainer) PaymentRepository(_ context.Context) repository.PaymentRepository {
 	if d.paymentRepository == nil {
-		d.paymentRepository = paymentRepository.NewRepository()
+		d.paymentRepository = paymentRepository.NewRepository(d.dbPool)
 	}
 
 	return d.paymentRepository
 }
+
+func (d *diContainer) LedgerRepository(_ context.Context) repository.LedgerRepository {
+	if d.ledgerRepository == nil {
+		d.ledgerRepository = ledgerRepository.NewRepository(d.dbPool)
+	}
+
+	return d.ledgerRepository
+}
+
+func (d *diContainer) InstallmentRepository(_ context.Context) repository.InstallmentRepository {
+	if d.installmentRepository == nil {
+		d.installmentRepository = installmentRepository.NewRepository(d.dbPool)
+	}
+
+	return d.installmentRepository
+}
+
+func (d *diContainer) ApprovalRepository(_ context.Context) repository.ApprovalRepository {
+	if d.approvalRepository == nil {
+		d.approvalRepository = approvalRepository.NewRepository(d.dbPool)
+	}
+
+	return d.approvalRepository
+}
+
+func (d *diContainer) FraudRepository(_ context.Context) repository.FraudRepository {
+	if d.fraudRepository == nil {
+		d.fraudRepository = fraudRepository.NewRepository(d.cacheClient)
+	}
+
+	return d.fraudRepository
+}
+
+func (d *diContainer) UserErasedConsumerService(ctx context.Context) service.ConsumerService {
+	if d.userErasedConsumerService == nil {
+		saramaConfig := sarama.NewConfig()
+		saramaConfig.Version = sarama.V2_6_0_0
+		saramaConfig.Consumer.Group.Rebalance.Strategy = sarama.NewBalanceStrategyRoundRobin()
+		saramaConfig.Consumer.Offsets.Initial = sarama.OffsetOldest
+
+		consumerGroup, err := sarama.NewConsumerGroup(
+			config.AppConfig().Kafka.Brokers(),
+			config.AppConfig().Kafka.UserErasedConsumerGroupID(),
+			saramaConfig,
+		)
+		if err != nil {
+			panic(fmt.Sprintf("failed to create Kafka consumer group for UserErased: %s\n", err.Error()))
+		}
+
+		closer.AddNamed("Kafka consumer group for UserErased", func(ctx context.Context) error {
+			return consumerGroup.Close()
+		})
+
+		kafkaConsumer := consumer.NewConsumer(
+			consumerGroup,
+			[]string{config.AppConfig().Kafka.UserErasedTopic()},
+			logger.Logger(),
+			kafkaMiddleware.Logging(logger.Logger()),
+		)
+
+		handler := user_erased_consumer.NewHandler(d.PaymentUseCase(ctx), logger.Logger())
+
+		d.userErasedConsumerService = user_erased_consumer.NewConsumer(kafkaConsumer, handler, logger.Logger())
+	}
+
+	return d.userErasedConsumerService
+}
+
+func (d *diContainer) InstallmentJob(ctx context.Context) service.JobService {
+	if d.installmentJob == nil {
+		d.installmentJob = installment_job.NewJob(
+			d.PaymentUseCase(ctx),
+			config.AppConfig().Installment,
+			logger.Logger(),
+		)
+	}
+
+	return d.installmentJob
+}
+
+func (d *diContainer) ApprovalJob(ctx context.Context) service.JobService {
+	if d.approvalJob == nil {
+		d.approvalJob = approval_job.NewJob(
+			d.PaymentUseCase(ctx),
+			config.AppConfig().Approval,
+			logger.Logger(),
+		)
+	}
+
+	return d.approvalJob
+}
+
+func (d *diContainer) RecoveryJob(ctx context.Context) service.JobService {
+	if d.recoveryJob == nil {
+		d.recoveryJob = recovery_job.NewJob(
+			d.PaymentUseCase(ctx),
+			config.AppConfig().Provider,
+			logger.Logger(),
+		)
+	}
+
+	return d.recoveryJob
+}
+
+func (d *diContainer) IAMClient(ctx context.Context) *iamclient.Client {
+	if d.iamClient == nil {
+		client, err := iamclient.New(ctx, config.AppConfig().IAMGRPC.Address())
+		if err != nil {
+			panic(fmt.Sprintf("failed to create IAM client: %s\n", err.Error()))
+		}
+		closer.AddNamed("IAM gRPC client", func(ctx context.Context) error {
+			return client.Close()
+		})
+		d.iamClient = client
+	}
+
+	return d.iamClient
+}
+
+func (d *diContainer) SessionResolver(ctx context.Context) *iamclient.SessionResolver {
+	if d.sessionResolver == nil {
+		d.sessionResolver = d.IAMClient(ctx).SessionResolver(
+			config.AppConfig().IAMGRPC.SessionCacheTTL(),
+			config.AppConfig().IAMGRPC.SessionCacheMaxEntries(),
+		)
+	}
+
+	return d.sessionResolver
+}
+
+func (d *diContainer) PaymentProducerService(ctx context.Context) service.PaymentProducerService {
+	if d.paymentProducerService == nil {
+		saramaConfig := sarama.NewConfig()
+		saramaConfig.Version = sarama.V2_6_0_0
+		saramaConfig.Producer.Return.Successes = true
+		saramaConfig.Producer.RequiredAcks = sarama.WaitForAll
+		saramaConfig.Producer.Retry.Max = 5
+
+		syncProducer, err := sarama.NewSyncProducer(config.AppConfig().Kafka.Brokers(), saramaConfig)
+		if err != nil {
+			panic(fmt.Sprintf("failed to create Kafka sync producer: %s\n", err.Error()))
+		}
+
+		closer.AddNamed("Kafka sync producer", func(ctx context.Context) error {
+			return syncProducer.Close()
+		})
+
+		kafkaProducer := producer.NewProducer(
+			syncProducer,
+			config.AppConfig().Kafka.PaymentEventsTopic(),
+			logger.Logger(),
+		)
+
+		d.paymentProducerService = payment_producer.NewProducer(kafkaProducer, logger.Logger())
+	}
+
+	return d.paymentProducerService
+}
+
+func (d *diContainer) PrometheusMetrics() *prommetrics.Metrics {
+	if d.prometheusMetrics == nil {
+		d.prometheusMetrics = prommetrics.New()
+	}
+
+	return d.prometheusMetrics
+}
+
+func (d *diContainer) PaymentMetrics() *paymentmetrics.PaymentMetrics {
+	if d.paymentMetrics == nil {
+		pm := d.PrometheusMetrics()
+		d.paymentMetrics = &paymentmetrics.PaymentMetrics{
+			TransactionsTotal: pm.NewCounter(
+				"payment_transactions_total",
+				"Total number of finished payment transactions",
+				[]string{"payment_method", "status"},
+			),
+			AmountTotal: pm.NewCounter(
+				"payment_amount_total",
+				"Total amount charged by payment transactions",
+				[]string{"payment_method"},
+			),
+			RefundedAmountTotal: pm.NewCounter(
+				"payment_refunded_amount_total",
+				"Total amount refunded to customers",
+				[]string{"payment_method"},
+			),
+			ProviderDuration: pm.NewHistogram(
+				"payment_provider_duration_seconds",
+				"Payment provider response time in seconds",
+				[]string{"provider", "operation", "result"},
+				[]float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2, 5, 10, 30},
+			),
+		}
+	}
+
+	return d.paymentMetrics
+}
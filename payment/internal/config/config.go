@@ -13,6 +13,16 @@ var appConfig *config
 type config struct {
 	Logger      LoggerConfig
 	PaymentGRPC PaymentGRPCConfig
+	IAMGRPC     IAMGRPCConfig
+	Metrics     MetricsConfig
+	Kafka       KafkaConfig
+	Provider    ProviderConfig
+	Simulator   SimulatorConfig
+	Postgres    PostgresConfig
+	Redis       RedisConfig
+	Fraud       FraudConfig
+	Installment InstallmentConfig
+	Approval    ApprovalConfig
 }
 
 // Load загружает конфигурацию из переменных окружения
@@ -32,9 +42,69 @@ func Load(path ...string) error {
 		return err
 	}
 
+	iamGRPCCfg, err := env.NewIAMGRPCConfig()
+	if err != nil {
+		return err
+	}
+
+	metricsCfg, err := env.NewMetricsConfig()
+	if err != nil {
+		return err
+	}
+
+	kafkaCfg, err := env.NewKafkaConfig()
+	if err != nil {
+		return err
+	}
+
+	providerCfg, err := env.NewProviderConfig()
+	if err != nil {
+		return err
+	}
+
+	simulatorCfg, err := env.NewSimulatorConfig()
+	if err != nil {
+		return err
+	}
+
+	postgresCfg, err := env.NewPostgresConfig()
+	if err != nil {
+		return err
+	}
+
+	redisCfg, err := env.NewRedisConfig()
+	if err != nil {
+		return err
+	}
+
+	fraudCfg, err := env.NewFraudConfig()
+	if err != nil {
+		return err
+	}
+
+	installmentCfg, err := env.NewInstallmentConfig()
+	if err != nil {
+		return err
+	}
+
+	approvalCfg, err := env.NewApprovalConfig()
+	if err != nil {
+		return err
+	}
+
 	appConfig = &config{
 		Logger:      loggerCfg,
 		PaymentGRPC: paymentGRPCCfg,
+		IAMGRPC:     iamGRPCCfg,
+		Metrics:     metricsCfg,
+		Kafka:       kafkaCfg,
+		Provider:    providerCfg,
+		Simulator:   simulatorCfg,
+		Postgres:    postgresCfg,
+		Redis:       redisCfg,
+		Fraud:       fraudCfg,
+		Installment: installmentCfg,
+		Approval:    approvalCfg,
 	}
 
 	return nil
@@ -0,0 +1,81 @@
+package env
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	approvalApproversEnv      = "APPROVAL_APPROVERS"
+	approvalQuorumEnv         = "APPROVAL_QUORUM"
+	approvalTimeoutEnv        = "APPROVAL_TIMEOUT"
+	approvalExpiryIntervalEnv = "APPROVAL_EXPIRY_INTERVAL"
+
+	defaultApprovalTimeout        = 48 * time.Hour
+	defaultApprovalExpiryInterval = time.Minute
+)
+
+type approvalConfig struct {
+	approvers      []string
+	quorum         int
+	timeout        time.Duration
+	expiryInterval time.Duration
+}
+
+// NewApprovalConfig создает конфигурацию согласования платежей средствами инвестора из переменных окружения
+func NewApprovalConfig() (*approvalConfig, error) {
+	// Пустой список согласующих отключает согласование
+	approvers := listEnv(approvalApproversEnv)
+
+	quorum, err := limitEnv(approvalQuorumEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	// По умолчанию платеж должны одобрить все согласующие
+	if quorum == 0 {
+		quorum = len(approvers)
+	}
+
+	if quorum > len(approvers) {
+		return nil, fmt.Errorf("invalid %s: quorum %d exceeds number of approvers %d", approvalQuorumEnv, quorum, len(approvers))
+	}
+
+	timeout, err := durationEnv(approvalTimeoutEnv, defaultApprovalTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	if timeout <= 0 {
+		return nil, fmt.Errorf("invalid %s: timeout must be positive", approvalTimeoutEnv)
+	}
+
+	// 0 отключает фоновую отмену просроченных запросов
+	expiryInterval, err := durationEnv(approvalExpiryIntervalEnv, defaultApprovalExpiryInterval)
+	if err != nil {
+		return nil, err
+	}
+
+	return &approvalConfig{
+		approvers:      approvers,
+		quorum:         quorum,
+		timeout:        timeout,
+		expiryInterval: expiryInterval,
+	}, nil
+}
+
+func (c *approvalConfig) Approvers() []string {
+	return c.approvers
+}
+
+func (c *approvalConfig) Quorum() int {
+	return c.quorum
+}
+
+func (c *approvalConfig) Timeout() time.Duration {
+	return c.timeout
+}
+
+func (c *approvalConfig) ExpiryInterval() time.Duration {
+	return c.expiryInterval
+}
@@ -0,0 +1,187 @@
+package env
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	payment_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/payment/v1"
+)
+
+const (
+	fraudEnabledEnv                = "FRAUD_ENABLED"
+	fraudVelocityWindowEnv         = "FRAUD_VELOCITY_WINDOW"
+	fraudVelocityReviewLimitEnv    = "FRAUD_VELOCITY_REVIEW_LIMIT"
+	fraudVelocityDeclineLimitEnv   = "FRAUD_VELOCITY_DECLINE_LIMIT"
+	fraudReviewAmountsEnv          = "FRAUD_REVIEW_AMOUNTS"
+	fraudDeclineAmountsEnv         = "FRAUD_DECLINE_AMOUNTS"
+	fraudNewAccountAgeEnv          = "FRAUD_NEW_ACCOUNT_AGE"
+	fraudNewAccountReviewAmountEnv = "FRAUD_NEW_ACCOUNT_REVIEW_AMOUNT"
+	fraudHistoryTTLEnv             = "FRAUD_HISTORY_TTL"
+
+	defaultFraudVelocityWindow = 10 * time.Minute
+	defaultFraudNewAccountAge  = 72 * time.Hour
+	defaultFraudHistoryTTL     = 90 * 24 * time.Hour
+)
+
+type fraudConfig struct {
+	enabled                bool
+	velocityWindow         time.Duration
+	velocityReviewLimit    int
+	velocityDeclineLimit   int
+	reviewAmounts          map[payment_v1.PaymentMethod]float64
+	declineAmounts         map[payment_v1.PaymentMethod]float64
+	newAccountAge          time.Duration
+	newAccountReviewAmount float64
+	historyTTL             time.Duration
+}
+
+// NewFraudConfig создает конфигурацию антифрод-проверки платежей из переменных окружения
+func NewFraudConfig() (*fraudConfig, error) {
+	enabled := true
+	if value := os.Getenv(fraudEnabledEnv); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", fraudEnabledEnv, err)
+		}
+		enabled = parsed
+	}
+
+	velocityWindow, err := durationEnv(fraudVelocityWindowEnv, defaultFraudVelocityWindow)
+	if err != nil {
+		return nil, err
+	}
+
+	velocityReviewLimit, err := limitEnv(fraudVelocityReviewLimitEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	velocityDeclineLimit, err := limitEnv(fraudVelocityDeclineLimitEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	reviewAmounts, err := paymentMethodAmountsEnv(fraudReviewAmountsEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	declineAmounts, err := paymentMethodAmountsEnv(fraudDeclineAmountsEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	newAccountAge, err := durationEnv(fraudNewAccountAgeEnv, defaultFraudNewAccountAge)
+	if err != nil {
+		return nil, err
+	}
+
+	// 0 отключает правило для новых аккаунтов
+	var newAccountReviewAmount float64
+	if amountStr := os.Getenv(fraudNewAccountReviewAmountEnv); amountStr != "" {
+		newAccountReviewAmount, err = strconv.ParseFloat(amountStr, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", fraudNewAccountReviewAmountEnv, err)
+		}
+	}
+
+	historyTTL, err := durationEnv(fraudHistoryTTLEnv, defaultFraudHistoryTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &fraudConfig{
+		enabled:                enabled,
+		velocityWindow:         velocityWindow,
+		velocityReviewLimit:    velocityReviewLimit,
+		velocityDeclineLimit:   velocityDeclineLimit,
+		reviewAmounts:          reviewAmounts,
+		declineAmounts:         declineAmounts,
+		newAccountAge:          newAccountAge,
+		newAccountReviewAmount: newAccountReviewAmount,
+		historyTTL:             historyTTL,
+	}, nil
+}
+
+func (c *fraudConfig) Enabled() bool {
+	return c.enabled
+}
+
+func (c *fraudConfig) VelocityWindow() time.Duration {
+	return c.velocityWindow
+}
+
+func (c *fraudConfig) VelocityReviewLimit() int {
+	return c.velocityReviewLimit
+}
+
+func (c *fraudConfig) VelocityDeclineLimit() int {
+	return c.velocityDeclineLimit
+}
+
+func (c *fraudConfig) ReviewAmounts() map[payment_v1.PaymentMethod]float64 {
+	return c.reviewAmounts
+}
+
+func (c *fraudConfig) DeclineAmounts() map[payment_v1.PaymentMethod]float64 {
+	return c.declineAmounts
+}
+
+func (c *fraudConfig) NewAccountAge() time.Duration {
+	return c.newAccountAge
+}
+
+func (c *fraudConfig) NewAccountReviewAmount() float64 {
+	return c.newAccountReviewAmount
+}
+
+func (c *fraudConfig) HistoryTTL() time.Duration {
+	return c.historyTTL
+}
+
+// limitEnv разбирает неотрицательный лимит, по умолчанию 0 (без ограничения)
+func limitEnv(name string) (int, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return 0, nil
+	}
+
+	limit, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", name, err)
+	}
+
+	if limit < 0 {
+		return 0, fmt.Errorf("invalid %s: limit must not be negative", name)
+	}
+
+	return limit, nil
+}
+
+// paymentMethodAmountsEnv разбирает суммы по способам оплаты в формате CARD:500000,SBP:1000000
+func paymentMethodAmountsEnv(name string) (map[payment_v1.PaymentMethod]float64, error) {
+	amounts := make(map[payment_v1.PaymentMethod]float64)
+	for _, value := range listEnv(name) {
+		methodStr, amountStr, ok := strings.Cut(value, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid %s: expected METHOD:AMOUNT, got %q", name, value)
+		}
+
+		method, ok := payment_v1.PaymentMethod_value[paymentMethodEnumPrefix+strings.ToUpper(strings.TrimSpace(methodStr))]
+		if !ok {
+			return nil, fmt.Errorf("invalid %s: unknown payment method %q", name, methodStr)
+		}
+
+		amount, err := strconv.ParseFloat(strings.TrimSpace(amountStr), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", name, err)
+		}
+
+		amounts[payment_v1.PaymentMethod(method)] = amount
+	}
+
+	return amounts, nil
+}
@@ -0,0 +1,63 @@
+package env
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+const (
+	iamGRPCAddressEnv           = "IAM_GRPC_ADDRESS"
+	iamSessionCacheTTLEnv       = "IAM_SESSION_CACHE_TTL"
+	iamSessionCacheMaxEntryEnv  = "IAM_SESSION_CACHE_MAX_ENTRIES"
+	defaultIAMSessionCacheTTL   = 30 * time.Second
+	defaultIAMSessionMaxEntries = 10000
+)
+
+type iamGRPCConfig struct {
+	address                string
+	sessionCacheTTL        time.Duration
+	sessionCacheMaxEntries int
+}
+
+// NewIAMGRPCConfig создает конфигурацию gRPC клиента IAM из переменных окружения
+func NewIAMGRPCConfig() (*iamGRPCConfig, error) {
+	address := os.Getenv(iamGRPCAddressEnv)
+	if address == "" {
+		address = "localhost:50053"
+	}
+
+	sessionCacheTTL, err := durationEnv(iamSessionCacheTTLEnv, defaultIAMSessionCacheTTL)
+	if err != nil {
+		return nil, err
+	}
+	if sessionCacheTTL < 0 {
+		return nil, fmt.Errorf("invalid %s: must not be negative", iamSessionCacheTTLEnv)
+	}
+
+	sessionCacheMaxEntries, err := limitEnv(iamSessionCacheMaxEntryEnv)
+	if err != nil {
+		return nil, err
+	}
+	if sessionCacheMaxEntries == 0 {
+		sessionCacheMaxEntries = defaultIAMSessionMaxEntries
+	}
+
+	return &iamGRPCConfig{
+		address:                address,
+		sessionCacheTTL:        sessionCacheTTL,
+		sessionCacheMaxEntries: sessionCacheMaxEntries,
+	}, nil
+}
+
+func (c *iamGRPCConfig) Address() string {
+	return c.address
+}
+
+func (c *iamGRPCConfig) SessionCacheTTL() time.Duration {
+	return c.sessionCacheTTL
+}
+
+func (c *iamGRPCConfig) SessionCacheMaxEntries() int {
+	return c.sessionCacheMaxEntries
+}
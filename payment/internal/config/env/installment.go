@@ -0,0 +1,130 @@
+package env
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	installmentTermsEnv          = "INSTALLMENT_TERMS"
+	installmentPeriodEnv         = "INSTALLMENT_PERIOD"
+	installmentRunnerIntervalEnv = "INSTALLMENT_RUNNER_INTERVAL"
+	installmentRetryIntervalEnv  = "INSTALLMENT_RETRY_INTERVAL"
+	installmentOverdueAfterEnv   = "INSTALLMENT_OVERDUE_AFTER"
+
+	defaultInstallmentTerms          = "3:0,6:0.01,12:0.015"
+	defaultInstallmentPeriod         = 30 * 24 * time.Hour
+	defaultInstallmentRunnerInterval = time.Minute
+	defaultInstallmentRetryInterval  = 24 * time.Hour
+	defaultInstallmentOverdueAfter   = 72 * time.Hour
+)
+
+type installmentConfig struct {
+	terms          map[int]float64
+	period         time.Duration
+	runnerInterval time.Duration
+	retryInterval  time.Duration
+	overdueAfter   time.Duration
+}
+
+// NewInstallmentConfig создает конфигурацию рассрочки из переменных окружения
+func NewInstallmentConfig() (*installmentConfig, error) {
+	termsStr := os.Getenv(installmentTermsEnv)
+	if termsStr == "" {
+		termsStr = defaultInstallmentTerms
+	}
+
+	terms, err := installmentTerms(termsStr)
+	if err != nil {
+		return nil, err
+	}
+
+	period, err := durationEnv(installmentPeriodEnv, defaultInstallmentPeriod)
+	if err != nil {
+		return nil, err
+	}
+
+	if period <= 0 {
+		return nil, fmt.Errorf("invalid %s: period must be positive", installmentPeriodEnv)
+	}
+
+	// 0 отключает фоновое списание платежей по графику
+	runnerInterval, err := durationEnv(installmentRunnerIntervalEnv, defaultInstallmentRunnerInterval)
+	if err != nil {
+		return nil, err
+	}
+
+	retryInterval, err := durationEnv(installmentRetryIntervalEnv, defaultInstallmentRetryInterval)
+	if err != nil {
+		return nil, err
+	}
+
+	overdueAfter, err := durationEnv(installmentOverdueAfterEnv, defaultInstallmentOverdueAfter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &installmentConfig{
+		terms:          terms,
+		period:         period,
+		runnerInterval: runnerInterval,
+		retryInterval:  retryInterval,
+		overdueAfter:   overdueAfter,
+	}, nil
+}
+
+func (c *installmentConfig) Terms() map[int]float64 {
+	return c.terms
+}
+
+func (c *installmentConfig) Period() time.Duration {
+	return c.period
+}
+
+func (c *installmentConfig) RunnerInterval() time.Duration {
+	return c.runnerInterval
+}
+
+func (c *installmentConfig) RetryInterval() time.Duration {
+	return c.retryInterval
+}
+
+func (c *installmentConfig) OverdueAfter() time.Duration {
+	return c.overdueAfter
+}
+
+// installmentTerms разбирает условия рассрочки в формате N:RATE,N:RATE (3:0,6:0.01)
+func installmentTerms(value string) (map[int]float64, error) {
+	terms := make(map[int]float64)
+	for _, term := range strings.Split(value, ",") {
+		if term = strings.TrimSpace(term); term == "" {
+			continue
+		}
+
+		countStr, rateStr, ok := strings.Cut(term, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid %s: expected N:RATE, got %q", installmentTermsEnv, term)
+		}
+
+		count, err := strconv.Atoi(strings.TrimSpace(countStr))
+		if err != nil || count < 2 {
+			return nil, fmt.Errorf("invalid %s: number of installments must be at least 2, got %q", installmentTermsEnv, countStr)
+		}
+
+		rate, err := strconv.ParseFloat(strings.TrimSpace(rateStr), 64)
+		if err != nil || rate < 0 || rate >= 1 {
+			return nil, fmt.Errorf("invalid %s: monthly rate must be in [0, 1), got %q", installmentTermsEnv, rateStr)
+		}
+
+		terms[count] = rate
+	}
+
+	if len(terms) == 0 {
+		return nil, fmt.Errorf("invalid %s: no terms configured", installmentTermsEnv)
+	}
+
+	return terms, nil
+}
@@ -0,0 +1,69 @@
+package env
+
+import (
+	"os"
+	"strings"
+)
+
+const (
+	kafkaBrokersEnv                = "KAFKA_BROKERS"
+	userErasedConsumerTopicEnv     = "USER_ERASED_CONSUMER_TOPIC"
+	userErasedConsumerGroupIDEnv   = "USER_ERASED_CONSUMER_GROUP_ID"
+	paymentEventsProducerTopicEnv  = "PAYMENT_EVENTS_PRODUCER_TOPIC"
+	defaultUserErasedTopic         = "user-erased"
+	defaultUserErasedConsumerGroup = "payment-user-erased-consumer"
+	defaultPaymentEventsTopic      = "payment-events"
+)
+
+type kafkaConfig struct {
+	brokers                   []string
+	userErasedTopic           string
+	userErasedConsumerGroupID string
+	paymentEventsTopic        string
+}
+
+// NewKafkaConfig создает конфигурацию Kafka из переменных окружения
+func NewKafkaConfig() (*kafkaConfig, error) {
+	brokers := []string{"localhost:9092"}
+	if brokersStr := os.Getenv(kafkaBrokersEnv); brokersStr != "" {
+		brokers = strings.Split(brokersStr, ",")
+	}
+
+	userErasedTopic := os.Getenv(userErasedConsumerTopicEnv)
+	if userErasedTopic == "" {
+		userErasedTopic = defaultUserErasedTopic
+	}
+
+	userErasedConsumerGroupID := os.Getenv(userErasedConsumerGroupIDEnv)
+	if userErasedConsumerGroupID == "" {
+		userErasedConsumerGroupID = defaultUserErasedConsumerGroup
+	}
+
+	paymentEventsTopic := os.Getenv(paymentEventsProducerTopicEnv)
+	if paymentEventsTopic == "" {
+		paymentEventsTopic = defaultPaymentEventsTopic
+	}
+
+	return &kafkaConfig{
+		brokers:                   brokers,
+		userErasedTopic:           userErasedTopic,
+		userErasedConsumerGroupID: userErasedConsumerGroupID,
+		paymentEventsTopic:        paymentEventsTopic,
+	}, nil
+}
+
+func (c *kafkaConfig) Brokers() []string {
+	return c.brokers
+}
+
+func (c *kafkaConfig) UserErasedTopic() string {
+	return c.userErasedTopic
+}
+
+func (c *kafkaConfig) UserErasedConsumerGroupID() string {
+	return c.userErasedConsumerGroupID
+}
+
+func (c *kafkaConfig) PaymentEventsTopic() string {
+	return c.paymentEventsTopic
+}
@@ -0,0 +1,233 @@
+package env
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	payment_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/payment/v1"
+)
+
+const (
+	providerTimeoutEnv             = "PAYMENT_PROVIDER_TIMEOUT"
+	providerFeeRateEnv             = "PAYMENT_PROVIDER_FEE_RATE"
+	providerRecoveryIntervalEnv    = "PAYMENT_PROVIDER_RECOVERY_INTERVAL"
+	providerRecoveryAfterEnv       = "PAYMENT_PROVIDER_RECOVERY_AFTER"
+	simulatorLatencyEnv            = "SIMULATOR_LATENCY"
+	simulatorLatencyJitterEnv      = "SIMULATOR_LATENCY_JITTER"
+	simulatorFailureRateEnv        = "SIMULATOR_FAILURE_RATE"
+	simulatorPendingRateEnv        = "SIMULATOR_PENDING_RATE"
+	simulatorDeclineAmountAboveEnv = "SIMULATOR_DECLINE_AMOUNT_ABOVE"
+	simulatorDeclineUsersEnv       = "SIMULATOR_DECLINE_USERS"
+	simulatorDeclineMethodsEnv     = "SIMULATOR_DECLINE_METHODS"
+
+	defaultProviderTimeout          = 5 * time.Second
+	defaultProviderRecoveryInterval = time.Minute
+	defaultProviderRecoveryAfter    = 5 * time.Minute
+
+	paymentMethodEnumPrefix = "PAYMENT_METHOD_"
+)
+
+type providerConfig struct {
+	timeout          time.Duration
+	feeRate          float64
+	recoveryInterval time.Duration
+	recoveryAfter    time.Duration
+}
+
+// NewProviderConfig создает конфигурацию вызовов платежных провайдеров из переменных окружения
+func NewProviderConfig() (*providerConfig, error) {
+	timeout, err := durationEnv(providerTimeoutEnv, defaultProviderTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	feeRate, err := rateEnv(providerFeeRateEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	// 0 отключает сверку транзакций с неизвестным исходом списания
+	recoveryInterval, err := durationEnv(providerRecoveryIntervalEnv, defaultProviderRecoveryInterval)
+	if err != nil {
+		return nil, err
+	}
+
+	recoveryAfter, err := durationEnv(providerRecoveryAfterEnv, defaultProviderRecoveryAfter)
+	if err != nil {
+		return nil, err
+	}
+
+	// Иначе сверка заберет транзакцию, ответ на списание которой еще ожидается
+	if recoveryAfter <= timeout {
+		return nil, fmt.Errorf("invalid %s: must exceed %s", providerRecoveryAfterEnv, providerTimeoutEnv)
+	}
+
+	return &providerConfig{
+		timeout:          timeout,
+		feeRate:          feeRate,
+		recoveryInterval: recoveryInterval,
+		recoveryAfter:    recoveryAfter,
+	}, nil
+}
+
+func (c *providerConfig) Timeout() time.Duration {
+	return c.timeout
+}
+
+func (c *providerConfig) FeeRate() float64 {
+	return c.feeRate
+}
+
+func (c *providerConfig) RecoveryInterval() time.Duration {
+	return c.recoveryInterval
+}
+
+func (c *providerConfig) RecoveryAfter() time.Duration {
+	return c.recoveryAfter
+}
+
+type simulatorConfig struct {
+	latency            time.Duration
+	latencyJitter      time.Duration
+	failureRate        float64
+	pendingRate        float64
+	declineAmountAbove float64
+	declineUsers       []string
+	declineMethods     []payment_v1.PaymentMethod
+}
+
+// NewSimulatorConfig создает конфигурацию симулятора платежного провайдера из переменных окружения
+func NewSimulatorConfig() (*simulatorConfig, error) {
+	latency, err := durationEnv(simulatorLatencyEnv, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	latencyJitter, err := durationEnv(simulatorLatencyJitterEnv, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	failureRate, err := rateEnv(simulatorFailureRateEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	pendingRate, err := rateEnv(simulatorPendingRateEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	// 0 отключает отказ по сумме
+	var declineAmountAbove float64
+	if amountStr := os.Getenv(simulatorDeclineAmountAboveEnv); amountStr != "" {
+		declineAmountAbove, err = strconv.ParseFloat(amountStr, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", simulatorDeclineAmountAboveEnv, err)
+		}
+	}
+
+	declineMethods, err := paymentMethodsEnv(simulatorDeclineMethodsEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	return &simulatorConfig{
+		latency:            latency,
+		latencyJitter:      latencyJitter,
+		failureRate:        failureRate,
+		pendingRate:        pendingRate,
+		declineAmountAbove: declineAmountAbove,
+		declineUsers:       listEnv(simulatorDeclineUsersEnv),
+		declineMethods:     declineMethods,
+	}, nil
+}
+
+func (c *simulatorConfig) Latency() time.Duration {
+	return c.latency
+}
+
+func (c *simulatorConfig) LatencyJitter() time.Duration {
+	return c.latencyJitter
+}
+
+func (c *simulatorConfig) FailureRate() float64 {
+	return c.failureRate
+}
+
+func (c *simulatorConfig) PendingRate() float64 {
+	return c.pendingRate
+}
+
+func (c *simulatorConfig) DeclineAmountAbove() float64 {
+	return c.declineAmountAbove
+}
+
+func (c *simulatorConfig) DeclineUsers() []string {
+	return c.declineUsers
+}
+
+func (c *simulatorConfig) DeclineMethods() []payment_v1.PaymentMethod {
+	return c.declineMethods
+}
+
+func durationEnv(name string, defaultValue time.Duration) (time.Duration, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", name, err)
+	}
+
+	return duration, nil
+}
+
+// rateEnv разбирает долю в диапазоне [0, 1], по умолчанию 0
+func rateEnv(name string) (float64, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return 0, nil
+	}
+
+	rate, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", name, err)
+	}
+
+	if rate < 0 || rate > 1 {
+		return 0, fmt.Errorf("invalid %s: rate must be between 0 and 1", name)
+	}
+
+	return rate, nil
+}
+
+func listEnv(name string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(name), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
+
+// paymentMethodsEnv разбирает список способов оплаты, заданных без префикса (CARD,SBP)
+func paymentMethodsEnv(name string) ([]payment_v1.PaymentMethod, error) {
+	var methods []payment_v1.PaymentMethod
+	for _, value := range listEnv(name) {
+		method, ok := payment_v1.PaymentMethod_value[paymentMethodEnumPrefix+strings.ToUpper(value)]
+		if !ok {
+			return nil, fmt.Errorf("invalid %s: unknown payment method %q", name, value)
+		}
+		methods = append(methods, payment_v1.PaymentMethod(method))
+	}
+
+	return methods, nil
+}
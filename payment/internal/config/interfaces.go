@@ -1,5 +1,11 @@
 package config
 
+import (
+	"time"
+
+	payment_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/payment/v1"
+)
+
 // LoggerConfig интерфейс конфигурации логгера
 type LoggerConfig interface {
 	Level() string
@@ -13,3 +19,121 @@ type LoggerConfig interface {
 type PaymentGRPCConfig interface {
 	Address() string
 }
+
+// IAMGRPCConfig интерфейс конфигурации gRPC клиента IAM, через который проверяются сессии согласующих
+type IAMGRPCConfig interface {
+	Address() string
+	SessionCacheTTL() time.Duration
+	SessionCacheMaxEntries() int
+}
+
+// MetricsConfig интерфейс конфигурации Prometheus метрик
+type MetricsConfig interface {
+	Port() int
+}
+
+// KafkaConfig интерфейс конфигурации Kafka
+type KafkaConfig interface {
+	Brokers() []string
+	UserErasedTopic() string
+	UserErasedConsumerGroupID() string
+	// PaymentEventsTopic топик событий PaymentCompleted и PaymentFailed
+	PaymentEventsTopic() string
+}
+
+// ProviderConfig интерфейс конфигурации вызовов платежных провайдеров
+type ProviderConfig interface {
+	// Timeout максимальное время ожидания ответа провайдера, после которого исход списания считается неизвестным
+	Timeout() time.Duration
+	// FeeRate доля суммы платежа, удерживаемая провайдером в качестве комиссии
+	FeeRate() float64
+	// RecoveryInterval период сверки с провайдером транзакций с неизвестным исходом списания
+	// и повтора не удавшихся возвратов частей раздельной оплаты (0 - отключено)
+	RecoveryInterval() time.Duration
+	// RecoveryAfter возраст PENDING транзакции, после которого ее исход запрашивается у провайдера
+	RecoveryAfter() time.Duration
+}
+
+// PostgresConfig интерфейс конфигурации PostgreSQL бухгалтерской книги
+type PostgresConfig interface {
+	DSN() string
+	MigrationsDir() string
+}
+
+// RedisConfig интерфейс конфигурации Redis, в котором хранится история платежей для антифрод-проверки
+type RedisConfig interface {
+	Addr() string
+	Password() string
+	DB() int
+	DialTimeout() time.Duration
+	ReadTimeout() time.Duration
+	WriteTimeout() time.Duration
+	PoolSize() int
+}
+
+// FraudConfig интерфейс конфигурации антифрод-проверки платежей.
+// Нулевые лимиты и суммы отключают соответствующие правила
+type FraudConfig interface {
+	// Enabled включает проверку; при выключенной проверке все платежи одобряются
+	Enabled() bool
+	// VelocityWindow окно, за которое считается число попыток оплаты пользователя
+	VelocityWindow() time.Duration
+	// VelocityReviewLimit число попыток за окно, сверх которого платеж отправляется на ручную проверку
+	VelocityReviewLimit() int
+	// VelocityDeclineLimit число попыток за окно, сверх которого платеж отклоняется
+	VelocityDeclineLimit() int
+	// ReviewAmounts суммы по способам оплаты, выше которых платеж отправляется на ручную проверку
+	ReviewAmounts() map[payment_v1.PaymentMethod]float64
+	// DeclineAmounts суммы по способам оплаты, выше которых платеж отклоняется
+	DeclineAmounts() map[payment_v1.PaymentMethod]float64
+	// NewAccountAge период после первой попытки оплаты, в течение которого пользователь считается новым
+	NewAccountAge() time.Duration
+	// NewAccountReviewAmount сумма, выше которой платеж нового пользователя отправляется на ручную проверку
+	NewAccountReviewAmount() float64
+	// HistoryTTL время хранения истории платежей пользователя
+	HistoryTTL() time.Duration
+}
+
+// InstallmentConfig интерфейс конфигурации рассрочки по кредитной карте
+type InstallmentConfig interface {
+	// Terms доступные сроки рассрочки: число платежей и месячная процентная ставка
+	Terms() map[int]float64
+	// Period интервал между платежами по графику
+	Period() time.Duration
+	// RunnerInterval период запуска списания платежей по графику (0 - списание отключено)
+	RunnerInterval() time.Duration
+	// RetryInterval интервал повторной попытки списания после неудачи
+	RetryInterval() time.Duration
+	// OverdueAfter время после срока платежа, по истечении которого неоплаченный платеж считается просроченным
+	OverdueAfter() time.Duration
+}
+
+// ApprovalConfig интерфейс конфигурации согласования платежей средствами инвестора
+type ApprovalConfig interface {
+	// Approvers логины пользователей IAM, согласующих платежи (пустой список - согласование отключено)
+	Approvers() []string
+	// Quorum число одобрений, необходимое для списания
+	Quorum() int
+	// Timeout время на согласование, после которого несогласованный платеж отменяется
+	Timeout() time.Duration
+	// ExpiryInterval период запуска отмены просроченных запросов (0 - отмена отключена)
+	ExpiryInterval() time.Duration
+}
+
+// SimulatorConfig интерфейс конфигурации симулятора платежного провайдера
+type SimulatorConfig interface {
+	// Latency базовая задержка ответа
+	Latency() time.Duration
+	// LatencyJitter максимальная случайная добавка к задержке
+	LatencyJitter() time.Duration
+	// FailureRate вероятность технического сбоя
+	FailureRate() float64
+	// PendingRate вероятность того, что платеж потребует подтверждения (3-D Secure)
+	PendingRate() float64
+	// DeclineAmountAbove сумма, выше которой платеж отклоняется (0 - без ограничения)
+	DeclineAmountAbove() float64
+	// DeclineUsers UUID пользователей, чьи платежи отклоняются
+	DeclineUsers() []string
+	// DeclineMethods способы оплаты, платежи которыми отклоняются
+	DeclineMethods() []payment_v1.PaymentMethod
+}
@@ -1,19 +1,215 @@
 package converter
 
 import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
 	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
+	payment_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/payment/v1"
 )
 
-// TransactionToProto конвертирует модель Transaction в protobuf (если бы он существовал)
-// Пока что просто возвращаем nil, так как в protobuf нет типа Transaction
-func TransactionToProto(transaction models.Transaction) interface{} {
-	// В protobuf схеме нет типа Transaction, поэтому возвращаем nil
-	return nil
+// TransactionToProto конвертирует модель Transaction в protobuf
+func TransactionToProto(transaction models.Transaction) *payment_v1.Transaction {
+	return &payment_v1.Transaction{
+		TransactionUuid:     transaction.UUID,
+		OrderUuid:           transaction.OrderUUID,
+		UserUuid:            transaction.UserID,
+		PaymentMethod:       transaction.PaymentMethod,
+		Amount:              transaction.Amount,
+		Status:              string(transaction.Status),
+		FailureReason:       transaction.FailureReason,
+		FraudDecision:       string(transaction.FraudDecision),
+		FraudReasons:        transaction.FraudReasons,
+		InstallmentPlanUuid: transaction.InstallmentPlanUUID,
+		ApprovalUuid:        transaction.ApprovalUUID,
+		SplitUuid:           transaction.SplitUUID,
+		ReviewedBy:          transaction.ReviewedBy,
+		CreatedAt:           timestamppb.New(transaction.CreatedAt),
+		UpdatedAt:           timestamppb.New(transaction.UpdatedAt),
+	}
 }
 
 // ProtoToTransaction конвертирует protobuf Transaction в модель Transaction
-// Пока что просто возвращаем пустую структуру, так как в protobuf нет типа Transaction
-func ProtoToTransaction(protoTransaction interface{}) models.Transaction {
-	// В protobuf схеме нет типа Transaction, поэтому возвращаем пустую структуру
-	return models.Transaction{}
+func ProtoToTransaction(protoTransaction *payment_v1.Transaction) models.Transaction {
+	return models.Transaction{
+		UUID:                protoTransaction.GetTransactionUuid(),
+		OrderUUID:           protoTransaction.GetOrderUuid(),
+		UserID:              protoTransaction.GetUserUuid(),
+		PaymentMethod:       protoTransaction.GetPaymentMethod(),
+		Amount:              protoTransaction.GetAmount(),
+		Status:              models.TransactionStatus(protoTransaction.GetStatus()),
+		FailureReason:       protoTransaction.GetFailureReason(),
+		FraudDecision:       models.FraudDecision(protoTransaction.GetFraudDecision()),
+		FraudReasons:        protoTransaction.GetFraudReasons(),
+		InstallmentPlanUUID: protoTransaction.GetInstallmentPlanUuid(),
+		ApprovalUUID:        protoTransaction.GetApprovalUuid(),
+		SplitUUID:           protoTransaction.GetSplitUuid(),
+		ReviewedBy:          protoTransaction.GetReviewedBy(),
+		CreatedAt:           protoTransaction.GetCreatedAt().AsTime(),
+		UpdatedAt:           protoTransaction.GetUpdatedAt().AsTime(),
+	}
+}
+
+// TransactionsToProto конвертирует список транзакций в protobuf
+func TransactionsToProto(transactions []models.Transaction) []*payment_v1.Transaction {
+	result := make([]*payment_v1.Transaction, 0, len(transactions))
+	for _, transaction := range transactions {
+		result = append(result, TransactionToProto(transaction))
+	}
+
+	return result
+}
+
+// ProtoToTenders конвертирует части раздельной оплаты из protobuf
+func ProtoToTenders(protoTenders []*payment_v1.Tender) []models.Tender {
+	tenders := make([]models.Tender, 0, len(protoTenders))
+	for _, tender := range protoTenders {
+		tenders = append(tenders, models.Tender{
+			PaymentMethod: tender.GetPaymentMethod(),
+			Amount:        tender.GetAmount(),
+		})
+	}
+
+	return tenders
+}
+
+// LedgerAccountToProto конвертирует счет книги в protobuf
+func LedgerAccountToProto(account models.LedgerAccount) *payment_v1.LedgerAccount {
+	return &payment_v1.LedgerAccount{
+		Code: account.Code,
+		Name: account.Name,
+		Type: string(account.Type),
+	}
+}
+
+// LedgerAccountsToProto конвертирует список счетов книги в protobuf
+func LedgerAccountsToProto(accounts []models.LedgerAccount) []*payment_v1.LedgerAccount {
+	result := make([]*payment_v1.LedgerAccount, 0, len(accounts))
+	for _, account := range accounts {
+		result = append(result, LedgerAccountToProto(account))
+	}
+
+	return result
+}
+
+// AccountBalanceToProto конвертирует обороты и сальдо счета в protobuf
+func AccountBalanceToProto(balance models.AccountBalance) *payment_v1.AccountBalance {
+	return &payment_v1.AccountBalance{
+		Account:     LedgerAccountToProto(balance.Account),
+		DebitTotal:  balance.Totals.Debit,
+		CreditTotal: balance.Totals.Credit,
+		Balance:     balance.Balance,
+	}
+}
+
+// AccountStatementToProto конвертирует выписку по счету в protobuf
+func AccountStatementToProto(statement models.AccountStatement) *payment_v1.GetAccountStatementResponse {
+	lines := make([]*payment_v1.StatementLine, 0, len(statement.Lines))
+	for _, line := range statement.Lines {
+		lines = append(lines, &payment_v1.StatementLine{
+			EntryUuid:       line.EntryUUID,
+			EntryType:       string(line.EntryType),
+			TransactionUuid: line.TransactionUUID,
+			Description:     line.Description,
+			Direction:       string(line.Direction),
+			Amount:          line.Amount,
+			Balance:         line.Balance,
+			CreatedAt:       timestamppb.New(line.CreatedAt),
+		})
+	}
+
+	return &payment_v1.GetAccountStatementResponse{
+		Account:        LedgerAccountToProto(statement.Account),
+		OpeningBalance: statement.OpeningBalance,
+		ClosingBalance: statement.ClosingBalance,
+		Lines:          lines,
+	}
+}
+
+// InstallmentQuotesToProto конвертирует расчеты рассрочки в protobuf
+func InstallmentQuotesToProto(quotes []models.InstallmentQuote) []*payment_v1.InstallmentQuote {
+	result := make([]*payment_v1.InstallmentQuote, 0, len(quotes))
+	for _, quote := range quotes {
+		result = append(result, &payment_v1.InstallmentQuote{
+			Installments: int32(quote.Installments), //nolint:gosec // число платежей ограничено условиями рассрочки
+			MonthlyRate:  quote.MonthlyRate,
+			Payments:     quote.Payments,
+			TotalAmount:  quote.TotalAmount,
+			Overpayment:  quote.Overpayment,
+		})
+	}
+
+	return result
+}
+
+// InstallmentPlanToProto конвертирует план рассрочки в protobuf
+func InstallmentPlanToProto(plan models.InstallmentPlan) *payment_v1.InstallmentPlan {
+	installments := make([]*payment_v1.Installment, 0, len(plan.Installments))
+	for _, installment := range plan.Installments {
+		protoInstallment := &payment_v1.Installment{
+			Number:          int32(installment.Number), //nolint:gosec // число платежей ограничено условиями рассрочки
+			Amount:          installment.Amount,
+			DueAt:           timestamppb.New(installment.DueAt),
+			Status:          string(installment.Status),
+			TransactionUuid: installment.TransactionUUID,
+			Attempts:        int32(installment.Attempts), //nolint:gosec // число попыток ограничено сроком рассрочки
+			FailureReason:   installment.FailureReason,
+		}
+		if !installment.PaidAt.IsZero() {
+			protoInstallment.PaidAt = timestamppb.New(installment.PaidAt)
+		}
+		installments = append(installments, protoInstallment)
+	}
+
+	return &payment_v1.InstallmentPlan{
+		PlanUuid:      plan.UUID,
+		OrderUuid:     plan.OrderUUID,
+		UserUuid:      plan.UserID,
+		PaymentMethod: plan.PaymentMethod,
+		Principal:     plan.Principal,
+		MonthlyRate:   plan.MonthlyRate,
+		TotalAmount:   plan.TotalAmount,
+		PaidAmount:    plan.PaidAmount(),
+		Status:        string(plan.Status),
+		Installments:  installments,
+		CreatedAt:     timestamppb.New(plan.CreatedAt),
+		UpdatedAt:     timestamppb.New(plan.UpdatedAt),
+	}
+}
+
+// ApprovalToProto конвертирует запрос на согласование платежа в protobuf
+func ApprovalToProto(approval models.Approval) *payment_v1.Approval {
+	decisions := make([]*payment_v1.ApprovalDecision, 0, len(approval.Decisions))
+	for _, decision := range approval.Decisions {
+		decisions = append(decisions, &payment_v1.ApprovalDecision{
+			ApproverId: decision.ApproverID,
+			Approved:   decision.Approved,
+			Reason:     decision.Reason,
+			DecidedAt:  timestamppb.New(decision.DecidedAt),
+		})
+	}
+
+	return &payment_v1.Approval{
+		ApprovalUuid:    approval.UUID,
+		TransactionUuid: approval.TransactionUUID,
+		OrderUuid:       approval.OrderUUID,
+		UserUuid:        approval.UserID,
+		Amount:          approval.Amount,
+		Approvers:       approval.Approvers,
+		Quorum:          int32(approval.Quorum), //nolint:gosec // кворум не больше числа согласующих
+		Status:          string(approval.Status),
+		Decisions:       decisions,
+		ExpiresAt:       timestamppb.New(approval.ExpiresAt),
+		CreatedAt:       timestamppb.New(approval.CreatedAt),
+		UpdatedAt:       timestamppb.New(approval.UpdatedAt),
+	}
+}
+
+// ApprovalsToProto конвертирует список запросов на согласование в protobuf
+func ApprovalsToProto(approvals []models.Approval) []*payment_v1.Approval {
+	result := make([]*payment_v1.Approval, 0, len(approvals))
+	for _, approval := range approvals {
+		result = append(result, ApprovalToProto(approval))
+	}
+
+	return result
 }
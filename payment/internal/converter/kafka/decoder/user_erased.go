@@ -0,0 +1,29 @@
+package decoder
+
+import (
+	"encoding/json"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/linemk/rocket-shop/payment/internal/entyties/events"
+	events_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/events/v1"
+)
+
+// DecodeUserErased декодирует событие UserErased из JSON
+func DecodeUserErased(data []byte) (*events.UserErasedEvent, error) {
+	var protoEvent events_v1.UserErased
+
+	// Пробуем protojson
+	if err := protojson.Unmarshal(data, &protoEvent); err != nil {
+		// Если не получилось, пробуем обычный JSON
+		if err := json.Unmarshal(data, &protoEvent); err != nil {
+			return nil, err
+		}
+	}
+
+	return &events.UserErasedEvent{
+		EventUUID: protoEvent.EventUuid,
+		UserUUID:  protoEvent.UserUuid,
+		ErasedAt:  protoEvent.ErasedAt.AsTime(),
+	}, nil
+}
@@ -0,0 +1,105 @@
+package kafka
+
+import (
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/linemk/rocket-shop/payment/internal/entyties/events"
+	events_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/events/v1"
+)
+
+// EncodePaymentCompleted кодирует событие PaymentCompleted в конверте PaymentEvent
+func EncodePaymentCompleted(event *events.PaymentCompletedEvent) ([]byte, error) {
+	return protojson.Marshal(&events_v1.PaymentEvent{
+		Event: &events_v1.PaymentEvent_Completed{
+			Completed: &events_v1.PaymentCompleted{
+				EventUuid:       event.EventUUID,
+				TransactionUuid: event.TransactionUUID,
+				OrderUuid:       event.OrderUUID,
+				UserUuid:        event.UserUUID,
+				PaymentMethod:   event.PaymentMethod,
+				Amount:          event.Amount,
+				CompletedAt:     timestamppb.New(event.CompletedAt),
+			},
+		},
+	})
+}
+
+// EncodePaymentFailed кодирует событие PaymentFailed в конверте PaymentEvent
+func EncodePaymentFailed(event *events.PaymentFailedEvent) ([]byte, error) {
+	return protojson.Marshal(&events_v1.PaymentEvent{
+		Event: &events_v1.PaymentEvent_Failed{
+			Failed: &events_v1.PaymentFailed{
+				EventUuid:       event.EventUUID,
+				TransactionUuid: event.TransactionUUID,
+				OrderUuid:       event.OrderUUID,
+				UserUuid:        event.UserUUID,
+				Status:          event.Status,
+				Reason:          event.Reason,
+				FailedAt:        timestamppb.New(event.FailedAt),
+			},
+		},
+	})
+}
+
+// EncodeInstallmentPaid кодирует событие InstallmentPaid в конверте PaymentEvent
+func EncodeInstallmentPaid(event *events.InstallmentPaidEvent) ([]byte, error) {
+	return protojson.Marshal(&events_v1.PaymentEvent{
+		Event: &events_v1.PaymentEvent_InstallmentPaid{
+			InstallmentPaid: &events_v1.InstallmentPaid{
+				EventUuid:         event.EventUUID,
+				PlanUuid:          event.PlanUUID,
+				TransactionUuid:   event.TransactionUUID,
+				OrderUuid:         event.OrderUUID,
+				UserUuid:          event.UserUUID,
+				InstallmentNumber: int32(event.InstallmentNumber), //nolint:gosec // число платежей ограничено условиями рассрочки
+				InstallmentsTotal: int32(event.InstallmentsTotal), //nolint:gosec // число платежей ограничено условиями рассрочки
+				Amount:            event.Amount,
+				PaidAmount:        event.PaidAmount,
+				RemainingAmount:   event.RemainingAmount,
+				PlanCompleted:     event.PlanCompleted,
+				PaidAt:            timestamppb.New(event.PaidAt),
+			},
+		},
+	})
+}
+
+// EncodePaymentOverdue кодирует событие PaymentOverdue в конверте PaymentEvent
+func EncodePaymentOverdue(event *events.PaymentOverdueEvent) ([]byte, error) {
+	return protojson.Marshal(&events_v1.PaymentEvent{
+		Event: &events_v1.PaymentEvent_Overdue{
+			Overdue: &events_v1.PaymentOverdue{
+				EventUuid:         event.EventUUID,
+				PlanUuid:          event.PlanUUID,
+				OrderUuid:         event.OrderUUID,
+				UserUuid:          event.UserUUID,
+				InstallmentNumber: int32(event.InstallmentNumber), //nolint:gosec // число платежей ограничено условиями рассрочки
+				Amount:            event.Amount,
+				DueAt:             timestamppb.New(event.DueAt),
+				Reason:            event.Reason,
+				DetectedAt:        timestamppb.New(event.DetectedAt),
+			},
+		},
+	})
+}
+
+// EncodeApprovalRequested кодирует событие ApprovalRequested в конверте PaymentEvent
+func EncodeApprovalRequested(event *events.ApprovalRequestedEvent) ([]byte, error) {
+	return protojson.Marshal(&events_v1.PaymentEvent{
+		Event: &events_v1.PaymentEvent_ApprovalRequested{
+			ApprovalRequested: &events_v1.ApprovalRequested{
+				EventUuid:       event.EventUUID,
+				ApprovalUuid:    event.ApprovalUUID,
+				TransactionUuid: event.TransactionUUID,
+				OrderUuid:       event.OrderUUID,
+				UserUuid:        event.UserUUID,
+				Amount:          event.Amount,
+				Approvers:       event.Approvers,
+				Quorum:          int32(event.Quorum), //nolint:gosec // кворум не больше числа согласующих
+				ExpiresAt:       timestamppb.New(event.ExpiresAt),
+				RequestedAt:     timestamppb.New(event.RequestedAt),
+				SplitUuid:       event.SplitUUID,
+			},
+		},
+	})
+}
@@ -1,7 +1,14 @@
 package v1
 
 import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
 	"github.com/linemk/rocket-shop/payment/internal/usecase"
+	grpcmiddleware "github.com/linemk/rocket-shop/platform/pkg/middleware/grpc"
+	"github.com/linemk/rocket-shop/shared/pkg/iamclient"
 	payment_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/payment/v1"
 )
 
@@ -15,3 +22,13 @@ func NewAPI(paymentUseCase usecase.PaymentUseCase) *API {
 		paymentUseCase: paymentUseCase,
 	}
 }
+
+// sessionUser возвращает пользователя сессии IAM, проверенной interceptor'ом
+func sessionUser(ctx context.Context) (*iamclient.User, error) {
+	user, ok := grpcmiddleware.UserFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "session is required")
+	}
+
+	return user, nil
+}
@@ -0,0 +1,92 @@
+package v1
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/linemk/rocket-shop/payment/internal/converter"
+	"github.com/linemk/rocket-shop/payment/internal/entyties/apperrors"
+	payment_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/payment/v1"
+)
+
+func (a *API) DecideApproval(ctx context.Context, req *payment_v1.DecideApprovalRequest) (*payment_v1.DecideApprovalResponse, error) {
+	if req.ApprovalUuid == "" {
+		return nil, status.Error(codes.InvalidArgument, "approval_uuid is required")
+	}
+
+	approverID, err := sessionApprover(ctx, req.ApproverId)
+	if err != nil {
+		return nil, err
+	}
+
+	approval, err := a.paymentUseCase.DecideApproval(ctx, req.ApprovalUuid, approverID, req.Approved, req.Reason)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrApprovalNotFound):
+			return nil, status.Errorf(codes.NotFound, "Approval not found: %v", err)
+		case errors.Is(err, apperrors.ErrNotApprover):
+			return nil, status.Errorf(codes.PermissionDenied, "Approver is not allowed: %v", err)
+		case errors.Is(err, apperrors.ErrAlreadyDecided):
+			return nil, status.Errorf(codes.AlreadyExists, "Decision already made: %v", err)
+		case errors.Is(err, apperrors.ErrApprovalNotPending):
+			return nil, status.Errorf(codes.FailedPrecondition, "Approval is not pending: %v", err)
+		default:
+			return nil, status.Errorf(codes.Internal, "Failed to decide approval: %v", err)
+		}
+	}
+
+	return &payment_v1.DecideApprovalResponse{
+		Approval: converter.ApprovalToProto(approval),
+	}, nil
+}
+
+func (a *API) GetApproval(ctx context.Context, req *payment_v1.GetApprovalRequest) (*payment_v1.GetApprovalResponse, error) {
+	if req.ApprovalUuid == "" {
+		return nil, status.Error(codes.InvalidArgument, "approval_uuid is required")
+	}
+
+	approval, err := a.paymentUseCase.GetApproval(ctx, req.ApprovalUuid)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrApprovalNotFound) {
+			return nil, status.Errorf(codes.NotFound, "Approval not found: %v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "Failed to get approval: %v", err)
+	}
+
+	return &payment_v1.GetApprovalResponse{
+		Approval: converter.ApprovalToProto(approval),
+	}, nil
+}
+
+func (a *API) ListPendingApprovals(ctx context.Context, req *payment_v1.ListPendingApprovalsRequest) (*payment_v1.ListPendingApprovalsResponse, error) {
+	approverID, err := sessionApprover(ctx, req.ApproverId)
+	if err != nil {
+		return nil, err
+	}
+
+	approvals, err := a.paymentUseCase.ListPendingApprovals(ctx, approverID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to list pending approvals: %v", err)
+	}
+
+	return &payment_v1.ListPendingApprovalsResponse{
+		Approvals: converter.ApprovalsToProto(approvals),
+	}, nil
+}
+
+// sessionApprover возвращает логин согласующего из сессии IAM. approver_id из запроса, если передан, должен с ним совпадать
+func sessionApprover(ctx context.Context, requested string) (string, error) {
+	user, err := sessionUser(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if requested != "" && requested != user.Login {
+		return "", status.Error(codes.PermissionDenied, "approver_id does not match session")
+	}
+
+	return user.Login, nil
+}
@@ -0,0 +1,42 @@
+package v1
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/linemk/rocket-shop/payment/internal/converter"
+	"github.com/linemk/rocket-shop/payment/internal/entyties/apperrors"
+	payment_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/payment/v1"
+)
+
+func (a *API) ConfirmTransaction(ctx context.Context, req *payment_v1.ConfirmTransactionRequest) (*payment_v1.ConfirmTransactionResponse, error) {
+	if req.TransactionUuid == "" {
+		return nil, status.Error(codes.InvalidArgument, "transaction_uuid is required")
+	}
+
+	user, err := sessionUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction, err := a.paymentUseCase.ConfirmTransaction(ctx, req.TransactionUuid, user.UUID, req.Approved, req.Reason)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrTransactionNotFound):
+			return nil, status.Errorf(codes.NotFound, "Transaction not found: %v", err)
+		case errors.Is(err, apperrors.ErrTransactionNotPending), errors.Is(err, apperrors.ErrTransactionInReview),
+			errors.Is(err, apperrors.ErrTransactionInApproval), errors.Is(err, apperrors.ErrTransactionNotApproved),
+			errors.Is(err, apperrors.ErrChargeNotCompleted):
+			return nil, status.Errorf(codes.FailedPrecondition, "Transaction cannot be confirmed: %v", err)
+		default:
+			return nil, status.Errorf(codes.Internal, "Failed to confirm transaction: %v", err)
+		}
+	}
+
+	return &payment_v1.ConfirmTransactionResponse{
+		Transaction: converter.TransactionToProto(transaction),
+	}, nil
+}
@@ -0,0 +1,72 @@
+package v1
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/linemk/rocket-shop/payment/internal/converter"
+	"github.com/linemk/rocket-shop/payment/internal/entyties/apperrors"
+	payment_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/payment/v1"
+)
+
+func (a *API) QuoteInstallments(ctx context.Context, req *payment_v1.QuoteInstallmentsRequest) (*payment_v1.QuoteInstallmentsResponse, error) {
+	quotes, err := a.paymentUseCase.QuoteInstallments(ctx, req.Amount)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrInvalidAmount) {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid amount: %v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "Failed to quote installments: %v", err)
+	}
+
+	return &payment_v1.QuoteInstallmentsResponse{
+		Quotes: converter.InstallmentQuotesToProto(quotes),
+	}, nil
+}
+
+func (a *API) CreateInstallmentPlan(ctx context.Context, req *payment_v1.CreateInstallmentPlanRequest) (*payment_v1.CreateInstallmentPlanResponse, error) {
+	if req.OrderUuid == "" {
+		return nil, status.Error(codes.InvalidArgument, "order_uuid is required")
+	}
+	if req.UserUuid == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_uuid is required")
+	}
+
+	plan, err := a.paymentUseCase.CreateInstallmentPlan(ctx, req.OrderUuid, req.UserUuid, req.Amount, int(req.Installments))
+	if err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrInvalidAmount), errors.Is(err, apperrors.ErrInvalidInstallments):
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid installment plan: %v", err)
+		case errors.Is(err, apperrors.ErrInstallmentPlanExists):
+			return nil, status.Errorf(codes.AlreadyExists, "Installment plan already exists: %v", err)
+		case errors.Is(err, apperrors.ErrInvalidPaymentMethod):
+			return nil, status.Errorf(codes.FailedPrecondition, "Installments are not available: %v", err)
+		default:
+			return nil, status.Errorf(codes.Internal, "Failed to create installment plan: %v", err)
+		}
+	}
+
+	return &payment_v1.CreateInstallmentPlanResponse{
+		Plan: converter.InstallmentPlanToProto(plan),
+	}, nil
+}
+
+func (a *API) GetInstallmentPlan(ctx context.Context, req *payment_v1.GetInstallmentPlanRequest) (*payment_v1.GetInstallmentPlanResponse, error) {
+	if req.PlanUuid == "" {
+		return nil, status.Error(codes.InvalidArgument, "plan_uuid is required")
+	}
+
+	plan, err := a.paymentUseCase.GetInstallmentPlan(ctx, req.PlanUuid)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrInstallmentPlanNotFound) {
+			return nil, status.Errorf(codes.NotFound, "Installment plan not found: %v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "Failed to get installment plan: %v", err)
+	}
+
+	return &payment_v1.GetInstallmentPlanResponse{
+		Plan: converter.InstallmentPlanToProto(plan),
+	}, nil
+}
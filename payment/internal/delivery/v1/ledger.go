@@ -0,0 +1,72 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/linemk/rocket-shop/payment/internal/converter"
+	"github.com/linemk/rocket-shop/payment/internal/entyties/apperrors"
+	payment_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/payment/v1"
+)
+
+func (a *API) ListLedgerAccounts(ctx context.Context, _ *payment_v1.ListLedgerAccountsRequest) (*payment_v1.ListLedgerAccountsResponse, error) {
+	accounts, err := a.paymentUseCase.ListLedgerAccounts(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list ledger accounts: %v", err)
+	}
+
+	return &payment_v1.ListLedgerAccountsResponse{
+		Accounts: converter.LedgerAccountsToProto(accounts),
+	}, nil
+}
+
+func (a *API) GetAccountBalance(ctx context.Context, req *payment_v1.GetAccountBalanceRequest) (*payment_v1.GetAccountBalanceResponse, error) {
+	if req.AccountCode == "" {
+		return nil, status.Error(codes.InvalidArgument, "account_code is required")
+	}
+
+	balance, err := a.paymentUseCase.GetAccountBalance(ctx, req.AccountCode)
+	if err != nil {
+		return nil, ledgerError(err)
+	}
+
+	return &payment_v1.GetAccountBalanceResponse{
+		Balance: converter.AccountBalanceToProto(balance),
+	}, nil
+}
+
+func (a *API) GetAccountStatement(ctx context.Context, req *payment_v1.GetAccountStatementRequest) (*payment_v1.GetAccountStatementResponse, error) {
+	if req.AccountCode == "" {
+		return nil, status.Error(codes.InvalidArgument, "account_code is required")
+	}
+
+	var from, to time.Time
+	if req.From != nil {
+		from = req.From.AsTime()
+	}
+	if req.To != nil {
+		to = req.To.AsTime()
+	}
+
+	statement, err := a.paymentUseCase.GetAccountStatement(ctx, req.AccountCode, from, to)
+	if err != nil {
+		return nil, ledgerError(err)
+	}
+
+	return converter.AccountStatementToProto(statement), nil
+}
+
+func ledgerError(err error) error {
+	switch {
+	case errors.Is(err, apperrors.ErrAccountNotFound):
+		return status.Errorf(codes.NotFound, "Ledger account not found: %v", err)
+	case errors.Is(err, apperrors.ErrInvalidPeriod):
+		return status.Errorf(codes.InvalidArgument, "Invalid statement period: %v", err)
+	default:
+		return status.Errorf(codes.Internal, "Failed to read ledger: %v", err)
+	}
+}
@@ -0,0 +1,26 @@
+package v1
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/linemk/rocket-shop/payment/internal/converter"
+	payment_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/payment/v1"
+)
+
+func (a *API) ListTransactions(ctx context.Context, req *payment_v1.ListTransactionsRequest) (*payment_v1.ListTransactionsResponse, error) {
+	if req.OrderUuid == "" {
+		return nil, status.Error(codes.InvalidArgument, "order_uuid is required")
+	}
+
+	transactions, err := a.paymentUseCase.ListTransactions(ctx, req.OrderUuid)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list transactions: %v", err)
+	}
+
+	return &payment_v1.ListTransactionsResponse{
+		Transactions: converter.TransactionsToProto(transactions),
+	}, nil
+}
@@ -0,0 +1,26 @@
+package v1
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/linemk/rocket-shop/payment/internal/converter"
+	payment_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/payment/v1"
+)
+
+func (a *API) ListUserTransactions(ctx context.Context, req *payment_v1.ListUserTransactionsRequest) (*payment_v1.ListUserTransactionsResponse, error) {
+	if req.UserUuid == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_uuid is required")
+	}
+
+	transactions, err := a.paymentUseCase.ListUserTransactions(ctx, req.UserUuid)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list transactions: %v", err)
+	}
+
+	return &payment_v1.ListUserTransactionsResponse{
+		Transactions: converter.TransactionsToProto(transactions),
+	}, nil
+}
@@ -2,20 +2,34 @@ package v1
 
 import (
 	"context"
+	"errors"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"github.com/linemk/rocket-shop/payment/internal/entyties/apperrors"
 	payment_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/payment/v1"
 )
 
 func (a *API) PayOrder(ctx context.Context, req *payment_v1.PayOrderRequest) (*payment_v1.PayOrderResponse, error) {
-	transactionUUID, err := a.paymentUseCase.PayOrder(ctx, req.OrderUuid, req.UserUuid, req.PaymentMethod)
+	transaction, err := a.paymentUseCase.PayOrder(ctx, req.OrderUuid, req.UserUuid, req.PaymentMethod, req.Amount, req.IdempotencyKey)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Payment failed: %v", err)
+		switch {
+		case errors.Is(err, apperrors.ErrInvalidAmount), errors.Is(err, apperrors.ErrInvalidPaymentMethod), errors.Is(err, apperrors.ErrInvalidIdempotencyKey):
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid payment request: %v", err)
+		case errors.Is(err, apperrors.ErrTransactionAlreadyExists):
+			return nil, status.Errorf(codes.AlreadyExists, "Payment rejected: %v", err)
+		case errors.Is(err, apperrors.ErrPaymentDeclined):
+			return nil, status.Errorf(codes.FailedPrecondition, "Payment declined: %v", err)
+		case errors.Is(err, apperrors.ErrPaymentTimeout):
+			return nil, status.Errorf(codes.DeadlineExceeded, "Payment cancelled: %v", err)
+		default:
+			return nil, status.Errorf(codes.Internal, "Payment failed: %v", err)
+		}
 	}
 
 	return &payment_v1.PayOrderResponse{
-		TransactionUuid: transactionUUID,
+		TransactionUuid: transaction.UUID,
+		Status:          string(transaction.Status),
 	}, nil
 }
@@ -0,0 +1,33 @@
+package v1
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/linemk/rocket-shop/payment/internal/converter"
+	"github.com/linemk/rocket-shop/payment/internal/entyties/apperrors"
+	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
+	payment_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/payment/v1"
+)
+
+func (a *API) PaySplit(ctx context.Context, req *payment_v1.PaySplitRequest) (*payment_v1.PaySplitResponse, error) {
+	splitUUID, transactions, err := a.paymentUseCase.PaySplit(ctx, req.GetOrderUuid(), req.GetUserUuid(), converter.ProtoToTenders(req.GetTenders()), req.GetAmount(), req.GetIdempotencyKey())
+	if err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrInvalidAmount), errors.Is(err, apperrors.ErrInvalidPaymentMethod), errors.Is(err, apperrors.ErrInvalidSplit),
+			errors.Is(err, apperrors.ErrInvalidIdempotencyKey):
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid split payment request: %v", err)
+		default:
+			return nil, status.Errorf(codes.Internal, "Split payment failed: %v", err)
+		}
+	}
+
+	return &payment_v1.PaySplitResponse{
+		SplitUuid:    splitUUID,
+		Status:       string(models.SplitStatus(transactions)),
+		Transactions: converter.TransactionsToProto(transactions),
+	}, nil
+}
@@ -0,0 +1,55 @@
+package v1
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/linemk/rocket-shop/payment/internal/converter"
+	"github.com/linemk/rocket-shop/payment/internal/entyties/apperrors"
+	payment_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/payment/v1"
+)
+
+func (a *API) ReviewTransaction(ctx context.Context, req *payment_v1.ReviewTransactionRequest) (*payment_v1.ReviewTransactionResponse, error) {
+	if req.TransactionUuid == "" {
+		return nil, status.Error(codes.InvalidArgument, "transaction_uuid is required")
+	}
+
+	reviewer, err := sessionUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction, err := a.paymentUseCase.ReviewTransaction(ctx, req.TransactionUuid, reviewer.Login, req.Approved, req.Reason)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrTransactionNotFound):
+			return nil, status.Errorf(codes.NotFound, "Transaction not found: %v", err)
+		case errors.Is(err, apperrors.ErrTransactionNotInReview):
+			return nil, status.Errorf(codes.FailedPrecondition, "Transaction cannot be reviewed: %v", err)
+		default:
+			return nil, status.Errorf(codes.Internal, "Failed to review transaction: %v", err)
+		}
+	}
+
+	return &payment_v1.ReviewTransactionResponse{
+		Transaction: converter.TransactionToProto(transaction),
+	}, nil
+}
+
+func (a *API) ListReviewQueue(ctx context.Context, _ *payment_v1.ListReviewQueueRequest) (*payment_v1.ListReviewQueueResponse, error) {
+	if _, err := sessionUser(ctx); err != nil {
+		return nil, err
+	}
+
+	transactions, err := a.paymentUseCase.ListReviewQueue(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list review queue: %v", err)
+	}
+
+	return &payment_v1.ListReviewQueueResponse{
+		Transactions: converter.TransactionsToProto(transactions),
+	}, nil
+}
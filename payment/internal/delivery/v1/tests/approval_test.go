@@ -0,0 +1,128 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	v1 "github.com/linemk/rocket-shop/payment/internal/delivery/v1"
+	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
+	"github.com/linemk/rocket-shop/payment/internal/mocks"
+	grpcmiddleware "github.com/linemk/rocket-shop/platform/pkg/middleware/grpc"
+	"github.com/linemk/rocket-shop/shared/pkg/iamclient"
+	payment_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/payment/v1"
+)
+
+const approverSession = "approver-session"
+
+type approverResolver struct{}
+
+func (approverResolver) Resolve(_ context.Context, sessionUUID string) (*iamclient.User, error) {
+	if sessionUUID != approverSession {
+		return nil, iamclient.ErrInvalidSession
+	}
+
+	return &iamclient.User{UUID: uuid.New().String(), Login: "finance"}, nil
+}
+
+// callWithSession вызывает метод API через interceptor, которым сервер проверяет сессии согласующих
+func callWithSession(ctx context.Context, method string, call func(ctx context.Context) (interface{}, error)) error {
+	interceptor := grpcmiddleware.UnaryMethodsSessionInterceptor(approverResolver{}, method)
+	_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, _ interface{}) (interface{}, error) {
+		return call(ctx)
+	})
+
+	return err
+}
+
+func TestDecideApprovalAPI(t *testing.T) {
+	approvalUUID := uuid.New().String()
+	withSession := metadata.NewIncomingContext(context.Background(), metadata.Pairs(grpcmiddleware.SessionUUIDHeader, approverSession))
+
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		approverID string
+		useCase    func(ctrl *gomock.Controller) *mocks.MockPaymentUseCase
+		wantCode   codes.Code
+	}{
+		{
+			name: "approver is taken from session",
+			ctx:  withSession,
+			useCase: func(ctrl *gomock.Controller) *mocks.MockPaymentUseCase {
+				mockUseCase := mocks.NewMockPaymentUseCase(ctrl)
+				mockUseCase.EXPECT().DecideApproval(gomock.Any(), approvalUUID, "finance", true, "").
+					Return(models.Approval{UUID: approvalUUID, Status: models.ApprovalStatusPending}, nil)
+				return mockUseCase
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name:       "matching approver id is accepted",
+			ctx:        withSession,
+			approverID: "finance",
+			useCase: func(ctrl *gomock.Controller) *mocks.MockPaymentUseCase {
+				mockUseCase := mocks.NewMockPaymentUseCase(ctrl)
+				mockUseCase.EXPECT().DecideApproval(gomock.Any(), approvalUUID, "finance", true, "").
+					Return(models.Approval{UUID: approvalUUID, Status: models.ApprovalStatusPending}, nil)
+				return mockUseCase
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name:       "approver id of another user is rejected",
+			ctx:        withSession,
+			approverID: "risk",
+			useCase:    mocks.NewMockPaymentUseCase,
+			wantCode:   codes.PermissionDenied,
+		},
+		{
+			name:       "request without session is rejected",
+			ctx:        context.Background(),
+			approverID: "finance",
+			useCase:    mocks.NewMockPaymentUseCase,
+			wantCode:   codes.Unauthenticated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := v1.NewAPI(tt.useCase(gomock.NewController(t)))
+
+			err := callWithSession(tt.ctx, payment_v1.PaymentService_DecideApproval_FullMethodName, func(ctx context.Context) (interface{}, error) {
+				return api.DecideApproval(ctx, &payment_v1.DecideApprovalRequest{
+					ApprovalUuid: approvalUUID,
+					ApproverId:   tt.approverID,
+					Approved:     true,
+				})
+			})
+
+			require.Equal(t, tt.wantCode, status.Code(err))
+		})
+	}
+}
+
+func TestListPendingApprovalsAPI(t *testing.T) {
+	withSession := metadata.NewIncomingContext(context.Background(), metadata.Pairs(grpcmiddleware.SessionUUIDHeader, approverSession))
+
+	mockUseCase := mocks.NewMockPaymentUseCase(gomock.NewController(t))
+	mockUseCase.EXPECT().ListPendingApprovals(gomock.Any(), "finance").Return([]models.Approval{{UUID: uuid.New().String()}}, nil)
+	api := v1.NewAPI(mockUseCase)
+
+	var resp *payment_v1.ListPendingApprovalsResponse
+	err := callWithSession(withSession, payment_v1.PaymentService_ListPendingApprovals_FullMethodName, func(ctx context.Context) (interface{}, error) {
+		var err error
+		resp, err = api.ListPendingApprovals(ctx, &payment_v1.ListPendingApprovalsRequest{})
+		return resp, err
+	})
+
+	require.NoError(t, err)
+	require.Len(t, resp.Approvals, 1)
+}
@@ -8,8 +8,12 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	v1 "github.com/linemk/rocket-shop/payment/internal/delivery/v1"
+	"github.com/linemk/rocket-shop/payment/internal/entyties/apperrors"
+	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
 	"github.com/linemk/rocket-shop/payment/internal/mocks"
 	payment_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/payment/v1"
 )
@@ -28,19 +32,25 @@ func TestPayOrderAPI(t *testing.T) {
 	orderUUID := uuid.New().String()
 	userUUID := uuid.New().String()
 	transactionUUID := uuid.New().String()
+	amount := 1500.0
 
 	tests := []struct {
-		name    string
-		fields  fields
-		args    args
-		wantErr bool
+		name       string
+		fields     fields
+		args       args
+		wantErr    bool
+		wantCode   codes.Code
+		wantStatus string
 	}{
 		{
 			name: "successfully pay order via API",
 			fields: fields{
 				useCaseMock: func() *mocks.MockPaymentUseCase {
 					mockUseCase := mocks.NewMockPaymentUseCase(gomock.NewController(t))
-					mockUseCase.EXPECT().PayOrder(ctx, orderUUID, userUUID, payment_v1.PaymentMethod_PAYMENT_METHOD_CARD).Return(transactionUUID, nil)
+					mockUseCase.EXPECT().PayOrder(ctx, orderUUID, userUUID, payment_v1.PaymentMethod_PAYMENT_METHOD_CARD, amount, gomock.Any()).Return(models.Transaction{
+						UUID:   transactionUUID,
+						Status: models.TransactionStatusCompleted,
+					}, nil)
 					return mockUseCase
 				},
 			},
@@ -49,16 +59,41 @@ func TestPayOrderAPI(t *testing.T) {
 					OrderUuid:     orderUUID,
 					UserUuid:      userUUID,
 					PaymentMethod: payment_v1.PaymentMethod_PAYMENT_METHOD_CARD,
+					Amount:        amount,
 				},
 			},
-			wantErr: false,
+			wantErr:    false,
+			wantStatus: string(models.TransactionStatusCompleted),
+		},
+		{
+			name: "pay order awaiting confirmation via API",
+			fields: fields{
+				useCaseMock: func() *mocks.MockPaymentUseCase {
+					mockUseCase := mocks.NewMockPaymentUseCase(gomock.NewController(t))
+					mockUseCase.EXPECT().PayOrder(ctx, orderUUID, userUUID, payment_v1.PaymentMethod_PAYMENT_METHOD_CARD, amount, gomock.Any()).Return(models.Transaction{
+						UUID:   transactionUUID,
+						Status: models.TransactionStatusPending,
+					}, nil)
+					return mockUseCase
+				},
+			},
+			args: args{
+				req: &payment_v1.PayOrderRequest{
+					OrderUuid:     orderUUID,
+					UserUuid:      userUUID,
+					PaymentMethod: payment_v1.PaymentMethod_PAYMENT_METHOD_CARD,
+					Amount:        amount,
+				},
+			},
+			wantErr:    false,
+			wantStatus: string(models.TransactionStatusPending),
 		},
 		{
 			name: "pay order with empty orderUUID",
 			fields: fields{
 				useCaseMock: func() *mocks.MockPaymentUseCase {
 					mockUseCase := mocks.NewMockPaymentUseCase(gomock.NewController(t))
-					mockUseCase.EXPECT().PayOrder(ctx, "", userUUID, gomock.Any()).Return("", fmt.Errorf("invalid order UUID")).AnyTimes()
+					mockUseCase.EXPECT().PayOrder(ctx, "", userUUID, gomock.Any(), gomock.Any(), gomock.Any()).Return(models.Transaction{}, apperrors.ErrInvalidAmount).AnyTimes()
 					return mockUseCase
 				},
 			},
@@ -69,14 +104,15 @@ func TestPayOrderAPI(t *testing.T) {
 					PaymentMethod: payment_v1.PaymentMethod_PAYMENT_METHOD_CARD,
 				},
 			},
-			wantErr: true,
+			wantErr:  true,
+			wantCode: codes.InvalidArgument,
 		},
 		{
 			name: "pay order with empty userUUID",
 			fields: fields{
 				useCaseMock: func() *mocks.MockPaymentUseCase {
 					mockUseCase := mocks.NewMockPaymentUseCase(gomock.NewController(t))
-					mockUseCase.EXPECT().PayOrder(ctx, orderUUID, "", gomock.Any()).Return("", fmt.Errorf("invalid user UUID")).AnyTimes()
+					mockUseCase.EXPECT().PayOrder(ctx, orderUUID, "", gomock.Any(), gomock.Any(), gomock.Any()).Return(models.Transaction{}, apperrors.ErrInvalidAmount).AnyTimes()
 					return mockUseCase
 				},
 			},
@@ -87,7 +123,54 @@ func TestPayOrderAPI(t *testing.T) {
 					PaymentMethod: payment_v1.PaymentMethod_PAYMENT_METHOD_CARD,
 				},
 			},
-			wantErr: true,
+			wantErr:  true,
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "pay order declined by provider",
+			fields: fields{
+				useCaseMock: func() *mocks.MockPaymentUseCase {
+					mockUseCase := mocks.NewMockPaymentUseCase(gomock.NewController(t))
+					mockUseCase.EXPECT().PayOrder(ctx, orderUUID, userUUID, gomock.Any(), amount, gomock.Any()).Return(models.Transaction{
+						UUID:   transactionUUID,
+						Status: models.TransactionStatusFailed,
+					}, fmt.Errorf("%w: amount exceeds limit", apperrors.ErrPaymentDeclined))
+					return mockUseCase
+				},
+			},
+			args: args{
+				req: &payment_v1.PayOrderRequest{
+					OrderUuid:     orderUUID,
+					UserUuid:      userUUID,
+					PaymentMethod: payment_v1.PaymentMethod_PAYMENT_METHOD_CARD,
+					Amount:        amount,
+				},
+			},
+			wantErr:  true,
+			wantCode: codes.FailedPrecondition,
+		},
+		{
+			name: "pay order cancelled by provider timeout",
+			fields: fields{
+				useCaseMock: func() *mocks.MockPaymentUseCase {
+					mockUseCase := mocks.NewMockPaymentUseCase(gomock.NewController(t))
+					mockUseCase.EXPECT().PayOrder(ctx, orderUUID, userUUID, gomock.Any(), amount, gomock.Any()).Return(models.Transaction{
+						UUID:   transactionUUID,
+						Status: models.TransactionStatusCancelled,
+					}, apperrors.ErrPaymentTimeout)
+					return mockUseCase
+				},
+			},
+			args: args{
+				req: &payment_v1.PayOrderRequest{
+					OrderUuid:     orderUUID,
+					UserUuid:      userUUID,
+					PaymentMethod: payment_v1.PaymentMethod_PAYMENT_METHOD_CARD,
+					Amount:        amount,
+				},
+			},
+			wantErr:  true,
+			wantCode: codes.DeadlineExceeded,
 		},
 	}
 
@@ -100,12 +183,14 @@ func TestPayOrderAPI(t *testing.T) {
 
 			if tt.wantErr {
 				require.Error(t, err)
+				require.Equal(t, tt.wantCode, status.Code(err))
 				return
 			}
 
 			require.NoError(t, err)
 			require.NotNil(t, resp)
 			require.Equal(t, transactionUUID, resp.TransactionUuid)
+			require.Equal(t, tt.wantStatus, resp.Status)
 		})
 	}
 }
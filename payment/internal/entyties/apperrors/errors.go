@@ -8,4 +8,27 @@ var (
 	ErrPaymentFailed            = errors.New("payment failed")
 	ErrTransactionAlreadyExists = errors.New("transaction already exists")
 	ErrInvalidAmount            = errors.New("invalid amount")
+	ErrPaymentDeclined          = errors.New("payment declined")
+	ErrPaymentTimeout           = errors.New("payment provider timeout")
+	ErrTransactionNotPending    = errors.New("transaction is not awaiting confirmation")
+	ErrTransactionInReview      = errors.New("transaction is awaiting fraud review")
+	ErrTransactionNotInReview   = errors.New("transaction is not awaiting fraud review")
+	ErrAccountNotFound          = errors.New("ledger account not found")
+	ErrUnbalancedEntry          = errors.New("journal entry postings are not balanced")
+	ErrInvalidPosting           = errors.New("invalid journal entry posting")
+	ErrEntryAlreadyExists       = errors.New("journal entry already exists")
+	ErrInvalidPeriod            = errors.New("invalid statement period")
+	ErrInvalidInstallments      = errors.New("installment plan with this number of payments is not available")
+	ErrInstallmentPlanNotFound  = errors.New("installment plan not found")
+	ErrInstallmentPlanExists    = errors.New("order already has an installment plan")
+	ErrApprovalNotFound         = errors.New("approval request not found")
+	ErrApprovalNotPending       = errors.New("approval request is already resolved")
+	ErrNotApprover              = errors.New("approver is not allowed to decide on this payment")
+	ErrAlreadyDecided           = errors.New("approver has already decided on this payment")
+	ErrTransactionInApproval    = errors.New("transaction is awaiting approval")
+	ErrTransactionNotApproved   = errors.New("transaction was not approved")
+	ErrChargeNotCompleted       = errors.New("charge is not completed at payment provider")
+	ErrChargeNotFound           = errors.New("charge not found at payment provider")
+	ErrInvalidSplit             = errors.New("split payment needs at least two tenders with positive amounts that sum to the order amount")
+	ErrInvalidIdempotencyKey    = errors.New("idempotency key must be a UUID")
 )
@@ -0,0 +1,77 @@
+package events
+
+import "time"
+
+// UserErasedEvent представляет событие об удалении персональных данных пользователя
+type UserErasedEvent struct {
+	EventUUID string
+	UserUUID  string
+	ErasedAt  time.Time
+}
+
+// PaymentCompletedEvent представляет событие об успешном списании средств
+type PaymentCompletedEvent struct {
+	EventUUID       string
+	TransactionUUID string
+	OrderUUID       string
+	UserUUID        string
+	PaymentMethod   string
+	Amount          float64
+	CompletedAt     time.Time
+}
+
+// PaymentFailedEvent представляет событие о неуспешном платеже
+type PaymentFailedEvent struct {
+	EventUUID       string
+	TransactionUUID string
+	OrderUUID       string
+	UserUUID        string
+	Status          string
+	Reason          string
+	FailedAt        time.Time
+}
+
+// InstallmentPaidEvent представляет событие об оплате платежа по графику рассрочки
+type InstallmentPaidEvent struct {
+	EventUUID         string
+	PlanUUID          string
+	TransactionUUID   string
+	OrderUUID         string
+	UserUUID          string
+	InstallmentNumber int
+	InstallmentsTotal int
+	Amount            float64
+	PaidAmount        float64
+	RemainingAmount   float64
+	PlanCompleted     bool
+	PaidAt            time.Time
+}
+
+// PaymentOverdueEvent представляет событие о просрочке платежа по графику рассрочки
+type PaymentOverdueEvent struct {
+	EventUUID         string
+	PlanUUID          string
+	OrderUUID         string
+	UserUUID          string
+	InstallmentNumber int
+	Amount            float64
+	DueAt             time.Time
+	Reason            string
+	DetectedAt        time.Time
+}
+
+// ApprovalRequestedEvent представляет событие о запросе на согласование платежа средствами инвестора
+type ApprovalRequestedEvent struct {
+	EventUUID       string
+	ApprovalUUID    string
+	TransactionUUID string
+	OrderUUID       string
+	UserUUID        string
+	Amount          float64
+	Approvers       []string
+	Quorum          int
+	ExpiresAt       time.Time
+	RequestedAt     time.Time
+	// SplitUUID группа раздельной оплаты, если согласуемый платеж - часть оплаты заказа
+	SplitUUID string
+}
@@ -0,0 +1,85 @@
+package models
+
+import (
+	"time"
+
+	payment_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/payment/v1"
+)
+
+// ApprovalPaymentMethod способ оплаты, платежи которым списываются только после согласования
+const ApprovalPaymentMethod = payment_v1.PaymentMethod_PAYMENT_METHOD_INVESTOR_MONEY
+
+// ApprovalStatus представляет статус запроса на согласование платежа
+type ApprovalStatus string
+
+const (
+	// ApprovalStatusPending запрос ожидает решений согласующих
+	ApprovalStatusPending ApprovalStatus = "PENDING"
+	// ApprovalStatusApproved набран кворум одобрений, платеж передан провайдеру
+	ApprovalStatusApproved ApprovalStatus = "APPROVED"
+	// ApprovalStatusRejected кворум больше не может быть набран, платеж отклонен
+	ApprovalStatusRejected ApprovalStatus = "REJECTED"
+	// ApprovalStatusExpired кворум не набран до срока, платеж отменен
+	ApprovalStatusExpired ApprovalStatus = "EXPIRED"
+)
+
+// Approval представляет запрос на согласование платежа средствами инвестора
+type Approval struct {
+	UUID            string
+	TransactionUUID string
+	OrderUUID       string
+	UserID          string
+	Amount          float64
+	// Approvers согласующие, которые могут принять решение
+	Approvers []string
+	// Quorum число одобрений, необходимое для списания
+	Quorum int
+	Status ApprovalStatus
+	// Decisions решения согласующих в порядке поступления
+	Decisions []ApprovalDecision
+	// ExpiresAt срок, после которого несогласованный платеж отменяется
+	ExpiresAt time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// ApprovalDecision представляет решение согласующего
+type ApprovalDecision struct {
+	ApproverID string
+	Approved   bool
+	Reason     string
+	DecidedAt  time.Time
+}
+
+// IsApprover проверяет, входит ли approverID в число согласующих запроса
+func (a Approval) IsApprover(approverID string) bool {
+	for _, approver := range a.Approvers {
+		if approver == approverID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Outcome возвращает итог согласования по принятым решениям: APPROVED при наборе кворума,
+// REJECTED, если оставшихся согласующих уже не хватит для кворума, иначе PENDING
+func (a Approval) Outcome() ApprovalStatus {
+	approvals, rejections := 0, 0
+	for _, decision := range a.Decisions {
+		if decision.Approved {
+			approvals++
+		} else {
+			rejections++
+		}
+	}
+
+	switch {
+	case approvals >= a.Quorum:
+		return ApprovalStatusApproved
+	case len(a.Approvers)-rejections < a.Quorum:
+		return ApprovalStatusRejected
+	default:
+		return ApprovalStatusPending
+	}
+}
@@ -0,0 +1,55 @@
+package models
+
+import (
+	"time"
+
+	payment_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/payment/v1"
+)
+
+// FraudDecision представляет решение антифрод-проверки платежа
+type FraudDecision string
+
+const (
+	// FraudDecisionApprove платеж передается провайдеру для списания
+	FraudDecisionApprove FraudDecision = "APPROVE"
+	// FraudDecisionReview платеж остается PENDING до ручной проверки
+	FraudDecisionReview FraudDecision = "REVIEW"
+	// FraudDecisionDecline платеж отклоняется без обращения к провайдеру
+	FraudDecisionDecline FraudDecision = "DECLINE"
+)
+
+// fraudDecisionSeverity задает порядок строгости решений
+var fraudDecisionSeverity = map[FraudDecision]int{
+	FraudDecisionApprove: 0,
+	FraudDecisionReview:  1,
+	FraudDecisionDecline: 2,
+}
+
+// StricterThan сообщает, строже ли решение, чем other
+func (d FraudDecision) StricterThan(other FraudDecision) bool {
+	return fraudDecisionSeverity[d] > fraudDecisionSeverity[other]
+}
+
+// FraudCheck представляет платеж, передаваемый на антифрод-проверку
+type FraudCheck struct {
+	TransactionUUID string
+	UserID          string
+	PaymentMethod   payment_v1.PaymentMethod
+	Amount          float64
+	At              time.Time
+}
+
+// FraudUserStats представляет накопленную историю платежей пользователя
+type FraudUserStats struct {
+	// RecentPayments число попыток оплаты за окно проверки частоты, включая текущую
+	RecentPayments int
+	// FirstSeenAt время первой попытки оплаты пользователя
+	FirstSeenAt time.Time
+}
+
+// FraudVerdict представляет итог антифрод-проверки
+type FraudVerdict struct {
+	Decision FraudDecision
+	// Reasons описания сработавших правил
+	Reasons []string
+}
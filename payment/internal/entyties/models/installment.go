@@ -0,0 +1,131 @@
+package models
+
+import (
+	"math"
+	"time"
+
+	payment_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/payment/v1"
+)
+
+// InstallmentPaymentMethod способ оплаты, для которого доступна рассрочка
+const InstallmentPaymentMethod = payment_v1.PaymentMethod_PAYMENT_METHOD_CREDIT_CARD
+
+// InstallmentPlanStatus представляет статус плана рассрочки
+type InstallmentPlanStatus string
+
+const (
+	// InstallmentPlanStatusActive план создан, не все платежи оплачены
+	InstallmentPlanStatusActive InstallmentPlanStatus = "ACTIVE"
+	// InstallmentPlanStatusCompleted все платежи плана оплачены
+	InstallmentPlanStatusCompleted InstallmentPlanStatus = "COMPLETED"
+	// InstallmentPlanStatusCancelled первый платеж не прошел, план не действует
+	InstallmentPlanStatusCancelled InstallmentPlanStatus = "CANCELLED"
+)
+
+// InstallmentStatus представляет статус платежа по графику рассрочки
+type InstallmentStatus string
+
+const (
+	// InstallmentStatusScheduled платеж ожидает срока или повторной попытки списания
+	InstallmentStatusScheduled InstallmentStatus = "SCHEDULED"
+	// InstallmentStatusPaid платеж списан
+	InstallmentStatusPaid InstallmentStatus = "PAID"
+	// InstallmentStatusOverdue платеж не удалось списать в течение льготного периода после срока
+	InstallmentStatusOverdue InstallmentStatus = "OVERDUE"
+)
+
+// InstallmentQuote представляет расчет плана рассрочки
+type InstallmentQuote struct {
+	Installments int
+	// MonthlyRate месячная процентная ставка (0.01 = 1%)
+	MonthlyRate float64
+	// Payments суммы платежей по порядку
+	Payments    []float64
+	TotalAmount float64
+	Overpayment float64
+}
+
+// InstallmentPlan представляет план рассрочки заказа
+type InstallmentPlan struct {
+	UUID          string
+	OrderUUID     string
+	UserID        string
+	PaymentMethod payment_v1.PaymentMethod
+	// Principal сумма заказа
+	Principal    float64
+	MonthlyRate  float64
+	TotalAmount  float64
+	Status       InstallmentPlanStatus
+	Installments []Installment
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// PaidAmount возвращает сумму оплаченных платежей
+func (p InstallmentPlan) PaidAmount() float64 {
+	var paid int64
+	for _, installment := range p.Installments {
+		if installment.Status == InstallmentStatusPaid {
+			paid += ToMinorUnits(installment.Amount)
+		}
+	}
+
+	return FromMinorUnits(paid)
+}
+
+// Completed сообщает, оплачены ли все платежи плана
+func (p InstallmentPlan) Completed() bool {
+	for _, installment := range p.Installments {
+		if installment.Status != InstallmentStatusPaid {
+			return false
+		}
+	}
+
+	return len(p.Installments) > 0
+}
+
+// Installment представляет платеж по графику рассрочки
+type Installment struct {
+	PlanUUID string
+	// Number номер платежа, начиная с 1
+	Number int
+	Amount float64
+	DueAt  time.Time
+	Status InstallmentStatus
+	// TransactionUUID транзакция последней попытки списания, сохраняется до обращения к провайдеру
+	TransactionUUID string
+	Attempts        int
+	// NextAttemptAt время, не раньше которого платеж будет списан или списан повторно
+	NextAttemptAt time.Time
+	PaidAt        time.Time
+	FailureReason string
+}
+
+// InstallmentSchedule рассчитывает аннуитетные платежи в копейках.
+// Округление копеек переносится в последний платеж, чтобы сумма платежей совпадала с расчетной
+func InstallmentSchedule(principal int64, installments int, monthlyRate float64) []int64 {
+	if installments <= 0 {
+		return nil
+	}
+
+	payment := float64(principal) / float64(installments)
+	if monthlyRate > 0 {
+		payment = float64(principal) * monthlyRate / (1 - math.Pow(1+monthlyRate, -float64(installments)))
+	}
+
+	regular := int64(math.Round(payment))
+	total := int64(math.Round(payment * float64(installments)))
+
+	schedule := make([]int64, installments)
+	for i := range schedule {
+		schedule[i] = regular
+	}
+	schedule[installments-1] = total - regular*int64(installments-1)
+
+	return schedule
+}
+
+// FromMinorUnits переводит сумму в копейках в рубли
+func FromMinorUnits(amount int64) float64 {
+	return float64(amount) / 100
+}
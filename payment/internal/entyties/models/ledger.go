@@ -0,0 +1,129 @@
+package models
+
+import (
+	"math"
+	"time"
+)
+
+// AccountType тип счета бухгалтерской книги
+type AccountType string
+
+const (
+	AccountTypeAsset     AccountType = "ASSET"
+	AccountTypeLiability AccountType = "LIABILITY"
+	AccountTypeEquity    AccountType = "EQUITY"
+	AccountTypeRevenue   AccountType = "REVENUE"
+	AccountTypeExpense   AccountType = "EXPENSE"
+)
+
+// Коды системных счетов, создаваемых миграцией
+const (
+	// AccountProviderClearingPrefix префикс счетов расчетов с провайдерами, к нему добавляется способ оплаты
+	AccountProviderClearingPrefix = "provider_clearing:"
+	AccountInvestorFunds          = "investor_funds"
+	AccountSalesRevenue           = "sales_revenue"
+	AccountProviderFees           = "provider_fees"
+)
+
+// EntryType тип записи журнала
+type EntryType string
+
+const (
+	EntryTypeCharge     EntryType = "CHARGE"
+	EntryTypeRefund     EntryType = "REFUND"
+	EntryTypeFee        EntryType = "FEE"
+	EntryTypeSettlement EntryType = "SETTLEMENT"
+)
+
+// Direction сторона проводки
+type Direction string
+
+const (
+	DirectionDebit  Direction = "DEBIT"
+	DirectionCredit Direction = "CREDIT"
+)
+
+// LedgerAccount счет бухгалтерской книги
+type LedgerAccount struct {
+	Code      string
+	Name      string
+	Type      AccountType
+	CreatedAt time.Time
+}
+
+// DebitNormal сообщает, увеличивается ли остаток счета по дебету (активы и расходы)
+func (a LedgerAccount) DebitNormal() bool {
+	return a.Type == AccountTypeAsset || a.Type == AccountTypeExpense
+}
+
+// Balance возвращает сальдо по оборотам со стороны нормального остатка счета
+func (a LedgerAccount) Balance(totals PostingTotals) int64 {
+	if a.DebitNormal() {
+		return totals.Debit - totals.Credit
+	}
+
+	return totals.Credit - totals.Debit
+}
+
+// JournalEntry неизменяемая запись журнала. Сумма дебетовых проводок записи равна сумме кредитовых
+type JournalEntry struct {
+	UUID string
+	Type EntryType
+	// TransactionUUID платежная транзакция, породившая запись; вместе с Type обеспечивает идемпотентность
+	TransactionUUID string
+	Description     string
+	CreatedAt       time.Time
+	Postings        []Posting
+}
+
+// Posting проводка по одному счету. Amount в копейках, всегда положительный
+type Posting struct {
+	AccountCode string
+	Direction   Direction
+	Amount      int64
+}
+
+// PostingTotals обороты счета в копейках
+type PostingTotals struct {
+	Debit  int64
+	Credit int64
+}
+
+// AccountBalance обороты и сальдо счета
+type AccountBalance struct {
+	Account LedgerAccount
+	Totals  PostingTotals
+	Balance int64
+}
+
+// AccountPosting проводка по счету вместе с данными записи журнала
+type AccountPosting struct {
+	EntryUUID       string
+	EntryType       EntryType
+	TransactionUUID string
+	Description     string
+	Direction       Direction
+	Amount          int64
+	CreatedAt       time.Time
+}
+
+// StatementLine строка выписки с сальдо счета после проводки
+type StatementLine struct {
+	AccountPosting
+	Balance int64
+}
+
+// AccountStatement выписка по счету за период [From, To)
+type AccountStatement struct {
+	Account        LedgerAccount
+	From           time.Time
+	To             time.Time
+	OpeningBalance int64
+	ClosingBalance int64
+	Lines          []StatementLine
+}
+
+// ToMinorUnits переводит сумму в рублях в копейки
+func ToMinorUnits(amount float64) int64 {
+	return int64(math.Round(amount * 100))
+}
@@ -6,6 +6,9 @@ import (
 	payment_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/payment/v1"
 )
 
+// ErasedUserID подставляется вместо UserID в транзакциях пользователя, чьи данные удалены по запросу
+const ErasedUserID = "00000000-0000-0000-0000-000000000000"
+
 // Transaction представляет транзакцию платежа
 type Transaction struct {
 	UUID          string
@@ -14,8 +17,22 @@ type Transaction struct {
 	PaymentMethod payment_v1.PaymentMethod
 	Amount        float64
 	Status        TransactionStatus
-	CreatedAt     time.Time
-	UpdatedAt     time.Time
+	// FailureReason причина отказа или ошибки провайдера для FAILED и CANCELLED транзакций
+	FailureReason string
+	// FraudDecision решение антифрод-проверки; REVIEW означает, что транзакция ожидает ручной проверки
+	FraudDecision FraudDecision
+	// FraudReasons сработавшие правила антифрод-проверки
+	FraudReasons []string
+	// InstallmentPlanUUID план рассрочки, если транзакция - платеж по его графику
+	InstallmentPlanUUID string
+	// ApprovalUUID запрос на согласование, если платеж списывается только после решения согласующих
+	ApprovalUUID string
+	// SplitUUID группа транзакций раздельной оплаты, если транзакция оплачивает часть заказа
+	SplitUUID string
+	// ReviewedBy логин проверяющего, принявшего решение по ручной проверке
+	ReviewedBy string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
 }
 
 // TransactionStatus представляет статус транзакции
@@ -26,6 +43,8 @@ const (
 	TransactionStatusCompleted TransactionStatus = "COMPLETED"
 	TransactionStatusFailed    TransactionStatus = "FAILED"
 	TransactionStatusCancelled TransactionStatus = "CANCELLED"
+	// TransactionStatusRefunded списанные средства возвращены покупателю
+	TransactionStatusRefunded TransactionStatus = "REFUNDED"
 )
 
 // PaymentRequest представляет запрос на платеж
@@ -36,6 +55,32 @@ type PaymentRequest struct {
 	Amount        float64
 }
 
+// ChargeRequest представляет запрос на списание средств у платежного провайдера
+type ChargeRequest struct {
+	TransactionUUID string
+	OrderUUID       string
+	UserID          string
+	PaymentMethod   payment_v1.PaymentMethod
+	Amount          float64
+}
+
+// RefundRequest представляет запрос на возврат списанных средств у платежного провайдера
+type RefundRequest struct {
+	TransactionUUID string
+	OrderUUID       string
+	UserID          string
+	PaymentMethod   payment_v1.PaymentMethod
+	Amount          float64
+	Reason          string
+}
+
+// ChargeResult представляет ответ платежного провайдера
+type ChargeResult struct {
+	// Status COMPLETED при успешном списании, FAILED при отказе, PENDING если требуется подтверждение (3-D Secure)
+	Status        TransactionStatus
+	DeclineReason string
+}
+
 // PaymentResponse представляет ответ на платеж
 type PaymentResponse struct {
 	TransactionUUID string
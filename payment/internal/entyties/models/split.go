@@ -0,0 +1,38 @@
+package models
+
+import (
+	payment_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/payment/v1"
+)
+
+// MinSplitTenders минимальное число частей раздельной оплаты
+const MinSplitTenders = 2
+
+// Tender часть раздельной оплаты заказа
+type Tender struct {
+	PaymentMethod payment_v1.PaymentMethod
+	Amount        float64
+}
+
+// SplitStatus возвращает статус группы транзакций раздельной оплаты: FAILED, если хотя бы одна часть
+// не прошла или возвращена, COMPLETED, если прошли все части, иначе PENDING
+func SplitStatus(transactions []Transaction) TransactionStatus {
+	if len(transactions) == 0 {
+		return TransactionStatusPending
+	}
+
+	completed := 0
+	for _, transaction := range transactions {
+		switch transaction.Status {
+		case TransactionStatusFailed, TransactionStatusCancelled, TransactionStatusRefunded:
+			return TransactionStatusFailed
+		case TransactionStatusCompleted:
+			completed++
+		}
+	}
+
+	if completed == len(transactions) {
+		return TransactionStatusCompleted
+	}
+
+	return TransactionStatusPending
+}
@@ -0,0 +1,76 @@
+package fraud
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
+	payment_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/payment/v1"
+)
+
+// rule правило антифрод-проверки. Правило, которое не сработало, возвращает APPROVE
+type rule interface {
+	evaluate(check models.FraudCheck, stats models.FraudUserStats) (models.FraudDecision, string)
+}
+
+// velocityRule ограничивает число попыток оплаты пользователя за окно
+type velocityRule struct {
+	window       time.Duration
+	reviewLimit  int
+	declineLimit int
+}
+
+func (r velocityRule) evaluate(_ models.FraudCheck, stats models.FraudUserStats) (models.FraudDecision, string) {
+	reason := fmt.Sprintf("velocity: %d payment attempts within %s", stats.RecentPayments, r.window)
+
+	switch {
+	case r.declineLimit > 0 && stats.RecentPayments > r.declineLimit:
+		return models.FraudDecisionDecline, reason
+	case r.reviewLimit > 0 && stats.RecentPayments > r.reviewLimit:
+		return models.FraudDecisionReview, reason
+	default:
+		return models.FraudDecisionApprove, ""
+	}
+}
+
+// amountRule ограничивает сумму платежа для каждого способа оплаты
+type amountRule struct {
+	reviewAmounts  map[payment_v1.PaymentMethod]float64
+	declineAmounts map[payment_v1.PaymentMethod]float64
+}
+
+func (r amountRule) evaluate(check models.FraudCheck, _ models.FraudUserStats) (models.FraudDecision, string) {
+	if limit := r.declineAmounts[check.PaymentMethod]; limit > 0 && check.Amount > limit {
+		return models.FraudDecisionDecline, amountReason(check, limit)
+	}
+
+	if limit := r.reviewAmounts[check.PaymentMethod]; limit > 0 && check.Amount > limit {
+		return models.FraudDecisionReview, amountReason(check, limit)
+	}
+
+	return models.FraudDecisionApprove, ""
+}
+
+func amountReason(check models.FraudCheck, limit float64) string {
+	return fmt.Sprintf("amount: %.2f exceeds %.2f for %s", check.Amount, limit, check.PaymentMethod)
+}
+
+// newAccountRule отправляет на ручную проверку крупные платежи пользователей, недавно начавших платить.
+// Возраст аккаунта отсчитывается от первой попытки оплаты, известной сервису
+type newAccountRule struct {
+	maxAge       time.Duration
+	reviewAmount float64
+}
+
+func (r newAccountRule) evaluate(check models.FraudCheck, stats models.FraudUserStats) (models.FraudDecision, string) {
+	if r.maxAge <= 0 || r.reviewAmount <= 0 {
+		return models.FraudDecisionApprove, ""
+	}
+
+	age := check.At.Sub(stats.FirstSeenAt)
+	if age >= r.maxAge || check.Amount <= r.reviewAmount {
+		return models.FraudDecisionApprove, ""
+	}
+
+	return models.FraudDecisionReview, fmt.Sprintf("new account: %.2f exceeds %.2f within %s of first payment", check.Amount, r.reviewAmount, r.maxAge)
+}
@@ -0,0 +1,89 @@
+package fraud
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/linemk/rocket-shop/payment/internal/config"
+	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
+	"github.com/linemk/rocket-shop/payment/internal/repository"
+	"github.com/linemk/rocket-shop/platform/pkg/logger"
+)
+
+// stateUnavailableReason причина ручной проверки, если история платежей пользователя недоступна
+const stateUnavailableReason = "payment history unavailable"
+
+// Screener определяет интерфейс антифрод-проверки платежей перед списанием
+type Screener interface {
+	// Screen учитывает попытку оплаты и возвращает самое строгое решение сработавших правил
+	Screen(ctx context.Context, check models.FraudCheck) models.FraudVerdict
+	// Forget удаляет накопленную историю платежей пользователя
+	Forget(ctx context.Context, userID string) error
+}
+
+type screener struct {
+	fraudRepository repository.FraudRepository
+	cfg             config.FraudConfig
+	rules           []rule
+}
+
+func NewScreener(fraudRepository repository.FraudRepository, cfg config.FraudConfig) Screener {
+	return &screener{
+		fraudRepository: fraudRepository,
+		cfg:             cfg,
+		rules: []rule{
+			velocityRule{
+				window:       cfg.VelocityWindow(),
+				reviewLimit:  cfg.VelocityReviewLimit(),
+				declineLimit: cfg.VelocityDeclineLimit(),
+			},
+			amountRule{
+				reviewAmounts:  cfg.ReviewAmounts(),
+				declineAmounts: cfg.DeclineAmounts(),
+			},
+			newAccountRule{
+				maxAge:       cfg.NewAccountAge(),
+				reviewAmount: cfg.NewAccountReviewAmount(),
+			},
+		},
+	}
+}
+
+func (s *screener) Screen(ctx context.Context, check models.FraudCheck) models.FraudVerdict {
+	verdict := models.FraudVerdict{Decision: models.FraudDecisionApprove}
+	if !s.cfg.Enabled() {
+		return verdict
+	}
+
+	stats, err := s.fraudRepository.RecordAttempt(ctx, check, s.cfg.VelocityWindow(), s.cfg.HistoryTTL())
+	if err != nil {
+		// Без истории правила частоты и новых аккаунтов не применимы, поэтому платеж не списывается автоматически
+		logger.Error(ctx, "Не удалось получить историю платежей пользователя",
+			zap.String("transaction_uuid", check.TransactionUUID),
+			zap.Error(err),
+		)
+
+		verdict.Decision = models.FraudDecisionReview
+		verdict.Reasons = append(verdict.Reasons, stateUnavailableReason)
+		stats = models.FraudUserStats{RecentPayments: 1, FirstSeenAt: check.At}
+	}
+
+	for _, r := range s.rules {
+		decision, reason := r.evaluate(check, stats)
+		if decision == models.FraudDecisionApprove {
+			continue
+		}
+
+		verdict.Reasons = append(verdict.Reasons, reason)
+		if decision.StricterThan(verdict.Decision) {
+			verdict.Decision = decision
+		}
+	}
+
+	return verdict
+}
+
+func (s *screener) Forget(ctx context.Context, userID string) error {
+	return s.fraudRepository.DeleteUser(ctx, userID)
+}
@@ -0,0 +1,154 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
+	"github.com/linemk/rocket-shop/payment/internal/fraud"
+	"github.com/linemk/rocket-shop/payment/internal/mocks"
+	"github.com/linemk/rocket-shop/platform/pkg/logger"
+	payment_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/payment/v1"
+)
+
+type fraudConfig struct {
+	disabled               bool
+	velocityReviewLimit    int
+	velocityDeclineLimit   int
+	reviewAmounts          map[payment_v1.PaymentMethod]float64
+	declineAmounts         map[payment_v1.PaymentMethod]float64
+	newAccountAge          time.Duration
+	newAccountReviewAmount float64
+}
+
+func (c fraudConfig) Enabled() bool                   { return !c.disabled }
+func (c fraudConfig) VelocityWindow() time.Duration   { return 10 * time.Minute }
+func (c fraudConfig) VelocityReviewLimit() int        { return c.velocityReviewLimit }
+func (c fraudConfig) VelocityDeclineLimit() int       { return c.velocityDeclineLimit }
+func (c fraudConfig) NewAccountAge() time.Duration    { return c.newAccountAge }
+func (c fraudConfig) NewAccountReviewAmount() float64 { return c.newAccountReviewAmount }
+func (c fraudConfig) HistoryTTL() time.Duration       { return 24 * time.Hour }
+func (c fraudConfig) ReviewAmounts() map[payment_v1.PaymentMethod]float64 {
+	return c.reviewAmounts
+}
+
+func (c fraudConfig) DeclineAmounts() map[payment_v1.PaymentMethod]float64 {
+	return c.declineAmounts
+}
+
+func TestScreen(t *testing.T) {
+	ctx := context.Background()
+	if err := logger.Init(ctx, "info", false, false, "", "payment-test"); err != nil {
+		t.Fatalf("failed to init logger: %v", err)
+	}
+
+	now := time.Now()
+	check := models.FraudCheck{
+		TransactionUUID: uuid.New().String(),
+		UserID:          uuid.New().String(),
+		PaymentMethod:   payment_v1.PaymentMethod_PAYMENT_METHOD_CARD,
+		Amount:          150000,
+		At:              now,
+	}
+	established := models.FraudUserStats{RecentPayments: 1, FirstSeenAt: now.Add(-30 * 24 * time.Hour)}
+
+	tests := []struct {
+		name         string
+		cfg          fraudConfig
+		stats        models.FraudUserStats
+		statsErr     error
+		wantDecision models.FraudDecision
+		wantReasons  int
+	}{
+		{
+			name:         "approves payment within limits",
+			cfg:          fraudConfig{velocityReviewLimit: 3, reviewAmounts: map[payment_v1.PaymentMethod]float64{payment_v1.PaymentMethod_PAYMENT_METHOD_CARD: 500000}},
+			stats:        established,
+			wantDecision: models.FraudDecisionApprove,
+		},
+		{
+			name:         "reviews frequent payments",
+			cfg:          fraudConfig{velocityReviewLimit: 3, velocityDeclineLimit: 10},
+			stats:        models.FraudUserStats{RecentPayments: 4, FirstSeenAt: established.FirstSeenAt},
+			wantDecision: models.FraudDecisionReview,
+			wantReasons:  1,
+		},
+		{
+			name:         "declines payments above velocity limit",
+			cfg:          fraudConfig{velocityReviewLimit: 3, velocityDeclineLimit: 10},
+			stats:        models.FraudUserStats{RecentPayments: 11, FirstSeenAt: established.FirstSeenAt},
+			wantDecision: models.FraudDecisionDecline,
+			wantReasons:  1,
+		},
+		{
+			name:         "reviews large card payment",
+			cfg:          fraudConfig{reviewAmounts: map[payment_v1.PaymentMethod]float64{payment_v1.PaymentMethod_PAYMENT_METHOD_CARD: 100000}},
+			stats:        established,
+			wantDecision: models.FraudDecisionReview,
+			wantReasons:  1,
+		},
+		{
+			name:         "amount thresholds apply per payment method",
+			cfg:          fraudConfig{declineAmounts: map[payment_v1.PaymentMethod]float64{payment_v1.PaymentMethod_PAYMENT_METHOD_SBP: 100000}},
+			stats:        established,
+			wantDecision: models.FraudDecisionApprove,
+		},
+		{
+			name:         "reviews high value payment of new account",
+			cfg:          fraudConfig{newAccountAge: 72 * time.Hour, newAccountReviewAmount: 100000},
+			stats:        models.FraudUserStats{RecentPayments: 1, FirstSeenAt: now},
+			wantDecision: models.FraudDecisionReview,
+			wantReasons:  1,
+		},
+		{
+			name:         "approves high value payment of established account",
+			cfg:          fraudConfig{newAccountAge: 72 * time.Hour, newAccountReviewAmount: 100000},
+			stats:        established,
+			wantDecision: models.FraudDecisionApprove,
+		},
+		{
+			name: "strictest decision wins",
+			cfg: fraudConfig{
+				newAccountAge:          72 * time.Hour,
+				newAccountReviewAmount: 100000,
+				declineAmounts:         map[payment_v1.PaymentMethod]float64{payment_v1.PaymentMethod_PAYMENT_METHOD_CARD: 100000},
+			},
+			stats:        models.FraudUserStats{RecentPayments: 1, FirstSeenAt: now},
+			wantDecision: models.FraudDecisionDecline,
+			wantReasons:  2,
+		},
+		{
+			name:         "reviews payment when history is unavailable",
+			cfg:          fraudConfig{},
+			statsErr:     errors.New("redis unavailable"),
+			wantDecision: models.FraudDecisionReview,
+			wantReasons:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := mocks.NewMockFraudRepository(gomock.NewController(t))
+			repo.EXPECT().RecordAttempt(ctx, check, 10*time.Minute, 24*time.Hour).Return(tt.stats, tt.statsErr)
+
+			verdict := fraud.NewScreener(repo, tt.cfg).Screen(ctx, check)
+
+			require.Equal(t, tt.wantDecision, verdict.Decision)
+			require.Len(t, verdict.Reasons, tt.wantReasons)
+		})
+	}
+
+	t.Run("disabled screening approves without history", func(t *testing.T) {
+		repo := mocks.NewMockFraudRepository(gomock.NewController(t))
+
+		verdict := fraud.NewScreener(repo, fraudConfig{disabled: true, velocityReviewLimit: 1}).Screen(ctx, check)
+
+		require.Equal(t, models.FraudDecisionApprove, verdict.Decision)
+	})
+}
@@ -0,0 +1,15 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// PaymentMetrics holds Payment service business metrics
+type PaymentMetrics struct {
+	// TransactionsTotal число транзакций, получивших итоговый статус, по способу оплаты и статусу
+	TransactionsTotal *prometheus.CounterVec
+	// AmountTotal сумма списанных средств по способу оплаты
+	AmountTotal *prometheus.CounterVec
+	// RefundedAmountTotal сумма возвращенных средств по способу оплаты
+	RefundedAmountTotal *prometheus.CounterVec
+	// ProviderDuration время ответа провайдера по провайдеру, операции и результату
+	ProviderDuration *prometheus.HistogramVec
+}
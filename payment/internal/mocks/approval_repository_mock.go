@@ -0,0 +1,139 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/linemk/rocket-shop/payment/internal/repository (interfaces: ApprovalRepository)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/linemk/rocket-shop/payment/internal/entyties/models"
+)
+
+// MockApprovalRepository is a mock of ApprovalRepository interface.
+type MockApprovalRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockApprovalRepositoryMockRecorder
+}
+
+// MockApprovalRepositoryMockRecorder is the mock recorder for MockApprovalRepository.
+type MockApprovalRepositoryMockRecorder struct {
+	mock *MockApprovalRepository
+}
+
+// NewMockApprovalRepository creates a new mock instance.
+func NewMockApprovalRepository(ctrl *gomock.Controller) *MockApprovalRepository {
+	mock := &MockApprovalRepository{ctrl: ctrl}
+	mock.recorder = &MockApprovalRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApprovalRepository) EXPECT() *MockApprovalRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddDecision mocks base method.
+func (m *MockApprovalRepository) AddDecision(arg0 context.Context, arg1 string, arg2 models.ApprovalDecision) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddDecision", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddDecision indicates an expected call of AddDecision.
+func (mr *MockApprovalRepositoryMockRecorder) AddDecision(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDecision", reflect.TypeOf((*MockApprovalRepository)(nil).AddDecision), arg0, arg1, arg2)
+}
+
+// AnonymizeUser mocks base method.
+func (m *MockApprovalRepository) AnonymizeUser(arg0 context.Context, arg1, arg2 string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnonymizeUser", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AnonymizeUser indicates an expected call of AnonymizeUser.
+func (mr *MockApprovalRepositoryMockRecorder) AnonymizeUser(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnonymizeUser", reflect.TypeOf((*MockApprovalRepository)(nil).AnonymizeUser), arg0, arg1, arg2)
+}
+
+// CreateApproval mocks base method.
+func (m *MockApprovalRepository) CreateApproval(arg0 context.Context, arg1 models.Approval) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateApproval", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateApproval indicates an expected call of CreateApproval.
+func (mr *MockApprovalRepositoryMockRecorder) CreateApproval(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateApproval", reflect.TypeOf((*MockApprovalRepository)(nil).CreateApproval), arg0, arg1)
+}
+
+// GetApproval mocks base method.
+func (m *MockApprovalRepository) GetApproval(arg0 context.Context, arg1 string) (models.Approval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetApproval", arg0, arg1)
+	ret0, _ := ret[0].(models.Approval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetApproval indicates an expected call of GetApproval.
+func (mr *MockApprovalRepositoryMockRecorder) GetApproval(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApproval", reflect.TypeOf((*MockApprovalRepository)(nil).GetApproval), arg0, arg1)
+}
+
+// ListExpired mocks base method.
+func (m *MockApprovalRepository) ListExpired(arg0 context.Context, arg1 time.Time, arg2 int) ([]models.Approval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpired", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]models.Approval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpired indicates an expected call of ListExpired.
+func (mr *MockApprovalRepositoryMockRecorder) ListExpired(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpired", reflect.TypeOf((*MockApprovalRepository)(nil).ListExpired), arg0, arg1, arg2)
+}
+
+// ListPending mocks base method.
+func (m *MockApprovalRepository) ListPending(arg0 context.Context, arg1 string) ([]models.Approval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPending", arg0, arg1)
+	ret0, _ := ret[0].([]models.Approval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPending indicates an expected call of ListPending.
+func (mr *MockApprovalRepositoryMockRecorder) ListPending(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPending", reflect.TypeOf((*MockApprovalRepository)(nil).ListPending), arg0, arg1)
+}
+
+// ResolveApproval mocks base method.
+func (m *MockApprovalRepository) ResolveApproval(arg0 context.Context, arg1 string, arg2 models.ApprovalStatus, arg3 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveApproval", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResolveApproval indicates an expected call of ResolveApproval.
+func (mr *MockApprovalRepositoryMockRecorder) ResolveApproval(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveApproval", reflect.TypeOf((*MockApprovalRepository)(nil).ResolveApproval), arg0, arg1, arg2, arg3)
+}
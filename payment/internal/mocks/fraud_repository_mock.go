@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/linemk/rocket-shop/payment/internal/repository (interfaces: FraudRepository)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/linemk/rocket-shop/payment/internal/entyties/models"
+)
+
+// MockFraudRepository is a mock of FraudRepository interface.
+type MockFraudRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockFraudRepositoryMockRecorder
+}
+
+// MockFraudRepositoryMockRecorder is the mock recorder for MockFraudRepository.
+type MockFraudRepositoryMockRecorder struct {
+	mock *MockFraudRepository
+}
+
+// NewMockFraudRepository creates a new mock instance.
+func NewMockFraudRepository(ctrl *gomock.Controller) *MockFraudRepository {
+	mock := &MockFraudRepository{ctrl: ctrl}
+	mock.recorder = &MockFraudRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFraudRepository) EXPECT() *MockFraudRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteUser mocks base method.
+func (m *MockFraudRepository) DeleteUser(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUser", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockFraudRepositoryMockRecorder) DeleteUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockFraudRepository)(nil).DeleteUser), arg0, arg1)
+}
+
+// RecordAttempt mocks base method.
+func (m *MockFraudRepository) RecordAttempt(arg0 context.Context, arg1 models.FraudCheck, arg2, arg3 time.Duration) (models.FraudUserStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordAttempt", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(models.FraudUserStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordAttempt indicates an expected call of RecordAttempt.
+func (mr *MockFraudRepositoryMockRecorder) RecordAttempt(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordAttempt", reflect.TypeOf((*MockFraudRepository)(nil).RecordAttempt), arg0, arg1, arg2, arg3)
+}
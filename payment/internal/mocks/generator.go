@@ -1,4 +1,11 @@
 package mocks
 
 //go:generate mockgen --package mocks --destination payment_repository_mock.go github.com/linemk/rocket-shop/payment/internal/repository PaymentRepository
+//go:generate mockgen --package mocks --destination ledger_repository_mock.go github.com/linemk/rocket-shop/payment/internal/repository LedgerRepository
+//go:generate mockgen --package mocks --destination fraud_repository_mock.go github.com/linemk/rocket-shop/payment/internal/repository FraudRepository
+//go:generate mockgen --package mocks --destination installment_repository_mock.go github.com/linemk/rocket-shop/payment/internal/repository InstallmentRepository
+//go:generate mockgen --package mocks --destination approval_repository_mock.go github.com/linemk/rocket-shop/payment/internal/repository ApprovalRepository
 //go:generate mockgen --package mocks --destination payment_usecase_mock.go github.com/linemk/rocket-shop/payment/internal/usecase PaymentUseCase
+//go:generate mockgen --package mocks --destination payment_provider_mock.go github.com/linemk/rocket-shop/payment/internal/provider PaymentProvider
+//go:generate mockgen --package mocks --destination fraud_screener_mock.go github.com/linemk/rocket-shop/payment/internal/fraud Screener
+//go:generate mockgen --package mocks --destination payment_producer_service_mock.go github.com/linemk/rocket-shop/payment/internal/service PaymentProducerService
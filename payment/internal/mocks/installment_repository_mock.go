@@ -0,0 +1,124 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/linemk/rocket-shop/payment/internal/repository (interfaces: InstallmentRepository)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/linemk/rocket-shop/payment/internal/entyties/models"
+)
+
+// MockInstallmentRepository is a mock of InstallmentRepository interface.
+type MockInstallmentRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockInstallmentRepositoryMockRecorder
+}
+
+// MockInstallmentRepositoryMockRecorder is the mock recorder for MockInstallmentRepository.
+type MockInstallmentRepositoryMockRecorder struct {
+	mock *MockInstallmentRepository
+}
+
+// NewMockInstallmentRepository creates a new mock instance.
+func NewMockInstallmentRepository(ctrl *gomock.Controller) *MockInstallmentRepository {
+	mock := &MockInstallmentRepository{ctrl: ctrl}
+	mock.recorder = &MockInstallmentRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInstallmentRepository) EXPECT() *MockInstallmentRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AnonymizeUser mocks base method.
+func (m *MockInstallmentRepository) AnonymizeUser(arg0 context.Context, arg1, arg2 string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnonymizeUser", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AnonymizeUser indicates an expected call of AnonymizeUser.
+func (mr *MockInstallmentRepositoryMockRecorder) AnonymizeUser(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnonymizeUser", reflect.TypeOf((*MockInstallmentRepository)(nil).AnonymizeUser), arg0, arg1, arg2)
+}
+
+// ClaimDue mocks base method.
+func (m *MockInstallmentRepository) ClaimDue(arg0 context.Context, arg1, arg2 time.Time, arg3 int) ([]models.Installment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDue", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]models.Installment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDue indicates an expected call of ClaimDue.
+func (mr *MockInstallmentRepositoryMockRecorder) ClaimDue(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDue", reflect.TypeOf((*MockInstallmentRepository)(nil).ClaimDue), arg0, arg1, arg2, arg3)
+}
+
+// CreatePlan mocks base method.
+func (m *MockInstallmentRepository) CreatePlan(arg0 context.Context, arg1 models.InstallmentPlan) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePlan", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePlan indicates an expected call of CreatePlan.
+func (mr *MockInstallmentRepositoryMockRecorder) CreatePlan(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePlan", reflect.TypeOf((*MockInstallmentRepository)(nil).CreatePlan), arg0, arg1)
+}
+
+// GetPlan mocks base method.
+func (m *MockInstallmentRepository) GetPlan(arg0 context.Context, arg1 string) (models.InstallmentPlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlan", arg0, arg1)
+	ret0, _ := ret[0].(models.InstallmentPlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlan indicates an expected call of GetPlan.
+func (mr *MockInstallmentRepositoryMockRecorder) GetPlan(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlan", reflect.TypeOf((*MockInstallmentRepository)(nil).GetPlan), arg0, arg1)
+}
+
+// UpdateInstallment mocks base method.
+func (m *MockInstallmentRepository) UpdateInstallment(arg0 context.Context, arg1 models.Installment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateInstallment", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateInstallment indicates an expected call of UpdateInstallment.
+func (mr *MockInstallmentRepositoryMockRecorder) UpdateInstallment(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInstallment", reflect.TypeOf((*MockInstallmentRepository)(nil).UpdateInstallment), arg0, arg1)
+}
+
+// UpdatePlanStatus mocks base method.
+func (m *MockInstallmentRepository) UpdatePlanStatus(arg0 context.Context, arg1 string, arg2 models.InstallmentPlanStatus, arg3 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePlanStatus", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePlanStatus indicates an expected call of UpdatePlanStatus.
+func (mr *MockInstallmentRepositoryMockRecorder) UpdatePlanStatus(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePlanStatus", reflect.TypeOf((*MockInstallmentRepository)(nil).UpdatePlanStatus), arg0, arg1, arg2, arg3)
+}
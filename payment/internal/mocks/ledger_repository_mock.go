@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/linemk/rocket-shop/payment/internal/repository (interfaces: LedgerRepository)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	models "github.com/linemk/rocket-shop/payment/internal/entyties/models"
+)
+
+// MockLedgerRepository is a mock of LedgerRepository interface.
+type MockLedgerRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLedgerRepositoryMockRecorder
+}
+
+// MockLedgerRepositoryMockRecorder is the mock recorder for MockLedgerRepository.
+type MockLedgerRepositoryMockRecorder struct {
+	mock *MockLedgerRepository
+}
+
+// NewMockLedgerRepository creates a new mock instance.
+func NewMockLedgerRepository(ctrl *gomock.Controller) *MockLedgerRepository {
+	mock := &MockLedgerRepository{ctrl: ctrl}
+	mock.recorder = &MockLedgerRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLedgerRepository) EXPECT() *MockLedgerRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetAccount mocks base method.
+func (m *MockLedgerRepository) GetAccount(arg0 context.Context, arg1 string) (models.LedgerAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccount", arg0, arg1)
+	ret0, _ := ret[0].(models.LedgerAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccount indicates an expected call of GetAccount.
+func (mr *MockLedgerRepositoryMockRecorder) GetAccount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockLedgerRepository)(nil).GetAccount), arg0, arg1)
+}
+
+// GetTotals mocks base method.
+func (m *MockLedgerRepository) GetTotals(arg0 context.Context, arg1 string, arg2 time.Time) (models.PostingTotals, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTotals", arg0, arg1, arg2)
+	ret0, _ := ret[0].(models.PostingTotals)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTotals indicates an expected call of GetTotals.
+func (mr *MockLedgerRepositoryMockRecorder) GetTotals(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTotals", reflect.TypeOf((*MockLedgerRepository)(nil).GetTotals), arg0, arg1, arg2)
+}
+
+// ListAccounts mocks base method.
+func (m *MockLedgerRepository) ListAccounts(arg0 context.Context) ([]models.LedgerAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccounts", arg0)
+	ret0, _ := ret[0].([]models.LedgerAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccounts indicates an expected call of ListAccounts.
+func (mr *MockLedgerRepositoryMockRecorder) ListAccounts(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccounts", reflect.TypeOf((*MockLedgerRepository)(nil).ListAccounts), arg0)
+}
+
+// ListPostings mocks base method.
+func (m *MockLedgerRepository) ListPostings(arg0 context.Context, arg1 string, arg2, arg3 time.Time) ([]models.AccountPosting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPostings", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]models.AccountPosting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPostings indicates an expected call of ListPostings.
+func (mr *MockLedgerRepositoryMockRecorder) ListPostings(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPostings", reflect.TypeOf((*MockLedgerRepository)(nil).ListPostings), arg0, arg1, arg2, arg3)
+}
+
+// PostEntry mocks base method.
+func (m *MockLedgerRepository) PostEntry(arg0 context.Context, arg1 models.JournalEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostEntry", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PostEntry indicates an expected call of PostEntry.
+func (mr *MockLedgerRepositoryMockRecorder) PostEntry(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostEntry", reflect.TypeOf((*MockLedgerRepository)(nil).PostEntry), arg0, arg1)
+}
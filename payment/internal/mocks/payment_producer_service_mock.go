@@ -0,0 +1,106 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/linemk/rocket-shop/payment/internal/service (interfaces: PaymentProducerService)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	events "github.com/linemk/rocket-shop/payment/internal/entyties/events"
+)
+
+// MockPaymentProducerService is a mock of PaymentProducerService interface.
+type MockPaymentProducerService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaymentProducerServiceMockRecorder
+}
+
+// MockPaymentProducerServiceMockRecorder is the mock recorder for MockPaymentProducerService.
+type MockPaymentProducerServiceMockRecorder struct {
+	mock *MockPaymentProducerService
+}
+
+// NewMockPaymentProducerService creates a new mock instance.
+func NewMockPaymentProducerService(ctrl *gomock.Controller) *MockPaymentProducerService {
+	mock := &MockPaymentProducerService{ctrl: ctrl}
+	mock.recorder = &MockPaymentProducerServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaymentProducerService) EXPECT() *MockPaymentProducerServiceMockRecorder {
+	return m.recorder
+}
+
+// SendApprovalRequested mocks base method.
+func (m *MockPaymentProducerService) SendApprovalRequested(arg0 context.Context, arg1 *events.ApprovalRequestedEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendApprovalRequested", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendApprovalRequested indicates an expected call of SendApprovalRequested.
+func (mr *MockPaymentProducerServiceMockRecorder) SendApprovalRequested(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendApprovalRequested", reflect.TypeOf((*MockPaymentProducerService)(nil).SendApprovalRequested), arg0, arg1)
+}
+
+// SendInstallmentPaid mocks base method.
+func (m *MockPaymentProducerService) SendInstallmentPaid(arg0 context.Context, arg1 *events.InstallmentPaidEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendInstallmentPaid", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendInstallmentPaid indicates an expected call of SendInstallmentPaid.
+func (mr *MockPaymentProducerServiceMockRecorder) SendInstallmentPaid(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendInstallmentPaid", reflect.TypeOf((*MockPaymentProducerService)(nil).SendInstallmentPaid), arg0, arg1)
+}
+
+// SendPaymentCompleted mocks base method.
+func (m *MockPaymentProducerService) SendPaymentCompleted(arg0 context.Context, arg1 *events.PaymentCompletedEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendPaymentCompleted", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendPaymentCompleted indicates an expected call of SendPaymentCompleted.
+func (mr *MockPaymentProducerServiceMockRecorder) SendPaymentCompleted(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendPaymentCompleted", reflect.TypeOf((*MockPaymentProducerService)(nil).SendPaymentCompleted), arg0, arg1)
+}
+
+// SendPaymentFailed mocks base method.
+func (m *MockPaymentProducerService) SendPaymentFailed(arg0 context.Context, arg1 *events.PaymentFailedEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendPaymentFailed", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendPaymentFailed indicates an expected call of SendPaymentFailed.
+func (mr *MockPaymentProducerServiceMockRecorder) SendPaymentFailed(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendPaymentFailed", reflect.TypeOf((*MockPaymentProducerService)(nil).SendPaymentFailed), arg0, arg1)
+}
+
+// SendPaymentOverdue mocks base method.
+func (m *MockPaymentProducerService) SendPaymentOverdue(arg0 context.Context, arg1 *events.PaymentOverdueEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendPaymentOverdue", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendPaymentOverdue indicates an expected call of SendPaymentOverdue.
+func (mr *MockPaymentProducerServiceMockRecorder) SendPaymentOverdue(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendPaymentOverdue", reflect.TypeOf((*MockPaymentProducerService)(nil).SendPaymentOverdue), arg0, arg1)
+}
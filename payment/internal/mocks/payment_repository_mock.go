@@ -7,6 +7,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	models "github.com/linemk/rocket-shop/payment/internal/entyties/models"
@@ -35,6 +36,21 @@ func (m *MockPaymentRepository) EXPECT() *MockPaymentRepositoryMockRecorder {
 	return m.recorder
 }
 
+// AnonymizeUser mocks base method.
+func (m *MockPaymentRepository) AnonymizeUser(arg0 context.Context, arg1, arg2 string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnonymizeUser", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AnonymizeUser indicates an expected call of AnonymizeUser.
+func (mr *MockPaymentRepositoryMockRecorder) AnonymizeUser(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnonymizeUser", reflect.TypeOf((*MockPaymentRepository)(nil).AnonymizeUser), arg0, arg1, arg2)
+}
+
 // CreateTransaction mocks base method.
 func (m *MockPaymentRepository) CreateTransaction(arg0 context.Context, arg1 models.Transaction) error {
 	m.ctrl.T.Helper()
@@ -64,6 +80,36 @@ func (mr *MockPaymentRepositoryMockRecorder) GetTransaction(arg0, arg1 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransaction", reflect.TypeOf((*MockPaymentRepository)(nil).GetTransaction), arg0, arg1)
 }
 
+// ListAwaitingReview mocks base method.
+func (m *MockPaymentRepository) ListAwaitingReview(arg0 context.Context) ([]models.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAwaitingReview", arg0)
+	ret0, _ := ret[0].([]models.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAwaitingReview indicates an expected call of ListAwaitingReview.
+func (mr *MockPaymentRepositoryMockRecorder) ListAwaitingReview(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAwaitingReview", reflect.TypeOf((*MockPaymentRepository)(nil).ListAwaitingReview), arg0)
+}
+
+// ListStalePending mocks base method.
+func (m *MockPaymentRepository) ListStalePending(arg0 context.Context, arg1 time.Time, arg2 models.Transaction, arg3 int) ([]models.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListStalePending", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]models.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListStalePending indicates an expected call of ListStalePending.
+func (mr *MockPaymentRepositoryMockRecorder) ListStalePending(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStalePending", reflect.TypeOf((*MockPaymentRepository)(nil).ListStalePending), arg0, arg1, arg2, arg3)
+}
+
 // ListTransactions mocks base method.
 func (m *MockPaymentRepository) ListTransactions(arg0 context.Context, arg1 string) ([]models.Transaction, error) {
 	m.ctrl.T.Helper()
@@ -79,6 +125,36 @@ func (mr *MockPaymentRepositoryMockRecorder) ListTransactions(arg0, arg1 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransactions", reflect.TypeOf((*MockPaymentRepository)(nil).ListTransactions), arg0, arg1)
 }
 
+// ListUncompensatedTenders mocks base method.
+func (m *MockPaymentRepository) ListUncompensatedTenders(arg0 context.Context, arg1 models.Transaction, arg2 int) ([]models.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUncompensatedTenders", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]models.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUncompensatedTenders indicates an expected call of ListUncompensatedTenders.
+func (mr *MockPaymentRepositoryMockRecorder) ListUncompensatedTenders(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUncompensatedTenders", reflect.TypeOf((*MockPaymentRepository)(nil).ListUncompensatedTenders), arg0, arg1, arg2)
+}
+
+// ListUserTransactions mocks base method.
+func (m *MockPaymentRepository) ListUserTransactions(arg0 context.Context, arg1 string) ([]models.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserTransactions", arg0, arg1)
+	ret0, _ := ret[0].([]models.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserTransactions indicates an expected call of ListUserTransactions.
+func (mr *MockPaymentRepositoryMockRecorder) ListUserTransactions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserTransactions", reflect.TypeOf((*MockPaymentRepository)(nil).ListUserTransactions), arg0, arg1)
+}
+
 // UpdateTransaction mocks base method.
 func (m *MockPaymentRepository) UpdateTransaction(arg0 context.Context, arg1 string, arg2 models.Transaction) error {
 	m.ctrl.T.Helper()
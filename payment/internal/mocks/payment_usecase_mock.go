@@ -7,6 +7,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	models "github.com/linemk/rocket-shop/payment/internal/entyties/models"
@@ -36,6 +37,185 @@ func (m *MockPaymentUseCase) EXPECT() *MockPaymentUseCaseMockRecorder {
 	return m.recorder
 }
 
+// ChargeDueInstallments mocks base method.
+func (m *MockPaymentUseCase) ChargeDueInstallments(arg0 context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChargeDueInstallments", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChargeDueInstallments indicates an expected call of ChargeDueInstallments.
+func (mr *MockPaymentUseCaseMockRecorder) ChargeDueInstallments(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChargeDueInstallments", reflect.TypeOf((*MockPaymentUseCase)(nil).ChargeDueInstallments), arg0)
+}
+
+// CompensateSplits mocks base method.
+func (m *MockPaymentUseCase) CompensateSplits(arg0 context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompensateSplits", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompensateSplits indicates an expected call of CompensateSplits.
+func (mr *MockPaymentUseCaseMockRecorder) CompensateSplits(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompensateSplits", reflect.TypeOf((*MockPaymentUseCase)(nil).CompensateSplits), arg0)
+}
+
+// ConfirmTransaction mocks base method.
+func (m *MockPaymentUseCase) ConfirmTransaction(arg0 context.Context, arg1, arg2 string, arg3 bool, arg4 string) (models.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmTransaction", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(models.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmTransaction indicates an expected call of ConfirmTransaction.
+func (mr *MockPaymentUseCaseMockRecorder) ConfirmTransaction(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmTransaction", reflect.TypeOf((*MockPaymentUseCase)(nil).ConfirmTransaction), arg0, arg1, arg2, arg3, arg4)
+}
+
+// CreateInstallmentPlan mocks base method.
+func (m *MockPaymentUseCase) CreateInstallmentPlan(arg0 context.Context, arg1, arg2 string, arg3 float64, arg4 int) (models.InstallmentPlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInstallmentPlan", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(models.InstallmentPlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateInstallmentPlan indicates an expected call of CreateInstallmentPlan.
+func (mr *MockPaymentUseCaseMockRecorder) CreateInstallmentPlan(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInstallmentPlan", reflect.TypeOf((*MockPaymentUseCase)(nil).CreateInstallmentPlan), arg0, arg1, arg2, arg3, arg4)
+}
+
+// DecideApproval mocks base method.
+func (m *MockPaymentUseCase) DecideApproval(arg0 context.Context, arg1, arg2 string, arg3 bool, arg4 string) (models.Approval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecideApproval", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(models.Approval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DecideApproval indicates an expected call of DecideApproval.
+func (mr *MockPaymentUseCaseMockRecorder) DecideApproval(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecideApproval", reflect.TypeOf((*MockPaymentUseCase)(nil).DecideApproval), arg0, arg1, arg2, arg3, arg4)
+}
+
+// Drain mocks base method.
+func (m *MockPaymentUseCase) Drain(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Drain", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Drain indicates an expected call of Drain.
+func (mr *MockPaymentUseCaseMockRecorder) Drain(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Drain", reflect.TypeOf((*MockPaymentUseCase)(nil).Drain), arg0)
+}
+
+// EraseUser mocks base method.
+func (m *MockPaymentUseCase) EraseUser(arg0 context.Context, arg1 string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EraseUser", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EraseUser indicates an expected call of EraseUser.
+func (mr *MockPaymentUseCaseMockRecorder) EraseUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EraseUser", reflect.TypeOf((*MockPaymentUseCase)(nil).EraseUser), arg0, arg1)
+}
+
+// ExpireApprovals mocks base method.
+func (m *MockPaymentUseCase) ExpireApprovals(arg0 context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireApprovals", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireApprovals indicates an expected call of ExpireApprovals.
+func (mr *MockPaymentUseCaseMockRecorder) ExpireApprovals(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireApprovals", reflect.TypeOf((*MockPaymentUseCase)(nil).ExpireApprovals), arg0)
+}
+
+// GetAccountBalance mocks base method.
+func (m *MockPaymentUseCase) GetAccountBalance(arg0 context.Context, arg1 string) (models.AccountBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountBalance", arg0, arg1)
+	ret0, _ := ret[0].(models.AccountBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountBalance indicates an expected call of GetAccountBalance.
+func (mr *MockPaymentUseCaseMockRecorder) GetAccountBalance(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountBalance", reflect.TypeOf((*MockPaymentUseCase)(nil).GetAccountBalance), arg0, arg1)
+}
+
+// GetAccountStatement mocks base method.
+func (m *MockPaymentUseCase) GetAccountStatement(arg0 context.Context, arg1 string, arg2, arg3 time.Time) (models.AccountStatement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountStatement", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(models.AccountStatement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountStatement indicates an expected call of GetAccountStatement.
+func (mr *MockPaymentUseCaseMockRecorder) GetAccountStatement(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountStatement", reflect.TypeOf((*MockPaymentUseCase)(nil).GetAccountStatement), arg0, arg1, arg2, arg3)
+}
+
+// GetApproval mocks base method.
+func (m *MockPaymentUseCase) GetApproval(arg0 context.Context, arg1 string) (models.Approval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetApproval", arg0, arg1)
+	ret0, _ := ret[0].(models.Approval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetApproval indicates an expected call of GetApproval.
+func (mr *MockPaymentUseCaseMockRecorder) GetApproval(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApproval", reflect.TypeOf((*MockPaymentUseCase)(nil).GetApproval), arg0, arg1)
+}
+
+// GetInstallmentPlan mocks base method.
+func (m *MockPaymentUseCase) GetInstallmentPlan(arg0 context.Context, arg1 string) (models.InstallmentPlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInstallmentPlan", arg0, arg1)
+	ret0, _ := ret[0].(models.InstallmentPlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInstallmentPlan indicates an expected call of GetInstallmentPlan.
+func (mr *MockPaymentUseCaseMockRecorder) GetInstallmentPlan(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInstallmentPlan", reflect.TypeOf((*MockPaymentUseCase)(nil).GetInstallmentPlan), arg0, arg1)
+}
+
 // GetTransaction mocks base method.
 func (m *MockPaymentUseCase) GetTransaction(arg0 context.Context, arg1 string) (models.Transaction, error) {
 	m.ctrl.T.Helper()
@@ -51,6 +231,51 @@ func (mr *MockPaymentUseCaseMockRecorder) GetTransaction(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransaction", reflect.TypeOf((*MockPaymentUseCase)(nil).GetTransaction), arg0, arg1)
 }
 
+// ListLedgerAccounts mocks base method.
+func (m *MockPaymentUseCase) ListLedgerAccounts(arg0 context.Context) ([]models.LedgerAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLedgerAccounts", arg0)
+	ret0, _ := ret[0].([]models.LedgerAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLedgerAccounts indicates an expected call of ListLedgerAccounts.
+func (mr *MockPaymentUseCaseMockRecorder) ListLedgerAccounts(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLedgerAccounts", reflect.TypeOf((*MockPaymentUseCase)(nil).ListLedgerAccounts), arg0)
+}
+
+// ListPendingApprovals mocks base method.
+func (m *MockPaymentUseCase) ListPendingApprovals(arg0 context.Context, arg1 string) ([]models.Approval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPendingApprovals", arg0, arg1)
+	ret0, _ := ret[0].([]models.Approval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPendingApprovals indicates an expected call of ListPendingApprovals.
+func (mr *MockPaymentUseCaseMockRecorder) ListPendingApprovals(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingApprovals", reflect.TypeOf((*MockPaymentUseCase)(nil).ListPendingApprovals), arg0, arg1)
+}
+
+// ListReviewQueue mocks base method.
+func (m *MockPaymentUseCase) ListReviewQueue(arg0 context.Context) ([]models.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReviewQueue", arg0)
+	ret0, _ := ret[0].([]models.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReviewQueue indicates an expected call of ListReviewQueue.
+func (mr *MockPaymentUseCaseMockRecorder) ListReviewQueue(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReviewQueue", reflect.TypeOf((*MockPaymentUseCase)(nil).ListReviewQueue), arg0)
+}
+
 // ListTransactions mocks base method.
 func (m *MockPaymentUseCase) ListTransactions(arg0 context.Context, arg1 string) ([]models.Transaction, error) {
 	m.ctrl.T.Helper()
@@ -66,17 +291,93 @@ func (mr *MockPaymentUseCaseMockRecorder) ListTransactions(arg0, arg1 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransactions", reflect.TypeOf((*MockPaymentUseCase)(nil).ListTransactions), arg0, arg1)
 }
 
+// ListUserTransactions mocks base method.
+func (m *MockPaymentUseCase) ListUserTransactions(arg0 context.Context, arg1 string) ([]models.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserTransactions", arg0, arg1)
+	ret0, _ := ret[0].([]models.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserTransactions indicates an expected call of ListUserTransactions.
+func (mr *MockPaymentUseCaseMockRecorder) ListUserTransactions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserTransactions", reflect.TypeOf((*MockPaymentUseCase)(nil).ListUserTransactions), arg0, arg1)
+}
+
 // PayOrder mocks base method.
-func (m *MockPaymentUseCase) PayOrder(arg0 context.Context, arg1, arg2 string, arg3 payment_v1.PaymentMethod) (string, error) {
+func (m *MockPaymentUseCase) PayOrder(arg0 context.Context, arg1, arg2 string, arg3 payment_v1.PaymentMethod, arg4 float64, arg5 string) (models.Transaction, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PayOrder", arg0, arg1, arg2, arg3)
-	ret0, _ := ret[0].(string)
+	ret := m.ctrl.Call(m, "PayOrder", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(models.Transaction)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // PayOrder indicates an expected call of PayOrder.
-func (mr *MockPaymentUseCaseMockRecorder) PayOrder(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+func (mr *MockPaymentUseCaseMockRecorder) PayOrder(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PayOrder", reflect.TypeOf((*MockPaymentUseCase)(nil).PayOrder), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// PaySplit mocks base method.
+func (m *MockPaymentUseCase) PaySplit(arg0 context.Context, arg1, arg2 string, arg3 []models.Tender, arg4 float64, arg5 string) (string, []models.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PaySplit", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].([]models.Transaction)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// PaySplit indicates an expected call of PaySplit.
+func (mr *MockPaymentUseCaseMockRecorder) PaySplit(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PaySplit", reflect.TypeOf((*MockPaymentUseCase)(nil).PaySplit), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// QuoteInstallments mocks base method.
+func (m *MockPaymentUseCase) QuoteInstallments(arg0 context.Context, arg1 float64) ([]models.InstallmentQuote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QuoteInstallments", arg0, arg1)
+	ret0, _ := ret[0].([]models.InstallmentQuote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QuoteInstallments indicates an expected call of QuoteInstallments.
+func (mr *MockPaymentUseCaseMockRecorder) QuoteInstallments(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QuoteInstallments", reflect.TypeOf((*MockPaymentUseCase)(nil).QuoteInstallments), arg0, arg1)
+}
+
+// RecoverPendingCharges mocks base method.
+func (m *MockPaymentUseCase) RecoverPendingCharges(arg0 context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecoverPendingCharges", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecoverPendingCharges indicates an expected call of RecoverPendingCharges.
+func (mr *MockPaymentUseCaseMockRecorder) RecoverPendingCharges(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecoverPendingCharges", reflect.TypeOf((*MockPaymentUseCase)(nil).RecoverPendingCharges), arg0)
+}
+
+// ReviewTransaction mocks base method.
+func (m *MockPaymentUseCase) ReviewTransaction(arg0 context.Context, arg1, arg2 string, arg3 bool, arg4 string) (models.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReviewTransaction", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(models.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReviewTransaction indicates an expected call of ReviewTransaction.
+func (mr *MockPaymentUseCaseMockRecorder) ReviewTransaction(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PayOrder", reflect.TypeOf((*MockPaymentUseCase)(nil).PayOrder), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReviewTransaction", reflect.TypeOf((*MockPaymentUseCase)(nil).ReviewTransaction), arg0, arg1, arg2, arg3, arg4)
 }
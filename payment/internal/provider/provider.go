@@ -0,0 +1,44 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/linemk/rocket-shop/payment/internal/entyties/apperrors"
+	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
+	payment_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/payment/v1"
+)
+
+// PaymentProvider определяет интерфейс платежного провайдера (шлюза), выполняющего списание средств
+type PaymentProvider interface {
+	// Name возвращает имя провайдера для логов и метрик
+	Name() string
+	// Charge списывает средства. Отказ провайдера возвращается статусом FAILED в результате,
+	// а ошибка означает технический сбой, после которого исход платежа неизвестен
+	Charge(ctx context.Context, req models.ChargeRequest) (models.ChargeResult, error)
+	// Status возвращает исход списания по UUID транзакции, когда ответ на Charge не был получен.
+	// apperrors.ErrChargeNotFound означает, что запрос на списание до провайдера не дошел
+	Status(ctx context.Context, transactionUUID string) (models.ChargeResult, error)
+	// Refund возвращает покупателю средства, списанные транзакцией
+	Refund(ctx context.Context, req models.RefundRequest) error
+}
+
+// Registry сопоставляет способ оплаты с обслуживающим его провайдером
+type Registry struct {
+	providers map[payment_v1.PaymentMethod]PaymentProvider
+}
+
+func NewRegistry(providers map[payment_v1.PaymentMethod]PaymentProvider) *Registry {
+	return &Registry{
+		providers: providers,
+	}
+}
+
+// Get возвращает провайдера для способа оплаты
+func (r *Registry) Get(paymentMethod payment_v1.PaymentMethod) (PaymentProvider, error) {
+	p, ok := r.providers[paymentMethod]
+	if !ok {
+		return nil, apperrors.ErrInvalidPaymentMethod
+	}
+
+	return p, nil
+}
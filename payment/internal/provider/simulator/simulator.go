@@ -0,0 +1,150 @@
+package simulator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/linemk/rocket-shop/payment/internal/config"
+	"github.com/linemk/rocket-shop/payment/internal/entyties/apperrors"
+	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
+	"github.com/linemk/rocket-shop/payment/internal/provider"
+)
+
+// ErrSimulatedFailure возвращается при имитации технического сбоя провайдера
+var ErrSimulatedFailure = errors.New("simulated provider failure")
+
+// simulator имитирует платежный шлюз: задержку ответа, технические сбои,
+// отказы по правилам и платежи, ожидающие подтверждения.
+// Как и настоящий шлюз, он запоминает исход списания по UUID транзакции: повторный Charge
+// не списывает средства второй раз, а Status отдает исход списания, ответ на которое был потерян
+type simulator struct {
+	name string
+	cfg  config.SimulatorConfig
+	// charges исходы списаний по UUID транзакции
+	charges sync.Map
+}
+
+func NewProvider(name string, cfg config.SimulatorConfig) provider.PaymentProvider {
+	return &simulator{
+		name: name,
+		cfg:  cfg,
+	}
+}
+
+func (s *simulator) Name() string {
+	return s.name
+}
+
+func (s *simulator) Charge(ctx context.Context, req models.ChargeRequest) (models.ChargeResult, error) {
+	if err := s.wait(ctx); err != nil {
+		return models.ChargeResult{}, err
+	}
+
+	stored, _ := s.charges.LoadOrStore(req.TransactionUUID, s.decide(req))
+	result, _ := stored.(models.ChargeResult)
+
+	// Сбой имитируется после обработки списания: исход сохранен, но ответ до клиента не дошел
+	if hit(s.cfg.FailureRate()) {
+		return models.ChargeResult{}, ErrSimulatedFailure
+	}
+
+	return result, nil
+}
+
+func (s *simulator) Status(ctx context.Context, transactionUUID string) (models.ChargeResult, error) {
+	if err := s.wait(ctx); err != nil {
+		return models.ChargeResult{}, err
+	}
+
+	if hit(s.cfg.FailureRate()) {
+		return models.ChargeResult{}, ErrSimulatedFailure
+	}
+
+	stored, ok := s.charges.Load(transactionUUID)
+	if !ok {
+		return models.ChargeResult{}, apperrors.ErrChargeNotFound
+	}
+
+	result, _ := stored.(models.ChargeResult)
+
+	return result, nil
+}
+
+// decide определяет исход нового списания
+func (s *simulator) decide(req models.ChargeRequest) models.ChargeResult {
+	if reason := s.declineReason(req); reason != "" {
+		return models.ChargeResult{
+			Status:        models.TransactionStatusFailed,
+			DeclineReason: reason,
+		}
+	}
+
+	if hit(s.cfg.PendingRate()) {
+		return models.ChargeResult{
+			Status: models.TransactionStatusPending,
+		}
+	}
+
+	return models.ChargeResult{
+		Status: models.TransactionStatusCompleted,
+	}
+}
+
+func (s *simulator) Refund(ctx context.Context, req models.RefundRequest) error {
+	if err := s.wait(ctx); err != nil {
+		return err
+	}
+
+	if hit(s.cfg.FailureRate()) {
+		return ErrSimulatedFailure
+	}
+
+	return nil
+}
+
+// wait имитирует задержку ответа шлюза и прерывается по отмене контекста
+func (s *simulator) wait(ctx context.Context) error {
+	latency := s.cfg.Latency()
+	if jitter := s.cfg.LatencyJitter(); jitter > 0 {
+		latency += rand.N(jitter) //nolint:gosec // случайность симулятора не требует криптостойкости
+	}
+
+	if latency <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(latency)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (s *simulator) declineReason(req models.ChargeRequest) string {
+	if limit := s.cfg.DeclineAmountAbove(); limit > 0 && req.Amount > limit {
+		return fmt.Sprintf("amount %.2f exceeds limit %.2f", req.Amount, limit)
+	}
+
+	if slices.Contains(s.cfg.DeclineUsers(), req.UserID) {
+		return "user is blocked by issuer"
+	}
+
+	if slices.Contains(s.cfg.DeclineMethods(), req.PaymentMethod) {
+		return fmt.Sprintf("payment method %s is declined", req.PaymentMethod)
+	}
+
+	return ""
+}
+
+func hit(rate float64) bool {
+	return rate > 0 && rand.Float64() < rate //nolint:gosec // случайность симулятора не требует криптостойкости
+}
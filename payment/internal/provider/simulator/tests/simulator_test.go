@@ -0,0 +1,187 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/linemk/rocket-shop/payment/internal/entyties/apperrors"
+	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
+	"github.com/linemk/rocket-shop/payment/internal/provider/simulator"
+	payment_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/payment/v1"
+)
+
+type simulatorConfig struct {
+	latency            time.Duration
+	failureRate        float64
+	pendingRate        float64
+	declineAmountAbove float64
+	declineUsers       []string
+	declineMethods     []payment_v1.PaymentMethod
+}
+
+func (c simulatorConfig) Latency() time.Duration                     { return c.latency }
+func (c simulatorConfig) LatencyJitter() time.Duration               { return 0 }
+func (c simulatorConfig) FailureRate() float64                       { return c.failureRate }
+func (c simulatorConfig) PendingRate() float64                       { return c.pendingRate }
+func (c simulatorConfig) DeclineAmountAbove() float64                { return c.declineAmountAbove }
+func (c simulatorConfig) DeclineUsers() []string                     { return c.declineUsers }
+func (c simulatorConfig) DeclineMethods() []payment_v1.PaymentMethod { return c.declineMethods }
+
+func TestSimulatorCharge(t *testing.T) {
+	blockedUser := uuid.New().String()
+
+	req := models.ChargeRequest{
+		TransactionUUID: uuid.New().String(),
+		OrderUUID:       uuid.New().String(),
+		UserID:          uuid.New().String(),
+		PaymentMethod:   payment_v1.PaymentMethod_PAYMENT_METHOD_CARD,
+		Amount:          1500,
+	}
+
+	tests := []struct {
+		name       string
+		cfg        simulatorConfig
+		req        func() models.ChargeRequest
+		timeout    time.Duration
+		wantErr    error
+		wantStatus models.TransactionStatus
+	}{
+		{
+			name:       "approves payment by default",
+			cfg:        simulatorConfig{},
+			wantStatus: models.TransactionStatusCompleted,
+		},
+		{
+			name:       "declines payment above amount limit",
+			cfg:        simulatorConfig{declineAmountAbove: 1000},
+			wantStatus: models.TransactionStatusFailed,
+		},
+		{
+			name: "declines payment of blocked user",
+			cfg:  simulatorConfig{declineUsers: []string{blockedUser}},
+			req: func() models.ChargeRequest {
+				blocked := req
+				blocked.UserID = blockedUser
+				return blocked
+			},
+			wantStatus: models.TransactionStatusFailed,
+		},
+		{
+			name:       "declines payment by declined method",
+			cfg:        simulatorConfig{declineMethods: []payment_v1.PaymentMethod{payment_v1.PaymentMethod_PAYMENT_METHOD_CARD}},
+			wantStatus: models.TransactionStatusFailed,
+		},
+		{
+			name:       "leaves payment pending",
+			cfg:        simulatorConfig{pendingRate: 1},
+			wantStatus: models.TransactionStatusPending,
+		},
+		{
+			name:    "fails with simulated failure",
+			cfg:     simulatorConfig{failureRate: 1},
+			wantErr: simulator.ErrSimulatedFailure,
+		},
+		{
+			name:    "stops waiting on context deadline",
+			cfg:     simulatorConfig{latency: time.Second},
+			timeout: 10 * time.Millisecond,
+			wantErr: context.DeadlineExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+
+			chargeReq := req
+			if tt.req != nil {
+				chargeReq = tt.req()
+			}
+
+			result, err := simulator.NewProvider("simulator-test", tt.cfg).Charge(ctx, chargeReq)
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.wantStatus, result.Status)
+			if tt.wantStatus == models.TransactionStatusFailed {
+				require.NotEmpty(t, result.DeclineReason)
+			}
+		})
+	}
+}
+
+func TestSimulatorRefund(t *testing.T) {
+	req := models.RefundRequest{
+		TransactionUUID: uuid.New().String(),
+		OrderUUID:       uuid.New().String(),
+		UserID:          uuid.New().String(),
+		PaymentMethod:   payment_v1.PaymentMethod_PAYMENT_METHOD_CARD,
+		Amount:          1500,
+	}
+
+	t.Run("refunds payment", func(t *testing.T) {
+		require.NoError(t, simulator.NewProvider("simulator-test", simulatorConfig{}).Refund(context.Background(), req))
+	})
+
+	t.Run("fails with simulated failure", func(t *testing.T) {
+		err := simulator.NewProvider("simulator-test", simulatorConfig{failureRate: 1}).Refund(context.Background(), req)
+		require.ErrorIs(t, err, simulator.ErrSimulatedFailure)
+	})
+}
+
+func TestSimulatorStatus(t *testing.T) {
+	ctx := context.Background()
+
+	req := models.ChargeRequest{
+		TransactionUUID: uuid.New().String(),
+		OrderUUID:       uuid.New().String(),
+		UserID:          uuid.New().String(),
+		PaymentMethod:   payment_v1.PaymentMethod_PAYMENT_METHOD_CARD,
+		Amount:          1500,
+	}
+
+	t.Run("unknown charge is not found", func(t *testing.T) {
+		_, err := simulator.NewProvider("simulator-test", simulatorConfig{}).Status(ctx, req.TransactionUUID)
+		require.ErrorIs(t, err, apperrors.ErrChargeNotFound)
+	})
+
+	t.Run("returns outcome of charge with lost response", func(t *testing.T) {
+		cfg := &simulatorConfig{failureRate: 1}
+		paymentProvider := simulator.NewProvider("simulator-test", cfg)
+
+		_, err := paymentProvider.Charge(ctx, req)
+		require.ErrorIs(t, err, simulator.ErrSimulatedFailure)
+
+		cfg.failureRate = 0
+		result, err := paymentProvider.Status(ctx, req.TransactionUUID)
+		require.NoError(t, err)
+		require.Equal(t, models.TransactionStatusCompleted, result.Status)
+	})
+
+	t.Run("repeated charge returns first outcome", func(t *testing.T) {
+		cfg := &simulatorConfig{declineAmountAbove: 1000}
+		paymentProvider := simulator.NewProvider("simulator-test", cfg)
+
+		first, err := paymentProvider.Charge(ctx, req)
+		require.NoError(t, err)
+		require.Equal(t, models.TransactionStatusFailed, first.Status)
+
+		cfg.declineAmountAbove = 0
+		second, err := paymentProvider.Charge(ctx, req)
+		require.NoError(t, err)
+		require.Equal(t, first, second)
+	})
+}
@@ -0,0 +1,90 @@
+package approval
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/linemk/rocket-shop/payment/internal/entyties/apperrors"
+	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
+)
+
+const pgUniqueViolationCode = "23505"
+
+func (r *Repository) AddDecision(ctx context.Context, uuid string, decision models.ApprovalDecision) (err error) {
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx) //nolint:gosec // ошибка отката не важнее исходной
+		}
+	}()
+
+	// Блокировка запроса упорядочивает решения согласующих и не дает принять решение
+	// одновременно с его отменой по сроку
+	query, args, err := sq.Select("status", "expires_at").
+		From("approvals").
+		PlaceholderFormat(sq.Dollar).
+		Where(sq.Eq{"uuid": uuid}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	var status string
+	var expiresAt time.Time
+	if err = tx.QueryRow(ctx, query, args...).Scan(&status, &expiresAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return apperrors.ErrApprovalNotFound
+		}
+		return err
+	}
+
+	if models.ApprovalStatus(status) != models.ApprovalStatusPending || !decision.DecidedAt.Before(expiresAt) {
+		return apperrors.ErrApprovalNotPending
+	}
+
+	query, args, err = sq.Insert("approval_decisions").
+		PlaceholderFormat(sq.Dollar).
+		Columns("approval_uuid", "approver_id", "approved", "reason", "decided_at").
+		Values(uuid, decision.ApproverID, decision.Approved, decision.Reason, decision.DecidedAt).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	if _, err = tx.Exec(ctx, query, args...); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolationCode {
+			return apperrors.ErrAlreadyDecided
+		}
+		return err
+	}
+
+	query, args, err = sq.Update("approvals").
+		PlaceholderFormat(sq.Dollar).
+		Set("updated_at", decision.DecidedAt).
+		Where(sq.Eq{"uuid": uuid}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	if _, err = tx.Exec(ctx, query, args...); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit approval decision: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package approval
+
+import (
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
+)
+
+func approvalColumns() sq.SelectBuilder {
+	return sq.Select(
+		"uuid",
+		"transaction_uuid",
+		"order_uuid",
+		"user_uuid",
+		"amount",
+		"approvers",
+		"quorum",
+		"status",
+		"expires_at",
+		"created_at",
+		"updated_at",
+	)
+}
+
+func scanApproval(row pgx.Row) (models.Approval, error) {
+	var approval models.Approval
+	var amount int64
+	var status string
+
+	if err := row.Scan(
+		&approval.UUID,
+		&approval.TransactionUUID,
+		&approval.OrderUUID,
+		&approval.UserID,
+		&amount,
+		&approval.Approvers,
+		&approval.Quorum,
+		&status,
+		&approval.ExpiresAt,
+		&approval.CreatedAt,
+		&approval.UpdatedAt,
+	); err != nil {
+		return models.Approval{}, err
+	}
+
+	approval.Amount = models.FromMinorUnits(amount)
+	approval.Status = models.ApprovalStatus(status)
+
+	return approval, nil
+}
+
+func scanApprovals(rows pgx.Rows) ([]models.Approval, error) {
+	approvals := make([]models.Approval, 0)
+	for rows.Next() {
+		approval, err := scanApproval(rows)
+		if err != nil {
+			return nil, err
+		}
+		approvals = append(approvals, approval)
+	}
+
+	return approvals, rows.Err()
+}
@@ -0,0 +1,35 @@
+package approval
+
+import (
+	"context"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
+)
+
+func (r *Repository) CreateApproval(ctx context.Context, approval models.Approval) error {
+	query, args, err := sq.Insert("approvals").
+		PlaceholderFormat(sq.Dollar).
+		Columns("uuid", "transaction_uuid", "order_uuid", "user_uuid", "amount", "approvers", "quorum", "status", "expires_at", "created_at", "updated_at").
+		Values(
+			approval.UUID,
+			approval.TransactionUUID,
+			approval.OrderUUID,
+			approval.UserID,
+			models.ToMinorUnits(approval.Amount),
+			approval.Approvers,
+			approval.Quorum,
+			string(approval.Status),
+			approval.ExpiresAt,
+			approval.CreatedAt,
+			approval.UpdatedAt,
+		).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Exec(ctx, query, args...)
+	return err
+}
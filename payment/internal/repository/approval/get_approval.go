@@ -0,0 +1,67 @@
+package approval
+
+import (
+	"context"
+	"errors"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/linemk/rocket-shop/payment/internal/entyties/apperrors"
+	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
+)
+
+func (r *Repository) GetApproval(ctx context.Context, uuid string) (models.Approval, error) {
+	query, args, err := approvalColumns().
+		From("approvals").
+		PlaceholderFormat(sq.Dollar).
+		Where(sq.Eq{"uuid": uuid}).
+		ToSql()
+	if err != nil {
+		return models.Approval{}, err
+	}
+
+	approval, err := scanApproval(r.db.QueryRow(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Approval{}, apperrors.ErrApprovalNotFound
+		}
+		return models.Approval{}, err
+	}
+
+	approval.Decisions, err = r.listDecisions(ctx, approval.UUID)
+	if err != nil {
+		return models.Approval{}, err
+	}
+
+	return approval, nil
+}
+
+func (r *Repository) listDecisions(ctx context.Context, approvalUUID string) ([]models.ApprovalDecision, error) {
+	query, args, err := sq.Select("approver_id", "approved", "reason", "decided_at").
+		From("approval_decisions").
+		PlaceholderFormat(sq.Dollar).
+		Where(sq.Eq{"approval_uuid": approvalUUID}).
+		OrderBy("decided_at").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	decisions := make([]models.ApprovalDecision, 0)
+	for rows.Next() {
+		var decision models.ApprovalDecision
+		if err := rows.Scan(&decision.ApproverID, &decision.Approved, &decision.Reason, &decision.DecidedAt); err != nil {
+			return nil, err
+		}
+		decisions = append(decisions, decision)
+	}
+
+	return decisions, rows.Err()
+}
@@ -0,0 +1,32 @@
+package approval
+
+import (
+	"context"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
+)
+
+func (r *Repository) ListExpired(ctx context.Context, now time.Time, limit int) ([]models.Approval, error) {
+	query, args, err := approvalColumns().
+		From("approvals").
+		PlaceholderFormat(sq.Dollar).
+		Where(sq.Eq{"status": string(models.ApprovalStatusPending)}).
+		Where(sq.LtOrEq{"expires_at": now}).
+		OrderBy("expires_at").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanApprovals(rows)
+}
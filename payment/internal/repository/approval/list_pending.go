@@ -0,0 +1,31 @@
+package approval
+
+import (
+	"context"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
+)
+
+func (r *Repository) ListPending(ctx context.Context, approverID string) ([]models.Approval, error) {
+	query, args, err := approvalColumns().
+		From("approvals a").
+		PlaceholderFormat(sq.Dollar).
+		Where(sq.Eq{"status": string(models.ApprovalStatusPending)}).
+		Where(sq.Expr("? = ANY(approvers)", approverID)).
+		Where(sq.Expr("NOT EXISTS (SELECT 1 FROM approval_decisions d WHERE d.approval_uuid = a.uuid AND d.approver_id = ?)", approverID)).
+		OrderBy("created_at").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanApprovals(rows)
+}
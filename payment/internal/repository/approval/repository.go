@@ -0,0 +1,16 @@
+package approval
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Repository хранит запросы на согласование платежей и решения согласующих в PostgreSQL
+type Repository struct {
+	db *pgxpool.Pool
+}
+
+func NewRepository(db *pgxpool.Pool) *Repository {
+	return &Repository{
+		db: db,
+	}
+}
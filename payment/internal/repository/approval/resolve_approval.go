@@ -0,0 +1,35 @@
+package approval
+
+import (
+	"context"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/linemk/rocket-shop/payment/internal/entyties/apperrors"
+	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
+)
+
+func (r *Repository) ResolveApproval(ctx context.Context, uuid string, status models.ApprovalStatus, updatedAt time.Time) error {
+	// Итог фиксируется только для ожидающего запроса: из одновременных попыток завершить запрос выигрывает одна
+	query, args, err := sq.Update("approvals").
+		PlaceholderFormat(sq.Dollar).
+		Set("status", string(status)).
+		Set("updated_at", updatedAt).
+		Where(sq.Eq{"uuid": uuid, "status": string(models.ApprovalStatusPending)}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return apperrors.ErrApprovalNotPending
+	}
+
+	return nil
+}
@@ -0,0 +1,14 @@
+package fraud
+
+import (
+	"context"
+	"fmt"
+)
+
+func (r *Repository) DeleteUser(ctx context.Context, userID string) error {
+	if err := r.cache.Del(ctx, velocityKeyPrefix+userID, firstSeenKeyPrefix+userID); err != nil {
+		return fmt.Errorf("failed to delete payment history: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package fraud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
+	"github.com/linemk/rocket-shop/platform/pkg/cache/redis"
+)
+
+func (r *Repository) RecordAttempt(ctx context.Context, check models.FraudCheck, window, ttl time.Duration) (models.FraudUserStats, error) {
+	firstSeenAt, err := r.firstSeen(ctx, check, ttl)
+	if err != nil {
+		return models.FraudUserStats{}, err
+	}
+
+	key := velocityKeyPrefix + check.UserID
+	sortedSet := r.cache.SortedSetOperator()
+
+	// Попытки старше окна больше не влияют на проверку частоты
+	if err := sortedSet.ZRemRangeByScore(ctx, key, 0, float64(check.At.Add(-window).UnixMilli())); err != nil {
+		return models.FraudUserStats{}, fmt.Errorf("failed to trim payment attempts: %w", err)
+	}
+
+	if err := sortedSet.ZAdd(ctx, key, float64(check.At.UnixMilli()), check.TransactionUUID); err != nil {
+		return models.FraudUserStats{}, fmt.Errorf("failed to record payment attempt: %w", err)
+	}
+
+	if err := r.cache.Expire(ctx, key, window); err != nil {
+		return models.FraudUserStats{}, fmt.Errorf("failed to set payment attempts ttl: %w", err)
+	}
+
+	count, err := sortedSet.ZCard(ctx, key)
+	if err != nil {
+		return models.FraudUserStats{}, fmt.Errorf("failed to count payment attempts: %w", err)
+	}
+
+	return models.FraudUserStats{
+		RecentPayments: int(count),
+		FirstSeenAt:    firstSeenAt,
+	}, nil
+}
+
+// firstSeen возвращает время первой попытки оплаты пользователя, запоминая текущую попытку, если она первая
+func (r *Repository) firstSeen(ctx context.Context, check models.FraudCheck, ttl time.Duration) (time.Time, error) {
+	key := firstSeenKeyPrefix + check.UserID
+
+	stored, err := r.cache.SetNX(ctx, key, []byte(check.At.Format(time.RFC3339Nano)), ttl)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to save first payment time: %w", err)
+	}
+	if stored {
+		return check.At, nil
+	}
+
+	value, err := r.cache.Get(ctx, key)
+	if err != nil {
+		// Ключ мог истечь между SetNX и Get - считаем попытку первой
+		if errors.Is(err, redis.ErrKeyNotFound) {
+			return check.At, nil
+		}
+		return time.Time{}, fmt.Errorf("failed to get first payment time: %w", err)
+	}
+
+	// Активный пользователь не должен снова становиться новым по истечении срока хранения
+	if err := r.cache.Expire(ctx, key, ttl); err != nil {
+		return time.Time{}, fmt.Errorf("failed to set first payment time ttl: %w", err)
+	}
+
+	firstSeenAt, err := time.Parse(time.RFC3339Nano, string(value))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse first payment time: %w", err)
+	}
+
+	return firstSeenAt, nil
+}
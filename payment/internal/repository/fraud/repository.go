@@ -0,0 +1,23 @@
+package fraud
+
+import (
+	"github.com/linemk/rocket-shop/platform/pkg/cache"
+)
+
+const (
+	// velocityKeyPrefix упорядоченное множество попыток оплаты пользователя с временем попытки в качестве веса
+	velocityKeyPrefix = "payment:fraud:velocity:"
+	// firstSeenKeyPrefix время первой попытки оплаты пользователя
+	firstSeenKeyPrefix = "payment:fraud:first_seen:"
+)
+
+// Repository хранит историю платежей пользователей для антифрод-проверки в Redis
+type Repository struct {
+	cache cache.Client
+}
+
+func NewRepository(cache cache.Client) *Repository {
+	return &Repository{
+		cache: cache,
+	}
+}
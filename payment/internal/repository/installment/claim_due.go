@@ -0,0 +1,47 @@
+package installment
+
+import (
+	"context"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
+)
+
+func (r *Repository) ClaimDue(ctx context.Context, now, leaseUntil time.Time, limit int) ([]models.Installment, error) {
+	// SKIP LOCKED не дает двум экземплярам сервиса одновременно забрать один платеж,
+	// а перенос next_attempt_at скрывает его от следующих выборок до окончания попытки
+	due := sq.Select("i.plan_uuid", "i.number").
+		From("installments i").
+		Join("installment_plans p ON p.uuid = i.plan_uuid").
+		Where(sq.NotEq{"i.status": string(models.InstallmentStatusPaid)}).
+		Where(sq.Eq{"p.status": string(models.InstallmentPlanStatusActive)}).
+		Where(sq.LtOrEq{"i.next_attempt_at": now}).
+		OrderBy("i.next_attempt_at").
+		Limit(uint64(limit)).
+		Suffix("FOR UPDATE OF i SKIP LOCKED")
+
+	dueSQL, dueArgs, err := due.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	query, args, err := sq.Update("installments").
+		PlaceholderFormat(sq.Dollar).
+		Set("next_attempt_at", leaseUntil).
+		Where(sq.Expr("(plan_uuid, number) IN ("+dueSQL+")", dueArgs...)).
+		Suffix("RETURNING plan_uuid, number, amount, due_at, status, transaction_uuid, attempts, next_attempt_at, paid_at, failure_reason").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanInstallments(rows)
+}
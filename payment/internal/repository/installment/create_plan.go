@@ -0,0 +1,85 @@
+package installment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/linemk/rocket-shop/payment/internal/entyties/apperrors"
+	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
+)
+
+const pgUniqueViolationCode = "23505"
+
+func (r *Repository) CreatePlan(ctx context.Context, plan models.InstallmentPlan) (err error) {
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx) //nolint:gosec // ошибка отката не важнее исходной
+		}
+	}()
+
+	query, args, err := sq.Insert("installment_plans").
+		PlaceholderFormat(sq.Dollar).
+		Columns("uuid", "order_uuid", "user_uuid", "payment_method", "principal", "monthly_rate", "total_amount", "status", "created_at", "updated_at").
+		Values(
+			plan.UUID,
+			plan.OrderUUID,
+			plan.UserID,
+			plan.PaymentMethod.String(),
+			models.ToMinorUnits(plan.Principal),
+			plan.MonthlyRate,
+			models.ToMinorUnits(plan.TotalAmount),
+			string(plan.Status),
+			plan.CreatedAt,
+			plan.UpdatedAt,
+		).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	if _, err = tx.Exec(ctx, query, args...); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolationCode {
+			return apperrors.ErrInstallmentPlanExists
+		}
+		return err
+	}
+
+	installments := sq.Insert("installments").
+		PlaceholderFormat(sq.Dollar).
+		Columns("plan_uuid", "number", "amount", "due_at", "status", "next_attempt_at")
+	for _, installment := range plan.Installments {
+		installments = installments.Values(
+			plan.UUID,
+			installment.Number,
+			models.ToMinorUnits(installment.Amount),
+			installment.DueAt,
+			string(installment.Status),
+			installment.NextAttemptAt,
+		)
+	}
+
+	query, args, err = installments.ToSql()
+	if err != nil {
+		return err
+	}
+
+	if _, err = tx.Exec(ctx, query, args...); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit installment plan: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package installment
+
+import (
+	"context"
+	"errors"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/linemk/rocket-shop/payment/internal/entyties/apperrors"
+	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
+	payment_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/payment/v1"
+)
+
+func (r *Repository) GetPlan(ctx context.Context, uuid string) (models.InstallmentPlan, error) {
+	query, args, err := sq.Select(
+		"uuid",
+		"order_uuid",
+		"user_uuid",
+		"payment_method",
+		"principal",
+		"monthly_rate::float8",
+		"total_amount",
+		"status",
+		"created_at",
+		"updated_at",
+	).
+		From("installment_plans").
+		PlaceholderFormat(sq.Dollar).
+		Where(sq.Eq{"uuid": uuid}).
+		ToSql()
+	if err != nil {
+		return models.InstallmentPlan{}, err
+	}
+
+	var plan models.InstallmentPlan
+	var paymentMethod, status string
+	var principal, totalAmount int64
+
+	err = r.db.QueryRow(ctx, query, args...).Scan(
+		&plan.UUID,
+		&plan.OrderUUID,
+		&plan.UserID,
+		&paymentMethod,
+		&principal,
+		&plan.MonthlyRate,
+		&totalAmount,
+		&status,
+		&plan.CreatedAt,
+		&plan.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.InstallmentPlan{}, apperrors.ErrInstallmentPlanNotFound
+		}
+		return models.InstallmentPlan{}, err
+	}
+
+	plan.PaymentMethod = payment_v1.PaymentMethod(payment_v1.PaymentMethod_value[paymentMethod])
+	plan.Principal = models.FromMinorUnits(principal)
+	plan.TotalAmount = models.FromMinorUnits(totalAmount)
+	plan.Status = models.InstallmentPlanStatus(status)
+
+	plan.Installments, err = r.listInstallments(ctx, plan.UUID)
+	if err != nil {
+		return models.InstallmentPlan{}, err
+	}
+
+	return plan, nil
+}
+
+func (r *Repository) listInstallments(ctx context.Context, planUUID string) ([]models.Installment, error) {
+	query, args, err := installmentColumns().
+		From("installments").
+		PlaceholderFormat(sq.Dollar).
+		Where(sq.Eq{"plan_uuid": planUUID}).
+		OrderBy("number").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanInstallments(rows)
+}
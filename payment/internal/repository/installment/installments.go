@@ -0,0 +1,59 @@
+package installment
+
+import (
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
+)
+
+func installmentColumns() sq.SelectBuilder {
+	return sq.Select(
+		"plan_uuid",
+		"number",
+		"amount",
+		"due_at",
+		"status",
+		"transaction_uuid",
+		"attempts",
+		"next_attempt_at",
+		"paid_at",
+		"failure_reason",
+	)
+}
+
+func scanInstallments(rows pgx.Rows) ([]models.Installment, error) {
+	installments := make([]models.Installment, 0)
+	for rows.Next() {
+		var installment models.Installment
+		var amount int64
+		var status string
+		var paidAt *time.Time
+
+		if err := rows.Scan(
+			&installment.PlanUUID,
+			&installment.Number,
+			&amount,
+			&installment.DueAt,
+			&status,
+			&installment.TransactionUUID,
+			&installment.Attempts,
+			&installment.NextAttemptAt,
+			&paidAt,
+			&installment.FailureReason,
+		); err != nil {
+			return nil, err
+		}
+
+		installment.Amount = models.FromMinorUnits(amount)
+		installment.Status = models.InstallmentStatus(status)
+		if paidAt != nil {
+			installment.PaidAt = *paidAt
+		}
+		installments = append(installments, installment)
+	}
+
+	return installments, rows.Err()
+}
@@ -0,0 +1,42 @@
+package installment
+
+import (
+	"context"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/linemk/rocket-shop/payment/internal/entyties/apperrors"
+	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
+)
+
+func (r *Repository) UpdateInstallment(ctx context.Context, installment models.Installment) error {
+	var paidAt any
+	if !installment.PaidAt.IsZero() {
+		paidAt = installment.PaidAt
+	}
+
+	query, args, err := sq.Update("installments").
+		PlaceholderFormat(sq.Dollar).
+		Set("status", string(installment.Status)).
+		Set("transaction_uuid", installment.TransactionUUID).
+		Set("attempts", installment.Attempts).
+		Set("next_attempt_at", installment.NextAttemptAt).
+		Set("paid_at", paidAt).
+		Set("failure_reason", installment.FailureReason).
+		Where(sq.Eq{"plan_uuid": installment.PlanUUID, "number": installment.Number}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return apperrors.ErrInstallmentPlanNotFound
+	}
+
+	return nil
+}
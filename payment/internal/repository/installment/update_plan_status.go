@@ -0,0 +1,34 @@
+package installment
+
+import (
+	"context"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/linemk/rocket-shop/payment/internal/entyties/apperrors"
+	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
+)
+
+func (r *Repository) UpdatePlanStatus(ctx context.Context, uuid string, status models.InstallmentPlanStatus, updatedAt time.Time) error {
+	query, args, err := sq.Update("installment_plans").
+		PlaceholderFormat(sq.Dollar).
+		Set("status", string(status)).
+		Set("updated_at", updatedAt).
+		Where(sq.Eq{"uuid": uuid}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return apperrors.ErrInstallmentPlanNotFound
+	}
+
+	return nil
+}
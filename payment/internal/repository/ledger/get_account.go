@@ -0,0 +1,38 @@
+package ledger
+
+import (
+	"context"
+	"errors"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/linemk/rocket-shop/payment/internal/entyties/apperrors"
+	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
+)
+
+func (r *Repository) GetAccount(ctx context.Context, code string) (models.LedgerAccount, error) {
+	query, args, err := sq.Select("code", "name", "type", "created_at").
+		From("ledger_accounts").
+		PlaceholderFormat(sq.Dollar).
+		Where(sq.Eq{"code": code}).
+		ToSql()
+	if err != nil {
+		return models.LedgerAccount{}, err
+	}
+
+	var account models.LedgerAccount
+	var accountType string
+
+	err = r.db.QueryRow(ctx, query, args...).Scan(&account.Code, &account.Name, &accountType, &account.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.LedgerAccount{}, apperrors.ErrAccountNotFound
+		}
+		return models.LedgerAccount{}, err
+	}
+
+	account.Type = models.AccountType(accountType)
+
+	return account, nil
+}
@@ -0,0 +1,36 @@
+package ledger
+
+import (
+	"context"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
+)
+
+func (r *Repository) GetTotals(ctx context.Context, code string, before time.Time) (models.PostingTotals, error) {
+	builder := sq.Select(
+		"COALESCE(SUM(amount) FILTER (WHERE direction = 'DEBIT'), 0)",
+		"COALESCE(SUM(amount) FILTER (WHERE direction = 'CREDIT'), 0)",
+	).
+		From("ledger_postings").
+		PlaceholderFormat(sq.Dollar).
+		Where(sq.Eq{"account_code": code})
+
+	if !before.IsZero() {
+		builder = builder.Where(sq.Lt{"created_at": before})
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return models.PostingTotals{}, err
+	}
+
+	var totals models.PostingTotals
+	if err := r.db.QueryRow(ctx, query, args...).Scan(&totals.Debit, &totals.Credit); err != nil {
+		return models.PostingTotals{}, err
+	}
+
+	return totals, nil
+}
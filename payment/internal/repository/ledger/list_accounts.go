@@ -0,0 +1,41 @@
+package ledger
+
+import (
+	"context"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
+)
+
+func (r *Repository) ListAccounts(ctx context.Context) ([]models.LedgerAccount, error) {
+	query, args, err := sq.Select("code", "name", "type", "created_at").
+		From("ledger_accounts").
+		PlaceholderFormat(sq.Dollar).
+		OrderBy("code").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	accounts := make([]models.LedgerAccount, 0)
+	for rows.Next() {
+		var account models.LedgerAccount
+		var accountType string
+
+		if err := rows.Scan(&account.Code, &account.Name, &accountType, &account.CreatedAt); err != nil {
+			return nil, err
+		}
+
+		account.Type = models.AccountType(accountType)
+		accounts = append(accounts, account)
+	}
+
+	return accounts, rows.Err()
+}
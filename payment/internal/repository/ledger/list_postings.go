@@ -0,0 +1,69 @@
+package ledger
+
+import (
+	"context"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
+)
+
+func (r *Repository) ListPostings(ctx context.Context, code string, from, to time.Time) ([]models.AccountPosting, error) {
+	builder := sq.Select(
+		"p.entry_uuid",
+		"e.type",
+		"COALESCE(e.transaction_uuid, '')",
+		"e.description",
+		"p.direction",
+		"p.amount",
+		"p.created_at",
+	).
+		From("ledger_postings p").
+		Join("ledger_entries e ON e.uuid = p.entry_uuid").
+		PlaceholderFormat(sq.Dollar).
+		Where(sq.Eq{"p.account_code": code}).
+		OrderBy("p.created_at", "p.id")
+
+	if !from.IsZero() {
+		builder = builder.Where(sq.GtOrEq{"p.created_at": from})
+	}
+	if !to.IsZero() {
+		builder = builder.Where(sq.Lt{"p.created_at": to})
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	postings := make([]models.AccountPosting, 0)
+	for rows.Next() {
+		var posting models.AccountPosting
+		var entryType, direction string
+
+		if err := rows.Scan(
+			&posting.EntryUUID,
+			&entryType,
+			&posting.TransactionUUID,
+			&posting.Description,
+			&direction,
+			&posting.Amount,
+			&posting.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+
+		posting.EntryType = models.EntryType(entryType)
+		posting.Direction = models.Direction(direction)
+		postings = append(postings, posting)
+	}
+
+	return postings, rows.Err()
+}
@@ -0,0 +1,88 @@
+package ledger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/linemk/rocket-shop/payment/internal/entyties/apperrors"
+	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
+)
+
+const (
+	pgUniqueViolationCode     = "23505"
+	pgForeignKeyViolationCode = "23503"
+)
+
+func (r *Repository) PostEntry(ctx context.Context, entry models.JournalEntry) (err error) {
+	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx) //nolint:gosec // ошибка отката не важнее исходной
+		}
+	}()
+
+	var transactionUUID *string
+	if entry.TransactionUUID != "" {
+		transactionUUID = &entry.TransactionUUID
+	}
+
+	query, args, err := sq.Insert("ledger_entries").
+		PlaceholderFormat(sq.Dollar).
+		Columns("uuid", "type", "transaction_uuid", "description", "created_at").
+		Values(entry.UUID, string(entry.Type), transactionUUID, entry.Description, entry.CreatedAt).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	if _, err = tx.Exec(ctx, query, args...); err != nil {
+		return mapPgError(err)
+	}
+
+	postings := sq.Insert("ledger_postings").
+		PlaceholderFormat(sq.Dollar).
+		Columns("entry_uuid", "account_code", "direction", "amount", "created_at")
+	for _, posting := range entry.Postings {
+		postings = postings.Values(entry.UUID, posting.AccountCode, string(posting.Direction), posting.Amount, entry.CreatedAt)
+	}
+
+	query, args, err = postings.ToSql()
+	if err != nil {
+		return err
+	}
+
+	if _, err = tx.Exec(ctx, query, args...); err != nil {
+		return mapPgError(err)
+	}
+
+	// Баланс записи дополнительно проверяется отложенным триггером при фиксации
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit journal entry: %w", err)
+	}
+
+	return nil
+}
+
+func mapPgError(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+
+	switch pgErr.Code {
+	case pgUniqueViolationCode:
+		return apperrors.ErrEntryAlreadyExists
+	case pgForeignKeyViolationCode:
+		return apperrors.ErrAccountNotFound
+	default:
+		return err
+	}
+}
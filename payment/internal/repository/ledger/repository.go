@@ -0,0 +1,16 @@
+package ledger
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Repository хранит бухгалтерскую книгу платежей в PostgreSQL
+type Repository struct {
+	db *pgxpool.Pool
+}
+
+func NewRepository(db *pgxpool.Pool) *Repository {
+	return &Repository{
+		db: db,
+	}
+}
@@ -0,0 +1,27 @@
+package payment
+
+import (
+	"context"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+func (r *Repository) AnonymizeUser(ctx context.Context, userID, replacement string) (int, error) {
+	query, args, err := sq.Update("transactions").
+		PlaceholderFormat(sq.Dollar).
+		Set("user_uuid", replacement).
+		Set("updated_at", time.Now()).
+		Where(sq.Eq{"user_uuid": userID}).
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := r.db.Exec(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(result.RowsAffected()), nil
+}
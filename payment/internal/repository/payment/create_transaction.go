@@ -0,0 +1,65 @@
+package payment
+
+import (
+	"context"
+	"errors"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/linemk/rocket-shop/payment/internal/entyties/apperrors"
+	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
+)
+
+func (r *Repository) CreateTransaction(ctx context.Context, transaction models.Transaction) error {
+	query, args, err := sq.Insert("transactions").
+		PlaceholderFormat(sq.Dollar).
+		Columns(
+			"uuid",
+			"order_uuid",
+			"user_uuid",
+			"payment_method",
+			"amount",
+			"status",
+			"failure_reason",
+			"fraud_decision",
+			"fraud_reasons",
+			"installment_plan_uuid",
+			"approval_uuid",
+			"split_uuid",
+			"reviewed_by",
+			"created_at",
+			"updated_at",
+		).
+		Values(
+			transaction.UUID,
+			transaction.OrderUUID,
+			transaction.UserID,
+			transaction.PaymentMethod.String(),
+			models.ToMinorUnits(transaction.Amount),
+			string(transaction.Status),
+			transaction.FailureReason,
+			string(transaction.FraudDecision),
+			fraudReasons(transaction),
+			transaction.InstallmentPlanUUID,
+			transaction.ApprovalUUID,
+			transaction.SplitUUID,
+			transaction.ReviewedBy,
+			transaction.CreatedAt,
+			transaction.UpdatedAt,
+		).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	if _, err = r.db.Exec(ctx, query, args...); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolationCode {
+			return apperrors.ErrTransactionAlreadyExists
+		}
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/linemk/rocket-shop/payment/internal/entyties/apperrors"
+	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
+)
+
+func (r *Repository) GetTransaction(ctx context.Context, uuid string) (models.Transaction, error) {
+	if !validUUID(uuid) {
+		return models.Transaction{}, fmt.Errorf("%w: %s", apperrors.ErrTransactionNotFound, uuid)
+	}
+
+	query, args, err := transactionColumns().
+		From("transactions").
+		PlaceholderFormat(sq.Dollar).
+		Where(sq.Eq{"uuid": uuid}).
+		ToSql()
+	if err != nil {
+		return models.Transaction{}, err
+	}
+
+	transaction, err := scanTransaction(r.db.QueryRow(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.Transaction{}, fmt.Errorf("%w: %s", apperrors.ErrTransactionNotFound, uuid)
+		}
+		return models.Transaction{}, err
+	}
+
+	return transaction, nil
+}
@@ -0,0 +1,32 @@
+package payment
+
+import (
+	"context"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
+)
+
+func (r *Repository) ListAwaitingReview(ctx context.Context) ([]models.Transaction, error) {
+	query, args, err := transactionColumns().
+		From("transactions").
+		PlaceholderFormat(sq.Dollar).
+		Where(sq.Eq{
+			"status":         string(models.TransactionStatusPending),
+			"fraud_decision": string(models.FraudDecisionReview),
+		}).
+		OrderBy("created_at", "uuid").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanTransactions(rows)
+}
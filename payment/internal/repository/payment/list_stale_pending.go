@@ -0,0 +1,35 @@
+package payment
+
+import (
+	"context"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
+)
+
+func (r *Repository) ListStalePending(ctx context.Context, createdBefore time.Time, after models.Transaction, limit int) ([]models.Transaction, error) {
+	builder := transactionColumns().
+		From("transactions").
+		PlaceholderFormat(sq.Dollar).
+		Where(sq.Eq{"status": string(models.TransactionStatusPending), "installment_plan_uuid": ""}).
+		Where(sq.NotEq{"fraud_decision": string(models.FraudDecisionReview)}).
+		Where(sq.Lt{"created_at": createdBefore})
+
+	query, args, err := createdAfter(builder, after).
+		OrderBy("created_at", "uuid").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanTransactions(rows)
+}
@@ -0,0 +1,37 @@
+package payment
+
+import (
+	"context"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
+)
+
+func (r *Repository) ListTransactions(ctx context.Context, orderUUID string) ([]models.Transaction, error) {
+	return r.listTransactions(ctx, sq.Eq{"order_uuid": orderUUID})
+}
+
+func (r *Repository) ListUserTransactions(ctx context.Context, userID string) ([]models.Transaction, error) {
+	return r.listTransactions(ctx, sq.Eq{"user_uuid": userID})
+}
+
+func (r *Repository) listTransactions(ctx context.Context, where sq.Eq) ([]models.Transaction, error) {
+	query, args, err := transactionColumns().
+		From("transactions").
+		PlaceholderFormat(sq.Dollar).
+		Where(where).
+		OrderBy("created_at", "uuid").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanTransactions(rows)
+}
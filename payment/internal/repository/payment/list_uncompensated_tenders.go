@@ -0,0 +1,39 @@
+package payment
+
+import (
+	"context"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
+)
+
+func (r *Repository) ListUncompensatedTenders(ctx context.Context, after models.Transaction, limit int) ([]models.Transaction, error) {
+	builder := transactionColumns().
+		From("transactions t").
+		PlaceholderFormat(sq.Dollar).
+		Where(sq.Eq{"status": string(models.TransactionStatusCompleted)}).
+		Where(sq.NotEq{"split_uuid": ""}).
+		Where(sq.Expr(
+			"EXISTS (SELECT 1 FROM transactions f WHERE f.split_uuid = t.split_uuid AND f.status IN (?, ?, ?))",
+			string(models.TransactionStatusFailed),
+			string(models.TransactionStatusCancelled),
+			string(models.TransactionStatusRefunded),
+		))
+
+	query, args, err := createdAfter(builder, after).
+		OrderBy("created_at", "uuid").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanTransactions(rows)
+}
@@ -0,0 +1,105 @@
+package payment
+
+import (
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
+	payment_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/payment/v1"
+)
+
+func transactionColumns() sq.SelectBuilder {
+	return sq.Select(
+		"uuid",
+		"order_uuid",
+		"user_uuid",
+		"payment_method",
+		"amount",
+		"status",
+		"failure_reason",
+		"fraud_decision",
+		"fraud_reasons",
+		"installment_plan_uuid",
+		"approval_uuid",
+		"split_uuid",
+		"reviewed_by",
+		"created_at",
+		"updated_at",
+	)
+}
+
+func scanTransaction(row pgx.Row) (models.Transaction, error) {
+	var transaction models.Transaction
+	var paymentMethod, status, fraudDecision string
+	var amount int64
+
+	if err := row.Scan(
+		&transaction.UUID,
+		&transaction.OrderUUID,
+		&transaction.UserID,
+		&paymentMethod,
+		&amount,
+		&status,
+		&transaction.FailureReason,
+		&fraudDecision,
+		&transaction.FraudReasons,
+		&transaction.InstallmentPlanUUID,
+		&transaction.ApprovalUUID,
+		&transaction.SplitUUID,
+		&transaction.ReviewedBy,
+		&transaction.CreatedAt,
+		&transaction.UpdatedAt,
+	); err != nil {
+		return models.Transaction{}, err
+	}
+
+	transaction.PaymentMethod = payment_v1.PaymentMethod(payment_v1.PaymentMethod_value[paymentMethod])
+	transaction.Amount = models.FromMinorUnits(amount)
+	transaction.Status = models.TransactionStatus(status)
+	transaction.FraudDecision = models.FraudDecision(fraudDecision)
+	if len(transaction.FraudReasons) == 0 {
+		transaction.FraudReasons = nil
+	}
+
+	return transaction, nil
+}
+
+func scanTransactions(rows pgx.Rows) ([]models.Transaction, error) {
+	transactions := make([]models.Transaction, 0)
+	for rows.Next() {
+		transaction, err := scanTransaction(rows)
+		if err != nil {
+			return nil, err
+		}
+		transactions = append(transactions, transaction)
+	}
+
+	return transactions, rows.Err()
+}
+
+// fraudReasons возвращает правила антифрод-проверки для записи: столбец не допускает NULL
+func fraudReasons(transaction models.Transaction) []string {
+	if transaction.FraudReasons == nil {
+		return []string{}
+	}
+
+	return transaction.FraudReasons
+}
+
+// createdAfter условие выборки транзакций, следующих за after в порядке создания;
+// UUID упорядочивает созданные одновременно. Нулевое значение after означает выборку с начала
+func createdAfter(builder sq.SelectBuilder, after models.Transaction) sq.SelectBuilder {
+	if after.UUID == "" {
+		return builder
+	}
+
+	return builder.Where(sq.Expr("(created_at, uuid) > (?, ?::uuid)", after.CreatedAt, after.UUID))
+}
+
+// validUUID проверяет, что значение может быть UUID транзакции: с другим значением запрос завершился бы ошибкой
+// приведения типа вместо отсутствия транзакции
+func validUUID(value string) bool {
+	_, err := uuid.Parse(value)
+	return err == nil
+}
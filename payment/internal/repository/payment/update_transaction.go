@@ -0,0 +1,49 @@
+package payment
+
+import (
+	"context"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/linemk/rocket-shop/payment/internal/entyties/apperrors"
+	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
+)
+
+func (r *Repository) UpdateTransaction(ctx context.Context, uuid string, transaction models.Transaction) error {
+	if !validUUID(uuid) {
+		return fmt.Errorf("%w: %s", apperrors.ErrTransactionNotFound, uuid)
+	}
+
+	query, args, err := sq.Update("transactions").
+		PlaceholderFormat(sq.Dollar).
+		Set("order_uuid", transaction.OrderUUID).
+		Set("user_uuid", transaction.UserID).
+		Set("payment_method", transaction.PaymentMethod.String()).
+		Set("amount", models.ToMinorUnits(transaction.Amount)).
+		Set("status", string(transaction.Status)).
+		Set("failure_reason", transaction.FailureReason).
+		Set("fraud_decision", string(transaction.FraudDecision)).
+		Set("fraud_reasons", fraudReasons(transaction)).
+		Set("installment_plan_uuid", transaction.InstallmentPlanUUID).
+		Set("approval_uuid", transaction.ApprovalUUID).
+		Set("split_uuid", transaction.SplitUUID).
+		Set("reviewed_by", transaction.ReviewedBy).
+		Set("updated_at", transaction.UpdatedAt).
+		Where(sq.Eq{"uuid": uuid}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("%w: %s", apperrors.ErrTransactionNotFound, uuid)
+	}
+
+	return nil
+}
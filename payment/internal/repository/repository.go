@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
 )
@@ -12,4 +13,68 @@ type PaymentRepository interface {
 	GetTransaction(ctx context.Context, uuid string) (models.Transaction, error)
 	UpdateTransaction(ctx context.Context, uuid string, transaction models.Transaction) error
 	ListTransactions(ctx context.Context, orderUUID string) ([]models.Transaction, error)
+	ListUserTransactions(ctx context.Context, userID string) ([]models.Transaction, error)
+	// ListAwaitingReview возвращает PENDING транзакции, задержанные антифрод-проверкой, в порядке создания
+	ListAwaitingReview(ctx context.Context) ([]models.Transaction, error)
+	// ListStalePending возвращает до limit PENDING транзакций, созданных до createdBefore и не ожидающих ручной проверки,
+	// в порядке создания, начиная со следующей за after (нулевое значение - с начала). Платежи по графику рассрочки
+	// не возвращаются: их повторяет фоновое списание рассрочки
+	ListStalePending(ctx context.Context, createdBefore time.Time, after models.Transaction, limit int) ([]models.Transaction, error)
+	// ListUncompensatedTenders возвращает до limit списанных частей раздельных оплат, группа которых не прошла,
+	// в порядке создания, начиная со следующей за after (нулевое значение - с начала)
+	ListUncompensatedTenders(ctx context.Context, after models.Transaction, limit int) ([]models.Transaction, error)
+	// AnonymizeUser заменяет UserID во всех транзакциях пользователя и возвращает число измененных транзакций
+	AnonymizeUser(ctx context.Context, userID, replacement string) (int, error)
+}
+
+// LedgerRepository определяет интерфейс бухгалтерской книги платежей
+type LedgerRepository interface {
+	// PostEntry атомарно сохраняет запись журнала вместе с ее проводками
+	PostEntry(ctx context.Context, entry models.JournalEntry) error
+	ListAccounts(ctx context.Context) ([]models.LedgerAccount, error)
+	GetAccount(ctx context.Context, code string) (models.LedgerAccount, error)
+	// GetTotals возвращает обороты счета по проводкам до момента before (нулевое значение - по всем проводкам)
+	GetTotals(ctx context.Context, code string, before time.Time) (models.PostingTotals, error)
+	// ListPostings возвращает проводки по счету за период [from, to) в хронологическом порядке
+	ListPostings(ctx context.Context, code string, from, to time.Time) ([]models.AccountPosting, error)
+}
+
+// FraudRepository хранит историю платежей пользователей для антифрод-проверки
+type FraudRepository interface {
+	// RecordAttempt учитывает попытку оплаты и возвращает историю пользователя с ее учетом.
+	// Попытки старше window не учитываются, время первой попытки хранится ttl с момента последней
+	RecordAttempt(ctx context.Context, check models.FraudCheck, window, ttl time.Duration) (models.FraudUserStats, error)
+	// DeleteUser удаляет историю платежей пользователя
+	DeleteUser(ctx context.Context, userID string) error
+}
+
+// InstallmentRepository хранит планы рассрочки и графики их платежей
+type InstallmentRepository interface {
+	// CreatePlan атомарно сохраняет план вместе с графиком платежей
+	CreatePlan(ctx context.Context, plan models.InstallmentPlan) error
+	GetPlan(ctx context.Context, uuid string) (models.InstallmentPlan, error)
+	// ClaimDue выбирает до limit неоплаченных платежей активных планов, срок попытки которых наступил к now,
+	// и откладывает их следующую попытку до leaseUntil, чтобы их не забрал другой экземпляр сервиса
+	ClaimDue(ctx context.Context, now, leaseUntil time.Time, limit int) ([]models.Installment, error)
+	UpdateInstallment(ctx context.Context, installment models.Installment) error
+	UpdatePlanStatus(ctx context.Context, uuid string, status models.InstallmentPlanStatus, updatedAt time.Time) error
+	// AnonymizeUser заменяет UserID во всех планах пользователя и возвращает число измененных планов
+	AnonymizeUser(ctx context.Context, userID, replacement string) (int, error)
+}
+
+// ApprovalRepository хранит запросы на согласование платежей средствами инвестора
+type ApprovalRepository interface {
+	CreateApproval(ctx context.Context, approval models.Approval) error
+	// GetApproval возвращает запрос вместе с решениями согласующих
+	GetApproval(ctx context.Context, uuid string) (models.Approval, error)
+	// AddDecision сохраняет решение согласующего, если запрос ожидает решений и его срок не истек к моменту решения
+	AddDecision(ctx context.Context, uuid string, decision models.ApprovalDecision) error
+	// ResolveApproval фиксирует итог ожидающего запроса; для уже завершенного запроса возвращает ErrApprovalNotPending
+	ResolveApproval(ctx context.Context, uuid string, status models.ApprovalStatus, updatedAt time.Time) error
+	// ListPending возвращает ожидающие запросы, по которым согласующий еще не принял решение, в порядке создания
+	ListPending(ctx context.Context, approverID string) ([]models.Approval, error)
+	// ListExpired возвращает до limit ожидающих запросов, срок которых истек к now
+	ListExpired(ctx context.Context, now time.Time, limit int) ([]models.Approval, error)
+	// AnonymizeUser заменяет UserID во всех запросах пользователя и возвращает число измененных запросов
+	AnonymizeUser(ctx context.Context, userID, replacement string) (int, error)
 }
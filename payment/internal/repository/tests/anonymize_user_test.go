@@ -0,0 +1,61 @@
+//go:build integration
+// +build integration
+
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
+	payment_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/payment/v1"
+)
+
+func TestAnonymizeUser(t *testing.T) {
+	ctx := context.Background()
+
+	repo := newTransactionRepository(ctx, t)
+
+	userID := uuid.New().String()
+	otherUserID := uuid.New().String()
+
+	for _, owner := range []string{userID, userID, otherUserID} {
+		err := repo.CreateTransaction(ctx, models.Transaction{
+			UUID:          uuid.New().String(),
+			OrderUUID:     uuid.New().String(),
+			UserID:        owner,
+			PaymentMethod: payment_v1.PaymentMethod_PAYMENT_METHOD_CARD,
+			Amount:        10.00,
+			Status:        models.TransactionStatusCompleted,
+			CreatedAt:     time.Now(),
+			UpdatedAt:     time.Now(),
+		})
+		require.NoError(t, err)
+	}
+
+	transactions, err := repo.ListUserTransactions(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, transactions, 2)
+
+	affected, err := repo.AnonymizeUser(ctx, userID, models.ErasedUserID)
+	require.NoError(t, err)
+	require.Equal(t, 2, affected)
+
+	transactions, err = repo.ListUserTransactions(ctx, userID)
+	require.NoError(t, err)
+	require.Empty(t, transactions)
+
+	// Транзакции сохраняются, но отвязываются от пользователя
+	transactions, err = repo.ListUserTransactions(ctx, models.ErasedUserID)
+	require.NoError(t, err)
+	require.Len(t, transactions, 2)
+
+	// Транзакции других пользователей не затрагиваются
+	transactions, err = repo.ListUserTransactions(ctx, otherUserID)
+	require.NoError(t, err)
+	require.Len(t, transactions, 1)
+}
@@ -1,3 +1,6 @@
+//go:build integration
+// +build integration
+
 package tests
 
 import (
@@ -9,14 +12,13 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
-	"github.com/linemk/rocket-shop/payment/internal/repository/payment"
 	payment_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/payment/v1"
 )
 
 func TestGetTransaction(t *testing.T) {
 	ctx := context.Background()
 
-	repo := payment.NewRepository()
+	repo := newTransactionRepository(ctx, t)
 
 	transaction := models.Transaction{
 		UUID:          uuid.New().String(),
@@ -1,3 +1,6 @@
+//go:build integration
+// +build integration
+
 package tests
 
 import (
@@ -9,14 +12,13 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
-	"github.com/linemk/rocket-shop/payment/internal/repository/payment"
 	payment_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/payment/v1"
 )
 
 func TestListTransactions(t *testing.T) {
 	ctx := context.Background()
 
-	repo := payment.NewRepository()
+	repo := newTransactionRepository(ctx, t)
 
 	orderUUID := uuid.New().String()
 	otherOrderUUID := uuid.New().String()
@@ -104,3 +106,170 @@ func TestListTransactions(t *testing.T) {
 		})
 	}
 }
+
+func TestListAwaitingReview(t *testing.T) {
+	ctx := context.Background()
+
+	repo := newTransactionRepository(ctx, t)
+
+	now := time.Now().Truncate(time.Microsecond)
+	newTransaction := func(status models.TransactionStatus, decision models.FraudDecision, createdAt time.Time) models.Transaction {
+		return models.Transaction{
+			UUID:          uuid.New().String(),
+			OrderUUID:     uuid.New().String(),
+			UserID:        uuid.New().String(),
+			PaymentMethod: payment_v1.PaymentMethod_PAYMENT_METHOD_CARD,
+			Amount:        100.00,
+			Status:        status,
+			FraudDecision: decision,
+			CreatedAt:     createdAt,
+			UpdatedAt:     createdAt,
+		}
+	}
+
+	later := newTransaction(models.TransactionStatusPending, models.FraudDecisionReview, now)
+	earlier := newTransaction(models.TransactionStatusPending, models.FraudDecisionReview, now.Add(-time.Minute))
+	approved := newTransaction(models.TransactionStatusPending, models.FraudDecisionApprove, now)
+	rejected := newTransaction(models.TransactionStatusFailed, models.FraudDecisionReview, now)
+
+	for _, transaction := range []models.Transaction{later, earlier, approved, rejected} {
+		require.NoError(t, repo.CreateTransaction(ctx, transaction))
+	}
+
+	result, err := repo.ListAwaitingReview(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []string{earlier.UUID, later.UUID}, createdUUIDs(result, later, earlier, approved, rejected))
+}
+
+func TestListStalePending(t *testing.T) {
+	ctx := context.Background()
+
+	repo := newTransactionRepository(ctx, t)
+
+	now := time.Now().Truncate(time.Microsecond)
+	cutoff := now.Add(-5 * time.Minute)
+	newTransaction := func(status models.TransactionStatus, createdAt time.Time) models.Transaction {
+		return models.Transaction{
+			UUID:          uuid.New().String(),
+			OrderUUID:     uuid.New().String(),
+			UserID:        uuid.New().String(),
+			PaymentMethod: payment_v1.PaymentMethod_PAYMENT_METHOD_CARD,
+			Amount:        100.00,
+			Status:        status,
+			FraudDecision: models.FraudDecisionApprove,
+			CreatedAt:     createdAt,
+			UpdatedAt:     createdAt,
+		}
+	}
+
+	oldest := newTransaction(models.TransactionStatusPending, now.Add(-time.Hour))
+	older := newTransaction(models.TransactionStatusPending, now.Add(-30*time.Minute))
+	stale := newTransaction(models.TransactionStatusPending, now.Add(-10*time.Minute))
+	fresh := newTransaction(models.TransactionStatusPending, now)
+	completed := newTransaction(models.TransactionStatusCompleted, now.Add(-time.Hour))
+	inReview := newTransaction(models.TransactionStatusPending, now.Add(-time.Hour))
+	inReview.FraudDecision = models.FraudDecisionReview
+	installment := newTransaction(models.TransactionStatusPending, now.Add(-time.Hour))
+	installment.InstallmentPlanUUID = uuid.New().String()
+
+	for _, transaction := range []models.Transaction{stale, fresh, older, completed, inReview, installment, oldest} {
+		require.NoError(t, repo.CreateTransaction(ctx, transaction))
+	}
+
+	created := []models.Transaction{stale, fresh, older, completed, inReview, installment, oldest}
+	want := []string{oldest.UUID, older.UUID, stale.UUID}
+
+	result, err := repo.ListStalePending(ctx, cutoff, models.Transaction{}, 1000)
+	require.NoError(t, err)
+	require.Equal(t, want, createdUUIDs(result, created...))
+
+	// Следующая страница начинается после последней транзакции предыдущей
+	pages := listPages(t, func(after models.Transaction) ([]models.Transaction, error) {
+		return repo.ListStalePending(ctx, cutoff, after, 2)
+	})
+	require.Equal(t, want, createdUUIDs(pages, created...))
+}
+
+func TestListUncompensatedTenders(t *testing.T) {
+	ctx := context.Background()
+
+	repo := newTransactionRepository(ctx, t)
+
+	now := time.Now().Truncate(time.Microsecond)
+	newTender := func(splitUUID string, status models.TransactionStatus, createdAt time.Time) models.Transaction {
+		return models.Transaction{
+			UUID:          uuid.New().String(),
+			OrderUUID:     uuid.New().String(),
+			UserID:        uuid.New().String(),
+			PaymentMethod: payment_v1.PaymentMethod_PAYMENT_METHOD_CARD,
+			Amount:        100.00,
+			Status:        status,
+			SplitUUID:     splitUUID,
+			CreatedAt:     createdAt,
+			UpdatedAt:     createdAt,
+		}
+	}
+
+	failedSplit, cancelledSplit, completedSplit := uuid.New().String(), uuid.New().String(), uuid.New().String()
+
+	charged := newTender(failedSplit, models.TransactionStatusCompleted, now)
+	refunded := newTender(failedSplit, models.TransactionStatusRefunded, now)
+	declined := newTender(failedSplit, models.TransactionStatusFailed, now)
+	chargedEarlier := newTender(cancelledSplit, models.TransactionStatusCompleted, now.Add(-time.Minute))
+	cancelled := newTender(cancelledSplit, models.TransactionStatusCancelled, now)
+	completed := newTender(completedSplit, models.TransactionStatusCompleted, now)
+	pending := newTender(completedSplit, models.TransactionStatusPending, now)
+	single := newTender("", models.TransactionStatusCompleted, now)
+	singleFailed := newTender("", models.TransactionStatusFailed, now)
+
+	for _, transaction := range []models.Transaction{charged, refunded, declined, chargedEarlier, cancelled, completed, pending, single, singleFailed} {
+		require.NoError(t, repo.CreateTransaction(ctx, transaction))
+	}
+
+	created := []models.Transaction{charged, refunded, declined, chargedEarlier, cancelled, completed, pending, single, singleFailed}
+	want := []string{chargedEarlier.UUID, charged.UUID}
+
+	result, err := repo.ListUncompensatedTenders(ctx, models.Transaction{}, 1000)
+	require.NoError(t, err)
+	require.Equal(t, want, createdUUIDs(result, created...))
+
+	pages := listPages(t, func(after models.Transaction) ([]models.Transaction, error) {
+		return repo.ListUncompensatedTenders(ctx, after, 1)
+	})
+	require.Equal(t, want, createdUUIDs(pages, created...))
+}
+
+// createdUUIDs возвращает в порядке выборки UUID транзакций, созданных тестом:
+// база общая, и в выборку попадают транзакции других запусков
+func createdUUIDs(result []models.Transaction, created ...models.Transaction) []string {
+	own := make(map[string]struct{}, len(created))
+	for _, transaction := range created {
+		own[transaction.UUID] = struct{}{}
+	}
+
+	uuids := make([]string, 0)
+	for _, transaction := range result {
+		if _, ok := own[transaction.UUID]; ok {
+			uuids = append(uuids, transaction.UUID)
+		}
+	}
+
+	return uuids
+}
+
+// listPages читает выборку постранично, продолжая каждую страницу после последней транзакции предыдущей
+func listPages(t *testing.T, list func(after models.Transaction) ([]models.Transaction, error)) []models.Transaction {
+	t.Helper()
+
+	var all []models.Transaction
+	var after models.Transaction
+	for {
+		page, err := list(after)
+		require.NoError(t, err)
+		if len(page) == 0 {
+			return all
+		}
+		all = append(all, page...)
+		after = page[len(page)-1]
+	}
+}
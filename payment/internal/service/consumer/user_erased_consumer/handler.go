@@ -0,0 +1,48 @@
+package user_erased_consumer
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/linemk/rocket-shop/payment/internal/converter/kafka/decoder"
+	"github.com/linemk/rocket-shop/payment/internal/usecase"
+	platformKafka "github.com/linemk/rocket-shop/platform/pkg/kafka"
+)
+
+type handler struct {
+	paymentUseCase usecase.PaymentUseCase
+	logger         Logger
+}
+
+func NewHandler(paymentUseCase usecase.PaymentUseCase, logger Logger) HandlerFunc {
+	h := &handler{
+		paymentUseCase: paymentUseCase,
+		logger:         logger,
+	}
+
+	return h.Handle
+}
+
+func (h *handler) Handle(ctx context.Context, msg platformKafka.Message) error {
+	h.logger.Info(ctx, "Received UserErased event", zap.String("topic", msg.Topic))
+
+	event, err := decoder.DecodeUserErased(msg.Value)
+	if err != nil {
+		h.logger.Error(ctx, "Failed to decode UserErased event", zap.Error(err))
+		return err
+	}
+
+	affected, err := h.paymentUseCase.EraseUser(ctx, event.UserUUID)
+	if err != nil {
+		h.logger.Error(ctx, "Failed to anonymize user transactions", zap.Error(err))
+		return err
+	}
+
+	h.logger.Info(ctx, "User transactions anonymized successfully",
+		zap.String("event_uuid", event.EventUUID),
+		zap.Int("transactions", affected),
+	)
+
+	return nil
+}
@@ -0,0 +1,24 @@
+package service
+
+import (
+	"context"
+
+	"github.com/linemk/rocket-shop/payment/internal/entyties/events"
+)
+
+type ConsumerService interface {
+	RunConsumers(ctx context.Context) error
+}
+
+type PaymentProducerService interface {
+	SendPaymentCompleted(ctx context.Context, event *events.PaymentCompletedEvent) error
+	SendPaymentFailed(ctx context.Context, event *events.PaymentFailedEvent) error
+	SendInstallmentPaid(ctx context.Context, event *events.InstallmentPaidEvent) error
+	SendPaymentOverdue(ctx context.Context, event *events.PaymentOverdueEvent) error
+	SendApprovalRequested(ctx context.Context, event *events.ApprovalRequestedEvent) error
+}
+
+// JobService фоновая задача, работающая до отмены контекста
+type JobService interface {
+	Run(ctx context.Context) error
+}
@@ -0,0 +1,64 @@
+package approval_job
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/linemk/rocket-shop/payment/internal/config"
+	"github.com/linemk/rocket-shop/payment/internal/usecase"
+)
+
+type Logger interface {
+	Info(ctx context.Context, msg string, fields ...zap.Field)
+	Error(ctx context.Context, msg string, fields ...zap.Field)
+}
+
+type job struct {
+	paymentUseCase usecase.PaymentUseCase
+	config         config.ApprovalConfig
+	logger         Logger
+}
+
+func NewJob(paymentUseCase usecase.PaymentUseCase, cfg config.ApprovalConfig, logger Logger) *job {
+	return &job{
+		paymentUseCase: paymentUseCase,
+		config:         cfg,
+		logger:         logger,
+	}
+}
+
+// Run периодически отменяет платежи, не согласованные до срока, до отмены контекста
+func (j *job) Run(ctx context.Context) error {
+	if j.config.ExpiryInterval() <= 0 {
+		j.logger.Info(ctx, "Approval expiry job disabled")
+		return nil
+	}
+
+	j.logger.Info(ctx, "Starting approval expiry job", zap.Duration("interval", j.config.ExpiryInterval()))
+
+	ticker := time.NewTicker(j.config.ExpiryInterval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			j.expire(ctx)
+		}
+	}
+}
+
+func (j *job) expire(ctx context.Context) {
+	expired, err := j.paymentUseCase.ExpireApprovals(ctx)
+	if err != nil {
+		j.logger.Error(ctx, "Approval expiry failed", zap.Error(err))
+		return
+	}
+
+	if expired > 0 {
+		j.logger.Info(ctx, "Expired approvals cancelled", zap.Int("approvals", expired))
+	}
+}
@@ -0,0 +1,64 @@
+package installment_job
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/linemk/rocket-shop/payment/internal/config"
+	"github.com/linemk/rocket-shop/payment/internal/usecase"
+)
+
+type Logger interface {
+	Info(ctx context.Context, msg string, fields ...zap.Field)
+	Error(ctx context.Context, msg string, fields ...zap.Field)
+}
+
+type job struct {
+	paymentUseCase usecase.PaymentUseCase
+	config         config.InstallmentConfig
+	logger         Logger
+}
+
+func NewJob(paymentUseCase usecase.PaymentUseCase, cfg config.InstallmentConfig, logger Logger) *job {
+	return &job{
+		paymentUseCase: paymentUseCase,
+		config:         cfg,
+		logger:         logger,
+	}
+}
+
+// Run периодически списывает платежи по графикам рассрочки до отмены контекста
+func (j *job) Run(ctx context.Context) error {
+	if j.config.RunnerInterval() <= 0 {
+		j.logger.Info(ctx, "Installment charge job disabled")
+		return nil
+	}
+
+	j.logger.Info(ctx, "Starting installment charge job", zap.Duration("interval", j.config.RunnerInterval()))
+
+	ticker := time.NewTicker(j.config.RunnerInterval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			j.charge(ctx)
+		}
+	}
+}
+
+func (j *job) charge(ctx context.Context) {
+	charged, err := j.paymentUseCase.ChargeDueInstallments(ctx)
+	if err != nil {
+		j.logger.Error(ctx, "Installment charge failed", zap.Error(err))
+		return
+	}
+
+	if charged > 0 {
+		j.logger.Info(ctx, "Due installments processed", zap.Int("installments", charged))
+	}
+}
@@ -0,0 +1,68 @@
+package recovery_job
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/linemk/rocket-shop/payment/internal/config"
+	"github.com/linemk/rocket-shop/payment/internal/usecase"
+)
+
+type Logger interface {
+	Info(ctx context.Context, msg string, fields ...zap.Field)
+	Error(ctx context.Context, msg string, fields ...zap.Field)
+}
+
+type job struct {
+	paymentUseCase usecase.PaymentUseCase
+	config         config.ProviderConfig
+	logger         Logger
+}
+
+func NewJob(paymentUseCase usecase.PaymentUseCase, cfg config.ProviderConfig, logger Logger) *job {
+	return &job{
+		paymentUseCase: paymentUseCase,
+		config:         cfg,
+		logger:         logger,
+	}
+}
+
+// Run периодически сверяет с провайдером транзакции с неизвестным исходом списания и повторяет
+// не удавшиеся возвраты частей раздельной оплаты до отмены контекста
+func (j *job) Run(ctx context.Context) error {
+	if j.config.RecoveryInterval() <= 0 {
+		j.logger.Info(ctx, "Recovery job disabled")
+		return nil
+	}
+
+	j.logger.Info(ctx, "Starting recovery job", zap.Duration("interval", j.config.RecoveryInterval()))
+
+	ticker := time.NewTicker(j.config.RecoveryInterval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			j.recover(ctx)
+		}
+	}
+}
+
+func (j *job) recover(ctx context.Context) {
+	// Сбой сверки не откладывает повтор возвратов: они не зависят друг от друга
+	if recovered, err := j.paymentUseCase.RecoverPendingCharges(ctx); err != nil {
+		j.logger.Error(ctx, "Charge recovery failed", zap.Error(err))
+	} else if recovered > 0 {
+		j.logger.Info(ctx, "Pending charges recovered", zap.Int("transactions", recovered))
+	}
+
+	if refunded, err := j.paymentUseCase.CompensateSplits(ctx); err != nil {
+		j.logger.Error(ctx, "Split compensation failed", zap.Error(err))
+	} else if refunded > 0 {
+		j.logger.Info(ctx, "Split tenders refunded", zap.Int("transactions", refunded))
+	}
+}
@@ -0,0 +1,133 @@
+package payment_producer
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/linemk/rocket-shop/payment/internal/converter/kafka"
+	"github.com/linemk/rocket-shop/payment/internal/entyties/events"
+	platformKafka "github.com/linemk/rocket-shop/platform/pkg/kafka"
+)
+
+type Logger interface {
+	Info(ctx context.Context, msg string, fields ...zap.Field)
+	Error(ctx context.Context, msg string, fields ...zap.Field)
+}
+
+type producer struct {
+	kafkaProducer platformKafka.Producer
+	logger        Logger
+}
+
+func NewProducer(kafkaProducer platformKafka.Producer, logger Logger) *producer {
+	return &producer{
+		kafkaProducer: kafkaProducer,
+		logger:        logger,
+	}
+}
+
+func (p *producer) SendPaymentCompleted(ctx context.Context, event *events.PaymentCompletedEvent) error {
+	data, err := kafka.EncodePaymentCompleted(event)
+	if err != nil {
+		p.logger.Error(ctx, "Failed to encode PaymentCompleted event", zap.Error(err))
+		return err
+	}
+
+	// Ключ сообщения - UUID заказа, чтобы события одного заказа обрабатывались по порядку
+	if err := p.kafkaProducer.Send(ctx, []byte(event.OrderUUID), data); err != nil {
+		p.logger.Error(ctx, "Failed to send PaymentCompleted event to Kafka", zap.Error(err))
+		return err
+	}
+
+	p.logger.Info(ctx, "PaymentCompleted event sent successfully",
+		zap.String("transaction_uuid", event.TransactionUUID),
+		zap.String("order_uuid", event.OrderUUID),
+	)
+
+	return nil
+}
+
+func (p *producer) SendPaymentFailed(ctx context.Context, event *events.PaymentFailedEvent) error {
+	data, err := kafka.EncodePaymentFailed(event)
+	if err != nil {
+		p.logger.Error(ctx, "Failed to encode PaymentFailed event", zap.Error(err))
+		return err
+	}
+
+	if err := p.kafkaProducer.Send(ctx, []byte(event.OrderUUID), data); err != nil {
+		p.logger.Error(ctx, "Failed to send PaymentFailed event to Kafka", zap.Error(err))
+		return err
+	}
+
+	p.logger.Info(ctx, "PaymentFailed event sent successfully",
+		zap.String("transaction_uuid", event.TransactionUUID),
+		zap.String("order_uuid", event.OrderUUID),
+		zap.String("status", event.Status),
+	)
+
+	return nil
+}
+
+func (p *producer) SendInstallmentPaid(ctx context.Context, event *events.InstallmentPaidEvent) error {
+	data, err := kafka.EncodeInstallmentPaid(event)
+	if err != nil {
+		p.logger.Error(ctx, "Failed to encode InstallmentPaid event", zap.Error(err))
+		return err
+	}
+
+	if err := p.kafkaProducer.Send(ctx, []byte(event.OrderUUID), data); err != nil {
+		p.logger.Error(ctx, "Failed to send InstallmentPaid event to Kafka", zap.Error(err))
+		return err
+	}
+
+	p.logger.Info(ctx, "InstallmentPaid event sent successfully",
+		zap.String("plan_uuid", event.PlanUUID),
+		zap.String("order_uuid", event.OrderUUID),
+		zap.Int("installment_number", event.InstallmentNumber),
+	)
+
+	return nil
+}
+
+func (p *producer) SendPaymentOverdue(ctx context.Context, event *events.PaymentOverdueEvent) error {
+	data, err := kafka.EncodePaymentOverdue(event)
+	if err != nil {
+		p.logger.Error(ctx, "Failed to encode PaymentOverdue event", zap.Error(err))
+		return err
+	}
+
+	if err := p.kafkaProducer.Send(ctx, []byte(event.OrderUUID), data); err != nil {
+		p.logger.Error(ctx, "Failed to send PaymentOverdue event to Kafka", zap.Error(err))
+		return err
+	}
+
+	p.logger.Info(ctx, "PaymentOverdue event sent successfully",
+		zap.String("plan_uuid", event.PlanUUID),
+		zap.String("order_uuid", event.OrderUUID),
+		zap.Int("installment_number", event.InstallmentNumber),
+	)
+
+	return nil
+}
+
+func (p *producer) SendApprovalRequested(ctx context.Context, event *events.ApprovalRequestedEvent) error {
+	data, err := kafka.EncodeApprovalRequested(event)
+	if err != nil {
+		p.logger.Error(ctx, "Failed to encode ApprovalRequested event", zap.Error(err))
+		return err
+	}
+
+	if err := p.kafkaProducer.Send(ctx, []byte(event.OrderUUID), data); err != nil {
+		p.logger.Error(ctx, "Failed to send ApprovalRequested event to Kafka", zap.Error(err))
+		return err
+	}
+
+	p.logger.Info(ctx, "ApprovalRequested event sent successfully",
+		zap.String("approval_uuid", event.ApprovalUUID),
+		zap.String("transaction_uuid", event.TransactionUUID),
+		zap.String("order_uuid", event.OrderUUID),
+	)
+
+	return nil
+}
@@ -0,0 +1,261 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/linemk/rocket-shop/payment/internal/entyties/apperrors"
+	"github.com/linemk/rocket-shop/payment/internal/entyties/events"
+	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
+	"github.com/linemk/rocket-shop/platform/pkg/logger"
+)
+
+const (
+	// approvalExpiryBatchSize максимальное число запросов, отменяемых за один запуск
+	approvalExpiryBatchSize = 100
+	// defaultApprovalRejectReason причина отказа, если согласующие не указали ее при отклонении
+	defaultApprovalRejectReason = "rejected by approvers"
+	// approvalExpiredReason причина отмены платежа, не согласованного до срока
+	approvalExpiredReason = "approval deadline expired"
+	// approvalRequestFailedReason причина отказа, если запрос на согласование не удалось создать
+	approvalRequestFailedReason = "failed to request approval"
+)
+
+// requiresApproval проверяет, списывается ли платеж только после согласования
+func (uc *useCase) requiresApproval(transaction models.Transaction) bool {
+	return transaction.PaymentMethod == models.ApprovalPaymentMethod && len(uc.approvalConfig.Approvers()) > 0
+}
+
+// requestApproval создает запрос на согласование транзакции. Транзакция остается PENDING
+// и передается провайдеру только после набора кворума одобрений
+func (uc *useCase) requestApproval(ctx context.Context, transaction models.Transaction) (models.Transaction, error) {
+	now := time.Now()
+
+	approval := models.Approval{
+		UUID:            uuid.New().String(),
+		TransactionUUID: transaction.UUID,
+		OrderUUID:       transaction.OrderUUID,
+		UserID:          transaction.UserID,
+		Amount:          transaction.Amount,
+		Approvers:       uc.approvalConfig.Approvers(),
+		Quorum:          uc.approvalConfig.Quorum(),
+		Status:          models.ApprovalStatusPending,
+		ExpiresAt:       now.Add(uc.approvalConfig.Timeout()),
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+
+	if err := uc.approvalRepository.CreateApproval(ctx, approval); err != nil {
+		logger.Error(ctx, "Не удалось создать запрос на согласование платежа",
+			zap.String("transaction_uuid", transaction.UUID),
+			zap.Error(err),
+		)
+
+		// Без запроса платеж никогда не будет согласован, поэтому заказ сразу возвращается к оплате
+		transaction.Status = models.TransactionStatusFailed
+		transaction.FailureReason = approvalRequestFailedReason
+		if err := uc.finish(ctx, transaction); err != nil {
+			return models.Transaction{}, err
+		}
+
+		return transaction, nil
+	}
+
+	transaction.ApprovalUUID = approval.UUID
+	transaction.UpdatedAt = now
+	if err := uc.paymentRepository.UpdateTransaction(ctx, transaction.UUID, transaction); err != nil {
+		return models.Transaction{}, err
+	}
+
+	logger.Info(ctx, "Платеж ожидает согласования",
+		zap.String("transaction_uuid", transaction.UUID),
+		zap.String("approval_uuid", approval.UUID),
+		zap.Int("quorum", approval.Quorum),
+	)
+
+	// Ошибка отправки события уже логируется в paymentProducer.
+	// Без события заказ остается в ожидании результата платежа и узнает итог согласования из PaymentCompleted или PaymentFailed
+	_ = uc.paymentProducer.SendApprovalRequested(ctx, &events.ApprovalRequestedEvent{
+		EventUUID:       uuid.New().String(),
+		ApprovalUUID:    approval.UUID,
+		TransactionUUID: transaction.UUID,
+		SplitUUID:       transaction.SplitUUID,
+		OrderUUID:       transaction.OrderUUID,
+		UserUUID:        transaction.UserID,
+		Amount:          transaction.Amount,
+		Approvers:       approval.Approvers,
+		Quorum:          approval.Quorum,
+		ExpiresAt:       approval.ExpiresAt,
+		RequestedAt:     now,
+	})
+
+	return transaction, nil
+}
+
+func (uc *useCase) DecideApproval(ctx context.Context, approvalUUID, approverID string, approved bool, reason string) (models.Approval, error) {
+	approval, err := uc.approvalRepository.GetApproval(ctx, approvalUUID)
+	if err != nil {
+		return models.Approval{}, err
+	}
+
+	if !approval.IsApprover(approverID) {
+		return models.Approval{}, apperrors.ErrNotApprover
+	}
+
+	if err := uc.approvalRepository.AddDecision(ctx, approvalUUID, models.ApprovalDecision{
+		ApproverID: approverID,
+		Approved:   approved,
+		Reason:     reason,
+		DecidedAt:  time.Now(),
+	}); err != nil {
+		return models.Approval{}, err
+	}
+
+	logger.Info(ctx, "Принято решение по согласованию платежа",
+		zap.String("approval_uuid", approvalUUID),
+		zap.String("approver_id", approverID),
+		zap.Bool("approved", approved),
+	)
+
+	// Решения читаются заново: одновременно с этим решением могли быть приняты и другие
+	approval, err = uc.approvalRepository.GetApproval(ctx, approvalUUID)
+	if err != nil {
+		return models.Approval{}, err
+	}
+
+	outcome := approval.Outcome()
+	if outcome == models.ApprovalStatusPending {
+		return approval, nil
+	}
+
+	return uc.resolveApproval(ctx, approval, outcome)
+}
+
+func (uc *useCase) GetApproval(ctx context.Context, approvalUUID string) (models.Approval, error) {
+	return uc.approvalRepository.GetApproval(ctx, approvalUUID)
+}
+
+func (uc *useCase) ListPendingApprovals(ctx context.Context, approverID string) ([]models.Approval, error) {
+	if approverID == "" {
+		return nil, apperrors.ErrNotApprover
+	}
+
+	return uc.approvalRepository.ListPending(ctx, approverID)
+}
+
+func (uc *useCase) ExpireApprovals(ctx context.Context) (int, error) {
+	approvals, err := uc.approvalRepository.ListExpired(ctx, time.Now(), approvalExpiryBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for _, approval := range approvals {
+		if _, err := uc.resolveApproval(ctx, approval, models.ApprovalStatusExpired); err != nil {
+			logger.Error(ctx, "Не удалось отменить просроченный запрос на согласование",
+				zap.String("approval_uuid", approval.UUID),
+				zap.Error(err),
+			)
+			continue
+		}
+		expired++
+	}
+
+	return expired, nil
+}
+
+// resolveApproval фиксирует итог согласования и передает транзакцию провайдеру либо завершает ее отказом.
+// Если запрос уже завершен другим решением или отменой по сроку, возвращает его текущее состояние.
+// Итог фиксируется до обработки транзакции: если она прервется, транзакцию по итогу завершит RecoverPendingCharges
+func (uc *useCase) resolveApproval(ctx context.Context, approval models.Approval, status models.ApprovalStatus) (models.Approval, error) {
+	now := time.Now()
+	if err := uc.approvalRepository.ResolveApproval(ctx, approval.UUID, status, now); err != nil {
+		if errors.Is(err, apperrors.ErrApprovalNotPending) {
+			return uc.approvalRepository.GetApproval(ctx, approval.UUID)
+		}
+		return models.Approval{}, err
+	}
+
+	approval.Status = status
+	approval.UpdatedAt = now
+
+	// Транзакцию сейчас сверяет RecoverPendingCharges: итог согласования она учтет при следующем запуске
+	if _, busy := uc.inFlight.LoadOrStore(approval.TransactionUUID, struct{}{}); busy {
+		return approval, nil
+	}
+	charging := false
+	defer func() {
+		if !charging {
+			uc.inFlight.Delete(approval.TransactionUUID)
+		}
+	}()
+
+	transaction, err := uc.paymentRepository.GetTransaction(ctx, approval.TransactionUUID)
+	if err != nil {
+		return models.Approval{}, apperrors.ErrTransactionNotFound
+	}
+
+	logger.Info(ctx, "Согласование платежа завершено",
+		zap.String("approval_uuid", approval.UUID),
+		zap.String("transaction_uuid", transaction.UUID),
+		zap.String("status", string(status)),
+	)
+
+	// Транзакция могла быть отменена без согласования, например при отказе другой части раздельной оплаты
+	if transaction.Status != models.TransactionStatusPending {
+		return approval, nil
+	}
+
+	if status == models.ApprovalStatusApproved {
+		paymentProvider, err := uc.providers.Get(transaction.PaymentMethod)
+		if err != nil {
+			return models.Approval{}, err
+		}
+
+		// Транзакция остается занятой до ответа провайдера, charge освободит ее сам
+		charging = true
+		uc.goCharge(ctx, transaction, paymentProvider)
+
+		return approval, nil
+	}
+
+	applyApprovalRefusal(&transaction, approval)
+	if err := uc.finish(ctx, transaction); err != nil {
+		return models.Approval{}, err
+	}
+
+	return approval, nil
+}
+
+// applyApprovalRefusal завершает транзакцию по отказу в согласовании: отклоненный платеж - FAILED, просроченный - CANCELLED
+func applyApprovalRefusal(transaction *models.Transaction, approval models.Approval) {
+	if approval.Status == models.ApprovalStatusExpired {
+		transaction.Status = models.TransactionStatusCancelled
+		transaction.FailureReason = approvalExpiredReason
+		return
+	}
+
+	transaction.Status = models.TransactionStatusFailed
+	transaction.FailureReason = rejectReason(approval)
+}
+
+// rejectReason собирает причины отказа из решений отклонивших платеж согласующих
+func rejectReason(approval models.Approval) string {
+	var reasons []string
+	for _, decision := range approval.Decisions {
+		if !decision.Approved && decision.Reason != "" {
+			reasons = append(reasons, decision.Reason)
+		}
+	}
+
+	if len(reasons) == 0 {
+		return defaultApprovalRejectReason
+	}
+
+	return strings.Join(reasons, "; ")
+}
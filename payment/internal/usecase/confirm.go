@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/linemk/rocket-shop/payment/internal/entyties/apperrors"
+	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
+	"github.com/linemk/rocket-shop/platform/pkg/logger"
+)
+
+// defaultRejectReason причина отказа, если она не передана при отклонении платежа
+const defaultRejectReason = "payment was not confirmed"
+
+func (uc *useCase) ConfirmTransaction(ctx context.Context, transactionUUID, userID string, approved bool, reason string) (models.Transaction, error) {
+	// Пока провайдер обрабатывает списание, исход платежа еще не известен. Транзакция занимается
+	// до сохранения решения, чтобы одновременные подтверждения не завершили ее дважды
+	if _, busy := uc.inFlight.LoadOrStore(transactionUUID, struct{}{}); busy {
+		return models.Transaction{}, apperrors.ErrTransactionNotPending
+	}
+	defer uc.inFlight.Delete(transactionUUID)
+
+	transaction, err := uc.paymentRepository.GetTransaction(ctx, transactionUUID)
+	if err != nil {
+		return models.Transaction{}, apperrors.ErrTransactionNotFound
+	}
+
+	// Чужая транзакция неотличима от несуществующей, чтобы по ответу нельзя было перебирать UUID
+	if transaction.UserID != userID {
+		return models.Transaction{}, apperrors.ErrTransactionNotFound
+	}
+
+	if transaction.Status != models.TransactionStatusPending {
+		return models.Transaction{}, apperrors.ErrTransactionNotPending
+	}
+
+	// Задержанная антифрод-проверкой транзакция еще не передавалась провайдеру
+	if transaction.FraudDecision == models.FraudDecisionReview {
+		return models.Transaction{}, apperrors.ErrTransactionInReview
+	}
+
+	// Платеж средствами инвестора передается провайдеру только после согласования.
+	// Отклоненный или просроченный запрос завершает транзакцию сам, подтверждать ее нечего
+	if transaction.ApprovalUUID != "" {
+		approval, err := uc.approvalRepository.GetApproval(ctx, transaction.ApprovalUUID)
+		if err != nil {
+			return models.Transaction{}, err
+		}
+		switch approval.Status {
+		case models.ApprovalStatusApproved:
+		case models.ApprovalStatusPending:
+			return models.Transaction{}, apperrors.ErrTransactionInApproval
+		default:
+			return models.Transaction{}, apperrors.ErrTransactionNotApproved
+		}
+	}
+
+	paymentProvider, err := uc.providers.Get(transaction.PaymentMethod)
+	if err != nil {
+		return models.Transaction{}, err
+	}
+
+	// Исход платежа определяет провайдер: подтверждение покупателя без списания у провайдера
+	// не завершает транзакцию, а отказ покупателя не отменяет уже прошедшее списание
+	statusCtx, cancel := context.WithTimeout(ctx, uc.providerConfig.Timeout())
+	start := time.Now()
+	result, err := paymentProvider.Status(statusCtx, transaction.UUID)
+	cancel()
+	uc.observeProvider(paymentProvider, providerOperationStatus, start, err)
+
+	switch {
+	case errors.Is(err, apperrors.ErrChargeNotFound):
+		result = models.ChargeResult{Status: models.TransactionStatusPending}
+	case err != nil:
+		return models.Transaction{}, err
+	}
+
+	switch {
+	case result.Status == models.TransactionStatusCompleted || result.Status == models.TransactionStatusFailed:
+		applyChargeResult(&transaction, result, nil)
+		if !approved && result.Status == models.TransactionStatusCompleted {
+			logger.Warn(ctx, "Покупатель отклонил платеж, уже списанный провайдером",
+				zap.String("transaction_uuid", transaction.UUID),
+				zap.String("provider", paymentProvider.Name()),
+			)
+		}
+	case approved:
+		return models.Transaction{}, apperrors.ErrChargeNotCompleted
+	default:
+		if reason == "" {
+			reason = defaultRejectReason
+		}
+		transaction.Status = models.TransactionStatusFailed
+		transaction.FailureReason = reason
+	}
+
+	if err := uc.finish(ctx, transaction); err != nil {
+		return models.Transaction{}, err
+	}
+
+	return transaction, nil
+}
@@ -0,0 +1,25 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
+)
+
+// EraseUser отвязывает транзакции, планы рассрочки и запросы на согласование от пользователя, сохраняя их для учета,
+// и удаляет историю его платежей. Возвращает число измененных транзакций
+func (uc *useCase) EraseUser(ctx context.Context, userID string) (int, error) {
+	if err := uc.fraudScreener.Forget(ctx, userID); err != nil {
+		return 0, err
+	}
+
+	if _, err := uc.installmentRepository.AnonymizeUser(ctx, userID, models.ErasedUserID); err != nil {
+		return 0, err
+	}
+
+	if _, err := uc.approvalRepository.AnonymizeUser(ctx, userID, models.ErasedUserID); err != nil {
+		return 0, err
+	}
+
+	return uc.paymentRepository.AnonymizeUser(ctx, userID, models.ErasedUserID)
+}
@@ -0,0 +1,409 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/linemk/rocket-shop/payment/internal/entyties/apperrors"
+	"github.com/linemk/rocket-shop/payment/internal/entyties/events"
+	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
+	"github.com/linemk/rocket-shop/payment/internal/provider"
+	"github.com/linemk/rocket-shop/platform/pkg/logger"
+)
+
+const (
+	// installmentBatchSize максимальное число платежей, списываемых за один запуск
+	installmentBatchSize = 100
+	// installmentLeaseMargin запас сверх таймаута провайдера, на который платеж скрывается от повторной выборки
+	installmentLeaseMargin = time.Minute
+	// installmentConfirmationReason причина отказа, если провайдер требует подтверждения платежа по графику
+	installmentConfirmationReason = "confirmation is not supported for installments"
+)
+
+func (uc *useCase) QuoteInstallments(_ context.Context, amount float64) ([]models.InstallmentQuote, error) {
+	if amount <= 0 {
+		return nil, apperrors.ErrInvalidAmount
+	}
+
+	terms := uc.installmentConfig.Terms()
+	counts := make([]int, 0, len(terms))
+	for count := range terms {
+		counts = append(counts, count)
+	}
+	sort.Ints(counts)
+
+	principal := models.ToMinorUnits(amount)
+	quotes := make([]models.InstallmentQuote, 0, len(counts))
+	for _, count := range counts {
+		schedule := models.InstallmentSchedule(principal, count, terms[count])
+
+		var total int64
+		payments := make([]float64, 0, len(schedule))
+		for _, payment := range schedule {
+			total += payment
+			payments = append(payments, models.FromMinorUnits(payment))
+		}
+
+		quotes = append(quotes, models.InstallmentQuote{
+			Installments: count,
+			MonthlyRate:  terms[count],
+			Payments:     payments,
+			TotalAmount:  models.FromMinorUnits(total),
+			Overpayment:  models.FromMinorUnits(total - principal),
+		})
+	}
+
+	return quotes, nil
+}
+
+func (uc *useCase) CreateInstallmentPlan(ctx context.Context, orderUUID, userID string, amount float64, installments int) (models.InstallmentPlan, error) {
+	if orderUUID == "" || userID == "" || amount <= 0 {
+		return models.InstallmentPlan{}, apperrors.ErrInvalidAmount
+	}
+
+	rate, ok := uc.installmentConfig.Terms()[installments]
+	if !ok {
+		return models.InstallmentPlan{}, apperrors.ErrInvalidInstallments
+	}
+
+	// Провайдер проверяется до сохранения плана, чтобы не создавать план, который нечем списывать
+	if _, err := uc.providers.Get(models.InstallmentPaymentMethod); err != nil {
+		return models.InstallmentPlan{}, err
+	}
+
+	now := time.Now()
+	principal := models.ToMinorUnits(amount)
+	schedule := models.InstallmentSchedule(principal, installments, rate)
+
+	plan := models.InstallmentPlan{
+		UUID:          uuid.New().String(),
+		OrderUUID:     orderUUID,
+		UserID:        userID,
+		PaymentMethod: models.InstallmentPaymentMethod,
+		Principal:     amount,
+		MonthlyRate:   rate,
+		Status:        models.InstallmentPlanStatusActive,
+		Installments:  make([]models.Installment, 0, len(schedule)),
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	var total int64
+	for i, payment := range schedule {
+		total += payment
+		dueAt := now.Add(time.Duration(i) * uc.installmentConfig.Period())
+		plan.Installments = append(plan.Installments, models.Installment{
+			PlanUUID:      plan.UUID,
+			Number:        i + 1,
+			Amount:        models.FromMinorUnits(payment),
+			DueAt:         dueAt,
+			Status:        models.InstallmentStatusScheduled,
+			NextAttemptAt: dueAt,
+		})
+	}
+	plan.TotalAmount = models.FromMinorUnits(total)
+
+	// Первый платеж списывается сразу, поэтому он скрыт от фонового списания на время попытки
+	plan.Installments[0].NextAttemptAt = now.Add(uc.installmentLease())
+
+	if err := uc.installmentRepository.CreatePlan(ctx, plan); err != nil {
+		return models.InstallmentPlan{}, err
+	}
+
+	uc.charges.Add(1)
+	go func(ctx context.Context) {
+		defer uc.charges.Done()
+		if err := uc.chargeInstallment(ctx, plan.UUID, 1); err != nil {
+			logger.Error(ctx, "Не удалось списать первый платеж по рассрочке",
+				zap.String("plan_uuid", plan.UUID),
+				zap.Error(err),
+			)
+		}
+	}(context.WithoutCancel(ctx))
+
+	return plan, nil
+}
+
+func (uc *useCase) GetInstallmentPlan(ctx context.Context, planUUID string) (models.InstallmentPlan, error) {
+	return uc.installmentRepository.GetPlan(ctx, planUUID)
+}
+
+func (uc *useCase) ChargeDueInstallments(ctx context.Context) (int, error) {
+	now := time.Now()
+	due, err := uc.installmentRepository.ClaimDue(ctx, now, now.Add(uc.installmentLease()), installmentBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, installment := range due {
+		// Ошибка одного платежа не останавливает остальные: платеж будет выбран снова после окончания аренды
+		if err := uc.chargeInstallment(ctx, installment.PlanUUID, installment.Number); err != nil {
+			logger.Error(ctx, "Не удалось списать платеж по рассрочке",
+				zap.String("plan_uuid", installment.PlanUUID),
+				zap.Int("installment_number", installment.Number),
+				zap.Error(err),
+			)
+		}
+	}
+
+	return len(due), nil
+}
+
+// installmentLease время, на которое платеж скрывается от фонового списания во время попытки
+func (uc *useCase) installmentLease() time.Duration {
+	return uc.providerConfig.Timeout() + installmentLeaseMargin
+}
+
+// chargeInstallment создает транзакцию для платежа по графику, списывает его и обновляет план.
+// Попытка с неизвестным исходом продолжается с тем же UUID транзакции: провайдер идемпотентен по нему,
+// поэтому повторная попытка не списывает платеж дважды
+func (uc *useCase) chargeInstallment(ctx context.Context, planUUID string, number int) error {
+	plan, err := uc.installmentRepository.GetPlan(ctx, planUUID)
+	if err != nil {
+		return err
+	}
+
+	if number < 1 || number > len(plan.Installments) {
+		return apperrors.ErrInstallmentPlanNotFound
+	}
+	installment := plan.Installments[number-1]
+
+	if plan.Status != models.InstallmentPlanStatusActive || installment.Status == models.InstallmentStatusPaid {
+		return nil
+	}
+
+	paymentProvider, err := uc.providers.Get(plan.PaymentMethod)
+	if err != nil {
+		return err
+	}
+
+	if installment.TransactionUUID != "" {
+		transaction, err := uc.paymentRepository.GetTransaction(ctx, installment.TransactionUUID)
+		switch {
+		case errors.Is(err, apperrors.ErrTransactionNotFound):
+			// UUID попытки сохраняется в плане раньше транзакции: если сервис остановился между этими
+			// записями, транзакция попытки создается по плану
+			transaction = installmentTransaction(plan, installment, installment.TransactionUUID)
+			if err := uc.paymentRepository.CreateTransaction(ctx, transaction); err != nil {
+				return err
+			}
+			return uc.resumeInstallment(ctx, plan, installment, transaction, paymentProvider)
+		case err != nil:
+			return err
+		case transaction.Status == models.TransactionStatusPending:
+			return uc.resumeInstallment(ctx, plan, installment, transaction, paymentProvider)
+		case transaction.Status == models.TransactionStatusCompleted:
+			// Платеж списан, но план не был обновлен
+			return uc.installmentPaid(ctx, plan, installment, transaction)
+		}
+	}
+
+	// UUID попытки сохраняется в плане до обращения к провайдеру, чтобы повторная попытка могла его продолжить
+	transaction := installmentTransaction(plan, installment, uuid.New().String())
+	installment.TransactionUUID = transaction.UUID
+	installment.Attempts++
+	if err := uc.installmentRepository.UpdateInstallment(ctx, installment); err != nil {
+		return err
+	}
+
+	if err := uc.paymentRepository.CreateTransaction(ctx, transaction); err != nil {
+		return err
+	}
+
+	return uc.chargeInstallmentAttempt(ctx, plan, installment, transaction, paymentProvider)
+}
+
+// installmentTransaction создает PENDING транзакцию попытки списания платежа по графику
+func installmentTransaction(plan models.InstallmentPlan, installment models.Installment, transactionUUID string) models.Transaction {
+	now := time.Now()
+	return models.Transaction{
+		UUID:                transactionUUID,
+		OrderUUID:           plan.OrderUUID,
+		UserID:              plan.UserID,
+		PaymentMethod:       plan.PaymentMethod,
+		Amount:              installment.Amount,
+		Status:              models.TransactionStatusPending,
+		InstallmentPlanUUID: plan.UUID,
+		CreatedAt:           now,
+		UpdatedAt:           now,
+	}
+}
+
+// resumeInstallment запрашивает у провайдера исход прошлой попытки. Списание, не дошедшее до провайдера,
+// отправляется повторно с тем же UUID транзакции
+func (uc *useCase) resumeInstallment(ctx context.Context, plan models.InstallmentPlan, installment models.Installment, transaction models.Transaction, paymentProvider provider.PaymentProvider) error {
+	statusCtx, cancel := context.WithTimeout(ctx, uc.providerConfig.Timeout())
+	start := time.Now()
+	result, err := paymentProvider.Status(statusCtx, transaction.UUID)
+	cancel()
+	uc.observeProvider(paymentProvider, providerOperationStatus, start, err)
+
+	if errors.Is(err, apperrors.ErrChargeNotFound) {
+		return uc.chargeInstallmentAttempt(ctx, plan, installment, transaction, paymentProvider)
+	}
+
+	return uc.settleInstallment(ctx, plan, installment, transaction, result, err)
+}
+
+// chargeInstallmentAttempt списывает платеж по графику в рамках сохраненной попытки
+func (uc *useCase) chargeInstallmentAttempt(ctx context.Context, plan models.InstallmentPlan, installment models.Installment, transaction models.Transaction, paymentProvider provider.PaymentProvider) error {
+	chargeCtx, cancel := context.WithTimeout(ctx, uc.providerConfig.Timeout())
+	start := time.Now()
+	result, err := paymentProvider.Charge(chargeCtx, models.ChargeRequest{
+		TransactionUUID: transaction.UUID,
+		OrderUUID:       transaction.OrderUUID,
+		UserID:          transaction.UserID,
+		PaymentMethod:   transaction.PaymentMethod,
+		Amount:          transaction.Amount,
+	})
+	cancel()
+	uc.observeProvider(paymentProvider, providerOperationCharge, start, err)
+
+	return uc.settleInstallment(ctx, plan, installment, transaction, result, err)
+}
+
+// settleInstallment завершает транзакцию попытки по ответу провайдера и обновляет план
+func (uc *useCase) settleInstallment(ctx context.Context, plan models.InstallmentPlan, installment models.Installment, transaction models.Transaction, result models.ChargeResult, err error) error {
+	if !applyChargeResult(&transaction, result, err) {
+		logger.Warn(ctx, "Исход списания по рассрочке неизвестен, транзакция остается PENDING",
+			zap.String("plan_uuid", plan.UUID),
+			zap.Int("installment_number", installment.Number),
+			zap.String("transaction_uuid", transaction.UUID),
+			zap.Error(err),
+		)
+		return nil
+	}
+
+	// Списание по графику выполняется без участия покупателя и не может ждать подтверждения
+	if transaction.Status == models.TransactionStatusPending {
+		transaction.Status = models.TransactionStatusFailed
+		transaction.FailureReason = installmentConfirmationReason
+	}
+
+	logger.Info(ctx, "Платеж по рассрочке обработан провайдером",
+		zap.String("plan_uuid", plan.UUID),
+		zap.Int("installment_number", installment.Number),
+		zap.String("transaction_uuid", transaction.UUID),
+		zap.String("status", string(transaction.Status)),
+		zap.String("failure_reason", transaction.FailureReason),
+	)
+
+	transaction.UpdatedAt = time.Now()
+	if transaction.Status == models.TransactionStatusCompleted {
+		if err := uc.recordPayment(ctx, transaction); err != nil {
+			return err
+		}
+	}
+
+	if err := uc.paymentRepository.UpdateTransaction(ctx, transaction.UUID, transaction); err != nil {
+		return err
+	}
+	uc.observeTransaction(transaction)
+
+	if transaction.Status == models.TransactionStatusCompleted {
+		return uc.installmentPaid(ctx, plan, installment, transaction)
+	}
+
+	return uc.installmentFailed(ctx, plan, installment, transaction)
+}
+
+// installmentPaid отмечает платеж оплаченным, завершает план после последнего платежа и публикует InstallmentPaid
+func (uc *useCase) installmentPaid(ctx context.Context, plan models.InstallmentPlan, installment models.Installment, transaction models.Transaction) error {
+	installment.Status = models.InstallmentStatusPaid
+	installment.PaidAt = transaction.UpdatedAt
+	installment.FailureReason = ""
+	if err := uc.installmentRepository.UpdateInstallment(ctx, installment); err != nil {
+		return err
+	}
+
+	plan.Installments[installment.Number-1] = installment
+	completed := plan.Completed()
+	if completed {
+		if err := uc.installmentRepository.UpdatePlanStatus(ctx, plan.UUID, models.InstallmentPlanStatusCompleted, transaction.UpdatedAt); err != nil {
+			return err
+		}
+	}
+
+	paidAmount := plan.PaidAmount()
+
+	return uc.paymentProducer.SendInstallmentPaid(ctx, &events.InstallmentPaidEvent{
+		EventUUID:         uuid.New().String(),
+		PlanUUID:          plan.UUID,
+		TransactionUUID:   transaction.UUID,
+		OrderUUID:         plan.OrderUUID,
+		UserUUID:          plan.UserID,
+		InstallmentNumber: installment.Number,
+		InstallmentsTotal: len(plan.Installments),
+		Amount:            installment.Amount,
+		PaidAmount:        paidAmount,
+		RemainingAmount:   models.FromMinorUnits(models.ToMinorUnits(plan.TotalAmount) - models.ToMinorUnits(paidAmount)),
+		PlanCompleted:     completed,
+		PaidAt:            transaction.UpdatedAt,
+	})
+}
+
+// installmentFailed планирует повторную попытку списания. Отказ первого платежа отменяет план,
+// а неоплаченный после льготного периода платеж отмечается просроченным
+func (uc *useCase) installmentFailed(ctx context.Context, plan models.InstallmentPlan, installment models.Installment, transaction models.Transaction) error {
+	now := transaction.UpdatedAt
+	installment.FailureReason = transaction.FailureReason
+	installment.NextAttemptAt = now.Add(uc.installmentConfig.RetryInterval())
+
+	// Без первого платежа заказ не считается оплаченным, поэтому рассрочка не оформляется
+	if installment.Number == 1 {
+		if err := uc.installmentRepository.UpdateInstallment(ctx, installment); err != nil {
+			return err
+		}
+
+		if err := uc.installmentRepository.UpdatePlanStatus(ctx, plan.UUID, models.InstallmentPlanStatusCancelled, now); err != nil {
+			return err
+		}
+
+		return uc.paymentProducer.SendPaymentFailed(ctx, &events.PaymentFailedEvent{
+			EventUUID:       uuid.New().String(),
+			TransactionUUID: plan.UUID,
+			OrderUUID:       plan.OrderUUID,
+			UserUUID:        plan.UserID,
+			Status:          string(transaction.Status),
+			Reason:          transaction.FailureReason,
+			FailedAt:        now,
+		})
+	}
+
+	overdue := installment.Status != models.InstallmentStatusOverdue &&
+		!now.Before(installment.DueAt.Add(uc.installmentConfig.OverdueAfter()))
+	if overdue {
+		installment.Status = models.InstallmentStatusOverdue
+	}
+
+	if err := uc.installmentRepository.UpdateInstallment(ctx, installment); err != nil {
+		return err
+	}
+
+	if !overdue {
+		return nil
+	}
+
+	logger.Warn(ctx, "Платеж по рассрочке просрочен",
+		zap.String("plan_uuid", plan.UUID),
+		zap.String("order_uuid", plan.OrderUUID),
+		zap.Int("installment_number", installment.Number),
+	)
+
+	return uc.paymentProducer.SendPaymentOverdue(ctx, &events.PaymentOverdueEvent{
+		EventUUID:         uuid.New().String(),
+		PlanUUID:          plan.UUID,
+		OrderUUID:         plan.OrderUUID,
+		UserUUID:          plan.UserID,
+		InstallmentNumber: installment.Number,
+		Amount:            installment.Amount,
+		DueAt:             installment.DueAt,
+		Reason:            transaction.FailureReason,
+		DetectedAt:        now,
+	})
+}
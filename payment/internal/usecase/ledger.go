@@ -0,0 +1,232 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/linemk/rocket-shop/payment/internal/entyties/apperrors"
+	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
+	payment_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/payment/v1"
+)
+
+func (uc *useCase) ListLedgerAccounts(ctx context.Context) ([]models.LedgerAccount, error) {
+	return uc.ledgerRepository.ListAccounts(ctx)
+}
+
+func (uc *useCase) GetAccountBalance(ctx context.Context, accountCode string) (models.AccountBalance, error) {
+	account, err := uc.ledgerRepository.GetAccount(ctx, accountCode)
+	if err != nil {
+		return models.AccountBalance{}, err
+	}
+
+	totals, err := uc.ledgerRepository.GetTotals(ctx, accountCode, time.Time{})
+	if err != nil {
+		return models.AccountBalance{}, err
+	}
+
+	return models.AccountBalance{
+		Account: account,
+		Totals:  totals,
+		Balance: account.Balance(totals),
+	}, nil
+}
+
+func (uc *useCase) GetAccountStatement(ctx context.Context, accountCode string, from, to time.Time) (models.AccountStatement, error) {
+	if !from.IsZero() && !to.IsZero() && !from.Before(to) {
+		return models.AccountStatement{}, apperrors.ErrInvalidPeriod
+	}
+
+	account, err := uc.ledgerRepository.GetAccount(ctx, accountCode)
+	if err != nil {
+		return models.AccountStatement{}, err
+	}
+
+	// Без начала периода выписка строится с первой проводки и начальное сальдо нулевое
+	var openingBalance int64
+	if !from.IsZero() {
+		totals, err := uc.ledgerRepository.GetTotals(ctx, accountCode, from)
+		if err != nil {
+			return models.AccountStatement{}, err
+		}
+		openingBalance = account.Balance(totals)
+	}
+
+	postings, err := uc.ledgerRepository.ListPostings(ctx, accountCode, from, to)
+	if err != nil {
+		return models.AccountStatement{}, err
+	}
+
+	balance := openingBalance
+	lines := make([]models.StatementLine, 0, len(postings))
+	for _, posting := range postings {
+		if (posting.Direction == models.DirectionDebit) == account.DebitNormal() {
+			balance += posting.Amount
+		} else {
+			balance -= posting.Amount
+		}
+
+		lines = append(lines, models.StatementLine{
+			AccountPosting: posting,
+			Balance:        balance,
+		})
+	}
+
+	return models.AccountStatement{
+		Account:        account,
+		From:           from,
+		To:             to,
+		OpeningBalance: openingBalance,
+		ClosingBalance: balance,
+		Lines:          lines,
+	}, nil
+}
+
+// recordPayment отражает завершенный платеж в книге: выручку и комиссию провайдера.
+// Записи уже отраженного платежа пропускаются, поэтому повторный вызов безопасен
+func (uc *useCase) recordPayment(ctx context.Context, transaction models.Transaction) error {
+	for _, entry := range paymentEntries(transaction, uc.providerConfig.FeeRate()) {
+		err := uc.postEntry(ctx, entry)
+		if err != nil && !errors.Is(err, apperrors.ErrEntryAlreadyExists) {
+			return fmt.Errorf("failed to post %s entry: %w", entry.Type, err)
+		}
+	}
+
+	return nil
+}
+
+// recordRefund отражает в книге возврат завершенного платежа. Повторный вызов безопасен: возврат транзакции записывается один раз
+func (uc *useCase) recordRefund(ctx context.Context, transaction models.Transaction) error {
+	entry, ok := refundEntry(transaction)
+	if !ok {
+		return nil
+	}
+
+	err := uc.postEntry(ctx, entry)
+	if err != nil && !errors.Is(err, apperrors.ErrEntryAlreadyExists) {
+		return fmt.Errorf("failed to post %s entry: %w", entry.Type, err)
+	}
+
+	return nil
+}
+
+// postEntry проверяет, что запись сбалансирована, и сохраняет ее
+func (uc *useCase) postEntry(ctx context.Context, entry models.JournalEntry) error {
+	if len(entry.Postings) < 2 {
+		return apperrors.ErrInvalidPosting
+	}
+
+	var debit, credit int64
+	for _, posting := range entry.Postings {
+		if posting.AccountCode == "" || posting.Amount <= 0 {
+			return apperrors.ErrInvalidPosting
+		}
+
+		switch posting.Direction {
+		case models.DirectionDebit:
+			debit += posting.Amount
+		case models.DirectionCredit:
+			credit += posting.Amount
+		default:
+			return apperrors.ErrInvalidPosting
+		}
+	}
+
+	if debit != credit {
+		return apperrors.ErrUnbalancedEntry
+	}
+
+	return uc.ledgerRepository.PostEntry(ctx, entry)
+}
+
+// paymentEntries строит записи журнала для завершенного платежа.
+// Оплата средствами инвесторов списывается с их счета без комиссии,
+// остальные способы поступают на счет расчетов с провайдером за вычетом комиссии
+func paymentEntries(transaction models.Transaction, feeRate float64) []models.JournalEntry {
+	amount := models.ToMinorUnits(transaction.Amount)
+	if amount <= 0 {
+		return nil
+	}
+
+	now := time.Now()
+
+	if transaction.PaymentMethod == payment_v1.PaymentMethod_PAYMENT_METHOD_INVESTOR_MONEY {
+		return []models.JournalEntry{{
+			UUID:            uuid.New().String(),
+			Type:            models.EntryTypeSettlement,
+			TransactionUUID: transaction.UUID,
+			Description:     "Оплата заказа " + transaction.OrderUUID + " средствами инвесторов",
+			CreatedAt:       now,
+			Postings: []models.Posting{
+				{AccountCode: models.AccountInvestorFunds, Direction: models.DirectionDebit, Amount: amount},
+				{AccountCode: models.AccountSalesRevenue, Direction: models.DirectionCredit, Amount: amount},
+			},
+		}}
+	}
+
+	clearingAccount := providerClearingAccount(transaction.PaymentMethod)
+
+	entries := []models.JournalEntry{{
+		UUID:            uuid.New().String(),
+		Type:            models.EntryTypeCharge,
+		TransactionUUID: transaction.UUID,
+		Description:     "Оплата заказа " + transaction.OrderUUID,
+		CreatedAt:       now,
+		Postings: []models.Posting{
+			{AccountCode: clearingAccount, Direction: models.DirectionDebit, Amount: amount},
+			{AccountCode: models.AccountSalesRevenue, Direction: models.DirectionCredit, Amount: amount},
+		},
+	}}
+
+	if fee := int64(math.Round(float64(amount) * feeRate)); fee > 0 {
+		entries = append(entries, models.JournalEntry{
+			UUID:            uuid.New().String(),
+			Type:            models.EntryTypeFee,
+			TransactionUUID: transaction.UUID,
+			Description:     "Комиссия провайдера за оплату заказа " + transaction.OrderUUID,
+			CreatedAt:       now,
+			Postings: []models.Posting{
+				{AccountCode: models.AccountProviderFees, Direction: models.DirectionDebit, Amount: fee},
+				{AccountCode: clearingAccount, Direction: models.DirectionCredit, Amount: fee},
+			},
+		})
+	}
+
+	return entries
+}
+
+// refundEntry строит запись журнала, сторнирующую выручку платежа. Средства инвесторов возвращаются на их счет,
+// остальные способы - на счет расчетов с провайдером; удержанная провайдером комиссия не возвращается
+func refundEntry(transaction models.Transaction) (models.JournalEntry, bool) {
+	amount := models.ToMinorUnits(transaction.Amount)
+	if amount <= 0 {
+		return models.JournalEntry{}, false
+	}
+
+	account := models.AccountInvestorFunds
+	if transaction.PaymentMethod != payment_v1.PaymentMethod_PAYMENT_METHOD_INVESTOR_MONEY {
+		account = providerClearingAccount(transaction.PaymentMethod)
+	}
+
+	return models.JournalEntry{
+		UUID:            uuid.New().String(),
+		Type:            models.EntryTypeRefund,
+		TransactionUUID: transaction.UUID,
+		Description:     "Возврат оплаты заказа " + transaction.OrderUUID,
+		CreatedAt:       time.Now(),
+		Postings: []models.Posting{
+			{AccountCode: models.AccountSalesRevenue, Direction: models.DirectionDebit, Amount: amount},
+			{AccountCode: account, Direction: models.DirectionCredit, Amount: amount},
+		},
+	}, true
+}
+
+// providerClearingAccount возвращает код счета расчетов с провайдером способа оплаты, например provider_clearing:card
+func providerClearingAccount(method payment_v1.PaymentMethod) string {
+	return models.AccountProviderClearingPrefix + strings.ToLower(strings.TrimPrefix(method.String(), "PAYMENT_METHOD_"))
+}
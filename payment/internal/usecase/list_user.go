@@ -0,0 +1,11 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
+)
+
+func (uc *useCase) ListUserTransactions(ctx context.Context, userID string) ([]models.Transaction, error) {
+	return uc.paymentRepository.ListUserTransactions(ctx, userID)
+}
@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
+	"github.com/linemk/rocket-shop/payment/internal/provider"
+)
+
+const (
+	providerOperationCharge = "charge"
+	providerOperationRefund = "refund"
+	providerOperationStatus = "status"
+
+	providerResultOK      = "ok"
+	providerResultError   = "error"
+	providerResultTimeout = "timeout"
+)
+
+// observeTransaction учитывает транзакцию, получившую итоговый статус
+func (uc *useCase) observeTransaction(transaction models.Transaction) {
+	if uc.metrics == nil {
+		return
+	}
+
+	paymentMethod := transaction.PaymentMethod.String()
+	uc.metrics.TransactionsTotal.WithLabelValues(paymentMethod, string(transaction.Status)).Inc()
+
+	switch transaction.Status {
+	case models.TransactionStatusCompleted:
+		uc.metrics.AmountTotal.WithLabelValues(paymentMethod).Add(transaction.Amount)
+	case models.TransactionStatusRefunded:
+		uc.metrics.RefundedAmountTotal.WithLabelValues(paymentMethod).Add(transaction.Amount)
+	}
+}
+
+// observeProvider учитывает время ответа провайдера на операцию, начатую в start
+func (uc *useCase) observeProvider(paymentProvider provider.PaymentProvider, operation string, start time.Time, err error) {
+	if uc.metrics == nil {
+		return
+	}
+
+	result := providerResultOK
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		result = providerResultTimeout
+	case err != nil:
+		result = providerResultError
+	}
+
+	uc.metrics.ProviderDuration.WithLabelValues(paymentProvider.Name(), operation, result).Observe(time.Since(start).Seconds())
+}
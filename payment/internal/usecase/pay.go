@@ -2,49 +2,294 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 
 	"github.com/linemk/rocket-shop/payment/internal/entyties/apperrors"
+	"github.com/linemk/rocket-shop/payment/internal/entyties/events"
 	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
+	"github.com/linemk/rocket-shop/payment/internal/provider"
 	"github.com/linemk/rocket-shop/platform/pkg/logger"
 	payment_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/payment/v1"
 )
 
-func (uc *useCase) PayOrder(ctx context.Context, orderUUID, userID string, paymentMethod payment_v1.PaymentMethod) (string, error) {
+// fraudDeclineReason причина отказа в платеже, отклоненном антифрод-проверкой
+const fraudDeclineReason = "declined by fraud screening"
+
+func (uc *useCase) PayOrder(ctx context.Context, orderUUID, userID string, paymentMethod payment_v1.PaymentMethod, amount float64, idempotencyKey string) (models.Transaction, error) {
 	// Валидация входных данных
 	if orderUUID == "" {
-		return "", apperrors.ErrInvalidAmount
+		return models.Transaction{}, apperrors.ErrInvalidAmount
 	}
 	if userID == "" {
-		return "", apperrors.ErrInvalidAmount
+		return models.Transaction{}, apperrors.ErrInvalidAmount
+	}
+	if amount < 0 {
+		return models.Transaction{}, apperrors.ErrInvalidAmount
+	}
+
+	transactionUUID, err := transactionUUIDFor(idempotencyKey)
+	if err != nil {
+		return models.Transaction{}, err
+	}
+
+	paymentProvider, err := uc.providers.Get(paymentMethod)
+	if err != nil {
+		return models.Transaction{}, err
+	}
+
+	// Повтор попытки с неизвестным исходом, как и новая попытка, пока прошлая не завершилась,
+	// не должны списать заказ дважды
+	existing, ok, err := uc.orderPayment(ctx, orderUUID, idempotencyKey)
+	if err != nil {
+		return models.Transaction{}, apperrors.ErrPaymentFailed
+	}
+	if ok {
+		return existing, nil
+	}
+
+	// Сохраняем транзакцию до обращения к провайдеру, чтобы платеж не потерялся при сбое
+	transaction := uc.newTransaction(ctx, transactionUUID, orderUUID, userID, paymentMethod, amount, "")
+	if err := uc.paymentRepository.CreateTransaction(ctx, transaction); err != nil {
+		if !errors.Is(err, apperrors.ErrTransactionAlreadyExists) {
+			return models.Transaction{}, apperrors.ErrPaymentFailed
+		}
+
+		// Транзакцию с этим ключом создал одновременный повтор, либо ключ занят транзакцией другого заказа
+		existing, ok, err := uc.orderPayment(ctx, orderUUID, idempotencyKey)
+		switch {
+		case err != nil:
+			return models.Transaction{}, apperrors.ErrPaymentFailed
+		case !ok:
+			return models.Transaction{}, apperrors.ErrTransactionAlreadyExists
+		}
+		return existing, nil
+	}
+
+	return uc.start(ctx, transaction, paymentProvider)
+}
+
+// transactionUUIDFor возвращает UUID новой транзакции или группы: ключ идемпотентности, если он задан
+func transactionUUIDFor(idempotencyKey string) (string, error) {
+	if idempotencyKey == "" {
+		return uuid.New().String(), nil
 	}
 
-	// Генерируем UUID транзакции
-	transactionUUID := uuid.New()
+	if _, err := uuid.Parse(idempotencyKey); err != nil {
+		return "", apperrors.ErrInvalidIdempotencyKey
+	}
+
+	return idempotencyKey, nil
+}
+
+// orderPayment ищет транзакцию оплаты заказа, которую нужно вернуть вместо новой: транзакцию с UUID idempotencyKey
+// в любом статусе, иначе ожидающую или завершенную транзакцию заказа. Части раздельной оплаты и платежи
+// по графику рассрочки оплачивают заказ иначе и не учитываются
+func (uc *useCase) orderPayment(ctx context.Context, orderUUID, idempotencyKey string) (models.Transaction, bool, error) {
+	transactions, err := uc.paymentRepository.ListTransactions(ctx, orderUUID)
+	if err != nil {
+		return models.Transaction{}, false, err
+	}
+
+	var active *models.Transaction
+	for i := range transactions {
+		transaction := &transactions[i]
+		if transaction.SplitUUID != "" || transaction.InstallmentPlanUUID != "" {
+			continue
+		}
+
+		if idempotencyKey != "" && transaction.UUID == idempotencyKey {
+			return *transaction, true, nil
+		}
+
+		if active == nil && (transaction.Status == models.TransactionStatusPending || transaction.Status == models.TransactionStatusCompleted) {
+			active = transaction
+		}
+	}
+
+	if active == nil {
+		return models.Transaction{}, false, nil
+	}
+
+	return *active, true, nil
+}
+
+// newTransaction создает PENDING транзакцию и проверяет ее антифрод-правилами
+func (uc *useCase) newTransaction(ctx context.Context, transactionUUID, orderUUID, userID string, paymentMethod payment_v1.PaymentMethod, amount float64, splitUUID string) models.Transaction {
 	now := time.Now()
 
-	// Создаем транзакцию (amount будет 0, так как его нет в protobuf)
 	transaction := models.Transaction{
-		UUID:          transactionUUID.String(),
+		UUID:          transactionUUID,
 		OrderUUID:     orderUUID,
 		UserID:        userID,
 		PaymentMethod: paymentMethod,
-		Amount:        0,                                 // В реальном приложении amount должен приходить извне
-		Status:        models.TransactionStatusCompleted, // В реальном приложении здесь была бы логика обработки платежа
+		Amount:        amount,
+		Status:        models.TransactionStatusPending,
+		SplitUUID:     splitUUID,
 		CreatedAt:     now,
 		UpdatedAt:     now,
 	}
 
-	// Сохраняем транзакцию в репозитории
-	if err := uc.paymentRepository.CreateTransaction(ctx, transaction); err != nil {
-		return "", apperrors.ErrPaymentFailed
+	verdict := uc.fraudScreener.Screen(ctx, models.FraudCheck{
+		TransactionUUID: transaction.UUID,
+		UserID:          transaction.UserID,
+		PaymentMethod:   transaction.PaymentMethod,
+		Amount:          transaction.Amount,
+		At:              now,
+	})
+	transaction.FraudDecision = verdict.Decision
+	transaction.FraudReasons = verdict.Reasons
+
+	return transaction
+}
+
+// start обрабатывает сохраненную транзакцию по решению антифрод-проверки: отклоняет ее, оставляет на ручную проверку,
+// запрашивает согласование или запускает списание
+func (uc *useCase) start(ctx context.Context, transaction models.Transaction, paymentProvider provider.PaymentProvider) (models.Transaction, error) {
+	switch transaction.FraudDecision {
+	case models.FraudDecisionDecline:
+		transaction.Status = models.TransactionStatusFailed
+		transaction.FailureReason = fraudDeclineReason
+		if err := uc.finish(ctx, transaction); err != nil {
+			return models.Transaction{}, err
+		}
+
+		return transaction, nil
+	case models.FraudDecisionReview:
+		logger.Warn(ctx, "Платеж отправлен на ручную проверку",
+			zap.String("transaction_uuid", transaction.UUID),
+			zap.String("order_uuid", transaction.OrderUUID),
+			zap.Strings("fraud_reasons", transaction.FraudReasons),
+		)
+
+		return transaction, nil
+	}
+
+	if uc.requiresApproval(transaction) {
+		return uc.requestApproval(ctx, transaction)
+	}
+
+	// Списание выполняется асинхронно: клиент получает PENDING транзакцию,
+	// а результат узнает из события PaymentCompleted или PaymentFailed
+	uc.inFlight.Store(transaction.UUID, struct{}{})
+	uc.goCharge(ctx, transaction, paymentProvider)
+
+	return transaction, nil
+}
+
+// goCharge запускает списание в фоне. Транзакция должна быть уже занята в inFlight, charge освободит ее сам
+func (uc *useCase) goCharge(ctx context.Context, transaction models.Transaction, paymentProvider provider.PaymentProvider) {
+	uc.charges.Add(1)
+	go func() {
+		defer uc.charges.Done()
+		uc.charge(context.WithoutCancel(ctx), transaction, paymentProvider)
+	}()
+}
+
+// charge обращается к провайдеру и завершает транзакцию по его ответу
+func (uc *useCase) charge(ctx context.Context, transaction models.Transaction, paymentProvider provider.PaymentProvider) {
+	defer uc.inFlight.Delete(transaction.UUID)
+
+	chargeCtx, cancel := context.WithTimeout(ctx, uc.providerConfig.Timeout())
+	defer cancel()
+
+	start := time.Now()
+	result, err := paymentProvider.Charge(chargeCtx, models.ChargeRequest{
+		TransactionUUID: transaction.UUID,
+		OrderUUID:       transaction.OrderUUID,
+		UserID:          transaction.UserID,
+		PaymentMethod:   transaction.PaymentMethod,
+		Amount:          transaction.Amount,
+	})
+	uc.observeProvider(paymentProvider, providerOperationCharge, start, err)
+
+	if !applyChargeResult(&transaction, result, err) {
+		logger.Warn(ctx, "Исход списания неизвестен, транзакция остается PENDING",
+			zap.String("transaction_uuid", transaction.UUID),
+			zap.String("provider", paymentProvider.Name()),
+			zap.Error(err),
+		)
+		return
+	}
+
+	logger.Info(ctx, "Платеж обработан провайдером",
+		zap.String("transaction_uuid", transaction.UUID),
+		zap.String("provider", paymentProvider.Name()),
+		zap.String("status", string(transaction.Status)),
+		zap.String("failure_reason", transaction.FailureReason),
+	)
+
+	// Платеж, требующий подтверждения, остается PENDING до вызова ConfirmTransaction
+	if transaction.Status == models.TransactionStatusPending {
+		return
+	}
+
+	if err := uc.finish(ctx, transaction); err != nil {
+		logger.Error(ctx, "Не удалось завершить транзакцию",
+			zap.String("transaction_uuid", transaction.UUID),
+			zap.Error(err),
+		)
 	}
+}
+
+// applyChargeResult переносит в транзакцию ответ провайдера и сообщает, известен ли исход списания.
+// Ошибка вызова, в том числе таймаут, не означает отказ: списание могло пройти, поэтому
+// транзакция остается PENDING до сверки статуса у провайдера или ручного ConfirmTransaction
+func applyChargeResult(transaction *models.Transaction, result models.ChargeResult, err error) bool {
+	if err != nil {
+		return false
+	}
+
+	transaction.Status = result.Status
+	transaction.FailureReason = result.DeclineReason
+
+	return true
+}
 
-	// Выводим сообщение в консоль согласно спецификации
-	logger.Info(ctx, "Оплата прошла успешно", zap.String("transaction_uuid", transactionUUID.String()))
+// finish сохраняет итоговый статус транзакции и публикует событие о результате платежа
+func (uc *useCase) finish(ctx context.Context, transaction models.Transaction) error {
+	// О результате раздельной оплаты заказ узнает по всей группе транзакций
+	if transaction.SplitUUID != "" {
+		return uc.finishTender(ctx, transaction)
+	}
+
+	transaction.UpdatedAt = time.Now()
+
+	// Платеж отражается в книге до смены статуса: при ошибке транзакция остается PENDING
+	// и может быть завершена повторно через ConfirmTransaction
+	if transaction.Status == models.TransactionStatusCompleted {
+		if err := uc.recordPayment(ctx, transaction); err != nil {
+			return err
+		}
+	}
+
+	if err := uc.paymentRepository.UpdateTransaction(ctx, transaction.UUID, transaction); err != nil {
+		return err
+	}
+	uc.observeTransaction(transaction)
+
+	if transaction.Status == models.TransactionStatusCompleted {
+		return uc.paymentProducer.SendPaymentCompleted(ctx, &events.PaymentCompletedEvent{
+			EventUUID:       uuid.New().String(),
+			TransactionUUID: transaction.UUID,
+			OrderUUID:       transaction.OrderUUID,
+			UserUUID:        transaction.UserID,
+			PaymentMethod:   transaction.PaymentMethod.String(),
+			Amount:          transaction.Amount,
+			CompletedAt:     transaction.UpdatedAt,
+		})
+	}
 
-	return transactionUUID.String(), nil
+	return uc.paymentProducer.SendPaymentFailed(ctx, &events.PaymentFailedEvent{
+		EventUUID:       uuid.New().String(),
+		TransactionUUID: transaction.UUID,
+		OrderUUID:       transaction.OrderUUID,
+		UserUUID:        transaction.UserID,
+		Status:          string(transaction.Status),
+		Reason:          transaction.FailureReason,
+		FailedAt:        transaction.UpdatedAt,
+	})
 }
@@ -0,0 +1,163 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/linemk/rocket-shop/payment/internal/entyties/apperrors"
+	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
+	"github.com/linemk/rocket-shop/platform/pkg/logger"
+)
+
+// recoveryBatchSize число транзакций, читаемых из хранилища за один раз
+const recoveryBatchSize = 100
+
+func (uc *useCase) RecoverPendingCharges(ctx context.Context) (int, error) {
+	createdBefore := time.Now().Add(-uc.providerConfig.RecoveryAfter())
+
+	// Сверяются все транзакции: ожидающие подтверждения покупателем или согласования остаются PENDING
+	// и при выборке одной пачкой не пропускали бы к сверке остальные
+	recovered := 0
+	var after models.Transaction
+	for ctx.Err() == nil {
+		stale, err := uc.paymentRepository.ListStalePending(ctx, createdBefore, after, recoveryBatchSize)
+		if err != nil {
+			return recovered, err
+		}
+
+		for _, transaction := range stale {
+			// Ошибка одной транзакции не останавливает остальные: она будет сверена при следующем запуске
+			done, err := uc.recoverCharge(ctx, transaction)
+			if err != nil {
+				logger.Error(ctx, "Не удалось сверить транзакцию с провайдером",
+					zap.String("transaction_uuid", transaction.UUID),
+					zap.Error(err),
+				)
+				continue
+			}
+			if done {
+				recovered++
+			}
+		}
+
+		if len(stale) < recoveryBatchSize {
+			break
+		}
+		after = stale[len(stale)-1]
+	}
+
+	return recovered, nil
+}
+
+// recoverCharge запрашивает у провайдера исход списания и завершает транзакцию, если он известен.
+// Списание, не дошедшее до провайдера, отправляется повторно с тем же UUID транзакции
+func (uc *useCase) recoverCharge(ctx context.Context, transaction models.Transaction) (bool, error) {
+	if _, busy := uc.inFlight.LoadOrStore(transaction.UUID, struct{}{}); busy {
+		return false, nil
+	}
+	charging := false
+	defer func() {
+		if !charging {
+			uc.inFlight.Delete(transaction.UUID)
+		}
+	}()
+
+	// Платеж средствами инвестора передается провайдеру только после согласования. Итог согласования
+	// фиксируется до обработки транзакции, поэтому прерванная обработка завершается здесь
+	if transaction.ApprovalUUID != "" {
+		approval, err := uc.approvalRepository.GetApproval(ctx, transaction.ApprovalUUID)
+		if err != nil {
+			return false, err
+		}
+
+		switch approval.Status {
+		case models.ApprovalStatusPending:
+			return false, nil
+		case models.ApprovalStatusRejected, models.ApprovalStatusExpired:
+			logger.Warn(ctx, "Транзакция не была завершена после отказа в согласовании, завершаем при сверке",
+				zap.String("transaction_uuid", transaction.UUID),
+				zap.String("approval_uuid", approval.UUID),
+				zap.String("approval_status", string(approval.Status)),
+			)
+
+			applyApprovalRefusal(&transaction, approval)
+			if err := uc.finish(ctx, transaction); err != nil {
+				return false, err
+			}
+
+			return true, nil
+		}
+	}
+
+	paymentProvider, err := uc.providers.Get(transaction.PaymentMethod)
+	if err != nil {
+		return false, err
+	}
+
+	statusCtx, cancel := context.WithTimeout(ctx, uc.providerConfig.Timeout())
+	start := time.Now()
+	result, err := paymentProvider.Status(statusCtx, transaction.UUID)
+	cancel()
+	uc.observeProvider(paymentProvider, providerOperationStatus, start, err)
+
+	switch {
+	case errors.Is(err, apperrors.ErrChargeNotFound):
+		// Часть раздельной оплаты, группа которой уже не прошла, не списывается, а отменяется
+		if transaction.SplitUUID != "" {
+			cancelled, err := uc.cancelFailedTender(ctx, transaction)
+			if err != nil || cancelled {
+				return cancelled, err
+			}
+		}
+
+		logger.Warn(ctx, "Списание не дошло до провайдера, отправляем повторно",
+			zap.String("transaction_uuid", transaction.UUID),
+			zap.String("provider", paymentProvider.Name()),
+		)
+
+		// Транзакция остается в inFlight до ответа провайдера, charge удалит ее сам
+		charging = true
+		uc.goCharge(ctx, transaction, paymentProvider)
+
+		return false, nil
+	case err != nil:
+		return false, err
+	}
+
+	applyChargeResult(&transaction, result, nil)
+
+	// Платеж ожидает подтверждения покупателем и будет завершен через ConfirmTransaction
+	if transaction.Status == models.TransactionStatusPending {
+		return false, nil
+	}
+
+	logger.Info(ctx, "Исход списания получен от провайдера при сверке",
+		zap.String("transaction_uuid", transaction.UUID),
+		zap.String("provider", paymentProvider.Name()),
+		zap.String("status", string(transaction.Status)),
+	)
+
+	if err := uc.finish(ctx, transaction); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (uc *useCase) Drain(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		uc.charges.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
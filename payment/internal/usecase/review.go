@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/linemk/rocket-shop/payment/internal/entyties/apperrors"
+	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
+	"github.com/linemk/rocket-shop/platform/pkg/logger"
+)
+
+// defaultReviewRejectReason причина отказа, если она не передана при отклонении платежа по итогам проверки
+const defaultReviewRejectReason = "declined by fraud review"
+
+func (uc *useCase) ReviewTransaction(ctx context.Context, transactionUUID, reviewerID string, approved bool, reason string) (models.Transaction, error) {
+	// Решение по транзакции принимается один раз, даже если проверяющие ответили одновременно
+	if _, busy := uc.inFlight.LoadOrStore(transactionUUID, struct{}{}); busy {
+		return models.Transaction{}, apperrors.ErrTransactionNotInReview
+	}
+	charging := false
+	defer func() {
+		if !charging {
+			uc.inFlight.Delete(transactionUUID)
+		}
+	}()
+
+	transaction, err := uc.paymentRepository.GetTransaction(ctx, transactionUUID)
+	if err != nil {
+		return models.Transaction{}, apperrors.ErrTransactionNotFound
+	}
+
+	if transaction.Status != models.TransactionStatusPending || transaction.FraudDecision != models.FraudDecisionReview {
+		return models.Transaction{}, apperrors.ErrTransactionNotInReview
+	}
+
+	logger.Info(ctx, "Принято решение по ручной проверке платежа",
+		zap.String("transaction_uuid", transaction.UUID),
+		zap.String("reviewed_by", reviewerID),
+		zap.Bool("approved", approved),
+		zap.String("reason", reason),
+	)
+
+	transaction.ReviewedBy = reviewerID
+
+	if !approved {
+		if reason == "" {
+			reason = defaultReviewRejectReason
+		}
+		transaction.FraudDecision = models.FraudDecisionDecline
+		transaction.Status = models.TransactionStatusFailed
+		transaction.FailureReason = reason
+
+		if err := uc.finish(ctx, transaction); err != nil {
+			return models.Transaction{}, err
+		}
+
+		return transaction, nil
+	}
+
+	paymentProvider, err := uc.providers.Get(transaction.PaymentMethod)
+	if err != nil {
+		return models.Transaction{}, err
+	}
+
+	transaction.FraudDecision = models.FraudDecisionApprove
+	transaction.UpdatedAt = time.Now()
+	if err := uc.paymentRepository.UpdateTransaction(ctx, transaction.UUID, transaction); err != nil {
+		return models.Transaction{}, err
+	}
+
+	if uc.requiresApproval(transaction) {
+		return uc.requestApproval(ctx, transaction)
+	}
+
+	// Транзакция остается в inFlight до ответа провайдера, charge удалит ее сам
+	charging = true
+	uc.goCharge(ctx, transaction, paymentProvider)
+
+	return transaction, nil
+}
+
+func (uc *useCase) ListReviewQueue(ctx context.Context) ([]models.Transaction, error) {
+	return uc.paymentRepository.ListAwaitingReview(ctx)
+}
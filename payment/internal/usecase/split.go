@@ -0,0 +1,404 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/linemk/rocket-shop/payment/internal/entyties/apperrors"
+	"github.com/linemk/rocket-shop/payment/internal/entyties/events"
+	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
+	"github.com/linemk/rocket-shop/payment/internal/provider"
+	"github.com/linemk/rocket-shop/platform/pkg/logger"
+)
+
+const (
+	// compensationBatchSize число частей, читаемых из хранилища за один раз при повторе компенсаций
+	compensationBatchSize = 100
+	// splitCompensatedReason причина отмены или возврата части, если не прошла другая часть раздельной оплаты
+	splitCompensatedReason = "another split tender failed"
+	// splitStartFailedReason причина отмены части, которую не удалось передать на списание
+	splitStartFailedReason = "failed to start split tender"
+)
+
+func (uc *useCase) PaySplit(ctx context.Context, orderUUID, userID string, tenders []models.Tender, amount float64, idempotencyKey string) (string, []models.Transaction, error) {
+	if orderUUID == "" || userID == "" || amount < 0 {
+		return "", nil, apperrors.ErrInvalidAmount
+	}
+	if len(tenders) < models.MinSplitTenders {
+		return "", nil, apperrors.ErrInvalidSplit
+	}
+
+	var total int64
+	providers := make([]provider.PaymentProvider, 0, len(tenders))
+	for _, tender := range tenders {
+		if tender.Amount <= 0 {
+			return "", nil, apperrors.ErrInvalidSplit
+		}
+		total += models.ToMinorUnits(tender.Amount)
+
+		paymentProvider, err := uc.providers.Get(tender.PaymentMethod)
+		if err != nil {
+			return "", nil, err
+		}
+		providers = append(providers, paymentProvider)
+	}
+
+	if total != models.ToMinorUnits(amount) {
+		return "", nil, apperrors.ErrInvalidSplit
+	}
+
+	splitUUID, err := transactionUUIDFor(idempotencyKey)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// Повтор попытки с неизвестным исходом возвращает уже созданную группу, не списывая части повторно
+	if idempotencyKey != "" {
+		existing, err := uc.splitTransactions(ctx, orderUUID, splitUUID)
+		if err != nil {
+			return "", nil, apperrors.ErrPaymentFailed
+		}
+		if len(existing) > 0 {
+			return splitUUID, existing, nil
+		}
+	}
+
+	// Все части сохраняются до списания первой из них, чтобы отказ любой части видел всю группу
+	transactions := make([]models.Transaction, 0, len(tenders))
+	for _, tender := range tenders {
+		transaction := uc.newTransaction(ctx, uuid.New().String(), orderUUID, userID, tender.PaymentMethod, tender.Amount, splitUUID)
+		if err := uc.paymentRepository.CreateTransaction(ctx, transaction); err != nil {
+			uc.abandonTenders(ctx, transactions)
+			return "", nil, apperrors.ErrPaymentFailed
+		}
+		transactions = append(transactions, transaction)
+	}
+
+	logger.Info(ctx, "Создана раздельная оплата заказа",
+		zap.String("split_uuid", splitUUID),
+		zap.String("order_uuid", orderUUID),
+		zap.Int("tenders", len(transactions)),
+	)
+
+	for i, transaction := range transactions {
+		// Части, отмененные из-за отказа предыдущей части, не списываются
+		current, err := uc.paymentRepository.GetTransaction(ctx, transaction.UUID)
+		if err != nil {
+			return "", nil, apperrors.ErrTransactionNotFound
+		}
+		if current.Status != models.TransactionStatusPending {
+			continue
+		}
+
+		if _, err := uc.start(ctx, transaction, providers[i]); err != nil {
+			// Отмена части проваливает всю группу: уже списанные части будут возвращены
+			transaction.Status = models.TransactionStatusCancelled
+			transaction.FailureReason = splitStartFailedReason
+			if finishErr := uc.finish(ctx, transaction); finishErr != nil {
+				logger.Error(ctx, "Не удалось отменить часть раздельной оплаты",
+					zap.String("split_uuid", splitUUID),
+					zap.String("transaction_uuid", transaction.UUID),
+					zap.Error(finishErr),
+				)
+			}
+
+			return "", nil, apperrors.ErrPaymentFailed
+		}
+	}
+
+	transactions, err = uc.splitTransactions(ctx, orderUUID, splitUUID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return splitUUID, transactions, nil
+}
+
+// finishTender сохраняет итоговый статус части раздельной оплаты. Заказ получает одно событие на всю группу:
+// PaymentCompleted после списания последней части или PaymentFailed после первого отказа. При отказе
+// уже списанные части возвращаются, ожидающие - отменяются, а части, списанные позже, возвращаются сразу
+func (uc *useCase) finishTender(ctx context.Context, transaction models.Transaction) error {
+	uc.splitMu.Lock()
+	defer uc.splitMu.Unlock()
+
+	transactions, err := uc.splitTransactions(ctx, transaction.OrderUUID, transaction.SplitUUID)
+	if err != nil {
+		return err
+	}
+
+	siblings := make([]models.Transaction, 0, len(transactions))
+	for _, sibling := range transactions {
+		if sibling.UUID != transaction.UUID {
+			siblings = append(siblings, sibling)
+		}
+	}
+
+	// Об отказе другой части заказ уже уведомлен
+	failedBefore := models.SplitStatus(siblings) == models.TransactionStatusFailed
+
+	transaction.UpdatedAt = time.Now()
+	if transaction.Status == models.TransactionStatusCompleted {
+		if err := uc.recordPayment(ctx, transaction); err != nil {
+			return err
+		}
+	}
+
+	if err := uc.paymentRepository.UpdateTransaction(ctx, transaction.UUID, transaction); err != nil {
+		return err
+	}
+	uc.observeTransaction(transaction)
+
+	if failedBefore {
+		if transaction.Status == models.TransactionStatusCompleted {
+			return uc.refundTender(ctx, transaction)
+		}
+		return nil
+	}
+
+	if transaction.Status != models.TransactionStatusCompleted {
+		uc.compensateSplit(ctx, siblings)
+
+		return uc.paymentProducer.SendPaymentFailed(ctx, &events.PaymentFailedEvent{
+			EventUUID:       uuid.New().String(),
+			TransactionUUID: transaction.SplitUUID,
+			OrderUUID:       transaction.OrderUUID,
+			UserUUID:        transaction.UserID,
+			Status:          string(transaction.Status),
+			Reason:          fmt.Sprintf("%s tender failed: %s", transaction.PaymentMethod, transaction.FailureReason),
+			FailedAt:        transaction.UpdatedAt,
+		})
+	}
+
+	if models.SplitStatus(siblings) != models.TransactionStatusCompleted {
+		return nil
+	}
+
+	methods := []string{transaction.PaymentMethod.String()}
+	amount := models.ToMinorUnits(transaction.Amount)
+	for _, sibling := range siblings {
+		methods = append(methods, sibling.PaymentMethod.String())
+		amount += models.ToMinorUnits(sibling.Amount)
+	}
+
+	return uc.paymentProducer.SendPaymentCompleted(ctx, &events.PaymentCompletedEvent{
+		EventUUID:       uuid.New().String(),
+		TransactionUUID: transaction.SplitUUID,
+		OrderUUID:       transaction.OrderUUID,
+		UserUUID:        transaction.UserID,
+		PaymentMethod:   strings.Join(methods, ","),
+		Amount:          float64(amount) / 100,
+		CompletedAt:     transaction.UpdatedAt,
+	})
+}
+
+// compensateSplit возвращает списанные части группы и отменяет ожидающие. Части, по которым ждется ответ провайдера
+// или принимается решение ручной проверки, возвращаются или отменяются при их завершении
+func (uc *useCase) compensateSplit(ctx context.Context, transactions []models.Transaction) {
+	for _, transaction := range transactions {
+		var err error
+		switch transaction.Status {
+		case models.TransactionStatusCompleted:
+			err = uc.refundTender(ctx, transaction)
+		case models.TransactionStatusPending:
+			if _, busy := uc.inFlight.Load(transaction.UUID); busy {
+				continue
+			}
+			err = uc.cancelTender(ctx, transaction, splitCompensatedReason)
+		}
+
+		// Списанная часть не прошедшей группы будет возвращена повторно в CompensateSplits
+		if err != nil {
+			logger.Error(ctx, "Не удалось компенсировать часть раздельной оплаты",
+				zap.String("split_uuid", transaction.SplitUUID),
+				zap.String("transaction_uuid", transaction.UUID),
+				zap.String("status", string(transaction.Status)),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+func (uc *useCase) CompensateSplits(ctx context.Context) (int, error) {
+	refunded := 0
+	var after models.Transaction
+	for ctx.Err() == nil {
+		tenders, err := uc.paymentRepository.ListUncompensatedTenders(ctx, after, compensationBatchSize)
+		if err != nil {
+			return refunded, err
+		}
+
+		for _, tender := range tenders {
+			// Ошибка одной части не останавливает остальные: она будет возвращена при следующем запуске
+			done, err := uc.compensateTender(ctx, tender.UUID)
+			if err != nil {
+				logger.Error(ctx, "Не удалось вернуть часть раздельной оплаты",
+					zap.String("split_uuid", tender.SplitUUID),
+					zap.String("transaction_uuid", tender.UUID),
+					zap.Error(err),
+				)
+				continue
+			}
+			if done {
+				refunded++
+			}
+		}
+
+		if len(tenders) < compensationBatchSize {
+			break
+		}
+		after = tenders[len(tenders)-1]
+	}
+
+	return refunded, nil
+}
+
+// compensateTender возвращает списанную часть не прошедшей группы, если ее еще не вернули
+func (uc *useCase) compensateTender(ctx context.Context, transactionUUID string) (bool, error) {
+	uc.splitMu.Lock()
+	defer uc.splitMu.Unlock()
+
+	// Часть перечитывается под блокировкой: ее мог вернуть finishTender
+	transaction, err := uc.paymentRepository.GetTransaction(ctx, transactionUUID)
+	if err != nil {
+		return false, err
+	}
+	if transaction.Status != models.TransactionStatusCompleted {
+		return false, nil
+	}
+
+	if err := uc.refundTender(ctx, transaction); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// refundTender возвращает средства списанной части через провайдера и отражает возврат в книге
+func (uc *useCase) refundTender(ctx context.Context, transaction models.Transaction) error {
+	paymentProvider, err := uc.providers.Get(transaction.PaymentMethod)
+	if err != nil {
+		return err
+	}
+
+	refundCtx, cancel := context.WithTimeout(ctx, uc.providerConfig.Timeout())
+	defer cancel()
+
+	start := time.Now()
+	err = paymentProvider.Refund(refundCtx, models.RefundRequest{
+		TransactionUUID: transaction.UUID,
+		OrderUUID:       transaction.OrderUUID,
+		UserID:          transaction.UserID,
+		PaymentMethod:   transaction.PaymentMethod,
+		Amount:          transaction.Amount,
+		Reason:          splitCompensatedReason,
+	})
+	uc.observeProvider(paymentProvider, providerOperationRefund, start, err)
+	if err != nil {
+		return fmt.Errorf("failed to refund transaction: %w", err)
+	}
+
+	if err := uc.recordRefund(ctx, transaction); err != nil {
+		return err
+	}
+
+	transaction.Status = models.TransactionStatusRefunded
+	transaction.FailureReason = splitCompensatedReason
+	transaction.UpdatedAt = time.Now()
+	if err := uc.paymentRepository.UpdateTransaction(ctx, transaction.UUID, transaction); err != nil {
+		return err
+	}
+	uc.observeTransaction(transaction)
+
+	logger.Info(ctx, "Часть раздельной оплаты возвращена",
+		zap.String("split_uuid", transaction.SplitUUID),
+		zap.String("transaction_uuid", transaction.UUID),
+		zap.String("provider", paymentProvider.Name()),
+	)
+
+	return nil
+}
+
+// cancelTender отменяет часть, не переданную провайдеру, вместе с ее запросом на согласование
+func (uc *useCase) cancelTender(ctx context.Context, transaction models.Transaction, reason string) error {
+	now := time.Now()
+
+	transaction.Status = models.TransactionStatusCancelled
+	transaction.FailureReason = reason
+	transaction.UpdatedAt = now
+	if err := uc.paymentRepository.UpdateTransaction(ctx, transaction.UUID, transaction); err != nil {
+		return err
+	}
+	uc.observeTransaction(transaction)
+
+	if transaction.ApprovalUUID == "" {
+		return nil
+	}
+
+	err := uc.approvalRepository.ResolveApproval(ctx, transaction.ApprovalUUID, models.ApprovalStatusRejected, now)
+	if err != nil && !errors.Is(err, apperrors.ErrApprovalNotPending) {
+		return err
+	}
+
+	return nil
+}
+
+// cancelFailedTender отменяет часть, не переданную провайдеру, если группа раздельной оплаты уже не прошла
+func (uc *useCase) cancelFailedTender(ctx context.Context, transaction models.Transaction) (bool, error) {
+	uc.splitMu.Lock()
+	defer uc.splitMu.Unlock()
+
+	transactions, err := uc.splitTransactions(ctx, transaction.OrderUUID, transaction.SplitUUID)
+	if err != nil {
+		return false, err
+	}
+	if models.SplitStatus(transactions) != models.TransactionStatusFailed {
+		return false, nil
+	}
+
+	if err := uc.cancelTender(ctx, transaction, splitCompensatedReason); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// abandonTenders отменяет сохраненные части группы, списание которых не начиналось
+func (uc *useCase) abandonTenders(ctx context.Context, transactions []models.Transaction) {
+	for _, transaction := range transactions {
+		if err := uc.cancelTender(ctx, transaction, splitStartFailedReason); err != nil {
+			logger.Error(ctx, "Не удалось отменить часть раздельной оплаты",
+				zap.String("split_uuid", transaction.SplitUUID),
+				zap.String("transaction_uuid", transaction.UUID),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+// splitTransactions возвращает части раздельной оплаты в порядке создания
+func (uc *useCase) splitTransactions(ctx context.Context, orderUUID, splitUUID string) ([]models.Transaction, error) {
+	orderTransactions, err := uc.paymentRepository.ListTransactions(ctx, orderUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	var transactions []models.Transaction
+	for _, transaction := range orderTransactions {
+		if transaction.SplitUUID == splitUUID {
+			transactions = append(transactions, transaction)
+		}
+	}
+
+	sort.SliceStable(transactions, func(i, j int) bool {
+		return transactions[i].CreatedAt.Before(transactions[j].CreatedAt)
+	})
+
+	return transactions, nil
+}
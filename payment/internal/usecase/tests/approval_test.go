@@ -0,0 +1,423 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/linemk/rocket-shop/payment/internal/entyties/apperrors"
+	"github.com/linemk/rocket-shop/payment/internal/entyties/events"
+	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
+	"github.com/linemk/rocket-shop/payment/internal/mocks"
+	"github.com/linemk/rocket-shop/payment/internal/provider"
+	"github.com/linemk/rocket-shop/payment/internal/usecase"
+	"github.com/linemk/rocket-shop/platform/pkg/logger"
+	payment_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/payment/v1"
+)
+
+type approvalConfig struct {
+	approvers []string
+	quorum    int
+	timeout   time.Duration
+}
+
+func (c approvalConfig) Approvers() []string {
+	return c.approvers
+}
+
+func (c approvalConfig) Quorum() int {
+	return c.quorum
+}
+
+func (c approvalConfig) Timeout() time.Duration {
+	return c.timeout
+}
+
+func (c approvalConfig) ExpiryInterval() time.Duration {
+	return time.Minute
+}
+
+func TestPayOrderInvestorMoney(t *testing.T) {
+	ctx := context.Background()
+	if err := logger.Init(ctx, "info", false, false, "", "payment-test"); err != nil {
+		t.Fatalf("failed to init logger: %v", err)
+	}
+
+	orderUUID := uuid.New().String()
+	userID := uuid.New().String()
+	cfg := approvalConfig{approvers: []string{"alice", "bob", "carol"}, quorum: 2, timeout: time.Hour}
+
+	t.Run("payment waits for approval", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		var approvalUUID string
+		approvalRepo := mocks.NewMockApprovalRepository(ctrl)
+		approvalRepo.EXPECT().CreateApproval(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, approval models.Approval) error {
+				require.Equal(t, cfg.approvers, approval.Approvers)
+				require.Equal(t, 2, approval.Quorum)
+				require.Equal(t, models.ApprovalStatusPending, approval.Status)
+				require.WithinDuration(t, time.Now().Add(time.Hour), approval.ExpiresAt, time.Minute)
+				approvalUUID = approval.UUID
+				return nil
+			})
+
+		repo := mocks.NewMockPaymentRepository(ctrl)
+		repo.EXPECT().ListTransactions(ctx, gomock.Any()).Return(nil, nil)
+		repo.EXPECT().CreateTransaction(ctx, gomock.Any()).Return(nil)
+		repo.EXPECT().UpdateTransaction(ctx, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, transaction models.Transaction) error {
+				require.Equal(t, approvalUUID, transaction.ApprovalUUID)
+				require.Equal(t, models.TransactionStatusPending, transaction.Status)
+				return nil
+			})
+
+		producer := mocks.NewMockPaymentProducerService(ctrl)
+		producer.EXPECT().SendApprovalRequested(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, event *events.ApprovalRequestedEvent) error {
+				require.Equal(t, approvalUUID, event.ApprovalUUID)
+				require.Equal(t, orderUUID, event.OrderUUID)
+				return nil
+			})
+
+		// Провайдер не вызывается до согласования
+		providers := provider.NewRegistry(map[payment_v1.PaymentMethod]provider.PaymentProvider{
+			payment_v1.PaymentMethod_PAYMENT_METHOD_INVESTOR_MONEY: mocks.NewMockPaymentProvider(ctrl),
+		})
+
+		uc := usecase.NewUseCase(repo, nil, nil, approvalRepo, providers, approveAll(ctrl), providerConfig{timeout: time.Second}, nil, cfg, producer, nil)
+
+		transaction, err := uc.PayOrder(ctx, orderUUID, userID, payment_v1.PaymentMethod_PAYMENT_METHOD_INVESTOR_MONEY, 1000, "")
+		require.NoError(t, err)
+		require.Equal(t, models.TransactionStatusPending, transaction.Status)
+		require.Equal(t, approvalUUID, transaction.ApprovalUUID)
+	})
+
+	t.Run("approval request failure fails payment", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		approvalRepo := mocks.NewMockApprovalRepository(ctrl)
+		approvalRepo.EXPECT().CreateApproval(ctx, gomock.Any()).Return(errors.New("database error"))
+
+		repo := mocks.NewMockPaymentRepository(ctrl)
+		repo.EXPECT().ListTransactions(ctx, gomock.Any()).Return(nil, nil)
+		repo.EXPECT().CreateTransaction(ctx, gomock.Any()).Return(nil)
+		repo.EXPECT().UpdateTransaction(ctx, gomock.Any(), gomock.Any()).Return(nil)
+
+		producer := mocks.NewMockPaymentProducerService(ctrl)
+		producer.EXPECT().SendPaymentFailed(ctx, gomock.Any()).Return(nil)
+
+		providers := provider.NewRegistry(map[payment_v1.PaymentMethod]provider.PaymentProvider{
+			payment_v1.PaymentMethod_PAYMENT_METHOD_INVESTOR_MONEY: mocks.NewMockPaymentProvider(ctrl),
+		})
+
+		uc := usecase.NewUseCase(repo, nil, nil, approvalRepo, providers, approveAll(ctrl), providerConfig{timeout: time.Second}, nil, cfg, producer, nil)
+
+		transaction, err := uc.PayOrder(ctx, orderUUID, userID, payment_v1.PaymentMethod_PAYMENT_METHOD_INVESTOR_MONEY, 1000, "")
+		require.NoError(t, err)
+		require.Equal(t, models.TransactionStatusFailed, transaction.Status)
+	})
+}
+
+func TestDecideApproval(t *testing.T) {
+	ctx := context.Background()
+	if err := logger.Init(ctx, "info", false, false, "", "payment-test"); err != nil {
+		t.Fatalf("failed to init logger: %v", err)
+	}
+
+	approvalUUID := uuid.New().String()
+	transaction := models.Transaction{
+		UUID:          uuid.New().String(),
+		OrderUUID:     uuid.New().String(),
+		UserID:        uuid.New().String(),
+		PaymentMethod: payment_v1.PaymentMethod_PAYMENT_METHOD_INVESTOR_MONEY,
+		Amount:        1000,
+		Status:        models.TransactionStatusPending,
+		ApprovalUUID:  approvalUUID,
+	}
+
+	pending := models.Approval{
+		UUID:            approvalUUID,
+		TransactionUUID: transaction.UUID,
+		OrderUUID:       transaction.OrderUUID,
+		Amount:          transaction.Amount,
+		Approvers:       []string{"alice", "bob", "carol"},
+		Quorum:          2,
+		Status:          models.ApprovalStatusPending,
+		ExpiresAt:       time.Now().Add(time.Hour),
+	}
+
+	withDecisions := func(decisions ...models.ApprovalDecision) models.Approval {
+		approval := pending
+		approval.Decisions = decisions
+		return approval
+	}
+
+	newUseCase := func(ctrl *gomock.Controller, repo *mocks.MockPaymentRepository, ledger *mocks.MockLedgerRepository,
+		approvalRepo *mocks.MockApprovalRepository, paymentProvider *mocks.MockPaymentProvider, producer *mocks.MockPaymentProducerService,
+	) usecase.PaymentUseCase {
+		providers := provider.NewRegistry(map[payment_v1.PaymentMethod]provider.PaymentProvider{
+			payment_v1.PaymentMethod_PAYMENT_METHOD_INVESTOR_MONEY: paymentProvider,
+		})
+		return usecase.NewUseCase(repo, ledger, nil, approvalRepo, providers, approveAll(ctrl), providerConfig{timeout: time.Second}, nil, nil, producer, nil)
+	}
+
+	t.Run("decision below quorum keeps approval pending", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		approvalRepo := mocks.NewMockApprovalRepository(ctrl)
+		gomock.InOrder(
+			approvalRepo.EXPECT().GetApproval(ctx, approvalUUID).Return(pending, nil),
+			approvalRepo.EXPECT().AddDecision(ctx, approvalUUID, gomock.Any()).
+				DoAndReturn(func(_ context.Context, _ string, decision models.ApprovalDecision) error {
+					require.Equal(t, "alice", decision.ApproverID)
+					require.True(t, decision.Approved)
+					return nil
+				}),
+			approvalRepo.EXPECT().GetApproval(ctx, approvalUUID).Return(withDecisions(models.ApprovalDecision{ApproverID: "alice", Approved: true}), nil),
+		)
+
+		uc := newUseCase(ctrl, mocks.NewMockPaymentRepository(ctrl), nil, approvalRepo, mocks.NewMockPaymentProvider(ctrl), mocks.NewMockPaymentProducerService(ctrl))
+
+		approval, err := uc.DecideApproval(ctx, approvalUUID, "alice", true, "")
+		require.NoError(t, err)
+		require.Equal(t, models.ApprovalStatusPending, approval.Status)
+	})
+
+	t.Run("quorum charges transaction", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		done := make(chan struct{})
+
+		approvalRepo := mocks.NewMockApprovalRepository(ctrl)
+		approvalRepo.EXPECT().GetApproval(ctx, approvalUUID).Return(withDecisions(models.ApprovalDecision{ApproverID: "alice", Approved: true}), nil)
+		approvalRepo.EXPECT().AddDecision(ctx, approvalUUID, gomock.Any()).Return(nil)
+		approvalRepo.EXPECT().GetApproval(ctx, approvalUUID).Return(withDecisions(
+			models.ApprovalDecision{ApproverID: "alice", Approved: true},
+			models.ApprovalDecision{ApproverID: "bob", Approved: true},
+		), nil)
+		approvalRepo.EXPECT().ResolveApproval(ctx, approvalUUID, models.ApprovalStatusApproved, gomock.Any()).Return(nil)
+
+		repo := mocks.NewMockPaymentRepository(ctrl)
+		repo.EXPECT().GetTransaction(ctx, transaction.UUID).Return(transaction, nil)
+		repo.EXPECT().UpdateTransaction(gomock.Any(), transaction.UUID, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, updated models.Transaction) error {
+				require.Equal(t, models.TransactionStatusCompleted, updated.Status)
+				return nil
+			})
+
+		ledger := mocks.NewMockLedgerRepository(ctrl)
+		ledger.EXPECT().PostEntry(gomock.Any(), gomock.Any()).Return(nil)
+
+		paymentProvider := mocks.NewMockPaymentProvider(ctrl)
+		paymentProvider.EXPECT().Name().Return("investor").AnyTimes()
+		paymentProvider.EXPECT().Charge(gomock.Any(), gomock.Any()).Return(models.ChargeResult{Status: models.TransactionStatusCompleted}, nil)
+
+		producer := mocks.NewMockPaymentProducerService(ctrl)
+		producer.EXPECT().SendPaymentCompleted(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, event *events.PaymentCompletedEvent) error {
+				defer close(done)
+				require.Equal(t, transaction.UUID, event.TransactionUUID)
+				return nil
+			})
+
+		uc := newUseCase(ctrl, repo, ledger, approvalRepo, paymentProvider, producer)
+
+		approval, err := uc.DecideApproval(ctx, approvalUUID, "bob", true, "")
+		require.NoError(t, err)
+		require.Equal(t, models.ApprovalStatusApproved, approval.Status)
+
+		select {
+		case <-done:
+		case <-time.After(asyncTimeout):
+			t.Fatal("payment result event was not published")
+		}
+	})
+
+	t.Run("unreachable quorum rejects transaction", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		rejected := withDecisions(
+			models.ApprovalDecision{ApproverID: "alice", Approved: false, Reason: "limit exceeded"},
+			models.ApprovalDecision{ApproverID: "bob", Approved: false},
+		)
+
+		approvalRepo := mocks.NewMockApprovalRepository(ctrl)
+		approvalRepo.EXPECT().GetApproval(ctx, approvalUUID).Return(withDecisions(rejected.Decisions[0]), nil)
+		approvalRepo.EXPECT().AddDecision(ctx, approvalUUID, gomock.Any()).Return(nil)
+		approvalRepo.EXPECT().GetApproval(ctx, approvalUUID).Return(rejected, nil)
+		approvalRepo.EXPECT().ResolveApproval(ctx, approvalUUID, models.ApprovalStatusRejected, gomock.Any()).Return(nil)
+
+		repo := mocks.NewMockPaymentRepository(ctrl)
+		repo.EXPECT().GetTransaction(ctx, transaction.UUID).Return(transaction, nil)
+		repo.EXPECT().UpdateTransaction(ctx, transaction.UUID, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, updated models.Transaction) error {
+				require.Equal(t, models.TransactionStatusFailed, updated.Status)
+				require.Equal(t, "limit exceeded", updated.FailureReason)
+				return nil
+			})
+
+		producer := mocks.NewMockPaymentProducerService(ctrl)
+		producer.EXPECT().SendPaymentFailed(ctx, gomock.Any()).Return(nil)
+
+		uc := newUseCase(ctrl, repo, nil, approvalRepo, mocks.NewMockPaymentProvider(ctrl), producer)
+
+		approval, err := uc.DecideApproval(ctx, approvalUUID, "bob", false, "")
+		require.NoError(t, err)
+		require.Equal(t, models.ApprovalStatusRejected, approval.Status)
+	})
+
+	t.Run("approval resolved concurrently is not applied twice", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		approved := withDecisions(
+			models.ApprovalDecision{ApproverID: "alice", Approved: true},
+			models.ApprovalDecision{ApproverID: "bob", Approved: true},
+			models.ApprovalDecision{ApproverID: "carol", Approved: true},
+		)
+		approved.Status = models.ApprovalStatusApproved
+
+		approvalRepo := mocks.NewMockApprovalRepository(ctrl)
+		gomock.InOrder(
+			approvalRepo.EXPECT().GetApproval(ctx, approvalUUID).Return(pending, nil),
+			approvalRepo.EXPECT().AddDecision(ctx, approvalUUID, gomock.Any()).Return(nil),
+			approvalRepo.EXPECT().GetApproval(ctx, approvalUUID).Return(approved, nil),
+			approvalRepo.EXPECT().ResolveApproval(ctx, approvalUUID, models.ApprovalStatusApproved, gomock.Any()).Return(apperrors.ErrApprovalNotPending),
+			approvalRepo.EXPECT().GetApproval(ctx, approvalUUID).Return(approved, nil),
+		)
+
+		uc := newUseCase(ctrl, mocks.NewMockPaymentRepository(ctrl), nil, approvalRepo, mocks.NewMockPaymentProvider(ctrl), mocks.NewMockPaymentProducerService(ctrl))
+
+		approval, err := uc.DecideApproval(ctx, approvalUUID, "carol", true, "")
+		require.NoError(t, err)
+		require.Equal(t, models.ApprovalStatusApproved, approval.Status)
+	})
+
+	t.Run("unknown approver is rejected", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		approvalRepo := mocks.NewMockApprovalRepository(ctrl)
+		approvalRepo.EXPECT().GetApproval(ctx, approvalUUID).Return(pending, nil)
+
+		uc := newUseCase(ctrl, mocks.NewMockPaymentRepository(ctrl), nil, approvalRepo, mocks.NewMockPaymentProvider(ctrl), mocks.NewMockPaymentProducerService(ctrl))
+
+		_, err := uc.DecideApproval(ctx, approvalUUID, "mallory", true, "")
+		require.ErrorIs(t, err, apperrors.ErrNotApprover)
+	})
+
+	t.Run("repeated decision is rejected", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		approvalRepo := mocks.NewMockApprovalRepository(ctrl)
+		approvalRepo.EXPECT().GetApproval(ctx, approvalUUID).Return(pending, nil)
+		approvalRepo.EXPECT().AddDecision(ctx, approvalUUID, gomock.Any()).Return(apperrors.ErrAlreadyDecided)
+
+		uc := newUseCase(ctrl, mocks.NewMockPaymentRepository(ctrl), nil, approvalRepo, mocks.NewMockPaymentProvider(ctrl), mocks.NewMockPaymentProducerService(ctrl))
+
+		_, err := uc.DecideApproval(ctx, approvalUUID, "alice", false, "")
+		require.ErrorIs(t, err, apperrors.ErrAlreadyDecided)
+	})
+}
+
+func TestExpireApprovals(t *testing.T) {
+	ctx := context.Background()
+	if err := logger.Init(ctx, "info", false, false, "", "payment-test"); err != nil {
+		t.Fatalf("failed to init logger: %v", err)
+	}
+
+	transaction := models.Transaction{
+		UUID:          uuid.New().String(),
+		OrderUUID:     uuid.New().String(),
+		PaymentMethod: payment_v1.PaymentMethod_PAYMENT_METHOD_INVESTOR_MONEY,
+		Amount:        1000,
+		Status:        models.TransactionStatusPending,
+	}
+	expired := models.Approval{
+		UUID:            uuid.New().String(),
+		TransactionUUID: transaction.UUID,
+		Status:          models.ApprovalStatusPending,
+		ExpiresAt:       time.Now().Add(-time.Minute),
+	}
+
+	t.Run("expired approval cancels transaction", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		approvalRepo := mocks.NewMockApprovalRepository(ctrl)
+		approvalRepo.EXPECT().ListExpired(ctx, gomock.Any(), gomock.Any()).Return([]models.Approval{expired}, nil)
+		approvalRepo.EXPECT().ResolveApproval(ctx, expired.UUID, models.ApprovalStatusExpired, gomock.Any()).Return(nil)
+
+		repo := mocks.NewMockPaymentRepository(ctrl)
+		repo.EXPECT().GetTransaction(ctx, transaction.UUID).Return(transaction, nil)
+		repo.EXPECT().UpdateTransaction(ctx, transaction.UUID, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, updated models.Transaction) error {
+				require.Equal(t, models.TransactionStatusCancelled, updated.Status)
+				return nil
+			})
+
+		producer := mocks.NewMockPaymentProducerService(ctrl)
+		producer.EXPECT().SendPaymentFailed(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, event *events.PaymentFailedEvent) error {
+				require.Equal(t, string(models.TransactionStatusCancelled), event.Status)
+				return nil
+			})
+
+		uc := usecase.NewUseCase(repo, nil, nil, approvalRepo, nil, nil, nil, nil, nil, producer, nil)
+
+		count, err := uc.ExpireApprovals(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+	})
+
+	t.Run("list error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		approvalRepo := mocks.NewMockApprovalRepository(ctrl)
+		approvalRepo.EXPECT().ListExpired(ctx, gomock.Any(), gomock.Any()).Return(nil, errors.New("database error"))
+
+		uc := usecase.NewUseCase(nil, nil, nil, approvalRepo, nil, nil, nil, nil, nil, nil, nil)
+
+		_, err := uc.ExpireApprovals(ctx)
+		require.Error(t, err)
+	})
+}
+
+func TestConfirmTransactionAwaitingApproval(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name           string
+		approvalStatus models.ApprovalStatus
+		wantErr        error
+	}{
+		{name: "approval is pending", approvalStatus: models.ApprovalStatusPending, wantErr: apperrors.ErrTransactionInApproval},
+		{name: "approval is rejected", approvalStatus: models.ApprovalStatusRejected, wantErr: apperrors.ErrTransactionNotApproved},
+		{name: "approval is expired", approvalStatus: models.ApprovalStatusExpired, wantErr: apperrors.ErrTransactionNotApproved},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			transaction := models.Transaction{
+				UUID:         uuid.New().String(),
+				Status:       models.TransactionStatusPending,
+				ApprovalUUID: uuid.New().String(),
+			}
+
+			repo := mocks.NewMockPaymentRepository(ctrl)
+			repo.EXPECT().GetTransaction(ctx, transaction.UUID).Return(transaction, nil)
+
+			approvalRepo := mocks.NewMockApprovalRepository(ctrl)
+			approvalRepo.EXPECT().GetApproval(ctx, transaction.ApprovalUUID).Return(models.Approval{Status: tt.approvalStatus}, nil)
+
+			uc := usecase.NewUseCase(repo, nil, nil, approvalRepo, nil, nil, nil, nil, nil, nil, nil)
+
+			_, err := uc.ConfirmTransaction(ctx, transaction.UUID, transaction.UserID, true, "")
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
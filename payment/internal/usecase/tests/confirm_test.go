@@ -0,0 +1,366 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/linemk/rocket-shop/payment/internal/entyties/apperrors"
+	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
+	"github.com/linemk/rocket-shop/payment/internal/mocks"
+	"github.com/linemk/rocket-shop/payment/internal/provider"
+	"github.com/linemk/rocket-shop/payment/internal/usecase"
+	payment_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/payment/v1"
+)
+
+var errLedgerUnavailable = errors.New("ledger unavailable")
+
+func TestConfirmTransaction(t *testing.T) {
+	ctx := context.Background()
+
+	transactionUUID := uuid.New().String()
+	pending := models.Transaction{
+		UUID:          transactionUUID,
+		OrderUUID:     uuid.New().String(),
+		UserID:        uuid.New().String(),
+		PaymentMethod: payment_v1.PaymentMethod_PAYMENT_METHOD_CARD,
+		Amount:        1500,
+		Status:        models.TransactionStatusPending,
+	}
+
+	noLedgerCalls := func(ctrl *gomock.Controller) *mocks.MockLedgerRepository {
+		return mocks.NewMockLedgerRepository(ctrl)
+	}
+
+	tests := []struct {
+		name         string
+		approved     bool
+		repoMock     func(ctrl *gomock.Controller) *mocks.MockPaymentRepository
+		ledgerMock   func(ctrl *gomock.Controller) *mocks.MockLedgerRepository
+		producerMock func(ctrl *gomock.Controller) *mocks.MockPaymentProducerService
+		// chargeStatus и chargeErr ответ провайдера на запрос статуса; пустые, если провайдер не вызывается
+		chargeStatus models.TransactionStatus
+		chargeErr    error
+		wantErr      error
+		wantStatus   models.TransactionStatus
+	}{
+		{
+			name:         "approve pending transaction",
+			chargeStatus: models.TransactionStatusCompleted,
+			approved:     true,
+			repoMock: func(ctrl *gomock.Controller) *mocks.MockPaymentRepository {
+				mockRepo := mocks.NewMockPaymentRepository(ctrl)
+				mockRepo.EXPECT().GetTransaction(ctx, transactionUUID).Return(pending, nil)
+				mockRepo.EXPECT().UpdateTransaction(ctx, transactionUUID, gomock.Any()).Return(nil)
+				return mockRepo
+			},
+			ledgerMock: func(ctrl *gomock.Controller) *mocks.MockLedgerRepository {
+				mockLedger := mocks.NewMockLedgerRepository(ctrl)
+				mockLedger.EXPECT().PostEntry(ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, entry models.JournalEntry) error {
+						require.Equal(t, models.EntryTypeCharge, entry.Type)
+						require.Equal(t, transactionUUID, entry.TransactionUUID)
+						return nil
+					})
+				return mockLedger
+			},
+			producerMock: func(ctrl *gomock.Controller) *mocks.MockPaymentProducerService {
+				producer := mocks.NewMockPaymentProducerService(ctrl)
+				producer.EXPECT().SendPaymentCompleted(ctx, gomock.Any()).Return(nil)
+				return producer
+			},
+			wantStatus: models.TransactionStatusCompleted,
+		},
+		{
+			name:         "approve transaction already recorded in ledger",
+			chargeStatus: models.TransactionStatusCompleted,
+			approved:     true,
+			repoMock: func(ctrl *gomock.Controller) *mocks.MockPaymentRepository {
+				mockRepo := mocks.NewMockPaymentRepository(ctrl)
+				mockRepo.EXPECT().GetTransaction(ctx, transactionUUID).Return(pending, nil)
+				mockRepo.EXPECT().UpdateTransaction(ctx, transactionUUID, gomock.Any()).Return(nil)
+				return mockRepo
+			},
+			ledgerMock: func(ctrl *gomock.Controller) *mocks.MockLedgerRepository {
+				mockLedger := mocks.NewMockLedgerRepository(ctrl)
+				mockLedger.EXPECT().PostEntry(ctx, gomock.Any()).Return(apperrors.ErrEntryAlreadyExists)
+				return mockLedger
+			},
+			producerMock: func(ctrl *gomock.Controller) *mocks.MockPaymentProducerService {
+				producer := mocks.NewMockPaymentProducerService(ctrl)
+				producer.EXPECT().SendPaymentCompleted(ctx, gomock.Any()).Return(nil)
+				return producer
+			},
+			wantStatus: models.TransactionStatusCompleted,
+		},
+		{
+			name:         "approve with ledger error keeps transaction pending",
+			chargeStatus: models.TransactionStatusCompleted,
+			approved:     true,
+			repoMock: func(ctrl *gomock.Controller) *mocks.MockPaymentRepository {
+				mockRepo := mocks.NewMockPaymentRepository(ctrl)
+				mockRepo.EXPECT().GetTransaction(ctx, transactionUUID).Return(pending, nil)
+				return mockRepo
+			},
+			ledgerMock: func(ctrl *gomock.Controller) *mocks.MockLedgerRepository {
+				mockLedger := mocks.NewMockLedgerRepository(ctrl)
+				mockLedger.EXPECT().PostEntry(ctx, gomock.Any()).Return(errLedgerUnavailable)
+				return mockLedger
+			},
+			producerMock: func(ctrl *gomock.Controller) *mocks.MockPaymentProducerService {
+				return mocks.NewMockPaymentProducerService(ctrl)
+			},
+			wantErr: errLedgerUnavailable,
+		},
+		{
+			name:         "reject pending transaction",
+			chargeStatus: models.TransactionStatusPending,
+			approved:     false,
+			repoMock: func(ctrl *gomock.Controller) *mocks.MockPaymentRepository {
+				mockRepo := mocks.NewMockPaymentRepository(ctrl)
+				mockRepo.EXPECT().GetTransaction(ctx, transactionUUID).Return(pending, nil)
+				mockRepo.EXPECT().UpdateTransaction(ctx, transactionUUID, gomock.Any()).Return(nil)
+				return mockRepo
+			},
+			ledgerMock: noLedgerCalls,
+			producerMock: func(ctrl *gomock.Controller) *mocks.MockPaymentProducerService {
+				producer := mocks.NewMockPaymentProducerService(ctrl)
+				producer.EXPECT().SendPaymentFailed(ctx, gomock.Any()).Return(nil)
+				return producer
+			},
+			wantStatus: models.TransactionStatusFailed,
+		},
+		{
+			name:         "approve charge not completed at provider",
+			approved:     true,
+			chargeStatus: models.TransactionStatusPending,
+			repoMock: func(ctrl *gomock.Controller) *mocks.MockPaymentRepository {
+				mockRepo := mocks.NewMockPaymentRepository(ctrl)
+				mockRepo.EXPECT().GetTransaction(ctx, transactionUUID).Return(pending, nil)
+				return mockRepo
+			},
+			ledgerMock: noLedgerCalls,
+			producerMock: func(ctrl *gomock.Controller) *mocks.MockPaymentProducerService {
+				return mocks.NewMockPaymentProducerService(ctrl)
+			},
+			wantErr: apperrors.ErrChargeNotCompleted,
+		},
+		{
+			name:      "approve charge that did not reach provider",
+			approved:  true,
+			chargeErr: apperrors.ErrChargeNotFound,
+			repoMock: func(ctrl *gomock.Controller) *mocks.MockPaymentRepository {
+				mockRepo := mocks.NewMockPaymentRepository(ctrl)
+				mockRepo.EXPECT().GetTransaction(ctx, transactionUUID).Return(pending, nil)
+				return mockRepo
+			},
+			ledgerMock: noLedgerCalls,
+			producerMock: func(ctrl *gomock.Controller) *mocks.MockPaymentProducerService {
+				return mocks.NewMockPaymentProducerService(ctrl)
+			},
+			wantErr: apperrors.ErrChargeNotCompleted,
+		},
+		{
+			name:         "approve charge declined by provider",
+			approved:     true,
+			chargeStatus: models.TransactionStatusFailed,
+			repoMock: func(ctrl *gomock.Controller) *mocks.MockPaymentRepository {
+				mockRepo := mocks.NewMockPaymentRepository(ctrl)
+				mockRepo.EXPECT().GetTransaction(ctx, transactionUUID).Return(pending, nil)
+				mockRepo.EXPECT().UpdateTransaction(ctx, transactionUUID, gomock.Any()).Return(nil)
+				return mockRepo
+			},
+			ledgerMock: noLedgerCalls,
+			producerMock: func(ctrl *gomock.Controller) *mocks.MockPaymentProducerService {
+				producer := mocks.NewMockPaymentProducerService(ctrl)
+				producer.EXPECT().SendPaymentFailed(ctx, gomock.Any()).Return(nil)
+				return producer
+			},
+			wantStatus: models.TransactionStatusFailed,
+		},
+		{
+			name:         "reject charge already completed by provider",
+			approved:     false,
+			chargeStatus: models.TransactionStatusCompleted,
+			repoMock: func(ctrl *gomock.Controller) *mocks.MockPaymentRepository {
+				mockRepo := mocks.NewMockPaymentRepository(ctrl)
+				mockRepo.EXPECT().GetTransaction(ctx, transactionUUID).Return(pending, nil)
+				mockRepo.EXPECT().UpdateTransaction(ctx, transactionUUID, gomock.Any()).Return(nil)
+				return mockRepo
+			},
+			ledgerMock: func(ctrl *gomock.Controller) *mocks.MockLedgerRepository {
+				mockLedger := mocks.NewMockLedgerRepository(ctrl)
+				mockLedger.EXPECT().PostEntry(ctx, gomock.Any()).Return(nil)
+				return mockLedger
+			},
+			producerMock: func(ctrl *gomock.Controller) *mocks.MockPaymentProducerService {
+				producer := mocks.NewMockPaymentProducerService(ctrl)
+				producer.EXPECT().SendPaymentCompleted(ctx, gomock.Any()).Return(nil)
+				return producer
+			},
+			wantStatus: models.TransactionStatusCompleted,
+		},
+		{
+			name:     "confirm already completed transaction",
+			approved: true,
+			repoMock: func(ctrl *gomock.Controller) *mocks.MockPaymentRepository {
+				completed := pending
+				completed.Status = models.TransactionStatusCompleted
+
+				mockRepo := mocks.NewMockPaymentRepository(ctrl)
+				mockRepo.EXPECT().GetTransaction(ctx, transactionUUID).Return(completed, nil)
+				return mockRepo
+			},
+			ledgerMock: noLedgerCalls,
+			producerMock: func(ctrl *gomock.Controller) *mocks.MockPaymentProducerService {
+				return mocks.NewMockPaymentProducerService(ctrl)
+			},
+			wantErr: apperrors.ErrTransactionNotPending,
+		},
+		{
+			name:     "confirm transaction awaiting fraud review",
+			approved: true,
+			repoMock: func(ctrl *gomock.Controller) *mocks.MockPaymentRepository {
+				inReview := pending
+				inReview.FraudDecision = models.FraudDecisionReview
+
+				mockRepo := mocks.NewMockPaymentRepository(ctrl)
+				mockRepo.EXPECT().GetTransaction(ctx, transactionUUID).Return(inReview, nil)
+				return mockRepo
+			},
+			ledgerMock: noLedgerCalls,
+			producerMock: func(ctrl *gomock.Controller) *mocks.MockPaymentProducerService {
+				return mocks.NewMockPaymentProducerService(ctrl)
+			},
+			wantErr: apperrors.ErrTransactionInReview,
+		},
+		{
+			name:     "confirm unknown transaction",
+			approved: true,
+			repoMock: func(ctrl *gomock.Controller) *mocks.MockPaymentRepository {
+				mockRepo := mocks.NewMockPaymentRepository(ctrl)
+				mockRepo.EXPECT().GetTransaction(ctx, transactionUUID).Return(models.Transaction{}, apperrors.ErrTransactionNotFound)
+				return mockRepo
+			},
+			ledgerMock: noLedgerCalls,
+			producerMock: func(ctrl *gomock.Controller) *mocks.MockPaymentProducerService {
+				return mocks.NewMockPaymentProducerService(ctrl)
+			},
+			wantErr: apperrors.ErrTransactionNotFound,
+		},
+		{
+			name:     "confirm transaction of another user",
+			approved: true,
+			repoMock: func(ctrl *gomock.Controller) *mocks.MockPaymentRepository {
+				foreign := pending
+				foreign.UserID = uuid.New().String()
+
+				mockRepo := mocks.NewMockPaymentRepository(ctrl)
+				mockRepo.EXPECT().GetTransaction(ctx, transactionUUID).Return(foreign, nil)
+				return mockRepo
+			},
+			ledgerMock: noLedgerCalls,
+			producerMock: func(ctrl *gomock.Controller) *mocks.MockPaymentProducerService {
+				return mocks.NewMockPaymentProducerService(ctrl)
+			},
+			wantErr: apperrors.ErrTransactionNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			paymentProvider := mocks.NewMockPaymentProvider(ctrl)
+			paymentProvider.EXPECT().Name().Return("test-provider").AnyTimes()
+			if tt.chargeStatus != "" || tt.chargeErr != nil {
+				paymentProvider.EXPECT().Status(gomock.Any(), transactionUUID).
+					Return(models.ChargeResult{Status: tt.chargeStatus}, tt.chargeErr)
+			}
+			providers := provider.NewRegistry(map[payment_v1.PaymentMethod]provider.PaymentProvider{
+				payment_v1.PaymentMethod_PAYMENT_METHOD_CARD: paymentProvider,
+			})
+
+			uc := usecase.NewUseCase(
+				tt.repoMock(ctrl),
+				tt.ledgerMock(ctrl),
+				nil,
+				nil,
+				providers,
+				nil,
+				providerConfig{timeout: time.Second},
+				nil,
+				nil,
+				tt.producerMock(ctrl),
+				nil,
+			)
+
+			transaction, err := uc.ConfirmTransaction(ctx, transactionUUID, pending.UserID, tt.approved, "")
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.wantStatus, transaction.Status)
+		})
+	}
+}
+
+func TestConfirmTransaction_Concurrent(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+
+	transactionUUID := uuid.New().String()
+	pending := models.Transaction{
+		UUID:          transactionUUID,
+		OrderUUID:     uuid.New().String(),
+		UserID:        uuid.New().String(),
+		PaymentMethod: payment_v1.PaymentMethod_PAYMENT_METHOD_CARD,
+		Amount:        1500,
+		Status:        models.TransactionStatusPending,
+	}
+
+	loading := make(chan struct{})
+	release := make(chan struct{})
+
+	// Решение сохраняется только один раз: второе подтверждение приходит, пока первое читает транзакцию
+	repo := mocks.NewMockPaymentRepository(ctrl)
+	repo.EXPECT().GetTransaction(ctx, transactionUUID).
+		DoAndReturn(func(context.Context, string) (models.Transaction, error) {
+			close(loading)
+			<-release
+			return pending, nil
+		})
+	repo.EXPECT().UpdateTransaction(ctx, transactionUUID, gomock.Any()).Return(nil)
+
+	producer := mocks.NewMockPaymentProducerService(ctrl)
+	producer.EXPECT().SendPaymentFailed(ctx, gomock.Any()).Return(nil)
+
+	paymentProvider := mocks.NewMockPaymentProvider(ctrl)
+	paymentProvider.EXPECT().Status(gomock.Any(), transactionUUID).Return(models.ChargeResult{Status: models.TransactionStatusPending}, nil)
+	providers := provider.NewRegistry(map[payment_v1.PaymentMethod]provider.PaymentProvider{
+		payment_v1.PaymentMethod_PAYMENT_METHOD_CARD: paymentProvider,
+	})
+
+	uc := usecase.NewUseCase(repo, mocks.NewMockLedgerRepository(ctrl), nil, nil, providers, nil, providerConfig{timeout: time.Second}, nil, nil, producer, nil)
+
+	done := make(chan error)
+	go func() {
+		_, err := uc.ConfirmTransaction(ctx, transactionUUID, pending.UserID, false, "")
+		done <- err
+	}()
+	<-loading
+
+	_, err := uc.ConfirmTransaction(ctx, transactionUUID, pending.UserID, true, "")
+	require.ErrorIs(t, err, apperrors.ErrTransactionNotPending)
+
+	close(release)
+	require.NoError(t, <-done)
+}
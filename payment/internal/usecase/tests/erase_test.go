@@ -0,0 +1,156 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
+	"github.com/linemk/rocket-shop/payment/internal/mocks"
+	"github.com/linemk/rocket-shop/payment/internal/usecase"
+)
+
+func TestEraseUser(t *testing.T) {
+	ctx := context.Background()
+
+	type fields struct {
+		repoMock            func() *mocks.MockPaymentRepository
+		installmentRepoMock func() *mocks.MockInstallmentRepository
+		approvalRepoMock    func() *mocks.MockApprovalRepository
+		screenerMock        func() *mocks.MockScreener
+	}
+
+	userID := uuid.New().String()
+
+	forgetUser := func() *mocks.MockScreener {
+		screener := mocks.NewMockScreener(gomock.NewController(t))
+		screener.EXPECT().Forget(ctx, userID).Return(nil)
+		return screener
+	}
+
+	anonymizePlans := func() *mocks.MockInstallmentRepository {
+		installmentRepo := mocks.NewMockInstallmentRepository(gomock.NewController(t))
+		installmentRepo.EXPECT().AnonymizeUser(ctx, userID, models.ErasedUserID).Return(1, nil)
+		return installmentRepo
+	}
+
+	anonymizeApprovals := func() *mocks.MockApprovalRepository {
+		approvalRepo := mocks.NewMockApprovalRepository(gomock.NewController(t))
+		approvalRepo.EXPECT().AnonymizeUser(ctx, userID, models.ErasedUserID).Return(1, nil)
+		return approvalRepo
+	}
+
+	noApprovals := func() *mocks.MockApprovalRepository {
+		return mocks.NewMockApprovalRepository(gomock.NewController(t))
+	}
+
+	noPlans := func() *mocks.MockInstallmentRepository {
+		return mocks.NewMockInstallmentRepository(gomock.NewController(t))
+	}
+
+	tests := []struct {
+		name         string
+		fields       fields
+		wantAffected int
+		wantErr      bool
+	}{
+		{
+			name: "successfully erase user",
+			fields: fields{
+				repoMock: func() *mocks.MockPaymentRepository {
+					mockRepo := mocks.NewMockPaymentRepository(gomock.NewController(t))
+					mockRepo.EXPECT().AnonymizeUser(ctx, userID, models.ErasedUserID).Return(3, nil)
+					return mockRepo
+				},
+				installmentRepoMock: anonymizePlans,
+				approvalRepoMock:    anonymizeApprovals,
+				screenerMock:        forgetUser,
+			},
+			wantAffected: 3,
+			wantErr:      false,
+		},
+		{
+			name: "repository error",
+			fields: fields{
+				repoMock: func() *mocks.MockPaymentRepository {
+					mockRepo := mocks.NewMockPaymentRepository(gomock.NewController(t))
+					mockRepo.EXPECT().AnonymizeUser(ctx, userID, models.ErasedUserID).Return(0, errors.New("storage error"))
+					return mockRepo
+				},
+				installmentRepoMock: anonymizePlans,
+				approvalRepoMock:    anonymizeApprovals,
+				screenerMock:        forgetUser,
+			},
+			wantErr: true,
+		},
+		{
+			name: "installment plans error",
+			fields: fields{
+				repoMock: func() *mocks.MockPaymentRepository {
+					return mocks.NewMockPaymentRepository(gomock.NewController(t))
+				},
+				installmentRepoMock: func() *mocks.MockInstallmentRepository {
+					installmentRepo := mocks.NewMockInstallmentRepository(gomock.NewController(t))
+					installmentRepo.EXPECT().AnonymizeUser(ctx, userID, models.ErasedUserID).Return(0, errors.New("database error"))
+					return installmentRepo
+				},
+				approvalRepoMock: noApprovals,
+				screenerMock:     forgetUser,
+			},
+			wantErr: true,
+		},
+		{
+			name: "approvals error",
+			fields: fields{
+				repoMock: func() *mocks.MockPaymentRepository {
+					return mocks.NewMockPaymentRepository(gomock.NewController(t))
+				},
+				installmentRepoMock: anonymizePlans,
+				approvalRepoMock: func() *mocks.MockApprovalRepository {
+					approvalRepo := mocks.NewMockApprovalRepository(gomock.NewController(t))
+					approvalRepo.EXPECT().AnonymizeUser(ctx, userID, models.ErasedUserID).Return(0, errors.New("database error"))
+					return approvalRepo
+				},
+				screenerMock: forgetUser,
+			},
+			wantErr: true,
+		},
+		{
+			name: "payment history error",
+			fields: fields{
+				repoMock: func() *mocks.MockPaymentRepository {
+					return mocks.NewMockPaymentRepository(gomock.NewController(t))
+				},
+				installmentRepoMock: noPlans,
+				approvalRepoMock:    noApprovals,
+				screenerMock: func() *mocks.MockScreener {
+					screener := mocks.NewMockScreener(gomock.NewController(t))
+					screener.EXPECT().Forget(ctx, userID).Return(errors.New("redis unavailable"))
+					return screener
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paymentRepo := tt.fields.repoMock()
+			uc := usecase.NewUseCase(paymentRepo, nil, tt.fields.installmentRepoMock(), tt.fields.approvalRepoMock(), nil, tt.fields.screenerMock(), nil, nil, nil, nil, nil)
+
+			affected, err := uc.EraseUser(ctx, userID)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.wantAffected, affected)
+		})
+	}
+}
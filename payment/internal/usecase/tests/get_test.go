@@ -70,7 +70,7 @@ func TestGetTransaction(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			paymentRepo := tt.fields.repoMock()
-			uc := usecase.NewUseCase(paymentRepo)
+			uc := usecase.NewUseCase(paymentRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 			result, err := uc.GetTransaction(ctx, tt.uuid)
 
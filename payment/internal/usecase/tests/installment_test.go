@@ -0,0 +1,557 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/linemk/rocket-shop/payment/internal/entyties/apperrors"
+	"github.com/linemk/rocket-shop/payment/internal/entyties/events"
+	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
+	"github.com/linemk/rocket-shop/payment/internal/mocks"
+	"github.com/linemk/rocket-shop/payment/internal/provider"
+	"github.com/linemk/rocket-shop/payment/internal/usecase"
+	"github.com/linemk/rocket-shop/platform/pkg/logger"
+	payment_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/payment/v1"
+)
+
+type installmentConfig struct {
+	terms        map[int]float64
+	period       time.Duration
+	retry        time.Duration
+	overdueAfter time.Duration
+}
+
+func (c installmentConfig) Terms() map[int]float64 {
+	return c.terms
+}
+
+func (c installmentConfig) Period() time.Duration {
+	return c.period
+}
+
+func (c installmentConfig) RunnerInterval() time.Duration {
+	return 0
+}
+
+func (c installmentConfig) RetryInterval() time.Duration {
+	return c.retry
+}
+
+func (c installmentConfig) OverdueAfter() time.Duration {
+	return c.overdueAfter
+}
+
+var testInstallmentConfig = installmentConfig{
+	terms:        map[int]float64{3: 0, 6: 0.01},
+	period:       30 * 24 * time.Hour,
+	retry:        24 * time.Hour,
+	overdueAfter: 72 * time.Hour,
+}
+
+func creditCardProviders(paymentProvider provider.PaymentProvider) *provider.Registry {
+	return provider.NewRegistry(map[payment_v1.PaymentMethod]provider.PaymentProvider{
+		payment_v1.PaymentMethod_PAYMENT_METHOD_CREDIT_CARD: paymentProvider,
+	})
+}
+
+func TestQuoteInstallments(t *testing.T) {
+	ctx := context.Background()
+	uc := usecase.NewUseCase(nil, nil, nil, nil, nil, nil, nil, testInstallmentConfig, nil, nil, nil)
+
+	quotes, err := uc.QuoteInstallments(ctx, 1000)
+	require.NoError(t, err)
+	require.Len(t, quotes, 2)
+
+	require.Equal(t, 3, quotes[0].Installments)
+	require.Equal(t, []float64{333.33, 333.33, 333.34}, quotes[0].Payments)
+	require.Equal(t, 1000.0, quotes[0].TotalAmount)
+	require.Zero(t, quotes[0].Overpayment)
+
+	require.Equal(t, 6, quotes[1].Installments)
+	require.Len(t, quotes[1].Payments, 6)
+	require.Greater(t, quotes[1].Overpayment, 0.0)
+	require.InDelta(t, 1000+quotes[1].Overpayment, quotes[1].TotalAmount, 0.001)
+
+	_, err = uc.QuoteInstallments(ctx, 0)
+	require.ErrorIs(t, err, apperrors.ErrInvalidAmount)
+}
+
+func TestCreateInstallmentPlan(t *testing.T) {
+	ctx := context.Background()
+	if err := logger.Init(ctx, "info", false, false, "", "payment-test"); err != nil {
+		t.Fatalf("failed to init logger: %v", err)
+	}
+
+	orderUUID := uuid.New().String()
+	userID := uuid.New().String()
+
+	t.Run("first installment is charged", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		done := make(chan struct{})
+
+		var created models.InstallmentPlan
+		installmentRepo := mocks.NewMockInstallmentRepository(ctrl)
+		installmentRepo.EXPECT().CreatePlan(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, plan models.InstallmentPlan) error {
+				created = plan
+				return nil
+			})
+		installmentRepo.EXPECT().GetPlan(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string) (models.InstallmentPlan, error) {
+				return created, nil
+			})
+		// Попытка сохраняется в плане до обращения к провайдеру
+		var attemptUUID string
+		installmentRepo.EXPECT().UpdateInstallment(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, installment models.Installment) error {
+				require.Equal(t, models.InstallmentStatusScheduled, installment.Status)
+				require.NotEmpty(t, installment.TransactionUUID)
+				attemptUUID = installment.TransactionUUID
+				return nil
+			})
+		installmentRepo.EXPECT().UpdateInstallment(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, installment models.Installment) error {
+				require.Equal(t, 1, installment.Number)
+				require.Equal(t, models.InstallmentStatusPaid, installment.Status)
+				require.Equal(t, 1, installment.Attempts)
+				return nil
+			})
+
+		paymentRepo := mocks.NewMockPaymentRepository(ctrl)
+		paymentRepo.EXPECT().CreateTransaction(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, transaction models.Transaction) error {
+				require.Equal(t, attemptUUID, transaction.UUID)
+				require.Equal(t, created.UUID, transaction.InstallmentPlanUUID)
+				require.Equal(t, 333.33, transaction.Amount)
+				return nil
+			})
+		paymentRepo.EXPECT().UpdateTransaction(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+		ledger := mocks.NewMockLedgerRepository(ctrl)
+		ledger.EXPECT().PostEntry(gomock.Any(), gomock.Any()).Return(nil)
+
+		paymentProvider := mocks.NewMockPaymentProvider(ctrl)
+		paymentProvider.EXPECT().Charge(gomock.Any(), gomock.Any()).
+			Return(models.ChargeResult{Status: models.TransactionStatusCompleted}, nil)
+
+		producer := mocks.NewMockPaymentProducerService(ctrl)
+		producer.EXPECT().SendInstallmentPaid(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, event *events.InstallmentPaidEvent) error {
+				defer close(done)
+				require.Equal(t, created.UUID, event.PlanUUID)
+				require.Equal(t, 1, event.InstallmentNumber)
+				require.Equal(t, 3, event.InstallmentsTotal)
+				require.Equal(t, 333.33, event.PaidAmount)
+				require.Equal(t, 666.67, event.RemainingAmount)
+				require.False(t, event.PlanCompleted)
+				return nil
+			})
+
+		uc := usecase.NewUseCase(
+			paymentRepo,
+			ledger,
+			installmentRepo,
+			nil,
+			creditCardProviders(paymentProvider),
+			nil,
+			providerConfig{timeout: time.Second},
+			testInstallmentConfig,
+			nil,
+			producer,
+			nil,
+		)
+
+		plan, err := uc.CreateInstallmentPlan(ctx, orderUUID, userID, 1000, 3)
+		require.NoError(t, err)
+		require.Equal(t, models.InstallmentPlanStatusActive, plan.Status)
+		require.Len(t, plan.Installments, 3)
+		require.Equal(t, testInstallmentConfig.period, plan.Installments[1].DueAt.Sub(plan.Installments[0].DueAt))
+
+		select {
+		case <-done:
+		case <-time.After(asyncTimeout):
+			t.Fatal("first installment was not charged")
+		}
+	})
+
+	t.Run("unavailable number of installments", func(t *testing.T) {
+		uc := usecase.NewUseCase(nil, nil, nil, nil, nil, nil, nil, testInstallmentConfig, nil, nil, nil)
+
+		_, err := uc.CreateInstallmentPlan(ctx, orderUUID, userID, 1000, 4)
+		require.ErrorIs(t, err, apperrors.ErrInvalidInstallments)
+	})
+}
+
+func TestChargeDueInstallments(t *testing.T) {
+	ctx := context.Background()
+	if err := logger.Init(ctx, "info", false, false, "", "payment-test"); err != nil {
+		t.Fatalf("failed to init logger: %v", err)
+	}
+
+	now := time.Now()
+	planWith := func(statuses ...models.InstallmentStatus) models.InstallmentPlan {
+		plan := models.InstallmentPlan{
+			UUID:          uuid.New().String(),
+			OrderUUID:     uuid.New().String(),
+			UserID:        uuid.New().String(),
+			PaymentMethod: models.InstallmentPaymentMethod,
+			Principal:     300,
+			TotalAmount:   300,
+			Status:        models.InstallmentPlanStatusActive,
+		}
+		for i, status := range statuses {
+			plan.Installments = append(plan.Installments, models.Installment{
+				PlanUUID: plan.UUID,
+				Number:   i + 1,
+				Amount:   100,
+				DueAt:    now.Add(time.Duration(i-len(statuses)+1) * testInstallmentConfig.period),
+				Status:   status,
+			})
+		}
+		return plan
+	}
+
+	declined := func(ctrl *gomock.Controller) *mocks.MockPaymentProvider {
+		paymentProvider := mocks.NewMockPaymentProvider(ctrl)
+		paymentProvider.EXPECT().Charge(gomock.Any(), gomock.Any()).
+			Return(models.ChargeResult{Status: models.TransactionStatusFailed, DeclineReason: "insufficient funds"}, nil)
+		return paymentProvider
+	}
+
+	type expectations func(ctrl *gomock.Controller, plan models.InstallmentPlan, installmentRepo *mocks.MockInstallmentRepository) (*mocks.MockPaymentProducerService, *mocks.MockLedgerRepository)
+
+	tests := []struct {
+		name     string
+		plan     models.InstallmentPlan
+		provider func(ctrl *gomock.Controller) *mocks.MockPaymentProvider
+		expect   expectations
+	}{
+		{
+			name: "last installment completes the plan",
+			plan: planWith(models.InstallmentStatusPaid, models.InstallmentStatusPaid, models.InstallmentStatusScheduled),
+			provider: func(ctrl *gomock.Controller) *mocks.MockPaymentProvider {
+				paymentProvider := mocks.NewMockPaymentProvider(ctrl)
+				paymentProvider.EXPECT().Charge(gomock.Any(), gomock.Any()).
+					Return(models.ChargeResult{Status: models.TransactionStatusCompleted}, nil)
+				return paymentProvider
+			},
+			expect: func(ctrl *gomock.Controller, plan models.InstallmentPlan, installmentRepo *mocks.MockInstallmentRepository) (*mocks.MockPaymentProducerService, *mocks.MockLedgerRepository) {
+				installmentRepo.EXPECT().UpdateInstallment(ctx, gomock.Any()).Return(nil)
+				installmentRepo.EXPECT().UpdatePlanStatus(ctx, plan.UUID, models.InstallmentPlanStatusCompleted, gomock.Any()).Return(nil)
+
+				ledger := mocks.NewMockLedgerRepository(ctrl)
+				ledger.EXPECT().PostEntry(ctx, gomock.Any()).Return(nil)
+
+				producer := mocks.NewMockPaymentProducerService(ctrl)
+				producer.EXPECT().SendInstallmentPaid(ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, event *events.InstallmentPaidEvent) error {
+						require.True(t, event.PlanCompleted)
+						require.Equal(t, 300.0, event.PaidAmount)
+						require.Zero(t, event.RemainingAmount)
+						return nil
+					})
+				return producer, ledger
+			},
+		},
+		{
+			name:     "installment past grace period becomes overdue",
+			plan:     planWith(models.InstallmentStatusPaid, models.InstallmentStatusScheduled, models.InstallmentStatusScheduled),
+			provider: declined,
+			expect: func(ctrl *gomock.Controller, plan models.InstallmentPlan, installmentRepo *mocks.MockInstallmentRepository) (*mocks.MockPaymentProducerService, *mocks.MockLedgerRepository) {
+				installmentRepo.EXPECT().UpdateInstallment(ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, installment models.Installment) error {
+						require.Equal(t, models.InstallmentStatusOverdue, installment.Status)
+						require.Equal(t, "insufficient funds", installment.FailureReason)
+						require.True(t, installment.NextAttemptAt.After(now))
+						return nil
+					})
+
+				producer := mocks.NewMockPaymentProducerService(ctrl)
+				producer.EXPECT().SendPaymentOverdue(ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, event *events.PaymentOverdueEvent) error {
+						require.Equal(t, plan.UUID, event.PlanUUID)
+						require.Equal(t, 2, event.InstallmentNumber)
+						return nil
+					})
+				return producer, mocks.NewMockLedgerRepository(ctrl)
+			},
+		},
+		{
+			name:     "already overdue installment is retried without new event",
+			plan:     planWith(models.InstallmentStatusPaid, models.InstallmentStatusOverdue, models.InstallmentStatusScheduled),
+			provider: declined,
+			expect: func(ctrl *gomock.Controller, _ models.InstallmentPlan, installmentRepo *mocks.MockInstallmentRepository) (*mocks.MockPaymentProducerService, *mocks.MockLedgerRepository) {
+				installmentRepo.EXPECT().UpdateInstallment(ctx, gomock.Any()).Return(nil)
+				return mocks.NewMockPaymentProducerService(ctrl), mocks.NewMockLedgerRepository(ctrl)
+			},
+		},
+		{
+			name:     "failed first installment cancels the plan",
+			plan:     planWith(models.InstallmentStatusScheduled),
+			provider: declined,
+			expect: func(ctrl *gomock.Controller, plan models.InstallmentPlan, installmentRepo *mocks.MockInstallmentRepository) (*mocks.MockPaymentProducerService, *mocks.MockLedgerRepository) {
+				installmentRepo.EXPECT().UpdateInstallment(ctx, gomock.Any()).Return(nil)
+				installmentRepo.EXPECT().UpdatePlanStatus(ctx, plan.UUID, models.InstallmentPlanStatusCancelled, gomock.Any()).Return(nil)
+
+				producer := mocks.NewMockPaymentProducerService(ctrl)
+				producer.EXPECT().SendPaymentFailed(ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, event *events.PaymentFailedEvent) error {
+						require.Equal(t, plan.UUID, event.TransactionUUID)
+						require.Equal(t, plan.OrderUUID, event.OrderUUID)
+						return nil
+					})
+				return producer, mocks.NewMockLedgerRepository(ctrl)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			due := tt.plan.Installments[len(tt.plan.Installments)-1]
+			for _, installment := range tt.plan.Installments {
+				if installment.Status != models.InstallmentStatusPaid {
+					due = installment
+					break
+				}
+			}
+
+			installmentRepo := mocks.NewMockInstallmentRepository(ctrl)
+			installmentRepo.EXPECT().ClaimDue(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return([]models.Installment{due}, nil)
+			installmentRepo.EXPECT().GetPlan(ctx, tt.plan.UUID).Return(tt.plan, nil)
+
+			installmentRepo.EXPECT().UpdateInstallment(ctx, gomock.Any()).
+				DoAndReturn(func(_ context.Context, installment models.Installment) error {
+					require.Equal(t, due.Number, installment.Number)
+					require.NotEmpty(t, installment.TransactionUUID)
+					require.Equal(t, 1, installment.Attempts)
+					return nil
+				})
+
+			paymentRepo := mocks.NewMockPaymentRepository(ctrl)
+			paymentRepo.EXPECT().CreateTransaction(ctx, gomock.Any()).Return(nil)
+			paymentRepo.EXPECT().UpdateTransaction(ctx, gomock.Any(), gomock.Any()).Return(nil)
+
+			producer, ledger := tt.expect(ctrl, tt.plan, installmentRepo)
+
+			uc := usecase.NewUseCase(
+				paymentRepo,
+				ledger,
+				installmentRepo,
+				nil,
+				creditCardProviders(tt.provider(ctrl)),
+				nil,
+				providerConfig{timeout: time.Second},
+				testInstallmentConfig,
+				nil,
+				producer,
+				nil,
+			)
+
+			charged, err := uc.ChargeDueInstallments(ctx)
+			require.NoError(t, err)
+			require.Equal(t, 1, charged)
+		})
+	}
+
+	t.Run("claim error", func(t *testing.T) {
+		installmentRepo := mocks.NewMockInstallmentRepository(gomock.NewController(t))
+		installmentRepo.EXPECT().ClaimDue(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("database error"))
+
+		uc := usecase.NewUseCase(nil, nil, installmentRepo, nil, nil, nil, providerConfig{timeout: time.Second}, testInstallmentConfig, nil, nil, nil)
+
+		_, err := uc.ChargeDueInstallments(ctx)
+		require.Error(t, err)
+	})
+}
+
+func TestChargeDueInstallments_ResumesAttempt(t *testing.T) {
+	ctx := context.Background()
+	if err := logger.Init(ctx, "info", false, false, "", "payment-test"); err != nil {
+		t.Fatalf("failed to init logger: %v", err)
+	}
+
+	attemptUUID := uuid.New().String()
+	now := time.Now()
+	newPlan := func() models.InstallmentPlan {
+		plan := models.InstallmentPlan{
+			UUID:          uuid.New().String(),
+			OrderUUID:     uuid.New().String(),
+			UserID:        uuid.New().String(),
+			PaymentMethod: models.InstallmentPaymentMethod,
+			Principal:     200,
+			TotalAmount:   200,
+			Status:        models.InstallmentPlanStatusActive,
+		}
+		plan.Installments = []models.Installment{
+			{PlanUUID: plan.UUID, Number: 1, Amount: 100, DueAt: now.Add(-testInstallmentConfig.period), Status: models.InstallmentStatusPaid},
+			{PlanUUID: plan.UUID, Number: 2, Amount: 100, DueAt: now, Status: models.InstallmentStatusScheduled, TransactionUUID: attemptUUID, Attempts: 1},
+		}
+		return plan
+	}
+
+	attempt := func(status models.TransactionStatus) models.Transaction {
+		return models.Transaction{
+			UUID:                attemptUUID,
+			PaymentMethod:       models.InstallmentPaymentMethod,
+			Amount:              100,
+			Status:              status,
+			InstallmentPlanUUID: uuid.New().String(),
+		}
+	}
+
+	expectPaid := func(ctrl *gomock.Controller, installmentRepo *mocks.MockInstallmentRepository) (*mocks.MockLedgerRepository, *mocks.MockPaymentProducerService) {
+		installmentRepo.EXPECT().UpdateInstallment(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, installment models.Installment) error {
+				require.Equal(t, models.InstallmentStatusPaid, installment.Status)
+				require.Equal(t, attemptUUID, installment.TransactionUUID)
+				require.Equal(t, 1, installment.Attempts)
+				return nil
+			})
+		installmentRepo.EXPECT().UpdatePlanStatus(ctx, gomock.Any(), models.InstallmentPlanStatusCompleted, gomock.Any()).Return(nil)
+
+		producer := mocks.NewMockPaymentProducerService(ctrl)
+		producer.EXPECT().SendInstallmentPaid(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, event *events.InstallmentPaidEvent) error {
+				require.Equal(t, attemptUUID, event.TransactionUUID)
+				return nil
+			})
+
+		return mocks.NewMockLedgerRepository(ctrl), producer
+	}
+
+	tests := []struct {
+		name   string
+		expect func(ctrl *gomock.Controller, paymentRepo *mocks.MockPaymentRepository, installmentRepo *mocks.MockInstallmentRepository,
+			paymentProvider *mocks.MockPaymentProvider) (*mocks.MockLedgerRepository, *mocks.MockPaymentProducerService)
+	}{
+		{
+			name: "pending attempt is settled by provider status",
+			expect: func(ctrl *gomock.Controller, paymentRepo *mocks.MockPaymentRepository, installmentRepo *mocks.MockInstallmentRepository,
+				paymentProvider *mocks.MockPaymentProvider,
+			) (*mocks.MockLedgerRepository, *mocks.MockPaymentProducerService) {
+				paymentRepo.EXPECT().GetTransaction(ctx, attemptUUID).Return(attempt(models.TransactionStatusPending), nil)
+				paymentRepo.EXPECT().UpdateTransaction(ctx, attemptUUID, gomock.Any()).Return(nil)
+				paymentProvider.EXPECT().Status(gomock.Any(), attemptUUID).
+					Return(models.ChargeResult{Status: models.TransactionStatusCompleted}, nil)
+
+				ledger, producer := expectPaid(ctrl, installmentRepo)
+				ledger.EXPECT().PostEntry(ctx, gomock.Any()).Return(nil)
+				return ledger, producer
+			},
+		},
+		{
+			name: "lost attempt unknown to provider is charged again with the same uuid",
+			expect: func(ctrl *gomock.Controller, paymentRepo *mocks.MockPaymentRepository, installmentRepo *mocks.MockInstallmentRepository,
+				paymentProvider *mocks.MockPaymentProvider,
+			) (*mocks.MockLedgerRepository, *mocks.MockPaymentProducerService) {
+				paymentRepo.EXPECT().GetTransaction(ctx, attemptUUID).Return(models.Transaction{}, apperrors.ErrTransactionNotFound)
+				paymentRepo.EXPECT().CreateTransaction(ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, transaction models.Transaction) error {
+						require.Equal(t, attemptUUID, transaction.UUID)
+						return nil
+					})
+				paymentRepo.EXPECT().UpdateTransaction(ctx, attemptUUID, gomock.Any()).Return(nil)
+				paymentProvider.EXPECT().Status(gomock.Any(), attemptUUID).Return(models.ChargeResult{}, apperrors.ErrChargeNotFound)
+				paymentProvider.EXPECT().Charge(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, req models.ChargeRequest) (models.ChargeResult, error) {
+						require.Equal(t, attemptUUID, req.TransactionUUID)
+						return models.ChargeResult{Status: models.TransactionStatusCompleted}, nil
+					})
+
+				ledger, producer := expectPaid(ctrl, installmentRepo)
+				ledger.EXPECT().PostEntry(ctx, gomock.Any()).Return(nil)
+				return ledger, producer
+			},
+		},
+		{
+			name: "pending attempt with unknown status stays pending",
+			expect: func(ctrl *gomock.Controller, paymentRepo *mocks.MockPaymentRepository, _ *mocks.MockInstallmentRepository,
+				paymentProvider *mocks.MockPaymentProvider,
+			) (*mocks.MockLedgerRepository, *mocks.MockPaymentProducerService) {
+				paymentRepo.EXPECT().GetTransaction(ctx, attemptUUID).Return(attempt(models.TransactionStatusPending), nil)
+				paymentProvider.EXPECT().Status(gomock.Any(), attemptUUID).Return(models.ChargeResult{}, context.DeadlineExceeded)
+
+				return mocks.NewMockLedgerRepository(ctrl), mocks.NewMockPaymentProducerService(ctrl)
+			},
+		},
+		{
+			name: "completed attempt only updates the plan",
+			expect: func(ctrl *gomock.Controller, paymentRepo *mocks.MockPaymentRepository, installmentRepo *mocks.MockInstallmentRepository,
+				_ *mocks.MockPaymentProvider,
+			) (*mocks.MockLedgerRepository, *mocks.MockPaymentProducerService) {
+				paymentRepo.EXPECT().GetTransaction(ctx, attemptUUID).Return(attempt(models.TransactionStatusCompleted), nil)
+
+				return expectPaid(ctrl, installmentRepo)
+			},
+		},
+		{
+			name: "declined attempt is retried with a new uuid",
+			expect: func(ctrl *gomock.Controller, paymentRepo *mocks.MockPaymentRepository, installmentRepo *mocks.MockInstallmentRepository,
+				paymentProvider *mocks.MockPaymentProvider,
+			) (*mocks.MockLedgerRepository, *mocks.MockPaymentProducerService) {
+				var retryUUID string
+				paymentRepo.EXPECT().GetTransaction(ctx, attemptUUID).Return(attempt(models.TransactionStatusFailed), nil)
+				installmentRepo.EXPECT().UpdateInstallment(ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, installment models.Installment) error {
+						require.NotEqual(t, attemptUUID, installment.TransactionUUID)
+						require.Equal(t, 2, installment.Attempts)
+						retryUUID = installment.TransactionUUID
+						return nil
+					})
+				paymentRepo.EXPECT().CreateTransaction(ctx, gomock.Any()).Return(nil)
+				paymentRepo.EXPECT().UpdateTransaction(ctx, gomock.Any(), gomock.Any()).Return(nil)
+				paymentProvider.EXPECT().Charge(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, req models.ChargeRequest) (models.ChargeResult, error) {
+						require.Equal(t, retryUUID, req.TransactionUUID)
+						return models.ChargeResult{Status: models.TransactionStatusFailed, DeclineReason: "insufficient funds"}, nil
+					})
+				installmentRepo.EXPECT().UpdateInstallment(ctx, gomock.Any()).Return(nil)
+
+				return mocks.NewMockLedgerRepository(ctrl), mocks.NewMockPaymentProducerService(ctrl)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			plan := newPlan()
+
+			installmentRepo := mocks.NewMockInstallmentRepository(ctrl)
+			installmentRepo.EXPECT().ClaimDue(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return([]models.Installment{plan.Installments[1]}, nil)
+			installmentRepo.EXPECT().GetPlan(ctx, plan.UUID).Return(plan, nil)
+
+			paymentRepo := mocks.NewMockPaymentRepository(ctrl)
+			paymentProvider := mocks.NewMockPaymentProvider(ctrl)
+			paymentProvider.EXPECT().Name().Return("test-provider").AnyTimes()
+
+			ledger, producer := tt.expect(ctrl, paymentRepo, installmentRepo, paymentProvider)
+
+			uc := usecase.NewUseCase(
+				paymentRepo,
+				ledger,
+				installmentRepo,
+				nil,
+				creditCardProviders(paymentProvider),
+				nil,
+				providerConfig{timeout: time.Second},
+				testInstallmentConfig,
+				nil,
+				producer,
+				nil,
+			)
+
+			charged, err := uc.ChargeDueInstallments(ctx)
+			require.NoError(t, err)
+			require.Equal(t, 1, charged)
+		})
+	}
+}
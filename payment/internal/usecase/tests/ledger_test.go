@@ -0,0 +1,228 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/linemk/rocket-shop/payment/internal/entyties/apperrors"
+	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
+	"github.com/linemk/rocket-shop/payment/internal/mocks"
+	"github.com/linemk/rocket-shop/payment/internal/provider"
+	"github.com/linemk/rocket-shop/payment/internal/usecase"
+	payment_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/payment/v1"
+)
+
+func TestRecordPaymentInLedger(t *testing.T) {
+	ctx := context.Background()
+
+	// sumPostings проверяет баланс записи и возвращает обороты по счетам
+	sumPostings := func(t *testing.T, entries []models.JournalEntry) map[string]int64 {
+		totals := make(map[string]int64)
+		for _, entry := range entries {
+			var debit, credit int64
+			for _, posting := range entry.Postings {
+				if posting.Direction == models.DirectionDebit {
+					debit += posting.Amount
+					totals[posting.AccountCode] += posting.Amount
+				} else {
+					credit += posting.Amount
+					totals[posting.AccountCode] -= posting.Amount
+				}
+			}
+			require.Equal(t, debit, credit, "entry %s is not balanced", entry.Type)
+		}
+		return totals
+	}
+
+	tests := []struct {
+		name          string
+		paymentMethod payment_v1.PaymentMethod
+		feeRate       float64
+		wantTypes     []models.EntryType
+		wantTotals    map[string]int64
+	}{
+		{
+			name:          "card payment with provider fee",
+			paymentMethod: payment_v1.PaymentMethod_PAYMENT_METHOD_CARD,
+			feeRate:       0.02,
+			wantTypes:     []models.EntryType{models.EntryTypeCharge, models.EntryTypeFee},
+			wantTotals: map[string]int64{
+				"provider_clearing:card":   147000,
+				models.AccountProviderFees: 3000,
+				models.AccountSalesRevenue: -150000,
+			},
+		},
+		{
+			name:          "sbp payment without fee",
+			paymentMethod: payment_v1.PaymentMethod_PAYMENT_METHOD_SBP,
+			wantTypes:     []models.EntryType{models.EntryTypeCharge},
+			wantTotals: map[string]int64{
+				"provider_clearing:sbp":    150000,
+				models.AccountSalesRevenue: -150000,
+			},
+		},
+		{
+			name:          "investor money settlement",
+			paymentMethod: payment_v1.PaymentMethod_PAYMENT_METHOD_INVESTOR_MONEY,
+			feeRate:       0.02,
+			wantTypes:     []models.EntryType{models.EntryTypeSettlement},
+			wantTotals: map[string]int64{
+				models.AccountInvestorFunds: 150000,
+				models.AccountSalesRevenue:  -150000,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			transaction := models.Transaction{
+				UUID:          uuid.New().String(),
+				OrderUUID:     uuid.New().String(),
+				UserID:        uuid.New().String(),
+				PaymentMethod: tt.paymentMethod,
+				Amount:        1500,
+				Status:        models.TransactionStatusPending,
+			}
+
+			mockRepo := mocks.NewMockPaymentRepository(ctrl)
+			mockRepo.EXPECT().GetTransaction(ctx, transaction.UUID).Return(transaction, nil)
+			mockRepo.EXPECT().UpdateTransaction(ctx, transaction.UUID, gomock.Any()).Return(nil)
+
+			var entries []models.JournalEntry
+			mockLedger := mocks.NewMockLedgerRepository(ctrl)
+			mockLedger.EXPECT().PostEntry(ctx, gomock.Any()).
+				DoAndReturn(func(_ context.Context, entry models.JournalEntry) error {
+					require.Equal(t, transaction.UUID, entry.TransactionUUID)
+					entries = append(entries, entry)
+					return nil
+				}).
+				Times(len(tt.wantTypes))
+
+			producer := mocks.NewMockPaymentProducerService(ctrl)
+			producer.EXPECT().SendPaymentCompleted(ctx, gomock.Any()).Return(nil)
+
+			paymentProvider := mocks.NewMockPaymentProvider(ctrl)
+			paymentProvider.EXPECT().Status(gomock.Any(), transaction.UUID).Return(models.ChargeResult{Status: models.TransactionStatusCompleted}, nil)
+			providers := provider.NewRegistry(map[payment_v1.PaymentMethod]provider.PaymentProvider{
+				tt.paymentMethod: paymentProvider,
+			})
+
+			uc := usecase.NewUseCase(mockRepo, mockLedger, nil, nil, providers, nil, providerConfig{timeout: time.Second, feeRate: tt.feeRate}, nil, nil, producer, nil)
+
+			_, err := uc.ConfirmTransaction(ctx, transaction.UUID, transaction.UserID, true, "")
+			require.NoError(t, err)
+
+			types := make([]models.EntryType, 0, len(entries))
+			for _, entry := range entries {
+				types = append(types, entry.Type)
+			}
+			require.Equal(t, tt.wantTypes, types)
+			require.Equal(t, tt.wantTotals, sumPostings(t, entries))
+		})
+	}
+}
+
+func TestGetAccountBalance(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		account     models.LedgerAccount
+		totals      models.PostingTotals
+		wantBalance int64
+	}{
+		{
+			name:        "asset account balance is debit minus credit",
+			account:     models.LedgerAccount{Code: "provider_clearing:card", Type: models.AccountTypeAsset},
+			totals:      models.PostingTotals{Debit: 150000, Credit: 3000},
+			wantBalance: 147000,
+		},
+		{
+			name:        "revenue account balance is credit minus debit",
+			account:     models.LedgerAccount{Code: models.AccountSalesRevenue, Type: models.AccountTypeRevenue},
+			totals:      models.PostingTotals{Debit: 10000, Credit: 150000},
+			wantBalance: 140000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockLedger := mocks.NewMockLedgerRepository(gomock.NewController(t))
+			mockLedger.EXPECT().GetAccount(ctx, tt.account.Code).Return(tt.account, nil)
+			mockLedger.EXPECT().GetTotals(ctx, tt.account.Code, time.Time{}).Return(tt.totals, nil)
+
+			uc := usecase.NewUseCase(nil, mockLedger, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+			balance, err := uc.GetAccountBalance(ctx, tt.account.Code)
+			require.NoError(t, err)
+			require.Equal(t, tt.totals, balance.Totals)
+			require.Equal(t, tt.wantBalance, balance.Balance)
+		})
+	}
+
+	t.Run("unknown account", func(t *testing.T) {
+		mockLedger := mocks.NewMockLedgerRepository(gomock.NewController(t))
+		mockLedger.EXPECT().GetAccount(ctx, "unknown").Return(models.LedgerAccount{}, apperrors.ErrAccountNotFound)
+
+		uc := usecase.NewUseCase(nil, mockLedger, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		_, err := uc.GetAccountBalance(ctx, "unknown")
+		require.ErrorIs(t, err, apperrors.ErrAccountNotFound)
+	})
+}
+
+func TestGetAccountStatement(t *testing.T) {
+	ctx := context.Background()
+
+	account := models.LedgerAccount{Code: "provider_clearing:card", Type: models.AccountTypeAsset}
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	t.Run("running balance starts from opening balance", func(t *testing.T) {
+		mockLedger := mocks.NewMockLedgerRepository(gomock.NewController(t))
+		mockLedger.EXPECT().GetAccount(ctx, account.Code).Return(account, nil)
+		mockLedger.EXPECT().GetTotals(ctx, account.Code, from).Return(models.PostingTotals{Debit: 50000}, nil)
+		mockLedger.EXPECT().ListPostings(ctx, account.Code, from, to).Return([]models.AccountPosting{
+			{EntryType: models.EntryTypeCharge, Direction: models.DirectionDebit, Amount: 150000},
+			{EntryType: models.EntryTypeFee, Direction: models.DirectionCredit, Amount: 3000},
+		}, nil)
+
+		uc := usecase.NewUseCase(nil, mockLedger, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		statement, err := uc.GetAccountStatement(ctx, account.Code, from, to)
+		require.NoError(t, err)
+		require.Equal(t, int64(50000), statement.OpeningBalance)
+		require.Len(t, statement.Lines, 2)
+		require.Equal(t, int64(200000), statement.Lines[0].Balance)
+		require.Equal(t, int64(197000), statement.Lines[1].Balance)
+		require.Equal(t, int64(197000), statement.ClosingBalance)
+	})
+
+	t.Run("statement without period start has zero opening balance", func(t *testing.T) {
+		mockLedger := mocks.NewMockLedgerRepository(gomock.NewController(t))
+		mockLedger.EXPECT().GetAccount(ctx, account.Code).Return(account, nil)
+		mockLedger.EXPECT().ListPostings(ctx, account.Code, time.Time{}, time.Time{}).Return(nil, nil)
+
+		uc := usecase.NewUseCase(nil, mockLedger, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		statement, err := uc.GetAccountStatement(ctx, account.Code, time.Time{}, time.Time{})
+		require.NoError(t, err)
+		require.Zero(t, statement.OpeningBalance)
+		require.Zero(t, statement.ClosingBalance)
+		require.Empty(t, statement.Lines)
+	})
+
+	t.Run("invalid period", func(t *testing.T) {
+		uc := usecase.NewUseCase(nil, mocks.NewMockLedgerRepository(gomock.NewController(t)), nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		_, err := uc.GetAccountStatement(ctx, account.Code, to, from)
+		require.ErrorIs(t, err, apperrors.ErrInvalidPeriod)
+	})
+}
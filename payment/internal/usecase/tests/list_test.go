@@ -81,7 +81,7 @@ func TestListTransactions(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			paymentRepo := tt.fields.repoMock()
-			uc := usecase.NewUseCase(paymentRepo)
+			uc := usecase.NewUseCase(paymentRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 
 			transactions, err := uc.ListTransactions(ctx, tt.uuid)
 
@@ -2,19 +2,57 @@ package tests
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 
 	"github.com/linemk/rocket-shop/payment/internal/entyties/apperrors"
+	"github.com/linemk/rocket-shop/payment/internal/entyties/events"
+	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
 	"github.com/linemk/rocket-shop/payment/internal/mocks"
+	"github.com/linemk/rocket-shop/payment/internal/provider"
 	"github.com/linemk/rocket-shop/payment/internal/usecase"
 	"github.com/linemk/rocket-shop/platform/pkg/logger"
 	payment_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/payment/v1"
 )
 
+// asyncTimeout время ожидания асинхронного завершения платежа
+const asyncTimeout = time.Second
+
+type providerConfig struct {
+	timeout       time.Duration
+	feeRate       float64
+	recoveryAfter time.Duration
+}
+
+func (c providerConfig) Timeout() time.Duration {
+	return c.timeout
+}
+
+func (c providerConfig) FeeRate() float64 {
+	return c.feeRate
+}
+
+func (c providerConfig) RecoveryInterval() time.Duration {
+	return 0
+}
+
+func (c providerConfig) RecoveryAfter() time.Duration {
+	return c.recoveryAfter
+}
+
+// approveAll возвращает антифрод-проверку, одобряющую все платежи
+func approveAll(ctrl *gomock.Controller) *mocks.MockScreener {
+	screener := mocks.NewMockScreener(ctrl)
+	screener.EXPECT().Screen(gomock.Any(), gomock.Any()).
+		Return(models.FraudVerdict{Decision: models.FraudDecisionApprove}).AnyTimes()
+	return screener
+}
+
 func TestPayOrder(t *testing.T) {
 	ctx := context.Background()
 	// Инициализируем logger для тестов
@@ -23,101 +61,449 @@ func TestPayOrder(t *testing.T) {
 	}
 
 	type fields struct {
-		repoMock func() *mocks.MockPaymentRepository
+		repoMock     func(ctrl *gomock.Controller) *mocks.MockPaymentRepository
+		ledgerMock   func(ctrl *gomock.Controller) *mocks.MockLedgerRepository
+		providerMock func(ctrl *gomock.Controller) *mocks.MockPaymentProvider
+		producerMock func(ctrl *gomock.Controller, done chan struct{}) *mocks.MockPaymentProducerService
 	}
 
 	type args struct {
 		orderUUID     string
 		userID        string
 		paymentMethod payment_v1.PaymentMethod
+		amount        float64
 	}
 
 	orderUUID := uuid.New().String()
 	userID := uuid.New().String()
+	amount := 1500.0
+
+	// expectStatus проверяет статус, с которым транзакция сохраняется после ответа провайдера
+	expectStatus := func(status models.TransactionStatus) func(ctrl *gomock.Controller) *mocks.MockPaymentRepository {
+		return func(ctrl *gomock.Controller) *mocks.MockPaymentRepository {
+			repo := mocks.NewMockPaymentRepository(ctrl)
+			repo.EXPECT().ListTransactions(gomock.Any(), gomock.Any()).Return(nil, nil)
+			repo.EXPECT().CreateTransaction(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, transaction models.Transaction) error {
+					require.Equal(t, models.TransactionStatusPending, transaction.Status)
+					require.Equal(t, amount, transaction.Amount)
+					return nil
+				})
+			repo.EXPECT().UpdateTransaction(gomock.Any(), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, _ string, transaction models.Transaction) error {
+					require.Equal(t, status, transaction.Status)
+					return nil
+				})
+			return repo
+		}
+	}
+
+	chargeReturns := func(result models.ChargeResult, err error) func(ctrl *gomock.Controller) *mocks.MockPaymentProvider {
+		return func(ctrl *gomock.Controller) *mocks.MockPaymentProvider {
+			mockProvider := mocks.NewMockPaymentProvider(ctrl)
+			mockProvider.EXPECT().Name().Return("test-provider").AnyTimes()
+			mockProvider.EXPECT().Charge(gomock.Any(), gomock.Any()).Return(result, err)
+			return mockProvider
+		}
+	}
+
+	expectCompleted := func(ctrl *gomock.Controller, done chan struct{}) *mocks.MockPaymentProducerService {
+		producer := mocks.NewMockPaymentProducerService(ctrl)
+		producer.EXPECT().SendPaymentCompleted(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, event *events.PaymentCompletedEvent) error {
+				defer close(done)
+				require.Equal(t, orderUUID, event.OrderUUID)
+				require.Equal(t, amount, event.Amount)
+				return nil
+			})
+		return producer
+	}
+
+	expectFailed := func(status models.TransactionStatus) func(ctrl *gomock.Controller, done chan struct{}) *mocks.MockPaymentProducerService {
+		return func(ctrl *gomock.Controller, done chan struct{}) *mocks.MockPaymentProducerService {
+			producer := mocks.NewMockPaymentProducerService(ctrl)
+			producer.EXPECT().SendPaymentFailed(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, event *events.PaymentFailedEvent) error {
+					defer close(done)
+					require.Equal(t, string(status), event.Status)
+					require.NotEmpty(t, event.Reason)
+					return nil
+				})
+			return producer
+		}
+	}
+
+	expectCharge := func(ctrl *gomock.Controller) *mocks.MockLedgerRepository {
+		mockLedger := mocks.NewMockLedgerRepository(ctrl)
+		mockLedger.EXPECT().PostEntry(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, entry models.JournalEntry) error {
+				require.Equal(t, models.EntryTypeCharge, entry.Type)
+				require.Equal(t, models.ToMinorUnits(amount), entry.Postings[0].Amount)
+				return nil
+			})
+		return mockLedger
+	}
+
+	noLedgerCalls := func(ctrl *gomock.Controller) *mocks.MockLedgerRepository {
+		return mocks.NewMockLedgerRepository(ctrl)
+	}
+
+	noProviderCalls := func(ctrl *gomock.Controller) *mocks.MockPaymentProvider {
+		return mocks.NewMockPaymentProvider(ctrl)
+	}
+
+	noEvents := func(ctrl *gomock.Controller, _ chan struct{}) *mocks.MockPaymentProducerService {
+		return mocks.NewMockPaymentProducerService(ctrl)
+	}
 
 	tests := []struct {
 		name    string
 		fields  fields
 		args    args
-		wantErr bool
+		wantErr error
+		// wantAsync true, если после ответа PayOrder ожидается событие о результате платежа
+		wantAsync bool
 	}{
 		{
 			name: "successfully pay order",
 			fields: fields{
-				repoMock: func() *mocks.MockPaymentRepository {
-					mockRepo := mocks.NewMockPaymentRepository(gomock.NewController(t))
-					mockRepo.EXPECT().CreateTransaction(ctx, gomock.Any()).Return(nil)
-					return mockRepo
-				},
+				repoMock:     expectStatus(models.TransactionStatusCompleted),
+				ledgerMock:   expectCharge,
+				providerMock: chargeReturns(models.ChargeResult{Status: models.TransactionStatusCompleted}, nil),
+				producerMock: expectCompleted,
+			},
+			args: args{
+				orderUUID:     orderUUID,
+				userID:        userID,
+				paymentMethod: payment_v1.PaymentMethod_PAYMENT_METHOD_CARD,
+				amount:        amount,
+			},
+			wantAsync: true,
+		},
+		{
+			name: "pay order declined by provider",
+			fields: fields{
+				repoMock:   expectStatus(models.TransactionStatusFailed),
+				ledgerMock: noLedgerCalls,
+				providerMock: chargeReturns(models.ChargeResult{
+					Status:        models.TransactionStatusFailed,
+					DeclineReason: "insufficient funds",
+				}, nil),
+				producerMock: expectFailed(models.TransactionStatusFailed),
 			},
 			args: args{
 				orderUUID:     orderUUID,
 				userID:        userID,
 				paymentMethod: payment_v1.PaymentMethod_PAYMENT_METHOD_CARD,
+				amount:        amount,
 			},
-			wantErr: false,
+			wantAsync: true,
+		},
+		{
+			name: "pay order with unsupported payment method",
+			fields: fields{
+				repoMock: func(ctrl *gomock.Controller) *mocks.MockPaymentRepository {
+					return mocks.NewMockPaymentRepository(ctrl)
+				},
+				ledgerMock:   noLedgerCalls,
+				providerMock: noProviderCalls,
+				producerMock: noEvents,
+			},
+			args: args{
+				orderUUID:     orderUUID,
+				userID:        userID,
+				paymentMethod: payment_v1.PaymentMethod_PAYMENT_METHOD_SBP,
+				amount:        amount,
+			},
+			wantErr: apperrors.ErrInvalidPaymentMethod,
 		},
 		{
 			name: "pay order with empty orderUUID",
 			fields: fields{
-				repoMock: func() *mocks.MockPaymentRepository {
-					return mocks.NewMockPaymentRepository(gomock.NewController(t))
+				repoMock: func(ctrl *gomock.Controller) *mocks.MockPaymentRepository {
+					return mocks.NewMockPaymentRepository(ctrl)
 				},
+				ledgerMock:   noLedgerCalls,
+				providerMock: noProviderCalls,
+				producerMock: noEvents,
 			},
 			args: args{
 				orderUUID:     "",
 				userID:        userID,
 				paymentMethod: payment_v1.PaymentMethod_PAYMENT_METHOD_CARD,
+				amount:        amount,
 			},
-			wantErr: true,
+			wantErr: apperrors.ErrInvalidAmount,
 		},
 		{
 			name: "pay order with empty userID",
 			fields: fields{
-				repoMock: func() *mocks.MockPaymentRepository {
-					return mocks.NewMockPaymentRepository(gomock.NewController(t))
+				repoMock: func(ctrl *gomock.Controller) *mocks.MockPaymentRepository {
+					return mocks.NewMockPaymentRepository(ctrl)
 				},
+				ledgerMock:   noLedgerCalls,
+				providerMock: noProviderCalls,
+				producerMock: noEvents,
 			},
 			args: args{
 				orderUUID:     orderUUID,
 				userID:        "",
 				paymentMethod: payment_v1.PaymentMethod_PAYMENT_METHOD_CARD,
+				amount:        amount,
 			},
-			wantErr: true,
+			wantErr: apperrors.ErrInvalidAmount,
 		},
 		{
 			name: "pay order with repository error",
 			fields: fields{
-				repoMock: func() *mocks.MockPaymentRepository {
-					mockRepo := mocks.NewMockPaymentRepository(gomock.NewController(t))
-					mockRepo.EXPECT().CreateTransaction(ctx, gomock.Any()).Return(apperrors.ErrPaymentFailed)
+				repoMock: func(ctrl *gomock.Controller) *mocks.MockPaymentRepository {
+					mockRepo := mocks.NewMockPaymentRepository(ctrl)
+					mockRepo.EXPECT().ListTransactions(gomock.Any(), gomock.Any()).Return(nil, nil)
+					mockRepo.EXPECT().CreateTransaction(gomock.Any(), gomock.Any()).Return(apperrors.ErrPaymentFailed)
 					return mockRepo
 				},
+				ledgerMock:   noLedgerCalls,
+				providerMock: noProviderCalls,
+				producerMock: noEvents,
 			},
 			args: args{
 				orderUUID:     orderUUID,
 				userID:        userID,
 				paymentMethod: payment_v1.PaymentMethod_PAYMENT_METHOD_CARD,
+				amount:        amount,
 			},
-			wantErr: true,
+			wantErr: apperrors.ErrPaymentFailed,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			paymentRepo := tt.fields.repoMock()
-			uc := usecase.NewUseCase(paymentRepo)
+			ctrl := gomock.NewController(t)
+			done := make(chan struct{})
+			providers := provider.NewRegistry(map[payment_v1.PaymentMethod]provider.PaymentProvider{
+				payment_v1.PaymentMethod_PAYMENT_METHOD_CARD: tt.fields.providerMock(ctrl),
+			})
+			uc := usecase.NewUseCase(
+				tt.fields.repoMock(ctrl),
+				tt.fields.ledgerMock(ctrl),
+				nil,
+				nil,
+				providers,
+				approveAll(ctrl),
+				providerConfig{timeout: time.Second},
+				nil,
+				nil,
+				tt.fields.producerMock(ctrl, done),
+				nil,
+			)
 
-			transactionUUID, err := uc.PayOrder(ctx, tt.args.orderUUID, tt.args.userID, tt.args.paymentMethod)
+			transaction, err := uc.PayOrder(ctx, tt.args.orderUUID, tt.args.userID, tt.args.paymentMethod, tt.args.amount, "")
 
-			if tt.wantErr {
-				require.Error(t, err)
-				require.Empty(t, transactionUUID)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
 				return
 			}
 
 			require.NoError(t, err)
-			require.NotEmpty(t, transactionUUID)
+			require.NotEmpty(t, transaction.UUID)
+			require.Equal(t, models.TransactionStatusPending, transaction.Status)
+
+			if tt.wantAsync {
+				select {
+				case <-done:
+				case <-time.After(asyncTimeout):
+					t.Fatal("payment result event was not published")
+				}
+			}
 		})
 	}
 }
+
+func TestPayOrderUnknownOutcome(t *testing.T) {
+	ctx := context.Background()
+	if err := logger.Init(ctx, "info", false, false, "", "payment-test"); err != nil {
+		t.Fatalf("failed to init logger: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		chargeErr error
+	}{
+		{
+			name:      "provider failure leaves transaction pending",
+			chargeErr: errors.New("gateway unavailable"),
+		},
+		{
+			name:      "provider timeout leaves transaction pending",
+			chargeErr: context.DeadlineExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			charged := make(chan struct{})
+
+			// Исход списания неизвестен: транзакция не обновляется и событие о результате не публикуется
+			repo := mocks.NewMockPaymentRepository(ctrl)
+			repo.EXPECT().ListTransactions(gomock.Any(), gomock.Any()).Return(nil, nil)
+			repo.EXPECT().CreateTransaction(gomock.Any(), gomock.Any()).Return(nil)
+
+			mockProvider := mocks.NewMockPaymentProvider(ctrl)
+			mockProvider.EXPECT().Name().Return("test-provider").AnyTimes()
+			mockProvider.EXPECT().Charge(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(context.Context, models.ChargeRequest) (models.ChargeResult, error) {
+					defer close(charged)
+					return models.ChargeResult{}, tt.chargeErr
+				})
+
+			uc := usecase.NewUseCase(
+				repo,
+				mocks.NewMockLedgerRepository(ctrl),
+				nil,
+				nil,
+				provider.NewRegistry(map[payment_v1.PaymentMethod]provider.PaymentProvider{
+					payment_v1.PaymentMethod_PAYMENT_METHOD_CARD: mockProvider,
+				}),
+				approveAll(ctrl),
+				providerConfig{timeout: time.Second},
+				nil,
+				nil,
+				mocks.NewMockPaymentProducerService(ctrl),
+				nil,
+			)
+
+			transaction, err := uc.PayOrder(ctx, uuid.New().String(), uuid.New().String(), payment_v1.PaymentMethod_PAYMENT_METHOD_CARD, 1500, "")
+			require.NoError(t, err)
+			require.Equal(t, models.TransactionStatusPending, transaction.Status)
+
+			select {
+			case <-charged:
+			case <-time.After(asyncTimeout):
+				t.Fatal("provider was not called")
+			}
+
+			// Пока ответ провайдера обрабатывается, транзакция занята и не может быть подтверждена вручную;
+			// после обработки ConfirmTransaction снова доступен для нее
+			require.Eventually(t, func() bool {
+				repo.EXPECT().GetTransaction(gomock.Any(), transaction.UUID).Return(models.Transaction{}, apperrors.ErrTransactionNotFound).MaxTimes(1)
+				_, err := uc.ConfirmTransaction(ctx, transaction.UUID, transaction.UserID, false, "")
+				return errors.Is(err, apperrors.ErrTransactionNotFound)
+			}, asyncTimeout, 10*time.Millisecond)
+		})
+	}
+}
+
+func TestPayOrderIdempotency(t *testing.T) {
+	ctx := context.Background()
+	if err := logger.Init(ctx, "info", false, false, "", "payment-test"); err != nil {
+		t.Fatalf("failed to init logger: %v", err)
+	}
+
+	card := payment_v1.PaymentMethod_PAYMENT_METHOD_CARD
+
+	// newUseCase возвращает usecase, провайдер которого оставляет списание ожидать подтверждения;
+	// charged получает UUID каждой списываемой транзакции
+	newUseCase := func(t *testing.T, repo *transactionStore, charges int) (usecase.PaymentUseCase, chan string) {
+		ctrl := gomock.NewController(t)
+		charged := make(chan string, charges)
+
+		mockProvider := mocks.NewMockPaymentProvider(ctrl)
+		mockProvider.EXPECT().Name().Return("test-provider").AnyTimes()
+		mockProvider.EXPECT().Charge(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, req models.ChargeRequest) (models.ChargeResult, error) {
+				charged <- req.TransactionUUID
+				return models.ChargeResult{Status: models.TransactionStatusPending}, nil
+			}).Times(charges)
+
+		uc := usecase.NewUseCase(repo, nil, nil, nil,
+			provider.NewRegistry(map[payment_v1.PaymentMethod]provider.PaymentProvider{card: mockProvider}),
+			approveAll(ctrl), providerConfig{timeout: time.Second}, nil, nil, mocks.NewMockPaymentProducerService(ctrl), nil)
+
+		return uc, charged
+	}
+
+	waitCharge := func(t *testing.T, charged chan string) string {
+		select {
+		case transactionUUID := <-charged:
+			return transactionUUID
+		case <-time.After(asyncTimeout):
+			t.Fatal("provider was not called")
+			return ""
+		}
+	}
+
+	t.Run("retry with the same key returns the transaction", func(t *testing.T) {
+		repo := newTransactionStore()
+		uc, charged := newUseCase(t, repo, 1)
+		orderUUID, userID, key := uuid.New().String(), uuid.New().String(), uuid.New().String()
+
+		transaction, err := uc.PayOrder(ctx, orderUUID, userID, card, 1500, key)
+		require.NoError(t, err)
+		require.Equal(t, key, transaction.UUID)
+		require.Equal(t, key, waitCharge(t, charged))
+
+		retried, err := uc.PayOrder(ctx, orderUUID, userID, card, 1500, key)
+		require.NoError(t, err)
+		require.Equal(t, key, retried.UUID)
+
+		// Новая попытка, пока прошлая ожидает результата, тоже не создает второе списание
+		another, err := uc.PayOrder(ctx, orderUUID, userID, card, 1500, uuid.New().String())
+		require.NoError(t, err)
+		require.Equal(t, key, another.UUID)
+
+		transactions, err := repo.ListTransactions(ctx, orderUUID)
+		require.NoError(t, err)
+		require.Len(t, transactions, 1)
+	})
+
+	t.Run("failed payment does not block a new attempt", func(t *testing.T) {
+		repo := newTransactionStore()
+		uc, charged := newUseCase(t, repo, 1)
+		orderUUID, userID, key := uuid.New().String(), uuid.New().String(), uuid.New().String()
+
+		require.NoError(t, repo.CreateTransaction(ctx, models.Transaction{
+			UUID:          uuid.New().String(),
+			OrderUUID:     orderUUID,
+			UserID:        userID,
+			PaymentMethod: card,
+			Amount:        1500,
+			Status:        models.TransactionStatusFailed,
+			CreatedAt:     time.Now(),
+			UpdatedAt:     time.Now(),
+		}))
+
+		transaction, err := uc.PayOrder(ctx, orderUUID, userID, card, 1500, key)
+		require.NoError(t, err)
+		require.Equal(t, key, transaction.UUID)
+		require.Equal(t, models.TransactionStatusPending, transaction.Status)
+		require.Equal(t, key, waitCharge(t, charged))
+	})
+
+	t.Run("key of another order is rejected", func(t *testing.T) {
+		repo := newTransactionStore()
+		uc, _ := newUseCase(t, repo, 0)
+		key := uuid.New().String()
+
+		require.NoError(t, repo.CreateTransaction(ctx, models.Transaction{
+			UUID:          key,
+			OrderUUID:     uuid.New().String(),
+			UserID:        uuid.New().String(),
+			PaymentMethod: card,
+			Amount:        1500,
+			Status:        models.TransactionStatusCompleted,
+			CreatedAt:     time.Now(),
+			UpdatedAt:     time.Now(),
+		}))
+
+		_, err := uc.PayOrder(ctx, uuid.New().String(), uuid.New().String(), card, 1500, key)
+		require.ErrorIs(t, err, apperrors.ErrTransactionAlreadyExists)
+	})
+
+	t.Run("key must be a UUID", func(t *testing.T) {
+		uc, _ := newUseCase(t, newTransactionStore(), 0)
+
+		_, err := uc.PayOrder(ctx, uuid.New().String(), uuid.New().String(), card, 1500, "attempt-1")
+		require.ErrorIs(t, err, apperrors.ErrInvalidIdempotencyKey)
+	})
+}
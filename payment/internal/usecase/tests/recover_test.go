@@ -0,0 +1,399 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/linemk/rocket-shop/payment/internal/entyties/apperrors"
+	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
+	"github.com/linemk/rocket-shop/payment/internal/mocks"
+	"github.com/linemk/rocket-shop/payment/internal/provider"
+	"github.com/linemk/rocket-shop/payment/internal/usecase"
+	"github.com/linemk/rocket-shop/platform/pkg/logger"
+	payment_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/payment/v1"
+)
+
+func TestRecoverPendingCharges(t *testing.T) {
+	ctx := context.Background()
+	if err := logger.Init(ctx, "info", false, false, "", "payment-test"); err != nil {
+		t.Fatalf("failed to init logger: %v", err)
+	}
+
+	newStale := func() models.Transaction {
+		return models.Transaction{
+			UUID:          uuid.New().String(),
+			OrderUUID:     uuid.New().String(),
+			UserID:        uuid.New().String(),
+			PaymentMethod: payment_v1.PaymentMethod_PAYMENT_METHOD_CARD,
+			Amount:        1500,
+			Status:        models.TransactionStatusPending,
+			CreatedAt:     time.Now().Add(-time.Hour),
+		}
+	}
+
+	newUseCase := func(ctrl *gomock.Controller, repo *mocks.MockPaymentRepository, ledger *mocks.MockLedgerRepository,
+		paymentProvider *mocks.MockPaymentProvider, producer *mocks.MockPaymentProducerService,
+	) usecase.PaymentUseCase {
+		paymentProvider.EXPECT().Name().Return("test-provider").AnyTimes()
+
+		return usecase.NewUseCase(
+			repo,
+			ledger,
+			nil,
+			nil,
+			provider.NewRegistry(map[payment_v1.PaymentMethod]provider.PaymentProvider{
+				payment_v1.PaymentMethod_PAYMENT_METHOD_CARD: paymentProvider,
+			}),
+			nil,
+			providerConfig{timeout: time.Second, recoveryAfter: time.Minute},
+			nil,
+			nil,
+			producer,
+			nil,
+		)
+	}
+
+	t.Run("completed charge is finished", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		stale := newStale()
+
+		repo := mocks.NewMockPaymentRepository(ctrl)
+		repo.EXPECT().ListStalePending(ctx, gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, createdBefore time.Time, _ models.Transaction, _ int) ([]models.Transaction, error) {
+				// Транзакции моложе RecoveryAfter могут еще ожидать ответа на исходное списание
+				require.WithinDuration(t, time.Now().Add(-time.Minute), createdBefore, time.Second)
+				return []models.Transaction{stale}, nil
+			})
+		repo.EXPECT().UpdateTransaction(ctx, stale.UUID, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, transaction models.Transaction) error {
+				require.Equal(t, models.TransactionStatusCompleted, transaction.Status)
+				return nil
+			})
+
+		ledger := mocks.NewMockLedgerRepository(ctrl)
+		ledger.EXPECT().PostEntry(ctx, gomock.Any()).Return(nil)
+
+		paymentProvider := mocks.NewMockPaymentProvider(ctrl)
+		paymentProvider.EXPECT().Status(gomock.Any(), stale.UUID).
+			Return(models.ChargeResult{Status: models.TransactionStatusCompleted}, nil)
+
+		producer := mocks.NewMockPaymentProducerService(ctrl)
+		producer.EXPECT().SendPaymentCompleted(ctx, gomock.Any()).Return(nil)
+
+		uc := newUseCase(ctrl, repo, ledger, paymentProvider, producer)
+
+		recovered, err := uc.RecoverPendingCharges(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 1, recovered)
+	})
+
+	t.Run("charge awaiting confirmation stays pending", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		stale := newStale()
+
+		repo := mocks.NewMockPaymentRepository(ctrl)
+		repo.EXPECT().ListStalePending(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return([]models.Transaction{stale}, nil)
+
+		paymentProvider := mocks.NewMockPaymentProvider(ctrl)
+		paymentProvider.EXPECT().Status(gomock.Any(), stale.UUID).
+			Return(models.ChargeResult{Status: models.TransactionStatusPending}, nil)
+
+		uc := newUseCase(ctrl, repo, mocks.NewMockLedgerRepository(ctrl), paymentProvider, mocks.NewMockPaymentProducerService(ctrl))
+
+		recovered, err := uc.RecoverPendingCharges(ctx)
+		require.NoError(t, err)
+		require.Zero(t, recovered)
+	})
+
+	t.Run("transactions awaiting confirmation do not hide later ones", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		// Полная пачка ожидающих подтверждения покупателем, за ней - транзакция с известным исходом
+		awaiting := make([]models.Transaction, 0, 100)
+		for range 100 {
+			awaiting = append(awaiting, newStale())
+		}
+		completed := newStale()
+
+		repo := mocks.NewMockPaymentRepository(ctrl)
+		gomock.InOrder(
+			repo.EXPECT().ListStalePending(ctx, gomock.Any(), models.Transaction{}, gomock.Any()).Return(awaiting, nil),
+			repo.EXPECT().ListStalePending(ctx, gomock.Any(), awaiting[len(awaiting)-1], gomock.Any()).Return([]models.Transaction{completed}, nil),
+		)
+		repo.EXPECT().UpdateTransaction(ctx, completed.UUID, gomock.Any()).Return(nil)
+
+		ledger := mocks.NewMockLedgerRepository(ctrl)
+		ledger.EXPECT().PostEntry(ctx, gomock.Any()).Return(nil)
+
+		paymentProvider := mocks.NewMockPaymentProvider(ctrl)
+		paymentProvider.EXPECT().Status(gomock.Any(), gomock.Not(completed.UUID)).
+			Return(models.ChargeResult{Status: models.TransactionStatusPending}, nil).Times(len(awaiting))
+		paymentProvider.EXPECT().Status(gomock.Any(), completed.UUID).
+			Return(models.ChargeResult{Status: models.TransactionStatusCompleted}, nil)
+
+		producer := mocks.NewMockPaymentProducerService(ctrl)
+		producer.EXPECT().SendPaymentCompleted(ctx, gomock.Any()).Return(nil)
+
+		uc := newUseCase(ctrl, repo, ledger, paymentProvider, producer)
+
+		recovered, err := uc.RecoverPendingCharges(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 1, recovered)
+	})
+
+	t.Run("status error is retried on next run", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		failed, completed := newStale(), newStale()
+
+		repo := mocks.NewMockPaymentRepository(ctrl)
+		repo.EXPECT().ListStalePending(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return([]models.Transaction{failed, completed}, nil)
+		repo.EXPECT().UpdateTransaction(ctx, completed.UUID, gomock.Any()).Return(nil)
+
+		ledger := mocks.NewMockLedgerRepository(ctrl)
+		ledger.EXPECT().PostEntry(ctx, gomock.Any()).Return(nil)
+
+		paymentProvider := mocks.NewMockPaymentProvider(ctrl)
+		paymentProvider.EXPECT().Status(gomock.Any(), failed.UUID).Return(models.ChargeResult{}, context.DeadlineExceeded)
+		paymentProvider.EXPECT().Status(gomock.Any(), completed.UUID).
+			Return(models.ChargeResult{Status: models.TransactionStatusCompleted}, nil)
+
+		producer := mocks.NewMockPaymentProducerService(ctrl)
+		producer.EXPECT().SendPaymentCompleted(ctx, gomock.Any()).Return(nil)
+
+		uc := newUseCase(ctrl, repo, ledger, paymentProvider, producer)
+
+		recovered, err := uc.RecoverPendingCharges(ctx)
+		require.NoError(t, err)
+		require.Equal(t, 1, recovered)
+	})
+
+	t.Run("charge unknown to provider is sent again with the same uuid", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		stale := newStale()
+		charged := make(chan struct{})
+
+		repo := mocks.NewMockPaymentRepository(ctrl)
+		repo.EXPECT().ListStalePending(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return([]models.Transaction{stale}, nil).Times(2)
+		repo.EXPECT().UpdateTransaction(gomock.Any(), stale.UUID, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, transaction models.Transaction) error {
+				require.Equal(t, models.TransactionStatusFailed, transaction.Status)
+				return nil
+			})
+
+		release := make(chan struct{})
+		paymentProvider := mocks.NewMockPaymentProvider(ctrl)
+		paymentProvider.EXPECT().Status(gomock.Any(), stale.UUID).Return(models.ChargeResult{}, apperrors.ErrChargeNotFound)
+		paymentProvider.EXPECT().Charge(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, req models.ChargeRequest) (models.ChargeResult, error) {
+				defer close(charged)
+				require.Equal(t, stale.UUID, req.TransactionUUID)
+				<-release
+				return models.ChargeResult{Status: models.TransactionStatusFailed, DeclineReason: "declined"}, nil
+			})
+
+		producer := mocks.NewMockPaymentProducerService(ctrl)
+		producer.EXPECT().SendPaymentFailed(gomock.Any(), gomock.Any()).Return(nil)
+
+		uc := newUseCase(ctrl, repo, mocks.NewMockLedgerRepository(ctrl), paymentProvider, producer)
+
+		recovered, err := uc.RecoverPendingCharges(ctx)
+		require.NoError(t, err)
+		require.Zero(t, recovered)
+
+		// Пока повторное списание не завершено, транзакция занята и не сверяется повторно
+		recovered, err = uc.RecoverPendingCharges(ctx)
+		require.NoError(t, err)
+		require.Zero(t, recovered)
+
+		close(release)
+		select {
+		case <-charged:
+		case <-time.After(asyncTimeout):
+			t.Fatal("provider was not called")
+		}
+
+		drainCtx, cancel := context.WithTimeout(ctx, asyncTimeout)
+		defer cancel()
+		require.NoError(t, uc.Drain(drainCtx))
+	})
+}
+
+func TestRecoverPendingCharges_Approval(t *testing.T) {
+	ctx := context.Background()
+	if err := logger.Init(ctx, "info", false, false, "", "payment-test"); err != nil {
+		t.Fatalf("failed to init logger: %v", err)
+	}
+
+	approvalUUID := uuid.New().String()
+	stale := models.Transaction{
+		UUID:          uuid.New().String(),
+		OrderUUID:     uuid.New().String(),
+		UserID:        uuid.New().String(),
+		PaymentMethod: models.ApprovalPaymentMethod,
+		Amount:        1500,
+		Status:        models.TransactionStatusPending,
+		ApprovalUUID:  approvalUUID,
+		CreatedAt:     time.Now().Add(-time.Hour),
+	}
+
+	tests := []struct {
+		name          string
+		approval      models.Approval
+		expect        func(repo *mocks.MockPaymentRepository, paymentProvider *mocks.MockPaymentProvider, producer *mocks.MockPaymentProducerService)
+		wantRecovered int
+	}{
+		{
+			name:     "approval still pending is skipped",
+			approval: models.Approval{UUID: approvalUUID, Status: models.ApprovalStatusPending},
+			expect: func(*mocks.MockPaymentRepository, *mocks.MockPaymentProvider, *mocks.MockPaymentProducerService) {
+			},
+		},
+		{
+			name: "rejected approval fails the transaction",
+			approval: models.Approval{
+				UUID:      approvalUUID,
+				Status:    models.ApprovalStatusRejected,
+				Decisions: []models.ApprovalDecision{{ApproverID: "risk", Reason: "limit exceeded"}},
+			},
+			expect: func(repo *mocks.MockPaymentRepository, _ *mocks.MockPaymentProvider, producer *mocks.MockPaymentProducerService) {
+				repo.EXPECT().UpdateTransaction(ctx, stale.UUID, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, transaction models.Transaction) error {
+						require.Equal(t, models.TransactionStatusFailed, transaction.Status)
+						require.Equal(t, "limit exceeded", transaction.FailureReason)
+						return nil
+					})
+				producer.EXPECT().SendPaymentFailed(ctx, gomock.Any()).Return(nil)
+			},
+			wantRecovered: 1,
+		},
+		{
+			name:     "expired approval cancels the transaction",
+			approval: models.Approval{UUID: approvalUUID, Status: models.ApprovalStatusExpired},
+			expect: func(repo *mocks.MockPaymentRepository, _ *mocks.MockPaymentProvider, producer *mocks.MockPaymentProducerService) {
+				repo.EXPECT().UpdateTransaction(ctx, stale.UUID, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, transaction models.Transaction) error {
+						require.Equal(t, models.TransactionStatusCancelled, transaction.Status)
+						return nil
+					})
+				producer.EXPECT().SendPaymentFailed(ctx, gomock.Any()).Return(nil)
+			},
+			wantRecovered: 1,
+		},
+		{
+			name:     "approved payment never sent to provider is charged",
+			approval: models.Approval{UUID: approvalUUID, Status: models.ApprovalStatusApproved},
+			expect: func(repo *mocks.MockPaymentRepository, paymentProvider *mocks.MockPaymentProvider, producer *mocks.MockPaymentProducerService) {
+				paymentProvider.EXPECT().Status(gomock.Any(), stale.UUID).Return(models.ChargeResult{}, apperrors.ErrChargeNotFound)
+				paymentProvider.EXPECT().Charge(gomock.Any(), gomock.Any()).
+					Return(models.ChargeResult{Status: models.TransactionStatusFailed, DeclineReason: "declined"}, nil)
+				repo.EXPECT().UpdateTransaction(gomock.Any(), stale.UUID, gomock.Any()).Return(nil)
+				producer.EXPECT().SendPaymentFailed(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			repo := mocks.NewMockPaymentRepository(ctrl)
+			repo.EXPECT().ListStalePending(ctx, gomock.Any(), gomock.Any(), gomock.Any()).Return([]models.Transaction{stale}, nil)
+
+			approvalRepo := mocks.NewMockApprovalRepository(ctrl)
+			approvalRepo.EXPECT().GetApproval(ctx, approvalUUID).Return(tt.approval, nil)
+
+			paymentProvider := mocks.NewMockPaymentProvider(ctrl)
+			paymentProvider.EXPECT().Name().Return("test-provider").AnyTimes()
+			producer := mocks.NewMockPaymentProducerService(ctrl)
+			tt.expect(repo, paymentProvider, producer)
+
+			uc := usecase.NewUseCase(
+				repo,
+				mocks.NewMockLedgerRepository(ctrl),
+				nil,
+				approvalRepo,
+				provider.NewRegistry(map[payment_v1.PaymentMethod]provider.PaymentProvider{
+					models.ApprovalPaymentMethod: paymentProvider,
+				}),
+				nil,
+				providerConfig{timeout: time.Second, recoveryAfter: time.Minute},
+				nil,
+				nil,
+				producer,
+				nil,
+			)
+
+			recovered, err := uc.RecoverPendingCharges(ctx)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantRecovered, recovered)
+
+			drainCtx, cancel := context.WithTimeout(ctx, asyncTimeout)
+			defer cancel()
+			require.NoError(t, uc.Drain(drainCtx))
+		})
+	}
+}
+
+func TestDrain(t *testing.T) {
+	ctx := context.Background()
+	if err := logger.Init(ctx, "info", false, false, "", "payment-test"); err != nil {
+		t.Fatalf("failed to init logger: %v", err)
+	}
+
+	ctrl := gomock.NewController(t)
+	release := make(chan struct{})
+	charging := make(chan struct{})
+
+	repo := mocks.NewMockPaymentRepository(ctrl)
+	repo.EXPECT().ListTransactions(gomock.Any(), gomock.Any()).Return(nil, nil)
+	repo.EXPECT().CreateTransaction(gomock.Any(), gomock.Any()).Return(nil)
+	repo.EXPECT().UpdateTransaction(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+	mockProvider := mocks.NewMockPaymentProvider(ctrl)
+	mockProvider.EXPECT().Name().Return("test-provider").AnyTimes()
+	mockProvider.EXPECT().Charge(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(context.Context, models.ChargeRequest) (models.ChargeResult, error) {
+			close(charging)
+			<-release
+			return models.ChargeResult{Status: models.TransactionStatusFailed}, nil
+		})
+
+	producer := mocks.NewMockPaymentProducerService(ctrl)
+	producer.EXPECT().SendPaymentFailed(gomock.Any(), gomock.Any()).Return(nil)
+
+	uc := usecase.NewUseCase(
+		repo,
+		mocks.NewMockLedgerRepository(ctrl),
+		nil,
+		nil,
+		provider.NewRegistry(map[payment_v1.PaymentMethod]provider.PaymentProvider{
+			payment_v1.PaymentMethod_PAYMENT_METHOD_CARD: mockProvider,
+		}),
+		approveAll(ctrl),
+		providerConfig{timeout: time.Second},
+		nil,
+		nil,
+		producer,
+		nil,
+	)
+
+	_, err := uc.PayOrder(ctx, uuid.New().String(), uuid.New().String(), payment_v1.PaymentMethod_PAYMENT_METHOD_CARD, 1500, "")
+	require.NoError(t, err)
+	<-charging
+
+	// Остановка сервиса ждет ответа провайдера на начатое списание
+	shortCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, uc.Drain(shortCtx), context.DeadlineExceeded)
+
+	close(release)
+
+	drainCtx, cancelDrain := context.WithTimeout(ctx, asyncTimeout)
+	defer cancelDrain()
+	require.NoError(t, uc.Drain(drainCtx))
+}
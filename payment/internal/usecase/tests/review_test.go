@@ -0,0 +1,237 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/linemk/rocket-shop/payment/internal/entyties/apperrors"
+	"github.com/linemk/rocket-shop/payment/internal/entyties/events"
+	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
+	"github.com/linemk/rocket-shop/payment/internal/mocks"
+	"github.com/linemk/rocket-shop/payment/internal/provider"
+	"github.com/linemk/rocket-shop/payment/internal/usecase"
+	"github.com/linemk/rocket-shop/platform/pkg/logger"
+	payment_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/payment/v1"
+)
+
+func TestPayOrderFraudScreening(t *testing.T) {
+	ctx := context.Background()
+	if err := logger.Init(ctx, "info", false, false, "", "payment-test"); err != nil {
+		t.Fatalf("failed to init logger: %v", err)
+	}
+
+	orderUUID := uuid.New().String()
+	userID := uuid.New().String()
+	amount := 2500000.0
+
+	t.Run("declined payment fails without charging", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		screener := mocks.NewMockScreener(ctrl)
+		screener.EXPECT().Screen(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, check models.FraudCheck) models.FraudVerdict {
+				require.Equal(t, userID, check.UserID)
+				require.Equal(t, amount, check.Amount)
+				return models.FraudVerdict{Decision: models.FraudDecisionDecline, Reasons: []string{"amount"}}
+			})
+
+		repo := mocks.NewMockPaymentRepository(ctrl)
+		repo.EXPECT().ListTransactions(ctx, gomock.Any()).Return(nil, nil)
+		repo.EXPECT().CreateTransaction(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, transaction models.Transaction) error {
+				require.Equal(t, models.FraudDecisionDecline, transaction.FraudDecision)
+				return nil
+			})
+		repo.EXPECT().UpdateTransaction(ctx, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, transaction models.Transaction) error {
+				require.Equal(t, models.TransactionStatusFailed, transaction.Status)
+				return nil
+			})
+
+		producer := mocks.NewMockPaymentProducerService(ctrl)
+		producer.EXPECT().SendPaymentFailed(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, event *events.PaymentFailedEvent) error {
+				require.Equal(t, orderUUID, event.OrderUUID)
+				require.NotEmpty(t, event.Reason)
+				return nil
+			})
+
+		providers := provider.NewRegistry(map[payment_v1.PaymentMethod]provider.PaymentProvider{
+			payment_v1.PaymentMethod_PAYMENT_METHOD_CARD: mocks.NewMockPaymentProvider(ctrl),
+		})
+
+		uc := usecase.NewUseCase(repo, mocks.NewMockLedgerRepository(ctrl), nil, nil, providers, screener, providerConfig{timeout: time.Second}, nil, nil, producer, nil)
+
+		transaction, err := uc.PayOrder(ctx, orderUUID, userID, payment_v1.PaymentMethod_PAYMENT_METHOD_CARD, amount, "")
+		require.NoError(t, err)
+		require.Equal(t, models.TransactionStatusFailed, transaction.Status)
+		require.Equal(t, []string{"amount"}, transaction.FraudReasons)
+	})
+
+	t.Run("payment under review stays pending", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		screener := mocks.NewMockScreener(ctrl)
+		screener.EXPECT().Screen(ctx, gomock.Any()).
+			Return(models.FraudVerdict{Decision: models.FraudDecisionReview, Reasons: []string{"new account"}})
+
+		repo := mocks.NewMockPaymentRepository(ctrl)
+		repo.EXPECT().ListTransactions(ctx, gomock.Any()).Return(nil, nil)
+		repo.EXPECT().CreateTransaction(ctx, gomock.Any()).Return(nil)
+
+		providers := provider.NewRegistry(map[payment_v1.PaymentMethod]provider.PaymentProvider{
+			payment_v1.PaymentMethod_PAYMENT_METHOD_CARD: mocks.NewMockPaymentProvider(ctrl),
+		})
+
+		uc := usecase.NewUseCase(
+			repo,
+			mocks.NewMockLedgerRepository(ctrl),
+			nil,
+			nil,
+			providers,
+			screener,
+			providerConfig{timeout: time.Second},
+			nil,
+			nil,
+			mocks.NewMockPaymentProducerService(ctrl),
+			nil,
+		)
+
+		transaction, err := uc.PayOrder(ctx, orderUUID, userID, payment_v1.PaymentMethod_PAYMENT_METHOD_CARD, amount, "")
+		require.NoError(t, err)
+		require.Equal(t, models.TransactionStatusPending, transaction.Status)
+		require.Equal(t, models.FraudDecisionReview, transaction.FraudDecision)
+	})
+}
+
+func TestReviewTransaction(t *testing.T) {
+	ctx := context.Background()
+	if err := logger.Init(ctx, "info", false, false, "", "payment-test"); err != nil {
+		t.Fatalf("failed to init logger: %v", err)
+	}
+
+	const reviewer = "fraud-analyst"
+
+	transactionUUID := uuid.New().String()
+	inReview := models.Transaction{
+		UUID:          transactionUUID,
+		OrderUUID:     uuid.New().String(),
+		UserID:        uuid.New().String(),
+		PaymentMethod: payment_v1.PaymentMethod_PAYMENT_METHOD_CARD,
+		Amount:        1500,
+		Status:        models.TransactionStatusPending,
+		FraudDecision: models.FraudDecisionReview,
+	}
+
+	t.Run("approved transaction is charged", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		done := make(chan struct{})
+
+		repo := mocks.NewMockPaymentRepository(ctrl)
+		repo.EXPECT().GetTransaction(ctx, transactionUUID).Return(inReview, nil)
+		gomock.InOrder(
+			repo.EXPECT().UpdateTransaction(ctx, transactionUUID, gomock.Any()).
+				DoAndReturn(func(_ context.Context, _ string, transaction models.Transaction) error {
+					require.Equal(t, models.TransactionStatusPending, transaction.Status)
+					require.Equal(t, models.FraudDecisionApprove, transaction.FraudDecision)
+					require.Equal(t, reviewer, transaction.ReviewedBy)
+					return nil
+				}),
+			repo.EXPECT().UpdateTransaction(gomock.Any(), transactionUUID, gomock.Any()).
+				DoAndReturn(func(_ context.Context, _ string, transaction models.Transaction) error {
+					require.Equal(t, models.TransactionStatusCompleted, transaction.Status)
+					return nil
+				}),
+		)
+
+		ledger := mocks.NewMockLedgerRepository(ctrl)
+		ledger.EXPECT().PostEntry(gomock.Any(), gomock.Any()).Return(nil)
+
+		paymentProvider := mocks.NewMockPaymentProvider(ctrl)
+		paymentProvider.EXPECT().Name().Return("test-provider").AnyTimes()
+		paymentProvider.EXPECT().Charge(gomock.Any(), gomock.Any()).
+			Return(models.ChargeResult{Status: models.TransactionStatusCompleted}, nil)
+
+		producer := mocks.NewMockPaymentProducerService(ctrl)
+		producer.EXPECT().SendPaymentCompleted(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, event *events.PaymentCompletedEvent) error {
+				defer close(done)
+				require.Equal(t, transactionUUID, event.TransactionUUID)
+				return nil
+			})
+
+		providers := provider.NewRegistry(map[payment_v1.PaymentMethod]provider.PaymentProvider{
+			payment_v1.PaymentMethod_PAYMENT_METHOD_CARD: paymentProvider,
+		})
+
+		uc := usecase.NewUseCase(repo, ledger, nil, nil, providers, mocks.NewMockScreener(ctrl), providerConfig{timeout: time.Second}, nil, nil, producer, nil)
+
+		transaction, err := uc.ReviewTransaction(ctx, transactionUUID, reviewer, true, "")
+		require.NoError(t, err)
+		require.Equal(t, models.TransactionStatusPending, transaction.Status)
+
+		select {
+		case <-done:
+		case <-time.After(asyncTimeout):
+			t.Fatal("payment was not charged after review")
+		}
+	})
+
+	t.Run("rejected transaction fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		repo := mocks.NewMockPaymentRepository(ctrl)
+		repo.EXPECT().GetTransaction(ctx, transactionUUID).Return(inReview, nil)
+		repo.EXPECT().UpdateTransaction(ctx, transactionUUID, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, transaction models.Transaction) error {
+				require.Equal(t, reviewer, transaction.ReviewedBy)
+				return nil
+			})
+
+		producer := mocks.NewMockPaymentProducerService(ctrl)
+		producer.EXPECT().SendPaymentFailed(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, event *events.PaymentFailedEvent) error {
+				require.Equal(t, "stolen card", event.Reason)
+				return nil
+			})
+
+		uc := usecase.NewUseCase(repo, mocks.NewMockLedgerRepository(ctrl), nil, nil, nil, mocks.NewMockScreener(ctrl), providerConfig{timeout: time.Second}, nil, nil, producer, nil)
+
+		transaction, err := uc.ReviewTransaction(ctx, transactionUUID, reviewer, false, "stolen card")
+		require.NoError(t, err)
+		require.Equal(t, models.TransactionStatusFailed, transaction.Status)
+		require.Equal(t, models.FraudDecisionDecline, transaction.FraudDecision)
+	})
+
+	t.Run("transaction not awaiting review", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		approved := inReview
+		approved.FraudDecision = models.FraudDecisionApprove
+
+		repo := mocks.NewMockPaymentRepository(ctrl)
+		repo.EXPECT().GetTransaction(ctx, transactionUUID).Return(approved, nil)
+
+		uc := usecase.NewUseCase(repo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		_, err := uc.ReviewTransaction(ctx, transactionUUID, reviewer, true, "")
+		require.ErrorIs(t, err, apperrors.ErrTransactionNotInReview)
+	})
+
+	t.Run("unknown transaction", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		repo := mocks.NewMockPaymentRepository(ctrl)
+		repo.EXPECT().GetTransaction(ctx, transactionUUID).Return(models.Transaction{}, apperrors.ErrTransactionNotFound)
+
+		uc := usecase.NewUseCase(repo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		_, err := uc.ReviewTransaction(ctx, transactionUUID, reviewer, true, "")
+		require.ErrorIs(t, err, apperrors.ErrTransactionNotFound)
+	})
+}
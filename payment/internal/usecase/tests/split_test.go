@@ -0,0 +1,382 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/linemk/rocket-shop/payment/internal/entyties/apperrors"
+	"github.com/linemk/rocket-shop/payment/internal/entyties/events"
+	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
+	"github.com/linemk/rocket-shop/payment/internal/mocks"
+	"github.com/linemk/rocket-shop/payment/internal/provider"
+	"github.com/linemk/rocket-shop/payment/internal/usecase"
+	"github.com/linemk/rocket-shop/platform/pkg/logger"
+	payment_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/payment/v1"
+)
+
+// entryRecorder запоминает типы записей, отраженных в книге
+type entryRecorder struct {
+	mu    sync.Mutex
+	types []models.EntryType
+}
+
+func (r *entryRecorder) ledger(ctrl *gomock.Controller) *mocks.MockLedgerRepository {
+	ledger := mocks.NewMockLedgerRepository(ctrl)
+	ledger.EXPECT().PostEntry(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, entry models.JournalEntry) error {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			r.types = append(r.types, entry.Type)
+			return nil
+		}).AnyTimes()
+	return ledger
+}
+
+func (r *entryRecorder) entries() []models.EntryType {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]models.EntryType(nil), r.types...)
+}
+
+func TestPaySplit(t *testing.T) {
+	ctx := context.Background()
+	if err := logger.Init(ctx, "info", false, false, "", "payment-test"); err != nil {
+		t.Fatalf("failed to init logger: %v", err)
+	}
+
+	orderUUID := uuid.New().String()
+	userID := uuid.New().String()
+
+	card := payment_v1.PaymentMethod_PAYMENT_METHOD_CARD
+	sbp := payment_v1.PaymentMethod_PAYMENT_METHOD_SBP
+
+	tenders := []models.Tender{
+		{PaymentMethod: sbp, Amount: 600},
+		{PaymentMethod: card, Amount: 400.5},
+	}
+
+	chargeReturns := func(ctrl *gomock.Controller, result models.ChargeResult) *mocks.MockPaymentProvider {
+		mockProvider := mocks.NewMockPaymentProvider(ctrl)
+		mockProvider.EXPECT().Name().Return("test-provider").AnyTimes()
+		mockProvider.EXPECT().Charge(gomock.Any(), gomock.Any()).Return(result, nil)
+		return mockProvider
+	}
+
+	// waitStatuses ждет, пока части группы получат ожидаемые статусы
+	waitStatuses := func(t *testing.T, repo *transactionStore, want map[payment_v1.PaymentMethod]models.TransactionStatus) {
+		require.Eventually(t, func() bool {
+			transactions, err := repo.ListTransactions(ctx, orderUUID)
+			require.NoError(t, err)
+			for _, transaction := range transactions {
+				if want[transaction.PaymentMethod] != transaction.Status {
+					return false
+				}
+			}
+			return len(transactions) == len(want)
+		}, asyncTimeout, 10*time.Millisecond)
+	}
+
+	t.Run("invalid tenders", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		providers := provider.NewRegistry(map[payment_v1.PaymentMethod]provider.PaymentProvider{
+			card: mocks.NewMockPaymentProvider(ctrl),
+			sbp:  mocks.NewMockPaymentProvider(ctrl),
+		})
+		uc := usecase.NewUseCase(mocks.NewMockPaymentRepository(ctrl), nil, nil, nil, providers, nil, providerConfig{timeout: time.Second}, nil, nil, nil, nil)
+
+		cases := []struct {
+			name    string
+			tenders []models.Tender
+			amount  float64
+			wantErr error
+		}{
+			{name: "single tender", tenders: tenders[:1], amount: 600, wantErr: apperrors.ErrInvalidSplit},
+			{name: "sum differs from amount", tenders: tenders, amount: 1000, wantErr: apperrors.ErrInvalidSplit},
+			{
+				name:    "zero tender",
+				tenders: []models.Tender{{PaymentMethod: sbp, Amount: 1000.5}, {PaymentMethod: card, Amount: 0}},
+				amount:  1000.5,
+				wantErr: apperrors.ErrInvalidSplit,
+			},
+			{
+				name: "unsupported payment method",
+				tenders: []models.Tender{
+					{PaymentMethod: sbp, Amount: 600},
+					{PaymentMethod: payment_v1.PaymentMethod_PAYMENT_METHOD_CREDIT_CARD, Amount: 400.5},
+				},
+				amount:  1000.5,
+				wantErr: apperrors.ErrInvalidPaymentMethod,
+			},
+		}
+
+		for _, tc := range cases {
+			_, _, err := uc.PaySplit(ctx, orderUUID, userID, tc.tenders, tc.amount, "")
+			require.ErrorIs(t, err, tc.wantErr, tc.name)
+		}
+	})
+
+	t.Run("all tenders completed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := newTransactionStore()
+		recorder := &entryRecorder{}
+
+		providers := provider.NewRegistry(map[payment_v1.PaymentMethod]provider.PaymentProvider{
+			card: chargeReturns(ctrl, models.ChargeResult{Status: models.TransactionStatusCompleted}),
+			sbp:  chargeReturns(ctrl, models.ChargeResult{Status: models.TransactionStatusCompleted}),
+		})
+
+		done := make(chan struct{})
+		var splitUUID string
+		var mu sync.Mutex
+		producer := mocks.NewMockPaymentProducerService(ctrl)
+		producer.EXPECT().SendPaymentCompleted(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, event *events.PaymentCompletedEvent) error {
+				defer close(done)
+				mu.Lock()
+				defer mu.Unlock()
+				splitUUID = event.TransactionUUID
+				require.Equal(t, orderUUID, event.OrderUUID)
+				require.Equal(t, 1000.5, event.Amount)
+				require.ElementsMatch(t, []string{sbp.String(), card.String()}, strings.Split(event.PaymentMethod, ","))
+				return nil
+			})
+
+		uc := usecase.NewUseCase(repo, recorder.ledger(ctrl), nil, nil, providers, approveAll(ctrl), providerConfig{timeout: time.Second}, nil, nil, producer, nil)
+
+		gotSplitUUID, transactions, err := uc.PaySplit(ctx, orderUUID, userID, tenders, 1000.5, "")
+		require.NoError(t, err)
+		require.Len(t, transactions, 2)
+		for _, transaction := range transactions {
+			require.Equal(t, gotSplitUUID, transaction.SplitUUID)
+		}
+
+		select {
+		case <-done:
+		case <-time.After(asyncTimeout):
+			t.Fatal("split payment was not completed")
+		}
+
+		mu.Lock()
+		require.Equal(t, gotSplitUUID, splitUUID)
+		mu.Unlock()
+
+		waitStatuses(t, repo, map[payment_v1.PaymentMethod]models.TransactionStatus{
+			sbp:  models.TransactionStatusCompleted,
+			card: models.TransactionStatusCompleted,
+		})
+		require.Equal(t, []models.EntryType{models.EntryTypeCharge, models.EntryTypeCharge}, recorder.entries())
+	})
+
+	t.Run("declined tender refunds charged tender", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := newTransactionStore()
+		recorder := &entryRecorder{}
+
+		cardProvider := chargeReturns(ctrl, models.ChargeResult{Status: models.TransactionStatusCompleted})
+		cardProvider.EXPECT().Refund(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, req models.RefundRequest) error {
+				require.Equal(t, 400.5, req.Amount)
+				require.Equal(t, card, req.PaymentMethod)
+				return nil
+			})
+
+		providers := provider.NewRegistry(map[payment_v1.PaymentMethod]provider.PaymentProvider{
+			card: cardProvider,
+			sbp: chargeReturns(ctrl, models.ChargeResult{
+				Status:        models.TransactionStatusFailed,
+				DeclineReason: "insufficient funds",
+			}),
+		})
+
+		done := make(chan struct{})
+		producer := mocks.NewMockPaymentProducerService(ctrl)
+		producer.EXPECT().SendPaymentFailed(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, event *events.PaymentFailedEvent) error {
+				defer close(done)
+				require.Equal(t, orderUUID, event.OrderUUID)
+				require.Contains(t, event.Reason, "insufficient funds")
+				return nil
+			})
+
+		uc := usecase.NewUseCase(repo, recorder.ledger(ctrl), nil, nil, providers, approveAll(ctrl), providerConfig{timeout: time.Second}, nil, nil, producer, nil)
+
+		splitUUID, _, err := uc.PaySplit(ctx, orderUUID, userID, tenders, 1000.5, "")
+		require.NoError(t, err)
+		require.NotEmpty(t, splitUUID)
+
+		select {
+		case <-done:
+		case <-time.After(asyncTimeout):
+			t.Fatal("split payment was not failed")
+		}
+
+		// Карта могла быть списана как до, так и после отказа СБП: в обоих случаях она возвращается
+		waitStatuses(t, repo, map[payment_v1.PaymentMethod]models.TransactionStatus{
+			sbp:  models.TransactionStatusFailed,
+			card: models.TransactionStatusRefunded,
+		})
+		require.Equal(t, []models.EntryType{models.EntryTypeCharge, models.EntryTypeRefund}, recorder.entries())
+	})
+
+	t.Run("fraud decline cancels remaining tenders", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := newTransactionStore()
+
+		screener := mocks.NewMockScreener(ctrl)
+		screener.EXPECT().Screen(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, check models.FraudCheck) models.FraudVerdict {
+				if check.PaymentMethod == sbp {
+					return models.FraudVerdict{Decision: models.FraudDecisionDecline}
+				}
+				return models.FraudVerdict{Decision: models.FraudDecisionApprove}
+			}).Times(2)
+
+		// Карта отменяется до списания, провайдеры не вызываются
+		providers := provider.NewRegistry(map[payment_v1.PaymentMethod]provider.PaymentProvider{
+			card: mocks.NewMockPaymentProvider(ctrl),
+			sbp:  mocks.NewMockPaymentProvider(ctrl),
+		})
+
+		producer := mocks.NewMockPaymentProducerService(ctrl)
+		producer.EXPECT().SendPaymentFailed(gomock.Any(), gomock.Any()).Return(nil)
+
+		uc := usecase.NewUseCase(repo, nil, nil, nil, providers, screener, providerConfig{timeout: time.Second}, nil, nil, producer, nil)
+
+		splitUUID, transactions, err := uc.PaySplit(ctx, orderUUID, userID, tenders, 1000.5, "")
+		require.NoError(t, err)
+		require.NotEmpty(t, splitUUID)
+		require.Equal(t, models.TransactionStatusFailed, models.SplitStatus(transactions))
+
+		waitStatuses(t, repo, map[payment_v1.PaymentMethod]models.TransactionStatus{
+			sbp:  models.TransactionStatusFailed,
+			card: models.TransactionStatusCancelled,
+		})
+	})
+
+	t.Run("retry with the same key returns the group", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		repo := newTransactionStore()
+		key := uuid.New().String()
+
+		// Части проверяются и отклоняются только при первой попытке
+		screener := mocks.NewMockScreener(ctrl)
+		screener.EXPECT().Screen(gomock.Any(), gomock.Any()).
+			Return(models.FraudVerdict{Decision: models.FraudDecisionDecline}).Times(2)
+
+		providers := provider.NewRegistry(map[payment_v1.PaymentMethod]provider.PaymentProvider{
+			card: mocks.NewMockPaymentProvider(ctrl),
+			sbp:  mocks.NewMockPaymentProvider(ctrl),
+		})
+
+		producer := mocks.NewMockPaymentProducerService(ctrl)
+		producer.EXPECT().SendPaymentFailed(gomock.Any(), gomock.Any()).Return(nil)
+
+		uc := usecase.NewUseCase(repo, nil, nil, nil, providers, screener, providerConfig{timeout: time.Second}, nil, nil, producer, nil)
+
+		splitUUID, transactions, err := uc.PaySplit(ctx, orderUUID, userID, tenders, 1000.5, key)
+		require.NoError(t, err)
+		require.Equal(t, key, splitUUID)
+		require.Len(t, transactions, 2)
+
+		retriedUUID, retried, err := uc.PaySplit(ctx, orderUUID, userID, tenders, 1000.5, key)
+		require.NoError(t, err)
+		require.Equal(t, key, retriedUUID)
+		require.ElementsMatch(t, transactions, retried)
+
+		_, _, err = uc.PaySplit(ctx, orderUUID, userID, tenders, 1000.5, "split-1")
+		require.ErrorIs(t, err, apperrors.ErrInvalidIdempotencyKey)
+	})
+}
+
+func TestCompensateSplits(t *testing.T) {
+	ctx := context.Background()
+	if err := logger.Init(ctx, "info", false, false, "", "payment-test"); err != nil {
+		t.Fatalf("failed to init logger: %v", err)
+	}
+
+	ctrl := gomock.NewController(t)
+	repo := newTransactionStore()
+	recorder := &entryRecorder{}
+
+	card := payment_v1.PaymentMethod_PAYMENT_METHOD_CARD
+	sbp := payment_v1.PaymentMethod_PAYMENT_METHOD_SBP
+	splitUUID := uuid.New().String()
+	orderUUID := uuid.New().String()
+	now := time.Now()
+
+	newTender := func(method payment_v1.PaymentMethod, status models.TransactionStatus, createdAt time.Time) models.Transaction {
+		return models.Transaction{
+			UUID:          uuid.New().String(),
+			OrderUUID:     orderUUID,
+			UserID:        uuid.New().String(),
+			PaymentMethod: method,
+			Amount:        500,
+			Status:        status,
+			SplitUUID:     splitUUID,
+			CreatedAt:     createdAt,
+			UpdatedAt:     createdAt,
+		}
+	}
+
+	// Возврат карты не удался при отказе СБП, поэтому карта осталась списанной
+	charged := newTender(card, models.TransactionStatusCompleted, now)
+	declined := newTender(sbp, models.TransactionStatusFailed, now.Add(time.Second))
+	require.NoError(t, repo.CreateTransaction(ctx, charged))
+	require.NoError(t, repo.CreateTransaction(ctx, declined))
+
+	cardProvider := mocks.NewMockPaymentProvider(ctrl)
+	cardProvider.EXPECT().Name().Return("test-provider").AnyTimes()
+	gomock.InOrder(
+		cardProvider.EXPECT().Refund(gomock.Any(), gomock.Any()).Return(errors.New("gateway unavailable")),
+		cardProvider.EXPECT().Refund(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, req models.RefundRequest) error {
+				require.Equal(t, charged.UUID, req.TransactionUUID)
+				return nil
+			}),
+	)
+
+	uc := usecase.NewUseCase(
+		repo,
+		recorder.ledger(ctrl),
+		nil,
+		nil,
+		provider.NewRegistry(map[payment_v1.PaymentMethod]provider.PaymentProvider{card: cardProvider}),
+		nil,
+		providerConfig{timeout: time.Second},
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	refunded, err := uc.CompensateSplits(ctx)
+	require.NoError(t, err)
+	require.Zero(t, refunded)
+
+	transaction, err := repo.GetTransaction(ctx, charged.UUID)
+	require.NoError(t, err)
+	require.Equal(t, models.TransactionStatusCompleted, transaction.Status)
+
+	refunded, err = uc.CompensateSplits(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, refunded)
+
+	transaction, err = repo.GetTransaction(ctx, charged.UUID)
+	require.NoError(t, err)
+	require.Equal(t, models.TransactionStatusRefunded, transaction.Status)
+	require.Equal(t, []models.EntryType{models.EntryTypeRefund}, recorder.entries())
+
+	// Возвращенная часть больше не выбирается
+	refunded, err = uc.CompensateSplits(ctx)
+	require.NoError(t, err)
+	require.Zero(t, refunded)
+}
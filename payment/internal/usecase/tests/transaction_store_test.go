@@ -0,0 +1,198 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/linemk/rocket-shop/payment/internal/entyties/apperrors"
+	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
+)
+
+// transactionStore хранит транзакции в памяти для тестов, которым нужно хранилище с настоящим состоянием,
+// например при одновременном завершении частей раздельной оплаты
+type transactionStore struct {
+	mu           sync.RWMutex
+	transactions map[string]models.Transaction
+}
+
+func newTransactionStore() *transactionStore {
+	return &transactionStore{
+		transactions: make(map[string]models.Transaction),
+	}
+}
+
+func (r *transactionStore) CreateTransaction(ctx context.Context, transaction models.Transaction) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.transactions[transaction.UUID]; exists {
+		return apperrors.ErrTransactionAlreadyExists
+	}
+
+	r.transactions[transaction.UUID] = transaction
+	return nil
+}
+
+func (r *transactionStore) GetTransaction(ctx context.Context, uuid string) (models.Transaction, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	transaction, exists := r.transactions[uuid]
+	if !exists {
+		return models.Transaction{}, fmt.Errorf("%w: %s", apperrors.ErrTransactionNotFound, uuid)
+	}
+
+	return transaction, nil
+}
+
+func (r *transactionStore) UpdateTransaction(ctx context.Context, uuid string, transaction models.Transaction) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.transactions[uuid]; !exists {
+		return fmt.Errorf("%w: %s", apperrors.ErrTransactionNotFound, uuid)
+	}
+
+	r.transactions[uuid] = transaction
+	return nil
+}
+
+func (r *transactionStore) ListTransactions(ctx context.Context, orderUUID string) ([]models.Transaction, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []models.Transaction
+	for _, transaction := range r.transactions {
+		if transaction.OrderUUID == orderUUID {
+			result = append(result, transaction)
+		}
+	}
+
+	return result, nil
+}
+
+func (r *transactionStore) ListUserTransactions(ctx context.Context, userID string) ([]models.Transaction, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []models.Transaction
+	for _, transaction := range r.transactions {
+		if transaction.UserID == userID {
+			result = append(result, transaction)
+		}
+	}
+
+	return result, nil
+}
+
+func (r *transactionStore) ListAwaitingReview(ctx context.Context) ([]models.Transaction, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []models.Transaction
+	for _, transaction := range r.transactions {
+		if transaction.Status == models.TransactionStatusPending && transaction.FraudDecision == models.FraudDecisionReview {
+			result = append(result, transaction)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+
+	return result, nil
+}
+
+func (r *transactionStore) ListStalePending(ctx context.Context, createdBefore time.Time, after models.Transaction, limit int) ([]models.Transaction, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []models.Transaction
+	for _, transaction := range r.transactions {
+		if transaction.Status != models.TransactionStatusPending ||
+			transaction.FraudDecision == models.FraudDecisionReview ||
+			transaction.InstallmentPlanUUID != "" ||
+			!transaction.CreatedAt.Before(createdBefore) ||
+			!createdAfter(transaction, after) {
+			continue
+		}
+		result = append(result, transaction)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return createdAfter(result[j], result[i])
+	})
+
+	if len(result) > limit {
+		result = result[:limit]
+	}
+
+	return result, nil
+}
+
+func (r *transactionStore) ListUncompensatedTenders(ctx context.Context, after models.Transaction, limit int) ([]models.Transaction, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	failedSplits := make(map[string]struct{})
+	for _, transaction := range r.transactions {
+		switch transaction.Status {
+		case models.TransactionStatusFailed, models.TransactionStatusCancelled, models.TransactionStatusRefunded:
+			if transaction.SplitUUID != "" {
+				failedSplits[transaction.SplitUUID] = struct{}{}
+			}
+		}
+	}
+
+	var result []models.Transaction
+	for _, transaction := range r.transactions {
+		if _, failed := failedSplits[transaction.SplitUUID]; !failed ||
+			transaction.Status != models.TransactionStatusCompleted ||
+			!createdAfter(transaction, after) {
+			continue
+		}
+		result = append(result, transaction)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return createdAfter(result[j], result[i])
+	})
+
+	if len(result) > limit {
+		result = result[:limit]
+	}
+
+	return result, nil
+}
+
+// createdAfter проверяет, следует ли транзакция за after в порядке создания; UUID упорядочивает созданные одновременно
+func createdAfter(transaction, after models.Transaction) bool {
+	if !transaction.CreatedAt.Equal(after.CreatedAt) {
+		return transaction.CreatedAt.After(after.CreatedAt)
+	}
+
+	return transaction.UUID > after.UUID
+}
+
+func (r *transactionStore) AnonymizeUser(ctx context.Context, userID, replacement string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	affected := 0
+	now := time.Now()
+	for uuid, transaction := range r.transactions {
+		if transaction.UserID != userID {
+			continue
+		}
+
+		transaction.UserID = replacement
+		transaction.UpdatedAt = now
+		r.transactions[uuid] = transaction
+		affected++
+	}
+
+	return affected, nil
+}
@@ -2,24 +2,119 @@ package usecase
 
 import (
 	"context"
+	"sync"
+	"time"
 
+	"github.com/linemk/rocket-shop/payment/internal/config"
 	"github.com/linemk/rocket-shop/payment/internal/entyties/models"
+	"github.com/linemk/rocket-shop/payment/internal/fraud"
+	"github.com/linemk/rocket-shop/payment/internal/metrics"
+	"github.com/linemk/rocket-shop/payment/internal/provider"
 	"github.com/linemk/rocket-shop/payment/internal/repository"
+	"github.com/linemk/rocket-shop/payment/internal/service"
 	payment_v1 "github.com/linemk/rocket-shop/shared/pkg/proto/payment/v1"
 )
 
 type PaymentUseCase interface {
-	PayOrder(ctx context.Context, orderUUID, userID string, paymentMethod payment_v1.PaymentMethod) (string, error)
+	// PayOrder создает PENDING транзакцию, проверяет ее антифрод-правилами и запускает списание
+	// через провайдера способа оплаты. Результат списания публикуется событием PaymentCompleted или PaymentFailed.
+	// Транзакция, отправленная на ручную проверку, остается PENDING до вызова ReviewTransaction,
+	// платеж средствами инвестора - до согласования через DecideApproval. Транзакция создается с UUID idempotencyKey;
+	// повтор с тем же ключом, как и оплата заказа с ожидающей или завершенной транзакцией, возвращает существующую транзакцию
+	PayOrder(ctx context.Context, orderUUID, userID string, paymentMethod payment_v1.PaymentMethod, amount float64, idempotencyKey string) (models.Transaction, error)
+	// PaySplit оплачивает заказ несколькими способами: каждая часть проходит как отдельная транзакция PayOrder
+	// и возвращается UUID группы вместе с транзакциями частей. Результат публикуется одним событием PaymentCompleted
+	// или PaymentFailed с UUID группы; при отказе любой части уже списанные части возвращаются.
+	// Группа создается с UUID idempotencyKey, повтор с тем же ключом возвращает существующую группу
+	PaySplit(ctx context.Context, orderUUID, userID string, tenders []models.Tender, amount float64, idempotencyKey string) (string, []models.Transaction, error)
+	// ConfirmTransaction завершает транзакцию, ожидающую подтверждения платежа; подтвердить ее может только плательщик userID
+	ConfirmTransaction(ctx context.Context, transactionUUID, userID string, approved bool, reason string) (models.Transaction, error)
+	// ReviewTransaction одобряет транзакцию, задержанную антифрод-проверкой, и запускает списание, либо отклоняет ее.
+	// Проверяющий reviewerID сохраняется в транзакции
+	ReviewTransaction(ctx context.Context, transactionUUID, reviewerID string, approved bool, reason string) (models.Transaction, error)
+	// ListReviewQueue возвращает транзакции, ожидающие ручной проверки, в порядке создания
+	ListReviewQueue(ctx context.Context) ([]models.Transaction, error)
 	GetTransaction(ctx context.Context, transactionUUID string) (models.Transaction, error)
 	ListTransactions(ctx context.Context, orderUUID string) ([]models.Transaction, error)
+	ListUserTransactions(ctx context.Context, userID string) ([]models.Transaction, error)
+	EraseUser(ctx context.Context, userID string) (int, error)
+	ListLedgerAccounts(ctx context.Context) ([]models.LedgerAccount, error)
+	// GetAccountBalance возвращает обороты и сальдо счета книги
+	GetAccountBalance(ctx context.Context, accountCode string) (models.AccountBalance, error)
+	// GetAccountStatement возвращает выписку по счету за период [from, to); нулевые границы не ограничивают период
+	GetAccountStatement(ctx context.Context, accountCode string, from, to time.Time) (models.AccountStatement, error)
+	// DecideApproval сохраняет решение согласующего по платежу средствами инвестора. При наборе кворума
+	// транзакция передается провайдеру, если кворум уже не набрать - завершается статусом FAILED
+	DecideApproval(ctx context.Context, approvalUUID, approverID string, approved bool, reason string) (models.Approval, error)
+	GetApproval(ctx context.Context, approvalUUID string) (models.Approval, error)
+	// ListPendingApprovals возвращает запросы, ожидающие решения согласующего, в порядке создания
+	ListPendingApprovals(ctx context.Context, approverID string) ([]models.Approval, error)
+	// ExpireApprovals отменяет транзакции, не согласованные до срока, и возвращает их число
+	ExpireApprovals(ctx context.Context) (int, error)
+	// QuoteInstallments рассчитывает графики платежей для всех доступных сроков рассрочки
+	QuoteInstallments(ctx context.Context, amount float64) ([]models.InstallmentQuote, error)
+	// CreateInstallmentPlan создает план рассрочки по кредитной карте и запускает списание первого платежа.
+	// Результат первого платежа публикуется событием InstallmentPaid или PaymentFailed с UUID плана
+	CreateInstallmentPlan(ctx context.Context, orderUUID, userID string, amount float64, installments int) (models.InstallmentPlan, error)
+	GetInstallmentPlan(ctx context.Context, planUUID string) (models.InstallmentPlan, error)
+	// ChargeDueInstallments списывает платежи по графику, срок которых наступил, и возвращает их число
+	ChargeDueInstallments(ctx context.Context) (int, error)
+	// RecoverPendingCharges сверяет с провайдером транзакции, оставшиеся PENDING после списания с неизвестным исходом,
+	// и завершает их по статусу у провайдера либо по итогу согласования. Возвращает число завершенных транзакций
+	RecoverPendingCharges(ctx context.Context) (int, error)
+	// CompensateSplits повторяет возврат списанных частей раздельных оплат, группа которых не прошла,
+	// если при отказе группы возврат не удался. Возвращает число возвращенных частей
+	CompensateSplits(ctx context.Context) (int, error)
+	// Drain дожидается завершения списаний, запущенных в фоне, или отмены контекста; вызывается при остановке сервиса
+	Drain(ctx context.Context) error
 }
 
 type useCase struct {
-	paymentRepository repository.PaymentRepository
+	paymentRepository     repository.PaymentRepository
+	ledgerRepository      repository.LedgerRepository
+	installmentRepository repository.InstallmentRepository
+	approvalRepository    repository.ApprovalRepository
+	providers             *provider.Registry
+	fraudScreener         fraud.Screener
+	providerConfig        config.ProviderConfig
+	installmentConfig     config.InstallmentConfig
+	approvalConfig        config.ApprovalConfig
+	paymentProducer       service.PaymentProducerService
+	metrics               *metrics.PaymentMetrics
+	// inFlight UUID транзакций, по которым ожидается ответ провайдера или принимается решение ручной проверки
+	inFlight sync.Map
+	// charges списания, запущенные в фоне; Drain дожидается их при остановке сервиса
+	charges sync.WaitGroup
+	// splitMu упорядочивает завершение частей раздельной оплаты, чтобы о группе публиковалось одно событие.
+	// Блокировка действует в пределах процесса: транзакции хранятся в PostgreSQL и переживают перезапуск,
+	// но завершение частей одной группы в разных экземплярах сервиса она не упорядочивает
+	splitMu sync.Mutex
 }
 
-func NewUseCase(paymentRepository repository.PaymentRepository) PaymentUseCase {
+func NewUseCase(
+	paymentRepository repository.PaymentRepository,
+	ledgerRepository repository.LedgerRepository,
+	installmentRepository repository.InstallmentRepository,
+	approvalRepository repository.ApprovalRepository,
+	providers *provider.Registry,
+	fraudScreener fraud.Screener,
+	providerConfig config.ProviderConfig,
+	installmentConfig config.InstallmentConfig,
+	approvalConfig config.ApprovalConfig,
+	paymentProducer service.PaymentProducerService,
+	metrics *metrics.PaymentMetrics,
+) PaymentUseCase {
 	return &useCase{
-		paymentRepository: paymentRepository,
+		paymentRepository:     paymentRepository,
+		ledgerRepository:      ledgerRepository,
+		installmentRepository: installmentRepository,
+		approvalRepository:    approvalRepository,
+		providers:             providers,
+		fraudScreener:         fraudScreener,
+		providerConfig:        providerConfig,
+		installmentConfig:     installmentConfig,
+		approvalConfig:        approvalConfig,
+		paymentProducer:       paymentProducer,
+		metrics:               metrics,
 	}
 }
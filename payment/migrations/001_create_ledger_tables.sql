@@ -0,0 +1,95 @@
+-- +goose Up
+-- счета бухгалтерской книги
+CREATE TABLE IF NOT EXISTS ledger_accounts
+(
+    code       VARCHAR(100) PRIMARY KEY,
+    name       VARCHAR(255) NOT NULL,
+    type       VARCHAR(20)  NOT NULL CHECK (type IN ('ASSET', 'LIABILITY', 'EQUITY', 'REVENUE', 'EXPENSE')),
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- записи журнала; пара (transaction_uuid, type) не дает записать один платеж дважды
+CREATE TABLE IF NOT EXISTS ledger_entries
+(
+    uuid             UUID PRIMARY KEY,
+    type             VARCHAR(20) NOT NULL CHECK (type IN ('CHARGE', 'REFUND', 'FEE', 'SETTLEMENT')),
+    transaction_uuid VARCHAR(255),
+    description      TEXT        NOT NULL DEFAULT '',
+    created_at       TIMESTAMPTZ NOT NULL,
+    UNIQUE (transaction_uuid, type)
+);
+
+-- проводки записей; суммы в копейках
+CREATE TABLE IF NOT EXISTS ledger_postings
+(
+    id           BIGSERIAL PRIMARY KEY,
+    entry_uuid   UUID         NOT NULL REFERENCES ledger_entries (uuid),
+    account_code VARCHAR(100) NOT NULL REFERENCES ledger_accounts (code),
+    direction    VARCHAR(6)   NOT NULL CHECK (direction IN ('DEBIT', 'CREDIT')),
+    amount       BIGINT       NOT NULL CHECK (amount > 0),
+    created_at   TIMESTAMPTZ  NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_ledger_postings_entry_uuid ON ledger_postings (entry_uuid);
+CREATE INDEX IF NOT EXISTS idx_ledger_postings_account_created_at ON ledger_postings (account_code, created_at, id);
+
+-- записи и проводки неизменяемы: исправления оформляются новыми записями
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION ledger_forbid_change() RETURNS TRIGGER AS
+$$
+BEGIN
+    RAISE EXCEPTION 'ledger records are immutable';
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER ledger_entries_immutable
+    BEFORE UPDATE OR DELETE
+    ON ledger_entries
+    FOR EACH ROW
+EXECUTE FUNCTION ledger_forbid_change();
+
+CREATE TRIGGER ledger_postings_immutable
+    BEFORE UPDATE OR DELETE
+    ON ledger_postings
+    FOR EACH ROW
+EXECUTE FUNCTION ledger_forbid_change();
+
+-- баланс записи проверяется при фиксации транзакции, когда вставлены все ее проводки
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION ledger_check_entry_balance() RETURNS TRIGGER AS
+$$
+BEGIN
+    IF (SELECT COALESCE(SUM(CASE WHEN direction = 'DEBIT' THEN amount ELSE -amount END), 0)
+        FROM ledger_postings
+        WHERE entry_uuid = NEW.entry_uuid) <> 0 THEN
+        RAISE EXCEPTION 'journal entry % is not balanced', NEW.entry_uuid;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE CONSTRAINT TRIGGER ledger_postings_balanced
+    AFTER INSERT
+    ON ledger_postings
+    DEFERRABLE INITIALLY DEFERRED
+    FOR EACH ROW
+EXECUTE FUNCTION ledger_check_entry_balance();
+
+-- системные счета
+INSERT INTO ledger_accounts (code, name, type)
+VALUES ('provider_clearing:card', 'Расчеты с провайдером: карта', 'ASSET'),
+       ('provider_clearing:sbp', 'Расчеты с провайдером: СБП', 'ASSET'),
+       ('provider_clearing:credit_card', 'Расчеты с провайдером: кредитная карта', 'ASSET'),
+       ('investor_funds', 'Средства инвесторов', 'LIABILITY'),
+       ('sales_revenue', 'Выручка от продаж', 'REVENUE'),
+       ('provider_fees', 'Комиссии платежных провайдеров', 'EXPENSE')
+ON CONFLICT (code) DO NOTHING;
+
+-- +goose Down
+DROP TABLE IF EXISTS ledger_postings;
+DROP TABLE IF EXISTS ledger_entries;
+DROP TABLE IF EXISTS ledger_accounts;
+DROP FUNCTION IF EXISTS ledger_check_entry_balance();
+DROP FUNCTION IF EXISTS ledger_forbid_change();
@@ -0,0 +1,47 @@
+-- +goose Up
+-- планы рассрочки; суммы в копейках
+CREATE TABLE IF NOT EXISTS installment_plans
+(
+    uuid           UUID PRIMARY KEY,
+    order_uuid     VARCHAR(255)  NOT NULL,
+    user_uuid      VARCHAR(255)  NOT NULL,
+    payment_method VARCHAR(50)   NOT NULL,
+    principal      BIGINT        NOT NULL CHECK (principal > 0),
+    monthly_rate   NUMERIC(8, 6) NOT NULL DEFAULT 0,
+    total_amount   BIGINT        NOT NULL CHECK (total_amount >= principal),
+    status         VARCHAR(20)   NOT NULL CHECK (status IN ('ACTIVE', 'COMPLETED', 'CANCELLED')),
+    created_at     TIMESTAMPTZ   NOT NULL,
+    updated_at     TIMESTAMPTZ   NOT NULL
+);
+
+-- у заказа может быть только один действующий план
+CREATE UNIQUE INDEX IF NOT EXISTS idx_installment_plans_active_order
+    ON installment_plans (order_uuid) WHERE status <> 'CANCELLED';
+CREATE INDEX IF NOT EXISTS idx_installment_plans_user_uuid ON installment_plans (user_uuid);
+
+-- график платежей плана
+CREATE TABLE IF NOT EXISTS installments
+(
+    plan_uuid        UUID         NOT NULL REFERENCES installment_plans (uuid),
+    number           INTEGER      NOT NULL CHECK (number > 0),
+    amount           BIGINT       NOT NULL CHECK (amount > 0),
+    due_at           TIMESTAMPTZ  NOT NULL,
+    status           VARCHAR(20)  NOT NULL CHECK (status IN ('SCHEDULED', 'PAID', 'OVERDUE')),
+    transaction_uuid VARCHAR(255) NOT NULL DEFAULT '',
+    attempts         INTEGER      NOT NULL DEFAULT 0,
+    next_attempt_at  TIMESTAMPTZ  NOT NULL,
+    paid_at          TIMESTAMPTZ,
+    failure_reason   TEXT         NOT NULL DEFAULT '',
+    PRIMARY KEY (plan_uuid, number)
+);
+
+-- поиск платежей, которые пора списать
+CREATE INDEX IF NOT EXISTS idx_installments_next_attempt_at
+    ON installments (next_attempt_at) WHERE status <> 'PAID';
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_installments_next_attempt_at;
+DROP TABLE IF EXISTS installments;
+DROP INDEX IF EXISTS idx_installment_plans_user_uuid;
+DROP INDEX IF EXISTS idx_installment_plans_active_order;
+DROP TABLE IF EXISTS installment_plans;
@@ -0,0 +1,38 @@
+-- +goose Up
+-- запросы на согласование платежей средствами инвестора; суммы в копейках
+CREATE TABLE IF NOT EXISTS approvals
+(
+    uuid             UUID PRIMARY KEY,
+    transaction_uuid VARCHAR(255) NOT NULL UNIQUE,
+    order_uuid       VARCHAR(255) NOT NULL,
+    user_uuid        VARCHAR(255) NOT NULL,
+    amount           BIGINT       NOT NULL CHECK (amount >= 0),
+    approvers        TEXT[]       NOT NULL,
+    quorum           INTEGER      NOT NULL CHECK (quorum > 0),
+    status           VARCHAR(20)  NOT NULL CHECK (status IN ('PENDING', 'APPROVED', 'REJECTED', 'EXPIRED')),
+    expires_at       TIMESTAMPTZ  NOT NULL,
+    created_at       TIMESTAMPTZ  NOT NULL,
+    updated_at       TIMESTAMPTZ  NOT NULL
+);
+
+-- поиск просроченных запросов
+CREATE INDEX IF NOT EXISTS idx_approvals_pending_expires_at
+    ON approvals (expires_at) WHERE status = 'PENDING';
+CREATE INDEX IF NOT EXISTS idx_approvals_user_uuid ON approvals (user_uuid);
+
+-- решения согласующих; каждый согласующий принимает решение один раз
+CREATE TABLE IF NOT EXISTS approval_decisions
+(
+    approval_uuid UUID         NOT NULL REFERENCES approvals (uuid),
+    approver_id   VARCHAR(255) NOT NULL,
+    approved      BOOLEAN      NOT NULL,
+    reason        TEXT         NOT NULL DEFAULT '',
+    decided_at    TIMESTAMPTZ  NOT NULL,
+    PRIMARY KEY (approval_uuid, approver_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS approval_decisions;
+DROP INDEX IF EXISTS idx_approvals_user_uuid;
+DROP INDEX IF EXISTS idx_approvals_pending_expires_at;
+DROP TABLE IF EXISTS approvals;
@@ -0,0 +1,35 @@
+-- +goose Up
+-- транзакции оплаты заказов; суммы в копейках, пустая строка означает отсутствие связи
+CREATE TABLE IF NOT EXISTS transactions
+(
+    uuid                  UUID PRIMARY KEY,
+    order_uuid            VARCHAR(255) NOT NULL,
+    user_uuid             VARCHAR(255) NOT NULL,
+    payment_method        VARCHAR(50)  NOT NULL,
+    amount                BIGINT       NOT NULL CHECK (amount >= 0),
+    status                VARCHAR(20)  NOT NULL CHECK (status IN ('PENDING', 'COMPLETED', 'FAILED', 'CANCELLED', 'REFUNDED')),
+    failure_reason        TEXT         NOT NULL DEFAULT '',
+    fraud_decision        VARCHAR(20)  NOT NULL DEFAULT '',
+    fraud_reasons         TEXT[]       NOT NULL DEFAULT '{}',
+    installment_plan_uuid VARCHAR(255) NOT NULL DEFAULT '',
+    approval_uuid         VARCHAR(255) NOT NULL DEFAULT '',
+    split_uuid            VARCHAR(255) NOT NULL DEFAULT '',
+    reviewed_by           VARCHAR(255) NOT NULL DEFAULT '',
+    created_at            TIMESTAMPTZ  NOT NULL,
+    updated_at            TIMESTAMPTZ  NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_transactions_order_uuid ON transactions (order_uuid);
+CREATE INDEX IF NOT EXISTS idx_transactions_user_uuid ON transactions (user_uuid);
+CREATE INDEX IF NOT EXISTS idx_transactions_split_uuid ON transactions (split_uuid) WHERE split_uuid <> '';
+
+-- поиск зависших и ожидающих ручной проверки транзакций
+CREATE INDEX IF NOT EXISTS idx_transactions_pending_created_at
+    ON transactions (created_at, uuid) WHERE status = 'PENDING';
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_transactions_pending_created_at;
+DROP INDEX IF EXISTS idx_transactions_split_uuid;
+DROP INDEX IF EXISTS idx_transactions_user_uuid;
+DROP INDEX IF EXISTS idx_transactions_order_uuid;
+DROP TABLE IF EXISTS transactions;
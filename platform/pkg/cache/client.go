@@ -24,11 +24,26 @@ type Client interface {
 	// Базовые операции
 	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
 	Get(ctx context.Context, key string) ([]byte, error)
+	// GetDel атомарно возвращает значение и удаляет ключ
+	GetDel(ctx context.Context, key string) ([]byte, error)
 	Del(ctx context.Context, keys ...string) error
 	Exists(ctx context.Context, key string) (bool, error)
+	// SetNX сохраняет значение, только если ключ еще не существует, и сообщает, было ли оно сохранено
+	SetNX(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error)
+	// Incr атомарно увеличивает числовое значение ключа на единицу и возвращает новое значение;
+	// отсутствующий ключ считается равным нулю и создается без времени жизни
+	Incr(ctx context.Context, key string) (int64, error)
+	// Expire обновляет время жизни ключа
+	Expire(ctx context.Context, key string, ttl time.Duration) error
 
 	// Set операции
 	SetOperator() SetOperator
+
+	// Sorted set операции
+	SortedSetOperator() SortedSetOperator
+
+	// Pub/Sub операции
+	PubSubOperator() PubSubOperator
 }
 
 // SetOperator интерфейс для работы с множествами (Sets)
@@ -45,3 +60,25 @@ type SetOperator interface {
 	// SCard возвращает количество элементов в множестве
 	SCard(ctx context.Context, key string) (int64, error)
 }
+
+// SortedSetOperator интерфейс для работы с упорядоченными множествами (Sorted Sets)
+type SortedSetOperator interface {
+	// ZAdd добавляет элемент с весом score или обновляет вес существующего элемента
+	ZAdd(ctx context.Context, key string, score float64, member string) error
+
+	// ZRemRangeByScore удаляет элементы с весом в диапазоне [min, max]
+	ZRemRangeByScore(ctx context.Context, key string, minScore, maxScore float64) error
+
+	// ZCard возвращает количество элементов в упорядоченном множестве
+	ZCard(ctx context.Context, key string) (int64, error)
+}
+
+// PubSubOperator интерфейс для обмена сообщениями через Pub/Sub
+type PubSubOperator interface {
+	// Publish публикует сообщение в канал
+	Publish(ctx context.Context, channel string, message []byte) error
+
+	// Subscribe подписывается на канал и вызывает handler для каждого сообщения.
+	// Блокирует выполнение до отмены контекста
+	Subscribe(ctx context.Context, channel string, handler func(message []byte)) error
+}
@@ -14,8 +14,10 @@ var ErrKeyNotFound = errors.New("key not found")
 
 // client реализация cache.Client для Redis
 type client struct {
-	rdb         *redis.Client
-	setOperator cache.SetOperator
+	rdb               *redis.Client
+	setOperator       cache.SetOperator
+	sortedSetOperator cache.SortedSetOperator
+	pubSubOperator    cache.PubSubOperator
 }
 
 // NewClient создает новый Redis клиент
@@ -35,6 +37,8 @@ func NewClient(cfg cache.Config) (cache.Client, error) {
 	}
 
 	c.setOperator = NewSetOperator(rdb)
+	c.sortedSetOperator = NewSortedSetOperator(rdb)
+	c.pubSubOperator = NewPubSubOperator(rdb)
 
 	return c, nil
 }
@@ -63,6 +67,18 @@ func (c *client) Get(ctx context.Context, key string) ([]byte, error) {
 	return result, nil
 }
 
+func (c *client) GetDel(ctx context.Context, key string) ([]byte, error) {
+	result, err := c.rdb.GetDel(ctx, key).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, ErrKeyNotFound
+		}
+		return nil, errors.Wrap(err, "failed to get and delete value")
+	}
+
+	return result, nil
+}
+
 func (c *client) Del(ctx context.Context, keys ...string) error {
 	return c.rdb.Del(ctx, keys...).Err()
 }
@@ -76,6 +92,36 @@ func (c *client) Exists(ctx context.Context, key string) (bool, error) {
 	return result > 0, nil
 }
 
+func (c *client) SetNX(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	result, err := c.rdb.SetNX(ctx, key, value, ttl).Result()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to set value if not exists")
+	}
+
+	return result, nil
+}
+
+func (c *client) Incr(ctx context.Context, key string) (int64, error) {
+	result, err := c.rdb.Incr(ctx, key).Result()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to increment value")
+	}
+
+	return result, nil
+}
+
+func (c *client) Expire(ctx context.Context, key string, ttl time.Duration) error {
+	return c.rdb.Expire(ctx, key, ttl).Err()
+}
+
 func (c *client) SetOperator() cache.SetOperator {
 	return c.setOperator
 }
+
+func (c *client) SortedSetOperator() cache.SortedSetOperator {
+	return c.sortedSetOperator
+}
+
+func (c *client) PubSubOperator() cache.PubSubOperator {
+	return c.pubSubOperator
+}
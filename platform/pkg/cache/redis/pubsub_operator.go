@@ -0,0 +1,56 @@
+package redis
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/linemk/rocket-shop/platform/pkg/cache"
+)
+
+// pubSubOperator реализация cache.PubSubOperator для Redis
+type pubSubOperator struct {
+	rdb *redis.Client
+}
+
+// NewPubSubOperator создает новый PubSubOperator
+func NewPubSubOperator(rdb *redis.Client) cache.PubSubOperator {
+	return &pubSubOperator{
+		rdb: rdb,
+	}
+}
+
+func (p *pubSubOperator) Publish(ctx context.Context, channel string, message []byte) error {
+	err := p.rdb.Publish(ctx, channel, message).Err()
+	if err != nil {
+		return errors.Wrap(err, "failed to publish message")
+	}
+
+	return nil
+}
+
+func (p *pubSubOperator) Subscribe(ctx context.Context, channel string, handler func(message []byte)) error {
+	sub := p.rdb.Subscribe(ctx, channel)
+	defer func() {
+		_ = sub.Close() //nolint:gosec // best-effort close
+	}()
+
+	// Дожидаемся подтверждения подписки, чтобы не потерять сообщения, опубликованные сразу после вызова
+	if _, err := sub.Receive(ctx); err != nil {
+		return errors.Wrap(err, "failed to subscribe to channel")
+	}
+
+	messages := sub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg, ok := <-messages:
+			if !ok {
+				return errors.New("subscription channel closed")
+			}
+			handler([]byte(msg.Payload))
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package redis
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/linemk/rocket-shop/platform/pkg/cache"
+)
+
+// sortedSetOperator реализация cache.SortedSetOperator для Redis
+type sortedSetOperator struct {
+	rdb *redis.Client
+}
+
+// NewSortedSetOperator создает новый SortedSetOperator
+func NewSortedSetOperator(rdb *redis.Client) cache.SortedSetOperator {
+	return &sortedSetOperator{
+		rdb: rdb,
+	}
+}
+
+func (s *sortedSetOperator) ZAdd(ctx context.Context, key string, score float64, member string) error {
+	return s.rdb.ZAdd(ctx, key, redis.Z{Score: score, Member: member}).Err()
+}
+
+func (s *sortedSetOperator) ZRemRangeByScore(ctx context.Context, key string, minScore, maxScore float64) error {
+	return s.rdb.ZRemRangeByScore(
+		ctx,
+		key,
+		strconv.FormatFloat(minScore, 'f', -1, 64),
+		strconv.FormatFloat(maxScore, 'f', -1, 64),
+	).Err()
+}
+
+func (s *sortedSetOperator) ZCard(ctx context.Context, key string) (int64, error) {
+	result, err := s.rdb.ZCard(ctx, key).Result()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get sorted set cardinality")
+	}
+
+	return result, nil
+}
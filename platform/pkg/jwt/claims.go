@@ -0,0 +1,50 @@
+package jwt
+
+import (
+	"context"
+
+	gojwt "github.com/golang-jwt/jwt/v5"
+)
+
+// Claims содержит claims access токена, выпускаемого IAM
+type Claims struct {
+	gojwt.RegisteredClaims
+
+	// Login логин пользователя
+	Login string `json:"login"`
+	// Roles роли пользователя
+	Roles []string `json:"roles,omitempty"`
+	// SessionUUID идентификатор сессии, для которой выпущен токен
+	SessionUUID string `json:"sid,omitempty"`
+}
+
+// UserUUID возвращает UUID пользователя (claim sub)
+func (c *Claims) UserUUID() string {
+	return c.Subject
+}
+
+// HasRole проверяет наличие роли у пользователя
+func (c *Claims) HasRole(role string) bool {
+	for _, r := range c.Roles {
+		if r == role {
+			return true
+		}
+	}
+
+	return false
+}
+
+type contextKey string
+
+const claimsContextKey contextKey = "jwt-claims"
+
+// ContextWithClaims добавляет claims в контекст
+func ContextWithClaims(ctx context.Context, claims *Claims) context.Context {
+	return context.WithValue(ctx, claimsContextKey, claims)
+}
+
+// ClaimsFromContext извлекает claims из контекста
+func ClaimsFromContext(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(claimsContextKey).(*Claims)
+	return claims, ok
+}
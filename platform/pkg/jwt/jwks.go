@@ -0,0 +1,80 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"encoding/base64"
+	"math/big"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// Algorithm алгоритм подписи access токенов
+	Algorithm = "ES256"
+
+	keyTypeEC  = "EC"
+	curveP256  = "P-256"
+	keyUseSign = "sig"
+
+	// p256CoordinateSize размер координаты точки кривой P-256 в байтах
+	p256CoordinateSize = 32
+)
+
+// JWK публичный ключ в формате JSON Web Key (RFC 7517)
+type JWK struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+}
+
+// JWKS набор публичных ключей (JSON Web Key Set)
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// NewJWK создает JWK из публичного ключа ECDSA P-256
+func NewJWK(kid string, key *ecdsa.PublicKey) JWK {
+	return JWK{
+		Kty: keyTypeEC,
+		Crv: curveP256,
+		X:   base64.RawURLEncoding.EncodeToString(key.X.FillBytes(make([]byte, p256CoordinateSize))),
+		Y:   base64.RawURLEncoding.EncodeToString(key.Y.FillBytes(make([]byte, p256CoordinateSize))),
+		Kid: kid,
+		Use: keyUseSign,
+		Alg: Algorithm,
+	}
+}
+
+// PublicKey восстанавливает публичный ключ ECDSA из JWK
+func (k JWK) PublicKey() (*ecdsa.PublicKey, error) {
+	if k.Kty != keyTypeEC || k.Crv != curveP256 {
+		return nil, errors.Errorf("unsupported key type %s/%s", k.Kty, k.Crv)
+	}
+
+	x, err := base64.RawURLEncoding.DecodeString(k.X)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode x coordinate")
+	}
+
+	y, err := base64.RawURLEncoding.DecodeString(k.Y)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode y coordinate")
+	}
+
+	key := &ecdsa.PublicKey{
+		Curve: elliptic.P256(),
+		X:     new(big.Int).SetBytes(x),
+		Y:     new(big.Int).SetBytes(y),
+	}
+
+	if !key.Curve.IsOnCurve(key.X, key.Y) { //nolint:staticcheck // проверка точки для ключей из внешнего источника
+		return nil, errors.New("invalid public key")
+	}
+
+	return key, nil
+}
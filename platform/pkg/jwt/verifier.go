@@ -0,0 +1,209 @@
+package jwt
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	gojwt "github.com/golang-jwt/jwt/v5"
+	"github.com/pkg/errors"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// minRefreshInterval защищает JWKS от частых перезапросов при потоке токенов с неизвестным kid
+	minRefreshInterval = time.Second
+	// refreshRetryDelay пауза перед повторным запросом JWKS после ошибки, чтобы недоступный эндпоинт
+	// не задерживал на время таймаута каждую проверку токена
+	refreshRetryDelay = 10 * time.Second
+)
+
+var (
+	// ErrInvalidToken возвращается когда токен не прошел проверку
+	ErrInvalidToken = errors.New("invalid token")
+	// ErrUnknownKey возвращается когда токен подписан неизвестным ключом
+	ErrUnknownKey = errors.New("unknown signing key")
+)
+
+// Verifier проверяет access токены
+type Verifier interface {
+	Verify(ctx context.Context, token string) (*Claims, error)
+}
+
+// JWKSVerifier проверяет токены по ключам, опубликованным на JWKS эндпоинте.
+// Ключи кешируются и перезапрашиваются по истечении refreshInterval или при встрече неизвестного kid.
+type JWKSVerifier struct {
+	jwksURL         string
+	issuer          string
+	refreshInterval time.Duration
+	httpClient      *http.Client
+
+	// group объединяет одновременные запросы JWKS в один
+	group singleflight.Group
+
+	mu          sync.RWMutex
+	keys        map[string]*ecdsa.PublicKey
+	lastRefresh time.Time
+	// nextAttempt время, раньше которого JWKS не перезапрашивается, в том числе после ошибки
+	nextAttempt time.Time
+}
+
+// NewJWKSVerifier создает Verifier, загружающий ключи с jwksURL
+func NewJWKSVerifier(jwksURL, issuer string, refreshInterval time.Duration) *JWKSVerifier {
+	return &JWKSVerifier{
+		jwksURL:         jwksURL,
+		issuer:          issuer,
+		refreshInterval: refreshInterval,
+		httpClient:      &http.Client{Timeout: 5 * time.Second},
+		keys:            make(map[string]*ecdsa.PublicKey),
+	}
+}
+
+func (v *JWKSVerifier) Verify(ctx context.Context, token string) (*Claims, error) {
+	claims := &Claims{}
+
+	opts := []gojwt.ParserOption{
+		gojwt.WithValidMethods([]string{Algorithm}),
+		gojwt.WithExpirationRequired(),
+	}
+	if v.issuer != "" {
+		opts = append(opts, gojwt.WithIssuer(v.issuer))
+	}
+
+	_, err := gojwt.ParseWithClaims(token, claims, func(t *gojwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		return v.key(ctx, kid)
+	}, opts...)
+	if err != nil {
+		if errors.Is(err, ErrUnknownKey) {
+			return nil, err
+		}
+		return nil, errors.Wrap(ErrInvalidToken, err.Error())
+	}
+
+	if claims.Subject == "" {
+		return nil, errors.Wrap(ErrInvalidToken, "subject is empty")
+	}
+
+	return claims, nil
+}
+
+// key возвращает публичный ключ по kid, при необходимости обновляя набор ключей
+func (v *JWKSVerifier) key(ctx context.Context, kid string) (*ecdsa.PublicKey, error) {
+	v.mu.RLock()
+	key, ok := v.keys[kid]
+	stale := time.Since(v.lastRefresh) > v.refreshInterval
+	canRefresh := !time.Now().Before(v.nextAttempt)
+	v.mu.RUnlock()
+
+	if ok && !stale {
+		return key, nil
+	}
+
+	if !canRefresh {
+		if ok {
+			return key, nil
+		}
+		return nil, ErrUnknownKey
+	}
+
+	if err := v.refresh(ctx); err != nil {
+		// Если JWKS недоступен, продолжаем работать с уже известным ключом
+		if ok {
+			return key, nil
+		}
+		return nil, err
+	}
+
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	key, ok = v.keys[kid]
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+
+	return key, nil
+}
+
+// refresh перезапрашивает JWKS. Запрос выполняется без блокировки и один на все одновременные проверки;
+// его не отменяет контекст первой проверки, а каждая проверка ждет результат не дольше своего контекста
+func (v *JWKSVerifier) refresh(ctx context.Context) error {
+	result := v.group.DoChan(v.jwksURL, func() (interface{}, error) {
+		return nil, v.fetchAndStore(context.WithoutCancel(ctx))
+	})
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case res := <-result:
+		return res.Err
+	}
+}
+
+// fetchAndStore загружает ключи и заменяет ими набор под блокировкой.
+// После ошибки следующий запрос откладывается на refreshRetryDelay
+func (v *JWKSVerifier) fetchAndStore(ctx context.Context) error {
+	v.mu.RLock()
+	throttled := time.Now().Before(v.nextAttempt)
+	v.mu.RUnlock()
+
+	if throttled {
+		return nil
+	}
+
+	keys, err := v.fetch(ctx)
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	now := time.Now()
+	if err != nil {
+		v.nextAttempt = now.Add(refreshRetryDelay)
+		return err
+	}
+
+	v.keys = keys
+	v.lastRefresh = now
+	v.nextAttempt = now.Add(minRefreshInterval)
+
+	return nil
+}
+
+func (v *JWKSVerifier) fetch(ctx context.Context) (map[string]*ecdsa.PublicKey, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.jwksURL, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create JWKS request")
+	}
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch JWKS")
+	}
+	defer func() {
+		_ = resp.Body.Close() //nolint:gosec // best-effort close
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("unexpected JWKS response status %d", resp.StatusCode)
+	}
+
+	var jwks JWKS
+	if err := json.NewDecoder(resp.Body).Decode(&jwks); err != nil {
+		return nil, errors.Wrap(err, "failed to decode JWKS")
+	}
+
+	keys := make(map[string]*ecdsa.PublicKey, len(jwks.Keys))
+	for _, jwk := range jwks.Keys {
+		key, err := jwk.PublicKey()
+		if err != nil {
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+
+	return keys, nil
+}
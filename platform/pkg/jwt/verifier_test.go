@@ -0,0 +1,180 @@
+package jwt_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	gojwt "github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+
+	"github.com/linemk/rocket-shop/platform/pkg/jwt"
+)
+
+const testIssuer = "rocket-shop-iam"
+
+func newJWKSServer(t *testing.T, jwks *jwt.JWKS) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		require.NoError(t, json.NewEncoder(w).Encode(jwks))
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func signToken(t *testing.T, key *ecdsa.PrivateKey, kid string, expiresAt time.Time) string {
+	t.Helper()
+
+	token := gojwt.NewWithClaims(gojwt.SigningMethodES256, &jwt.Claims{
+		RegisteredClaims: gojwt.RegisteredClaims{
+			Issuer:    testIssuer,
+			Subject:   "user-uuid",
+			ExpiresAt: gojwt.NewNumericDate(expiresAt),
+		},
+		Login: "astronaut",
+		Roles: []string{"user"},
+	})
+	token.Header["kid"] = kid
+
+	signed, err := token.SignedString(key)
+	require.NoError(t, err)
+
+	return signed
+}
+
+// TestJWKSVerifier_Verify проверяет офлайн валидацию токенов по ключам из JWKS
+func TestJWKSVerifier_Verify(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	server := newJWKSServer(t, &jwt.JWKS{Keys: []jwt.JWK{jwt.NewJWK("key-1", &key.PublicKey)}})
+	verifier := jwt.NewJWKSVerifier(server.URL, testIssuer, time.Hour)
+
+	t.Run("valid token", func(t *testing.T) {
+		claims, err := verifier.Verify(context.Background(), signToken(t, key, "key-1", time.Now().Add(time.Minute)))
+		require.NoError(t, err)
+		require.Equal(t, "user-uuid", claims.UserUUID())
+		require.Equal(t, "astronaut", claims.Login)
+		require.True(t, claims.HasRole("user"))
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		_, err := verifier.Verify(context.Background(), signToken(t, key, "key-1", time.Now().Add(-time.Minute)))
+		require.ErrorIs(t, err, jwt.ErrInvalidToken)
+	})
+
+	t.Run("wrong signature", func(t *testing.T) {
+		_, err := verifier.Verify(context.Background(), signToken(t, otherKey, "key-1", time.Now().Add(time.Minute)))
+		require.ErrorIs(t, err, jwt.ErrInvalidToken)
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		_, err := verifier.Verify(context.Background(), signToken(t, key, "key-2", time.Now().Add(time.Minute)))
+		require.ErrorIs(t, err, jwt.ErrUnknownKey)
+	})
+}
+
+// TestJWKSVerifier_UnavailableJWKS проверяет, что недоступный JWKS не запрашивается на каждую проверку токена
+func TestJWKSVerifier_UnavailableJWKS(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	var (
+		requests  atomic.Int32
+		available atomic.Bool
+	)
+	available.Store(true)
+
+	jwks := &jwt.JWKS{Keys: []jwt.JWK{jwt.NewJWK("key-1", &key.PublicKey)}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		if !available.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(jwks))
+	}))
+	t.Cleanup(server.Close)
+
+	verifier := jwt.NewJWKSVerifier(server.URL, testIssuer, time.Nanosecond)
+	token := signToken(t, key, "key-1", time.Now().Add(time.Minute))
+
+	_, err = verifier.Verify(context.Background(), token)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, requests.Load())
+
+	available.Store(false)
+	time.Sleep(1100 * time.Millisecond)
+
+	// Ключ устарел, но JWKS недоступен: проверка продолжает работать с известным ключом
+	_, err = verifier.Verify(context.Background(), token)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, requests.Load())
+
+	// После ошибки JWKS не перезапрашивается до истечения паузы
+	for i := 0; i < 5; i++ {
+		_, err = verifier.Verify(context.Background(), token)
+		require.NoError(t, err)
+		_, err = verifier.Verify(context.Background(), signToken(t, key, "key-2", time.Now().Add(time.Minute)))
+		require.ErrorIs(t, err, jwt.ErrUnknownKey)
+	}
+	require.EqualValues(t, 2, requests.Load())
+}
+
+// TestJWKSVerifier_ConcurrentRefresh проверяет, что одновременные проверки запрашивают JWKS один раз
+func TestJWKSVerifier_ConcurrentRefresh(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	var requests atomic.Int32
+	release := make(chan struct{})
+
+	jwks := &jwt.JWKS{Keys: []jwt.JWK{jwt.NewJWK("key-1", &key.PublicKey)}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		<-release
+		require.NoError(t, json.NewEncoder(w).Encode(jwks))
+	}))
+	t.Cleanup(server.Close)
+
+	verifier := jwt.NewJWKSVerifier(server.URL, testIssuer, time.Hour)
+	token := signToken(t, key, "key-1", time.Now().Add(time.Minute))
+
+	// Проверка, отмененная вызывающим, не отменяет общий запрос JWKS
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = verifier.Verify(cancelled, token)
+	require.Error(t, err)
+
+	var wg sync.WaitGroup
+	errs := make([]error, 10)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = verifier.Verify(context.Background(), token)
+		}(i)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+	require.EqualValues(t, 1, requests.Load())
+}
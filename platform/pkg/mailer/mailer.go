@@ -0,0 +1,15 @@
+package mailer
+
+import "context"
+
+// Message представляет письмо для отправки
+type Message struct {
+	To      []string
+	Subject string
+	Body    string
+}
+
+// Sender интерфейс для отправки писем
+type Sender interface {
+	Send(ctx context.Context, msg Message) error
+}
@@ -0,0 +1,75 @@
+package smtp
+
+import (
+	"context"
+	"fmt"
+	"net/smtp"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/linemk/rocket-shop/platform/pkg/mailer"
+)
+
+// Config содержит параметры подключения к SMTP серверу
+type Config struct {
+	Addr     string
+	From     string
+	Username string
+	Password string
+}
+
+// sender реализация mailer.Sender поверх SMTP
+type sender struct {
+	cfg  Config
+	auth smtp.Auth
+}
+
+// NewSender создает новый SMTP отправитель писем
+func NewSender(cfg Config) mailer.Sender {
+	s := &sender{
+		cfg: cfg,
+	}
+
+	if cfg.Username != "" {
+		host := cfg.Addr
+		if idx := strings.LastIndex(host, ":"); idx != -1 {
+			host = host[:idx]
+		}
+		s.auth = smtp.PlainAuth("", cfg.Username, cfg.Password, host)
+	}
+
+	return s
+}
+
+func (s *sender) Send(ctx context.Context, msg mailer.Message) error {
+	if len(msg.To) == 0 {
+		return errors.New("message has no recipients")
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	err := smtp.SendMail(s.cfg.Addr, s.auth, s.cfg.From, msg.To, buildMessage(s.cfg.From, msg))
+	if err != nil {
+		return errors.Wrap(err, "failed to send email")
+	}
+
+	return nil
+}
+
+// buildMessage формирует тело письма в формате RFC 5322
+func buildMessage(from string, msg mailer.Message) []byte {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(msg.To, ", "))
+	fmt.Fprintf(&b, "Subject: %s\r\n", msg.Subject)
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+
+	return []byte(b.String())
+}
@@ -0,0 +1,48 @@
+package smtp_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/linemk/rocket-shop/platform/pkg/mailer"
+	"github.com/linemk/rocket-shop/platform/pkg/mailer/smtp"
+	"github.com/linemk/rocket-shop/platform/pkg/mailer/smtp/smtptest"
+)
+
+// TestSender_Send проверяет отправку письма через локальный SMTP сервер-заглушку
+func TestSender_Send(t *testing.T) {
+	server, err := smtptest.NewServer()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = server.Close() //nolint:gosec // best-effort shutdown
+	})
+
+	sender := smtp.NewSender(smtp.Config{
+		Addr: server.Addr,
+		From: "noreply@rocket-shop.local",
+	})
+
+	err = sender.Send(context.Background(), mailer.Message{
+		To:      []string{"user@example.com"},
+		Subject: "Сброс пароля",
+		Body:    "Токен: abc123",
+	})
+	require.NoError(t, err)
+
+	messages := server.Messages()
+	require.Len(t, messages, 1)
+	require.Equal(t, "noreply@rocket-shop.local", messages[0].From)
+	require.Equal(t, []string{"user@example.com"}, messages[0].To)
+	require.Contains(t, messages[0].Data, "Subject: Сброс пароля")
+	require.Contains(t, messages[0].Data, "Токен: abc123")
+}
+
+// TestSender_SendWithoutRecipients проверяет, что письмо без получателей не отправляется
+func TestSender_SendWithoutRecipients(t *testing.T) {
+	sender := smtp.NewSender(smtp.Config{Addr: "127.0.0.1:0", From: "noreply@rocket-shop.local"})
+
+	err := sender.Send(context.Background(), mailer.Message{Subject: "test"})
+	require.Error(t, err)
+}
@@ -0,0 +1,166 @@
+// Package smtptest содержит локальный SMTP сервер-заглушку для тестов и локальной разработки.
+// Сервер принимает письма без аутентификации и сохраняет их в памяти.
+package smtptest
+
+import (
+	"net"
+	"net/textproto"
+	"strings"
+	"sync"
+)
+
+// Message представляет письмо, принятое сервером
+type Message struct {
+	From string
+	To   []string
+	Data string
+}
+
+// Server локальный SMTP сервер-заглушка
+type Server struct {
+	// Addr адрес, на котором слушает сервер (host:port)
+	Addr string
+
+	listener net.Listener
+	wg       sync.WaitGroup
+
+	mu       sync.Mutex
+	messages []Message
+}
+
+// NewServer запускает SMTP сервер на случайном локальном порту
+func NewServer() (*Server, error) {
+	return NewServerOn("127.0.0.1:0")
+}
+
+// NewServerOn запускает SMTP сервер на указанном адресе
+func NewServerOn(addr string) (*Server, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Server{
+		Addr:     listener.Addr().String(),
+		listener: listener,
+	}
+
+	s.wg.Add(1)
+	go s.serve()
+
+	return s, nil
+}
+
+// Messages возвращает копию всех принятых писем
+func (s *Server) Messages() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make([]Message, len(s.messages))
+	copy(result, s.messages)
+
+	return result
+}
+
+// Close останавливает сервер
+func (s *Server) Close() error {
+	err := s.listener.Close()
+	s.wg.Wait()
+	return err
+}
+
+func (s *Server) serve() {
+	defer s.wg.Done()
+
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.handle(conn)
+		}()
+	}
+}
+
+func (s *Server) handle(conn net.Conn) {
+	defer func() {
+		_ = conn.Close() //nolint:gosec // best-effort close
+	}()
+
+	tp := textproto.NewConn(conn)
+	reply := func(format string, args ...any) bool {
+		return tp.PrintfLine(format, args...) == nil
+	}
+
+	if !reply("220 smtptest ready") {
+		return
+	}
+
+	var current Message
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+
+		cmd, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(cmd) {
+		case "HELO", "EHLO":
+			reply("250 smtptest")
+		case "MAIL":
+			current = Message{From: extractAddress(arg)}
+			reply("250 OK")
+		case "RCPT":
+			current.To = append(current.To, extractAddress(arg))
+			reply("250 OK")
+		case "DATA":
+			if !reply("354 End data with <CR><LF>.<CR><LF>") {
+				return
+			}
+			data, err := tp.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			current.Data = string(data)
+			s.store(current)
+			current = Message{}
+			reply("250 OK")
+		case "RSET":
+			current = Message{}
+			reply("250 OK")
+		case "NOOP":
+			reply("250 OK")
+		case "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("502 command %s not implemented", cmd)
+		}
+	}
+}
+
+func (s *Server) store(msg Message) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.messages = append(s.messages, msg)
+}
+
+// extractAddress извлекает адрес из аргумента команды вида "FROM:<user@example.com>"
+func extractAddress(arg string) string {
+	_, addr, found := strings.Cut(arg, ":")
+	if !found {
+		addr = arg
+	}
+
+	addr = strings.TrimSpace(addr)
+	if idx := strings.Index(addr, " "); idx != -1 {
+		addr = addr[:idx]
+	}
+
+	return strings.Trim(addr, "<>")
+}
@@ -0,0 +1,64 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/linemk/rocket-shop/platform/pkg/jwt"
+)
+
+const AuthorizationHeader = "authorization"
+
+// UnaryJWTInterceptor возвращает unary interceptor, проверяющий access токен без обращения к IAM
+func UnaryJWTInterceptor(verifier jwt.Verifier) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		claims, err := verifyJWT(ctx, verifier)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(jwt.ContextWithClaims(ctx, claims), req)
+	}
+}
+
+// StreamJWTInterceptor возвращает stream interceptor, проверяющий access токен без обращения к IAM
+func StreamJWTInterceptor(verifier jwt.Verifier) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		claims, err := verifyJWT(ss.Context(), verifier)
+		if err != nil {
+			return err
+		}
+
+		newCtx := jwt.ContextWithClaims(ss.Context(), claims)
+		return handler(srv, &contextWrappedStream{stream: ss, ctx: newCtx})
+	}
+}
+
+func verifyJWT(ctx context.Context, verifier jwt.Verifier) (*jwt.Claims, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "metadata not found")
+	}
+
+	values := md.Get(AuthorizationHeader)
+	if len(values) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "authorization header not found")
+	}
+
+	token, ok := strings.CutPrefix(values[0], "Bearer ")
+	if !ok || token == "" {
+		return nil, status.Error(codes.Unauthenticated, "bearer token not found")
+	}
+
+	claims, err := verifier.Verify(ctx, token)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid access token")
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,124 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/linemk/rocket-shop/shared/pkg/iamclient"
+)
+
+// DefaultPublicMethods методы, доступные без сессии: health check и reflection
+var DefaultPublicMethods = []string{
+	"/grpc.health.v1.Health/",
+	"/grpc.reflection.v1.ServerReflection/",
+	"/grpc.reflection.v1alpha.ServerReflection/",
+}
+
+const userContextKey contextKey = "user"
+
+// SessionResolver получает пользователя по session UUID (например, iamclient.SessionResolver)
+type SessionResolver interface {
+	Resolve(ctx context.Context, sessionUUID string) (*iamclient.User, error)
+}
+
+// UnarySessionInterceptor возвращает unary interceptor, проверяющий сессию через IAM.
+// publicMethods дополняют DefaultPublicMethods; элемент, оканчивающийся на "/", задает префикс сервиса
+func UnarySessionInterceptor(resolver SessionResolver, publicMethods ...string) grpc.UnaryServerInterceptor {
+	allowlist := append(append([]string{}, DefaultPublicMethods...), publicMethods...)
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if matchMethod(info.FullMethod, allowlist) {
+			return handler(ctx, req)
+		}
+
+		newCtx, err := resolveSession(ctx, resolver)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(newCtx, req)
+	}
+}
+
+// UnaryMethodsSessionInterceptor возвращает unary interceptor, проверяющий сессию через IAM только для перечисленных методов.
+// Остальные методы вызываются без проверки; элемент, оканчивающийся на "/", задает префикс сервиса
+func UnaryMethodsSessionInterceptor(resolver SessionResolver, methods ...string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !matchMethod(info.FullMethod, methods) {
+			return handler(ctx, req)
+		}
+
+		newCtx, err := resolveSession(ctx, resolver)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(newCtx, req)
+	}
+}
+
+// StreamSessionInterceptor возвращает stream interceptor, проверяющий сессию через IAM
+func StreamSessionInterceptor(resolver SessionResolver, publicMethods ...string) grpc.StreamServerInterceptor {
+	allowlist := append(append([]string{}, DefaultPublicMethods...), publicMethods...)
+
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if matchMethod(info.FullMethod, allowlist) {
+			return handler(srv, ss)
+		}
+
+		newCtx, err := resolveSession(ss.Context(), resolver)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &contextWrappedStream{stream: ss, ctx: newCtx})
+	}
+}
+
+// UserFromContext извлекает пользователя, проверенного UnarySessionInterceptor/StreamSessionInterceptor
+func UserFromContext(ctx context.Context) (*iamclient.User, bool) {
+	user, ok := ctx.Value(userContextKey).(*iamclient.User)
+	return user, ok
+}
+
+func resolveSession(ctx context.Context, resolver SessionResolver) (context.Context, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "metadata not found")
+	}
+
+	sessionUUIDs := md.Get(SessionUUIDHeader)
+	if len(sessionUUIDs) == 0 || sessionUUIDs[0] == "" {
+		return nil, status.Error(codes.Unauthenticated, "session uuid not found")
+	}
+
+	user, err := resolver.Resolve(ctx, sessionUUIDs[0])
+	if err != nil {
+		if errors.Is(err, iamclient.ErrIAMUnavailable) {
+			return nil, status.Error(codes.Unavailable, "failed to validate session")
+		}
+		return nil, status.Error(codes.Unauthenticated, "invalid session")
+	}
+
+	ctx = context.WithValue(ctx, sessionUUIDContextKey, sessionUUIDs[0])
+	ctx = context.WithValue(ctx, userContextKey, user)
+
+	return ctx, nil
+}
+
+// matchMethod проверяет, входит ли метод в список методов или префиксов сервисов
+func matchMethod(fullMethod string, methods []string) bool {
+	for _, method := range methods {
+		if method == fullMethod || (strings.HasSuffix(method, "/") && strings.HasPrefix(fullMethod, method)) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,117 @@
+package grpc_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	grpcmiddleware "github.com/linemk/rocket-shop/platform/pkg/middleware/grpc"
+	"github.com/linemk/rocket-shop/shared/pkg/iamclient"
+)
+
+const testSessionUUID = "session-uuid"
+
+type resolverFunc func(ctx context.Context, sessionUUID string) (*iamclient.User, error)
+
+func (f resolverFunc) Resolve(ctx context.Context, sessionUUID string) (*iamclient.User, error) {
+	return f(ctx, sessionUUID)
+}
+
+func invoke(t *testing.T, resolver grpcmiddleware.SessionResolver, ctx context.Context, method string) (*iamclient.User, error) {
+	t.Helper()
+
+	var user *iamclient.User
+	interceptor := grpcmiddleware.UnarySessionInterceptor(resolver, "/test.v1.Public/Ping")
+	_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, _ interface{}) (interface{}, error) {
+		user, _ = grpcmiddleware.UserFromContext(ctx)
+		return nil, nil
+	})
+
+	return user, err
+}
+
+func withSession(sessionUUID string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(grpcmiddleware.SessionUUIDHeader, sessionUUID))
+}
+
+func TestUnarySessionInterceptor(t *testing.T) {
+	resolver := resolverFunc(func(_ context.Context, sessionUUID string) (*iamclient.User, error) {
+		switch sessionUUID {
+		case testSessionUUID:
+			return &iamclient.User{UUID: "user-uuid", Login: "pilot", Roles: []string{"user"}}, nil
+		case "iam-down":
+			return nil, iamclient.ErrIAMUnavailable
+		default:
+			return nil, iamclient.ErrInvalidSession
+		}
+	})
+
+	t.Run("valid session", func(t *testing.T) {
+		user, err := invoke(t, resolver, withSession(testSessionUUID), "/inventory.v1.InventoryService/ListParts")
+		require.NoError(t, err)
+		require.Equal(t, "user-uuid", user.UUID)
+		require.True(t, user.HasRole("user"))
+	})
+
+	t.Run("unknown session", func(t *testing.T) {
+		_, err := invoke(t, resolver, withSession("garbage"), "/inventory.v1.InventoryService/ListParts")
+		require.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("missing session", func(t *testing.T) {
+		_, err := invoke(t, resolver, context.Background(), "/inventory.v1.InventoryService/ListParts")
+		require.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("iam unavailable", func(t *testing.T) {
+		_, err := invoke(t, resolver, withSession("iam-down"), "/inventory.v1.InventoryService/ListParts")
+		require.Equal(t, codes.Unavailable, status.Code(err))
+	})
+
+	t.Run("public methods skip validation", func(t *testing.T) {
+		_, err := invoke(t, resolver, context.Background(), "/grpc.health.v1.Health/Check")
+		require.NoError(t, err)
+
+		_, err = invoke(t, resolver, context.Background(), "/test.v1.Public/Ping")
+		require.NoError(t, err)
+	})
+}
+
+func TestUnaryMethodsSessionInterceptor(t *testing.T) {
+	resolver := resolverFunc(func(_ context.Context, sessionUUID string) (*iamclient.User, error) {
+		if sessionUUID == testSessionUUID {
+			return &iamclient.User{UUID: "user-uuid"}, nil
+		}
+		return nil, iamclient.ErrInvalidSession
+	})
+
+	interceptor := grpcmiddleware.UnaryMethodsSessionInterceptor(resolver, "/test.v1.Service/Protected")
+	invokeMethod := func(ctx context.Context, method string) (*iamclient.User, error) {
+		var user *iamclient.User
+		_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, _ interface{}) (interface{}, error) {
+			user, _ = grpcmiddleware.UserFromContext(ctx)
+			return nil, nil
+		})
+		return user, err
+	}
+
+	t.Run("listed method requires session", func(t *testing.T) {
+		_, err := invokeMethod(context.Background(), "/test.v1.Service/Protected")
+		require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+		user, err := invokeMethod(withSession(testSessionUUID), "/test.v1.Service/Protected")
+		require.NoError(t, err)
+		require.Equal(t, "user-uuid", user.UUID)
+	})
+
+	t.Run("other methods skip validation", func(t *testing.T) {
+		user, err := invokeMethod(context.Background(), "/test.v1.Service/Open")
+		require.NoError(t, err)
+		require.Nil(t, user)
+	})
+}
@@ -0,0 +1,31 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/linemk/rocket-shop/platform/pkg/jwt"
+)
+
+const AuthorizationHeader = "Authorization"
+
+// JWTMiddleware возвращает middleware, проверяющий access токен без обращения к IAM
+func JWTMiddleware(verifier jwt.Verifier) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token, ok := strings.CutPrefix(r.Header.Get(AuthorizationHeader), "Bearer ")
+			if !ok || token == "" {
+				http.Error(w, "Missing bearer token", http.StatusUnauthorized)
+				return
+			}
+
+			claims, err := verifier.Verify(r.Context(), token)
+			if err != nil {
+				http.Error(w, "Invalid access token", http.StatusUnauthorized)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(jwt.ContextWithClaims(r.Context(), claims)))
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package prometheus
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// GRPCMetrics holds gRPC server metrics
+type GRPCMetrics struct {
+	requestsTotal   *prometheus.CounterVec
+	requestDuration *prometheus.HistogramVec
+}
+
+// NewGRPCMetrics creates gRPC server metrics
+func NewGRPCMetrics(m *Metrics) *GRPCMetrics {
+	return &GRPCMetrics{
+		requestsTotal: m.NewCounter(
+			"grpc_server_requests_total",
+			"Total gRPC requests handled by the server",
+			[]string{"method", "code"},
+		),
+		requestDuration: m.NewHistogram(
+			"grpc_server_request_duration_seconds",
+			"gRPC request duration in seconds",
+			[]string{"method"},
+			[]float64{0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1, 2, 5},
+		),
+	}
+}
+
+// UnaryServerInterceptor returns gRPC unary server interceptor reporting request count, code and latency per method
+func (g *GRPCMetrics) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		start := time.Now()
+
+		resp, err := handler(ctx, req)
+
+		g.observe(info.FullMethod, start, err)
+
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor returns gRPC stream server interceptor reporting request count, code and latency per method
+func (g *GRPCMetrics) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		start := time.Now()
+
+		err := handler(srv, ss)
+
+		g.observe(info.FullMethod, start, err)
+
+		return err
+	}
+}
+
+func (g *GRPCMetrics) observe(method string, start time.Time, err error) {
+	// status.Code returns OK for nil error and Unknown for non-status errors
+	code := status.Code(err)
+
+	g.requestsTotal.WithLabelValues(method, code.String()).Inc()
+	g.requestDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+}
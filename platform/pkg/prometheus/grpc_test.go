@@ -0,0 +1,46 @@
+package prometheus_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	prommetrics "github.com/linemk/rocket-shop/platform/pkg/prometheus"
+)
+
+func TestGRPCMetricsUnaryServerInterceptor(t *testing.T) {
+	m := prommetrics.New()
+	interceptor := prommetrics.NewGRPCMetrics(m).UnaryServerInterceptor()
+
+	call := func(method string, err error) {
+		_, gotErr := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: method}, func(context.Context, interface{}) (interface{}, error) {
+			return nil, err
+		})
+		require.Equal(t, err, gotErr)
+	}
+
+	call("/test.v1.Service/Get", nil)
+	call("/test.v1.Service/Get", nil)
+	call("/test.v1.Service/Get", status.Error(codes.NotFound, "not found"))
+	call("/test.v1.Service/Pay", errors.New("provider unavailable"))
+
+	expected := `
+# HELP grpc_server_requests_total Total gRPC requests handled by the server
+# TYPE grpc_server_requests_total counter
+grpc_server_requests_total{code="NotFound",method="/test.v1.Service/Get"} 1
+grpc_server_requests_total{code="OK",method="/test.v1.Service/Get"} 2
+grpc_server_requests_total{code="Unknown",method="/test.v1.Service/Pay"} 1
+`
+	require.NoError(t, testutil.GatherAndCompare(m.Registry(), strings.NewReader(expected), "grpc_server_requests_total"))
+
+	count, err := testutil.GatherAndCount(m.Registry(), "grpc_server_request_duration_seconds")
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+}
@@ -33,6 +33,14 @@ func (m *Metrics) NewCounter(name, help string, labels []string) *prometheus.Cou
 	}, labels)
 }
 
+// NewGauge creates new gauge metric with labels
+func (m *Metrics) NewGauge(name, help string, labels []string) *prometheus.GaugeVec {
+	return m.factory.NewGaugeVec(prometheus.GaugeOpts{
+		Name: name,
+		Help: help,
+	}, labels)
+}
+
 // NewHistogram creates new histogram metric with labels
 func (m *Metrics) NewHistogram(name, help string, labels []string, buckets []float64) *prometheus.HistogramVec {
 	return m.factory.NewHistogramVec(prometheus.HistogramOpts{
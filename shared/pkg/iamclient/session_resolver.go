@@ -0,0 +1,151 @@
+package iamclient
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	authv1 "github.com/linemk/rocket-shop/shared/pkg/proto/auth/v1"
+)
+
+var (
+	// ErrInvalidSession сессия не найдена или истекла
+	ErrInvalidSession = errors.New("invalid session")
+	// ErrIAMUnavailable IAM не ответил, проверить сессию не удалось
+	ErrIAMUnavailable = errors.New("iam unavailable")
+)
+
+// User пользователь, которому принадлежит сессия
+type User struct {
+	UUID  string
+	Login string
+	Roles []string
+}
+
+// HasRole проверяет наличие роли у пользователя
+func (u *User) HasRole(role string) bool {
+	for _, r := range u.Roles {
+		if r == role {
+			return true
+		}
+	}
+
+	return false
+}
+
+type cachedUser struct {
+	user      *User
+	expiresAt time.Time
+}
+
+// SessionResolver получает пользователя сессии через IAM Whoami и кеширует успешные ответы на короткое время
+type SessionResolver struct {
+	authSvc    authv1.AuthServiceClient
+	ttl        time.Duration
+	maxEntries int
+
+	mu    sync.Mutex
+	cache map[string]cachedUser
+}
+
+// NewSessionResolver создает резолвер сессий. ttl <= 0 отключает кеширование
+func NewSessionResolver(authSvc authv1.AuthServiceClient, ttl time.Duration, maxEntries int) *SessionResolver {
+	return &SessionResolver{
+		authSvc:    authSvc,
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		cache:      make(map[string]cachedUser),
+	}
+}
+
+// SessionResolver возвращает резолвер сессий поверх AuthService этого клиента
+func (c *Client) SessionResolver(ttl time.Duration, maxEntries int) *SessionResolver {
+	return NewSessionResolver(c.authSvc, ttl, maxEntries)
+}
+
+// Resolve возвращает пользователя сессии. Ошибки не кешируются
+func (r *SessionResolver) Resolve(ctx context.Context, sessionUUID string) (*User, error) {
+	if user, ok := r.get(sessionUUID); ok {
+		return user, nil
+	}
+
+	resp, err := r.authSvc.Whoami(ctx, &authv1.WhoamiRequest{SessionUuid: sessionUUID})
+	if err != nil {
+		switch status.Code(err) {
+		case codes.Unavailable, codes.DeadlineExceeded, codes.Canceled:
+			return nil, errors.Join(ErrIAMUnavailable, err)
+		default:
+			return nil, errors.Join(ErrInvalidSession, err)
+		}
+	}
+
+	if resp.GetUser() == nil {
+		return nil, ErrInvalidSession
+	}
+
+	user := &User{
+		UUID:  resp.GetUser().GetUserUuid(),
+		Login: resp.GetUser().GetLogin(),
+		Roles: resp.GetUser().GetRoles(),
+	}
+
+	r.set(sessionUUID, user)
+
+	return user, nil
+}
+
+func (r *SessionResolver) get(sessionUUID string) (*User, bool) {
+	if r.ttl <= 0 {
+		return nil, false
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cached, ok := r.cache[sessionUUID]
+	if !ok {
+		return nil, false
+	}
+
+	if time.Now().After(cached.expiresAt) {
+		delete(r.cache, sessionUUID)
+		return nil, false
+	}
+
+	return cached.user, true
+}
+
+func (r *SessionResolver) set(sessionUUID string, user *User) {
+	if r.ttl <= 0 {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+
+	if r.maxEntries > 0 && len(r.cache) >= r.maxEntries {
+		// Сначала убираем истекшие записи, при нехватке места — произвольную
+		for key, cached := range r.cache {
+			if now.After(cached.expiresAt) {
+				delete(r.cache, key)
+			}
+		}
+		for key := range r.cache {
+			if len(r.cache) < r.maxEntries {
+				break
+			}
+			delete(r.cache, key)
+		}
+	}
+
+	r.cache[sessionUUID] = cachedUser{
+		user:      user,
+		expiresAt: now.Add(r.ttl),
+	}
+}
@@ -31,8 +31,8 @@ type Invoker interface {
 	// CancelOrder invokes CancelOrder operation.
 	//
 	// Отменяет заказ. Проверяет статус заказа - если
-	// PENDING_PAYMENT, меняет статус на CANCELLED. Если PAID, возвращает
-	// ошибку 409.
+	// PENDING_PAYMENT, меняет статус на CANCELLED. Если PAID или оплата
+	// еще обрабатывается (PAYMENT_PROCESSING), возвращает ошибку 409.
 	//
 	// POST /api/v1/orders/{order_uuid}/cancel
 	CancelOrder(ctx context.Context, params CancelOrderParams) (CancelOrderRes, error)
@@ -44,17 +44,38 @@ type Invoker interface {
 	//
 	// POST /api/v1/orders
 	CreateOrder(ctx context.Context, request OptCreateOrderReq) (CreateOrderRes, error)
+	// GetInstallmentQuotes invokes GetInstallmentQuotes operation.
+	//
+	// Рассчитывает доступные варианты рассрочки по
+	// кредитной карте для стоимости заказа.
+	//
+	// GET /api/v1/orders/{order_uuid}/installments/quotes
+	GetInstallmentQuotes(ctx context.Context, params GetInstallmentQuotesParams) (GetInstallmentQuotesRes, error)
 	// GetOrder invokes GetOrder operation.
 	//
 	// Get order by uuid.
 	//
 	// GET /api/v1/orders/{order_uuid}
 	GetOrder(ctx context.Context, params GetOrderParams) (GetOrderRes, error)
+	// ListUserOrders invokes ListUserOrders operation.
+	//
+	// Возвращает все заказы пользователя. Используется
+	// IAMService для выгрузки данных пользователя.
+	//
+	// GET /api/v1/users/{user_uuid}/orders
+	ListUserOrders(ctx context.Context, params ListUserOrdersParams) (*ListUserOrdersResp, error)
 	// PayOrder invokes PayOrder operation.
 	//
-	// Проводит оплату ранее созданного заказа. Находит
-	// заказ по UUID, вызывает PaymentService для обработки платежа и
-	// обновляет статус заказа.
+	// Запускает оплату ранее созданного заказа. Находит
+	// заказ по UUID, передает платеж в PaymentService и переводит
+	// заказ в статус PAYMENT_PROCESSING. Заказ становится PAID после
+	// события PaymentCompleted или возвращается в PENDING_PAYMENT после
+	// PaymentFailed. При оплате в рассрочку transaction_uuid заказа - UUID
+	// плана рассрочки; после первого платежа заказ
+	// переходит в PARTIALLY_PAID, после последнего - в PAID. При
+	// раздельной оплате (tenders) transaction_uuid заказа - UUID группы
+	// транзакций частей; заказ становится PAID, только если
+	// прошли все части.
 	//
 	// POST /api/v1/orders/{order_uuid}/pay
 	PayOrder(ctx context.Context, request *PayOrderReq, params PayOrderParams) (PayOrderRes, error)
@@ -110,8 +131,8 @@ func (c *Client) requestURL(ctx context.Context) *url.URL {
 // CancelOrder invokes CancelOrder operation.
 //
 // Отменяет заказ. Проверяет статус заказа - если
-// PENDING_PAYMENT, меняет статус на CANCELLED. Если PAID, возвращает
-// ошибку 409.
+// PENDING_PAYMENT, меняет статус на CANCELLED. Если PAID или оплата
+// еще обрабатывается (PAYMENT_PROCESSING), возвращает ошибку 409.
 //
 // POST /api/v1/orders/{order_uuid}/cancel
 func (c *Client) CancelOrder(ctx context.Context, params CancelOrderParams) (CancelOrderRes, error) {
@@ -277,6 +298,98 @@ func (c *Client) sendCreateOrder(ctx context.Context, request OptCreateOrderReq)
 	return result, nil
 }
 
+// GetInstallmentQuotes invokes GetInstallmentQuotes operation.
+//
+// Рассчитывает доступные варианты рассрочки по
+// кредитной карте для стоимости заказа.
+//
+// GET /api/v1/orders/{order_uuid}/installments/quotes
+func (c *Client) GetInstallmentQuotes(ctx context.Context, params GetInstallmentQuotesParams) (GetInstallmentQuotesRes, error) {
+	res, err := c.sendGetInstallmentQuotes(ctx, params)
+	return res, err
+}
+
+func (c *Client) sendGetInstallmentQuotes(ctx context.Context, params GetInstallmentQuotesParams) (res GetInstallmentQuotesRes, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("GetInstallmentQuotes"),
+		semconv.HTTPRequestMethodKey.String("GET"),
+		semconv.HTTPRouteKey.String("/api/v1/orders/{order_uuid}/installments/quotes"),
+	}
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, GetInstallmentQuotesOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [3]string
+	pathParts[0] = "/api/v1/orders/"
+	{
+		// Encode "order_uuid" parameter.
+		e := uri.NewPathEncoder(uri.PathEncoderConfig{
+			Param:   "order_uuid",
+			Style:   uri.PathStyleSimple,
+			Explode: false,
+		})
+		if err := func() error {
+			return e.EncodeValue(conv.UUIDToString(params.OrderUUID))
+		}(); err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		encoded, err := e.Result()
+		if err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		pathParts[1] = encoded
+	}
+	pathParts[2] = "/installments/quotes"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "GET", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeGetInstallmentQuotesResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
 // GetOrder invokes GetOrder operation.
 //
 // Get order by uuid.
@@ -367,11 +480,110 @@ func (c *Client) sendGetOrder(ctx context.Context, params GetOrderParams) (res G
 	return result, nil
 }
 
+// ListUserOrders invokes ListUserOrders operation.
+//
+// Возвращает все заказы пользователя. Используется
+// IAMService для выгрузки данных пользователя.
+//
+// GET /api/v1/users/{user_uuid}/orders
+func (c *Client) ListUserOrders(ctx context.Context, params ListUserOrdersParams) (*ListUserOrdersResp, error) {
+	res, err := c.sendListUserOrders(ctx, params)
+	return res, err
+}
+
+func (c *Client) sendListUserOrders(ctx context.Context, params ListUserOrdersParams) (res *ListUserOrdersResp, err error) {
+	otelAttrs := []attribute.KeyValue{
+		otelogen.OperationID("ListUserOrders"),
+		semconv.HTTPRequestMethodKey.String("GET"),
+		semconv.HTTPRouteKey.String("/api/v1/users/{user_uuid}/orders"),
+	}
+
+	// Run stopwatch.
+	startTime := time.Now()
+	defer func() {
+		// Use floating point division here for higher precision (instead of Millisecond method).
+		elapsedDuration := time.Since(startTime)
+		c.duration.Record(ctx, float64(elapsedDuration)/float64(time.Millisecond), metric.WithAttributes(otelAttrs...))
+	}()
+
+	// Increment request counter.
+	c.requests.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+
+	// Start a span for this request.
+	ctx, span := c.cfg.Tracer.Start(ctx, ListUserOrdersOperation,
+		trace.WithAttributes(otelAttrs...),
+		clientSpanKind,
+	)
+	// Track stage for error reporting.
+	var stage string
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, stage)
+			c.errors.Add(ctx, 1, metric.WithAttributes(otelAttrs...))
+		}
+		span.End()
+	}()
+
+	stage = "BuildURL"
+	u := uri.Clone(c.requestURL(ctx))
+	var pathParts [3]string
+	pathParts[0] = "/api/v1/users/"
+	{
+		// Encode "user_uuid" parameter.
+		e := uri.NewPathEncoder(uri.PathEncoderConfig{
+			Param:   "user_uuid",
+			Style:   uri.PathStyleSimple,
+			Explode: false,
+		})
+		if err := func() error {
+			return e.EncodeValue(conv.UUIDToString(params.UserUUID))
+		}(); err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		encoded, err := e.Result()
+		if err != nil {
+			return res, errors.Wrap(err, "encode path")
+		}
+		pathParts[1] = encoded
+	}
+	pathParts[2] = "/orders"
+	uri.AddPathParts(u, pathParts[:]...)
+
+	stage = "EncodeRequest"
+	r, err := ht.NewRequest(ctx, "GET", u)
+	if err != nil {
+		return res, errors.Wrap(err, "create request")
+	}
+
+	stage = "SendRequest"
+	resp, err := c.cfg.Client.Do(r)
+	if err != nil {
+		return res, errors.Wrap(err, "do request")
+	}
+	defer resp.Body.Close()
+
+	stage = "DecodeResponse"
+	result, err := decodeListUserOrdersResponse(resp)
+	if err != nil {
+		return res, errors.Wrap(err, "decode response")
+	}
+
+	return result, nil
+}
+
 // PayOrder invokes PayOrder operation.
 //
-// Проводит оплату ранее созданного заказа. Находит
-// заказ по UUID, вызывает PaymentService для обработки платежа и
-// обновляет статус заказа.
+// Запускает оплату ранее созданного заказа. Находит
+// заказ по UUID, передает платеж в PaymentService и переводит
+// заказ в статус PAYMENT_PROCESSING. Заказ становится PAID после
+// события PaymentCompleted или возвращается в PENDING_PAYMENT после
+// PaymentFailed. При оплате в рассрочку transaction_uuid заказа - UUID
+// плана рассрочки; после первого платежа заказ
+// переходит в PARTIALLY_PAID, после последнего - в PAID. При
+// раздельной оплате (tenders) transaction_uuid заказа - UUID группы
+// транзакций частей; заказ становится PAID, только если
+// прошли все части.
 //
 // POST /api/v1/orders/{order_uuid}/pay
 func (c *Client) PayOrder(ctx context.Context, request *PayOrderReq, params PayOrderParams) (PayOrderRes, error) {
@@ -9,6 +9,10 @@ type CreateOrderRes interface {
 	createOrderRes()
 }
 
+type GetInstallmentQuotesRes interface {
+	getInstallmentQuotesRes()
+}
+
 type GetOrderRes interface {
 	getOrderRes()
 }
@@ -6,8 +6,10 @@ package order_v1
 type OperationName = string
 
 const (
-	CancelOrderOperation OperationName = "CancelOrder"
-	CreateOrderOperation OperationName = "CreateOrder"
-	GetOrderOperation    OperationName = "GetOrder"
-	PayOrderOperation    OperationName = "PayOrder"
+	CancelOrderOperation          OperationName = "CancelOrder"
+	CreateOrderOperation          OperationName = "CreateOrder"
+	GetInstallmentQuotesOperation OperationName = "GetInstallmentQuotes"
+	GetOrderOperation             OperationName = "GetOrder"
+	ListUserOrdersOperation       OperationName = "ListUserOrders"
+	PayOrderOperation             OperationName = "PayOrder"
 )
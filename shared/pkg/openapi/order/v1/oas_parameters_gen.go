@@ -83,6 +83,73 @@ func decodeCancelOrderParams(args [1]string, argsEscaped bool, r *http.Request)
 	return params, nil
 }
 
+// GetInstallmentQuotesParams is parameters of GetInstallmentQuotes operation.
+type GetInstallmentQuotesParams struct {
+	// UUID заказа, для которого запрашиваются или
+	// обновляются данные.
+	OrderUUID uuid.UUID
+}
+
+func unpackGetInstallmentQuotesParams(packed middleware.Parameters) (params GetInstallmentQuotesParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "order_uuid",
+			In:   "path",
+		}
+		params.OrderUUID = packed[key].(uuid.UUID)
+	}
+	return params
+}
+
+func decodeGetInstallmentQuotesParams(args [1]string, argsEscaped bool, r *http.Request) (params GetInstallmentQuotesParams, _ error) {
+	// Decode path: order_uuid.
+	if err := func() error {
+		param := args[0]
+		if argsEscaped {
+			unescaped, err := url.PathUnescape(args[0])
+			if err != nil {
+				return errors.Wrap(err, "unescape path")
+			}
+			param = unescaped
+		}
+		if len(param) > 0 {
+			d := uri.NewPathDecoder(uri.PathDecoderConfig{
+				Param:   "order_uuid",
+				Value:   param,
+				Style:   uri.PathStyleSimple,
+				Explode: false,
+			})
+
+			if err := func() error {
+				val, err := d.DecodeValue()
+				if err != nil {
+					return err
+				}
+
+				c, err := conv.ToUUID(val)
+				if err != nil {
+					return err
+				}
+
+				params.OrderUUID = c
+				return nil
+			}(); err != nil {
+				return err
+			}
+		} else {
+			return validate.ErrFieldRequired
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "order_uuid",
+			In:   "path",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
 // GetOrderParams is parameters of GetOrder operation.
 type GetOrderParams struct {
 	// UUID заказа, для которого запрашиваются или
@@ -150,6 +217,72 @@ func decodeGetOrderParams(args [1]string, argsEscaped bool, r *http.Request) (pa
 	return params, nil
 }
 
+// ListUserOrdersParams is parameters of ListUserOrders operation.
+type ListUserOrdersParams struct {
+	// UUID пользователя, для которого запрашиваются данные.
+	UserUUID uuid.UUID
+}
+
+func unpackListUserOrdersParams(packed middleware.Parameters) (params ListUserOrdersParams) {
+	{
+		key := middleware.ParameterKey{
+			Name: "user_uuid",
+			In:   "path",
+		}
+		params.UserUUID = packed[key].(uuid.UUID)
+	}
+	return params
+}
+
+func decodeListUserOrdersParams(args [1]string, argsEscaped bool, r *http.Request) (params ListUserOrdersParams, _ error) {
+	// Decode path: user_uuid.
+	if err := func() error {
+		param := args[0]
+		if argsEscaped {
+			unescaped, err := url.PathUnescape(args[0])
+			if err != nil {
+				return errors.Wrap(err, "unescape path")
+			}
+			param = unescaped
+		}
+		if len(param) > 0 {
+			d := uri.NewPathDecoder(uri.PathDecoderConfig{
+				Param:   "user_uuid",
+				Value:   param,
+				Style:   uri.PathStyleSimple,
+				Explode: false,
+			})
+
+			if err := func() error {
+				val, err := d.DecodeValue()
+				if err != nil {
+					return err
+				}
+
+				c, err := conv.ToUUID(val)
+				if err != nil {
+					return err
+				}
+
+				params.UserUUID = c
+				return nil
+			}(); err != nil {
+				return err
+			}
+		} else {
+			return validate.ErrFieldRequired
+		}
+		return nil
+	}(); err != nil {
+		return params, &ogenerrors.DecodeParamError{
+			Name: "user_uuid",
+			In:   "path",
+			Err:  err,
+		}
+	}
+	return params, nil
+}
+
 // PayOrderParams is parameters of PayOrder operation.
 type PayOrderParams struct {
 	// UUID заказа, для которого запрашиваются или
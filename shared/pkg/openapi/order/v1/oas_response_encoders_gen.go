@@ -85,6 +85,39 @@ func encodeCreateOrderResponse(response CreateOrderRes, w http.ResponseWriter, s
 	}
 }
 
+func encodeGetInstallmentQuotesResponse(response GetInstallmentQuotesRes, w http.ResponseWriter, span trace.Span) error {
+	switch response := response.(type) {
+	case *GetInstallmentQuotesResp:
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(200)
+		span.SetStatus(codes.Ok, http.StatusText(200))
+
+		e := new(jx.Encoder)
+		response.Encode(e)
+		if _, err := e.WriteTo(w); err != nil {
+			return errors.Wrap(err, "write")
+		}
+
+		return nil
+
+	case *NotFoundErr:
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(404)
+		span.SetStatus(codes.Error, http.StatusText(404))
+
+		e := new(jx.Encoder)
+		response.Encode(e)
+		if _, err := e.WriteTo(w); err != nil {
+			return errors.Wrap(err, "write")
+		}
+
+		return nil
+
+	default:
+		return errors.Errorf("unexpected response type: %T", response)
+	}
+}
+
 func encodeGetOrderResponse(response GetOrderRes, w http.ResponseWriter, span trace.Span) error {
 	switch response := response.(type) {
 	case *GetOrderResp:
@@ -118,6 +151,20 @@ func encodeGetOrderResponse(response GetOrderRes, w http.ResponseWriter, span tr
 	}
 }
 
+func encodeListUserOrdersResponse(response *ListUserOrdersResp, w http.ResponseWriter, span trace.Span) error {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(200)
+	span.SetStatus(codes.Ok, http.StatusText(200))
+
+	e := new(jx.Encoder)
+	response.Encode(e)
+	if _, err := e.WriteTo(w); err != nil {
+		return errors.Wrap(err, "write")
+	}
+
+	return nil
+}
+
 func encodePayOrderResponse(response PayOrderRes, w http.ResponseWriter, span trace.Span) error {
 	switch response := response.(type) {
 	case *PayOrderResp:
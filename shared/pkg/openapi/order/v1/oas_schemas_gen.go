@@ -138,6 +138,25 @@ func (s *CreateOrderResp) SetTotalPrice(val float32) {
 
 func (*CreateOrderResp) createOrderRes() {}
 
+// Ref: #/components/schemas/get_installment_quotes_resp
+type GetInstallmentQuotesResp struct {
+	// Доступные варианты рассрочки по возрастанию числа
+	// платежей.
+	Quotes []InstallmentQuote `json:"quotes"`
+}
+
+// GetQuotes returns the value of Quotes.
+func (s *GetInstallmentQuotesResp) GetQuotes() []InstallmentQuote {
+	return s.Quotes
+}
+
+// SetQuotes sets the value of Quotes.
+func (s *GetInstallmentQuotesResp) SetQuotes(val []InstallmentQuote) {
+	s.Quotes = val
+}
+
+func (*GetInstallmentQuotesResp) getInstallmentQuotesRes() {}
+
 // Ref: #/components/schemas/get_order_resp
 type GetOrderResp struct {
 	// UUID заказа.
@@ -148,10 +167,19 @@ type GetOrderResp struct {
 	PartUuids []uuid.UUID `json:"part_uuids"`
 	// Общая цена заказа.
 	TotalPrice float32 `json:"total_price"`
-	// UUID транзакции.
-	TransactionUUID uuid.UUID     `json:"transaction_uuid"`
-	PaymentMethod   PaymentMethod `json:"payment_method"`
-	Status          OrderStatus   `json:"status"`
+	// UUID транзакции, плана рассрочки или группы транзакций
+	// раздельной оплаты.
+	TransactionUUID uuid.UUID `json:"transaction_uuid"`
+	// Способ оплаты заказа; при раздельной оплате -
+	// PAYMENT_METHOD_UNSPECIFIED, способы перечислены в tenders.
+	PaymentMethod PaymentMethod `json:"payment_method"`
+	Status        OrderStatus   `json:"status"`
+	// Число платежей рассрочки, 0 - заказ оплачивается
+	// целиком.
+	Installments OptInt `json:"installments"`
+	// Части раздельной оплаты; пусто, если заказ
+	// оплачивается одним способом.
+	Tenders []Tender `json:"tenders"`
 }
 
 // GetOrderUUID returns the value of OrderUUID.
@@ -189,6 +217,16 @@ func (s *GetOrderResp) GetStatus() OrderStatus {
 	return s.Status
 }
 
+// GetInstallments returns the value of Installments.
+func (s *GetOrderResp) GetInstallments() OptInt {
+	return s.Installments
+}
+
+// GetTenders returns the value of Tenders.
+func (s *GetOrderResp) GetTenders() []Tender {
+	return s.Tenders
+}
+
 // SetOrderUUID sets the value of OrderUUID.
 func (s *GetOrderResp) SetOrderUUID(val uuid.UUID) {
 	s.OrderUUID = val
@@ -224,8 +262,98 @@ func (s *GetOrderResp) SetStatus(val OrderStatus) {
 	s.Status = val
 }
 
+// SetInstallments sets the value of Installments.
+func (s *GetOrderResp) SetInstallments(val OptInt) {
+	s.Installments = val
+}
+
+// SetTenders sets the value of Tenders.
+func (s *GetOrderResp) SetTenders(val []Tender) {
+	s.Tenders = val
+}
+
 func (*GetOrderResp) getOrderRes() {}
 
+// Ref: #/components/schemas/installment_quote
+type InstallmentQuote struct {
+	// Число платежей.
+	Installments int `json:"installments"`
+	// Месячная процентная ставка (0.01 = 1%).
+	MonthlyRate float64 `json:"monthly_rate"`
+	// Суммы платежей по порядку.
+	Payments []float64 `json:"payments"`
+	// Сумма всех платежей.
+	TotalAmount float64 `json:"total_amount"`
+	// Переплата относительно стоимости заказа.
+	Overpayment float64 `json:"overpayment"`
+}
+
+// GetInstallments returns the value of Installments.
+func (s *InstallmentQuote) GetInstallments() int {
+	return s.Installments
+}
+
+// GetMonthlyRate returns the value of MonthlyRate.
+func (s *InstallmentQuote) GetMonthlyRate() float64 {
+	return s.MonthlyRate
+}
+
+// GetPayments returns the value of Payments.
+func (s *InstallmentQuote) GetPayments() []float64 {
+	return s.Payments
+}
+
+// GetTotalAmount returns the value of TotalAmount.
+func (s *InstallmentQuote) GetTotalAmount() float64 {
+	return s.TotalAmount
+}
+
+// GetOverpayment returns the value of Overpayment.
+func (s *InstallmentQuote) GetOverpayment() float64 {
+	return s.Overpayment
+}
+
+// SetInstallments sets the value of Installments.
+func (s *InstallmentQuote) SetInstallments(val int) {
+	s.Installments = val
+}
+
+// SetMonthlyRate sets the value of MonthlyRate.
+func (s *InstallmentQuote) SetMonthlyRate(val float64) {
+	s.MonthlyRate = val
+}
+
+// SetPayments sets the value of Payments.
+func (s *InstallmentQuote) SetPayments(val []float64) {
+	s.Payments = val
+}
+
+// SetTotalAmount sets the value of TotalAmount.
+func (s *InstallmentQuote) SetTotalAmount(val float64) {
+	s.TotalAmount = val
+}
+
+// SetOverpayment sets the value of Overpayment.
+func (s *InstallmentQuote) SetOverpayment(val float64) {
+	s.Overpayment = val
+}
+
+// Ref: #/components/schemas/list_user_orders_resp
+type ListUserOrdersResp struct {
+	// Заказы пользователя.
+	Orders []GetOrderResp `json:"orders"`
+}
+
+// GetOrders returns the value of Orders.
+func (s *ListUserOrdersResp) GetOrders() []GetOrderResp {
+	return s.Orders
+}
+
+// SetOrders sets the value of Orders.
+func (s *ListUserOrdersResp) SetOrders(val []GetOrderResp) {
+	s.Orders = val
+}
+
 // Ref: #/components/schemas/not_found_err
 type NotFoundErr struct {
 	// HTTP-код ошибки.
@@ -254,9 +382,10 @@ func (s *NotFoundErr) SetMessage(val string) {
 	s.Message = val
 }
 
-func (*NotFoundErr) cancelOrderRes() {}
-func (*NotFoundErr) getOrderRes()    {}
-func (*NotFoundErr) payOrderRes()    {}
+func (*NotFoundErr) cancelOrderRes()          {}
+func (*NotFoundErr) getInstallmentQuotesRes() {}
+func (*NotFoundErr) getOrderRes()             {}
+func (*NotFoundErr) payOrderRes()             {}
 
 // NewOptCreateOrderReq returns new OptCreateOrderReq with value set to v.
 func NewOptCreateOrderReq(v CreateOrderReq) OptCreateOrderReq {
@@ -304,21 +433,121 @@ func (o OptCreateOrderReq) Or(d CreateOrderReq) CreateOrderReq {
 	return d
 }
 
-// Статус заказа.
+// NewOptInt returns new OptInt with value set to v.
+func NewOptInt(v int) OptInt {
+	return OptInt{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptInt is optional int.
+type OptInt struct {
+	Value int
+	Set   bool
+}
+
+// IsSet returns true if OptInt was set.
+func (o OptInt) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptInt) Reset() {
+	var v int
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptInt) SetTo(v int) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptInt) Get() (v int, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptInt) Or(d int) int {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// NewOptPaymentMethod returns new OptPaymentMethod with value set to v.
+func NewOptPaymentMethod(v PaymentMethod) OptPaymentMethod {
+	return OptPaymentMethod{
+		Value: v,
+		Set:   true,
+	}
+}
+
+// OptPaymentMethod is optional PaymentMethod.
+type OptPaymentMethod struct {
+	Value PaymentMethod
+	Set   bool
+}
+
+// IsSet returns true if OptPaymentMethod was set.
+func (o OptPaymentMethod) IsSet() bool { return o.Set }
+
+// Reset unsets value.
+func (o *OptPaymentMethod) Reset() {
+	var v PaymentMethod
+	o.Value = v
+	o.Set = false
+}
+
+// SetTo sets value to v.
+func (o *OptPaymentMethod) SetTo(v PaymentMethod) {
+	o.Set = true
+	o.Value = v
+}
+
+// Get returns value and boolean that denotes whether value was set.
+func (o OptPaymentMethod) Get() (v PaymentMethod, ok bool) {
+	if !o.Set {
+		return v, false
+	}
+	return o.Value, true
+}
+
+// Or returns value if set, or given parameter if does not.
+func (o OptPaymentMethod) Or(d PaymentMethod) PaymentMethod {
+	if v, ok := o.Get(); ok {
+		return v
+	}
+	return d
+}
+
+// Статус заказа. AWAITING_APPROVAL - платеж средствами
+// инвестора ожидает согласования, PARTIALLY_PAID - оплачена
+// часть платежей рассрочки.
 // Ref: #/components/schemas/order_status
 type OrderStatus string
 
 const (
-	OrderStatusPENDINGPAYMENT OrderStatus = "PENDING_PAYMENT"
-	OrderStatusPAID           OrderStatus = "PAID"
-	OrderStatusCANCELLED      OrderStatus = "CANCELLED"
-	OrderStatusCOMPLETED      OrderStatus = "COMPLETED"
+	OrderStatusPENDINGPAYMENT    OrderStatus = "PENDING_PAYMENT"
+	OrderStatusPAYMENTPROCESSING OrderStatus = "PAYMENT_PROCESSING"
+	OrderStatusAWAITINGAPPROVAL  OrderStatus = "AWAITING_APPROVAL"
+	OrderStatusPARTIALLYPAID     OrderStatus = "PARTIALLY_PAID"
+	OrderStatusPAID              OrderStatus = "PAID"
+	OrderStatusCANCELLED         OrderStatus = "CANCELLED"
+	OrderStatusCOMPLETED         OrderStatus = "COMPLETED"
 )
 
 // AllValues returns all OrderStatus values.
 func (OrderStatus) AllValues() []OrderStatus {
 	return []OrderStatus{
 		OrderStatusPENDINGPAYMENT,
+		OrderStatusPAYMENTPROCESSING,
+		OrderStatusAWAITINGAPPROVAL,
+		OrderStatusPARTIALLYPAID,
 		OrderStatusPAID,
 		OrderStatusCANCELLED,
 		OrderStatusCOMPLETED,
@@ -330,6 +559,12 @@ func (s OrderStatus) MarshalText() ([]byte, error) {
 	switch s {
 	case OrderStatusPENDINGPAYMENT:
 		return []byte(s), nil
+	case OrderStatusPAYMENTPROCESSING:
+		return []byte(s), nil
+	case OrderStatusAWAITINGAPPROVAL:
+		return []byte(s), nil
+	case OrderStatusPARTIALLYPAID:
+		return []byte(s), nil
 	case OrderStatusPAID:
 		return []byte(s), nil
 	case OrderStatusCANCELLED:
@@ -347,6 +582,15 @@ func (s *OrderStatus) UnmarshalText(data []byte) error {
 	case OrderStatusPENDINGPAYMENT:
 		*s = OrderStatusPENDINGPAYMENT
 		return nil
+	case OrderStatusPAYMENTPROCESSING:
+		*s = OrderStatusPAYMENTPROCESSING
+		return nil
+	case OrderStatusAWAITINGAPPROVAL:
+		*s = OrderStatusAWAITINGAPPROVAL
+		return nil
+	case OrderStatusPARTIALLYPAID:
+		*s = OrderStatusPARTIALLYPAID
+		return nil
 	case OrderStatusPAID:
 		*s = OrderStatusPAID
 		return nil
@@ -363,23 +607,53 @@ func (s *OrderStatus) UnmarshalText(data []byte) error {
 
 // Ref: #/components/schemas/pay_order_req
 type PayOrderReq struct {
-	// Способ оплаты заказа.
-	PaymentMethod PaymentMethod `json:"payment_method"`
+	// Способ оплаты заказа целиком. Обязателен, если не
+	// передан tenders.
+	PaymentMethod OptPaymentMethod `json:"payment_method"`
+	// Число платежей рассрочки (только для PAYMENT_METHOD_CREDIT_CARD).
+	// Доступные варианты возвращает GetInstallmentQuotes, 0 или
+	// отсутствие поля - оплата целиком.
+	Installments OptInt `json:"installments"`
+	// Раздельная оплата несколькими способами (не менее
+	// двух частей, сумма частей равна сумме заказа).
+	// Передается вместо payment_method; заказ оплачен, только если
+	// прошли все части, иначе списанные части возвращаются.
+	Tenders []Tender `json:"tenders"`
 }
 
 // GetPaymentMethod returns the value of PaymentMethod.
-func (s *PayOrderReq) GetPaymentMethod() PaymentMethod {
+func (s *PayOrderReq) GetPaymentMethod() OptPaymentMethod {
 	return s.PaymentMethod
 }
 
+// GetInstallments returns the value of Installments.
+func (s *PayOrderReq) GetInstallments() OptInt {
+	return s.Installments
+}
+
+// GetTenders returns the value of Tenders.
+func (s *PayOrderReq) GetTenders() []Tender {
+	return s.Tenders
+}
+
 // SetPaymentMethod sets the value of PaymentMethod.
-func (s *PayOrderReq) SetPaymentMethod(val PaymentMethod) {
+func (s *PayOrderReq) SetPaymentMethod(val OptPaymentMethod) {
 	s.PaymentMethod = val
 }
 
+// SetInstallments sets the value of Installments.
+func (s *PayOrderReq) SetInstallments(val OptInt) {
+	s.Installments = val
+}
+
+// SetTenders sets the value of Tenders.
+func (s *PayOrderReq) SetTenders(val []Tender) {
+	s.Tenders = val
+}
+
 // Ref: #/components/schemas/pay_order_resp
 type PayOrderResp struct {
-	// UUID транзакции платежа.
+	// UUID транзакции платежа или плана рассрочки.
 	TransactionUUID uuid.UUID `json:"transaction_uuid"`
 }
 
@@ -459,6 +733,34 @@ func (s *PaymentMethod) UnmarshalText(data []byte) error {
 	}
 }
 
+// Ref: #/components/schemas/tender
+type Tender struct {
+	// Способ оплаты части.
+	PaymentMethod PaymentMethod `json:"payment_method"`
+	// Сумма части.
+	Amount float64 `json:"amount"`
+}
+
+// GetPaymentMethod returns the value of PaymentMethod.
+func (s *Tender) GetPaymentMethod() PaymentMethod {
+	return s.PaymentMethod
+}
+
+// GetAmount returns the value of Amount.
+func (s *Tender) GetAmount() float64 {
+	return s.Amount
+}
+
+// SetPaymentMethod sets the value of PaymentMethod.
+func (s *Tender) SetPaymentMethod(val PaymentMethod) {
+	s.PaymentMethod = val
+}
+
+// SetAmount sets the value of Amount.
+func (s *Tender) SetAmount(val float64) {
+	s.Amount = val
+}
+
 // Ref: #/components/schemas/unexpected_err
 type UnexpectedErr struct {
 	// HTTP-код ошибки.
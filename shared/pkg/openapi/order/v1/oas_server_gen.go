@@ -11,8 +11,8 @@ type Handler interface {
 	// CancelOrder implements CancelOrder operation.
 	//
 	// Отменяет заказ. Проверяет статус заказа - если
-	// PENDING_PAYMENT, меняет статус на CANCELLED. Если PAID, возвращает
-	// ошибку 409.
+	// PENDING_PAYMENT, меняет статус на CANCELLED. Если PAID или оплата
+	// еще обрабатывается (PAYMENT_PROCESSING), возвращает ошибку 409.
 	//
 	// POST /api/v1/orders/{order_uuid}/cancel
 	CancelOrder(ctx context.Context, params CancelOrderParams) (CancelOrderRes, error)
@@ -24,17 +24,38 @@ type Handler interface {
 	//
 	// POST /api/v1/orders
 	CreateOrder(ctx context.Context, req OptCreateOrderReq) (CreateOrderRes, error)
+	// GetInstallmentQuotes implements GetInstallmentQuotes operation.
+	//
+	// Рассчитывает доступные варианты рассрочки по
+	// кредитной карте для стоимости заказа.
+	//
+	// GET /api/v1/orders/{order_uuid}/installments/quotes
+	GetInstallmentQuotes(ctx context.Context, params GetInstallmentQuotesParams) (GetInstallmentQuotesRes, error)
 	// GetOrder implements GetOrder operation.
 	//
 	// Get order by uuid.
 	//
 	// GET /api/v1/orders/{order_uuid}
 	GetOrder(ctx context.Context, params GetOrderParams) (GetOrderRes, error)
+	// ListUserOrders implements ListUserOrders operation.
+	//
+	// Возвращает все заказы пользователя. Используется
+	// IAMService для выгрузки данных пользователя.
+	//
+	// GET /api/v1/users/{user_uuid}/orders
+	ListUserOrders(ctx context.Context, params ListUserOrdersParams) (*ListUserOrdersResp, error)
 	// PayOrder implements PayOrder operation.
 	//
-	// Проводит оплату ранее созданного заказа. Находит
-	// заказ по UUID, вызывает PaymentService для обработки платежа и
-	// обновляет статус заказа.
+	// Запускает оплату ранее созданного заказа. Находит
+	// заказ по UUID, передает платеж в PaymentService и переводит
+	// заказ в статус PAYMENT_PROCESSING. Заказ становится PAID после
+	// события PaymentCompleted или возвращается в PENDING_PAYMENT после
+	// PaymentFailed. При оплате в рассрочку transaction_uuid заказа - UUID
+	// плана рассрочки; после первого платежа заказ
+	// переходит в PARTIALLY_PAID, после последнего - в PAID. При
+	// раздельной оплате (tenders) transaction_uuid заказа - UUID группы
+	// транзакций частей; заказ становится PAID, только если
+	// прошли все части.
 	//
 	// POST /api/v1/orders/{order_uuid}/pay
 	PayOrder(ctx context.Context, req *PayOrderReq, params PayOrderParams) (PayOrderRes, error)
@@ -16,8 +16,8 @@ var _ Handler = UnimplementedHandler{}
 // CancelOrder implements CancelOrder operation.
 //
 // Отменяет заказ. Проверяет статус заказа - если
-// PENDING_PAYMENT, меняет статус на CANCELLED. Если PAID, возвращает
-// ошибку 409.
+// PENDING_PAYMENT, меняет статус на CANCELLED. Если PAID или оплата
+// еще обрабатывается (PAYMENT_PROCESSING), возвращает ошибку 409.
 //
 // POST /api/v1/orders/{order_uuid}/cancel
 func (UnimplementedHandler) CancelOrder(ctx context.Context, params CancelOrderParams) (r CancelOrderRes, _ error) {
@@ -35,6 +35,16 @@ func (UnimplementedHandler) CreateOrder(ctx context.Context, req OptCreateOrderR
 	return r, ht.ErrNotImplemented
 }
 
+// GetInstallmentQuotes implements GetInstallmentQuotes operation.
+//
+// Рассчитывает доступные варианты рассрочки по
+// кредитной карте для стоимости заказа.
+//
+// GET /api/v1/orders/{order_uuid}/installments/quotes
+func (UnimplementedHandler) GetInstallmentQuotes(ctx context.Context, params GetInstallmentQuotesParams) (r GetInstallmentQuotesRes, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
 // GetOrder implements GetOrder operation.
 //
 // Get order by uuid.
@@ -44,11 +54,28 @@ func (UnimplementedHandler) GetOrder(ctx context.Context, params GetOrderParams)
 	return r, ht.ErrNotImplemented
 }
 
+// ListUserOrders implements ListUserOrders operation.
+//
+// Возвращает все заказы пользователя. Используется
+// IAMService для выгрузки данных пользователя.
+//
+// GET /api/v1/users/{user_uuid}/orders
+func (UnimplementedHandler) ListUserOrders(ctx context.Context, params ListUserOrdersParams) (r *ListUserOrdersResp, _ error) {
+	return r, ht.ErrNotImplemented
+}
+
 // PayOrder implements PayOrder operation.
 //
-// Проводит оплату ранее созданного заказа. Находит
-// заказ по UUID, вызывает PaymentService для обработки платежа и
-// обновляет статус заказа.
+// Запускает оплату ранее созданного заказа. Находит
+// заказ по UUID, передает платеж в PaymentService и переводит
+// заказ в статус PAYMENT_PROCESSING. Заказ становится PAID после
+// события PaymentCompleted или возвращается в PENDING_PAYMENT после
+// PaymentFailed. При оплате в рассрочку transaction_uuid заказа - UUID
+// плана рассрочки; после первого платежа заказ
+// переходит в PARTIALLY_PAID, после последнего - в PAID. При
+// раздельной оплате (tenders) transaction_uuid заказа - UUID группы
+// транзакций частей; заказ становится PAID, только если
+// прошли все части.
 //
 // POST /api/v1/orders/{order_uuid}/pay
 func (UnimplementedHandler) PayOrder(ctx context.Context, req *PayOrderReq, params PayOrderParams) (r PayOrderRes, _ error) {
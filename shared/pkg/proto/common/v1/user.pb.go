@@ -34,8 +34,12 @@ type User struct {
 	Email string `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
 	// notification_methods каналы для получения уведомлений
 	NotificationMethods []*NotificationMethod `protobuf:"bytes,4,rep,name=notification_methods,json=notificationMethods,proto3" json:"notification_methods,omitempty"`
-	unknownFields       protoimpl.UnknownFields
-	sizeCache           protoimpl.SizeCache
+	// email_verified признак подтвержденного email
+	EmailVerified bool `protobuf:"varint,5,opt,name=email_verified,json=emailVerified,proto3" json:"email_verified,omitempty"`
+	// roles роли пользователя
+	Roles         []string `protobuf:"bytes,6,rep,name=roles,proto3" json:"roles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *User) Reset() {
@@ -96,6 +100,20 @@ func (x *User) GetNotificationMethods() []*NotificationMethod {
 	return nil
 }
 
+func (x *User) GetEmailVerified() bool {
+	if x != nil {
+		return x.EmailVerified
+	}
+	return false
+}
+
+func (x *User) GetRoles() []string {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
 // NotificationMethod представляет канал для уведомлений
 type NotificationMethod struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -155,12 +173,14 @@ var File_common_v1_user_proto protoreflect.FileDescriptor
 
 const file_common_v1_user_proto_rawDesc = "" +
 	"\n" +
-	"\x14common/v1/user.proto\x12\tcommon.v1\"\xa1\x01\n" +
+	"\x14common/v1/user.proto\x12\tcommon.v1\"\xde\x01\n" +
 	"\x04User\x12\x1b\n" +
 	"\tuser_uuid\x18\x01 \x01(\tR\buserUuid\x12\x14\n" +
 	"\x05login\x18\x02 \x01(\tR\x05login\x12\x14\n" +
 	"\x05email\x18\x03 \x01(\tR\x05email\x12P\n" +
-	"\x14notification_methods\x18\x04 \x03(\v2\x1d.common.v1.NotificationMethodR\x13notificationMethods\"Q\n" +
+	"\x14notification_methods\x18\x04 \x03(\v2\x1d.common.v1.NotificationMethodR\x13notificationMethods\x12%\n" +
+	"\x0eemail_verified\x18\x05 \x01(\bR\remailVerified\x12\x14\n" +
+	"\x05roles\x18\x06 \x03(\tR\x05roles\"Q\n" +
 	"\x12NotificationMethod\x12#\n" +
 	"\rprovider_name\x18\x01 \x01(\tR\fproviderName\x12\x16\n" +
 	"\x06target\x18\x02 \x01(\tR\x06targetBDZBgithub.com/linemk/rocket-shop/shared/pkg/proto/common/v1;common_v1b\x06proto3"
@@ -11,6 +11,7 @@ package events_v1
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -182,11 +183,1539 @@ func (x *ShipAssembled) GetBuildTimeSec() int64 {
 	return 0
 }
 
+// Событие о выпуске одноразового токена для пользователя
+// Публикуется IAMService при запросе сброса пароля или подтверждения email
+// Потребляется NotificationService для отправки письма
+type AuthTokenIssued struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// event_uuid уникальный идентификатор события (для идемпотентности)
+	EventUuid string `protobuf:"bytes,1,opt,name=event_uuid,json=eventUuid,proto3" json:"event_uuid,omitempty"`
+	// user_uuid идентификатор пользователя
+	UserUuid string `protobuf:"bytes,2,opt,name=user_uuid,json=userUuid,proto3" json:"user_uuid,omitempty"`
+	// email адрес, на который нужно отправить токен
+	Email string `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	// purpose назначение токена (password_reset, email_verification)
+	Purpose string `protobuf:"bytes,4,opt,name=purpose,proto3" json:"purpose,omitempty"`
+	// token одноразовый токен
+	Token string `protobuf:"bytes,5,opt,name=token,proto3" json:"token,omitempty"`
+	// ttl_sec время жизни токена в секундах
+	TtlSec        int64 `protobuf:"varint,6,opt,name=ttl_sec,json=ttlSec,proto3" json:"ttl_sec,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuthTokenIssued) Reset() {
+	*x = AuthTokenIssued{}
+	mi := &file_events_v1_events_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthTokenIssued) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthTokenIssued) ProtoMessage() {}
+
+func (x *AuthTokenIssued) ProtoReflect() protoreflect.Message {
+	mi := &file_events_v1_events_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthTokenIssued.ProtoReflect.Descriptor instead.
+func (*AuthTokenIssued) Descriptor() ([]byte, []int) {
+	return file_events_v1_events_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *AuthTokenIssued) GetEventUuid() string {
+	if x != nil {
+		return x.EventUuid
+	}
+	return ""
+}
+
+func (x *AuthTokenIssued) GetUserUuid() string {
+	if x != nil {
+		return x.UserUuid
+	}
+	return ""
+}
+
+func (x *AuthTokenIssued) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *AuthTokenIssued) GetPurpose() string {
+	if x != nil {
+		return x.Purpose
+	}
+	return ""
+}
+
+func (x *AuthTokenIssued) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *AuthTokenIssued) GetTtlSec() int64 {
+	if x != nil {
+		return x.TtlSec
+	}
+	return 0
+}
+
+// Событие аудита аутентификации и авторизации
+// Публикуется IAMService при входе, создании и отзыве сессий и отказе в доступе на ext_authz
+// Потребляется IAMService для сохранения в хранилище аудита
+type AuthAuditEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// event_uuid уникальный идентификатор события (для идемпотентности)
+	EventUuid string `protobuf:"bytes,1,opt,name=event_uuid,json=eventUuid,proto3" json:"event_uuid,omitempty"`
+	// event_type тип события (login_succeeded, login_failed, session_created, session_revoked, authz_denied)
+	EventType string `protobuf:"bytes,2,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	// user_uuid идентификатор пользователя (может быть пустым, если пользователь не определен)
+	UserUuid string `protobuf:"bytes,3,opt,name=user_uuid,json=userUuid,proto3" json:"user_uuid,omitempty"`
+	// login логин, указанный при входе
+	Login string `protobuf:"bytes,4,opt,name=login,proto3" json:"login,omitempty"`
+	// session_uuid идентификатор сессии
+	SessionUuid string `protobuf:"bytes,5,opt,name=session_uuid,json=sessionUuid,proto3" json:"session_uuid,omitempty"`
+	// ip_address IP адрес клиента
+	IpAddress string `protobuf:"bytes,6,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`
+	// user_agent User-Agent клиента
+	UserAgent string `protobuf:"bytes,7,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	// reason причина отказа или отзыва
+	Reason string `protobuf:"bytes,8,opt,name=reason,proto3" json:"reason,omitempty"`
+	// resource запрошенный ресурс (метод и путь) для событий авторизации
+	Resource string `protobuf:"bytes,9,opt,name=resource,proto3" json:"resource,omitempty"`
+	// occurred_at время события
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuthAuditEvent) Reset() {
+	*x = AuthAuditEvent{}
+	mi := &file_events_v1_events_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthAuditEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthAuditEvent) ProtoMessage() {}
+
+func (x *AuthAuditEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_events_v1_events_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthAuditEvent.ProtoReflect.Descriptor instead.
+func (*AuthAuditEvent) Descriptor() ([]byte, []int) {
+	return file_events_v1_events_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *AuthAuditEvent) GetEventUuid() string {
+	if x != nil {
+		return x.EventUuid
+	}
+	return ""
+}
+
+func (x *AuthAuditEvent) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *AuthAuditEvent) GetUserUuid() string {
+	if x != nil {
+		return x.UserUuid
+	}
+	return ""
+}
+
+func (x *AuthAuditEvent) GetLogin() string {
+	if x != nil {
+		return x.Login
+	}
+	return ""
+}
+
+func (x *AuthAuditEvent) GetSessionUuid() string {
+	if x != nil {
+		return x.SessionUuid
+	}
+	return ""
+}
+
+func (x *AuthAuditEvent) GetIpAddress() string {
+	if x != nil {
+		return x.IpAddress
+	}
+	return ""
+}
+
+func (x *AuthAuditEvent) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+func (x *AuthAuditEvent) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *AuthAuditEvent) GetResource() string {
+	if x != nil {
+		return x.Resource
+	}
+	return ""
+}
+
+func (x *AuthAuditEvent) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+// Событие об удалении персональных данных пользователя
+// Публикуется IAMService после анонимизации учетной записи
+// Потребляется OrderService, PaymentService и NotificationService для анонимизации своих данных
+type UserErased struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// event_uuid уникальный идентификатор события (для идемпотентности)
+	EventUuid string `protobuf:"bytes,1,opt,name=event_uuid,json=eventUuid,proto3" json:"event_uuid,omitempty"`
+	// user_uuid идентификатор удаленного пользователя
+	UserUuid string `protobuf:"bytes,2,opt,name=user_uuid,json=userUuid,proto3" json:"user_uuid,omitempty"`
+	// erased_at время удаления данных в IAMService
+	ErasedAt      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=erased_at,json=erasedAt,proto3" json:"erased_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserErased) Reset() {
+	*x = UserErased{}
+	mi := &file_events_v1_events_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserErased) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserErased) ProtoMessage() {}
+
+func (x *UserErased) ProtoReflect() protoreflect.Message {
+	mi := &file_events_v1_events_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserErased.ProtoReflect.Descriptor instead.
+func (*UserErased) Descriptor() ([]byte, []int) {
+	return file_events_v1_events_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *UserErased) GetEventUuid() string {
+	if x != nil {
+		return x.EventUuid
+	}
+	return ""
+}
+
+func (x *UserErased) GetUserUuid() string {
+	if x != nil {
+		return x.UserUuid
+	}
+	return ""
+}
+
+func (x *UserErased) GetErasedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ErasedAt
+	}
+	return nil
+}
+
+// Событие об успешном списании средств по транзакции
+// Публикуется PaymentService после подтверждения платежа провайдером
+// Потребляется OrderService для перевода заказа в статус PAID
+type PaymentCompleted struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// event_uuid уникальный идентификатор события (для идемпотентности)
+	EventUuid string `protobuf:"bytes,1,opt,name=event_uuid,json=eventUuid,proto3" json:"event_uuid,omitempty"`
+	// transaction_uuid идентификатор транзакции; для раздельной оплаты - идентификатор группы транзакций
+	TransactionUuid string `protobuf:"bytes,2,opt,name=transaction_uuid,json=transactionUuid,proto3" json:"transaction_uuid,omitempty"`
+	// order_uuid идентификатор оплачиваемого заказа
+	OrderUuid string `protobuf:"bytes,3,opt,name=order_uuid,json=orderUuid,proto3" json:"order_uuid,omitempty"`
+	// user_uuid идентификатор пользователя
+	UserUuid string `protobuf:"bytes,4,opt,name=user_uuid,json=userUuid,proto3" json:"user_uuid,omitempty"`
+	// payment_method способ оплаты (строкой, значение из PaymentMethod); для раздельной оплаты - способы через запятую
+	PaymentMethod string `protobuf:"bytes,5,opt,name=payment_method,json=paymentMethod,proto3" json:"payment_method,omitempty"`
+	// amount списанная сумма
+	Amount float64 `protobuf:"fixed64,6,opt,name=amount,proto3" json:"amount,omitempty"`
+	// completed_at время подтверждения платежа
+	CompletedAt   *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PaymentCompleted) Reset() {
+	*x = PaymentCompleted{}
+	mi := &file_events_v1_events_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PaymentCompleted) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentCompleted) ProtoMessage() {}
+
+func (x *PaymentCompleted) ProtoReflect() protoreflect.Message {
+	mi := &file_events_v1_events_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaymentCompleted.ProtoReflect.Descriptor instead.
+func (*PaymentCompleted) Descriptor() ([]byte, []int) {
+	return file_events_v1_events_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *PaymentCompleted) GetEventUuid() string {
+	if x != nil {
+		return x.EventUuid
+	}
+	return ""
+}
+
+func (x *PaymentCompleted) GetTransactionUuid() string {
+	if x != nil {
+		return x.TransactionUuid
+	}
+	return ""
+}
+
+func (x *PaymentCompleted) GetOrderUuid() string {
+	if x != nil {
+		return x.OrderUuid
+	}
+	return ""
+}
+
+func (x *PaymentCompleted) GetUserUuid() string {
+	if x != nil {
+		return x.UserUuid
+	}
+	return ""
+}
+
+func (x *PaymentCompleted) GetPaymentMethod() string {
+	if x != nil {
+		return x.PaymentMethod
+	}
+	return ""
+}
+
+func (x *PaymentCompleted) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *PaymentCompleted) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+// Событие о неуспешном платеже
+// Публикуется PaymentService при отказе провайдера, техническом сбое или таймауте
+// Потребляется OrderService для возврата заказа в статус PENDING_PAYMENT
+type PaymentFailed struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// event_uuid уникальный идентификатор события (для идемпотентности)
+	EventUuid string `protobuf:"bytes,1,opt,name=event_uuid,json=eventUuid,proto3" json:"event_uuid,omitempty"`
+	// transaction_uuid идентификатор транзакции; для раздельной оплаты - идентификатор группы транзакций
+	TransactionUuid string `protobuf:"bytes,2,opt,name=transaction_uuid,json=transactionUuid,proto3" json:"transaction_uuid,omitempty"`
+	// order_uuid идентификатор оплачиваемого заказа
+	OrderUuid string `protobuf:"bytes,3,opt,name=order_uuid,json=orderUuid,proto3" json:"order_uuid,omitempty"`
+	// user_uuid идентификатор пользователя
+	UserUuid string `protobuf:"bytes,4,opt,name=user_uuid,json=userUuid,proto3" json:"user_uuid,omitempty"`
+	// status итоговый статус транзакции (FAILED, CANCELLED)
+	Status string `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	// reason причина отказа
+	Reason string `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+	// failed_at время завершения платежа
+	FailedAt      *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=failed_at,json=failedAt,proto3" json:"failed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PaymentFailed) Reset() {
+	*x = PaymentFailed{}
+	mi := &file_events_v1_events_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PaymentFailed) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentFailed) ProtoMessage() {}
+
+func (x *PaymentFailed) ProtoReflect() protoreflect.Message {
+	mi := &file_events_v1_events_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaymentFailed.ProtoReflect.Descriptor instead.
+func (*PaymentFailed) Descriptor() ([]byte, []int) {
+	return file_events_v1_events_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PaymentFailed) GetEventUuid() string {
+	if x != nil {
+		return x.EventUuid
+	}
+	return ""
+}
+
+func (x *PaymentFailed) GetTransactionUuid() string {
+	if x != nil {
+		return x.TransactionUuid
+	}
+	return ""
+}
+
+func (x *PaymentFailed) GetOrderUuid() string {
+	if x != nil {
+		return x.OrderUuid
+	}
+	return ""
+}
+
+func (x *PaymentFailed) GetUserUuid() string {
+	if x != nil {
+		return x.UserUuid
+	}
+	return ""
+}
+
+func (x *PaymentFailed) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *PaymentFailed) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *PaymentFailed) GetFailedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FailedAt
+	}
+	return nil
+}
+
+// Событие об оплате платежа по графику рассрочки
+// Публикуется PaymentService после успешного списания очередного платежа
+// Потребляется OrderService для перевода заказа в статус PARTIALLY_PAID, а после последнего платежа - в PAID
+type InstallmentPaid struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// event_uuid уникальный идентификатор события (для идемпотентности)
+	EventUuid string `protobuf:"bytes,1,opt,name=event_uuid,json=eventUuid,proto3" json:"event_uuid,omitempty"`
+	// plan_uuid идентификатор плана рассрочки (transaction_id заказа)
+	PlanUuid string `protobuf:"bytes,2,opt,name=plan_uuid,json=planUuid,proto3" json:"plan_uuid,omitempty"`
+	// transaction_uuid идентификатор транзакции платежа
+	TransactionUuid string `protobuf:"bytes,3,opt,name=transaction_uuid,json=transactionUuid,proto3" json:"transaction_uuid,omitempty"`
+	// order_uuid идентификатор заказа
+	OrderUuid string `protobuf:"bytes,4,opt,name=order_uuid,json=orderUuid,proto3" json:"order_uuid,omitempty"`
+	// user_uuid идентификатор пользователя
+	UserUuid string `protobuf:"bytes,5,opt,name=user_uuid,json=userUuid,proto3" json:"user_uuid,omitempty"`
+	// installment_number номер оплаченного платежа
+	InstallmentNumber int32 `protobuf:"varint,6,opt,name=installment_number,json=installmentNumber,proto3" json:"installment_number,omitempty"`
+	// installments_total число платежей в плане
+	InstallmentsTotal int32 `protobuf:"varint,7,opt,name=installments_total,json=installmentsTotal,proto3" json:"installments_total,omitempty"`
+	// amount сумма оплаченного платежа
+	Amount float64 `protobuf:"fixed64,8,opt,name=amount,proto3" json:"amount,omitempty"`
+	// paid_amount сумма всех оплаченных платежей плана
+	PaidAmount float64 `protobuf:"fixed64,9,opt,name=paid_amount,json=paidAmount,proto3" json:"paid_amount,omitempty"`
+	// remaining_amount сумма оставшихся платежей плана
+	RemainingAmount float64 `protobuf:"fixed64,10,opt,name=remaining_amount,json=remainingAmount,proto3" json:"remaining_amount,omitempty"`
+	// plan_completed true, если оплачен последний платеж плана
+	PlanCompleted bool `protobuf:"varint,11,opt,name=plan_completed,json=planCompleted,proto3" json:"plan_completed,omitempty"`
+	// paid_at время оплаты
+	PaidAt        *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=paid_at,json=paidAt,proto3" json:"paid_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InstallmentPaid) Reset() {
+	*x = InstallmentPaid{}
+	mi := &file_events_v1_events_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstallmentPaid) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstallmentPaid) ProtoMessage() {}
+
+func (x *InstallmentPaid) ProtoReflect() protoreflect.Message {
+	mi := &file_events_v1_events_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstallmentPaid.ProtoReflect.Descriptor instead.
+func (*InstallmentPaid) Descriptor() ([]byte, []int) {
+	return file_events_v1_events_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *InstallmentPaid) GetEventUuid() string {
+	if x != nil {
+		return x.EventUuid
+	}
+	return ""
+}
+
+func (x *InstallmentPaid) GetPlanUuid() string {
+	if x != nil {
+		return x.PlanUuid
+	}
+	return ""
+}
+
+func (x *InstallmentPaid) GetTransactionUuid() string {
+	if x != nil {
+		return x.TransactionUuid
+	}
+	return ""
+}
+
+func (x *InstallmentPaid) GetOrderUuid() string {
+	if x != nil {
+		return x.OrderUuid
+	}
+	return ""
+}
+
+func (x *InstallmentPaid) GetUserUuid() string {
+	if x != nil {
+		return x.UserUuid
+	}
+	return ""
+}
+
+func (x *InstallmentPaid) GetInstallmentNumber() int32 {
+	if x != nil {
+		return x.InstallmentNumber
+	}
+	return 0
+}
+
+func (x *InstallmentPaid) GetInstallmentsTotal() int32 {
+	if x != nil {
+		return x.InstallmentsTotal
+	}
+	return 0
+}
+
+func (x *InstallmentPaid) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *InstallmentPaid) GetPaidAmount() float64 {
+	if x != nil {
+		return x.PaidAmount
+	}
+	return 0
+}
+
+func (x *InstallmentPaid) GetRemainingAmount() float64 {
+	if x != nil {
+		return x.RemainingAmount
+	}
+	return 0
+}
+
+func (x *InstallmentPaid) GetPlanCompleted() bool {
+	if x != nil {
+		return x.PlanCompleted
+	}
+	return false
+}
+
+func (x *InstallmentPaid) GetPaidAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PaidAt
+	}
+	return nil
+}
+
+// Событие о просрочке платежа по графику рассрочки
+// Публикуется PaymentService, если платеж не удалось списать в течение льготного периода после срока
+type PaymentOverdue struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// event_uuid уникальный идентификатор события (для идемпотентности)
+	EventUuid string `protobuf:"bytes,1,opt,name=event_uuid,json=eventUuid,proto3" json:"event_uuid,omitempty"`
+	// plan_uuid идентификатор плана рассрочки
+	PlanUuid string `protobuf:"bytes,2,opt,name=plan_uuid,json=planUuid,proto3" json:"plan_uuid,omitempty"`
+	// order_uuid идентификатор заказа
+	OrderUuid string `protobuf:"bytes,3,opt,name=order_uuid,json=orderUuid,proto3" json:"order_uuid,omitempty"`
+	// user_uuid идентификатор пользователя
+	UserUuid string `protobuf:"bytes,4,opt,name=user_uuid,json=userUuid,proto3" json:"user_uuid,omitempty"`
+	// installment_number номер просроченного платежа
+	InstallmentNumber int32 `protobuf:"varint,5,opt,name=installment_number,json=installmentNumber,proto3" json:"installment_number,omitempty"`
+	// amount сумма просроченного платежа
+	Amount float64 `protobuf:"fixed64,6,opt,name=amount,proto3" json:"amount,omitempty"`
+	// due_at срок платежа
+	DueAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=due_at,json=dueAt,proto3" json:"due_at,omitempty"`
+	// reason причина отказа последней попытки списания
+	Reason string `protobuf:"bytes,8,opt,name=reason,proto3" json:"reason,omitempty"`
+	// detected_at время обнаружения просрочки
+	DetectedAt    *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=detected_at,json=detectedAt,proto3" json:"detected_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PaymentOverdue) Reset() {
+	*x = PaymentOverdue{}
+	mi := &file_events_v1_events_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PaymentOverdue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentOverdue) ProtoMessage() {}
+
+func (x *PaymentOverdue) ProtoReflect() protoreflect.Message {
+	mi := &file_events_v1_events_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaymentOverdue.ProtoReflect.Descriptor instead.
+func (*PaymentOverdue) Descriptor() ([]byte, []int) {
+	return file_events_v1_events_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PaymentOverdue) GetEventUuid() string {
+	if x != nil {
+		return x.EventUuid
+	}
+	return ""
+}
+
+func (x *PaymentOverdue) GetPlanUuid() string {
+	if x != nil {
+		return x.PlanUuid
+	}
+	return ""
+}
+
+func (x *PaymentOverdue) GetOrderUuid() string {
+	if x != nil {
+		return x.OrderUuid
+	}
+	return ""
+}
+
+func (x *PaymentOverdue) GetUserUuid() string {
+	if x != nil {
+		return x.UserUuid
+	}
+	return ""
+}
+
+func (x *PaymentOverdue) GetInstallmentNumber() int32 {
+	if x != nil {
+		return x.InstallmentNumber
+	}
+	return 0
+}
+
+func (x *PaymentOverdue) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *PaymentOverdue) GetDueAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueAt
+	}
+	return nil
+}
+
+func (x *PaymentOverdue) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *PaymentOverdue) GetDetectedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DetectedAt
+	}
+	return nil
+}
+
+// Событие о запросе на согласование платежа средствами инвестора
+// Публикуется PaymentService до решения согласующих, итог публикуется событием PaymentCompleted или PaymentFailed
+type ApprovalRequested struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// event_uuid уникальный идентификатор события (для идемпотентности)
+	EventUuid string `protobuf:"bytes,1,opt,name=event_uuid,json=eventUuid,proto3" json:"event_uuid,omitempty"`
+	// approval_uuid идентификатор запроса на согласование
+	ApprovalUuid string `protobuf:"bytes,2,opt,name=approval_uuid,json=approvalUuid,proto3" json:"approval_uuid,omitempty"`
+	// transaction_uuid идентификатор согласуемой транзакции
+	TransactionUuid string `protobuf:"bytes,3,opt,name=transaction_uuid,json=transactionUuid,proto3" json:"transaction_uuid,omitempty"`
+	// order_uuid идентификатор заказа
+	OrderUuid string `protobuf:"bytes,4,opt,name=order_uuid,json=orderUuid,proto3" json:"order_uuid,omitempty"`
+	// user_uuid идентификатор пользователя
+	UserUuid string `protobuf:"bytes,5,opt,name=user_uuid,json=userUuid,proto3" json:"user_uuid,omitempty"`
+	// amount сумма платежа
+	Amount float64 `protobuf:"fixed64,6,opt,name=amount,proto3" json:"amount,omitempty"`
+	// approvers согласующие, которые могут принять решение
+	Approvers []string `protobuf:"bytes,7,rep,name=approvers,proto3" json:"approvers,omitempty"`
+	// quorum число одобрений, необходимое для списания
+	Quorum int32 `protobuf:"varint,8,opt,name=quorum,proto3" json:"quorum,omitempty"`
+	// expires_at срок, после которого несогласованный платеж отменяется
+	ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// requested_at время создания запроса
+	RequestedAt *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=requested_at,json=requestedAt,proto3" json:"requested_at,omitempty"`
+	// split_uuid идентификатор группы транзакций, если платеж - часть раздельной оплаты заказа
+	SplitUuid     string `protobuf:"bytes,11,opt,name=split_uuid,json=splitUuid,proto3" json:"split_uuid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApprovalRequested) Reset() {
+	*x = ApprovalRequested{}
+	mi := &file_events_v1_events_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApprovalRequested) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApprovalRequested) ProtoMessage() {}
+
+func (x *ApprovalRequested) ProtoReflect() protoreflect.Message {
+	mi := &file_events_v1_events_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApprovalRequested.ProtoReflect.Descriptor instead.
+func (*ApprovalRequested) Descriptor() ([]byte, []int) {
+	return file_events_v1_events_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ApprovalRequested) GetEventUuid() string {
+	if x != nil {
+		return x.EventUuid
+	}
+	return ""
+}
+
+func (x *ApprovalRequested) GetApprovalUuid() string {
+	if x != nil {
+		return x.ApprovalUuid
+	}
+	return ""
+}
+
+func (x *ApprovalRequested) GetTransactionUuid() string {
+	if x != nil {
+		return x.TransactionUuid
+	}
+	return ""
+}
+
+func (x *ApprovalRequested) GetOrderUuid() string {
+	if x != nil {
+		return x.OrderUuid
+	}
+	return ""
+}
+
+func (x *ApprovalRequested) GetUserUuid() string {
+	if x != nil {
+		return x.UserUuid
+	}
+	return ""
+}
+
+func (x *ApprovalRequested) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *ApprovalRequested) GetApprovers() []string {
+	if x != nil {
+		return x.Approvers
+	}
+	return nil
+}
+
+func (x *ApprovalRequested) GetQuorum() int32 {
+	if x != nil {
+		return x.Quorum
+	}
+	return 0
+}
+
+func (x *ApprovalRequested) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *ApprovalRequested) GetRequestedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RequestedAt
+	}
+	return nil
+}
+
+func (x *ApprovalRequested) GetSplitUuid() string {
+	if x != nil {
+		return x.SplitUuid
+	}
+	return ""
+}
+
+// Конверт событий платежей, публикуемых в общий топик PaymentService
+type PaymentEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Event:
+	//
+	//	*PaymentEvent_Completed
+	//	*PaymentEvent_Failed
+	//	*PaymentEvent_InstallmentPaid
+	//	*PaymentEvent_Overdue
+	//	*PaymentEvent_ApprovalRequested
+	Event         isPaymentEvent_Event `protobuf_oneof:"event"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PaymentEvent) Reset() {
+	*x = PaymentEvent{}
+	mi := &file_events_v1_events_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PaymentEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentEvent) ProtoMessage() {}
+
+func (x *PaymentEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_events_v1_events_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaymentEvent.ProtoReflect.Descriptor instead.
+func (*PaymentEvent) Descriptor() ([]byte, []int) {
+	return file_events_v1_events_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PaymentEvent) GetEvent() isPaymentEvent_Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *PaymentEvent) GetCompleted() *PaymentCompleted {
+	if x != nil {
+		if x, ok := x.Event.(*PaymentEvent_Completed); ok {
+			return x.Completed
+		}
+	}
+	return nil
+}
+
+func (x *PaymentEvent) GetFailed() *PaymentFailed {
+	if x != nil {
+		if x, ok := x.Event.(*PaymentEvent_Failed); ok {
+			return x.Failed
+		}
+	}
+	return nil
+}
+
+func (x *PaymentEvent) GetInstallmentPaid() *InstallmentPaid {
+	if x != nil {
+		if x, ok := x.Event.(*PaymentEvent_InstallmentPaid); ok {
+			return x.InstallmentPaid
+		}
+	}
+	return nil
+}
+
+func (x *PaymentEvent) GetOverdue() *PaymentOverdue {
+	if x != nil {
+		if x, ok := x.Event.(*PaymentEvent_Overdue); ok {
+			return x.Overdue
+		}
+	}
+	return nil
+}
+
+func (x *PaymentEvent) GetApprovalRequested() *ApprovalRequested {
+	if x != nil {
+		if x, ok := x.Event.(*PaymentEvent_ApprovalRequested); ok {
+			return x.ApprovalRequested
+		}
+	}
+	return nil
+}
+
+type isPaymentEvent_Event interface {
+	isPaymentEvent_Event()
+}
+
+type PaymentEvent_Completed struct {
+	Completed *PaymentCompleted `protobuf:"bytes,1,opt,name=completed,proto3,oneof"`
+}
+
+type PaymentEvent_Failed struct {
+	Failed *PaymentFailed `protobuf:"bytes,2,opt,name=failed,proto3,oneof"`
+}
+
+type PaymentEvent_InstallmentPaid struct {
+	InstallmentPaid *InstallmentPaid `protobuf:"bytes,3,opt,name=installment_paid,json=installmentPaid,proto3,oneof"`
+}
+
+type PaymentEvent_Overdue struct {
+	Overdue *PaymentOverdue `protobuf:"bytes,4,opt,name=overdue,proto3,oneof"`
+}
+
+type PaymentEvent_ApprovalRequested struct {
+	ApprovalRequested *ApprovalRequested `protobuf:"bytes,5,opt,name=approval_requested,json=approvalRequested,proto3,oneof"`
+}
+
+func (*PaymentEvent_Completed) isPaymentEvent_Event() {}
+
+func (*PaymentEvent_Failed) isPaymentEvent_Event() {}
+
+func (*PaymentEvent_InstallmentPaid) isPaymentEvent_Event() {}
+
+func (*PaymentEvent_Overdue) isPaymentEvent_Event() {}
+
+func (*PaymentEvent_ApprovalRequested) isPaymentEvent_Event() {}
+
+// Событие о добавлении детали в каталог
+// Публикуется InventoryService после создания детали
+type PartCreated struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// event_uuid уникальный идентификатор события (для идемпотентности)
+	EventUuid string `protobuf:"bytes,1,opt,name=event_uuid,json=eventUuid,proto3" json:"event_uuid,omitempty"`
+	// part_uuid идентификатор детали
+	PartUuid string `protobuf:"bytes,2,opt,name=part_uuid,json=partUuid,proto3" json:"part_uuid,omitempty"`
+	// name название детали
+	Name string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// category категория детали (строкой, значение из Category)
+	Category string `protobuf:"bytes,4,opt,name=category,proto3" json:"category,omitempty"`
+	// price цена детали
+	Price float64 `protobuf:"fixed64,5,opt,name=price,proto3" json:"price,omitempty"`
+	// stock_quantity количество на складе
+	StockQuantity int64 `protobuf:"varint,6,opt,name=stock_quantity,json=stockQuantity,proto3" json:"stock_quantity,omitempty"`
+	// tags теги детали
+	Tags []string `protobuf:"bytes,7,rep,name=tags,proto3" json:"tags,omitempty"`
+	// occurred_at время создания
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PartCreated) Reset() {
+	*x = PartCreated{}
+	mi := &file_events_v1_events_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PartCreated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PartCreated) ProtoMessage() {}
+
+func (x *PartCreated) ProtoReflect() protoreflect.Message {
+	mi := &file_events_v1_events_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PartCreated.ProtoReflect.Descriptor instead.
+func (*PartCreated) Descriptor() ([]byte, []int) {
+	return file_events_v1_events_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *PartCreated) GetEventUuid() string {
+	if x != nil {
+		return x.EventUuid
+	}
+	return ""
+}
+
+func (x *PartCreated) GetPartUuid() string {
+	if x != nil {
+		return x.PartUuid
+	}
+	return ""
+}
+
+func (x *PartCreated) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PartCreated) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *PartCreated) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *PartCreated) GetStockQuantity() int64 {
+	if x != nil {
+		return x.StockQuantity
+	}
+	return 0
+}
+
+func (x *PartCreated) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *PartCreated) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+// Событие об изменении детали
+// Публикуется InventoryService после обновления детали и содержит ее новое состояние
+type PartUpdated struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// event_uuid уникальный идентификатор события (для идемпотентности)
+	EventUuid string `protobuf:"bytes,1,opt,name=event_uuid,json=eventUuid,proto3" json:"event_uuid,omitempty"`
+	// part_uuid идентификатор детали
+	PartUuid string `protobuf:"bytes,2,opt,name=part_uuid,json=partUuid,proto3" json:"part_uuid,omitempty"`
+	// name название детали
+	Name string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// category категория детали (строкой, значение из Category)
+	Category string `protobuf:"bytes,4,opt,name=category,proto3" json:"category,omitempty"`
+	// price новая цена детали
+	Price float64 `protobuf:"fixed64,5,opt,name=price,proto3" json:"price,omitempty"`
+	// previous_price цена детали до обновления
+	PreviousPrice float64 `protobuf:"fixed64,6,opt,name=previous_price,json=previousPrice,proto3" json:"previous_price,omitempty"`
+	// stock_quantity количество на складе
+	StockQuantity int64 `protobuf:"varint,7,opt,name=stock_quantity,json=stockQuantity,proto3" json:"stock_quantity,omitempty"`
+	// tags теги детали
+	Tags []string `protobuf:"bytes,8,rep,name=tags,proto3" json:"tags,omitempty"`
+	// occurred_at время обновления
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PartUpdated) Reset() {
+	*x = PartUpdated{}
+	mi := &file_events_v1_events_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PartUpdated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PartUpdated) ProtoMessage() {}
+
+func (x *PartUpdated) ProtoReflect() protoreflect.Message {
+	mi := &file_events_v1_events_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PartUpdated.ProtoReflect.Descriptor instead.
+func (*PartUpdated) Descriptor() ([]byte, []int) {
+	return file_events_v1_events_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *PartUpdated) GetEventUuid() string {
+	if x != nil {
+		return x.EventUuid
+	}
+	return ""
+}
+
+func (x *PartUpdated) GetPartUuid() string {
+	if x != nil {
+		return x.PartUuid
+	}
+	return ""
+}
+
+func (x *PartUpdated) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PartUpdated) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *PartUpdated) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *PartUpdated) GetPreviousPrice() float64 {
+	if x != nil {
+		return x.PreviousPrice
+	}
+	return 0
+}
+
+func (x *PartUpdated) GetStockQuantity() int64 {
+	if x != nil {
+		return x.StockQuantity
+	}
+	return 0
+}
+
+func (x *PartUpdated) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *PartUpdated) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+// Событие об удалении детали из каталога
+// Публикуется InventoryService после удаления детали
+type PartDeleted struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// event_uuid уникальный идентификатор события (для идемпотентности)
+	EventUuid string `protobuf:"bytes,1,opt,name=event_uuid,json=eventUuid,proto3" json:"event_uuid,omitempty"`
+	// part_uuid идентификатор детали
+	PartUuid string `protobuf:"bytes,2,opt,name=part_uuid,json=partUuid,proto3" json:"part_uuid,omitempty"`
+	// occurred_at время удаления
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PartDeleted) Reset() {
+	*x = PartDeleted{}
+	mi := &file_events_v1_events_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PartDeleted) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PartDeleted) ProtoMessage() {}
+
+func (x *PartDeleted) ProtoReflect() protoreflect.Message {
+	mi := &file_events_v1_events_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PartDeleted.ProtoReflect.Descriptor instead.
+func (*PartDeleted) Descriptor() ([]byte, []int) {
+	return file_events_v1_events_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *PartDeleted) GetEventUuid() string {
+	if x != nil {
+		return x.EventUuid
+	}
+	return ""
+}
+
+func (x *PartDeleted) GetPartUuid() string {
+	if x != nil {
+		return x.PartUuid
+	}
+	return ""
+}
+
+func (x *PartDeleted) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+// Событие об изменении остатка детали на складе
+// Публикуется InventoryService вместе с PartUpdated, если обновление изменило количество на складе
+type StockChanged struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// event_uuid уникальный идентификатор события (для идемпотентности)
+	EventUuid string `protobuf:"bytes,1,opt,name=event_uuid,json=eventUuid,proto3" json:"event_uuid,omitempty"`
+	// part_uuid идентификатор детали
+	PartUuid string `protobuf:"bytes,2,opt,name=part_uuid,json=partUuid,proto3" json:"part_uuid,omitempty"`
+	// previous_quantity количество на складе до обновления
+	PreviousQuantity int64 `protobuf:"varint,3,opt,name=previous_quantity,json=previousQuantity,proto3" json:"previous_quantity,omitempty"`
+	// quantity количество на складе после обновления
+	Quantity int64 `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// available true, если деталь есть на складе после обновления
+	Available bool `protobuf:"varint,5,opt,name=available,proto3" json:"available,omitempty"`
+	// occurred_at время изменения
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockChanged) Reset() {
+	*x = StockChanged{}
+	mi := &file_events_v1_events_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockChanged) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockChanged) ProtoMessage() {}
+
+func (x *StockChanged) ProtoReflect() protoreflect.Message {
+	mi := &file_events_v1_events_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockChanged.ProtoReflect.Descriptor instead.
+func (*StockChanged) Descriptor() ([]byte, []int) {
+	return file_events_v1_events_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *StockChanged) GetEventUuid() string {
+	if x != nil {
+		return x.EventUuid
+	}
+	return ""
+}
+
+func (x *StockChanged) GetPartUuid() string {
+	if x != nil {
+		return x.PartUuid
+	}
+	return ""
+}
+
+func (x *StockChanged) GetPreviousQuantity() int64 {
+	if x != nil {
+		return x.PreviousQuantity
+	}
+	return 0
+}
+
+func (x *StockChanged) GetQuantity() int64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *StockChanged) GetAvailable() bool {
+	if x != nil {
+		return x.Available
+	}
+	return false
+}
+
+func (x *StockChanged) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+// Конверт событий каталога, публикуемых в общий топик InventoryService
+// Ключ сообщения - UUID детали, поэтому события одной детали обрабатываются по порядку
+type InventoryEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Event:
+	//
+	//	*InventoryEvent_PartCreated
+	//	*InventoryEvent_PartUpdated
+	//	*InventoryEvent_PartDeleted
+	//	*InventoryEvent_StockChanged
+	Event         isInventoryEvent_Event `protobuf_oneof:"event"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InventoryEvent) Reset() {
+	*x = InventoryEvent{}
+	mi := &file_events_v1_events_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InventoryEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InventoryEvent) ProtoMessage() {}
+
+func (x *InventoryEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_events_v1_events_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InventoryEvent.ProtoReflect.Descriptor instead.
+func (*InventoryEvent) Descriptor() ([]byte, []int) {
+	return file_events_v1_events_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *InventoryEvent) GetEvent() isInventoryEvent_Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *InventoryEvent) GetPartCreated() *PartCreated {
+	if x != nil {
+		if x, ok := x.Event.(*InventoryEvent_PartCreated); ok {
+			return x.PartCreated
+		}
+	}
+	return nil
+}
+
+func (x *InventoryEvent) GetPartUpdated() *PartUpdated {
+	if x != nil {
+		if x, ok := x.Event.(*InventoryEvent_PartUpdated); ok {
+			return x.PartUpdated
+		}
+	}
+	return nil
+}
+
+func (x *InventoryEvent) GetPartDeleted() *PartDeleted {
+	if x != nil {
+		if x, ok := x.Event.(*InventoryEvent_PartDeleted); ok {
+			return x.PartDeleted
+		}
+	}
+	return nil
+}
+
+func (x *InventoryEvent) GetStockChanged() *StockChanged {
+	if x != nil {
+		if x, ok := x.Event.(*InventoryEvent_StockChanged); ok {
+			return x.StockChanged
+		}
+	}
+	return nil
+}
+
+type isInventoryEvent_Event interface {
+	isInventoryEvent_Event()
+}
+
+type InventoryEvent_PartCreated struct {
+	PartCreated *PartCreated `protobuf:"bytes,1,opt,name=part_created,json=partCreated,proto3,oneof"`
+}
+
+type InventoryEvent_PartUpdated struct {
+	PartUpdated *PartUpdated `protobuf:"bytes,2,opt,name=part_updated,json=partUpdated,proto3,oneof"`
+}
+
+type InventoryEvent_PartDeleted struct {
+	PartDeleted *PartDeleted `protobuf:"bytes,3,opt,name=part_deleted,json=partDeleted,proto3,oneof"`
+}
+
+type InventoryEvent_StockChanged struct {
+	StockChanged *StockChanged `protobuf:"bytes,4,opt,name=stock_changed,json=stockChanged,proto3,oneof"`
+}
+
+func (*InventoryEvent_PartCreated) isInventoryEvent_Event() {}
+
+func (*InventoryEvent_PartUpdated) isInventoryEvent_Event() {}
+
+func (*InventoryEvent_PartDeleted) isInventoryEvent_Event() {}
+
+func (*InventoryEvent_StockChanged) isInventoryEvent_Event() {}
+
 var File_events_v1_events_proto protoreflect.FileDescriptor
 
 const file_events_v1_events_proto_rawDesc = "" +
 	"\n" +
-	"\x16events/v1/events.proto\x12\tevents.v1\"\xb8\x01\n" +
+	"\x16events/v1/events.proto\x12\tevents.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb8\x01\n" +
 	"\tOrderPaid\x12\x1d\n" +
 	"\n" +
 	"event_uuid\x18\x01 \x01(\tR\teventUuid\x12\x1d\n" +
@@ -201,7 +1730,156 @@ const file_events_v1_events_proto_rawDesc = "" +
 	"\n" +
 	"order_uuid\x18\x02 \x01(\tR\torderUuid\x12\x1b\n" +
 	"\tuser_uuid\x18\x03 \x01(\tR\buserUuid\x12$\n" +
-	"\x0ebuild_time_sec\x18\x04 \x01(\x03R\fbuildTimeSecBDZBgithub.com/linemk/rocket-shop/shared/pkg/proto/events/v1;events_v1b\x06proto3"
+	"\x0ebuild_time_sec\x18\x04 \x01(\x03R\fbuildTimeSec\"\xac\x01\n" +
+	"\x0fAuthTokenIssued\x12\x1d\n" +
+	"\n" +
+	"event_uuid\x18\x01 \x01(\tR\teventUuid\x12\x1b\n" +
+	"\tuser_uuid\x18\x02 \x01(\tR\buserUuid\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12\x18\n" +
+	"\apurpose\x18\x04 \x01(\tR\apurpose\x12\x14\n" +
+	"\x05token\x18\x05 \x01(\tR\x05token\x12\x17\n" +
+	"\attl_sec\x18\x06 \x01(\x03R\x06ttlSec\"\xd3\x02\n" +
+	"\x0eAuthAuditEvent\x12\x1d\n" +
+	"\n" +
+	"event_uuid\x18\x01 \x01(\tR\teventUuid\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x02 \x01(\tR\teventType\x12\x1b\n" +
+	"\tuser_uuid\x18\x03 \x01(\tR\buserUuid\x12\x14\n" +
+	"\x05login\x18\x04 \x01(\tR\x05login\x12!\n" +
+	"\fsession_uuid\x18\x05 \x01(\tR\vsessionUuid\x12\x1d\n" +
+	"\n" +
+	"ip_address\x18\x06 \x01(\tR\tipAddress\x12\x1d\n" +
+	"\n" +
+	"user_agent\x18\a \x01(\tR\tuserAgent\x12\x16\n" +
+	"\x06reason\x18\b \x01(\tR\x06reason\x12\x1a\n" +
+	"\bresource\x18\t \x01(\tR\bresource\x12;\n" +
+	"\voccurred_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\"\x81\x01\n" +
+	"\n" +
+	"UserErased\x12\x1d\n" +
+	"\n" +
+	"event_uuid\x18\x01 \x01(\tR\teventUuid\x12\x1b\n" +
+	"\tuser_uuid\x18\x02 \x01(\tR\buserUuid\x127\n" +
+	"\terased_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\berasedAt\"\x96\x02\n" +
+	"\x10PaymentCompleted\x12\x1d\n" +
+	"\n" +
+	"event_uuid\x18\x01 \x01(\tR\teventUuid\x12)\n" +
+	"\x10transaction_uuid\x18\x02 \x01(\tR\x0ftransactionUuid\x12\x1d\n" +
+	"\n" +
+	"order_uuid\x18\x03 \x01(\tR\torderUuid\x12\x1b\n" +
+	"\tuser_uuid\x18\x04 \x01(\tR\buserUuid\x12%\n" +
+	"\x0epayment_method\x18\x05 \x01(\tR\rpaymentMethod\x12\x16\n" +
+	"\x06amount\x18\x06 \x01(\x01R\x06amount\x12=\n" +
+	"\fcompleted_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\"\xfe\x01\n" +
+	"\rPaymentFailed\x12\x1d\n" +
+	"\n" +
+	"event_uuid\x18\x01 \x01(\tR\teventUuid\x12)\n" +
+	"\x10transaction_uuid\x18\x02 \x01(\tR\x0ftransactionUuid\x12\x1d\n" +
+	"\n" +
+	"order_uuid\x18\x03 \x01(\tR\torderUuid\x12\x1b\n" +
+	"\tuser_uuid\x18\x04 \x01(\tR\buserUuid\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12\x16\n" +
+	"\x06reason\x18\x06 \x01(\tR\x06reason\x127\n" +
+	"\tfailed_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\bfailedAt\"\xd2\x03\n" +
+	"\x0fInstallmentPaid\x12\x1d\n" +
+	"\n" +
+	"event_uuid\x18\x01 \x01(\tR\teventUuid\x12\x1b\n" +
+	"\tplan_uuid\x18\x02 \x01(\tR\bplanUuid\x12)\n" +
+	"\x10transaction_uuid\x18\x03 \x01(\tR\x0ftransactionUuid\x12\x1d\n" +
+	"\n" +
+	"order_uuid\x18\x04 \x01(\tR\torderUuid\x12\x1b\n" +
+	"\tuser_uuid\x18\x05 \x01(\tR\buserUuid\x12-\n" +
+	"\x12installment_number\x18\x06 \x01(\x05R\x11installmentNumber\x12-\n" +
+	"\x12installments_total\x18\a \x01(\x05R\x11installmentsTotal\x12\x16\n" +
+	"\x06amount\x18\b \x01(\x01R\x06amount\x12\x1f\n" +
+	"\vpaid_amount\x18\t \x01(\x01R\n" +
+	"paidAmount\x12)\n" +
+	"\x10remaining_amount\x18\n" +
+	" \x01(\x01R\x0fremainingAmount\x12%\n" +
+	"\x0eplan_completed\x18\v \x01(\bR\rplanCompleted\x123\n" +
+	"\apaid_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\x06paidAt\"\xd7\x02\n" +
+	"\x0ePaymentOverdue\x12\x1d\n" +
+	"\n" +
+	"event_uuid\x18\x01 \x01(\tR\teventUuid\x12\x1b\n" +
+	"\tplan_uuid\x18\x02 \x01(\tR\bplanUuid\x12\x1d\n" +
+	"\n" +
+	"order_uuid\x18\x03 \x01(\tR\torderUuid\x12\x1b\n" +
+	"\tuser_uuid\x18\x04 \x01(\tR\buserUuid\x12-\n" +
+	"\x12installment_number\x18\x05 \x01(\x05R\x11installmentNumber\x12\x16\n" +
+	"\x06amount\x18\x06 \x01(\x01R\x06amount\x121\n" +
+	"\x06due_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x05dueAt\x12\x16\n" +
+	"\x06reason\x18\b \x01(\tR\x06reason\x12;\n" +
+	"\vdetected_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"detectedAt\"\xa5\x03\n" +
+	"\x11ApprovalRequested\x12\x1d\n" +
+	"\n" +
+	"event_uuid\x18\x01 \x01(\tR\teventUuid\x12#\n" +
+	"\rapproval_uuid\x18\x02 \x01(\tR\fapprovalUuid\x12)\n" +
+	"\x10transaction_uuid\x18\x03 \x01(\tR\x0ftransactionUuid\x12\x1d\n" +
+	"\n" +
+	"order_uuid\x18\x04 \x01(\tR\torderUuid\x12\x1b\n" +
+	"\tuser_uuid\x18\x05 \x01(\tR\buserUuid\x12\x16\n" +
+	"\x06amount\x18\x06 \x01(\x01R\x06amount\x12\x1c\n" +
+	"\tapprovers\x18\a \x03(\tR\tapprovers\x12\x16\n" +
+	"\x06quorum\x18\b \x01(\x05R\x06quorum\x129\n" +
+	"\n" +
+	"expires_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12=\n" +
+	"\frequested_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\vrequestedAt\x12\x1d\n" +
+	"\n" +
+	"split_uuid\x18\v \x01(\tR\tsplitUuid\"\xd7\x02\n" +
+	"\fPaymentEvent\x12;\n" +
+	"\tcompleted\x18\x01 \x01(\v2\x1b.events.v1.PaymentCompletedH\x00R\tcompleted\x122\n" +
+	"\x06failed\x18\x02 \x01(\v2\x18.events.v1.PaymentFailedH\x00R\x06failed\x12G\n" +
+	"\x10installment_paid\x18\x03 \x01(\v2\x1a.events.v1.InstallmentPaidH\x00R\x0finstallmentPaid\x125\n" +
+	"\aoverdue\x18\x04 \x01(\v2\x19.events.v1.PaymentOverdueH\x00R\aoverdue\x12M\n" +
+	"\x12approval_requested\x18\x05 \x01(\v2\x1c.events.v1.ApprovalRequestedH\x00R\x11approvalRequestedB\a\n" +
+	"\x05event\"\x87\x02\n" +
+	"\vPartCreated\x12\x1d\n" +
+	"\n" +
+	"event_uuid\x18\x01 \x01(\tR\teventUuid\x12\x1b\n" +
+	"\tpart_uuid\x18\x02 \x01(\tR\bpartUuid\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1a\n" +
+	"\bcategory\x18\x04 \x01(\tR\bcategory\x12\x14\n" +
+	"\x05price\x18\x05 \x01(\x01R\x05price\x12%\n" +
+	"\x0estock_quantity\x18\x06 \x01(\x03R\rstockQuantity\x12\x12\n" +
+	"\x04tags\x18\a \x03(\tR\x04tags\x12;\n" +
+	"\voccurred_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\"\xae\x02\n" +
+	"\vPartUpdated\x12\x1d\n" +
+	"\n" +
+	"event_uuid\x18\x01 \x01(\tR\teventUuid\x12\x1b\n" +
+	"\tpart_uuid\x18\x02 \x01(\tR\bpartUuid\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1a\n" +
+	"\bcategory\x18\x04 \x01(\tR\bcategory\x12\x14\n" +
+	"\x05price\x18\x05 \x01(\x01R\x05price\x12%\n" +
+	"\x0eprevious_price\x18\x06 \x01(\x01R\rpreviousPrice\x12%\n" +
+	"\x0estock_quantity\x18\a \x01(\x03R\rstockQuantity\x12\x12\n" +
+	"\x04tags\x18\b \x03(\tR\x04tags\x12;\n" +
+	"\voccurred_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\"\x86\x01\n" +
+	"\vPartDeleted\x12\x1d\n" +
+	"\n" +
+	"event_uuid\x18\x01 \x01(\tR\teventUuid\x12\x1b\n" +
+	"\tpart_uuid\x18\x02 \x01(\tR\bpartUuid\x12;\n" +
+	"\voccurred_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\"\xee\x01\n" +
+	"\fStockChanged\x12\x1d\n" +
+	"\n" +
+	"event_uuid\x18\x01 \x01(\tR\teventUuid\x12\x1b\n" +
+	"\tpart_uuid\x18\x02 \x01(\tR\bpartUuid\x12+\n" +
+	"\x11previous_quantity\x18\x03 \x01(\x03R\x10previousQuantity\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\x03R\bquantity\x12\x1c\n" +
+	"\tavailable\x18\x05 \x01(\bR\tavailable\x12;\n" +
+	"\voccurred_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\"\x90\x02\n" +
+	"\x0eInventoryEvent\x12;\n" +
+	"\fpart_created\x18\x01 \x01(\v2\x16.events.v1.PartCreatedH\x00R\vpartCreated\x12;\n" +
+	"\fpart_updated\x18\x02 \x01(\v2\x16.events.v1.PartUpdatedH\x00R\vpartUpdated\x12;\n" +
+	"\fpart_deleted\x18\x03 \x01(\v2\x16.events.v1.PartDeletedH\x00R\vpartDeleted\x12>\n" +
+	"\rstock_changed\x18\x04 \x01(\v2\x17.events.v1.StockChangedH\x00R\fstockChangedB\a\n" +
+	"\x05eventBDZBgithub.com/linemk/rocket-shop/shared/pkg/proto/events/v1;events_v1b\x06proto3"
 
 var (
 	file_events_v1_events_proto_rawDescOnce sync.Once
@@ -215,17 +1893,54 @@ func file_events_v1_events_proto_rawDescGZIP() []byte {
 	return file_events_v1_events_proto_rawDescData
 }
 
-var file_events_v1_events_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_events_v1_events_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
 var file_events_v1_events_proto_goTypes = []any{
-	(*OrderPaid)(nil),     // 0: events.v1.OrderPaid
-	(*ShipAssembled)(nil), // 1: events.v1.ShipAssembled
+	(*OrderPaid)(nil),             // 0: events.v1.OrderPaid
+	(*ShipAssembled)(nil),         // 1: events.v1.ShipAssembled
+	(*AuthTokenIssued)(nil),       // 2: events.v1.AuthTokenIssued
+	(*AuthAuditEvent)(nil),        // 3: events.v1.AuthAuditEvent
+	(*UserErased)(nil),            // 4: events.v1.UserErased
+	(*PaymentCompleted)(nil),      // 5: events.v1.PaymentCompleted
+	(*PaymentFailed)(nil),         // 6: events.v1.PaymentFailed
+	(*InstallmentPaid)(nil),       // 7: events.v1.InstallmentPaid
+	(*PaymentOverdue)(nil),        // 8: events.v1.PaymentOverdue
+	(*ApprovalRequested)(nil),     // 9: events.v1.ApprovalRequested
+	(*PaymentEvent)(nil),          // 10: events.v1.PaymentEvent
+	(*PartCreated)(nil),           // 11: events.v1.PartCreated
+	(*PartUpdated)(nil),           // 12: events.v1.PartUpdated
+	(*PartDeleted)(nil),           // 13: events.v1.PartDeleted
+	(*StockChanged)(nil),          // 14: events.v1.StockChanged
+	(*InventoryEvent)(nil),        // 15: events.v1.InventoryEvent
+	(*timestamppb.Timestamp)(nil), // 16: google.protobuf.Timestamp
 }
 var file_events_v1_events_proto_depIdxs = []int32{
-	0, // [0:0] is the sub-list for method output_type
-	0, // [0:0] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	16, // 0: events.v1.AuthAuditEvent.occurred_at:type_name -> google.protobuf.Timestamp
+	16, // 1: events.v1.UserErased.erased_at:type_name -> google.protobuf.Timestamp
+	16, // 2: events.v1.PaymentCompleted.completed_at:type_name -> google.protobuf.Timestamp
+	16, // 3: events.v1.PaymentFailed.failed_at:type_name -> google.protobuf.Timestamp
+	16, // 4: events.v1.InstallmentPaid.paid_at:type_name -> google.protobuf.Timestamp
+	16, // 5: events.v1.PaymentOverdue.due_at:type_name -> google.protobuf.Timestamp
+	16, // 6: events.v1.PaymentOverdue.detected_at:type_name -> google.protobuf.Timestamp
+	16, // 7: events.v1.ApprovalRequested.expires_at:type_name -> google.protobuf.Timestamp
+	16, // 8: events.v1.ApprovalRequested.requested_at:type_name -> google.protobuf.Timestamp
+	5,  // 9: events.v1.PaymentEvent.completed:type_name -> events.v1.PaymentCompleted
+	6,  // 10: events.v1.PaymentEvent.failed:type_name -> events.v1.PaymentFailed
+	7,  // 11: events.v1.PaymentEvent.installment_paid:type_name -> events.v1.InstallmentPaid
+	8,  // 12: events.v1.PaymentEvent.overdue:type_name -> events.v1.PaymentOverdue
+	9,  // 13: events.v1.PaymentEvent.approval_requested:type_name -> events.v1.ApprovalRequested
+	16, // 14: events.v1.PartCreated.occurred_at:type_name -> google.protobuf.Timestamp
+	16, // 15: events.v1.PartUpdated.occurred_at:type_name -> google.protobuf.Timestamp
+	16, // 16: events.v1.PartDeleted.occurred_at:type_name -> google.protobuf.Timestamp
+	16, // 17: events.v1.StockChanged.occurred_at:type_name -> google.protobuf.Timestamp
+	11, // 18: events.v1.InventoryEvent.part_created:type_name -> events.v1.PartCreated
+	12, // 19: events.v1.InventoryEvent.part_updated:type_name -> events.v1.PartUpdated
+	13, // 20: events.v1.InventoryEvent.part_deleted:type_name -> events.v1.PartDeleted
+	14, // 21: events.v1.InventoryEvent.stock_changed:type_name -> events.v1.StockChanged
+	22, // [22:22] is the sub-list for method output_type
+	22, // [22:22] is the sub-list for method input_type
+	22, // [22:22] is the sub-list for extension type_name
+	22, // [22:22] is the sub-list for extension extendee
+	0,  // [0:22] is the sub-list for field type_name
 }
 
 func init() { file_events_v1_events_proto_init() }
@@ -233,13 +1948,26 @@ func file_events_v1_events_proto_init() {
 	if File_events_v1_events_proto != nil {
 		return
 	}
+	file_events_v1_events_proto_msgTypes[10].OneofWrappers = []any{
+		(*PaymentEvent_Completed)(nil),
+		(*PaymentEvent_Failed)(nil),
+		(*PaymentEvent_InstallmentPaid)(nil),
+		(*PaymentEvent_Overdue)(nil),
+		(*PaymentEvent_ApprovalRequested)(nil),
+	}
+	file_events_v1_events_proto_msgTypes[15].OneofWrappers = []any{
+		(*InventoryEvent_PartCreated)(nil),
+		(*InventoryEvent_PartUpdated)(nil),
+		(*InventoryEvent_PartDeleted)(nil),
+		(*InventoryEvent_StockChanged)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_events_v1_events_proto_rawDesc), len(file_events_v1_events_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   16,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
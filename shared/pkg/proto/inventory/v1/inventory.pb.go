@@ -9,6 +9,7 @@
 package inventory_v1
 
 import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	structpb "google.golang.org/protobuf/types/known/structpb"
@@ -81,6 +82,178 @@ func (Category) EnumDescriptor() ([]byte, []int) {
 	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{0}
 }
 
+// Поле сортировки списка деталей
+type PartsSortField int32
+
+const (
+	// PARTS_SORT_FIELD_UNSPECIFIED по релевантности при поиске, иначе по названию
+	PartsSortField_PARTS_SORT_FIELD_UNSPECIFIED    PartsSortField = 0
+	PartsSortField_PARTS_SORT_FIELD_NAME           PartsSortField = 1
+	PartsSortField_PARTS_SORT_FIELD_PRICE          PartsSortField = 2
+	PartsSortField_PARTS_SORT_FIELD_STOCK_QUANTITY PartsSortField = 3
+	PartsSortField_PARTS_SORT_FIELD_CREATED_AT     PartsSortField = 4
+	// PARTS_SORT_FIELD_RELEVANCE релевантность поискового запроса, всегда по убыванию. Доступна только вместе с query
+	PartsSortField_PARTS_SORT_FIELD_RELEVANCE PartsSortField = 5
+)
+
+// Enum value maps for PartsSortField.
+var (
+	PartsSortField_name = map[int32]string{
+		0: "PARTS_SORT_FIELD_UNSPECIFIED",
+		1: "PARTS_SORT_FIELD_NAME",
+		2: "PARTS_SORT_FIELD_PRICE",
+		3: "PARTS_SORT_FIELD_STOCK_QUANTITY",
+		4: "PARTS_SORT_FIELD_CREATED_AT",
+		5: "PARTS_SORT_FIELD_RELEVANCE",
+	}
+	PartsSortField_value = map[string]int32{
+		"PARTS_SORT_FIELD_UNSPECIFIED":    0,
+		"PARTS_SORT_FIELD_NAME":           1,
+		"PARTS_SORT_FIELD_PRICE":          2,
+		"PARTS_SORT_FIELD_STOCK_QUANTITY": 3,
+		"PARTS_SORT_FIELD_CREATED_AT":     4,
+		"PARTS_SORT_FIELD_RELEVANCE":      5,
+	}
+)
+
+func (x PartsSortField) Enum() *PartsSortField {
+	p := new(PartsSortField)
+	*p = x
+	return p
+}
+
+func (x PartsSortField) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PartsSortField) Descriptor() protoreflect.EnumDescriptor {
+	return file_inventory_v1_inventory_proto_enumTypes[1].Descriptor()
+}
+
+func (PartsSortField) Type() protoreflect.EnumType {
+	return &file_inventory_v1_inventory_proto_enumTypes[1]
+}
+
+func (x PartsSortField) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PartsSortField.Descriptor instead.
+func (PartsSortField) EnumDescriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{1}
+}
+
+// Направление сортировки
+type SortDirection int32
+
+const (
+	// SORT_DIRECTION_UNSPECIFIED по возрастанию
+	SortDirection_SORT_DIRECTION_UNSPECIFIED SortDirection = 0
+	SortDirection_SORT_DIRECTION_ASC         SortDirection = 1
+	SortDirection_SORT_DIRECTION_DESC        SortDirection = 2
+)
+
+// Enum value maps for SortDirection.
+var (
+	SortDirection_name = map[int32]string{
+		0: "SORT_DIRECTION_UNSPECIFIED",
+		1: "SORT_DIRECTION_ASC",
+		2: "SORT_DIRECTION_DESC",
+	}
+	SortDirection_value = map[string]int32{
+		"SORT_DIRECTION_UNSPECIFIED": 0,
+		"SORT_DIRECTION_ASC":         1,
+		"SORT_DIRECTION_DESC":        2,
+	}
+)
+
+func (x SortDirection) Enum() *SortDirection {
+	p := new(SortDirection)
+	*p = x
+	return p
+}
+
+func (x SortDirection) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SortDirection) Descriptor() protoreflect.EnumDescriptor {
+	return file_inventory_v1_inventory_proto_enumTypes[2].Descriptor()
+}
+
+func (SortDirection) Type() protoreflect.EnumType {
+	return &file_inventory_v1_inventory_proto_enumTypes[2]
+}
+
+func (x SortDirection) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SortDirection.Descriptor instead.
+func (SortDirection) EnumDescriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{2}
+}
+
+// Тип нарушения правил сборки
+type BuildViolationType int32
+
+const (
+	BuildViolationType_BUILD_VIOLATION_TYPE_UNSPECIFIED BuildViolationType = 0
+	// BUILD_VIOLATION_TYPE_UNKNOWN_PART деталь не найдена в каталоге
+	BuildViolationType_BUILD_VIOLATION_TYPE_UNKNOWN_PART BuildViolationType = 1
+	// BUILD_VIOLATION_TYPE_MISSING_CATEGORY в сборке меньше деталей обязательной категории, чем требуется
+	BuildViolationType_BUILD_VIOLATION_TYPE_MISSING_CATEGORY BuildViolationType = 2
+	// BUILD_VIOLATION_TYPE_INCOMPATIBLE_PARTS две детали сборки несовместимы
+	BuildViolationType_BUILD_VIOLATION_TYPE_INCOMPATIBLE_PARTS BuildViolationType = 3
+	// BUILD_VIOLATION_TYPE_MISSING_DEPENDENCY детали нужна другая деталь или деталь категории, которой нет в сборке
+	BuildViolationType_BUILD_VIOLATION_TYPE_MISSING_DEPENDENCY BuildViolationType = 4
+)
+
+// Enum value maps for BuildViolationType.
+var (
+	BuildViolationType_name = map[int32]string{
+		0: "BUILD_VIOLATION_TYPE_UNSPECIFIED",
+		1: "BUILD_VIOLATION_TYPE_UNKNOWN_PART",
+		2: "BUILD_VIOLATION_TYPE_MISSING_CATEGORY",
+		3: "BUILD_VIOLATION_TYPE_INCOMPATIBLE_PARTS",
+		4: "BUILD_VIOLATION_TYPE_MISSING_DEPENDENCY",
+	}
+	BuildViolationType_value = map[string]int32{
+		"BUILD_VIOLATION_TYPE_UNSPECIFIED":        0,
+		"BUILD_VIOLATION_TYPE_UNKNOWN_PART":       1,
+		"BUILD_VIOLATION_TYPE_MISSING_CATEGORY":   2,
+		"BUILD_VIOLATION_TYPE_INCOMPATIBLE_PARTS": 3,
+		"BUILD_VIOLATION_TYPE_MISSING_DEPENDENCY": 4,
+	}
+)
+
+func (x BuildViolationType) Enum() *BuildViolationType {
+	p := new(BuildViolationType)
+	*p = x
+	return p
+}
+
+func (x BuildViolationType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BuildViolationType) Descriptor() protoreflect.EnumDescriptor {
+	return file_inventory_v1_inventory_proto_enumTypes[3].Descriptor()
+}
+
+func (BuildViolationType) Type() protoreflect.EnumType {
+	return &file_inventory_v1_inventory_proto_enumTypes[3]
+}
+
+func (x BuildViolationType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BuildViolationType.Descriptor instead.
+func (BuildViolationType) EnumDescriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{3}
+}
+
 // Размеры детали
 type Dimensions struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -541,7 +714,23 @@ func (x *GetPartResponse) GetPart() *Part {
 type ListPartsRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// filter фильтр по деталям (все поля опциональны)
-	Filter        *PartsFilter `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	Filter *PartsFilter `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	// query полнотекстовый поиск по названию, описанию и тегам. Пусто — без поиска
+	Query string `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
+	// min_price минимальная цена включительно. 0 — без ограничения
+	MinPrice float64 `protobuf:"fixed64,3,opt,name=min_price,json=minPrice,proto3" json:"min_price,omitempty"`
+	// max_price максимальная цена включительно. 0 — без ограничения
+	MaxPrice float64 `protobuf:"fixed64,4,opt,name=max_price,json=maxPrice,proto3" json:"max_price,omitempty"`
+	// in_stock_only возвращать только детали, которые есть на складе
+	InStockOnly bool `protobuf:"varint,5,opt,name=in_stock_only,json=inStockOnly,proto3" json:"in_stock_only,omitempty"`
+	// sort_field поле сортировки
+	SortField PartsSortField `protobuf:"varint,6,opt,name=sort_field,json=sortField,proto3,enum=inventory.v1.PartsSortField" json:"sort_field,omitempty"`
+	// sort_direction направление сортировки
+	SortDirection SortDirection `protobuf:"varint,7,opt,name=sort_direction,json=sortDirection,proto3,enum=inventory.v1.SortDirection" json:"sort_direction,omitempty"`
+	// page_size размер страницы. 0 — размер по умолчанию, больше максимального — максимальный
+	PageSize int32 `protobuf:"varint,8,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// page_token токен страницы из next_page_token предыдущего ответа. Пусто — первая страница
+	PageToken     string `protobuf:"bytes,9,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -583,11 +772,69 @@ func (x *ListPartsRequest) GetFilter() *PartsFilter {
 	return nil
 }
 
+func (x *ListPartsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *ListPartsRequest) GetMinPrice() float64 {
+	if x != nil {
+		return x.MinPrice
+	}
+	return 0
+}
+
+func (x *ListPartsRequest) GetMaxPrice() float64 {
+	if x != nil {
+		return x.MaxPrice
+	}
+	return 0
+}
+
+func (x *ListPartsRequest) GetInStockOnly() bool {
+	if x != nil {
+		return x.InStockOnly
+	}
+	return false
+}
+
+func (x *ListPartsRequest) GetSortField() PartsSortField {
+	if x != nil {
+		return x.SortField
+	}
+	return PartsSortField_PARTS_SORT_FIELD_UNSPECIFIED
+}
+
+func (x *ListPartsRequest) GetSortDirection() SortDirection {
+	if x != nil {
+		return x.SortDirection
+	}
+	return SortDirection_SORT_DIRECTION_UNSPECIFIED
+}
+
+func (x *ListPartsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListPartsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
 // Ответ со списком деталей
 type ListPartsResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// parts список найденных деталей
-	Parts         []*Part `protobuf:"bytes,1,rep,name=parts,proto3" json:"parts,omitempty"`
+	Parts []*Part `protobuf:"bytes,1,rep,name=parts,proto3" json:"parts,omitempty"`
+	// next_page_token токен следующей страницы. Пусто — страница последняя
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -629,11 +876,210 @@ func (x *ListPartsResponse) GetParts() []*Part {
 	return nil
 }
 
+func (x *ListPartsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Нарушение правила сборки
+type BuildViolation struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// type тип нарушения
+	Type BuildViolationType `protobuf:"varint,1,opt,name=type,proto3,enum=inventory.v1.BuildViolationType" json:"type,omitempty"`
+	// part_uuids детали сборки, нарушающие правило
+	PartUuids []string `protobuf:"bytes,2,rep,name=part_uuids,json=partUuids,proto3" json:"part_uuids,omitempty"`
+	// category недостающая категория для MISSING_CATEGORY и MISSING_DEPENDENCY
+	Category Category `protobuf:"varint,3,opt,name=category,proto3,enum=inventory.v1.Category" json:"category,omitempty"`
+	// required_part_uuid недостающая деталь для MISSING_DEPENDENCY
+	RequiredPartUuid string `protobuf:"bytes,4,opt,name=required_part_uuid,json=requiredPartUuid,proto3" json:"required_part_uuid,omitempty"`
+	// rule_uuid нарушенное правило совместимости. Пусто для UNKNOWN_PART
+	RuleUuid string `protobuf:"bytes,5,opt,name=rule_uuid,json=ruleUuid,proto3" json:"rule_uuid,omitempty"`
+	// message описание нарушения
+	Message       string `protobuf:"bytes,6,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BuildViolation) Reset() {
+	*x = BuildViolation{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BuildViolation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuildViolation) ProtoMessage() {}
+
+func (x *BuildViolation) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BuildViolation.ProtoReflect.Descriptor instead.
+func (*BuildViolation) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *BuildViolation) GetType() BuildViolationType {
+	if x != nil {
+		return x.Type
+	}
+	return BuildViolationType_BUILD_VIOLATION_TYPE_UNSPECIFIED
+}
+
+func (x *BuildViolation) GetPartUuids() []string {
+	if x != nil {
+		return x.PartUuids
+	}
+	return nil
+}
+
+func (x *BuildViolation) GetCategory() Category {
+	if x != nil {
+		return x.Category
+	}
+	return Category_CATEGORY_UNSPECIFIED
+}
+
+func (x *BuildViolation) GetRequiredPartUuid() string {
+	if x != nil {
+		return x.RequiredPartUuid
+	}
+	return ""
+}
+
+func (x *BuildViolation) GetRuleUuid() string {
+	if x != nil {
+		return x.RuleUuid
+	}
+	return ""
+}
+
+func (x *BuildViolation) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Запрос на проверку сборки
+type ValidateBuildRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// part_uuids детали сборки; повторяющийся UUID означает несколько одинаковых деталей
+	PartUuids     []string `protobuf:"bytes,1,rep,name=part_uuids,json=partUuids,proto3" json:"part_uuids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateBuildRequest) Reset() {
+	*x = ValidateBuildRequest{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateBuildRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateBuildRequest) ProtoMessage() {}
+
+func (x *ValidateBuildRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateBuildRequest.ProtoReflect.Descriptor instead.
+func (*ValidateBuildRequest) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ValidateBuildRequest) GetPartUuids() []string {
+	if x != nil {
+		return x.PartUuids
+	}
+	return nil
+}
+
+// Результат проверки сборки
+type ValidateBuildResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// valid true, если нарушений нет
+	Valid bool `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	// violations найденные нарушения
+	Violations    []*BuildViolation `protobuf:"bytes,2,rep,name=violations,proto3" json:"violations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateBuildResponse) Reset() {
+	*x = ValidateBuildResponse{}
+	mi := &file_inventory_v1_inventory_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateBuildResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateBuildResponse) ProtoMessage() {}
+
+func (x *ValidateBuildResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_inventory_v1_inventory_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateBuildResponse.ProtoReflect.Descriptor instead.
+func (*ValidateBuildResponse) Descriptor() ([]byte, []int) {
+	return file_inventory_v1_inventory_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ValidateBuildResponse) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *ValidateBuildResponse) GetViolations() []*BuildViolation {
+	if x != nil {
+		return x.Violations
+	}
+	return nil
+}
+
 var File_inventory_v1_inventory_proto protoreflect.FileDescriptor
 
 const file_inventory_v1_inventory_proto_rawDesc = "" +
 	"\n" +
-	"\x1cinventory/v1/inventory.proto\x12\finventory.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1cgoogle/protobuf/struct.proto\"j\n" +
+	"\x1cinventory/v1/inventory.proto\x12\finventory.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1cgoogle/api/annotations.proto\"j\n" +
 	"\n" +
 	"Dimensions\x12\x16\n" +
 	"\x06length\x18\x01 \x01(\x01R\x06length\x12\x14\n" +
@@ -673,20 +1119,65 @@ const file_inventory_v1_inventory_proto_rawDesc = "" +
 	"\x0eGetPartRequest\x12\x12\n" +
 	"\x04uuid\x18\x01 \x01(\tR\x04uuid\"9\n" +
 	"\x0fGetPartResponse\x12&\n" +
-	"\x04part\x18\x01 \x01(\v2\x12.inventory.v1.PartR\x04part\"E\n" +
+	"\x04part\x18\x01 \x01(\v2\x12.inventory.v1.PartR\x04part\"\xf6\x02\n" +
 	"\x10ListPartsRequest\x121\n" +
-	"\x06filter\x18\x01 \x01(\v2\x19.inventory.v1.PartsFilterR\x06filter\"=\n" +
+	"\x06filter\x18\x01 \x01(\v2\x19.inventory.v1.PartsFilterR\x06filter\x12\x14\n" +
+	"\x05query\x18\x02 \x01(\tR\x05query\x12\x1b\n" +
+	"\tmin_price\x18\x03 \x01(\x01R\bminPrice\x12\x1b\n" +
+	"\tmax_price\x18\x04 \x01(\x01R\bmaxPrice\x12\"\n" +
+	"\rin_stock_only\x18\x05 \x01(\bR\vinStockOnly\x12;\n" +
+	"\n" +
+	"sort_field\x18\x06 \x01(\x0e2\x1c.inventory.v1.PartsSortFieldR\tsortField\x12B\n" +
+	"\x0esort_direction\x18\a \x01(\x0e2\x1b.inventory.v1.SortDirectionR\rsortDirection\x12\x1b\n" +
+	"\tpage_size\x18\b \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\t \x01(\tR\tpageToken\"e\n" +
 	"\x11ListPartsResponse\x12(\n" +
-	"\x05parts\x18\x01 \x03(\v2\x12.inventory.v1.PartR\x05parts*v\n" +
+	"\x05parts\x18\x01 \x03(\v2\x12.inventory.v1.PartR\x05parts\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xfe\x01\n" +
+	"\x0eBuildViolation\x124\n" +
+	"\x04type\x18\x01 \x01(\x0e2 .inventory.v1.BuildViolationTypeR\x04type\x12\x1d\n" +
+	"\n" +
+	"part_uuids\x18\x02 \x03(\tR\tpartUuids\x122\n" +
+	"\bcategory\x18\x03 \x01(\x0e2\x16.inventory.v1.CategoryR\bcategory\x12,\n" +
+	"\x12required_part_uuid\x18\x04 \x01(\tR\x10requiredPartUuid\x12\x1b\n" +
+	"\trule_uuid\x18\x05 \x01(\tR\bruleUuid\x12\x18\n" +
+	"\amessage\x18\x06 \x01(\tR\amessage\"5\n" +
+	"\x14ValidateBuildRequest\x12\x1d\n" +
+	"\n" +
+	"part_uuids\x18\x01 \x03(\tR\tpartUuids\"k\n" +
+	"\x15ValidateBuildResponse\x12\x14\n" +
+	"\x05valid\x18\x01 \x01(\bR\x05valid\x12<\n" +
+	"\n" +
+	"violations\x18\x02 \x03(\v2\x1c.inventory.v1.BuildViolationR\n" +
+	"violations*v\n" +
 	"\bCategory\x12\x18\n" +
 	"\x14CATEGORY_UNSPECIFIED\x10\x00\x12\x13\n" +
 	"\x0fCATEGORY_ENGINE\x10\x01\x12\x11\n" +
 	"\rCATEGORY_FUEL\x10\x02\x12\x15\n" +
 	"\x11CATEGORY_PORTHOLE\x10\x03\x12\x11\n" +
-	"\rCATEGORY_WING\x10\x042\xa8\x01\n" +
-	"\x10InventoryService\x12F\n" +
-	"\aGetPart\x12\x1c.inventory.v1.GetPartRequest\x1a\x1d.inventory.v1.GetPartResponse\x12L\n" +
-	"\tListParts\x12\x1e.inventory.v1.ListPartsRequest\x1a\x1f.inventory.v1.ListPartsResponseBJZHgithub.com/linemk/rocket-shop/shared/pkg/proto/inventory/v1;inventory_v1b\x06proto3"
+	"\rCATEGORY_WING\x10\x04*\xcf\x01\n" +
+	"\x0ePartsSortField\x12 \n" +
+	"\x1cPARTS_SORT_FIELD_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15PARTS_SORT_FIELD_NAME\x10\x01\x12\x1a\n" +
+	"\x16PARTS_SORT_FIELD_PRICE\x10\x02\x12#\n" +
+	"\x1fPARTS_SORT_FIELD_STOCK_QUANTITY\x10\x03\x12\x1f\n" +
+	"\x1bPARTS_SORT_FIELD_CREATED_AT\x10\x04\x12\x1e\n" +
+	"\x1aPARTS_SORT_FIELD_RELEVANCE\x10\x05*`\n" +
+	"\rSortDirection\x12\x1e\n" +
+	"\x1aSORT_DIRECTION_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12SORT_DIRECTION_ASC\x10\x01\x12\x17\n" +
+	"\x13SORT_DIRECTION_DESC\x10\x02*\xe6\x01\n" +
+	"\x12BuildViolationType\x12$\n" +
+	" BUILD_VIOLATION_TYPE_UNSPECIFIED\x10\x00\x12%\n" +
+	"!BUILD_VIOLATION_TYPE_UNKNOWN_PART\x10\x01\x12)\n" +
+	"%BUILD_VIOLATION_TYPE_MISSING_CATEGORY\x10\x02\x12+\n" +
+	"'BUILD_VIOLATION_TYPE_INCOMPATIBLE_PARTS\x10\x03\x12+\n" +
+	"'BUILD_VIOLATION_TYPE_MISSING_DEPENDENCY\x10\x042\xfa\x02\n" +
+	"\x10InventoryService\x12n\n" +
+	"\aGetPart\x12\x1c.inventory.v1.GetPartRequest\x1a\x1d.inventory.v1.GetPartResponse\"&\x82\xd3\xe4\x93\x02 \x12\x1e/api/v1/inventory/parts/{uuid}\x12m\n" +
+	"\tListParts\x12\x1e.inventory.v1.ListPartsRequest\x1a\x1f.inventory.v1.ListPartsResponse\"\x1f\x82\xd3\xe4\x93\x02\x19\x12\x17/api/v1/inventory/parts\x12\x86\x01\n" +
+	"\rValidateBuild\x12\".inventory.v1.ValidateBuildRequest\x1a#.inventory.v1.ValidateBuildResponse\",\x82\xd3\xe4\x93\x02&:\x01*\"!/api/v1/inventory/builds:validateBJZHgithub.com/linemk/rocket-shop/shared/pkg/proto/inventory/v1;inventory_v1b\x06proto3"
 
 var (
 	file_inventory_v1_inventory_proto_rawDescOnce sync.Once
@@ -700,41 +1191,54 @@ func file_inventory_v1_inventory_proto_rawDescGZIP() []byte {
 	return file_inventory_v1_inventory_proto_rawDescData
 }
 
-var file_inventory_v1_inventory_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_inventory_v1_inventory_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_inventory_v1_inventory_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_inventory_v1_inventory_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
 var file_inventory_v1_inventory_proto_goTypes = []any{
 	(Category)(0),                 // 0: inventory.v1.Category
-	(*Dimensions)(nil),            // 1: inventory.v1.Dimensions
-	(*Manufacturer)(nil),          // 2: inventory.v1.Manufacturer
-	(*PartsFilter)(nil),           // 3: inventory.v1.PartsFilter
-	(*Part)(nil),                  // 4: inventory.v1.Part
-	(*GetPartRequest)(nil),        // 5: inventory.v1.GetPartRequest
-	(*GetPartResponse)(nil),       // 6: inventory.v1.GetPartResponse
-	(*ListPartsRequest)(nil),      // 7: inventory.v1.ListPartsRequest
-	(*ListPartsResponse)(nil),     // 8: inventory.v1.ListPartsResponse
-	(*structpb.Struct)(nil),       // 9: google.protobuf.Struct
-	(*timestamppb.Timestamp)(nil), // 10: google.protobuf.Timestamp
+	(PartsSortField)(0),           // 1: inventory.v1.PartsSortField
+	(SortDirection)(0),            // 2: inventory.v1.SortDirection
+	(BuildViolationType)(0),       // 3: inventory.v1.BuildViolationType
+	(*Dimensions)(nil),            // 4: inventory.v1.Dimensions
+	(*Manufacturer)(nil),          // 5: inventory.v1.Manufacturer
+	(*PartsFilter)(nil),           // 6: inventory.v1.PartsFilter
+	(*Part)(nil),                  // 7: inventory.v1.Part
+	(*GetPartRequest)(nil),        // 8: inventory.v1.GetPartRequest
+	(*GetPartResponse)(nil),       // 9: inventory.v1.GetPartResponse
+	(*ListPartsRequest)(nil),      // 10: inventory.v1.ListPartsRequest
+	(*ListPartsResponse)(nil),     // 11: inventory.v1.ListPartsResponse
+	(*BuildViolation)(nil),        // 12: inventory.v1.BuildViolation
+	(*ValidateBuildRequest)(nil),  // 13: inventory.v1.ValidateBuildRequest
+	(*ValidateBuildResponse)(nil), // 14: inventory.v1.ValidateBuildResponse
+	(*structpb.Struct)(nil),       // 15: google.protobuf.Struct
+	(*timestamppb.Timestamp)(nil), // 16: google.protobuf.Timestamp
 }
 var file_inventory_v1_inventory_proto_depIdxs = []int32{
 	0,  // 0: inventory.v1.PartsFilter.categories:type_name -> inventory.v1.Category
 	0,  // 1: inventory.v1.Part.category:type_name -> inventory.v1.Category
-	1,  // 2: inventory.v1.Part.dimensions:type_name -> inventory.v1.Dimensions
-	2,  // 3: inventory.v1.Part.manufacturer:type_name -> inventory.v1.Manufacturer
-	9,  // 4: inventory.v1.Part.metadata:type_name -> google.protobuf.Struct
-	10, // 5: inventory.v1.Part.created_at:type_name -> google.protobuf.Timestamp
-	10, // 6: inventory.v1.Part.updated_at:type_name -> google.protobuf.Timestamp
-	4,  // 7: inventory.v1.GetPartResponse.part:type_name -> inventory.v1.Part
-	3,  // 8: inventory.v1.ListPartsRequest.filter:type_name -> inventory.v1.PartsFilter
-	4,  // 9: inventory.v1.ListPartsResponse.parts:type_name -> inventory.v1.Part
-	5,  // 10: inventory.v1.InventoryService.GetPart:input_type -> inventory.v1.GetPartRequest
-	7,  // 11: inventory.v1.InventoryService.ListParts:input_type -> inventory.v1.ListPartsRequest
-	6,  // 12: inventory.v1.InventoryService.GetPart:output_type -> inventory.v1.GetPartResponse
-	8,  // 13: inventory.v1.InventoryService.ListParts:output_type -> inventory.v1.ListPartsResponse
-	12, // [12:14] is the sub-list for method output_type
-	10, // [10:12] is the sub-list for method input_type
-	10, // [10:10] is the sub-list for extension type_name
-	10, // [10:10] is the sub-list for extension extendee
-	0,  // [0:10] is the sub-list for field type_name
+	4,  // 2: inventory.v1.Part.dimensions:type_name -> inventory.v1.Dimensions
+	5,  // 3: inventory.v1.Part.manufacturer:type_name -> inventory.v1.Manufacturer
+	15, // 4: inventory.v1.Part.metadata:type_name -> google.protobuf.Struct
+	16, // 5: inventory.v1.Part.created_at:type_name -> google.protobuf.Timestamp
+	16, // 6: inventory.v1.Part.updated_at:type_name -> google.protobuf.Timestamp
+	7,  // 7: inventory.v1.GetPartResponse.part:type_name -> inventory.v1.Part
+	6,  // 8: inventory.v1.ListPartsRequest.filter:type_name -> inventory.v1.PartsFilter
+	1,  // 9: inventory.v1.ListPartsRequest.sort_field:type_name -> inventory.v1.PartsSortField
+	2,  // 10: inventory.v1.ListPartsRequest.sort_direction:type_name -> inventory.v1.SortDirection
+	7,  // 11: inventory.v1.ListPartsResponse.parts:type_name -> inventory.v1.Part
+	3,  // 12: inventory.v1.BuildViolation.type:type_name -> inventory.v1.BuildViolationType
+	0,  // 13: inventory.v1.BuildViolation.category:type_name -> inventory.v1.Category
+	12, // 14: inventory.v1.ValidateBuildResponse.violations:type_name -> inventory.v1.BuildViolation
+	8,  // 15: inventory.v1.InventoryService.GetPart:input_type -> inventory.v1.GetPartRequest
+	10, // 16: inventory.v1.InventoryService.ListParts:input_type -> inventory.v1.ListPartsRequest
+	13, // 17: inventory.v1.InventoryService.ValidateBuild:input_type -> inventory.v1.ValidateBuildRequest
+	9,  // 18: inventory.v1.InventoryService.GetPart:output_type -> inventory.v1.GetPartResponse
+	11, // 19: inventory.v1.InventoryService.ListParts:output_type -> inventory.v1.ListPartsResponse
+	14, // 20: inventory.v1.InventoryService.ValidateBuild:output_type -> inventory.v1.ValidateBuildResponse
+	18, // [18:21] is the sub-list for method output_type
+	15, // [15:18] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
 }
 
 func init() { file_inventory_v1_inventory_proto_init() }
@@ -747,8 +1251,8 @@ func file_inventory_v1_inventory_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_inventory_v1_inventory_proto_rawDesc), len(file_inventory_v1_inventory_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   8,
+			NumEnums:      4,
+			NumMessages:   11,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -21,8 +21,9 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	InventoryService_GetPart_FullMethodName   = "/inventory.v1.InventoryService/GetPart"
-	InventoryService_ListParts_FullMethodName = "/inventory.v1.InventoryService/ListParts"
+	InventoryService_GetPart_FullMethodName       = "/inventory.v1.InventoryService/GetPart"
+	InventoryService_ListParts_FullMethodName     = "/inventory.v1.InventoryService/ListParts"
+	InventoryService_ValidateBuild_FullMethodName = "/inventory.v1.InventoryService/ValidateBuild"
 )
 
 // InventoryServiceClient is the client API for InventoryService service.
@@ -33,8 +34,11 @@ const (
 type InventoryServiceClient interface {
 	// GetPart возвращает информацию о детали по её UUID
 	GetPart(ctx context.Context, in *GetPartRequest, opts ...grpc.CallOption) (*GetPartResponse, error)
-	// ListParts возвращает список деталей с возможностью фильтрации
+	// ListParts возвращает страницу деталей с возможностью фильтрации, полнотекстового поиска и сортировки
 	ListParts(ctx context.Context, in *ListPartsRequest, opts ...grpc.CallOption) (*ListPartsResponse, error)
+	// ValidateBuild проверяет, что детали образуют допустимую ракету: совместимы между собой
+	// и покрывают обязательные категории. Нарушения правил возвращаются в ответе, а не ошибкой
+	ValidateBuild(ctx context.Context, in *ValidateBuildRequest, opts ...grpc.CallOption) (*ValidateBuildResponse, error)
 }
 
 type inventoryServiceClient struct {
@@ -65,6 +69,16 @@ func (c *inventoryServiceClient) ListParts(ctx context.Context, in *ListPartsReq
 	return out, nil
 }
 
+func (c *inventoryServiceClient) ValidateBuild(ctx context.Context, in *ValidateBuildRequest, opts ...grpc.CallOption) (*ValidateBuildResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ValidateBuildResponse)
+	err := c.cc.Invoke(ctx, InventoryService_ValidateBuild_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // InventoryServiceServer is the server API for InventoryService service.
 // All implementations must embed UnimplementedInventoryServiceServer
 // for forward compatibility.
@@ -73,8 +87,11 @@ func (c *inventoryServiceClient) ListParts(ctx context.Context, in *ListPartsReq
 type InventoryServiceServer interface {
 	// GetPart возвращает информацию о детали по её UUID
 	GetPart(context.Context, *GetPartRequest) (*GetPartResponse, error)
-	// ListParts возвращает список деталей с возможностью фильтрации
+	// ListParts возвращает страницу деталей с возможностью фильтрации, полнотекстового поиска и сортировки
 	ListParts(context.Context, *ListPartsRequest) (*ListPartsResponse, error)
+	// ValidateBuild проверяет, что детали образуют допустимую ракету: совместимы между собой
+	// и покрывают обязательные категории. Нарушения правил возвращаются в ответе, а не ошибкой
+	ValidateBuild(context.Context, *ValidateBuildRequest) (*ValidateBuildResponse, error)
 	mustEmbedUnimplementedInventoryServiceServer()
 }
 
@@ -91,6 +108,9 @@ func (UnimplementedInventoryServiceServer) GetPart(context.Context, *GetPartRequ
 func (UnimplementedInventoryServiceServer) ListParts(context.Context, *ListPartsRequest) (*ListPartsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListParts not implemented")
 }
+func (UnimplementedInventoryServiceServer) ValidateBuild(context.Context, *ValidateBuildRequest) (*ValidateBuildResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateBuild not implemented")
+}
 func (UnimplementedInventoryServiceServer) mustEmbedUnimplementedInventoryServiceServer() {}
 func (UnimplementedInventoryServiceServer) testEmbeddedByValue()                          {}
 
@@ -148,6 +168,24 @@ func _InventoryService_ListParts_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _InventoryService_ValidateBuild_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateBuildRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InventoryServiceServer).ValidateBuild(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InventoryService_ValidateBuild_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InventoryServiceServer).ValidateBuild(ctx, req.(*ValidateBuildRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // InventoryService_ServiceDesc is the grpc.ServiceDesc for InventoryService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -163,6 +201,10 @@ var InventoryService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListParts",
 			Handler:    _InventoryService_ListParts_Handler,
 		},
+		{
+			MethodName: "ValidateBuild",
+			Handler:    _InventoryService_ValidateBuild_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "inventory/v1/inventory.proto",
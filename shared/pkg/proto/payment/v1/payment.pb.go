@@ -11,6 +11,7 @@ package payment_v1
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -88,8 +89,14 @@ type PayOrderRequest struct {
 	UserUuid string `protobuf:"bytes,2,opt,name=user_uuid,json=userUuid,proto3" json:"user_uuid,omitempty"`
 	// payment_method выбранный способ оплаты
 	PaymentMethod PaymentMethod `protobuf:"varint,3,opt,name=payment_method,json=paymentMethod,proto3,enum=payment.v1.PaymentMethod" json:"payment_method,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	// amount сумма к оплате
+	Amount float64 `protobuf:"fixed64,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	// idempotency_key UUID попытки оплаты, который заказ выбирает до вызова. Транзакция создается с этим UUID,
+	// а повторный запрос с тем же ключом возвращает ее, не списывая заказ повторно. Если ключ не задан,
+	// UUID транзакции выбирает PaymentService
+	IdempotencyKey string `protobuf:"bytes,5,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *PayOrderRequest) Reset() {
@@ -143,13 +150,29 @@ func (x *PayOrderRequest) GetPaymentMethod() PaymentMethod {
 	return PaymentMethod_PAYMENT_METHOD_UNSPECIFIED
 }
 
+func (x *PayOrderRequest) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *PayOrderRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
 // Ответ с результатом оплаты
 type PayOrderResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// transaction_uuid UUID транзакции оплаты
 	TransactionUuid string `protobuf:"bytes,1,opt,name=transaction_uuid,json=transactionUuid,proto3" json:"transaction_uuid,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	// status статус транзакции на момент ответа (обычно PENDING)
+	Status        string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *PayOrderResponse) Reset() {
@@ -189,27 +212,2822 @@ func (x *PayOrderResponse) GetTransactionUuid() string {
 	return ""
 }
 
-var File_payment_v1_payment_proto protoreflect.FileDescriptor
+func (x *PayOrderResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
 
-const file_payment_v1_payment_proto_rawDesc = "" +
-	"\n" +
-	"\x18payment/v1/payment.proto\x12\n" +
-	"payment.v1\"\x8f\x01\n" +
-	"\x0fPayOrderRequest\x12\x1d\n" +
-	"\n" +
-	"order_uuid\x18\x01 \x01(\tR\torderUuid\x12\x1b\n" +
-	"\tuser_uuid\x18\x02 \x01(\tR\buserUuid\x12@\n" +
-	"\x0epayment_method\x18\x03 \x01(\x0e2\x19.payment.v1.PaymentMethodR\rpaymentMethod\"=\n" +
-	"\x10PayOrderResponse\x12)\n" +
-	"\x10transaction_uuid\x18\x01 \x01(\tR\x0ftransactionUuid*\xa3\x01\n" +
+// Запрос на раздельную оплату заказа
+type PaySplitRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// order_uuid UUID заказа
+	OrderUuid string `protobuf:"bytes,1,opt,name=order_uuid,json=orderUuid,proto3" json:"order_uuid,omitempty"`
+	// user_uuid UUID пользователя, который инициирует оплату
+	UserUuid string `protobuf:"bytes,2,opt,name=user_uuid,json=userUuid,proto3" json:"user_uuid,omitempty"`
+	// amount сумма заказа, которой должна быть равна сумма частей
+	Amount float64 `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	// tenders части оплаты (не менее двух)
+	Tenders []*Tender `protobuf:"bytes,4,rep,name=tenders,proto3" json:"tenders,omitempty"`
+	// idempotency_key UUID попытки оплаты, который заказ выбирает до вызова. Группа частей создается с этим UUID,
+	// а повторный запрос с тем же ключом возвращает ее, не списывая части повторно. Если ключ не задан,
+	// UUID группы выбирает PaymentService
+	IdempotencyKey string `protobuf:"bytes,5,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *PaySplitRequest) Reset() {
+	*x = PaySplitRequest{}
+	mi := &file_payment_v1_payment_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PaySplitRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaySplitRequest) ProtoMessage() {}
+
+func (x *PaySplitRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaySplitRequest.ProtoReflect.Descriptor instead.
+func (*PaySplitRequest) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PaySplitRequest) GetOrderUuid() string {
+	if x != nil {
+		return x.OrderUuid
+	}
+	return ""
+}
+
+func (x *PaySplitRequest) GetUserUuid() string {
+	if x != nil {
+		return x.UserUuid
+	}
+	return ""
+}
+
+func (x *PaySplitRequest) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *PaySplitRequest) GetTenders() []*Tender {
+	if x != nil {
+		return x.Tenders
+	}
+	return nil
+}
+
+func (x *PaySplitRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+// Часть раздельной оплаты
+type Tender struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// payment_method способ оплаты части
+	PaymentMethod PaymentMethod `protobuf:"varint,1,opt,name=payment_method,json=paymentMethod,proto3,enum=payment.v1.PaymentMethod" json:"payment_method,omitempty"`
+	// amount сумма части
+	Amount        float64 `protobuf:"fixed64,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Tender) Reset() {
+	*x = Tender{}
+	mi := &file_payment_v1_payment_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Tender) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tender) ProtoMessage() {}
+
+func (x *Tender) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tender.ProtoReflect.Descriptor instead.
+func (*Tender) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Tender) GetPaymentMethod() PaymentMethod {
+	if x != nil {
+		return x.PaymentMethod
+	}
+	return PaymentMethod_PAYMENT_METHOD_UNSPECIFIED
+}
+
+func (x *Tender) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+// Ответ с результатом раздельной оплаты
+type PaySplitResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// split_uuid UUID группы транзакций; результат оплаты публикуется событием с этим UUID
+	SplitUuid string `protobuf:"bytes,1,opt,name=split_uuid,json=splitUuid,proto3" json:"split_uuid,omitempty"`
+	// status статус группы на момент ответа (PENDING, COMPLETED, FAILED)
+	Status string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	// transactions транзакции частей оплаты
+	Transactions  []*Transaction `protobuf:"bytes,3,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PaySplitResponse) Reset() {
+	*x = PaySplitResponse{}
+	mi := &file_payment_v1_payment_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PaySplitResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaySplitResponse) ProtoMessage() {}
+
+func (x *PaySplitResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaySplitResponse.ProtoReflect.Descriptor instead.
+func (*PaySplitResponse) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PaySplitResponse) GetSplitUuid() string {
+	if x != nil {
+		return x.SplitUuid
+	}
+	return ""
+}
+
+func (x *PaySplitResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *PaySplitResponse) GetTransactions() []*Transaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+// Запрос на подтверждение транзакции
+type ConfirmTransactionRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// transaction_uuid UUID транзакции в статусе PENDING
+	TransactionUuid string `protobuf:"bytes,1,opt,name=transaction_uuid,json=transactionUuid,proto3" json:"transaction_uuid,omitempty"`
+	// approved true, если платеж подтвержден, false - если отклонен
+	Approved bool `protobuf:"varint,2,opt,name=approved,proto3" json:"approved,omitempty"`
+	// reason причина отклонения
+	Reason        string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmTransactionRequest) Reset() {
+	*x = ConfirmTransactionRequest{}
+	mi := &file_payment_v1_payment_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmTransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmTransactionRequest) ProtoMessage() {}
+
+func (x *ConfirmTransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmTransactionRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmTransactionRequest) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ConfirmTransactionRequest) GetTransactionUuid() string {
+	if x != nil {
+		return x.TransactionUuid
+	}
+	return ""
+}
+
+func (x *ConfirmTransactionRequest) GetApproved() bool {
+	if x != nil {
+		return x.Approved
+	}
+	return false
+}
+
+func (x *ConfirmTransactionRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// Ответ с итоговым состоянием транзакции
+type ConfirmTransactionResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// transaction транзакция после подтверждения
+	Transaction   *Transaction `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmTransactionResponse) Reset() {
+	*x = ConfirmTransactionResponse{}
+	mi := &file_payment_v1_payment_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmTransactionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmTransactionResponse) ProtoMessage() {}
+
+func (x *ConfirmTransactionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmTransactionResponse.ProtoReflect.Descriptor instead.
+func (*ConfirmTransactionResponse) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ConfirmTransactionResponse) GetTransaction() *Transaction {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+// Запрос на ручную проверку транзакции
+type ReviewTransactionRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// transaction_uuid UUID транзакции, ожидающей ручной проверки
+	TransactionUuid string `protobuf:"bytes,1,opt,name=transaction_uuid,json=transactionUuid,proto3" json:"transaction_uuid,omitempty"`
+	// approved true, если платеж можно списать, false - если его нужно отклонить
+	Approved bool `protobuf:"varint,2,opt,name=approved,proto3" json:"approved,omitempty"`
+	// reason причина отклонения
+	Reason        string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewTransactionRequest) Reset() {
+	*x = ReviewTransactionRequest{}
+	mi := &file_payment_v1_payment_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewTransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewTransactionRequest) ProtoMessage() {}
+
+func (x *ReviewTransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewTransactionRequest.ProtoReflect.Descriptor instead.
+func (*ReviewTransactionRequest) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ReviewTransactionRequest) GetTransactionUuid() string {
+	if x != nil {
+		return x.TransactionUuid
+	}
+	return ""
+}
+
+func (x *ReviewTransactionRequest) GetApproved() bool {
+	if x != nil {
+		return x.Approved
+	}
+	return false
+}
+
+func (x *ReviewTransactionRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// Ответ с состоянием транзакции после ручной проверки
+type ReviewTransactionResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// transaction транзакция после проверки
+	Transaction   *Transaction `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewTransactionResponse) Reset() {
+	*x = ReviewTransactionResponse{}
+	mi := &file_payment_v1_payment_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewTransactionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewTransactionResponse) ProtoMessage() {}
+
+func (x *ReviewTransactionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewTransactionResponse.ProtoReflect.Descriptor instead.
+func (*ReviewTransactionResponse) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ReviewTransactionResponse) GetTransaction() *Transaction {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+// Запрос на получение очереди ручной проверки
+type ListReviewQueueRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReviewQueueRequest) Reset() {
+	*x = ListReviewQueueRequest{}
+	mi := &file_payment_v1_payment_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReviewQueueRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReviewQueueRequest) ProtoMessage() {}
+
+func (x *ListReviewQueueRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReviewQueueRequest.ProtoReflect.Descriptor instead.
+func (*ListReviewQueueRequest) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{9}
+}
+
+// Ответ с очередью ручной проверки
+type ListReviewQueueResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// transactions транзакции, ожидающие ручной проверки
+	Transactions  []*Transaction `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReviewQueueResponse) Reset() {
+	*x = ListReviewQueueResponse{}
+	mi := &file_payment_v1_payment_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReviewQueueResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReviewQueueResponse) ProtoMessage() {}
+
+func (x *ListReviewQueueResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReviewQueueResponse.ProtoReflect.Descriptor instead.
+func (*ListReviewQueueResponse) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListReviewQueueResponse) GetTransactions() []*Transaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+// Запрос на решение по согласованию платежа
+type DecideApprovalRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// approval_uuid UUID запроса на согласование
+	ApprovalUuid string `protobuf:"bytes,1,opt,name=approval_uuid,json=approvalUuid,proto3" json:"approval_uuid,omitempty"`
+	// approver_id необязательный логин согласующего: согласующий определяется по сессии IAM, переданный логин должен с ней совпадать
+	ApproverId string `protobuf:"bytes,2,opt,name=approver_id,json=approverId,proto3" json:"approver_id,omitempty"`
+	// approved true, если согласующий одобряет платеж, false - если отклоняет
+	Approved bool `protobuf:"varint,3,opt,name=approved,proto3" json:"approved,omitempty"`
+	// reason комментарий к решению
+	Reason        string `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DecideApprovalRequest) Reset() {
+	*x = DecideApprovalRequest{}
+	mi := &file_payment_v1_payment_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DecideApprovalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DecideApprovalRequest) ProtoMessage() {}
+
+func (x *DecideApprovalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecideApprovalRequest.ProtoReflect.Descriptor instead.
+func (*DecideApprovalRequest) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DecideApprovalRequest) GetApprovalUuid() string {
+	if x != nil {
+		return x.ApprovalUuid
+	}
+	return ""
+}
+
+func (x *DecideApprovalRequest) GetApproverId() string {
+	if x != nil {
+		return x.ApproverId
+	}
+	return ""
+}
+
+func (x *DecideApprovalRequest) GetApproved() bool {
+	if x != nil {
+		return x.Approved
+	}
+	return false
+}
+
+func (x *DecideApprovalRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// Ответ с состоянием запроса после решения
+type DecideApprovalResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// approval запрос на согласование
+	Approval      *Approval `protobuf:"bytes,1,opt,name=approval,proto3" json:"approval,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DecideApprovalResponse) Reset() {
+	*x = DecideApprovalResponse{}
+	mi := &file_payment_v1_payment_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DecideApprovalResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DecideApprovalResponse) ProtoMessage() {}
+
+func (x *DecideApprovalResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecideApprovalResponse.ProtoReflect.Descriptor instead.
+func (*DecideApprovalResponse) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DecideApprovalResponse) GetApproval() *Approval {
+	if x != nil {
+		return x.Approval
+	}
+	return nil
+}
+
+// Запрос на получение запроса на согласование
+type GetApprovalRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// approval_uuid UUID запроса на согласование
+	ApprovalUuid  string `protobuf:"bytes,1,opt,name=approval_uuid,json=approvalUuid,proto3" json:"approval_uuid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetApprovalRequest) Reset() {
+	*x = GetApprovalRequest{}
+	mi := &file_payment_v1_payment_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetApprovalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetApprovalRequest) ProtoMessage() {}
+
+func (x *GetApprovalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetApprovalRequest.ProtoReflect.Descriptor instead.
+func (*GetApprovalRequest) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetApprovalRequest) GetApprovalUuid() string {
+	if x != nil {
+		return x.ApprovalUuid
+	}
+	return ""
+}
+
+// Ответ с запросом на согласование
+type GetApprovalResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// approval запрос на согласование
+	Approval      *Approval `protobuf:"bytes,1,opt,name=approval,proto3" json:"approval,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetApprovalResponse) Reset() {
+	*x = GetApprovalResponse{}
+	mi := &file_payment_v1_payment_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetApprovalResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetApprovalResponse) ProtoMessage() {}
+
+func (x *GetApprovalResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetApprovalResponse.ProtoReflect.Descriptor instead.
+func (*GetApprovalResponse) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetApprovalResponse) GetApproval() *Approval {
+	if x != nil {
+		return x.Approval
+	}
+	return nil
+}
+
+// Запрос на получение запросов, ожидающих решения согласующего
+type ListPendingApprovalsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// approver_id необязательный логин согласующего: согласующий определяется по сессии IAM, переданный логин должен с ней совпадать
+	ApproverId    string `protobuf:"bytes,1,opt,name=approver_id,json=approverId,proto3" json:"approver_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPendingApprovalsRequest) Reset() {
+	*x = ListPendingApprovalsRequest{}
+	mi := &file_payment_v1_payment_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPendingApprovalsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPendingApprovalsRequest) ProtoMessage() {}
+
+func (x *ListPendingApprovalsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPendingApprovalsRequest.ProtoReflect.Descriptor instead.
+func (*ListPendingApprovalsRequest) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListPendingApprovalsRequest) GetApproverId() string {
+	if x != nil {
+		return x.ApproverId
+	}
+	return ""
+}
+
+// Ответ со списком запросов, ожидающих решения
+type ListPendingApprovalsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// approvals запросы на согласование
+	Approvals     []*Approval `protobuf:"bytes,1,rep,name=approvals,proto3" json:"approvals,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPendingApprovalsResponse) Reset() {
+	*x = ListPendingApprovalsResponse{}
+	mi := &file_payment_v1_payment_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPendingApprovalsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPendingApprovalsResponse) ProtoMessage() {}
+
+func (x *ListPendingApprovalsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPendingApprovalsResponse.ProtoReflect.Descriptor instead.
+func (*ListPendingApprovalsResponse) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListPendingApprovalsResponse) GetApprovals() []*Approval {
+	if x != nil {
+		return x.Approvals
+	}
+	return nil
+}
+
+// Запрос на согласование платежа средствами инвестора
+type Approval struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// approval_uuid UUID запроса
+	ApprovalUuid string `protobuf:"bytes,1,opt,name=approval_uuid,json=approvalUuid,proto3" json:"approval_uuid,omitempty"`
+	// transaction_uuid UUID согласуемой транзакции
+	TransactionUuid string `protobuf:"bytes,2,opt,name=transaction_uuid,json=transactionUuid,proto3" json:"transaction_uuid,omitempty"`
+	// order_uuid UUID заказа
+	OrderUuid string `protobuf:"bytes,3,opt,name=order_uuid,json=orderUuid,proto3" json:"order_uuid,omitempty"`
+	// user_uuid UUID пользователя
+	UserUuid string `protobuf:"bytes,4,opt,name=user_uuid,json=userUuid,proto3" json:"user_uuid,omitempty"`
+	// amount сумма платежа
+	Amount float64 `protobuf:"fixed64,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	// approvers согласующие, которые могут принять решение
+	Approvers []string `protobuf:"bytes,6,rep,name=approvers,proto3" json:"approvers,omitempty"`
+	// quorum число одобрений, необходимое для списания
+	Quorum int32 `protobuf:"varint,7,opt,name=quorum,proto3" json:"quorum,omitempty"`
+	// status статус запроса (PENDING, APPROVED, REJECTED, EXPIRED)
+	Status string `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`
+	// decisions принятые решения в порядке поступления
+	Decisions []*ApprovalDecision `protobuf:"bytes,9,rep,name=decisions,proto3" json:"decisions,omitempty"`
+	// expires_at срок, после которого несогласованный платеж отменяется
+	ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// created_at время создания запроса
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// updated_at время последнего изменения запроса
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Approval) Reset() {
+	*x = Approval{}
+	mi := &file_payment_v1_payment_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Approval) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Approval) ProtoMessage() {}
+
+func (x *Approval) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Approval.ProtoReflect.Descriptor instead.
+func (*Approval) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Approval) GetApprovalUuid() string {
+	if x != nil {
+		return x.ApprovalUuid
+	}
+	return ""
+}
+
+func (x *Approval) GetTransactionUuid() string {
+	if x != nil {
+		return x.TransactionUuid
+	}
+	return ""
+}
+
+func (x *Approval) GetOrderUuid() string {
+	if x != nil {
+		return x.OrderUuid
+	}
+	return ""
+}
+
+func (x *Approval) GetUserUuid() string {
+	if x != nil {
+		return x.UserUuid
+	}
+	return ""
+}
+
+func (x *Approval) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Approval) GetApprovers() []string {
+	if x != nil {
+		return x.Approvers
+	}
+	return nil
+}
+
+func (x *Approval) GetQuorum() int32 {
+	if x != nil {
+		return x.Quorum
+	}
+	return 0
+}
+
+func (x *Approval) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Approval) GetDecisions() []*ApprovalDecision {
+	if x != nil {
+		return x.Decisions
+	}
+	return nil
+}
+
+func (x *Approval) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *Approval) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Approval) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// Решение согласующего
+type ApprovalDecision struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// approver_id идентификатор согласующего
+	ApproverId string `protobuf:"bytes,1,opt,name=approver_id,json=approverId,proto3" json:"approver_id,omitempty"`
+	// approved true, если платеж одобрен
+	Approved bool `protobuf:"varint,2,opt,name=approved,proto3" json:"approved,omitempty"`
+	// reason комментарий к решению
+	Reason string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	// decided_at время решения
+	DecidedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=decided_at,json=decidedAt,proto3" json:"decided_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApprovalDecision) Reset() {
+	*x = ApprovalDecision{}
+	mi := &file_payment_v1_payment_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApprovalDecision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApprovalDecision) ProtoMessage() {}
+
+func (x *ApprovalDecision) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApprovalDecision.ProtoReflect.Descriptor instead.
+func (*ApprovalDecision) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ApprovalDecision) GetApproverId() string {
+	if x != nil {
+		return x.ApproverId
+	}
+	return ""
+}
+
+func (x *ApprovalDecision) GetApproved() bool {
+	if x != nil {
+		return x.Approved
+	}
+	return false
+}
+
+func (x *ApprovalDecision) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ApprovalDecision) GetDecidedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DecidedAt
+	}
+	return nil
+}
+
+// Запрос на расчет планов рассрочки
+type QuoteInstallmentsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// amount сумма заказа
+	Amount        float64 `protobuf:"fixed64,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuoteInstallmentsRequest) Reset() {
+	*x = QuoteInstallmentsRequest{}
+	mi := &file_payment_v1_payment_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuoteInstallmentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuoteInstallmentsRequest) ProtoMessage() {}
+
+func (x *QuoteInstallmentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuoteInstallmentsRequest.ProtoReflect.Descriptor instead.
+func (*QuoteInstallmentsRequest) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *QuoteInstallmentsRequest) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+// Ответ с доступными планами рассрочки
+type QuoteInstallmentsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// quotes планы рассрочки в порядке возрастания числа платежей
+	Quotes        []*InstallmentQuote `protobuf:"bytes,1,rep,name=quotes,proto3" json:"quotes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuoteInstallmentsResponse) Reset() {
+	*x = QuoteInstallmentsResponse{}
+	mi := &file_payment_v1_payment_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuoteInstallmentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuoteInstallmentsResponse) ProtoMessage() {}
+
+func (x *QuoteInstallmentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuoteInstallmentsResponse.ProtoReflect.Descriptor instead.
+func (*QuoteInstallmentsResponse) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *QuoteInstallmentsResponse) GetQuotes() []*InstallmentQuote {
+	if x != nil {
+		return x.Quotes
+	}
+	return nil
+}
+
+// Запрос на создание плана рассрочки
+type CreateInstallmentPlanRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// order_uuid UUID заказа
+	OrderUuid string `protobuf:"bytes,1,opt,name=order_uuid,json=orderUuid,proto3" json:"order_uuid,omitempty"`
+	// user_uuid UUID пользователя
+	UserUuid string `protobuf:"bytes,2,opt,name=user_uuid,json=userUuid,proto3" json:"user_uuid,omitempty"`
+	// amount сумма заказа
+	Amount float64 `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	// installments число платежей, одно из значений QuoteInstallments
+	Installments  int32 `protobuf:"varint,4,opt,name=installments,proto3" json:"installments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateInstallmentPlanRequest) Reset() {
+	*x = CreateInstallmentPlanRequest{}
+	mi := &file_payment_v1_payment_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateInstallmentPlanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateInstallmentPlanRequest) ProtoMessage() {}
+
+func (x *CreateInstallmentPlanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateInstallmentPlanRequest.ProtoReflect.Descriptor instead.
+func (*CreateInstallmentPlanRequest) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *CreateInstallmentPlanRequest) GetOrderUuid() string {
+	if x != nil {
+		return x.OrderUuid
+	}
+	return ""
+}
+
+func (x *CreateInstallmentPlanRequest) GetUserUuid() string {
+	if x != nil {
+		return x.UserUuid
+	}
+	return ""
+}
+
+func (x *CreateInstallmentPlanRequest) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *CreateInstallmentPlanRequest) GetInstallments() int32 {
+	if x != nil {
+		return x.Installments
+	}
+	return 0
+}
+
+// Ответ с созданным планом рассрочки
+type CreateInstallmentPlanResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// plan план рассрочки
+	Plan          *InstallmentPlan `protobuf:"bytes,1,opt,name=plan,proto3" json:"plan,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateInstallmentPlanResponse) Reset() {
+	*x = CreateInstallmentPlanResponse{}
+	mi := &file_payment_v1_payment_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateInstallmentPlanResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateInstallmentPlanResponse) ProtoMessage() {}
+
+func (x *CreateInstallmentPlanResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateInstallmentPlanResponse.ProtoReflect.Descriptor instead.
+func (*CreateInstallmentPlanResponse) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *CreateInstallmentPlanResponse) GetPlan() *InstallmentPlan {
+	if x != nil {
+		return x.Plan
+	}
+	return nil
+}
+
+// Запрос на получение плана рассрочки
+type GetInstallmentPlanRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// plan_uuid UUID плана рассрочки
+	PlanUuid      string `protobuf:"bytes,1,opt,name=plan_uuid,json=planUuid,proto3" json:"plan_uuid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInstallmentPlanRequest) Reset() {
+	*x = GetInstallmentPlanRequest{}
+	mi := &file_payment_v1_payment_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInstallmentPlanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInstallmentPlanRequest) ProtoMessage() {}
+
+func (x *GetInstallmentPlanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInstallmentPlanRequest.ProtoReflect.Descriptor instead.
+func (*GetInstallmentPlanRequest) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetInstallmentPlanRequest) GetPlanUuid() string {
+	if x != nil {
+		return x.PlanUuid
+	}
+	return ""
+}
+
+// Ответ с планом рассрочки
+type GetInstallmentPlanResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// plan план рассрочки
+	Plan          *InstallmentPlan `protobuf:"bytes,1,opt,name=plan,proto3" json:"plan,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInstallmentPlanResponse) Reset() {
+	*x = GetInstallmentPlanResponse{}
+	mi := &file_payment_v1_payment_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInstallmentPlanResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInstallmentPlanResponse) ProtoMessage() {}
+
+func (x *GetInstallmentPlanResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInstallmentPlanResponse.ProtoReflect.Descriptor instead.
+func (*GetInstallmentPlanResponse) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetInstallmentPlanResponse) GetPlan() *InstallmentPlan {
+	if x != nil {
+		return x.Plan
+	}
+	return nil
+}
+
+// Расчет плана рассрочки
+type InstallmentQuote struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// installments число платежей
+	Installments int32 `protobuf:"varint,1,opt,name=installments,proto3" json:"installments,omitempty"`
+	// monthly_rate месячная процентная ставка (0.01 = 1%)
+	MonthlyRate float64 `protobuf:"fixed64,2,opt,name=monthly_rate,json=monthlyRate,proto3" json:"monthly_rate,omitempty"`
+	// payments суммы платежей по порядку
+	Payments []float64 `protobuf:"fixed64,3,rep,packed,name=payments,proto3" json:"payments,omitempty"`
+	// total_amount сумма всех платежей
+	TotalAmount float64 `protobuf:"fixed64,4,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
+	// overpayment переплата относительно суммы заказа
+	Overpayment   float64 `protobuf:"fixed64,5,opt,name=overpayment,proto3" json:"overpayment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InstallmentQuote) Reset() {
+	*x = InstallmentQuote{}
+	mi := &file_payment_v1_payment_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstallmentQuote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstallmentQuote) ProtoMessage() {}
+
+func (x *InstallmentQuote) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstallmentQuote.ProtoReflect.Descriptor instead.
+func (*InstallmentQuote) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *InstallmentQuote) GetInstallments() int32 {
+	if x != nil {
+		return x.Installments
+	}
+	return 0
+}
+
+func (x *InstallmentQuote) GetMonthlyRate() float64 {
+	if x != nil {
+		return x.MonthlyRate
+	}
+	return 0
+}
+
+func (x *InstallmentQuote) GetPayments() []float64 {
+	if x != nil {
+		return x.Payments
+	}
+	return nil
+}
+
+func (x *InstallmentQuote) GetTotalAmount() float64 {
+	if x != nil {
+		return x.TotalAmount
+	}
+	return 0
+}
+
+func (x *InstallmentQuote) GetOverpayment() float64 {
+	if x != nil {
+		return x.Overpayment
+	}
+	return 0
+}
+
+// План рассрочки
+type InstallmentPlan struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// plan_uuid UUID плана
+	PlanUuid string `protobuf:"bytes,1,opt,name=plan_uuid,json=planUuid,proto3" json:"plan_uuid,omitempty"`
+	// order_uuid UUID заказа
+	OrderUuid string `protobuf:"bytes,2,opt,name=order_uuid,json=orderUuid,proto3" json:"order_uuid,omitempty"`
+	// user_uuid UUID пользователя
+	UserUuid string `protobuf:"bytes,3,opt,name=user_uuid,json=userUuid,proto3" json:"user_uuid,omitempty"`
+	// payment_method способ оплаты платежей плана
+	PaymentMethod PaymentMethod `protobuf:"varint,4,opt,name=payment_method,json=paymentMethod,proto3,enum=payment.v1.PaymentMethod" json:"payment_method,omitempty"`
+	// principal сумма заказа
+	Principal float64 `protobuf:"fixed64,5,opt,name=principal,proto3" json:"principal,omitempty"`
+	// monthly_rate месячная процентная ставка
+	MonthlyRate float64 `protobuf:"fixed64,6,opt,name=monthly_rate,json=monthlyRate,proto3" json:"monthly_rate,omitempty"`
+	// total_amount сумма всех платежей
+	TotalAmount float64 `protobuf:"fixed64,7,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
+	// paid_amount сумма оплаченных платежей
+	PaidAmount float64 `protobuf:"fixed64,8,opt,name=paid_amount,json=paidAmount,proto3" json:"paid_amount,omitempty"`
+	// status статус плана (ACTIVE, COMPLETED, CANCELLED)
+	Status string `protobuf:"bytes,9,opt,name=status,proto3" json:"status,omitempty"`
+	// installments график платежей
+	Installments []*Installment `protobuf:"bytes,10,rep,name=installments,proto3" json:"installments,omitempty"`
+	// created_at время создания плана
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// updated_at время последнего изменения плана
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InstallmentPlan) Reset() {
+	*x = InstallmentPlan{}
+	mi := &file_payment_v1_payment_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstallmentPlan) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstallmentPlan) ProtoMessage() {}
+
+func (x *InstallmentPlan) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstallmentPlan.ProtoReflect.Descriptor instead.
+func (*InstallmentPlan) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *InstallmentPlan) GetPlanUuid() string {
+	if x != nil {
+		return x.PlanUuid
+	}
+	return ""
+}
+
+func (x *InstallmentPlan) GetOrderUuid() string {
+	if x != nil {
+		return x.OrderUuid
+	}
+	return ""
+}
+
+func (x *InstallmentPlan) GetUserUuid() string {
+	if x != nil {
+		return x.UserUuid
+	}
+	return ""
+}
+
+func (x *InstallmentPlan) GetPaymentMethod() PaymentMethod {
+	if x != nil {
+		return x.PaymentMethod
+	}
+	return PaymentMethod_PAYMENT_METHOD_UNSPECIFIED
+}
+
+func (x *InstallmentPlan) GetPrincipal() float64 {
+	if x != nil {
+		return x.Principal
+	}
+	return 0
+}
+
+func (x *InstallmentPlan) GetMonthlyRate() float64 {
+	if x != nil {
+		return x.MonthlyRate
+	}
+	return 0
+}
+
+func (x *InstallmentPlan) GetTotalAmount() float64 {
+	if x != nil {
+		return x.TotalAmount
+	}
+	return 0
+}
+
+func (x *InstallmentPlan) GetPaidAmount() float64 {
+	if x != nil {
+		return x.PaidAmount
+	}
+	return 0
+}
+
+func (x *InstallmentPlan) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *InstallmentPlan) GetInstallments() []*Installment {
+	if x != nil {
+		return x.Installments
+	}
+	return nil
+}
+
+func (x *InstallmentPlan) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *InstallmentPlan) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// Платеж по графику рассрочки
+type Installment struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// number номер платежа, начиная с 1
+	Number int32 `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	// amount сумма платежа
+	Amount float64 `protobuf:"fixed64,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	// due_at срок платежа
+	DueAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=due_at,json=dueAt,proto3" json:"due_at,omitempty"`
+	// status статус платежа (SCHEDULED, PAID, OVERDUE)
+	Status string `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	// transaction_uuid UUID транзакции последней попытки списания
+	TransactionUuid string `protobuf:"bytes,5,opt,name=transaction_uuid,json=transactionUuid,proto3" json:"transaction_uuid,omitempty"`
+	// attempts число попыток списания
+	Attempts int32 `protobuf:"varint,6,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	// paid_at время оплаты
+	PaidAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=paid_at,json=paidAt,proto3" json:"paid_at,omitempty"`
+	// failure_reason причина отказа последней попытки списания
+	FailureReason string `protobuf:"bytes,8,opt,name=failure_reason,json=failureReason,proto3" json:"failure_reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Installment) Reset() {
+	*x = Installment{}
+	mi := &file_payment_v1_payment_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Installment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Installment) ProtoMessage() {}
+
+func (x *Installment) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Installment.ProtoReflect.Descriptor instead.
+func (*Installment) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *Installment) GetNumber() int32 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *Installment) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Installment) GetDueAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueAt
+	}
+	return nil
+}
+
+func (x *Installment) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Installment) GetTransactionUuid() string {
+	if x != nil {
+		return x.TransactionUuid
+	}
+	return ""
+}
+
+func (x *Installment) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *Installment) GetPaidAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PaidAt
+	}
+	return nil
+}
+
+func (x *Installment) GetFailureReason() string {
+	if x != nil {
+		return x.FailureReason
+	}
+	return ""
+}
+
+// Запрос на получение транзакций заказа
+type ListTransactionsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// order_uuid UUID заказа
+	OrderUuid     string `protobuf:"bytes,1,opt,name=order_uuid,json=orderUuid,proto3" json:"order_uuid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTransactionsRequest) Reset() {
+	*x = ListTransactionsRequest{}
+	mi := &file_payment_v1_payment_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTransactionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransactionsRequest) ProtoMessage() {}
+
+func (x *ListTransactionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransactionsRequest.ProtoReflect.Descriptor instead.
+func (*ListTransactionsRequest) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ListTransactionsRequest) GetOrderUuid() string {
+	if x != nil {
+		return x.OrderUuid
+	}
+	return ""
+}
+
+// Ответ со списком транзакций заказа
+type ListTransactionsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// transactions транзакции заказа
+	Transactions  []*Transaction `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTransactionsResponse) Reset() {
+	*x = ListTransactionsResponse{}
+	mi := &file_payment_v1_payment_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTransactionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransactionsResponse) ProtoMessage() {}
+
+func (x *ListTransactionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransactionsResponse.ProtoReflect.Descriptor instead.
+func (*ListTransactionsResponse) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ListTransactionsResponse) GetTransactions() []*Transaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+// Запрос на получение транзакций пользователя
+type ListUserTransactionsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// user_uuid UUID пользователя
+	UserUuid      string `protobuf:"bytes,1,opt,name=user_uuid,json=userUuid,proto3" json:"user_uuid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUserTransactionsRequest) Reset() {
+	*x = ListUserTransactionsRequest{}
+	mi := &file_payment_v1_payment_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUserTransactionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUserTransactionsRequest) ProtoMessage() {}
+
+func (x *ListUserTransactionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUserTransactionsRequest.ProtoReflect.Descriptor instead.
+func (*ListUserTransactionsRequest) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ListUserTransactionsRequest) GetUserUuid() string {
+	if x != nil {
+		return x.UserUuid
+	}
+	return ""
+}
+
+// Ответ со списком транзакций пользователя
+type ListUserTransactionsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// transactions транзакции пользователя
+	Transactions  []*Transaction `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUserTransactionsResponse) Reset() {
+	*x = ListUserTransactionsResponse{}
+	mi := &file_payment_v1_payment_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUserTransactionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUserTransactionsResponse) ProtoMessage() {}
+
+func (x *ListUserTransactionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUserTransactionsResponse.ProtoReflect.Descriptor instead.
+func (*ListUserTransactionsResponse) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ListUserTransactionsResponse) GetTransactions() []*Transaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+// Транзакция оплаты заказа
+type Transaction struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// transaction_uuid UUID транзакции
+	TransactionUuid string `protobuf:"bytes,1,opt,name=transaction_uuid,json=transactionUuid,proto3" json:"transaction_uuid,omitempty"`
+	// order_uuid UUID оплаченного заказа
+	OrderUuid string `protobuf:"bytes,2,opt,name=order_uuid,json=orderUuid,proto3" json:"order_uuid,omitempty"`
+	// user_uuid UUID пользователя
+	UserUuid string `protobuf:"bytes,3,opt,name=user_uuid,json=userUuid,proto3" json:"user_uuid,omitempty"`
+	// payment_method способ оплаты
+	PaymentMethod PaymentMethod `protobuf:"varint,4,opt,name=payment_method,json=paymentMethod,proto3,enum=payment.v1.PaymentMethod" json:"payment_method,omitempty"`
+	// amount сумма платежа
+	Amount float64 `protobuf:"fixed64,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	// status статус транзакции (PENDING, COMPLETED, FAILED, CANCELLED, REFUNDED)
+	Status string `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	// created_at время создания транзакции
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// updated_at время последнего изменения транзакции
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// failure_reason причина отказа провайдера для FAILED и CANCELLED транзакций
+	FailureReason string `protobuf:"bytes,9,opt,name=failure_reason,json=failureReason,proto3" json:"failure_reason,omitempty"`
+	// fraud_decision решение антифрод-проверки (APPROVE, REVIEW, DECLINE)
+	FraudDecision string `protobuf:"bytes,10,opt,name=fraud_decision,json=fraudDecision,proto3" json:"fraud_decision,omitempty"`
+	// fraud_reasons сработавшие правила антифрод-проверки
+	FraudReasons []string `protobuf:"bytes,11,rep,name=fraud_reasons,json=fraudReasons,proto3" json:"fraud_reasons,omitempty"`
+	// installment_plan_uuid UUID плана рассрочки, если транзакция - платеж по его графику
+	InstallmentPlanUuid string `protobuf:"bytes,12,opt,name=installment_plan_uuid,json=installmentPlanUuid,proto3" json:"installment_plan_uuid,omitempty"`
+	// approval_uuid UUID запроса на согласование для платежа средствами инвестора
+	ApprovalUuid string `protobuf:"bytes,13,opt,name=approval_uuid,json=approvalUuid,proto3" json:"approval_uuid,omitempty"`
+	// split_uuid UUID группы транзакций раздельной оплаты, в которую входит транзакция
+	SplitUuid string `protobuf:"bytes,14,opt,name=split_uuid,json=splitUuid,proto3" json:"split_uuid,omitempty"`
+	// reviewed_by логин проверяющего, принявшего решение по ручной проверке
+	ReviewedBy    string `protobuf:"bytes,15,opt,name=reviewed_by,json=reviewedBy,proto3" json:"reviewed_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Transaction) Reset() {
+	*x = Transaction{}
+	mi := &file_payment_v1_payment_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Transaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transaction) ProtoMessage() {}
+
+func (x *Transaction) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transaction.ProtoReflect.Descriptor instead.
+func (*Transaction) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *Transaction) GetTransactionUuid() string {
+	if x != nil {
+		return x.TransactionUuid
+	}
+	return ""
+}
+
+func (x *Transaction) GetOrderUuid() string {
+	if x != nil {
+		return x.OrderUuid
+	}
+	return ""
+}
+
+func (x *Transaction) GetUserUuid() string {
+	if x != nil {
+		return x.UserUuid
+	}
+	return ""
+}
+
+func (x *Transaction) GetPaymentMethod() PaymentMethod {
+	if x != nil {
+		return x.PaymentMethod
+	}
+	return PaymentMethod_PAYMENT_METHOD_UNSPECIFIED
+}
+
+func (x *Transaction) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Transaction) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Transaction) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Transaction) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Transaction) GetFailureReason() string {
+	if x != nil {
+		return x.FailureReason
+	}
+	return ""
+}
+
+func (x *Transaction) GetFraudDecision() string {
+	if x != nil {
+		return x.FraudDecision
+	}
+	return ""
+}
+
+func (x *Transaction) GetFraudReasons() []string {
+	if x != nil {
+		return x.FraudReasons
+	}
+	return nil
+}
+
+func (x *Transaction) GetInstallmentPlanUuid() string {
+	if x != nil {
+		return x.InstallmentPlanUuid
+	}
+	return ""
+}
+
+func (x *Transaction) GetApprovalUuid() string {
+	if x != nil {
+		return x.ApprovalUuid
+	}
+	return ""
+}
+
+func (x *Transaction) GetSplitUuid() string {
+	if x != nil {
+		return x.SplitUuid
+	}
+	return ""
+}
+
+func (x *Transaction) GetReviewedBy() string {
+	if x != nil {
+		return x.ReviewedBy
+	}
+	return ""
+}
+
+// Запрос на получение счетов бухгалтерской книги
+type ListLedgerAccountsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLedgerAccountsRequest) Reset() {
+	*x = ListLedgerAccountsRequest{}
+	mi := &file_payment_v1_payment_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLedgerAccountsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLedgerAccountsRequest) ProtoMessage() {}
+
+func (x *ListLedgerAccountsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLedgerAccountsRequest.ProtoReflect.Descriptor instead.
+func (*ListLedgerAccountsRequest) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{33}
+}
+
+// Ответ со счетами бухгалтерской книги
+type ListLedgerAccountsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// accounts счета книги
+	Accounts      []*LedgerAccount `protobuf:"bytes,1,rep,name=accounts,proto3" json:"accounts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLedgerAccountsResponse) Reset() {
+	*x = ListLedgerAccountsResponse{}
+	mi := &file_payment_v1_payment_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLedgerAccountsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLedgerAccountsResponse) ProtoMessage() {}
+
+func (x *ListLedgerAccountsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLedgerAccountsResponse.ProtoReflect.Descriptor instead.
+func (*ListLedgerAccountsResponse) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ListLedgerAccountsResponse) GetAccounts() []*LedgerAccount {
+	if x != nil {
+		return x.Accounts
+	}
+	return nil
+}
+
+// Запрос на получение сальдо счета
+type GetAccountBalanceRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// account_code код счета
+	AccountCode   string `protobuf:"bytes,1,opt,name=account_code,json=accountCode,proto3" json:"account_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAccountBalanceRequest) Reset() {
+	*x = GetAccountBalanceRequest{}
+	mi := &file_payment_v1_payment_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAccountBalanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAccountBalanceRequest) ProtoMessage() {}
+
+func (x *GetAccountBalanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAccountBalanceRequest.ProtoReflect.Descriptor instead.
+func (*GetAccountBalanceRequest) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GetAccountBalanceRequest) GetAccountCode() string {
+	if x != nil {
+		return x.AccountCode
+	}
+	return ""
+}
+
+// Ответ с сальдо счета
+type GetAccountBalanceResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// balance обороты и сальдо счета
+	Balance       *AccountBalance `protobuf:"bytes,1,opt,name=balance,proto3" json:"balance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAccountBalanceResponse) Reset() {
+	*x = GetAccountBalanceResponse{}
+	mi := &file_payment_v1_payment_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAccountBalanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAccountBalanceResponse) ProtoMessage() {}
+
+func (x *GetAccountBalanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAccountBalanceResponse.ProtoReflect.Descriptor instead.
+func (*GetAccountBalanceResponse) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *GetAccountBalanceResponse) GetBalance() *AccountBalance {
+	if x != nil {
+		return x.Balance
+	}
+	return nil
+}
+
+// Запрос на получение выписки по счету
+type GetAccountStatementRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// account_code код счета
+	AccountCode string `protobuf:"bytes,1,opt,name=account_code,json=accountCode,proto3" json:"account_code,omitempty"`
+	// from начало периода (включительно), если не задано - с первой проводки
+	From *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	// to конец периода (не включительно), если не задано - по текущий момент
+	To            *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAccountStatementRequest) Reset() {
+	*x = GetAccountStatementRequest{}
+	mi := &file_payment_v1_payment_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAccountStatementRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAccountStatementRequest) ProtoMessage() {}
+
+func (x *GetAccountStatementRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAccountStatementRequest.ProtoReflect.Descriptor instead.
+func (*GetAccountStatementRequest) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *GetAccountStatementRequest) GetAccountCode() string {
+	if x != nil {
+		return x.AccountCode
+	}
+	return ""
+}
+
+func (x *GetAccountStatementRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *GetAccountStatementRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+// Ответ с выпиской по счету
+type GetAccountStatementResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// account счет
+	Account *LedgerAccount `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	// opening_balance сальдо на начало периода в копейках
+	OpeningBalance int64 `protobuf:"varint,2,opt,name=opening_balance,json=openingBalance,proto3" json:"opening_balance,omitempty"`
+	// closing_balance сальдо на конец периода в копейках
+	ClosingBalance int64 `protobuf:"varint,3,opt,name=closing_balance,json=closingBalance,proto3" json:"closing_balance,omitempty"`
+	// lines проводки по счету за период в хронологическом порядке
+	Lines         []*StatementLine `protobuf:"bytes,4,rep,name=lines,proto3" json:"lines,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAccountStatementResponse) Reset() {
+	*x = GetAccountStatementResponse{}
+	mi := &file_payment_v1_payment_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAccountStatementResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAccountStatementResponse) ProtoMessage() {}
+
+func (x *GetAccountStatementResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAccountStatementResponse.ProtoReflect.Descriptor instead.
+func (*GetAccountStatementResponse) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *GetAccountStatementResponse) GetAccount() *LedgerAccount {
+	if x != nil {
+		return x.Account
+	}
+	return nil
+}
+
+func (x *GetAccountStatementResponse) GetOpeningBalance() int64 {
+	if x != nil {
+		return x.OpeningBalance
+	}
+	return 0
+}
+
+func (x *GetAccountStatementResponse) GetClosingBalance() int64 {
+	if x != nil {
+		return x.ClosingBalance
+	}
+	return 0
+}
+
+func (x *GetAccountStatementResponse) GetLines() []*StatementLine {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+// Счет бухгалтерской книги
+type LedgerAccount struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// code код счета, например provider_clearing:card
+	Code string `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	// name название счета
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// type тип счета (ASSET, LIABILITY, EQUITY, REVENUE, EXPENSE)
+	Type          string `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LedgerAccount) Reset() {
+	*x = LedgerAccount{}
+	mi := &file_payment_v1_payment_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LedgerAccount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LedgerAccount) ProtoMessage() {}
+
+func (x *LedgerAccount) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LedgerAccount.ProtoReflect.Descriptor instead.
+func (*LedgerAccount) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *LedgerAccount) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *LedgerAccount) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *LedgerAccount) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+// Обороты и сальдо счета
+type AccountBalance struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// account счет
+	Account *LedgerAccount `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	// debit_total сумма дебетовых проводок в копейках
+	DebitTotal int64 `protobuf:"varint,2,opt,name=debit_total,json=debitTotal,proto3" json:"debit_total,omitempty"`
+	// credit_total сумма кредитовых проводок в копейках
+	CreditTotal int64 `protobuf:"varint,3,opt,name=credit_total,json=creditTotal,proto3" json:"credit_total,omitempty"`
+	// balance сальдо со стороны нормального остатка счета в копейках
+	Balance       int64 `protobuf:"varint,4,opt,name=balance,proto3" json:"balance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AccountBalance) Reset() {
+	*x = AccountBalance{}
+	mi := &file_payment_v1_payment_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccountBalance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountBalance) ProtoMessage() {}
+
+func (x *AccountBalance) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountBalance.ProtoReflect.Descriptor instead.
+func (*AccountBalance) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *AccountBalance) GetAccount() *LedgerAccount {
+	if x != nil {
+		return x.Account
+	}
+	return nil
+}
+
+func (x *AccountBalance) GetDebitTotal() int64 {
+	if x != nil {
+		return x.DebitTotal
+	}
+	return 0
+}
+
+func (x *AccountBalance) GetCreditTotal() int64 {
+	if x != nil {
+		return x.CreditTotal
+	}
+	return 0
+}
+
+func (x *AccountBalance) GetBalance() int64 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+// Строка выписки по счету
+type StatementLine struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// entry_uuid UUID записи журнала
+	EntryUuid string `protobuf:"bytes,1,opt,name=entry_uuid,json=entryUuid,proto3" json:"entry_uuid,omitempty"`
+	// entry_type тип записи (CHARGE, REFUND, FEE, SETTLEMENT)
+	EntryType string `protobuf:"bytes,2,opt,name=entry_type,json=entryType,proto3" json:"entry_type,omitempty"`
+	// transaction_uuid UUID платежной транзакции, к которой относится запись
+	TransactionUuid string `protobuf:"bytes,3,opt,name=transaction_uuid,json=transactionUuid,proto3" json:"transaction_uuid,omitempty"`
+	// description описание записи
+	Description string `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	// direction сторона проводки (DEBIT, CREDIT)
+	Direction string `protobuf:"bytes,5,opt,name=direction,proto3" json:"direction,omitempty"`
+	// amount сумма проводки в копейках
+	Amount int64 `protobuf:"varint,6,opt,name=amount,proto3" json:"amount,omitempty"`
+	// balance сальдо счета после проводки в копейках
+	Balance int64 `protobuf:"varint,7,opt,name=balance,proto3" json:"balance,omitempty"`
+	// created_at время записи
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatementLine) Reset() {
+	*x = StatementLine{}
+	mi := &file_payment_v1_payment_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatementLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatementLine) ProtoMessage() {}
+
+func (x *StatementLine) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_v1_payment_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatementLine.ProtoReflect.Descriptor instead.
+func (*StatementLine) Descriptor() ([]byte, []int) {
+	return file_payment_v1_payment_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *StatementLine) GetEntryUuid() string {
+	if x != nil {
+		return x.EntryUuid
+	}
+	return ""
+}
+
+func (x *StatementLine) GetEntryType() string {
+	if x != nil {
+		return x.EntryType
+	}
+	return ""
+}
+
+func (x *StatementLine) GetTransactionUuid() string {
+	if x != nil {
+		return x.TransactionUuid
+	}
+	return ""
+}
+
+func (x *StatementLine) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *StatementLine) GetDirection() string {
+	if x != nil {
+		return x.Direction
+	}
+	return ""
+}
+
+func (x *StatementLine) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *StatementLine) GetBalance() int64 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+func (x *StatementLine) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+var File_payment_v1_payment_proto protoreflect.FileDescriptor
+
+const file_payment_v1_payment_proto_rawDesc = "" +
+	"\n" +
+	"\x18payment/v1/payment.proto\x12\n" +
+	"payment.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xd0\x01\n" +
+	"\x0fPayOrderRequest\x12\x1d\n" +
+	"\n" +
+	"order_uuid\x18\x01 \x01(\tR\torderUuid\x12\x1b\n" +
+	"\tuser_uuid\x18\x02 \x01(\tR\buserUuid\x12@\n" +
+	"\x0epayment_method\x18\x03 \x01(\x0e2\x19.payment.v1.PaymentMethodR\rpaymentMethod\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x01R\x06amount\x12'\n" +
+	"\x0fidempotency_key\x18\x05 \x01(\tR\x0eidempotencyKey\"U\n" +
+	"\x10PayOrderResponse\x12)\n" +
+	"\x10transaction_uuid\x18\x01 \x01(\tR\x0ftransactionUuid\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\"\xbc\x01\n" +
+	"\x0fPaySplitRequest\x12\x1d\n" +
+	"\n" +
+	"order_uuid\x18\x01 \x01(\tR\torderUuid\x12\x1b\n" +
+	"\tuser_uuid\x18\x02 \x01(\tR\buserUuid\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x01R\x06amount\x12,\n" +
+	"\atenders\x18\x04 \x03(\v2\x12.payment.v1.TenderR\atenders\x12'\n" +
+	"\x0fidempotency_key\x18\x05 \x01(\tR\x0eidempotencyKey\"b\n" +
+	"\x06Tender\x12@\n" +
+	"\x0epayment_method\x18\x01 \x01(\x0e2\x19.payment.v1.PaymentMethodR\rpaymentMethod\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x01R\x06amount\"\x86\x01\n" +
+	"\x10PaySplitResponse\x12\x1d\n" +
+	"\n" +
+	"split_uuid\x18\x01 \x01(\tR\tsplitUuid\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12;\n" +
+	"\ftransactions\x18\x03 \x03(\v2\x17.payment.v1.TransactionR\ftransactions\"z\n" +
+	"\x19ConfirmTransactionRequest\x12)\n" +
+	"\x10transaction_uuid\x18\x01 \x01(\tR\x0ftransactionUuid\x12\x1a\n" +
+	"\bapproved\x18\x02 \x01(\bR\bapproved\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"W\n" +
+	"\x1aConfirmTransactionResponse\x129\n" +
+	"\vtransaction\x18\x01 \x01(\v2\x17.payment.v1.TransactionR\vtransaction\"y\n" +
+	"\x18ReviewTransactionRequest\x12)\n" +
+	"\x10transaction_uuid\x18\x01 \x01(\tR\x0ftransactionUuid\x12\x1a\n" +
+	"\bapproved\x18\x02 \x01(\bR\bapproved\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"V\n" +
+	"\x19ReviewTransactionResponse\x129\n" +
+	"\vtransaction\x18\x01 \x01(\v2\x17.payment.v1.TransactionR\vtransaction\"\x18\n" +
+	"\x16ListReviewQueueRequest\"V\n" +
+	"\x17ListReviewQueueResponse\x12;\n" +
+	"\ftransactions\x18\x01 \x03(\v2\x17.payment.v1.TransactionR\ftransactions\"\x91\x01\n" +
+	"\x15DecideApprovalRequest\x12#\n" +
+	"\rapproval_uuid\x18\x01 \x01(\tR\fapprovalUuid\x12\x1f\n" +
+	"\vapprover_id\x18\x02 \x01(\tR\n" +
+	"approverId\x12\x1a\n" +
+	"\bapproved\x18\x03 \x01(\bR\bapproved\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\"J\n" +
+	"\x16DecideApprovalResponse\x120\n" +
+	"\bapproval\x18\x01 \x01(\v2\x14.payment.v1.ApprovalR\bapproval\"9\n" +
+	"\x12GetApprovalRequest\x12#\n" +
+	"\rapproval_uuid\x18\x01 \x01(\tR\fapprovalUuid\"G\n" +
+	"\x13GetApprovalResponse\x120\n" +
+	"\bapproval\x18\x01 \x01(\v2\x14.payment.v1.ApprovalR\bapproval\">\n" +
+	"\x1bListPendingApprovalsRequest\x12\x1f\n" +
+	"\vapprover_id\x18\x01 \x01(\tR\n" +
+	"approverId\"R\n" +
+	"\x1cListPendingApprovalsResponse\x122\n" +
+	"\tapprovals\x18\x01 \x03(\v2\x14.payment.v1.ApprovalR\tapprovals\"\xe9\x03\n" +
+	"\bApproval\x12#\n" +
+	"\rapproval_uuid\x18\x01 \x01(\tR\fapprovalUuid\x12)\n" +
+	"\x10transaction_uuid\x18\x02 \x01(\tR\x0ftransactionUuid\x12\x1d\n" +
+	"\n" +
+	"order_uuid\x18\x03 \x01(\tR\torderUuid\x12\x1b\n" +
+	"\tuser_uuid\x18\x04 \x01(\tR\buserUuid\x12\x16\n" +
+	"\x06amount\x18\x05 \x01(\x01R\x06amount\x12\x1c\n" +
+	"\tapprovers\x18\x06 \x03(\tR\tapprovers\x12\x16\n" +
+	"\x06quorum\x18\a \x01(\x05R\x06quorum\x12\x16\n" +
+	"\x06status\x18\b \x01(\tR\x06status\x12:\n" +
+	"\tdecisions\x18\t \x03(\v2\x1c.payment.v1.ApprovalDecisionR\tdecisions\x129\n" +
+	"\n" +
+	"expires_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x129\n" +
+	"\n" +
+	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xa2\x01\n" +
+	"\x10ApprovalDecision\x12\x1f\n" +
+	"\vapprover_id\x18\x01 \x01(\tR\n" +
+	"approverId\x12\x1a\n" +
+	"\bapproved\x18\x02 \x01(\bR\bapproved\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x129\n" +
+	"\n" +
+	"decided_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tdecidedAt\"2\n" +
+	"\x18QuoteInstallmentsRequest\x12\x16\n" +
+	"\x06amount\x18\x01 \x01(\x01R\x06amount\"Q\n" +
+	"\x19QuoteInstallmentsResponse\x124\n" +
+	"\x06quotes\x18\x01 \x03(\v2\x1c.payment.v1.InstallmentQuoteR\x06quotes\"\x96\x01\n" +
+	"\x1cCreateInstallmentPlanRequest\x12\x1d\n" +
+	"\n" +
+	"order_uuid\x18\x01 \x01(\tR\torderUuid\x12\x1b\n" +
+	"\tuser_uuid\x18\x02 \x01(\tR\buserUuid\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x01R\x06amount\x12\"\n" +
+	"\finstallments\x18\x04 \x01(\x05R\finstallments\"P\n" +
+	"\x1dCreateInstallmentPlanResponse\x12/\n" +
+	"\x04plan\x18\x01 \x01(\v2\x1b.payment.v1.InstallmentPlanR\x04plan\"8\n" +
+	"\x19GetInstallmentPlanRequest\x12\x1b\n" +
+	"\tplan_uuid\x18\x01 \x01(\tR\bplanUuid\"M\n" +
+	"\x1aGetInstallmentPlanResponse\x12/\n" +
+	"\x04plan\x18\x01 \x01(\v2\x1b.payment.v1.InstallmentPlanR\x04plan\"\xba\x01\n" +
+	"\x10InstallmentQuote\x12\"\n" +
+	"\finstallments\x18\x01 \x01(\x05R\finstallments\x12!\n" +
+	"\fmonthly_rate\x18\x02 \x01(\x01R\vmonthlyRate\x12\x1a\n" +
+	"\bpayments\x18\x03 \x03(\x01R\bpayments\x12!\n" +
+	"\ftotal_amount\x18\x04 \x01(\x01R\vtotalAmount\x12 \n" +
+	"\voverpayment\x18\x05 \x01(\x01R\voverpayment\"\xfc\x03\n" +
+	"\x0fInstallmentPlan\x12\x1b\n" +
+	"\tplan_uuid\x18\x01 \x01(\tR\bplanUuid\x12\x1d\n" +
+	"\n" +
+	"order_uuid\x18\x02 \x01(\tR\torderUuid\x12\x1b\n" +
+	"\tuser_uuid\x18\x03 \x01(\tR\buserUuid\x12@\n" +
+	"\x0epayment_method\x18\x04 \x01(\x0e2\x19.payment.v1.PaymentMethodR\rpaymentMethod\x12\x1c\n" +
+	"\tprincipal\x18\x05 \x01(\x01R\tprincipal\x12!\n" +
+	"\fmonthly_rate\x18\x06 \x01(\x01R\vmonthlyRate\x12!\n" +
+	"\ftotal_amount\x18\a \x01(\x01R\vtotalAmount\x12\x1f\n" +
+	"\vpaid_amount\x18\b \x01(\x01R\n" +
+	"paidAmount\x12\x16\n" +
+	"\x06status\x18\t \x01(\tR\x06status\x12;\n" +
+	"\finstallments\x18\n" +
+	" \x03(\v2\x17.payment.v1.InstallmentR\finstallments\x129\n" +
+	"\n" +
+	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xab\x02\n" +
+	"\vInstallment\x12\x16\n" +
+	"\x06number\x18\x01 \x01(\x05R\x06number\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x01R\x06amount\x121\n" +
+	"\x06due_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x05dueAt\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12)\n" +
+	"\x10transaction_uuid\x18\x05 \x01(\tR\x0ftransactionUuid\x12\x1a\n" +
+	"\battempts\x18\x06 \x01(\x05R\battempts\x123\n" +
+	"\apaid_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x06paidAt\x12%\n" +
+	"\x0efailure_reason\x18\b \x01(\tR\rfailureReason\"8\n" +
+	"\x17ListTransactionsRequest\x12\x1d\n" +
+	"\n" +
+	"order_uuid\x18\x01 \x01(\tR\torderUuid\"W\n" +
+	"\x18ListTransactionsResponse\x12;\n" +
+	"\ftransactions\x18\x01 \x03(\v2\x17.payment.v1.TransactionR\ftransactions\":\n" +
+	"\x1bListUserTransactionsRequest\x12\x1b\n" +
+	"\tuser_uuid\x18\x01 \x01(\tR\buserUuid\"[\n" +
+	"\x1cListUserTransactionsResponse\x12;\n" +
+	"\ftransactions\x18\x01 \x03(\v2\x17.payment.v1.TransactionR\ftransactions\"\xe8\x04\n" +
+	"\vTransaction\x12)\n" +
+	"\x10transaction_uuid\x18\x01 \x01(\tR\x0ftransactionUuid\x12\x1d\n" +
+	"\n" +
+	"order_uuid\x18\x02 \x01(\tR\torderUuid\x12\x1b\n" +
+	"\tuser_uuid\x18\x03 \x01(\tR\buserUuid\x12@\n" +
+	"\x0epayment_method\x18\x04 \x01(\x0e2\x19.payment.v1.PaymentMethodR\rpaymentMethod\x12\x16\n" +
+	"\x06amount\x18\x05 \x01(\x01R\x06amount\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12%\n" +
+	"\x0efailure_reason\x18\t \x01(\tR\rfailureReason\x12%\n" +
+	"\x0efraud_decision\x18\n" +
+	" \x01(\tR\rfraudDecision\x12#\n" +
+	"\rfraud_reasons\x18\v \x03(\tR\ffraudReasons\x122\n" +
+	"\x15installment_plan_uuid\x18\f \x01(\tR\x13installmentPlanUuid\x12#\n" +
+	"\rapproval_uuid\x18\r \x01(\tR\fapprovalUuid\x12\x1d\n" +
+	"\n" +
+	"split_uuid\x18\x0e \x01(\tR\tsplitUuid\x12\x1f\n" +
+	"\vreviewed_by\x18\x0f \x01(\tR\n" +
+	"reviewedBy\"\x1b\n" +
+	"\x19ListLedgerAccountsRequest\"S\n" +
+	"\x1aListLedgerAccountsResponse\x125\n" +
+	"\baccounts\x18\x01 \x03(\v2\x19.payment.v1.LedgerAccountR\baccounts\"=\n" +
+	"\x18GetAccountBalanceRequest\x12!\n" +
+	"\faccount_code\x18\x01 \x01(\tR\vaccountCode\"Q\n" +
+	"\x19GetAccountBalanceResponse\x124\n" +
+	"\abalance\x18\x01 \x01(\v2\x1a.payment.v1.AccountBalanceR\abalance\"\x9b\x01\n" +
+	"\x1aGetAccountStatementRequest\x12!\n" +
+	"\faccount_code\x18\x01 \x01(\tR\vaccountCode\x12.\n" +
+	"\x04from\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\"\xd5\x01\n" +
+	"\x1bGetAccountStatementResponse\x123\n" +
+	"\aaccount\x18\x01 \x01(\v2\x19.payment.v1.LedgerAccountR\aaccount\x12'\n" +
+	"\x0fopening_balance\x18\x02 \x01(\x03R\x0eopeningBalance\x12'\n" +
+	"\x0fclosing_balance\x18\x03 \x01(\x03R\x0eclosingBalance\x12/\n" +
+	"\x05lines\x18\x04 \x03(\v2\x19.payment.v1.StatementLineR\x05lines\"K\n" +
+	"\rLedgerAccount\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\"\xa3\x01\n" +
+	"\x0eAccountBalance\x123\n" +
+	"\aaccount\x18\x01 \x01(\v2\x19.payment.v1.LedgerAccountR\aaccount\x12\x1f\n" +
+	"\vdebit_total\x18\x02 \x01(\x03R\n" +
+	"debitTotal\x12!\n" +
+	"\fcredit_total\x18\x03 \x01(\x03R\vcreditTotal\x12\x18\n" +
+	"\abalance\x18\x04 \x01(\x03R\abalance\"\xa5\x02\n" +
+	"\rStatementLine\x12\x1d\n" +
+	"\n" +
+	"entry_uuid\x18\x01 \x01(\tR\tentryUuid\x12\x1d\n" +
+	"\n" +
+	"entry_type\x18\x02 \x01(\tR\tentryType\x12)\n" +
+	"\x10transaction_uuid\x18\x03 \x01(\tR\x0ftransactionUuid\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x1c\n" +
+	"\tdirection\x18\x05 \x01(\tR\tdirection\x12\x16\n" +
+	"\x06amount\x18\x06 \x01(\x03R\x06amount\x12\x18\n" +
+	"\abalance\x18\a \x01(\x03R\abalance\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt*\xa3\x01\n" +
 	"\rPaymentMethod\x12\x1e\n" +
 	"\x1aPAYMENT_METHOD_UNSPECIFIED\x10\x00\x12\x17\n" +
 	"\x13PAYMENT_METHOD_CARD\x10\x01\x12\x16\n" +
 	"\x12PAYMENT_METHOD_SBP\x10\x02\x12\x1e\n" +
 	"\x1aPAYMENT_METHOD_CREDIT_CARD\x10\x03\x12!\n" +
-	"\x1dPAYMENT_METHOD_INVESTOR_MONEY\x10\x042W\n" +
+	"\x1dPAYMENT_METHOD_INVESTOR_MONEY\x10\x042\x83\f\n" +
 	"\x0ePaymentService\x12E\n" +
-	"\bPayOrder\x12\x1b.payment.v1.PayOrderRequest\x1a\x1c.payment.v1.PayOrderResponseBFZDgithub.com/linemk/rocket-shop/shared/pkg/proto/payment/v1;payment_v1b\x06proto3"
+	"\bPayOrder\x12\x1b.payment.v1.PayOrderRequest\x1a\x1c.payment.v1.PayOrderResponse\x12E\n" +
+	"\bPaySplit\x12\x1b.payment.v1.PaySplitRequest\x1a\x1c.payment.v1.PaySplitResponse\x12c\n" +
+	"\x12ConfirmTransaction\x12%.payment.v1.ConfirmTransactionRequest\x1a&.payment.v1.ConfirmTransactionResponse\x12`\n" +
+	"\x11ReviewTransaction\x12$.payment.v1.ReviewTransactionRequest\x1a%.payment.v1.ReviewTransactionResponse\x12Z\n" +
+	"\x0fListReviewQueue\x12\".payment.v1.ListReviewQueueRequest\x1a#.payment.v1.ListReviewQueueResponse\x12W\n" +
+	"\x0eDecideApproval\x12!.payment.v1.DecideApprovalRequest\x1a\".payment.v1.DecideApprovalResponse\x12N\n" +
+	"\vGetApproval\x12\x1e.payment.v1.GetApprovalRequest\x1a\x1f.payment.v1.GetApprovalResponse\x12i\n" +
+	"\x14ListPendingApprovals\x12'.payment.v1.ListPendingApprovalsRequest\x1a(.payment.v1.ListPendingApprovalsResponse\x12`\n" +
+	"\x11QuoteInstallments\x12$.payment.v1.QuoteInstallmentsRequest\x1a%.payment.v1.QuoteInstallmentsResponse\x12l\n" +
+	"\x15CreateInstallmentPlan\x12(.payment.v1.CreateInstallmentPlanRequest\x1a).payment.v1.CreateInstallmentPlanResponse\x12c\n" +
+	"\x12GetInstallmentPlan\x12%.payment.v1.GetInstallmentPlanRequest\x1a&.payment.v1.GetInstallmentPlanResponse\x12]\n" +
+	"\x10ListTransactions\x12#.payment.v1.ListTransactionsRequest\x1a$.payment.v1.ListTransactionsResponse\x12i\n" +
+	"\x14ListUserTransactions\x12'.payment.v1.ListUserTransactionsRequest\x1a(.payment.v1.ListUserTransactionsResponse\x12c\n" +
+	"\x12ListLedgerAccounts\x12%.payment.v1.ListLedgerAccountsRequest\x1a&.payment.v1.ListLedgerAccountsResponse\x12`\n" +
+	"\x11GetAccountBalance\x12$.payment.v1.GetAccountBalanceRequest\x1a%.payment.v1.GetAccountBalanceResponse\x12f\n" +
+	"\x13GetAccountStatement\x12&.payment.v1.GetAccountStatementRequest\x1a'.payment.v1.GetAccountStatementResponseBFZDgithub.com/linemk/rocket-shop/shared/pkg/proto/payment/v1;payment_v1b\x06proto3"
 
 var (
 	file_payment_v1_payment_proto_rawDescOnce sync.Once
@@ -224,21 +3042,128 @@ func file_payment_v1_payment_proto_rawDescGZIP() []byte {
 }
 
 var file_payment_v1_payment_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_payment_v1_payment_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_payment_v1_payment_proto_msgTypes = make([]protoimpl.MessageInfo, 42)
 var file_payment_v1_payment_proto_goTypes = []any{
-	(PaymentMethod)(0),       // 0: payment.v1.PaymentMethod
-	(*PayOrderRequest)(nil),  // 1: payment.v1.PayOrderRequest
-	(*PayOrderResponse)(nil), // 2: payment.v1.PayOrderResponse
+	(PaymentMethod)(0),                    // 0: payment.v1.PaymentMethod
+	(*PayOrderRequest)(nil),               // 1: payment.v1.PayOrderRequest
+	(*PayOrderResponse)(nil),              // 2: payment.v1.PayOrderResponse
+	(*PaySplitRequest)(nil),               // 3: payment.v1.PaySplitRequest
+	(*Tender)(nil),                        // 4: payment.v1.Tender
+	(*PaySplitResponse)(nil),              // 5: payment.v1.PaySplitResponse
+	(*ConfirmTransactionRequest)(nil),     // 6: payment.v1.ConfirmTransactionRequest
+	(*ConfirmTransactionResponse)(nil),    // 7: payment.v1.ConfirmTransactionResponse
+	(*ReviewTransactionRequest)(nil),      // 8: payment.v1.ReviewTransactionRequest
+	(*ReviewTransactionResponse)(nil),     // 9: payment.v1.ReviewTransactionResponse
+	(*ListReviewQueueRequest)(nil),        // 10: payment.v1.ListReviewQueueRequest
+	(*ListReviewQueueResponse)(nil),       // 11: payment.v1.ListReviewQueueResponse
+	(*DecideApprovalRequest)(nil),         // 12: payment.v1.DecideApprovalRequest
+	(*DecideApprovalResponse)(nil),        // 13: payment.v1.DecideApprovalResponse
+	(*GetApprovalRequest)(nil),            // 14: payment.v1.GetApprovalRequest
+	(*GetApprovalResponse)(nil),           // 15: payment.v1.GetApprovalResponse
+	(*ListPendingApprovalsRequest)(nil),   // 16: payment.v1.ListPendingApprovalsRequest
+	(*ListPendingApprovalsResponse)(nil),  // 17: payment.v1.ListPendingApprovalsResponse
+	(*Approval)(nil),                      // 18: payment.v1.Approval
+	(*ApprovalDecision)(nil),              // 19: payment.v1.ApprovalDecision
+	(*QuoteInstallmentsRequest)(nil),      // 20: payment.v1.QuoteInstallmentsRequest
+	(*QuoteInstallmentsResponse)(nil),     // 21: payment.v1.QuoteInstallmentsResponse
+	(*CreateInstallmentPlanRequest)(nil),  // 22: payment.v1.CreateInstallmentPlanRequest
+	(*CreateInstallmentPlanResponse)(nil), // 23: payment.v1.CreateInstallmentPlanResponse
+	(*GetInstallmentPlanRequest)(nil),     // 24: payment.v1.GetInstallmentPlanRequest
+	(*GetInstallmentPlanResponse)(nil),    // 25: payment.v1.GetInstallmentPlanResponse
+	(*InstallmentQuote)(nil),              // 26: payment.v1.InstallmentQuote
+	(*InstallmentPlan)(nil),               // 27: payment.v1.InstallmentPlan
+	(*Installment)(nil),                   // 28: payment.v1.Installment
+	(*ListTransactionsRequest)(nil),       // 29: payment.v1.ListTransactionsRequest
+	(*ListTransactionsResponse)(nil),      // 30: payment.v1.ListTransactionsResponse
+	(*ListUserTransactionsRequest)(nil),   // 31: payment.v1.ListUserTransactionsRequest
+	(*ListUserTransactionsResponse)(nil),  // 32: payment.v1.ListUserTransactionsResponse
+	(*Transaction)(nil),                   // 33: payment.v1.Transaction
+	(*ListLedgerAccountsRequest)(nil),     // 34: payment.v1.ListLedgerAccountsRequest
+	(*ListLedgerAccountsResponse)(nil),    // 35: payment.v1.ListLedgerAccountsResponse
+	(*GetAccountBalanceRequest)(nil),      // 36: payment.v1.GetAccountBalanceRequest
+	(*GetAccountBalanceResponse)(nil),     // 37: payment.v1.GetAccountBalanceResponse
+	(*GetAccountStatementRequest)(nil),    // 38: payment.v1.GetAccountStatementRequest
+	(*GetAccountStatementResponse)(nil),   // 39: payment.v1.GetAccountStatementResponse
+	(*LedgerAccount)(nil),                 // 40: payment.v1.LedgerAccount
+	(*AccountBalance)(nil),                // 41: payment.v1.AccountBalance
+	(*StatementLine)(nil),                 // 42: payment.v1.StatementLine
+	(*timestamppb.Timestamp)(nil),         // 43: google.protobuf.Timestamp
 }
 var file_payment_v1_payment_proto_depIdxs = []int32{
-	0, // 0: payment.v1.PayOrderRequest.payment_method:type_name -> payment.v1.PaymentMethod
-	1, // 1: payment.v1.PaymentService.PayOrder:input_type -> payment.v1.PayOrderRequest
-	2, // 2: payment.v1.PaymentService.PayOrder:output_type -> payment.v1.PayOrderResponse
-	2, // [2:3] is the sub-list for method output_type
-	1, // [1:2] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	0,  // 0: payment.v1.PayOrderRequest.payment_method:type_name -> payment.v1.PaymentMethod
+	4,  // 1: payment.v1.PaySplitRequest.tenders:type_name -> payment.v1.Tender
+	0,  // 2: payment.v1.Tender.payment_method:type_name -> payment.v1.PaymentMethod
+	33, // 3: payment.v1.PaySplitResponse.transactions:type_name -> payment.v1.Transaction
+	33, // 4: payment.v1.ConfirmTransactionResponse.transaction:type_name -> payment.v1.Transaction
+	33, // 5: payment.v1.ReviewTransactionResponse.transaction:type_name -> payment.v1.Transaction
+	33, // 6: payment.v1.ListReviewQueueResponse.transactions:type_name -> payment.v1.Transaction
+	18, // 7: payment.v1.DecideApprovalResponse.approval:type_name -> payment.v1.Approval
+	18, // 8: payment.v1.GetApprovalResponse.approval:type_name -> payment.v1.Approval
+	18, // 9: payment.v1.ListPendingApprovalsResponse.approvals:type_name -> payment.v1.Approval
+	19, // 10: payment.v1.Approval.decisions:type_name -> payment.v1.ApprovalDecision
+	43, // 11: payment.v1.Approval.expires_at:type_name -> google.protobuf.Timestamp
+	43, // 12: payment.v1.Approval.created_at:type_name -> google.protobuf.Timestamp
+	43, // 13: payment.v1.Approval.updated_at:type_name -> google.protobuf.Timestamp
+	43, // 14: payment.v1.ApprovalDecision.decided_at:type_name -> google.protobuf.Timestamp
+	26, // 15: payment.v1.QuoteInstallmentsResponse.quotes:type_name -> payment.v1.InstallmentQuote
+	27, // 16: payment.v1.CreateInstallmentPlanResponse.plan:type_name -> payment.v1.InstallmentPlan
+	27, // 17: payment.v1.GetInstallmentPlanResponse.plan:type_name -> payment.v1.InstallmentPlan
+	0,  // 18: payment.v1.InstallmentPlan.payment_method:type_name -> payment.v1.PaymentMethod
+	28, // 19: payment.v1.InstallmentPlan.installments:type_name -> payment.v1.Installment
+	43, // 20: payment.v1.InstallmentPlan.created_at:type_name -> google.protobuf.Timestamp
+	43, // 21: payment.v1.InstallmentPlan.updated_at:type_name -> google.protobuf.Timestamp
+	43, // 22: payment.v1.Installment.due_at:type_name -> google.protobuf.Timestamp
+	43, // 23: payment.v1.Installment.paid_at:type_name -> google.protobuf.Timestamp
+	33, // 24: payment.v1.ListTransactionsResponse.transactions:type_name -> payment.v1.Transaction
+	33, // 25: payment.v1.ListUserTransactionsResponse.transactions:type_name -> payment.v1.Transaction
+	0,  // 26: payment.v1.Transaction.payment_method:type_name -> payment.v1.PaymentMethod
+	43, // 27: payment.v1.Transaction.created_at:type_name -> google.protobuf.Timestamp
+	43, // 28: payment.v1.Transaction.updated_at:type_name -> google.protobuf.Timestamp
+	40, // 29: payment.v1.ListLedgerAccountsResponse.accounts:type_name -> payment.v1.LedgerAccount
+	41, // 30: payment.v1.GetAccountBalanceResponse.balance:type_name -> payment.v1.AccountBalance
+	43, // 31: payment.v1.GetAccountStatementRequest.from:type_name -> google.protobuf.Timestamp
+	43, // 32: payment.v1.GetAccountStatementRequest.to:type_name -> google.protobuf.Timestamp
+	40, // 33: payment.v1.GetAccountStatementResponse.account:type_name -> payment.v1.LedgerAccount
+	42, // 34: payment.v1.GetAccountStatementResponse.lines:type_name -> payment.v1.StatementLine
+	40, // 35: payment.v1.AccountBalance.account:type_name -> payment.v1.LedgerAccount
+	43, // 36: payment.v1.StatementLine.created_at:type_name -> google.protobuf.Timestamp
+	1,  // 37: payment.v1.PaymentService.PayOrder:input_type -> payment.v1.PayOrderRequest
+	3,  // 38: payment.v1.PaymentService.PaySplit:input_type -> payment.v1.PaySplitRequest
+	6,  // 39: payment.v1.PaymentService.ConfirmTransaction:input_type -> payment.v1.ConfirmTransactionRequest
+	8,  // 40: payment.v1.PaymentService.ReviewTransaction:input_type -> payment.v1.ReviewTransactionRequest
+	10, // 41: payment.v1.PaymentService.ListReviewQueue:input_type -> payment.v1.ListReviewQueueRequest
+	12, // 42: payment.v1.PaymentService.DecideApproval:input_type -> payment.v1.DecideApprovalRequest
+	14, // 43: payment.v1.PaymentService.GetApproval:input_type -> payment.v1.GetApprovalRequest
+	16, // 44: payment.v1.PaymentService.ListPendingApprovals:input_type -> payment.v1.ListPendingApprovalsRequest
+	20, // 45: payment.v1.PaymentService.QuoteInstallments:input_type -> payment.v1.QuoteInstallmentsRequest
+	22, // 46: payment.v1.PaymentService.CreateInstallmentPlan:input_type -> payment.v1.CreateInstallmentPlanRequest
+	24, // 47: payment.v1.PaymentService.GetInstallmentPlan:input_type -> payment.v1.GetInstallmentPlanRequest
+	29, // 48: payment.v1.PaymentService.ListTransactions:input_type -> payment.v1.ListTransactionsRequest
+	31, // 49: payment.v1.PaymentService.ListUserTransactions:input_type -> payment.v1.ListUserTransactionsRequest
+	34, // 50: payment.v1.PaymentService.ListLedgerAccounts:input_type -> payment.v1.ListLedgerAccountsRequest
+	36, // 51: payment.v1.PaymentService.GetAccountBalance:input_type -> payment.v1.GetAccountBalanceRequest
+	38, // 52: payment.v1.PaymentService.GetAccountStatement:input_type -> payment.v1.GetAccountStatementRequest
+	2,  // 53: payment.v1.PaymentService.PayOrder:output_type -> payment.v1.PayOrderResponse
+	5,  // 54: payment.v1.PaymentService.PaySplit:output_type -> payment.v1.PaySplitResponse
+	7,  // 55: payment.v1.PaymentService.ConfirmTransaction:output_type -> payment.v1.ConfirmTransactionResponse
+	9,  // 56: payment.v1.PaymentService.ReviewTransaction:output_type -> payment.v1.ReviewTransactionResponse
+	11, // 57: payment.v1.PaymentService.ListReviewQueue:output_type -> payment.v1.ListReviewQueueResponse
+	13, // 58: payment.v1.PaymentService.DecideApproval:output_type -> payment.v1.DecideApprovalResponse
+	15, // 59: payment.v1.PaymentService.GetApproval:output_type -> payment.v1.GetApprovalResponse
+	17, // 60: payment.v1.PaymentService.ListPendingApprovals:output_type -> payment.v1.ListPendingApprovalsResponse
+	21, // 61: payment.v1.PaymentService.QuoteInstallments:output_type -> payment.v1.QuoteInstallmentsResponse
+	23, // 62: payment.v1.PaymentService.CreateInstallmentPlan:output_type -> payment.v1.CreateInstallmentPlanResponse
+	25, // 63: payment.v1.PaymentService.GetInstallmentPlan:output_type -> payment.v1.GetInstallmentPlanResponse
+	30, // 64: payment.v1.PaymentService.ListTransactions:output_type -> payment.v1.ListTransactionsResponse
+	32, // 65: payment.v1.PaymentService.ListUserTransactions:output_type -> payment.v1.ListUserTransactionsResponse
+	35, // 66: payment.v1.PaymentService.ListLedgerAccounts:output_type -> payment.v1.ListLedgerAccountsResponse
+	37, // 67: payment.v1.PaymentService.GetAccountBalance:output_type -> payment.v1.GetAccountBalanceResponse
+	39, // 68: payment.v1.PaymentService.GetAccountStatement:output_type -> payment.v1.GetAccountStatementResponse
+	53, // [53:69] is the sub-list for method output_type
+	37, // [37:53] is the sub-list for method input_type
+	37, // [37:37] is the sub-list for extension type_name
+	37, // [37:37] is the sub-list for extension extendee
+	0,  // [0:37] is the sub-list for field type_name
 }
 
 func init() { file_payment_v1_payment_proto_init() }
@@ -252,7 +3177,7 @@ func file_payment_v1_payment_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_payment_v1_payment_proto_rawDesc), len(file_payment_v1_payment_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   2,
+			NumMessages:   42,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -21,7 +21,22 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	PaymentService_PayOrder_FullMethodName = "/payment.v1.PaymentService/PayOrder"
+	PaymentService_PayOrder_FullMethodName              = "/payment.v1.PaymentService/PayOrder"
+	PaymentService_PaySplit_FullMethodName              = "/payment.v1.PaymentService/PaySplit"
+	PaymentService_ConfirmTransaction_FullMethodName    = "/payment.v1.PaymentService/ConfirmTransaction"
+	PaymentService_ReviewTransaction_FullMethodName     = "/payment.v1.PaymentService/ReviewTransaction"
+	PaymentService_ListReviewQueue_FullMethodName       = "/payment.v1.PaymentService/ListReviewQueue"
+	PaymentService_DecideApproval_FullMethodName        = "/payment.v1.PaymentService/DecideApproval"
+	PaymentService_GetApproval_FullMethodName           = "/payment.v1.PaymentService/GetApproval"
+	PaymentService_ListPendingApprovals_FullMethodName  = "/payment.v1.PaymentService/ListPendingApprovals"
+	PaymentService_QuoteInstallments_FullMethodName     = "/payment.v1.PaymentService/QuoteInstallments"
+	PaymentService_CreateInstallmentPlan_FullMethodName = "/payment.v1.PaymentService/CreateInstallmentPlan"
+	PaymentService_GetInstallmentPlan_FullMethodName    = "/payment.v1.PaymentService/GetInstallmentPlan"
+	PaymentService_ListTransactions_FullMethodName      = "/payment.v1.PaymentService/ListTransactions"
+	PaymentService_ListUserTransactions_FullMethodName  = "/payment.v1.PaymentService/ListUserTransactions"
+	PaymentService_ListLedgerAccounts_FullMethodName    = "/payment.v1.PaymentService/ListLedgerAccounts"
+	PaymentService_GetAccountBalance_FullMethodName     = "/payment.v1.PaymentService/GetAccountBalance"
+	PaymentService_GetAccountStatement_FullMethodName   = "/payment.v1.PaymentService/GetAccountStatement"
 )
 
 // PaymentServiceClient is the client API for PaymentService service.
@@ -30,8 +45,47 @@ const (
 //
 // PaymentService предоставляет API для работы с платежами
 type PaymentServiceClient interface {
-	// PayOrder обрабатывает команду на оплату и возвращает transaction_uuid
+	// PayOrder принимает команду на оплату и возвращает transaction_uuid транзакции в статусе PENDING.
+	// Результат списания публикуется событием PaymentCompleted или PaymentFailed. Если у заказа уже есть
+	// ожидающая или завершенная транзакция, новая не создается и возвращается она
 	PayOrder(ctx context.Context, in *PayOrderRequest, opts ...grpc.CallOption) (*PayOrderResponse, error)
+	// PaySplit оплачивает заказ несколькими способами: каждая часть списывается отдельной транзакцией.
+	// Заказ считается оплаченным, только если прошли все части, иначе уже списанные части возвращаются
+	PaySplit(ctx context.Context, in *PaySplitRequest, opts ...grpc.CallOption) (*PaySplitResponse, error)
+	// ConfirmTransaction завершает транзакцию, ожидающую подтверждения платежа (3-D Secure).
+	// Подтвердить платеж может только его плательщик, определяемый по сессии IAM.
+	// Транзакция завершается по статусу списания у провайдера: подтверждение без прошедшего списания отклоняется
+	ConfirmTransaction(ctx context.Context, in *ConfirmTransactionRequest, opts ...grpc.CallOption) (*ConfirmTransactionResponse, error)
+	// ReviewTransaction принимает решение по транзакции, задержанной антифрод-проверкой, от имени пользователя сессии IAM.
+	// Одобренная транзакция передается провайдеру для списания, отклоненная завершается статусом FAILED
+	ReviewTransaction(ctx context.Context, in *ReviewTransactionRequest, opts ...grpc.CallOption) (*ReviewTransactionResponse, error)
+	// ListReviewQueue возвращает транзакции, ожидающие ручной проверки, в порядке создания
+	ListReviewQueue(ctx context.Context, in *ListReviewQueueRequest, opts ...grpc.CallOption) (*ListReviewQueueResponse, error)
+	// DecideApproval принимает решение согласующего по платежу средствами инвестора.
+	// При достижении кворума транзакция передается провайдеру, при невозможности его набрать - завершается статусом FAILED
+	DecideApproval(ctx context.Context, in *DecideApprovalRequest, opts ...grpc.CallOption) (*DecideApprovalResponse, error)
+	// GetApproval возвращает запрос на согласование платежа с решениями согласующих
+	GetApproval(ctx context.Context, in *GetApprovalRequest, opts ...grpc.CallOption) (*GetApprovalResponse, error)
+	// ListPendingApprovals возвращает запросы, ожидающие решения согласующего, в порядке создания
+	ListPendingApprovals(ctx context.Context, in *ListPendingApprovalsRequest, opts ...grpc.CallOption) (*ListPendingApprovalsResponse, error)
+	// QuoteInstallments рассчитывает доступные планы рассрочки по кредитной карте для суммы заказа
+	QuoteInstallments(ctx context.Context, in *QuoteInstallmentsRequest, opts ...grpc.CallOption) (*QuoteInstallmentsResponse, error)
+	// CreateInstallmentPlan создает план рассрочки по кредитной карте и запускает списание первого платежа.
+	// Результат каждого платежа публикуется событием InstallmentPaid, отказ в первом платеже отменяет план
+	// и публикуется событием PaymentFailed с plan_uuid в качестве transaction_uuid
+	CreateInstallmentPlan(ctx context.Context, in *CreateInstallmentPlanRequest, opts ...grpc.CallOption) (*CreateInstallmentPlanResponse, error)
+	// GetInstallmentPlan возвращает план рассрочки с графиком платежей
+	GetInstallmentPlan(ctx context.Context, in *GetInstallmentPlanRequest, opts ...grpc.CallOption) (*GetInstallmentPlanResponse, error)
+	// ListTransactions возвращает все транзакции заказа (для сверки заказов с платежами)
+	ListTransactions(ctx context.Context, in *ListTransactionsRequest, opts ...grpc.CallOption) (*ListTransactionsResponse, error)
+	// ListUserTransactions возвращает все транзакции пользователя (для выгрузки данных пользователя)
+	ListUserTransactions(ctx context.Context, in *ListUserTransactionsRequest, opts ...grpc.CallOption) (*ListUserTransactionsResponse, error)
+	// ListLedgerAccounts возвращает счета бухгалтерской книги платежей
+	ListLedgerAccounts(ctx context.Context, in *ListLedgerAccountsRequest, opts ...grpc.CallOption) (*ListLedgerAccountsResponse, error)
+	// GetAccountBalance возвращает обороты и сальдо счета
+	GetAccountBalance(ctx context.Context, in *GetAccountBalanceRequest, opts ...grpc.CallOption) (*GetAccountBalanceResponse, error)
+	// GetAccountStatement возвращает выписку по счету за период
+	GetAccountStatement(ctx context.Context, in *GetAccountStatementRequest, opts ...grpc.CallOption) (*GetAccountStatementResponse, error)
 }
 
 type paymentServiceClient struct {
@@ -52,14 +106,203 @@ func (c *paymentServiceClient) PayOrder(ctx context.Context, in *PayOrderRequest
 	return out, nil
 }
 
+func (c *paymentServiceClient) PaySplit(ctx context.Context, in *PaySplitRequest, opts ...grpc.CallOption) (*PaySplitResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PaySplitResponse)
+	err := c.cc.Invoke(ctx, PaymentService_PaySplit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) ConfirmTransaction(ctx context.Context, in *ConfirmTransactionRequest, opts ...grpc.CallOption) (*ConfirmTransactionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfirmTransactionResponse)
+	err := c.cc.Invoke(ctx, PaymentService_ConfirmTransaction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) ReviewTransaction(ctx context.Context, in *ReviewTransactionRequest, opts ...grpc.CallOption) (*ReviewTransactionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReviewTransactionResponse)
+	err := c.cc.Invoke(ctx, PaymentService_ReviewTransaction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) ListReviewQueue(ctx context.Context, in *ListReviewQueueRequest, opts ...grpc.CallOption) (*ListReviewQueueResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListReviewQueueResponse)
+	err := c.cc.Invoke(ctx, PaymentService_ListReviewQueue_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) DecideApproval(ctx context.Context, in *DecideApprovalRequest, opts ...grpc.CallOption) (*DecideApprovalResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DecideApprovalResponse)
+	err := c.cc.Invoke(ctx, PaymentService_DecideApproval_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) GetApproval(ctx context.Context, in *GetApprovalRequest, opts ...grpc.CallOption) (*GetApprovalResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetApprovalResponse)
+	err := c.cc.Invoke(ctx, PaymentService_GetApproval_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) ListPendingApprovals(ctx context.Context, in *ListPendingApprovalsRequest, opts ...grpc.CallOption) (*ListPendingApprovalsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPendingApprovalsResponse)
+	err := c.cc.Invoke(ctx, PaymentService_ListPendingApprovals_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) QuoteInstallments(ctx context.Context, in *QuoteInstallmentsRequest, opts ...grpc.CallOption) (*QuoteInstallmentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QuoteInstallmentsResponse)
+	err := c.cc.Invoke(ctx, PaymentService_QuoteInstallments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) CreateInstallmentPlan(ctx context.Context, in *CreateInstallmentPlanRequest, opts ...grpc.CallOption) (*CreateInstallmentPlanResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateInstallmentPlanResponse)
+	err := c.cc.Invoke(ctx, PaymentService_CreateInstallmentPlan_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) GetInstallmentPlan(ctx context.Context, in *GetInstallmentPlanRequest, opts ...grpc.CallOption) (*GetInstallmentPlanResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetInstallmentPlanResponse)
+	err := c.cc.Invoke(ctx, PaymentService_GetInstallmentPlan_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) ListTransactions(ctx context.Context, in *ListTransactionsRequest, opts ...grpc.CallOption) (*ListTransactionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTransactionsResponse)
+	err := c.cc.Invoke(ctx, PaymentService_ListTransactions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) ListUserTransactions(ctx context.Context, in *ListUserTransactionsRequest, opts ...grpc.CallOption) (*ListUserTransactionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListUserTransactionsResponse)
+	err := c.cc.Invoke(ctx, PaymentService_ListUserTransactions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) ListLedgerAccounts(ctx context.Context, in *ListLedgerAccountsRequest, opts ...grpc.CallOption) (*ListLedgerAccountsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLedgerAccountsResponse)
+	err := c.cc.Invoke(ctx, PaymentService_ListLedgerAccounts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) GetAccountBalance(ctx context.Context, in *GetAccountBalanceRequest, opts ...grpc.CallOption) (*GetAccountBalanceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAccountBalanceResponse)
+	err := c.cc.Invoke(ctx, PaymentService_GetAccountBalance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) GetAccountStatement(ctx context.Context, in *GetAccountStatementRequest, opts ...grpc.CallOption) (*GetAccountStatementResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAccountStatementResponse)
+	err := c.cc.Invoke(ctx, PaymentService_GetAccountStatement_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PaymentServiceServer is the server API for PaymentService service.
 // All implementations must embed UnimplementedPaymentServiceServer
 // for forward compatibility.
 //
 // PaymentService предоставляет API для работы с платежами
 type PaymentServiceServer interface {
-	// PayOrder обрабатывает команду на оплату и возвращает transaction_uuid
+	// PayOrder принимает команду на оплату и возвращает transaction_uuid транзакции в статусе PENDING.
+	// Результат списания публикуется событием PaymentCompleted или PaymentFailed. Если у заказа уже есть
+	// ожидающая или завершенная транзакция, новая не создается и возвращается она
 	PayOrder(context.Context, *PayOrderRequest) (*PayOrderResponse, error)
+	// PaySplit оплачивает заказ несколькими способами: каждая часть списывается отдельной транзакцией.
+	// Заказ считается оплаченным, только если прошли все части, иначе уже списанные части возвращаются
+	PaySplit(context.Context, *PaySplitRequest) (*PaySplitResponse, error)
+	// ConfirmTransaction завершает транзакцию, ожидающую подтверждения платежа (3-D Secure).
+	// Подтвердить платеж может только его плательщик, определяемый по сессии IAM.
+	// Транзакция завершается по статусу списания у провайдера: подтверждение без прошедшего списания отклоняется
+	ConfirmTransaction(context.Context, *ConfirmTransactionRequest) (*ConfirmTransactionResponse, error)
+	// ReviewTransaction принимает решение по транзакции, задержанной антифрод-проверкой, от имени пользователя сессии IAM.
+	// Одобренная транзакция передается провайдеру для списания, отклоненная завершается статусом FAILED
+	ReviewTransaction(context.Context, *ReviewTransactionRequest) (*ReviewTransactionResponse, error)
+	// ListReviewQueue возвращает транзакции, ожидающие ручной проверки, в порядке создания
+	ListReviewQueue(context.Context, *ListReviewQueueRequest) (*ListReviewQueueResponse, error)
+	// DecideApproval принимает решение согласующего по платежу средствами инвестора.
+	// При достижении кворума транзакция передается провайдеру, при невозможности его набрать - завершается статусом FAILED
+	DecideApproval(context.Context, *DecideApprovalRequest) (*DecideApprovalResponse, error)
+	// GetApproval возвращает запрос на согласование платежа с решениями согласующих
+	GetApproval(context.Context, *GetApprovalRequest) (*GetApprovalResponse, error)
+	// ListPendingApprovals возвращает запросы, ожидающие решения согласующего, в порядке создания
+	ListPendingApprovals(context.Context, *ListPendingApprovalsRequest) (*ListPendingApprovalsResponse, error)
+	// QuoteInstallments рассчитывает доступные планы рассрочки по кредитной карте для суммы заказа
+	QuoteInstallments(context.Context, *QuoteInstallmentsRequest) (*QuoteInstallmentsResponse, error)
+	// CreateInstallmentPlan создает план рассрочки по кредитной карте и запускает списание первого платежа.
+	// Результат каждого платежа публикуется событием InstallmentPaid, отказ в первом платеже отменяет план
+	// и публикуется событием PaymentFailed с plan_uuid в качестве transaction_uuid
+	CreateInstallmentPlan(context.Context, *CreateInstallmentPlanRequest) (*CreateInstallmentPlanResponse, error)
+	// GetInstallmentPlan возвращает план рассрочки с графиком платежей
+	GetInstallmentPlan(context.Context, *GetInstallmentPlanRequest) (*GetInstallmentPlanResponse, error)
+	// ListTransactions возвращает все транзакции заказа (для сверки заказов с платежами)
+	ListTransactions(context.Context, *ListTransactionsRequest) (*ListTransactionsResponse, error)
+	// ListUserTransactions возвращает все транзакции пользователя (для выгрузки данных пользователя)
+	ListUserTransactions(context.Context, *ListUserTransactionsRequest) (*ListUserTransactionsResponse, error)
+	// ListLedgerAccounts возвращает счета бухгалтерской книги платежей
+	ListLedgerAccounts(context.Context, *ListLedgerAccountsRequest) (*ListLedgerAccountsResponse, error)
+	// GetAccountBalance возвращает обороты и сальдо счета
+	GetAccountBalance(context.Context, *GetAccountBalanceRequest) (*GetAccountBalanceResponse, error)
+	// GetAccountStatement возвращает выписку по счету за период
+	GetAccountStatement(context.Context, *GetAccountStatementRequest) (*GetAccountStatementResponse, error)
 	mustEmbedUnimplementedPaymentServiceServer()
 }
 
@@ -73,6 +316,51 @@ type UnimplementedPaymentServiceServer struct{}
 func (UnimplementedPaymentServiceServer) PayOrder(context.Context, *PayOrderRequest) (*PayOrderResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PayOrder not implemented")
 }
+func (UnimplementedPaymentServiceServer) PaySplit(context.Context, *PaySplitRequest) (*PaySplitResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PaySplit not implemented")
+}
+func (UnimplementedPaymentServiceServer) ConfirmTransaction(context.Context, *ConfirmTransactionRequest) (*ConfirmTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmTransaction not implemented")
+}
+func (UnimplementedPaymentServiceServer) ReviewTransaction(context.Context, *ReviewTransactionRequest) (*ReviewTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReviewTransaction not implemented")
+}
+func (UnimplementedPaymentServiceServer) ListReviewQueue(context.Context, *ListReviewQueueRequest) (*ListReviewQueueResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReviewQueue not implemented")
+}
+func (UnimplementedPaymentServiceServer) DecideApproval(context.Context, *DecideApprovalRequest) (*DecideApprovalResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DecideApproval not implemented")
+}
+func (UnimplementedPaymentServiceServer) GetApproval(context.Context, *GetApprovalRequest) (*GetApprovalResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetApproval not implemented")
+}
+func (UnimplementedPaymentServiceServer) ListPendingApprovals(context.Context, *ListPendingApprovalsRequest) (*ListPendingApprovalsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPendingApprovals not implemented")
+}
+func (UnimplementedPaymentServiceServer) QuoteInstallments(context.Context, *QuoteInstallmentsRequest) (*QuoteInstallmentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QuoteInstallments not implemented")
+}
+func (UnimplementedPaymentServiceServer) CreateInstallmentPlan(context.Context, *CreateInstallmentPlanRequest) (*CreateInstallmentPlanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateInstallmentPlan not implemented")
+}
+func (UnimplementedPaymentServiceServer) GetInstallmentPlan(context.Context, *GetInstallmentPlanRequest) (*GetInstallmentPlanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInstallmentPlan not implemented")
+}
+func (UnimplementedPaymentServiceServer) ListTransactions(context.Context, *ListTransactionsRequest) (*ListTransactionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTransactions not implemented")
+}
+func (UnimplementedPaymentServiceServer) ListUserTransactions(context.Context, *ListUserTransactionsRequest) (*ListUserTransactionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUserTransactions not implemented")
+}
+func (UnimplementedPaymentServiceServer) ListLedgerAccounts(context.Context, *ListLedgerAccountsRequest) (*ListLedgerAccountsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLedgerAccounts not implemented")
+}
+func (UnimplementedPaymentServiceServer) GetAccountBalance(context.Context, *GetAccountBalanceRequest) (*GetAccountBalanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccountBalance not implemented")
+}
+func (UnimplementedPaymentServiceServer) GetAccountStatement(context.Context, *GetAccountStatementRequest) (*GetAccountStatementResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccountStatement not implemented")
+}
 func (UnimplementedPaymentServiceServer) mustEmbedUnimplementedPaymentServiceServer() {}
 func (UnimplementedPaymentServiceServer) testEmbeddedByValue()                        {}
 
@@ -112,6 +400,276 @@ func _PaymentService_PayOrder_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PaymentService_PaySplit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PaySplitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).PaySplit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_PaySplit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).PaySplit(ctx, req.(*PaySplitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_ConfirmTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).ConfirmTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_ConfirmTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).ConfirmTransaction(ctx, req.(*ConfirmTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_ReviewTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReviewTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).ReviewTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_ReviewTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).ReviewTransaction(ctx, req.(*ReviewTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_ListReviewQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReviewQueueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).ListReviewQueue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_ListReviewQueue_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).ListReviewQueue(ctx, req.(*ListReviewQueueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_DecideApproval_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DecideApprovalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).DecideApproval(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_DecideApproval_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).DecideApproval(ctx, req.(*DecideApprovalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_GetApproval_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetApprovalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).GetApproval(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_GetApproval_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).GetApproval(ctx, req.(*GetApprovalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_ListPendingApprovals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPendingApprovalsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).ListPendingApprovals(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_ListPendingApprovals_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).ListPendingApprovals(ctx, req.(*ListPendingApprovalsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_QuoteInstallments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QuoteInstallmentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).QuoteInstallments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_QuoteInstallments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).QuoteInstallments(ctx, req.(*QuoteInstallmentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_CreateInstallmentPlan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateInstallmentPlanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).CreateInstallmentPlan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_CreateInstallmentPlan_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).CreateInstallmentPlan(ctx, req.(*CreateInstallmentPlanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_GetInstallmentPlan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInstallmentPlanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).GetInstallmentPlan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_GetInstallmentPlan_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).GetInstallmentPlan(ctx, req.(*GetInstallmentPlanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_ListTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).ListTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_ListTransactions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).ListTransactions(ctx, req.(*ListTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_ListUserTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUserTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).ListUserTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_ListUserTransactions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).ListUserTransactions(ctx, req.(*ListUserTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_ListLedgerAccounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLedgerAccountsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).ListLedgerAccounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_ListLedgerAccounts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).ListLedgerAccounts(ctx, req.(*ListLedgerAccountsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_GetAccountBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccountBalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).GetAccountBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_GetAccountBalance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).GetAccountBalance(ctx, req.(*GetAccountBalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_GetAccountStatement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccountStatementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).GetAccountStatement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_GetAccountStatement_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).GetAccountStatement(ctx, req.(*GetAccountStatementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // PaymentService_ServiceDesc is the grpc.ServiceDesc for PaymentService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -123,6 +681,66 @@ var PaymentService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "PayOrder",
 			Handler:    _PaymentService_PayOrder_Handler,
 		},
+		{
+			MethodName: "PaySplit",
+			Handler:    _PaymentService_PaySplit_Handler,
+		},
+		{
+			MethodName: "ConfirmTransaction",
+			Handler:    _PaymentService_ConfirmTransaction_Handler,
+		},
+		{
+			MethodName: "ReviewTransaction",
+			Handler:    _PaymentService_ReviewTransaction_Handler,
+		},
+		{
+			MethodName: "ListReviewQueue",
+			Handler:    _PaymentService_ListReviewQueue_Handler,
+		},
+		{
+			MethodName: "DecideApproval",
+			Handler:    _PaymentService_DecideApproval_Handler,
+		},
+		{
+			MethodName: "GetApproval",
+			Handler:    _PaymentService_GetApproval_Handler,
+		},
+		{
+			MethodName: "ListPendingApprovals",
+			Handler:    _PaymentService_ListPendingApprovals_Handler,
+		},
+		{
+			MethodName: "QuoteInstallments",
+			Handler:    _PaymentService_QuoteInstallments_Handler,
+		},
+		{
+			MethodName: "CreateInstallmentPlan",
+			Handler:    _PaymentService_CreateInstallmentPlan_Handler,
+		},
+		{
+			MethodName: "GetInstallmentPlan",
+			Handler:    _PaymentService_GetInstallmentPlan_Handler,
+		},
+		{
+			MethodName: "ListTransactions",
+			Handler:    _PaymentService_ListTransactions_Handler,
+		},
+		{
+			MethodName: "ListUserTransactions",
+			Handler:    _PaymentService_ListUserTransactions_Handler,
+		},
+		{
+			MethodName: "ListLedgerAccounts",
+			Handler:    _PaymentService_ListLedgerAccounts_Handler,
+		},
+		{
+			MethodName: "GetAccountBalance",
+			Handler:    _PaymentService_GetAccountBalance_Handler,
+		},
+		{
+			MethodName: "GetAccountStatement",
+			Handler:    _PaymentService_GetAccountStatement_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "payment/v1/payment.proto",